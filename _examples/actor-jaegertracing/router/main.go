@@ -64,7 +64,7 @@ func initJaeger() io.Closer {
 	return closer
 }
 
-func createProps(routerFunc func(size int) *actor.Props, levels int) *actor.Props {
+func createProps(routerFunc func(size int, opts ...router.Option) *actor.Props, levels int) *actor.Props {
 	if levels == 1 {
 		sleep := time.Duration(rand.Intn(5000))
 		return routerFunc(3).WithFunc(func(c actor.Context) {
@@ -0,0 +1,7 @@
+module actortree
+
+go 1.13
+
+replace github.com/AsynkronIT/protoactor-go => ../..
+
+require github.com/AsynkronIT/protoactor-go v0.0.0-00010101000000-000000000000
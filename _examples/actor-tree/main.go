@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/diagnostics"
+	"github.com/AsynkronIT/protoactor-go/remote"
+)
+
+var (
+	serve   = flag.String("serve", "", "run a demo node listening on host:port instead of inspecting one")
+	address = flag.String("address", "127.0.0.1:8090", "the node to inspect")
+	root    = flag.String("root", "", "the id of the actor whose subtree is printed, the top level actors when empty")
+	depth   = flag.Int("depth", 0, "the number of levels printed, all when zero")
+	timeout = flag.Duration("timeout", 5*time.Second, "how long the node is waited for")
+)
+
+type supervisor struct {
+	workers int
+}
+
+func (s *supervisor) Receive(ctx actor.Context) {
+	if _, ok := ctx.Message().(*actor.Started); ok {
+		for i := 0; i < s.workers; i++ {
+			ctx.Spawn(actor.PropsFromProducer(func() actor.Actor { return &supervisor{} }))
+		}
+	}
+}
+
+func main() {
+	flag.Parse()
+	if *serve != "" {
+		runNode(*serve)
+		return
+	}
+
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(system, remote.Configure("127.0.0.1", 0))
+	r.Start()
+	defer r.Shutdown(true)
+
+	request := &diagnostics.ActorTreeRequest{Depth: int32(*depth)}
+	if *root != "" {
+		request.Root = actor.NewPID(*address, *root)
+	}
+	res, err := system.Root.RequestFuture(actor.NewPID(*address, diagnostics.Name), request, *timeout).Result()
+	if err != nil {
+		log.Fatal(err)
+	}
+	tree := res.(*diagnostics.ActorTreeResponse)
+	if tree.Error != "" {
+		log.Fatal(tree.Error)
+	}
+	fmt.Println(tree.Address)
+	for _, node := range tree.Nodes {
+		printNode(node, 1)
+	}
+}
+
+func printNode(node *diagnostics.ActorNode, level int) {
+	fmt.Printf("%s%s %s %s mailbox=%d restarts=%d children=%d\n", strings.Repeat("  ", level),
+		node.Pid.Id, node.Type, node.State, node.MailboxLength, node.RestartCount, node.ChildrenCount)
+	for _, child := range node.Children {
+		printNode(child, level+1)
+	}
+}
+
+// runNode runs a node with the debug actor and a few supervisors to inspect
+func runNode(hostPort string) {
+	host, port, err := splitHostPort(hostPort)
+	if err != nil {
+		log.Fatal(err)
+	}
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(system, remote.Configure(host, port))
+	r.Start()
+	defer r.Shutdown(true)
+	if _, err := diagnostics.Spawn(system); err != nil {
+		log.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return &supervisor{workers: 2} }))
+	}
+	log.Printf("node listening on %s, inspect it with: go run . -address %s", system.Address(), system.Address())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	<-signals
+}
+
+func splitHostPort(hostPort string) (string, int, error) {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", 0, err
+	}
+	p, err := strconv.Atoi(port)
+	return host, p, err
+}
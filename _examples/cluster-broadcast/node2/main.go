@@ -2,6 +2,7 @@ package main
 
 import (
 	"cluster-broadcast/shared"
+	"context"
 	"fmt"
 	"time"
 
@@ -13,33 +14,33 @@ import (
 )
 
 func main() {
-	cluster := startNode(8081)
+	c := startNode(8081)
 
 	fmt.Print("\nBoot other nodes and press Enter\n")
 	console.ReadLine()
 
 	fmt.Print("\nAdding 1 Egg - Enter\n")
 	console.ReadLine()
-	calcAdd("Eggs", 1)
+	calcAdd(c, "Eggs", 1)
 
 	fmt.Print("\nAdding 10 Egg - Enter\n")
 	console.ReadLine()
-	calcAdd("Eggs", 10)
+	calcAdd(c, "Eggs", 10)
 
 	fmt.Print("\nAdding 100 Bananas - Enter\n")
 	console.ReadLine()
-	calcAdd("Bananas", 100)
+	calcAdd(c, "Bananas", 100)
 
 	fmt.Print("\nAdding 2 Meat - Enter\n")
 	console.ReadLine()
-	calcAdd("Meat", 3)
-	calcAdd("Meat", 9000)
+	calcAdd(c, "Meat", 3)
+	calcAdd(c, "Meat", 9000)
 
-	getAll()
+	getAll(c)
 
 	console.ReadLine()
 
-	cluster.Shutdown(true)
+	c.Shutdown(true)
 }
 
 func startNode(port int64) *cluster.Cluster {
@@ -48,41 +49,38 @@ func startNode(port int64) *cluster.Cluster {
 
 	system := actor.NewActorSystem()
 	config := remote.Configure("localhost", 0)
-	remote := remote.NewRemote(system, config)
 
-	provider, _ := consul.New()
-	clusterConfig := cluster.Configure("my-cluster", provider, config)
-	cluster := cluster.New(system, clusterConfig)
-
-	// this node knows about Hello kind
-	remote.Register("Calculator", actor.PropsFromProducer(func() actor.Actor {
+	// this node knows about Calculator and Tracker kinds
+	calculatorKind := cluster.NewKind("Calculator", actor.PropsFromProducer(func() actor.Actor {
 		return &shared.CalculatorActor{
-			Timeout: &timeout,
+			Timeout: timeout,
 		}
 	}))
-
-	// this node knows about Hello kind
-	remote.Register("Tracker", actor.PropsFromProducer(func() actor.Actor {
+	trackerKind := cluster.NewKind("Tracker", actor.PropsFromProducer(func() actor.Actor {
 		return &shared.TrackerActor{
-			Timeout: &timeout,
+			Timeout: timeout,
 		}
 	}))
 
+	provider, _ := consul.New()
+	clusterConfig := cluster.Configure("my-cluster", provider, config, calculatorKind, trackerKind)
+	c := cluster.New(system, clusterConfig)
+
 	shared.CalculatorFactory(func() shared.Calculator {
-		return &shared.CalcGrain{}
+		return &shared.CalcGrain{Cluster: c}
 	})
 
 	shared.TrackerFactory(func() shared.Tracker {
-		return &shared.TrackGrain{}
+		return &shared.TrackGrain{Cluster: c}
 	})
 
-	cluster.Start()
-	return cluster
+	c.Start()
+	return c
 }
 
-func calcAdd(grainId string, addNumber int64) {
-	calcGrain := shared.GetCalculatorGrain(grainId)
-	total1, err := calcGrain.Add(&shared.NumberRequest{Number: addNumber})
+func calcAdd(c *cluster.Cluster, grainId string, addNumber int64) {
+	calcGrain := shared.GetCalculatorGrainClient(c, grainId)
+	total1, err := calcGrain.Add(context.Background(), &shared.NumberRequest{Number: addNumber})
 	if err != nil {
 		panic(err)
 	}
@@ -90,9 +88,9 @@ func calcAdd(grainId string, addNumber int64) {
 	fmt.Printf("Grain: %v - Total: %v \n", calcGrain.ID, total1.Number)
 }
 
-func getAll() {
-	trackerGrain := shared.GetTrackerGrain("singleTrackerGrain")
-	totals, err := trackerGrain.BroadcastGetCounts(&shared.Noop{})
+func getAll(c *cluster.Cluster) {
+	trackerGrain := shared.GetTrackerGrainClient(c, "singleTrackerGrain")
+	totals, err := trackerGrain.BroadcastGetCounts(context.Background(), &shared.Noop{})
 	if err != nil {
 		panic(err)
 	}
@@ -1,2 +1,2 @@
 protoc -I=. -I=$GOPATH/src --gogoslick_out=. protos.proto
-protoc -I=. -I=$GOPATH/src --gograin_out=. protos.proto 
+protoc -I=. -I=$GOPATH/src --gograinv2_out=. protos.proto 
@@ -1,10 +1,16 @@
 package shared
 
-import "github.com/AsynkronIT/protoactor-go/cluster"
+import (
+	"context"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/cluster"
+)
 
 type CalcGrain struct {
 	cluster.Grain
-	total int64
+	Cluster *cluster.Cluster
+	total   int64
 }
 
 func (c *CalcGrain) Init(id string) {
@@ -12,15 +18,18 @@ func (c *CalcGrain) Init(id string) {
 	c.total = 0
 
 	// register with the tracker
-	trackerGrain := GetTrackerGrain("singleTrackerGrain")
-	trackerGrain.RegisterGrain(&RegisterMessage{GrainId: c.ID()})
+	trackerGrain := GetTrackerGrainClient(c.Cluster, "singleTrackerGrain")
+	trackerGrain.RegisterGrain(context.Background(), &RegisterMessage{GrainId: c.ID()})
 }
 
 func (c *CalcGrain) Terminate() {
 
 	// deregister with the tracker
-	trackerGrain := GetTrackerGrain("singleTrackerGrain")
-	trackerGrain.DeregisterGrain(&RegisterMessage{GrainId: c.ID()})
+	trackerGrain := GetTrackerGrainClient(c.Cluster, "singleTrackerGrain")
+	trackerGrain.DeregisterGrain(context.Background(), &RegisterMessage{GrainId: c.ID()})
+}
+
+func (*CalcGrain) ReceiveDefault(ctx actor.Context) {
 }
 
 func (c *CalcGrain) Add(n *NumberRequest, ctx cluster.GrainContext) (*CountResponse, error) {
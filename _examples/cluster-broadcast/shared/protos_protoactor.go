@@ -1,32 +1,32 @@
+// Package shared is generated by protoactor-go/protoc-gen-gograin@0.1.0
 package shared
 
 import (
 	"errors"
 	"fmt"
-	"log"
+	"io"
 	"math"
 	"time"
 
-	"github.com/AsynkronIT/protoactor-go/cluster/consul"
-
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/cluster"
-	"github.com/AsynkronIT/protoactor-go/remote"
+	logmod "github.com/AsynkronIT/protoactor-go/log"
 	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
 )
 
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
-
-var system = actor.NewActorSystem()
-var rootContext = system.Root
-
-var remoteConfig = remote.Configure("127.0.0.1", 8080)
+var (
+	plog = logmod.New(logmod.InfoLevel, "[GRAIN]")
+	_    = proto.Marshal
+	_    = fmt.Errorf
+	_    = math.Inf
+	_    = io.EOF
+)
 
-var provider, _ = consul.New()
-var clusterConfig = cluster.Configure("my-cluster", provider, remoteConfig)
-var c = cluster.New(system, clusterConfig)
+// SetLogLevel sets the log level.
+func SetLogLevel(level logmod.Level) {
+	plog.SetLevel(level)
+}
 
 var xCalculatorFactory func() Calculator
 
@@ -35,245 +35,115 @@ func CalculatorFactory(factory func() Calculator) {
 	xCalculatorFactory = factory
 }
 
-// GetCalculatorGrain instantiates a new CalculatorGrain with given ID
-func GetCalculatorGrain(id string) *CalculatorGrain {
-	return &CalculatorGrain{ID: id}
+// GetCalculatorGrainClient instantiates a new CalculatorGrainClient with given ID
+func GetCalculatorGrainClient(c *cluster.Cluster, id string) *CalculatorGrainClient {
+	if c == nil {
+		panic(fmt.Errorf("nil cluster instance"))
+	}
+	if id == "" {
+		panic(fmt.Errorf("empty id"))
+	}
+	return &CalculatorGrainClient{ID: id, cluster: c}
 }
 
 // Calculator interfaces the services available to the Calculator
 type Calculator interface {
 	Init(id string)
 	Terminate()
-
+	ReceiveDefault(ctx actor.Context)
 	Add(*NumberRequest, cluster.GrainContext) (*CountResponse, error)
-
 	Subtract(*NumberRequest, cluster.GrainContext) (*CountResponse, error)
-
 	GetCurrent(*Noop, cluster.GrainContext) (*CountResponse, error)
 }
 
-// CalculatorGrain holds the base data for the CalculatorGrain
-type CalculatorGrain struct {
-	ID string
+// CalculatorGrainClient holds the base data for the CalculatorGrain
+type CalculatorGrainClient struct {
+	ID      string
+	cluster *cluster.Cluster
 }
 
-// Add requests the execution on to the cluster using default options
-func (g *CalculatorGrain) Add(r *NumberRequest) (*CountResponse, error) {
-	return g.AddWithOpts(r, cluster.DefaultGrainCallOptions())
-}
-
-// AddWithOpts requests the execution on to the cluster
-func (g *CalculatorGrain) AddWithOpts(r *NumberRequest, opts *cluster.GrainCallOptions) (*CountResponse, error) {
-	fun := func() (*CountResponse, error) {
-		pid, statusCode := cluster.Get(g.ID, "Calculator")
-		if statusCode != remote.ResponseStatusCodeOK {
-			return nil, fmt.Errorf("get PID failed with StatusCode: %v", statusCode)
-		}
-		bytes, err := proto.Marshal(r)
-		if err != nil {
-			return nil, err
-		}
-		request := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
-		response, err := rootContext.RequestFuture(pid, request, opts.Timeout).Result()
-		if err != nil {
-			return nil, err
-		}
-		switch msg := response.(type) {
-		case *cluster.GrainResponse:
-			result := &CountResponse{}
-			err = proto.Unmarshal(msg.MessageData, result)
-			if err != nil {
-				return nil, err
-			}
-			return result, nil
-		case *cluster.GrainErrorResponse:
-			return nil, errors.New(msg.Err)
-		default:
-			return nil, errors.New("unknown response")
-		}
+// Add requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *CalculatorGrainClient) Add(ctx context.Context, r *NumberRequest, opts ...*cluster.GrainCallOptions) (*CountResponse, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
 	}
-
-	var res *CountResponse
-	var err error
-	for i := 0; i < opts.RetryCount; i++ {
-		res, err = fun()
-		if err == nil || err.Error() != "future: timeout" {
-			return res, err
-		} else if opts.RetryAction != nil {
-			opts.RetryAction(i)
-		}
+	reqMsg := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Calculator", reqMsg, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
-}
-
-// AddChan allows to use a channel to execute the method using default options
-func (g *CalculatorGrain) AddChan(r *NumberRequest) (<-chan *CountResponse, <-chan error) {
-	return g.AddChanWithOpts(r, cluster.DefaultGrainCallOptions())
-}
-
-// AddChanWithOpts allows to use a channel to execute the method
-func (g *CalculatorGrain) AddChanWithOpts(r *NumberRequest, opts *cluster.GrainCallOptions) (<-chan *CountResponse, <-chan error) {
-	c := make(chan *CountResponse)
-	e := make(chan error)
-	go func() {
-		res, err := g.AddWithOpts(r, opts)
-		if err != nil {
-			e <- err
-		} else {
-			c <- res
-		}
-		close(c)
-		close(e)
-	}()
-	return c, e
-}
-
-// Subtract requests the execution on to the cluster using default options
-func (g *CalculatorGrain) Subtract(r *NumberRequest) (*CountResponse, error) {
-	return g.SubtractWithOpts(r, cluster.DefaultGrainCallOptions())
-}
-
-// SubtractWithOpts requests the execution on to the cluster
-func (g *CalculatorGrain) SubtractWithOpts(r *NumberRequest, opts *cluster.GrainCallOptions) (*CountResponse, error) {
-	fun := func() (*CountResponse, error) {
-		pid, statusCode := cluster.Get(g.ID, "Calculator")
-		if statusCode != remote.ResponseStatusCodeOK {
-			return nil, fmt.Errorf("get PID failed with StatusCode: %v", statusCode)
-		}
-		bytes, err := proto.Marshal(r)
+	switch msg := resp.(type) {
+	case *cluster.GrainResponse:
+		result := &CountResponse{}
+		err = proto.Unmarshal(msg.MessageData, result)
 		if err != nil {
 			return nil, err
 		}
-		request := &cluster.GrainRequest{MethodIndex: 1, MessageData: bytes}
-		response, err := rootContext.RequestFuture(pid, request, opts.Timeout).Result()
-		if err != nil {
-			return nil, err
-		}
-		switch msg := response.(type) {
-		case *cluster.GrainResponse:
-			result := &CountResponse{}
-			err = proto.Unmarshal(msg.MessageData, result)
-			if err != nil {
-				return nil, err
-			}
-			return result, nil
-		case *cluster.GrainErrorResponse:
-			return nil, errors.New(msg.Err)
-		default:
-			return nil, errors.New("unknown response")
-		}
-	}
-
-	var res *CountResponse
-	var err error
-	for i := 0; i < opts.RetryCount; i++ {
-		res, err = fun()
-		if err == nil || err.Error() != "future: timeout" {
-			return res, err
-		} else if opts.RetryAction != nil {
-			opts.RetryAction(i)
-		}
+		return result, nil
+	case *cluster.GrainErrorResponse:
+		return nil, errors.New(msg.Err)
+	default:
+		return nil, errors.New("unknown response")
 	}
-	return nil, err
-}
-
-// SubtractChan allows to use a channel to execute the method using default options
-func (g *CalculatorGrain) SubtractChan(r *NumberRequest) (<-chan *CountResponse, <-chan error) {
-	return g.SubtractChanWithOpts(r, cluster.DefaultGrainCallOptions())
-}
-
-// SubtractChanWithOpts allows to use a channel to execute the method
-func (g *CalculatorGrain) SubtractChanWithOpts(r *NumberRequest, opts *cluster.GrainCallOptions) (<-chan *CountResponse, <-chan error) {
-	c := make(chan *CountResponse)
-	e := make(chan error)
-	go func() {
-		res, err := g.SubtractWithOpts(r, opts)
-		if err != nil {
-			e <- err
-		} else {
-			c <- res
-		}
-		close(c)
-		close(e)
-	}()
-	return c, e
-}
-
-// GetCurrent requests the execution on to the cluster using default options
-func (g *CalculatorGrain) GetCurrent(r *Noop) (*CountResponse, error) {
-	return g.GetCurrentWithOpts(r, cluster.DefaultGrainCallOptions())
 }
 
-// GetCurrentWithOpts requests the execution on to the cluster
-func (g *CalculatorGrain) GetCurrentWithOpts(r *Noop, opts *cluster.GrainCallOptions) (*CountResponse, error) {
-	fun := func() (*CountResponse, error) {
-		pid, statusCode := cluster.Get(g.ID, "Calculator")
-		if statusCode != remote.ResponseStatusCodeOK {
-			return nil, fmt.Errorf("get PID failed with StatusCode: %v", statusCode)
-		}
-		bytes, err := proto.Marshal(r)
-		if err != nil {
-			return nil, err
-		}
-		request := &cluster.GrainRequest{MethodIndex: 2, MessageData: bytes}
-		response, err := rootContext.RequestFuture(pid, request, opts.Timeout).Result()
+// Subtract requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *CalculatorGrainClient) Subtract(ctx context.Context, r *NumberRequest, opts ...*cluster.GrainCallOptions) (*CountResponse, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg := &cluster.GrainRequest{MethodIndex: 1, MessageData: bytes}
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Calculator", reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := resp.(type) {
+	case *cluster.GrainResponse:
+		result := &CountResponse{}
+		err = proto.Unmarshal(msg.MessageData, result)
 		if err != nil {
 			return nil, err
 		}
-		switch msg := response.(type) {
-		case *cluster.GrainResponse:
-			result := &CountResponse{}
-			err = proto.Unmarshal(msg.MessageData, result)
-			if err != nil {
-				return nil, err
-			}
-			return result, nil
-		case *cluster.GrainErrorResponse:
-			return nil, errors.New(msg.Err)
-		default:
-			return nil, errors.New("unknown response")
-		}
-	}
-
-	var res *CountResponse
-	var err error
-	for i := 0; i < opts.RetryCount; i++ {
-		res, err = fun()
-		if err == nil || err.Error() != "future: timeout" {
-			return res, err
-		} else if opts.RetryAction != nil {
-			opts.RetryAction(i)
-		}
+		return result, nil
+	case *cluster.GrainErrorResponse:
+		return nil, errors.New(msg.Err)
+	default:
+		return nil, errors.New("unknown response")
 	}
-	return nil, err
 }
 
-// GetCurrentChan allows to use a channel to execute the method using default options
-func (g *CalculatorGrain) GetCurrentChan(r *Noop) (<-chan *CountResponse, <-chan error) {
-	return g.GetCurrentChanWithOpts(r, cluster.DefaultGrainCallOptions())
-}
-
-// GetCurrentChanWithOpts allows to use a channel to execute the method
-func (g *CalculatorGrain) GetCurrentChanWithOpts(r *Noop, opts *cluster.GrainCallOptions) (<-chan *CountResponse, <-chan error) {
-	c := make(chan *CountResponse)
-	e := make(chan error)
-	go func() {
-		res, err := g.GetCurrentWithOpts(r, opts)
+// GetCurrent requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *CalculatorGrainClient) GetCurrent(ctx context.Context, r *Noop, opts ...*cluster.GrainCallOptions) (*CountResponse, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg := &cluster.GrainRequest{MethodIndex: 2, MessageData: bytes}
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Calculator", reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := resp.(type) {
+	case *cluster.GrainResponse:
+		result := &CountResponse{}
+		err = proto.Unmarshal(msg.MessageData, result)
 		if err != nil {
-			e <- err
-		} else {
-			c <- res
+			return nil, err
 		}
-		close(c)
-		close(e)
-	}()
-	return c, e
+		return result, nil
+	case *cluster.GrainErrorResponse:
+		return nil, errors.New(msg.Err)
+	default:
+		return nil, errors.New("unknown response")
+	}
 }
 
 // CalculatorActor represents the actor structure
 type CalculatorActor struct {
 	inner   Calculator
-	Timeout *time.Duration
+	Timeout time.Duration
 }
 
 // Receive ensures the lifecycle of the actor for the received message
@@ -281,330 +151,269 @@ func (a *CalculatorActor) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 	case *actor.Started:
 		a.inner = xCalculatorFactory()
-		id := ctx.Self().Id
-		a.inner.Init(id[7:]) // skip "remote$"
-		if a.Timeout != nil {
-			ctx.SetReceiveTimeout(*a.Timeout)
+		id := ctx.Self().Id[17:] // skip "activator/Remote$"
+		a.inner.Init(id)
+		if a.Timeout > 0 {
+			ctx.SetReceiveTimeout(a.Timeout)
 		}
 	case *actor.ReceiveTimeout:
 		a.inner.Terminate()
-		rootContext.PoisonFuture(ctx.Self()).Wait()
+		ctx.Poison(ctx.Self())
 
 	case actor.AutoReceiveMessage: // pass
 	case actor.SystemMessage: // pass
 
 	case *cluster.GrainRequest:
 		switch msg.MethodIndex {
-
 		case 0:
 			req := &NumberRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("Add(NumberRequest) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.Add(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("Add(NumberRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		case 1:
 			req := &NumberRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("Subtract(NumberRequest) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.Subtract(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("Subtract(NumberRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		case 2:
 			req := &Noop{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("GetCurrent(Noop) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.GetCurrent(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("GetCurrent(Noop) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		}
 	default:
-		log.Printf("Unknown message %v", msg)
+		a.inner.ReceiveDefault(ctx)
 	}
 }
 
-var xTrackerFactory func() Tracker
-
-// TrackerFactory produces a Tracker
-func TrackerFactory(factory func() Tracker) {
-	xTrackerFactory = factory
-}
-
-// GetTrackerGrain instantiates a new TrackerGrain with given ID
-func GetTrackerGrain(id string) *TrackerGrain {
-	return &TrackerGrain{ID: id}
+// MockCalculator is a Calculator calling the functions it is set up with, for testing
+type MockCalculator struct {
+	InitFunc           func(id string)
+	TerminateFunc      func()
+	ReceiveDefaultFunc func(ctx actor.Context)
+	AddFunc            func(*NumberRequest, cluster.GrainContext) (*CountResponse, error)
+	SubtractFunc       func(*NumberRequest, cluster.GrainContext) (*CountResponse, error)
+	GetCurrentFunc     func(*Noop, cluster.GrainContext) (*CountResponse, error)
 }
 
-// Tracker interfaces the services available to the Tracker
-type Tracker interface {
-	Init(id string)
-	Terminate()
-
-	RegisterGrain(*RegisterMessage, cluster.GrainContext) (*Noop, error)
-
-	DeregisterGrain(*RegisterMessage, cluster.GrainContext) (*Noop, error)
-
-	BroadcastGetCounts(*Noop, cluster.GrainContext) (*TotalsResponse, error)
+// Init calls InitFunc if it is set
+func (m *MockCalculator) Init(id string) {
+	if m.InitFunc != nil {
+		m.InitFunc(id)
+	}
 }
 
-// TrackerGrain holds the base data for the TrackerGrain
-type TrackerGrain struct {
-	ID string
+// Terminate calls TerminateFunc if it is set
+func (m *MockCalculator) Terminate() {
+	if m.TerminateFunc != nil {
+		m.TerminateFunc()
+	}
 }
 
-// RegisterGrain requests the execution on to the cluster using default options
-func (g *TrackerGrain) RegisterGrain(r *RegisterMessage) (*Noop, error) {
-	return g.RegisterGrainWithOpts(r, cluster.DefaultGrainCallOptions())
+// ReceiveDefault calls ReceiveDefaultFunc if it is set
+func (m *MockCalculator) ReceiveDefault(ctx actor.Context) {
+	if m.ReceiveDefaultFunc != nil {
+		m.ReceiveDefaultFunc(ctx)
+	}
 }
 
-// RegisterGrainWithOpts requests the execution on to the cluster
-func (g *TrackerGrain) RegisterGrainWithOpts(r *RegisterMessage, opts *cluster.GrainCallOptions) (*Noop, error) {
-	fun := func() (*Noop, error) {
-		pid, statusCode := cluster.Get(g.ID, "Tracker")
-		if statusCode != remote.ResponseStatusCodeOK {
-			return nil, fmt.Errorf("get PID failed with StatusCode: %v", statusCode)
-		}
-		bytes, err := proto.Marshal(r)
-		if err != nil {
-			return nil, err
-		}
-		request := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
-		response, err := rootContext.RequestFuture(pid, request, opts.Timeout).Result()
-		if err != nil {
-			return nil, err
-		}
-		switch msg := response.(type) {
-		case *cluster.GrainResponse:
-			result := &Noop{}
-			err = proto.Unmarshal(msg.MessageData, result)
-			if err != nil {
-				return nil, err
-			}
-			return result, nil
-		case *cluster.GrainErrorResponse:
-			return nil, errors.New(msg.Err)
-		default:
-			return nil, errors.New("unknown response")
-		}
+// Add calls AddFunc, it fails if it is not set
+func (m *MockCalculator) Add(r *NumberRequest, ctx cluster.GrainContext) (*CountResponse, error) {
+	if m.AddFunc == nil {
+		return nil, errors.New("MockCalculator.AddFunc is not set")
 	}
+	return m.AddFunc(r, ctx)
+}
 
-	var res *Noop
-	var err error
-	for i := 0; i < opts.RetryCount; i++ {
-		res, err = fun()
-		if err == nil || err.Error() != "future: timeout" {
-			return res, err
-		} else if opts.RetryAction != nil {
-			opts.RetryAction(i)
-		}
+// Subtract calls SubtractFunc, it fails if it is not set
+func (m *MockCalculator) Subtract(r *NumberRequest, ctx cluster.GrainContext) (*CountResponse, error) {
+	if m.SubtractFunc == nil {
+		return nil, errors.New("MockCalculator.SubtractFunc is not set")
 	}
-	return nil, err
+	return m.SubtractFunc(r, ctx)
 }
 
-// RegisterGrainChan allows to use a channel to execute the method using default options
-func (g *TrackerGrain) RegisterGrainChan(r *RegisterMessage) (<-chan *Noop, <-chan error) {
-	return g.RegisterGrainChanWithOpts(r, cluster.DefaultGrainCallOptions())
+// GetCurrent calls GetCurrentFunc, it fails if it is not set
+func (m *MockCalculator) GetCurrent(r *Noop, ctx cluster.GrainContext) (*CountResponse, error) {
+	if m.GetCurrentFunc == nil {
+		return nil, errors.New("MockCalculator.GetCurrentFunc is not set")
+	}
+	return m.GetCurrentFunc(r, ctx)
 }
 
-// RegisterGrainChanWithOpts allows to use a channel to execute the method
-func (g *TrackerGrain) RegisterGrainChanWithOpts(r *RegisterMessage, opts *cluster.GrainCallOptions) (<-chan *Noop, <-chan error) {
-	c := make(chan *Noop)
-	e := make(chan error)
-	go func() {
-		res, err := g.RegisterGrainWithOpts(r, opts)
-		if err != nil {
-			e <- err
-		} else {
-			c <- res
-		}
-		close(c)
-		close(e)
-	}()
-	return c, e
-}
+var xTrackerFactory func() Tracker
 
-// DeregisterGrain requests the execution on to the cluster using default options
-func (g *TrackerGrain) DeregisterGrain(r *RegisterMessage) (*Noop, error) {
-	return g.DeregisterGrainWithOpts(r, cluster.DefaultGrainCallOptions())
+// TrackerFactory produces a Tracker
+func TrackerFactory(factory func() Tracker) {
+	xTrackerFactory = factory
 }
 
-// DeregisterGrainWithOpts requests the execution on to the cluster
-func (g *TrackerGrain) DeregisterGrainWithOpts(r *RegisterMessage, opts *cluster.GrainCallOptions) (*Noop, error) {
-	fun := func() (*Noop, error) {
-		pid, statusCode := cluster.Get(g.ID, "Tracker")
-		if statusCode != remote.ResponseStatusCodeOK {
-			return nil, fmt.Errorf("get PID failed with StatusCode: %v", statusCode)
-		}
-		bytes, err := proto.Marshal(r)
-		if err != nil {
-			return nil, err
-		}
-		request := &cluster.GrainRequest{MethodIndex: 1, MessageData: bytes}
-		response, err := rootContext.RequestFuture(pid, request, opts.Timeout).Result()
-		if err != nil {
-			return nil, err
-		}
-		switch msg := response.(type) {
-		case *cluster.GrainResponse:
-			result := &Noop{}
-			err = proto.Unmarshal(msg.MessageData, result)
-			if err != nil {
-				return nil, err
-			}
-			return result, nil
-		case *cluster.GrainErrorResponse:
-			return nil, errors.New(msg.Err)
-		default:
-			return nil, errors.New("unknown response")
-		}
+// GetTrackerGrainClient instantiates a new TrackerGrainClient with given ID
+func GetTrackerGrainClient(c *cluster.Cluster, id string) *TrackerGrainClient {
+	if c == nil {
+		panic(fmt.Errorf("nil cluster instance"))
 	}
-
-	var res *Noop
-	var err error
-	for i := 0; i < opts.RetryCount; i++ {
-		res, err = fun()
-		if err == nil || err.Error() != "future: timeout" {
-			return res, err
-		} else if opts.RetryAction != nil {
-			opts.RetryAction(i)
-		}
+	if id == "" {
+		panic(fmt.Errorf("empty id"))
 	}
-	return nil, err
+	return &TrackerGrainClient{ID: id, cluster: c}
 }
 
-// DeregisterGrainChan allows to use a channel to execute the method using default options
-func (g *TrackerGrain) DeregisterGrainChan(r *RegisterMessage) (<-chan *Noop, <-chan error) {
-	return g.DeregisterGrainChanWithOpts(r, cluster.DefaultGrainCallOptions())
-}
-
-// DeregisterGrainChanWithOpts allows to use a channel to execute the method
-func (g *TrackerGrain) DeregisterGrainChanWithOpts(r *RegisterMessage, opts *cluster.GrainCallOptions) (<-chan *Noop, <-chan error) {
-	c := make(chan *Noop)
-	e := make(chan error)
-	go func() {
-		res, err := g.DeregisterGrainWithOpts(r, opts)
-		if err != nil {
-			e <- err
-		} else {
-			c <- res
-		}
-		close(c)
-		close(e)
-	}()
-	return c, e
+// Tracker interfaces the services available to the Tracker
+type Tracker interface {
+	Init(id string)
+	Terminate()
+	ReceiveDefault(ctx actor.Context)
+	RegisterGrain(*RegisterMessage, cluster.GrainContext) (*Noop, error)
+	DeregisterGrain(*RegisterMessage, cluster.GrainContext) (*Noop, error)
+	BroadcastGetCounts(*Noop, cluster.GrainContext) (*TotalsResponse, error)
 }
 
-// BroadcastGetCounts requests the execution on to the cluster using default options
-func (g *TrackerGrain) BroadcastGetCounts(r *Noop) (*TotalsResponse, error) {
-	return g.BroadcastGetCountsWithOpts(r, cluster.DefaultGrainCallOptions())
+// TrackerGrainClient holds the base data for the TrackerGrain
+type TrackerGrainClient struct {
+	ID      string
+	cluster *cluster.Cluster
 }
 
-// BroadcastGetCountsWithOpts requests the execution on to the cluster
-func (g *TrackerGrain) BroadcastGetCountsWithOpts(r *Noop, opts *cluster.GrainCallOptions) (*TotalsResponse, error) {
-	fun := func() (*TotalsResponse, error) {
-		pid, statusCode := cluster.Get(g.ID, "Tracker")
-		if statusCode != remote.ResponseStatusCodeOK {
-			return nil, fmt.Errorf("get PID failed with StatusCode: %v", statusCode)
-		}
-		bytes, err := proto.Marshal(r)
-		if err != nil {
-			return nil, err
-		}
-		request := &cluster.GrainRequest{MethodIndex: 2, MessageData: bytes}
-		response, err := rootContext.RequestFuture(pid, request, opts.Timeout).Result()
+// RegisterGrain requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *TrackerGrainClient) RegisterGrain(ctx context.Context, r *RegisterMessage, opts ...*cluster.GrainCallOptions) (*Noop, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Tracker", reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := resp.(type) {
+	case *cluster.GrainResponse:
+		result := &Noop{}
+		err = proto.Unmarshal(msg.MessageData, result)
 		if err != nil {
 			return nil, err
 		}
-		switch msg := response.(type) {
-		case *cluster.GrainResponse:
-			result := &TotalsResponse{}
-			err = proto.Unmarshal(msg.MessageData, result)
-			if err != nil {
-				return nil, err
-			}
-			return result, nil
-		case *cluster.GrainErrorResponse:
-			return nil, errors.New(msg.Err)
-		default:
-			return nil, errors.New("unknown response")
-		}
+		return result, nil
+	case *cluster.GrainErrorResponse:
+		return nil, errors.New(msg.Err)
+	default:
+		return nil, errors.New("unknown response")
 	}
+}
 
-	var res *TotalsResponse
-	var err error
-	for i := 0; i < opts.RetryCount; i++ {
-		res, err = fun()
-		if err == nil || err.Error() != "future: timeout" {
-			return res, err
-		} else if opts.RetryAction != nil {
-			opts.RetryAction(i)
+// DeregisterGrain requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *TrackerGrainClient) DeregisterGrain(ctx context.Context, r *RegisterMessage, opts ...*cluster.GrainCallOptions) (*Noop, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg := &cluster.GrainRequest{MethodIndex: 1, MessageData: bytes}
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Tracker", reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := resp.(type) {
+	case *cluster.GrainResponse:
+		result := &Noop{}
+		err = proto.Unmarshal(msg.MessageData, result)
+		if err != nil {
+			return nil, err
 		}
+		return result, nil
+	case *cluster.GrainErrorResponse:
+		return nil, errors.New(msg.Err)
+	default:
+		return nil, errors.New("unknown response")
 	}
-	return nil, err
-}
-
-// BroadcastGetCountsChan allows to use a channel to execute the method using default options
-func (g *TrackerGrain) BroadcastGetCountsChan(r *Noop) (<-chan *TotalsResponse, <-chan error) {
-	return g.BroadcastGetCountsChanWithOpts(r, cluster.DefaultGrainCallOptions())
 }
 
-// BroadcastGetCountsChanWithOpts allows to use a channel to execute the method
-func (g *TrackerGrain) BroadcastGetCountsChanWithOpts(r *Noop, opts *cluster.GrainCallOptions) (<-chan *TotalsResponse, <-chan error) {
-	c := make(chan *TotalsResponse)
-	e := make(chan error)
-	go func() {
-		res, err := g.BroadcastGetCountsWithOpts(r, opts)
+// BroadcastGetCounts requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *TrackerGrainClient) BroadcastGetCounts(ctx context.Context, r *Noop, opts ...*cluster.GrainCallOptions) (*TotalsResponse, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg := &cluster.GrainRequest{MethodIndex: 2, MessageData: bytes}
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Tracker", reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := resp.(type) {
+	case *cluster.GrainResponse:
+		result := &TotalsResponse{}
+		err = proto.Unmarshal(msg.MessageData, result)
 		if err != nil {
-			e <- err
-		} else {
-			c <- res
+			return nil, err
 		}
-		close(c)
-		close(e)
-	}()
-	return c, e
+		return result, nil
+	case *cluster.GrainErrorResponse:
+		return nil, errors.New(msg.Err)
+	default:
+		return nil, errors.New("unknown response")
+	}
 }
 
 // TrackerActor represents the actor structure
 type TrackerActor struct {
 	inner   Tracker
-	Timeout *time.Duration
+	Timeout time.Duration
 }
 
 // Receive ensures the lifecycle of the actor for the received message
@@ -612,80 +421,149 @@ func (a *TrackerActor) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 	case *actor.Started:
 		a.inner = xTrackerFactory()
-		id := ctx.Self().Id
-		a.inner.Init(id[7:]) // skip "remote$"
-		if a.Timeout != nil {
-			ctx.SetReceiveTimeout(*a.Timeout)
+		id := ctx.Self().Id[17:] // skip "activator/Remote$"
+		a.inner.Init(id)
+		if a.Timeout > 0 {
+			ctx.SetReceiveTimeout(a.Timeout)
 		}
 	case *actor.ReceiveTimeout:
 		a.inner.Terminate()
-		rootContext.PoisonFuture(ctx.Self()).Wait()
+		ctx.Poison(ctx.Self())
 
 	case actor.AutoReceiveMessage: // pass
 	case actor.SystemMessage: // pass
 
 	case *cluster.GrainRequest:
 		switch msg.MethodIndex {
-
 		case 0:
 			req := &RegisterMessage{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("RegisterGrain(RegisterMessage) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.RegisterGrain(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("RegisterGrain(RegisterMessage) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		case 1:
 			req := &RegisterMessage{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("DeregisterGrain(RegisterMessage) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.DeregisterGrain(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("DeregisterGrain(RegisterMessage) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		case 2:
 			req := &Noop{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("BroadcastGetCounts(Noop) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.BroadcastGetCounts(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("BroadcastGetCounts(Noop) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		}
 	default:
-		log.Printf("Unknown message %v", msg)
+		a.inner.ReceiveDefault(ctx)
+	}
+}
+
+// MockTracker is a Tracker calling the functions it is set up with, for testing
+type MockTracker struct {
+	InitFunc               func(id string)
+	TerminateFunc          func()
+	ReceiveDefaultFunc     func(ctx actor.Context)
+	RegisterGrainFunc      func(*RegisterMessage, cluster.GrainContext) (*Noop, error)
+	DeregisterGrainFunc    func(*RegisterMessage, cluster.GrainContext) (*Noop, error)
+	BroadcastGetCountsFunc func(*Noop, cluster.GrainContext) (*TotalsResponse, error)
+}
+
+// Init calls InitFunc if it is set
+func (m *MockTracker) Init(id string) {
+	if m.InitFunc != nil {
+		m.InitFunc(id)
+	}
+}
+
+// Terminate calls TerminateFunc if it is set
+func (m *MockTracker) Terminate() {
+	if m.TerminateFunc != nil {
+		m.TerminateFunc()
+	}
+}
+
+// ReceiveDefault calls ReceiveDefaultFunc if it is set
+func (m *MockTracker) ReceiveDefault(ctx actor.Context) {
+	if m.ReceiveDefaultFunc != nil {
+		m.ReceiveDefaultFunc(ctx)
+	}
+}
+
+// RegisterGrain calls RegisterGrainFunc, it fails if it is not set
+func (m *MockTracker) RegisterGrain(r *RegisterMessage, ctx cluster.GrainContext) (*Noop, error) {
+	if m.RegisterGrainFunc == nil {
+		return nil, errors.New("MockTracker.RegisterGrainFunc is not set")
+	}
+	return m.RegisterGrainFunc(r, ctx)
+}
+
+// DeregisterGrain calls DeregisterGrainFunc, it fails if it is not set
+func (m *MockTracker) DeregisterGrain(r *RegisterMessage, ctx cluster.GrainContext) (*Noop, error) {
+	if m.DeregisterGrainFunc == nil {
+		return nil, errors.New("MockTracker.DeregisterGrainFunc is not set")
+	}
+	return m.DeregisterGrainFunc(r, ctx)
+}
+
+// BroadcastGetCounts calls BroadcastGetCountsFunc, it fails if it is not set
+func (m *MockTracker) BroadcastGetCounts(r *Noop, ctx cluster.GrainContext) (*TotalsResponse, error) {
+	if m.BroadcastGetCountsFunc == nil {
+		return nil, errors.New("MockTracker.BroadcastGetCountsFunc is not set")
 	}
+	return m.BroadcastGetCountsFunc(r, ctx)
 }
@@ -1,13 +1,16 @@
 package shared
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/cluster"
 )
 
 type TrackGrain struct {
 	cluster.Grain
+	Cluster   *cluster.Cluster
 	grainsMap map[string]bool
 }
 
@@ -19,6 +22,9 @@ func (t *TrackGrain) Init(id string) {
 func (t *TrackGrain) Terminate() {
 }
 
+func (*TrackGrain) ReceiveDefault(ctx actor.Context) {
+}
+
 func (t *TrackGrain) RegisterGrain(n *RegisterMessage, ctx cluster.GrainContext) (*Noop, error) {
 	t.grainsMap[n.GrainId] = true
 	return &Noop{}, nil
@@ -33,12 +39,13 @@ func (t *TrackGrain) BroadcastGetCounts(n *Noop, ctx cluster.GrainContext) (*Tot
 
 	totals := map[string]int64{}
 	for grainAddress, _ := range t.grainsMap {
-		calcGrain := GetCalculatorGrain(grainAddress)
-		grainTotal, err := calcGrain.GetCurrent(&Noop{})
+		calcGrain := GetCalculatorGrainClient(t.Cluster, grainAddress)
+		grainTotal, err := calcGrain.GetCurrent(context.Background(), &Noop{})
 		if err != nil {
-			fmt.Sprintf("Grain %s issued an error : %s", grainAddress, err)
+			fmt.Printf("Grain %s issued an error : %s\n", grainAddress, err)
+			continue
 		}
-		fmt.Sprintf("Grain %s - %v", grainAddress, grainTotal.Number)
+		fmt.Printf("Grain %s - %v\n", grainAddress, grainTotal.Number)
 		totals[grainAddress] = grainTotal.Number
 	}
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -48,13 +49,13 @@ func doRequests(c *cluster.Cluster, callopts *cluster.GrainCallOptions) {
 	msg := &shared.HelloRequest{Name: "GAM"}
 	helloGrain := shared.GetHelloGrainClient(c, "abc")
 	// with default callopts
-	resp, err := helloGrain.SayHello(msg)
+	resp, err := helloGrain.SayHello(context.Background(), msg)
 	if err != nil {
 		log.Fatalf("SayHello failed. err:%v", err)
 	}
 
 	// with custom callopts
-	resp, err = helloGrain.SayHello(msg, callopts)
+	resp, err = helloGrain.SayHello(context.Background(), msg, callopts)
 	if err != nil {
 		log.Fatalf("SayHello failed. err:%v", err)
 	}
@@ -62,7 +63,7 @@ func doRequests(c *cluster.Cluster, callopts *cluster.GrainCallOptions) {
 	for i := 0; i < 10000; i++ {
 		grainId := fmt.Sprintf("hello%v", i)
 		x := shared.GetHelloGrainClient(c, grainId)
-		x.SayHello(&shared.HelloRequest{Name: grainId})
+		x.SayHello(context.Background(), &shared.HelloRequest{Name: grainId})
 	}
 	log.Println("Done")
 }
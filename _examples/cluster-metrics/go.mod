@@ -8,4 +8,5 @@ require (
 	github.com/AsynkronIT/goconsole v0.0.0-20160504192649-bfa12eebf716
 	github.com/AsynkronIT/protoactor-go v0.0.0-00010101000000-000000000000
 	github.com/gogo/protobuf v1.3.1
+	golang.org/x/net v0.0.0-20191116160921-f9c825593386
 )
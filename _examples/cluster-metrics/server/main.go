@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"time"
@@ -50,7 +51,7 @@ func main() {
 	// this node knows about Hello kind
 	hello := shared.GetHelloGrainClient(c, "MyGrain")
 	msg := &shared.HelloRequest{Name: "Roger"}
-	res, err := hello.SayHello(msg)
+	res, err := hello.SayHello(context.Background(), msg)
 	if err != nil {
 		log.Fatalf("failed to call SayHello, err:%v", err)
 	}
@@ -4,19 +4,29 @@ package shared
 import (
 	"errors"
 	"fmt"
-	"log"
+	"io"
 	"math"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/cluster"
+	logmod "github.com/AsynkronIT/protoactor-go/log"
 	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
 )
 
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+var (
+	plog = logmod.New(logmod.InfoLevel, "[GRAIN]")
+	_    = proto.Marshal
+	_    = fmt.Errorf
+	_    = math.Inf
+	_    = io.EOF
+)
 
+// SetLogLevel sets the log level.
+func SetLogLevel(level logmod.Level) {
+	plog.SetLevel(level)
+}
 
 var xHelloFactory func() Hello
 
@@ -44,7 +54,6 @@ type Hello interface {
 	SayHello(*HelloRequest, cluster.GrainContext) (*HelloResponse, error)
 	Add(*AddRequest, cluster.GrainContext) (*AddResponse, error)
 	VoidFunc(*AddRequest, cluster.GrainContext) (*Unit, error)
-	
 }
 
 // HelloGrainClient holds the base data for the HelloGrain
@@ -53,14 +62,14 @@ type HelloGrainClient struct {
 	cluster *cluster.Cluster
 }
 
-// SayHello requests the execution on to the cluster with CallOptions
-func (g *HelloGrainClient) SayHello(r *HelloRequest, opts ...*cluster.GrainCallOptions) (*HelloResponse, error) {
+// SayHello requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *HelloGrainClient) SayHello(ctx context.Context, r *HelloRequest, opts ...*cluster.GrainCallOptions) (*HelloResponse, error) {
 	bytes, err := proto.Marshal(r)
 	if err != nil {
 		return nil, err
 	}
 	reqMsg := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
-	resp, err := g.cluster.Call(g.ID, "Hello", reqMsg, opts...)
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Hello", reqMsg, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -79,14 +88,14 @@ func (g *HelloGrainClient) SayHello(r *HelloRequest, opts ...*cluster.GrainCallO
 	}
 }
 
-// Add requests the execution on to the cluster with CallOptions
-func (g *HelloGrainClient) Add(r *AddRequest, opts ...*cluster.GrainCallOptions) (*AddResponse, error) {
+// Add requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *HelloGrainClient) Add(ctx context.Context, r *AddRequest, opts ...*cluster.GrainCallOptions) (*AddResponse, error) {
 	bytes, err := proto.Marshal(r)
 	if err != nil {
 		return nil, err
 	}
 	reqMsg := &cluster.GrainRequest{MethodIndex: 1, MessageData: bytes}
-	resp, err := g.cluster.Call(g.ID, "Hello", reqMsg, opts...)
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Hello", reqMsg, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -105,14 +114,14 @@ func (g *HelloGrainClient) Add(r *AddRequest, opts ...*cluster.GrainCallOptions)
 	}
 }
 
-// VoidFunc requests the execution on to the cluster with CallOptions
-func (g *HelloGrainClient) VoidFunc(r *AddRequest, opts ...*cluster.GrainCallOptions) (*Unit, error) {
+// VoidFunc requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *HelloGrainClient) VoidFunc(ctx context.Context, r *AddRequest, opts ...*cluster.GrainCallOptions) (*Unit, error) {
 	bytes, err := proto.Marshal(r)
 	if err != nil {
 		return nil, err
 	}
 	reqMsg := &cluster.GrainRequest{MethodIndex: 2, MessageData: bytes}
-	resp, err := g.cluster.Call(g.ID, "Hello", reqMsg, opts...)
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Hello", reqMsg, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -131,10 +140,9 @@ func (g *HelloGrainClient) VoidFunc(r *AddRequest, opts ...*cluster.GrainCallOpt
 	}
 }
 
-
 // HelloActor represents the actor structure
 type HelloActor struct {
-	inner Hello
+	inner   Hello
 	Timeout time.Duration
 }
 
@@ -157,66 +165,135 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 
 	case *cluster.GrainRequest:
 		switch msg.MethodIndex {
-			
 		case 0:
 			req := &HelloRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("SayHello(HelloRequest) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.SayHello(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("SayHello(HelloRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-			
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		case 1:
 			req := &AddRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("Add(AddRequest) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.Add(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("Add(AddRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-			
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		case 2:
 			req := &AddRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				log.Fatalf("[GRAIN] proto.Unmarshal failed %v", err)
+				plog.Error("VoidFunc(AddRequest) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
 			}
 			r0, err := a.inner.VoidFunc(req, ctx)
-			if err == nil {
-				bytes, errMarshal := proto.Marshal(r0)
-				if errMarshal != nil {
-					log.Fatalf("[GRAIN] proto.Marshal failed %v", errMarshal)
-				}
-				resp := &cluster.GrainResponse{MessageData: bytes}
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
-			} else {
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("VoidFunc(AddRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
+				return
 			}
-		
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			ctx.Respond(resp)
 		}
 	default:
 		a.inner.ReceiveDefault(ctx)
 	}
 }
+
+// MockHello is a Hello calling the functions it is set up with, for testing
+type MockHello struct {
+	InitFunc           func(id string)
+	TerminateFunc      func()
+	ReceiveDefaultFunc func(ctx actor.Context)
+	SayHelloFunc       func(*HelloRequest, cluster.GrainContext) (*HelloResponse, error)
+	AddFunc            func(*AddRequest, cluster.GrainContext) (*AddResponse, error)
+	VoidFuncFunc       func(*AddRequest, cluster.GrainContext) (*Unit, error)
+}
+
+// Init calls InitFunc if it is set
+func (m *MockHello) Init(id string) {
+	if m.InitFunc != nil {
+		m.InitFunc(id)
+	}
+}
+
+// Terminate calls TerminateFunc if it is set
+func (m *MockHello) Terminate() {
+	if m.TerminateFunc != nil {
+		m.TerminateFunc()
+	}
+}
+
+// ReceiveDefault calls ReceiveDefaultFunc if it is set
+func (m *MockHello) ReceiveDefault(ctx actor.Context) {
+	if m.ReceiveDefaultFunc != nil {
+		m.ReceiveDefaultFunc(ctx)
+	}
+}
+
+// SayHello calls SayHelloFunc, it fails if it is not set
+func (m *MockHello) SayHello(r *HelloRequest, ctx cluster.GrainContext) (*HelloResponse, error) {
+	if m.SayHelloFunc == nil {
+		return nil, errors.New("MockHello.SayHelloFunc is not set")
+	}
+	return m.SayHelloFunc(r, ctx)
+}
+
+// Add calls AddFunc, it fails if it is not set
+func (m *MockHello) Add(r *AddRequest, ctx cluster.GrainContext) (*AddResponse, error) {
+	if m.AddFunc == nil {
+		return nil, errors.New("MockHello.AddFunc is not set")
+	}
+	return m.AddFunc(r, ctx)
+}
+
+// VoidFunc calls VoidFuncFunc, it fails if it is not set
+func (m *MockHello) VoidFunc(r *AddRequest, ctx cluster.GrainContext) (*Unit, error) {
+	if m.VoidFuncFunc == nil {
+		return nil, errors.New("MockHello.VoidFuncFunc is not set")
+	}
+	return m.VoidFuncFunc(r, ctx)
+}
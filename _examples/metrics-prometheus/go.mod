@@ -0,0 +1,10 @@
+module metricsprometheus
+
+go 1.13
+
+replace github.com/AsynkronIT/protoactor-go => ../..
+
+require (
+	github.com/AsynkronIT/protoactor-go v0.0.0-00010101000000-000000000000
+	github.com/prometheus/client_golang v1.0.0
+)
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"log"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/mailbox"
+	"github.com/AsynkronIT/protoactor-go/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type work struct {
+	duration time.Duration
+}
+
+type worker struct{}
+
+func (*worker) Receive(ctx actor.Context) {
+	if msg, ok := ctx.Message().(*work); ok {
+		time.Sleep(msg.duration)
+		if rand.Intn(20) == 0 {
+			panic("failed")
+		}
+		ctx.Respond(msg)
+	}
+}
+
+func main() {
+	system := actor.NewActorSystem()
+	registry := prometheus.NewRegistry()
+	m, err := metrics.Install(registry, system.Root, metrics.WithActorTypes("*main.worker"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	props := actor.PropsFromProducer(func() actor.Actor { return &worker{} }).
+		WithMailbox(mailbox.Unbounded(m.MailboxStatistics("*main.worker")))
+	workers := make([]*actor.PID, 10)
+	for i := range workers {
+		workers[i] = system.Root.Spawn(props)
+	}
+
+	go func() {
+		for {
+			pid := workers[rand.Intn(len(workers))]
+			// some requests time out, and the responses to them go to the dead letters
+			system.Root.RequestFuture(pid, &work{duration: time.Duration(rand.Intn(20)) * time.Millisecond}, 15*time.Millisecond)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	log.Print("Serving the metrics at http://localhost:8080/metrics")
+	log.Fatal(http.ListenAndServe(":8080", nil))
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	console "github.com/AsynkronIT/goconsole"
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/persistence"
+)
+
+type Provider struct {
+	*persistence.InMemoryProvider
+}
+
+func (p *Provider) GetState() persistence.ProviderState {
+	return p.InMemoryProvider
+}
+
+type protoMsg struct{ state string }
+
+func (p *protoMsg) Reset()         {}
+func (p *protoMsg) String() string { return p.state }
+func (p *protoMsg) ProtoMessage()  {}
+
+type Deposited struct{ protoMsg }
+
+// Account persists its deposits
+type Account struct {
+	persistence.Mixin
+	balance int
+}
+
+func (a *Account) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *Deposited:
+		if !a.Recovering() {
+			a.PersistReceive(msg)
+		}
+		amount, _ := strconv.Atoi(msg.state)
+		a.balance += amount
+	}
+}
+
+// ReadModelStore keeps the total deposits along with the checkpoint of the projection,
+// the global offset it goes on from when it restarts
+type ReadModelStore struct {
+	mutex  sync.Mutex
+	total  int
+	offset int64
+}
+
+func (s *ReadModelStore) Load() (int, int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.total, s.offset
+}
+
+func (s *ReadModelStore) Save(total int, offset int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.total = total
+	s.offset = offset
+}
+
+// TotalDeposits projects the deposits of all accounts
+type TotalDeposits struct {
+	reader       persistence.GlobalEventReader
+	store        *ReadModelStore
+	subscription *actor.PID
+	total        int
+}
+
+func (p *TotalDeposits) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		var offset int64
+		p.total, offset = p.store.Load()
+		log.Printf("projection started from global offset %v, total deposits %v", offset, p.total)
+		p.subscription = persistence.SubscribeAll(ctx, p.reader, offset, ctx.Self(), 100*time.Millisecond)
+	case *persistence.PersistedEvent:
+		if deposited, ok := msg.Message.(*Deposited); ok {
+			amount, _ := strconv.Atoi(deposited.state)
+			p.total += amount
+			log.Printf("%v deposited %v, total deposits %v", msg.ActorName, amount, p.total)
+		}
+		p.store.Save(p.total, msg.GlobalOffset+1)
+	}
+}
+
+func main() {
+	system := actor.NewActorSystem()
+	provider := &Provider{persistence.NewInMemoryProvider(100)}
+	store := &ReadModelStore{}
+
+	accountProps := actor.PropsFromProducer(func() actor.Actor { return &Account{} }).
+		WithReceiverMiddleware(persistence.Using(provider))
+	var accounts []*actor.PID
+	for _, name := range []string{"alice", "bob"} {
+		pid, _ := system.Root.SpawnNamed(accountProps, name)
+		accounts = append(accounts, pid)
+	}
+
+	projectionProps := actor.PropsFromProducer(func() actor.Actor {
+		return &TotalDeposits{reader: provider.InMemoryProvider, store: store}
+	})
+	projection := system.Root.Spawn(projectionProps)
+	for i, pid := range accounts {
+		system.Root.Send(pid, &Deposited{protoMsg{state: strconv.Itoa(10 * (i + 1))}})
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	// the restarted projection only processes the events after its checkpoint
+	_ = system.Root.PoisonFuture(projection).Wait()
+	for _, pid := range accounts {
+		system.Root.Send(pid, &Deposited{protoMsg{state: "5"}})
+	}
+	system.Root.Spawn(projectionProps)
+
+	_, _ = console.ReadLine()
+}
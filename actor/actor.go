@@ -10,6 +10,27 @@ type Actor interface {
 	Receive(c Context)
 }
 
+// PassivationAware actors spawned with idle passivation veto it while CanPassivate returns false,
+// e.g. while they have pending work
+type PassivationAware interface {
+	CanPassivate() bool
+}
+
+// PreRestartAware actors are told why they are restarted in PreRestart, which is called on the failed incarnation
+// instead of delivering Restarting, before its children are stopped. Reason is the failure the supervisor restarts the
+// actor for and message the message that failed, the actor's own or a sibling's with an all-for-one strategy. They are
+// nil when the actor is restarted without a failure. The panics PreRestart raises are logged and swallowed
+type PreRestartAware interface {
+	PreRestart(reason interface{}, message interface{})
+}
+
+// SafeCleanup actors release the resources they own in Cleanup when their supervisor restarts or stops them after
+// a failure, before they receive Restarting or Stopping, which may fail as well. Cleanup is called once per failure
+// with its reason, the panics it raises are logged and swallowed
+type SafeCleanup interface {
+	Cleanup(reason interface{})
+}
+
 // The ReceiveFunc type is an adapter to allow the use of ordinary functions as actors to process messages
 type ReceiveFunc func(c Context)
 
@@ -19,11 +19,39 @@ const (
 
 type actorContextExtras struct {
 	children            PIDSet
-	receiveTimeoutTimer *time.Timer
-	rs                  *RestartStatistics
-	stash               *linkedliststack.Stack
-	watchers            PIDSet
-	context             Context
+	receiveTimeoutTimer Timer
+	// when the receive timeout expires, zero while its timer is stopped
+	receiveTimeoutDeadline time.Time
+	rs                     *RestartStatistics
+	stash                  *linkedliststack.Stack
+	watchers               PIDSet
+	delayed                *delayedSends
+	context                Context
+	behavior               Behavior
+	accept                 func(message interface{}) bool
+	stashed                []interface{} // the messages rejected by accept
+	unstashed              []interface{} // the messages to process again before the mailbox
+	passivated             bool
+	watchMessages          map[pidKey]interface{} // the messages replacing Terminated, by watched PID
+	restarts               int
+	locals                 map[*LocalKey]interface{}
+	failed                 bool             // until the actor is resumed, restarted or stopped
+	failure                interface{}      // the reason of the failure
+	failedMessage          interface{}      // the message the actor failed with, when it can be redelivered
+	redelivery             *MessageEnvelope // the failed message, redelivered once the actor restarted
+	stopReason             StopReason       // why the actor is stopping, once it received Stop
+	awaitingResponse       bool             // the current message is a request not answered yet, see ResponseCheck
+	draining               bool             // the actor stops once it processed the messages in its mailbox
+	drainTimer             Timer
+	watching               PIDSet          // the actors watched, until they terminate or are unwatched
+	watcherLimitReached    bool            // until the watchers are back within the limit of the props
+	invocation             *invocation     // the message being processed, when the actor has a budget
+	liveness               *liveness       // the heartbeats of the actor, when it has a liveness
+	budgetViolations       int             // the messages in a row processed longer than the hard budget
+	history                *messageHistory // the last messages processed, when the actor has a message history
+	goContext              *goContext      // the context.Context of the actor, once asked for
+	restartReason          interface{}     // the failure the actor is restarting for, until it notified its parent
+	watchTimeouts          *watchTimeouts  // the timeouts of the watches, see WatchWithTimeout
 }
 
 func newActorContextExtras(context Context) *actorContextExtras {
@@ -33,24 +61,27 @@ func newActorContextExtras(context Context) *actorContextExtras {
 	return this
 }
 
-func (ctxExt *actorContextExtras) restartStats() *RestartStatistics {
+func (ctxExt *actorContextExtras) restartStats(clock Clock) *RestartStatistics {
 	// lazy initialize the child restart stats if this is the first time
 	// further mutations are handled within "restart"
 	if ctxExt.rs == nil {
 		ctxExt.rs = NewRestartStatistics()
+		ctxExt.rs.clock = clock
 	}
 	return ctxExt.rs
 }
 
-func (ctxExt *actorContextExtras) initReceiveTimeoutTimer(timer *time.Timer) {
+func (ctxExt *actorContextExtras) initReceiveTimeoutTimer(timer Timer, deadline time.Time) {
 	ctxExt.receiveTimeoutTimer = timer
+	ctxExt.receiveTimeoutDeadline = deadline
 }
 
-func (ctxExt *actorContextExtras) resetReceiveTimeoutTimer(time time.Duration) {
+func (ctxExt *actorContextExtras) resetReceiveTimeoutTimer(clock Clock, d time.Duration) {
 	if ctxExt.receiveTimeoutTimer == nil {
 		return
 	}
-	ctxExt.receiveTimeoutTimer.Reset(time)
+	ctxExt.receiveTimeoutTimer.Reset(d)
+	ctxExt.receiveTimeoutDeadline = clock.Now().Add(d)
 }
 
 func (ctxExt *actorContextExtras) stopReceiveTimeoutTimer() {
@@ -58,6 +89,7 @@ func (ctxExt *actorContextExtras) stopReceiveTimeoutTimer() {
 		return
 	}
 	ctxExt.receiveTimeoutTimer.Stop()
+	ctxExt.receiveTimeoutDeadline = time.Time{}
 }
 
 func (ctxExt *actorContextExtras) killReceiveTimeoutTimer() {
@@ -66,6 +98,60 @@ func (ctxExt *actorContextExtras) killReceiveTimeoutTimer() {
 	}
 	ctxExt.receiveTimeoutTimer.Stop()
 	ctxExt.receiveTimeoutTimer = nil
+	ctxExt.receiveTimeoutDeadline = time.Time{}
+}
+
+// receiveTimeoutExpired tells whether the receive timeout expired, a timer which fired before it was stopped or reset
+// posted a stale receiveTimeoutFired
+func (ctxExt *actorContextExtras) receiveTimeoutExpired(clock Clock) bool {
+	return !ctxExt.receiveTimeoutDeadline.IsZero() && !clock.Now().Before(ctxExt.receiveTimeoutDeadline)
+}
+
+// switchBehavior makes the messages stashed by the previous behavior processed again, in their order
+func (ctxExt *actorContextExtras) switchBehavior(accept func(message interface{}) bool) {
+	if len(ctxExt.stashed) > 0 {
+		ctxExt.unstashed = append(ctxExt.stashed, ctxExt.unstashed...)
+		ctxExt.stashed = nil
+	}
+	ctxExt.accept = accept
+}
+
+// stashes tells if the behavior stashes message, the lifecycle and system messages are never stashed
+func (ctxExt *actorContextExtras) stashes(message interface{}) bool {
+	if ctxExt.accept == nil {
+		return false
+	}
+	switch message.(type) {
+	case AutoReceiveMessage, SystemMessage:
+		return false
+	}
+	return !ctxExt.accept(message)
+}
+
+func (ctxExt *actorContextExtras) nextUnstashed() (interface{}, bool) {
+	if len(ctxExt.unstashed) == 0 {
+		return nil, false
+	}
+	message := ctxExt.unstashed[0]
+	ctxExt.unstashed[0] = nil
+	ctxExt.unstashed = ctxExt.unstashed[1:]
+	return message, true
+}
+
+func (ctxExt *actorContextExtras) setWatchMessage(pid *PID, message interface{}) {
+	if ctxExt.watchMessages == nil {
+		ctxExt.watchMessages = make(map[pidKey]interface{})
+	}
+	ctxExt.watchMessages[keyOf(pid)] = message
+}
+
+func (ctxExt *actorContextExtras) removeWatchMessage(pid *PID) (interface{}, bool) {
+	key := keyOf(pid)
+	message, ok := ctxExt.watchMessages[key]
+	if ok {
+		delete(ctxExt.watchMessages, key)
+	}
+	return message, ok
 }
 
 func (ctxExt *actorContextExtras) addChild(pid *PID) {
@@ -147,6 +233,13 @@ func (ctx *actorContext) ReceiveTimeout() time.Duration {
 	return ctx.receiveTimeout
 }
 
+func (ctx *actorContext) StopReason() StopReason {
+	if ctx.extras == nil {
+		return StopReason_StopRequested
+	}
+	return ctx.extras.stopReason
+}
+
 func (ctx *actorContext) Children() []*PID {
 	if ctx.extras == nil {
 		return make([]*PID, 0)
@@ -157,12 +250,47 @@ func (ctx *actorContext) Children() []*PID {
 
 func (ctx *actorContext) Respond(response interface{}) {
 	// If the message is addressed to nil forward it to the dead letter channel
-	if ctx.Sender() == nil {
+	sender := ctx.Sender()
+	if sender == nil {
 		ctx.actorSystem.DeadLetter.SendUserMessage(nil, response)
 		return
 	}
 
-	ctx.Send(ctx.Sender(), response)
+	if header := ctx.MessageHeader(); header != nil {
+		if correlationID := header.Get(CorrelationIDHeader); correlationID != "" {
+			response = correlatedEnvelope(response, correlationID, ctx.Self())
+		}
+	}
+	if ctx.respondToFuture(sender, response) {
+		return
+	}
+	ctx.Send(sender, response)
+}
+
+// respondToFuture completes the local future sender with response on the calling goroutine. The responses go through
+// the full path when the actor has sender middlewares, or when the config asks for it
+func (ctx *actorContext) respondToFuture(sender *PID, response interface{}) bool {
+	if ctx.actorSystem.Config.FullPathResponses || ctx.props.senderMiddlewareChain != nil {
+		return false
+	}
+	future, ok := sender.ref(ctx.actorSystem).(*futureProcess)
+	if !ok || future.actorSystem != ctx.actorSystem {
+		// the futures of the other systems of the process are remote
+		return false
+	}
+	if ctx.extras != nil && ctx.extras.awaitingResponse {
+		ctx.responded()
+	}
+	if ctx.actorSystem.Config.CopyOnSend {
+		response = ctx.actorSystem.copyOnSend(sender, response)
+	}
+	// the future dead letters the late responses
+	future.SendUserMessage(sender, response)
+	return true
+}
+
+func (ctx *actorContext) RespondError(err error) {
+	ctx.Respond(NewErrorResponse(err))
 }
 
 func (ctx *actorContext) Stash() {
@@ -171,20 +299,73 @@ func (ctx *actorContext) Stash() {
 		extra.stash = linkedliststack.New()
 	}
 	extra.stash.Push(ctx.Message())
+	ctx.responded()
+}
+
+func (ctx *actorContext) Become(receive ReceiveFunc) {
+	extra := ctx.ensureExtras()
+	extra.behavior.Become(receive)
+	extra.switchBehavior(nil)
+}
+
+func (ctx *actorContext) BecomeStacked(receive ReceiveFunc) {
+	extra := ctx.ensureExtras()
+	extra.behavior.BecomeStacked(receive)
+	extra.switchBehavior(nil)
+}
+
+func (ctx *actorContext) UnbecomeStacked() {
+	extra := ctx.ensureExtras()
+	extra.behavior.UnbecomeStacked()
+	extra.switchBehavior(nil)
+}
+
+func (ctx *actorContext) BecomeStashed(receive ReceiveFunc, accept func(message interface{}) bool) {
+	extra := ctx.ensureExtras()
+	extra.behavior.Become(receive)
+	extra.switchBehavior(accept)
 }
 
 func (ctx *actorContext) Watch(who *PID) {
+	extras := ctx.ensureExtras()
+	extras.removeWatchMessage(who)
+	extras.watching.Add(who)
+	who.sendSystemMessage(ctx.actorSystem, &Watch{
+		Watcher: ctx.self,
+	})
+}
+
+func (ctx *actorContext) WatchWith(who *PID, message interface{}) {
+	extras := ctx.ensureExtras()
+	extras.setWatchMessage(who, message)
+	extras.watching.Add(who)
 	who.sendSystemMessage(ctx.actorSystem, &Watch{
 		Watcher: ctx.self,
 	})
 }
 
 func (ctx *actorContext) Unwatch(who *PID) {
+	if ctx.extras != nil {
+		ctx.extras.removeWatchMessage(who)
+		ctx.extras.watching.Remove(who)
+		if ctx.extras.watchTimeouts != nil {
+			ctx.extras.watchTimeouts.remove(who)
+		}
+	}
 	who.sendSystemMessage(ctx.actorSystem, &Unwatch{
 		Watcher: ctx.self,
 	})
 }
 
+func (ctx *actorContext) UnwatchAll() {
+	if ctx.extras == nil {
+		return
+	}
+	for _, who := range ctx.extras.watching.Values() {
+		ctx.Unwatch(who)
+	}
+}
+
 func (ctx *actorContext) SetReceiveTimeout(d time.Duration) {
 	if d <= 0 {
 		panic("Duration must be greater than zero")
@@ -204,10 +385,11 @@ func (ctx *actorContext) SetReceiveTimeout(d time.Duration) {
 	ctx.ensureExtras()
 	ctx.extras.stopReceiveTimeoutTimer()
 	if d > 0 {
+		clock := ctx.actorSystem.Config.Clock
 		if ctx.extras.receiveTimeoutTimer == nil {
-			ctx.extras.initReceiveTimeoutTimer(time.AfterFunc(d, ctx.receiveTimeoutHandler))
+			ctx.extras.initReceiveTimeoutTimer(clock.AfterFunc(d, ctx.receiveTimeoutHandler), clock.Now().Add(d))
 		} else {
-			ctx.extras.resetReceiveTimeoutTimer(d)
+			ctx.extras.resetReceiveTimeoutTimer(clock, d)
 		}
 	}
 }
@@ -221,11 +403,9 @@ func (ctx *actorContext) CancelReceiveTimeout() {
 	ctx.receiveTimeout = 0
 }
 
+// receiveTimeoutHandler runs on the timer, it leaves the receive timeout of the actor to the actor itself
 func (ctx *actorContext) receiveTimeoutHandler() {
-	if ctx.extras != nil && ctx.extras.receiveTimeoutTimer != nil {
-		ctx.CancelReceiveTimeout()
-		ctx.Send(ctx.self, receiveTimeoutMessage)
-	}
+	ctx.self.ref(ctx.actorSystem).SendUserMessage(ctx.self, receiveTimeoutFiredMessage)
 }
 
 func (ctx *actorContext) Forward(pid *PID) {
@@ -234,7 +414,9 @@ func (ctx *actorContext) Forward(pid *PID) {
 		plog.Error("SystemMessage cannot be forwarded", log.Message(msg))
 		return
 	}
-	ctx.sendUserMessage(pid, ctx.messageOrEnvelope)
+	// the actor forwarded to responds
+	ctx.responded()
+	ctx.sendUserMessage(pid, ctx.keptMessage())
 }
 
 func (ctx *actorContext) AwaitFuture(f *Future, cont func(res interface{}, err error)) {
@@ -242,7 +424,13 @@ func (ctx *actorContext) AwaitFuture(f *Future, cont func(res interface{}, err e
 		cont(f.result, f.err)
 	}
 
-	message := ctx.messageOrEnvelope
+	if ctx.actorSystem.Diagnostics.Enabled() {
+		ctx.actorSystem.Diagnostics.awaited(ctx.self, f)
+	}
+
+	// the continuation may respond
+	ctx.responded()
+	message := ctx.keptMessage()
 	// invoke the callback when the future completes
 	f.continueWith(func(res interface{}, err error) {
 		// send the wrapped callback as a continuation message to self
@@ -270,6 +458,9 @@ func (ctx *actorContext) Send(pid *PID, message interface{}) {
 }
 
 func (ctx *actorContext) sendUserMessage(pid *PID, message interface{}) {
+	if ctx.extras != nil && ctx.extras.awaitingResponse && pid.Equal(ctx.Sender()) {
+		ctx.responded()
+	}
 	if ctx.props.senderMiddlewareChain != nil {
 		ctx.props.senderMiddlewareChain(ctx.ensureExtras().context, pid, WrapEnvelope(message))
 	} else {
@@ -278,59 +469,97 @@ func (ctx *actorContext) sendUserMessage(pid *PID, message interface{}) {
 }
 
 func (ctx *actorContext) Request(pid *PID, message interface{}) {
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  ctx.Self(),
-	}
-
-	ctx.sendUserMessage(pid, env)
+	ctx.sendUserMessage(pid, ctx.actorSystem.newEnvelope(message, ctx.Self()))
 }
 
 func (ctx *actorContext) RequestWithCustomSender(pid *PID, message interface{}, sender *PID) {
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  sender,
-	}
-	ctx.sendUserMessage(pid, env)
+	ctx.sendUserMessage(pid, ctx.actorSystem.newEnvelope(message, sender))
 }
 
 func (ctx *actorContext) RequestFuture(pid *PID, message interface{}, timeout time.Duration) *Future {
 	future := NewFuture(ctx.actorSystem, timeout)
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  future.PID(),
+	if ctx.actorSystem.Diagnostics.Enabled() {
+		ctx.actorSystem.Diagnostics.record(ctx.self, pid, message, future)
 	}
+	env := ctx.actorSystem.newEnvelope(message, future.PID())
 	ctx.sendUserMessage(pid, env)
 
 	return future
 }
 
+func (ctx *actorContext) RequestWithCorrelation(pid *PID, message interface{}, timeout time.Duration) *Future {
+	future := newCorrelatedFuture(ctx.actorSystem, timeout)
+	if ctx.actorSystem.Diagnostics.Enabled() {
+		ctx.actorSystem.Diagnostics.record(ctx.self, pid, message, future)
+	}
+	ctx.sendUserMessage(pid, correlatedEnvelope(message, future.correlationID, future.PID()))
+	return future
+}
+
+func (ctx *actorContext) RequestAggregate(pids []*PID, message interface{}, count int, timeout time.Duration) *AggregatorFuture {
+	future := NewAggregatorFuture(ctx.actorSystem, count, timeout)
+	for _, pid := range pids {
+		ctx.sendUserMessage(pid, correlatedEnvelope(message, future.correlationID, future.PID()))
+	}
+	return future
+}
+
 //
 // Interface: receiver
 //
 
 func (ctx *actorContext) Receive(envelope *MessageEnvelope) {
+	// the message being processed when the actor receives another one, it still responds to its sender
+	current := ctx.messageOrEnvelope
 	ctx.messageOrEnvelope = envelope
 	ctx.defaultReceive()
-	ctx.messageOrEnvelope = nil
+	ctx.messageOrEnvelope = current
 }
 
 func (ctx *actorContext) defaultReceive() {
-	if _, ok := ctx.Message().(*PoisonPill); ok {
-		ctx.Stop(ctx.self)
+	switch msg := ctx.Message().(type) {
+	case *PoisonPill:
+		reason := msg.Reason
+		if reason == StopReason_StopRequested {
+			reason = StopReason_PoisonPilled
+		}
+		ctx.self.stop(ctx.actorSystem, reason)
 		return
+	case *ReceiveTimeout:
+		if ctx.props.idlePassivation > 0 {
+			ctx.passivate()
+		}
 	}
 
 	// are we using decorators, if so, ensure it has been created
+	context := Context(ctx)
 	if ctx.props.contextDecoratorChain != nil {
-		ctx.actor.Receive(ctx.ensureExtras().context)
-		return
+		context = ctx.ensureExtras().context
 	}
 
-	ctx.actor.Receive(Context(ctx))
+	if ctx.extras != nil {
+		if ctx.extras.stashes(ctx.Message()) {
+			ctx.extras.stashed = append(ctx.extras.stashed, ctx.keptMessage())
+			ctx.responded()
+			return
+		}
+		if receive, ok := ctx.extras.behavior.peek(); ok {
+			receive(context)
+			return
+		}
+	}
+
+	ctx.actor.Receive(context)
+}
+
+// passivate poisons the idle actor, or waits for the next idle period when the actor cannot passivate
+func (ctx *actorContext) passivate() {
+	if a, ok := ctx.actor.(PassivationAware); ok && !a.CanPassivate() {
+		ctx.SetReceiveTimeout(ctx.props.idlePassivation)
+		return
+	}
+	ctx.ensureExtras().passivated = true
+	ctx.self.sendUserMessage(ctx.actorSystem, &PoisonPill{Reason: StopReason_Passivation})
 }
 
 //
@@ -345,6 +574,10 @@ func (ctx *actorContext) Spawn(props *Props) *PID {
 	return pid
 }
 
+func (ctx *actorContext) SpawnChecked(props *Props) (*PID, error) {
+	return ctx.SpawnNamed(props, ctx.actorSystem.ProcessRegistry.NextId())
+}
+
 func (ctx *actorContext) SpawnPrefix(props *Props, prefix string) *PID {
 	pid, err := ctx.SpawnNamed(props, prefix+ctx.actorSystem.ProcessRegistry.NextId())
 	if err != nil {
@@ -357,6 +590,9 @@ func (ctx *actorContext) SpawnNamed(props *Props, name string) (*PID, error) {
 	if props.guardianStrategy != nil {
 		panic(errors.New("props used to spawn child cannot have GuardianStrategy"))
 	}
+	if err := ctx.checkMaxChildren(name); err != nil {
+		return nil, err
+	}
 
 	var pid *PID
 	var err error
@@ -414,25 +650,90 @@ func (ctx *actorContext) PoisonFuture(pid *PID) *Future {
 //
 
 func (ctx *actorContext) InvokeUserMessage(md interface{}) {
+	ctx.invokeUserMessage(md)
+	// the messages stashed by a behavior are processed after the switch, before the next messages of the mailbox
+	for ctx.extras != nil {
+		md, ok := ctx.extras.nextUnstashed()
+		if !ok {
+			return
+		}
+		ctx.invokeUserMessage(md)
+	}
+}
+
+func (ctx *actorContext) invokeUserMessage(md interface{}) {
+	if ctx.extras != nil && ctx.extras.failed {
+		// the actor was resumed
+		ctx.extras.failed, ctx.extras.failure, ctx.extras.failedMessage = false, nil, nil
+	}
+	switch msg := md.(type) {
+	case *drained:
+		ctx.endDrain()
+		return
+	case *receiveTimeoutFired:
+		if ctx.extras == nil || !ctx.extras.receiveTimeoutExpired(ctx.actorSystem.Config.Clock) {
+			return
+		}
+		ctx.CancelReceiveTimeout()
+		md = receiveTimeoutMessage
+	case *MessageEnvelope:
+		msg.checkReleased()
+	}
 	if atomic.LoadInt32(&ctx.state) == stateStopped {
-		// already stopped
+		// already stopped, messages that were still in the mailbox are dead letters
+		ctx.actorSystem.DeadLetter.SendUserMessageWithReason(ctx.self, md, DeadLetterStoppedBeforeProcessing)
+		return
+	}
+	if expired(md) {
+		ctx.actorSystem.DeadLetter.SendUserMessageWithReason(ctx.self, md, DeadLetterExpired)
 		return
 	}
 
 	influenceTimeout := true
 	if ctx.receiveTimeout > 0 {
-		_, influenceTimeout = md.(NotInfluenceReceiveTimeout)
-		influenceTimeout = !influenceTimeout
+		influenceTimeout = ctx.influencesReceiveTimeout(md)
 		if influenceTimeout {
 			ctx.extras.stopReceiveTimeoutTimer()
 		}
 	}
 
-	ctx.processMessage(md)
+	var inv *invocation
+	if ctx.props.budget != nil {
+		inv = ctx.startInvocation(md)
+	}
+	if ctx.props.liveness != nil {
+		ctx.extras.liveness.begin()
+	}
+	if ctx.props.messageHistory > 0 {
+		ctx.messageHistory().begin(md, ctx.actorSystem.Config.Clock.Now())
+	}
+	if ctx.extras != nil && ctx.extras.watchTimeouts != nil {
+		// the messages of the actors watched with a timeout are signs of life
+		if _, _, sender := UnwrapEnvelope(md); sender != nil {
+			ctx.extras.watchTimeouts.alive(sender, ctx.actorSystem.Config.Clock.Now())
+		}
+	}
+	ctx.resetMessageGoContext()
+	if ctx.awaitResponse(md) {
+		ctx.processMessage(md)
+		ctx.checkResponse(md)
+	} else {
+		ctx.processMessage(md)
+	}
+	if inv != nil {
+		ctx.endInvocation(inv)
+	}
+	if ctx.props.liveness != nil {
+		ctx.extras.liveness.end(md)
+	}
+	if ctx.props.messageHistory > 0 {
+		ctx.extras.history.end(ctx.actorSystem.Config.Clock.Now())
+	}
 
 	if ctx.receiveTimeout > 0 && influenceTimeout {
-		ctx.extras.resetReceiveTimeoutTimer(ctx.receiveTimeout)
+		ctx.extras.resetReceiveTimeoutTimer(ctx.actorSystem.Config.Clock, ctx.receiveTimeout)
 	}
+	ctx.releaseEnvelope(md)
 }
 
 func (ctx *actorContext) processMessage(m interface{}) {
@@ -453,7 +754,11 @@ func (ctx *actorContext) processMessage(m interface{}) {
 
 func (ctx *actorContext) incarnateActor() {
 	atomic.StoreInt32(&ctx.state, stateAlive)
-	ctx.actor = ctx.props.producer()
+	if ctx.props.standby != nil {
+		ctx.actor = ctx.props.standby.get(ctx.props.producer)
+	} else {
+		ctx.actor = incarnate(ctx.props.producer)
+	}
 }
 
 func (ctx *actorContext) InvokeSystemMessage(message interface{}) {
@@ -463,6 +768,12 @@ func (ctx *actorContext) InvokeSystemMessage(message interface{}) {
 		msg.f()                             // invoke the continuation in the current actor context
 		ctx.messageOrEnvelope = nil         // release the message
 	case *Started:
+		if ctx.props.idlePassivation > 0 {
+			ctx.SetReceiveTimeout(ctx.props.idlePassivation)
+		}
+		if ctx.props.liveness != nil {
+			ctx.startLiveness()
+		}
 		ctx.InvokeUserMessage(msg) // forward
 	case *Watch:
 		ctx.handleWatch(msg)
@@ -470,19 +781,37 @@ func (ctx *actorContext) InvokeSystemMessage(message interface{}) {
 		ctx.handleUnwatch(msg)
 	case *Stop:
 		ctx.handleStop(msg)
+	case *drainTimeout:
+		ctx.endDrain()
+	case *watchTimeoutTick:
+		ctx.handleWatchTimeoutTick(msg)
 	case *Terminated:
 		ctx.handleTerminated(msg)
 	case *Failure:
 		ctx.handleFailure(msg)
 	case *Restart:
 		ctx.handleRestart(msg)
+	case *restartWithRedelivery:
+		ctx.handleRestartWithRedelivery(msg)
+	case *releaseChild:
+		ctx.handleReleaseChild(msg)
+	case *reparent:
+		ctx.handleReparent(msg)
+	case *adoptChild:
+		ctx.handleAdoptChild(msg)
+	case *childReleased:
+		ctx.handleChildReleased(msg)
+	case *inspectActor:
+		msg.reply <- ctx.inspect()
+	case *inspectWatchers:
+		msg.reply <- ctx.inspectWatchers(msg.maxWatchers)
 	default:
 		plog.Error("unknown system message", log.Message(msg))
 	}
 }
 
 func (ctx *actorContext) handleRootFailure(failure *Failure) {
-	defaultSupervisionStrategy.HandleFailure(ctx.actorSystem, ctx, failure.Who, failure.RestartStats, failure.Reason, failure.Message)
+	ctx.actorSystem.Config.DefaultSupervisorStrategy.HandleFailure(ctx.actorSystem, ctx, failure.Who, failure.RestartStats, failure.Reason, failure.Message)
 }
 
 func (ctx *actorContext) handleWatch(msg *Watch) {
@@ -492,6 +821,7 @@ func (ctx *actorContext) handleWatch(msg *Watch) {
 		})
 	} else {
 		ctx.ensureExtras().watch(msg.Watcher)
+		ctx.checkWatcherLimit()
 	}
 }
 
@@ -500,11 +830,24 @@ func (ctx *actorContext) handleUnwatch(msg *Unwatch) {
 		return
 	}
 	ctx.extras.unwatch(msg.Watcher)
+	ctx.checkWatcherLimit()
 }
 
 func (ctx *actorContext) handleRestart(msg *Restart) {
 	atomic.StoreInt32(&ctx.state, stateRestarting)
-	ctx.InvokeUserMessage(restartingMessage)
+	reason, message := msg.Reason, msg.Message
+	if reason == nil && ctx.extras != nil && ctx.extras.failed {
+		// restarted by hand after failing
+		reason, message = ctx.extras.failure, ctx.extras.failedMessage
+	}
+	ctx.ensureExtras().restartReason = reason
+	ctx.cleanupAfterFailure()
+	if actor, ok := ctx.actor.(PreRestartAware); ok {
+		_, message, _ = UnwrapEnvelope(message)
+		ctx.preRestartSafely(actor, reason, message)
+	} else {
+		ctx.InvokeUserMessage(restartingMessage)
+	}
 	ctx.stopAllChildren()
 	ctx.tryRestartOrTerminate()
 }
@@ -515,31 +858,85 @@ func (ctx *actorContext) handleStop(msg *Stop) {
 		// already stopping or stopped
 		return
 	}
+	if extras := ctx.ensureExtras(); !extras.draining {
+		extras.stopReason = msg.Reason
+	}
+	if ctx.drain() {
+		return
+	}
+	ctx.stop()
+}
 
+func (ctx *actorContext) stop() {
+	ctx.cancelDrain()
 	atomic.StoreInt32(&ctx.state, stateStopping)
+	// the calls in flight abort before the actor releases its resources
+	ctx.cancelGoContext()
+	ctx.cleanupAfterFailure()
 
 	ctx.InvokeUserMessage(stoppingMessage)
 	ctx.stopAllChildren()
 	ctx.tryRestartOrTerminate()
 }
 
+// cleanupAfterFailure lets the failed actor release its resources before it is restarted or stopped
+func (ctx *actorContext) cleanupAfterFailure() {
+	if ctx.extras == nil || !ctx.extras.failed {
+		return
+	}
+	reason := ctx.extras.failure
+	ctx.extras.failed, ctx.extras.failure, ctx.extras.failedMessage = false, nil, nil
+	if actor, ok := ctx.actor.(SafeCleanup); ok {
+		ctx.cleanupSafely(actor.Cleanup, reason)
+	} else if ctx.props.cleanup != nil {
+		ctx.cleanupSafely(ctx.props.cleanup, reason)
+	}
+}
+
+func (ctx *actorContext) preRestartSafely(actor PreRestartAware, reason interface{}, message interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			plog.Error("actor pre-restart failed", log.Stringer("pid", ctx.self), log.Object("reason", r))
+		}
+	}()
+	actor.PreRestart(reason, message)
+}
+
+func (ctx *actorContext) cleanupSafely(cleanup func(reason interface{}), reason interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			plog.Error("actor cleanup failed", log.Stringer("pid", ctx.self), log.Object("reason", r))
+		}
+	}()
+	cleanup(reason)
+}
+
 // child stopped, check if we can stop or restart (if needed)
 func (ctx *actorContext) handleTerminated(msg *Terminated) {
+	var message interface{} = msg
 	if ctx.extras != nil {
 		ctx.extras.removeChild(msg.Who)
+		ctx.extras.watching.Remove(msg.Who)
+		if ctx.extras.watchTimeouts != nil {
+			ctx.extras.watchTimeouts.remove(msg.Who)
+		}
+		if watchMessage, ok := ctx.extras.removeWatchMessage(msg.Who); ok {
+			message = watchMessage
+		}
 	}
 
-	ctx.InvokeUserMessage(msg)
+	ctx.InvokeUserMessage(message)
 	ctx.tryRestartOrTerminate()
 }
 
 // offload the supervision completely to the supervisor strategy
 func (ctx *actorContext) handleFailure(msg *Failure) {
+	supervisor := &failureSupervisor{Supervisor: ctx, system: ctx.actorSystem, failure: msg}
 	if strategy, ok := ctx.actor.(SupervisorStrategy); ok {
-		strategy.HandleFailure(ctx.actorSystem, ctx, msg.Who, msg.RestartStats, msg.Reason, msg.Message)
+		strategy.HandleFailure(ctx.actorSystem, supervisor, msg.Who, msg.RestartStats, msg.Reason, msg.Message)
 		return
 	}
-	ctx.props.getSupervisor().HandleFailure(ctx.actorSystem, ctx, msg.Who, msg.RestartStats, msg.Reason, msg.Message)
+	ctx.props.getSupervisor(ctx.actorSystem).HandleFailure(ctx.actorSystem, supervisor, msg.Who, msg.RestartStats, msg.Reason, msg.Message)
 }
 
 func (ctx *actorContext) stopAllChildren() {
@@ -547,7 +944,7 @@ func (ctx *actorContext) stopAllChildren() {
 		return
 	}
 	ctx.extras.children.ForEach(func(_ int, pid *PID) {
-		ctx.Stop(pid)
+		pid.stop(ctx.actorSystem, StopReason_ParentStopped)
 	})
 }
 
@@ -568,6 +965,16 @@ func (ctx *actorContext) tryRestartOrTerminate() {
 
 func (ctx *actorContext) restart() {
 	ctx.incarnateActor()
+	ctx.ensureExtras().restarts++
+	if ctx.extras != nil {
+		// the new incarnation starts with its own receive, and processes the messages stashed by the behaviors
+		ctx.extras.behavior.clear()
+		ctx.extras.switchBehavior(nil)
+		ctx.extras.dropLocals()
+		if ctx.extras.watchTimeouts != nil {
+			ctx.extras.watchTimeouts.clear()
+		}
+	}
 	ctx.self.sendSystemMessage(ctx.actorSystem, resumeMailboxMessage)
 	ctx.InvokeUserMessage(startedMessage)
 	if ctx.extras != nil && ctx.extras.stash != nil {
@@ -576,12 +983,45 @@ func (ctx *actorContext) restart() {
 			ctx.InvokeUserMessage(msg)
 		}
 	}
+	ctx.redeliver()
+	ctx.notifyRestarted()
+}
+
+// notifyRestarted tells the parent the actor restarted, after it processed Started
+func (ctx *actorContext) notifyRestarted() {
+	reason := ctx.extras.restartReason
+	ctx.extras.restartReason = nil
+	if ctx.parent == nil || !ctx.props.notifyRestarts {
+		return
+	}
+	ctx.parent.sendUserMessage(ctx.actorSystem, &ChildRestarted{Who: ctx.self, Reason: reason, RestartCount: ctx.extras.restarts})
 }
 
 func (ctx *actorContext) finalizeStop() {
 	ctx.actorSystem.ProcessRegistry.Remove(ctx.self)
+	if ctx.extras != nil && ctx.extras.delayed != nil {
+		ctx.extras.delayed.cancelAll()
+	}
+	if ctx.extras != nil && ctx.extras.liveness != nil {
+		ctx.extras.liveness.stop()
+	}
+	if ctx.extras != nil && ctx.extras.watchTimeouts != nil {
+		ctx.extras.watchTimeouts.clear()
+	}
+	if ctx.extras != nil {
+		ctx.extras.switchBehavior(nil)
+		for md, ok := ctx.extras.nextUnstashed(); ok; md, ok = ctx.extras.nextUnstashed() {
+			ctx.actorSystem.DeadLetter.SendUserMessageWithReason(ctx.self, md, DeadLetterStoppedBeforeProcessing)
+		}
+	}
 	ctx.InvokeUserMessage(stoppedMessage)
+	if ctx.extras != nil {
+		ctx.extras.locals = nil
+	}
 	otherStopped := &Terminated{Who: ctx.self}
+	if ctx.extras != nil && ctx.extras.passivated {
+		otherStopped.Why = TerminatedReason_Passivated
+	}
 	// Notify watchers
 	if ctx.extras != nil {
 		ctx.extras.watchers.ForEach(func(i int, pid *PID) {
@@ -599,8 +1039,26 @@ func (ctx *actorContext) finalizeStop() {
 // Interface: Supervisor
 //
 
+// HandleOverflow dead-letters the messages the mailbox of the actor had no room for
+func (ctx *actorContext) HandleOverflow(message interface{}) {
+	ctx.actorSystem.DeadLetter.SendUserMessageWithReason(ctx.self, message, DeadLetterMailboxFull)
+}
+
 func (ctx *actorContext) EscalateFailure(reason interface{}, message interface{}) {
-	failure := &Failure{Reason: reason, Who: ctx.self, RestartStats: ctx.ensureExtras().restartStats(), Message: message}
+	ctx.abandonInvocation()
+	if ctx.extras != nil && ctx.extras.liveness != nil {
+		ctx.extras.liveness.idle()
+	}
+	failure := &Failure{Reason: reason, Who: ctx.self, RestartStats: ctx.ensureExtras().restartStats(ctx.actorSystem.Config.Clock), Message: message}
+	ctx.extras.failed, ctx.extras.failure = true, reason
+	if redeliverable(message) {
+		ctx.extras.failedMessage = message
+	}
+	if history := ctx.messageHistory(); history != nil {
+		history.end(ctx.actorSystem.Config.Clock.Now())
+		failure.History = history.get()
+		ctx.actorSystem.SystemEventStream.Publish(failure)
+	}
 	ctx.self.sendSystemMessage(ctx.actorSystem, suspendMailboxMessage)
 	if ctx.parent == nil {
 		ctx.handleRootFailure(failure)
@@ -617,9 +1075,15 @@ func (ctx *actorContext) RestartChildren(pids ...*PID) {
 	}
 }
 
+func (ctx *actorContext) RestartChildrenWithRedelivery(pids ...*PID) {
+	for _, pid := range pids {
+		pid.sendSystemMessage(ctx.actorSystem, restartWithRedeliveryMessage)
+	}
+}
+
 func (ctx *actorContext) StopChildren(pids ...*PID) {
 	for _, pid := range pids {
-		pid.sendSystemMessage(ctx.actorSystem, stopMessage)
+		pid.sendSystemMessage(ctx.actorSystem, &Stop{Reason: StopReason_SupervisorStopped})
 	}
 }
 
@@ -2,6 +2,7 @@ package actor
 
 import (
 	"fmt"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -153,6 +154,21 @@ func TestActorContext_Respond(t *testing.T) {
 	system.EventStream.Unsubscribe(deadLetterSubscriber)
 }
 
+func TestActorContext_RespondAfterReceive(t *testing.T) {
+	// the responder receives a nested message before responding to the request
+	responder := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			ctx.Receive(&MessageEnvelope{Message: 1})
+			ctx.Respond(ctx.Message() == msg)
+		}
+	}))
+
+	res, err := rootContext.RequestFuture(responder, "hello", time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, true, res, "the request is the message again after the nested one")
+}
+
 func TestActorContext_Forward(t *testing.T) {
 	// Defined a respond actor
 	// It simply respond the string message
@@ -251,3 +267,75 @@ func TestActorContinueFutureInActor(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "done", res)
 }
+
+type nullProcess struct{}
+
+func (nullProcess) SendUserMessage(*PID, interface{})   {}
+func (nullProcess) SendSystemMessage(*PID, interface{}) {}
+func (nullProcess) Stop(*PID)                           {}
+
+// tenThousandWatches are the watches of 10k watchers discarding the messages they get
+func tenThousandWatches() []*Watch {
+	var process Process = nullProcess{}
+	watches := make([]*Watch, 10000)
+	for i := range watches {
+		watcher := NewPID("nohost", "watcher"+strconv.Itoa(i))
+		watcher.p = &process
+		watches[i] = &Watch{Watcher: watcher}
+	}
+	return watches
+}
+
+func BenchmarkActorContext_WatchAndStop10kWatchers(b *testing.B) {
+	watches := tenThousandWatches()
+	self := NewPID(localAddress, "watched")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx := newActorContext(system, PropsFromFunc(nullReceive), nil)
+		ctx.self = self
+		for _, watch := range watches {
+			ctx.handleWatch(watch)
+		}
+		ctx.finalizeStop()
+	}
+}
+
+func BenchmarkActorContext_FinalizeStop10kWatchers(b *testing.B) {
+	watches := tenThousandWatches()
+	self := NewPID(localAddress, "watched")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		ctx := newActorContext(system, PropsFromFunc(nullReceive), nil)
+		ctx.self = self
+		for _, watch := range watches {
+			ctx.handleWatch(watch)
+		}
+		b.StartTimer()
+		ctx.finalizeStop()
+	}
+}
+
+func BenchmarkActorContext_Unwatch10kWatchers(b *testing.B) {
+	watches := tenThousandWatches()
+	unwatches := make([]*Unwatch, len(watches))
+	for i, watch := range watches {
+		unwatches[i] = &Unwatch{Watcher: watch.Watcher}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		ctx := newActorContext(system, PropsFromFunc(nullReceive), nil)
+		for _, watch := range watches {
+			ctx.handleWatch(watch)
+		}
+		b.StartTimer()
+		// the oldest watchers unwatch first
+		for _, unwatch := range unwatches {
+			ctx.handleUnwatch(unwatch)
+		}
+	}
+}
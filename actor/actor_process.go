@@ -9,12 +9,24 @@ import (
 type ActorProcess struct {
 	mailbox mailbox.Mailbox
 	dead    int32
+	parent  atomic.Value // the *PID of the parent of the actor, nil when spawned from the root context
+	phase   atomic.Value // the shutdown phase of a top level actor, DefaultShutdownPhase when not set
 }
 
 func NewActorProcess(mailbox mailbox.Mailbox) *ActorProcess {
 	return &ActorProcess{mailbox: mailbox}
 }
 
+func (ref *ActorProcess) setParent(parent *PID) {
+	ref.parent.Store(parent)
+}
+
+// parentPID returns the parent of the actor, nil when it is a top level actor
+func (ref *ActorProcess) parentPID() *PID {
+	parent, _ := ref.parent.Load().(*PID)
+	return parent
+}
+
 func (ref *ActorProcess) SendUserMessage(pid *PID, message interface{}) {
 	ref.mailbox.PostUserMessage(message)
 }
@@ -6,6 +6,8 @@ import (
 
 	"github.com/AsynkronIT/protoactor-go/eventstream"
 	"github.com/AsynkronIT/protoactor-go/extensions"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+	"github.com/AsynkronIT/protoactor-go/mailbox"
 )
 
 //goland:noinspection GoNameStartsWithPackageName
@@ -13,15 +15,35 @@ type ActorSystem struct {
 	ProcessRegistry *ProcessRegistryValue
 	Root            *RootContext
 	EventStream     *eventstream.EventStream
-	Guardians       *guardiansValue
-	DeadLetter      *deadLetterProcess
-	Extensions      *extensions.Extensions
+	// SystemEventStream receives the dead letters and supervision events, it is EventStream unless configured
+	SystemEventStream *eventstream.EventStream
+	Guardians         *guardiansValue
+	DeadLetter        *deadLetterProcess
+	Extensions        *extensions.Extensions
+	// Diagnostics records the pending asks while enabled
+	Diagnostics *Diagnostics
+	// Config is the configuration of the system, it is not changed once the system is created
+	Config *Config
+
+	timers   *timerwheel.Wheel
+	blocking *blockingPool
+	watchdog *watchdog
+	liveness *livenessWatchdog
+	eventLog *eventLog
+	quotas   quotaCounters
+	// envelopes recycles the envelopes of the requests, it is nil unless Config.EnvelopePooling
+	envelopes *envelopePool
 }
 
 func (as *ActorSystem) NewLocalPID(id string) *PID {
 	return NewPID(as.ProcessRegistry.Address, id)
 }
 
+// Clock is the clock the timers of the actor system run on
+func (as *ActorSystem) Clock() Clock {
+	return as.Config.Clock
+}
+
 func (as *ActorSystem) Address() string {
 	return as.ProcessRegistry.Address
 }
@@ -29,7 +51,7 @@ func (as *ActorSystem) Address() string {
 func (as *ActorSystem) GetHostPort() (host string, port int, err error) {
 	addr := as.ProcessRegistry.Address
 	if h, p, e := net.SplitHostPort(addr); e != nil {
-		if addr != localAddress {
+		if addr != localAddress && !isLocalAlias(addr) {
 			err = e
 		}
 		host = localAddress
@@ -41,13 +63,60 @@ func (as *ActorSystem) GetHostPort() (host string, port int, err error) {
 	return
 }
 
-func NewActorSystem() *ActorSystem {
-	system := &ActorSystem{}
+// SystemOption configures an actor system
+type SystemOption func(*ActorSystem)
+
+// WithSystemEventStream publishes the dead letters and supervision events to es,
+// so that they do not mix with the events of the application
+func WithSystemEventStream(es *eventstream.EventStream) SystemOption {
+	return func(system *ActorSystem) {
+		system.SystemEventStream = es
+	}
+}
+
+// NewActorSystem returns an actor system with the default Config, configured with options
+func NewActorSystem(options ...SystemOption) *ActorSystem {
+	return NewActorSystemWithConfig(NewConfig(), options...)
+}
+
+// NewActorSystemWithConfig returns an actor system with a copy of config, configured with options
+func NewActorSystemWithConfig(config *Config, options ...SystemOption) *ActorSystem {
+	cfg := *config
+	cfg.MailboxStatistics = append([]mailbox.Statistics(nil), config.MailboxStatistics...)
+	system := &ActorSystem{Config: &cfg}
+	if cfg.Clock == nil {
+		cfg.Clock = RealClock{}
+	}
+	if cfg.DefaultDispatcher == nil {
+		cfg.DefaultDispatcher = defaultDispatcher
+	}
+	if cfg.DefaultSupervisorStrategy == nil {
+		cfg.DefaultSupervisorStrategy = defaultSupervisionStrategy
+	}
+	if cfg.BlockingPoolSize <= 0 {
+		cfg.BlockingPoolSize = DefaultBlockingPoolSize
+	}
+	if cfg.ShutdownPhaseTimeout <= 0 {
+		cfg.ShutdownPhaseTimeout = DefaultShutdownPhaseTimeout
+	}
 
 	system.ProcessRegistry = NewProcessRegistry(system)
 	system.Root = NewRootContext(system, EmptyMessageHeader)
 	system.Guardians = NewGuardians(system)
 	system.EventStream = eventstream.NewEventStream()
+	system.SystemEventStream = system.EventStream
+	system.Diagnostics = newDiagnostics(system)
+	for _, option := range options {
+		option(system)
+	}
+	system.timers = newTimerWheel(system.Config.Clock)
+	system.blocking = newBlockingPool(cfg.BlockingPoolSize)
+	system.watchdog = newWatchdog()
+	if cfg.EnvelopePooling {
+		system.envelopes = newEnvelopePool(cfg.EnvelopePoolDebug)
+	}
+	system.liveness = newLivenessWatchdog(system)
+	system.eventLog = newEventLog(system)
 	system.DeadLetter = NewDeadLetter(system)
 	system.Extensions = extensions.NewExtensions()
 	SubscribeSupervision(system)
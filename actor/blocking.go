@@ -0,0 +1,93 @@
+package actor
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultBlockingPoolSize is the number of blocking calls run at once when the Config does not set it
+const DefaultBlockingPoolSize = 64
+
+// blockingPool runs the blocking calls of the actors of a system on at most size goroutines,
+// the calls made while all of them are busy wait in order for the first to be free
+type blockingPool struct {
+	size    int
+	mu      sync.Mutex
+	running int
+	queue   []func()
+}
+
+func newBlockingPool(size int) *blockingPool {
+	return &blockingPool{size: size}
+}
+
+func (p *blockingPool) run(task func()) {
+	p.mu.Lock()
+	if p.running >= p.size {
+		p.queue = append(p.queue, task)
+		p.mu.Unlock()
+		return
+	}
+	p.running++
+	p.mu.Unlock()
+	go p.work(task)
+}
+
+// work runs task, then the queued tasks until the queue is empty
+func (p *blockingPool) work(task func()) {
+	for task != nil {
+		task()
+
+		p.mu.Lock()
+		if len(p.queue) == 0 {
+			p.running--
+			task = nil
+		} else {
+			task = p.queue[0]
+			p.queue[0] = nil
+			p.queue = p.queue[1:]
+		}
+		p.mu.Unlock()
+	}
+}
+
+// callBlocking returns the result of run, a panic of run is returned as an error
+func callBlocking(run func() (interface{}, error)) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, fmt.Errorf("blocking call panicked: %v", r)
+		}
+	}()
+	return run()
+}
+
+func (ctx *actorContext) RunBlocking(run func() (interface{}, error), cont func(res interface{}, err error)) {
+	ctx.runBlocking(run, cont, false)
+}
+
+func (ctx *actorContext) RunBlockingExclusive(run func() (interface{}, error), cont func(res interface{}, err error)) {
+	ctx.runBlocking(run, cont, true)
+}
+
+func (ctx *actorContext) runBlocking(run func() (interface{}, error), cont func(res interface{}, err error), exclusive bool) {
+	if exclusive {
+		// the mailbox is suspended once the current message is processed, before the next user message
+		ctx.self.sendSystemMessage(ctx.actorSystem, suspendMailboxMessage)
+	}
+
+	// the continuation may respond
+	ctx.responded()
+	message := ctx.keptMessage()
+	ctx.actorSystem.blocking.run(func() {
+		res, err := callBlocking(run)
+		ctx.self.sendSystemMessage(ctx.actorSystem, &continuation{
+			f: func() {
+				cont(res, err)
+				if exclusive {
+					ctx.self.sendSystemMessage(ctx.actorSystem, resumeMailboxMessage)
+				}
+			},
+			message: message,
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package actor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBlocking_KeepsProcessingMessages(t *testing.T) {
+	release := make(chan struct{})
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message() {
+		case "block":
+			ctx.RunBlocking(func() (interface{}, error) {
+				<-release
+				return "unblocked", nil
+			}, func(res interface{}, err error) {
+				// the continuation runs with the message of the call
+				ctx.Respond(res)
+			})
+		case "ping":
+			ctx.Respond("pong")
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	blocked := rootContext.RequestFuture(pid, "block", testTimeout)
+	res, err := rootContext.RequestFuture(pid, "ping", testTimeout).Result()
+	require.NoError(t, err, "the actor is not blocked")
+	assert.Equal(t, "pong", res)
+
+	close(release)
+	res, err = blocked.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "unblocked", res)
+}
+
+func TestRunBlockingExclusive_SuspendsUserMessages(t *testing.T) {
+	release := make(chan struct{})
+	events := make(chan string, 10)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message() {
+		case "block":
+			ctx.RunBlockingExclusive(func() (interface{}, error) {
+				<-release
+				return nil, nil
+			}, func(interface{}, error) {
+				events <- "continued"
+			})
+		case "ping":
+			events <- "ping"
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, "block")
+	rootContext.Send(pid, "ping")
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected %s while blocked", event)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	assert.Equal(t, "continued", <-events)
+	assert.Equal(t, "ping", <-events)
+}
+
+func TestRunBlocking_PoolSaturation(t *testing.T) {
+	system := NewActorSystemWithConfig(NewConfig(WithBlockingPoolSize(2)))
+	var running, exceeded int32
+	release := make(chan struct{})
+	done := make(chan interface{}, 10)
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if n, ok := ctx.Message().(int); ok {
+			ctx.RunBlocking(func() (interface{}, error) {
+				if atomic.AddInt32(&running, 1) > 2 {
+					atomic.StoreInt32(&exceeded, 1)
+				}
+				<-release
+				atomic.AddInt32(&running, -1)
+				return n, nil
+			}, func(res interface{}, err error) {
+				done <- res
+			})
+		}
+	}))
+	defer system.Root.Stop(pid)
+
+	for i := 0; i < 5; i++ {
+		system.Root.Send(pid, i)
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&running) == 2 }, testTimeout, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&running), "the other calls wait for a free worker")
+	assert.Len(t, done, 0)
+
+	close(release)
+	results := make(map[interface{}]bool)
+	for i := 0; i < 5; i++ {
+		select {
+		case res := <-done:
+			results[res] = true
+		case <-time.After(testTimeout):
+			t.Fatal("a blocking call never completed")
+		}
+	}
+	assert.Len(t, results, 5)
+	assert.Zero(t, atomic.LoadInt32(&exceeded), "no more calls than the pool size run at once")
+}
+
+func TestRunBlocking_PanicIsError(t *testing.T) {
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if ctx.Message() == "panic" {
+			ctx.RunBlocking(func() (interface{}, error) {
+				panic("boom")
+			}, func(res interface{}, err error) {
+				ctx.Respond(err.Error())
+			})
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	res, err := rootContext.RequestFuture(pid, "panic", testTimeout).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "blocking call panicked: boom", res)
+}
@@ -0,0 +1,228 @@
+package actor
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// watchdogInterval is how often the watchdog samples the messages being processed, the messages slower than their
+// soft budget are found within it
+const watchdogInterval = 5 * time.Millisecond
+
+// SlowMessageEvent is published on the system event stream when an actor processes a message longer than its soft
+// budget, see Props.WithMessageBudget. It is published once per message: with the stack of the goroutine processing
+// it when the watchdog found it still processed, or without when it was processed before the watchdog sampled it
+type SlowMessageEvent struct {
+	PID         *PID
+	ActorType   string
+	MessageType string
+	Elapsed     time.Duration
+	Stack       string
+}
+
+// BudgetExceededEvent is published on the system event stream when an actor processed a message longer than its
+// hard budget, Violations is the number of messages in a row it did so
+type BudgetExceededEvent struct {
+	PID         *PID
+	ActorType   string
+	MessageType string
+	Elapsed     time.Duration
+	Violations  int
+}
+
+// BudgetExceededError is the reason of the failure of an actor which exceeded its hard budget as many messages in a
+// row as allowed
+type BudgetExceededError struct {
+	MessageType string
+	Elapsed     time.Duration
+	Budget      time.Duration
+	Violations  int
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("processed %v messages in a row longer than %v, the last one %v in %v",
+		e.Violations, e.Budget, e.MessageType, e.Elapsed)
+}
+
+type messageBudget struct {
+	soft          time.Duration
+	hard          time.Duration
+	maxViolations int
+}
+
+// invocation is a message processed by an actor with a budget
+type invocation struct {
+	ctx         *actorContext
+	actorType   string
+	messageType string
+	started     time.Time
+	goroutine   uint64
+	reported    int32
+}
+
+func (ctx *actorContext) startInvocation(message interface{}) *invocation {
+	_, msg, _ := UnwrapEnvelope(message)
+	inv := &invocation{
+		ctx:         ctx,
+		actorType:   fmt.Sprintf("%T", ctx.actor),
+		messageType: fmt.Sprintf("%T", msg),
+		started:     time.Now(),
+	}
+	if ctx.props.budget.soft > 0 {
+		// the stack of the goroutine is sampled if the message is slow
+		inv.goroutine = goroutineID()
+		ctx.ensureExtras().invocation = inv
+		ctx.actorSystem.watchdog.add(inv)
+	}
+	return inv
+}
+
+// endInvocation reports the message of inv if it was processed over budget, and fails the actor when it exceeded its
+// hard budget too many times in a row
+func (ctx *actorContext) endInvocation(inv *invocation) {
+	ctx.abandonInvocation()
+	budget := ctx.props.budget
+	elapsed := time.Since(inv.started)
+	if budget.soft > 0 && elapsed > budget.soft && atomic.CompareAndSwapInt32(&inv.reported, 0, 1) {
+		inv.report(elapsed, "")
+	}
+	if budget.hard <= 0 {
+		return
+	}
+	extras := ctx.ensureExtras()
+	if elapsed <= budget.hard {
+		extras.budgetViolations = 0
+		return
+	}
+
+	extras.budgetViolations++
+	event := &BudgetExceededEvent{
+		PID:         ctx.self,
+		ActorType:   inv.actorType,
+		MessageType: inv.messageType,
+		Elapsed:     elapsed,
+		Violations:  extras.budgetViolations,
+	}
+	plog.Info("actor exceeded its hard budget", log.Stringer("pid", ctx.self), log.String("message", event.MessageType),
+		log.Duration("elapsed", elapsed), log.Int("violations", event.Violations))
+	ctx.actorSystem.SystemEventStream.Publish(event)
+
+	if budget.maxViolations > 0 && extras.budgetViolations >= budget.maxViolations {
+		extras.budgetViolations = 0
+		ctx.EscalateFailure(&BudgetExceededError{
+			MessageType: inv.messageType,
+			Elapsed:     elapsed,
+			Budget:      budget.hard,
+			Violations:  event.Violations,
+		}, nil)
+	}
+}
+
+// abandonInvocation stops watching the message being processed, when it failed the actor
+func (ctx *actorContext) abandonInvocation() {
+	if ctx.extras != nil && ctx.extras.invocation != nil {
+		ctx.actorSystem.watchdog.remove(ctx.extras.invocation)
+		ctx.extras.invocation = nil
+	}
+}
+
+func (inv *invocation) report(elapsed time.Duration, stack string) {
+	event := &SlowMessageEvent{
+		PID:         inv.ctx.self,
+		ActorType:   inv.actorType,
+		MessageType: inv.messageType,
+		Elapsed:     elapsed,
+		Stack:       stack,
+	}
+	plog.Info("actor is slow to process a message", log.Stringer("pid", event.PID), log.String("message", event.MessageType),
+		log.Duration("elapsed", elapsed))
+	inv.ctx.actorSystem.SystemEventStream.Publish(event)
+}
+
+// watchdog samples the messages processed by the actors with a budget, its goroutine runs while there are some
+type watchdog struct {
+	mu          sync.Mutex
+	invocations map[*invocation]struct{}
+	running     bool
+}
+
+func newWatchdog() *watchdog {
+	return &watchdog{invocations: make(map[*invocation]struct{})}
+}
+
+func (w *watchdog) add(inv *invocation) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.invocations[inv] = struct{}{}
+	if !w.running {
+		w.running = true
+		go w.run()
+	}
+}
+
+func (w *watchdog) remove(inv *invocation) {
+	w.mu.Lock()
+	delete(w.invocations, inv)
+	w.mu.Unlock()
+}
+
+func (w *watchdog) run() {
+	ticker := time.NewTicker(watchdogInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		w.mu.Lock()
+		if len(w.invocations) == 0 {
+			w.running = false
+			w.mu.Unlock()
+			return
+		}
+		var slow []*invocation
+		for inv := range w.invocations {
+			if time.Since(inv.started) > inv.ctx.props.budget.soft && atomic.LoadInt32(&inv.reported) == 0 {
+				slow = append(slow, inv)
+			}
+		}
+		w.mu.Unlock()
+
+		if len(slow) == 0 {
+			continue
+		}
+		stacks := goroutineStacks()
+		for _, inv := range slow {
+			if atomic.CompareAndSwapInt32(&inv.reported, 0, 1) {
+				inv.report(time.Since(inv.started), stacks[inv.goroutine])
+			}
+		}
+	}
+}
+
+// goroutineStacks returns the stacks of all the goroutines by id
+func goroutineStacks() map[uint64]string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := make(map[uint64]string)
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		header := bytes.TrimPrefix(stack, goroutinePrefix)
+		if i := bytes.IndexByte(header, ' '); i > 0 {
+			if id, err := strconv.ParseUint(string(header[:i]), 10, 64); err == nil {
+				stacks[id] = string(stack)
+			}
+		}
+	}
+	return stacks
+}
@@ -0,0 +1,105 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowActor sleeps as long as the durations it receives
+type slowActor struct {
+	restarts chan struct{}
+}
+
+func (a *slowActor) Receive(ctx Context) {
+	switch msg := ctx.Message().(type) {
+	case time.Duration:
+		time.Sleep(msg)
+	case *Restarting:
+		a.restarts <- struct{}{}
+	}
+}
+
+// budgetedSystem returns a system and the events of budgets it publishes
+func budgetedSystem() (*ActorSystem, chan interface{}) {
+	events := eventstream.NewEventStream()
+	system := NewActorSystem(WithSystemEventStream(events))
+	budgets := make(chan interface{}, 10)
+	events.Subscribe(func(evt interface{}) {
+		switch evt.(type) {
+		case *SlowMessageEvent, *BudgetExceededEvent, *SupervisorEvent:
+			budgets <- evt
+		}
+	})
+	return system, budgets
+}
+
+func TestMessageBudget_Soft(t *testing.T) {
+	system, events := budgetedSystem()
+	a := &slowActor{restarts: make(chan struct{}, 1)}
+	pid := system.Root.Spawn(PropsFromProducer(func() Actor { return a }).WithMessageBudget(20*time.Millisecond, 0, 0))
+
+	system.Root.Send(pid, time.Millisecond)
+	system.Root.Send(pid, 100*time.Millisecond)
+	var slow *SlowMessageEvent
+	select {
+	case evt := <-events:
+		slow = evt.(*SlowMessageEvent)
+	case <-time.After(testTimeout):
+		t.Fatal("the slow message was not reported")
+	}
+	assert.Equal(t, pid, slow.PID)
+	assert.Equal(t, "*actor.slowActor", slow.ActorType)
+	assert.Equal(t, "time.Duration", slow.MessageType)
+	assert.GreaterOrEqual(t, int64(slow.Elapsed), int64(20*time.Millisecond))
+	assert.Less(t, int64(slow.Elapsed), int64(100*time.Millisecond), "the message is reported while processed")
+	assert.Contains(t, slow.Stack, "(*slowActor).Receive", "the stack of the actor is sampled")
+
+	_ = system.Root.PoisonFuture(pid).Wait()
+	assert.Len(t, events, 0, "the messages are reported once")
+}
+
+func TestMessageBudget_HardFailsRepeatedViolations(t *testing.T) {
+	system, events := budgetedSystem()
+	a := &slowActor{restarts: make(chan struct{}, 1)}
+	pid := system.Root.Spawn(PropsFromProducer(func() Actor { return a }).WithMessageBudget(0, 10*time.Millisecond, 2))
+
+	system.Root.Send(pid, 20*time.Millisecond)
+	system.Root.Send(pid, time.Millisecond) // within the budget, the violations are not in a row
+	system.Root.Send(pid, 20*time.Millisecond)
+	system.Root.Send(pid, 20*time.Millisecond)
+
+	var violations []int
+	for len(violations) < 3 {
+		select {
+		case evt := <-events:
+			exceeded, ok := evt.(*BudgetExceededEvent)
+			require.True(t, ok, "%T", evt)
+			assert.GreaterOrEqual(t, int64(exceeded.Elapsed), int64(10*time.Millisecond))
+			violations = append(violations, exceeded.Violations)
+		case <-time.After(testTimeout):
+			t.Fatal("the violations were not reported")
+		}
+	}
+	assert.Equal(t, []int{1, 1, 2}, violations)
+
+	select {
+	case evt := <-events:
+		supervised := evt.(*SupervisorEvent)
+		var exceeded *BudgetExceededError
+		require.True(t, errors.As(supervised.Reason.(error), &exceeded))
+		assert.Equal(t, 2, exceeded.Violations)
+		assert.Equal(t, 10*time.Millisecond, exceeded.Budget)
+	case <-time.After(testTimeout):
+		t.Fatal("the actor did not fail")
+	}
+	select {
+	case <-a.restarts:
+	case <-time.After(testTimeout):
+		t.Fatal("the actor was not restarted")
+	}
+}
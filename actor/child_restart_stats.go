@@ -7,11 +7,19 @@ import (
 // RestartStatistics keeps track of how many times an actor have restarted and when
 type RestartStatistics struct {
 	failureTimes []time.Time
+	clock        Clock // the system clock when nil
 }
 
 // NewRestartStatistics construct a RestartStatistics
 func NewRestartStatistics() *RestartStatistics {
-	return &RestartStatistics{[]time.Time{}}
+	return &RestartStatistics{failureTimes: []time.Time{}}
+}
+
+func (rs *RestartStatistics) now() time.Time {
+	if rs.clock == nil {
+		return time.Now()
+	}
+	return rs.clock.Now()
 }
 
 // FailureCount returns failure count
@@ -21,7 +29,7 @@ func (rs *RestartStatistics) FailureCount() int {
 
 // Fail increases the associated actors failure count
 func (rs *RestartStatistics) Fail() {
-	rs.failureTimes = append(rs.failureTimes, time.Now())
+	rs.failureTimes = append(rs.failureTimes, rs.now())
 }
 
 // Reset the associated actors failure count
@@ -36,7 +44,7 @@ func (rs *RestartStatistics) NumberOfFailures(withinDuration time.Duration) int
 	}
 
 	num := 0
-	currTime := time.Now()
+	currTime := rs.now()
 	for _, t := range rs.failureTimes {
 		if currTime.Sub(t) < withinDuration {
 			num++
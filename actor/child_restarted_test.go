@@ -0,0 +1,122 @@
+package actor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type childFail struct{}
+
+type childHello struct {
+	incarnation int
+}
+
+// cachingParent keeps per child state, which goes stale when the child restarts
+type cachingParent struct {
+	childProps  *Props
+	child       *PID
+	incarnation int // the incarnation of the child the state is for
+	events      chan interface{}
+}
+
+func (p *cachingParent) Receive(ctx Context) {
+	switch msg := ctx.Message().(type) {
+	case *Started:
+		p.child = ctx.Spawn(p.childProps)
+		p.incarnation = 1
+	case *childFail:
+		ctx.Send(p.child, msg)
+	case *childHello:
+		p.events <- msg
+	case *ChildRestarted:
+		// rebuild the state for the new incarnation
+		p.incarnation = msg.RestartCount + 1
+		p.events <- msg
+	}
+}
+
+func greetingChild(incarnations *int) *Props {
+	return PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *Started:
+			*incarnations++
+			ctx.Send(ctx.Parent(), &childHello{incarnation: *incarnations})
+		case *childFail:
+			panic("boom")
+		}
+	})
+}
+
+func nextEvent(t *testing.T, events chan interface{}) interface{} {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(testTimeout):
+		t.Fatal("no event")
+		return nil
+	}
+}
+
+func TestChildRestarted(t *testing.T) {
+	events := make(chan interface{}, 10)
+	incarnations := 0
+	parent := &cachingParent{childProps: greetingChild(&incarnations).WithNotifyParentOnRestart(true), events: events}
+	pid := rootContext.Spawn(PropsFromProducer(func() Actor { return parent }))
+	defer rootContext.Stop(pid)
+	assert.Equal(t, &childHello{incarnation: 1}, nextEvent(t, events))
+
+	for i := 1; i <= 2; i++ {
+		rootContext.Send(pid, &childFail{})
+		// the greeting of the new incarnation comes before the notification, which is sent once it started
+		assert.Equal(t, &childHello{incarnation: i + 1}, nextEvent(t, events))
+		restarted, ok := nextEvent(t, events).(*ChildRestarted)
+		require.True(t, ok)
+		assert.Equal(t, parent.child, restarted.Who)
+		assert.Equal(t, "boom", restarted.Reason)
+		assert.Equal(t, i, restarted.RestartCount)
+		assert.Equal(t, i+1, parent.incarnation)
+	}
+}
+
+func TestChildRestarted_OffByDefault(t *testing.T) {
+	events := make(chan interface{}, 10)
+	incarnations := 0
+	parent := &cachingParent{childProps: greetingChild(&incarnations), events: events}
+	pid := rootContext.Spawn(PropsFromProducer(func() Actor { return parent }))
+	defer rootContext.Stop(pid)
+	nextEvent(t, events)
+
+	rootContext.Send(pid, &childFail{})
+	assert.Equal(t, &childHello{incarnation: 2}, nextEvent(t, events))
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected %#v", e)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestChildRestarted_Guardian(t *testing.T) {
+	incarnations := int32(0)
+	props := PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *Started:
+			atomic.AddInt32(&incarnations, 1)
+		case *childFail:
+			panic("boom")
+		case string:
+			ctx.Respond(ctx.Message())
+		}
+	}).WithNotifyParentOnRestart(true)
+	pid := NewRootContext(system, nil).WithGuardian(DefaultSupervisorStrategy()).Spawn(props)
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, &childFail{})
+	res, err := rootContext.RequestFuture(pid, "hello", testTimeout).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&incarnations), "the guardian takes the notification without failing the child again")
+}
@@ -0,0 +1,182 @@
+package actor_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fileActor holds a temp file, it fails on "fail"
+type fileActor struct {
+	file   *os.File
+	events chan string
+}
+
+func (a *fileActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Restarting:
+		a.events <- "restarting"
+	case *actor.Stopping:
+		a.events <- "stopping"
+	case string:
+		if msg == "fail" {
+			panic(msg)
+		}
+		a.events <- msg
+	}
+}
+
+func (a *fileActor) Cleanup(reason interface{}) {
+	if err := a.file.Close(); err != nil {
+		a.events <- "closed twice"
+		return
+	}
+	a.events <- "cleanup " + reason.(string)
+}
+
+// fileActors produces fileActors, it keeps their files
+type fileActors struct {
+	t      *testing.T
+	events chan string
+	files  []*os.File
+}
+
+func newFileActors(t *testing.T) *fileActors {
+	return &fileActors{t: t, events: make(chan string, 100)}
+}
+
+func (f *fileActors) props() *actor.Props {
+	return actor.PropsFromProducer(func() actor.Actor {
+		file, err := ioutil.TempFile(f.t.TempDir(), "cleanup")
+		require.NoError(f.t, err)
+		f.files = append(f.files, file)
+		return &fileActor{file: file, events: f.events}
+	})
+}
+
+func (f *fileActors) expect(t *testing.T, events ...string) {
+	for _, expected := range events {
+		select {
+		case event := <-f.events:
+			assert.Equal(t, expected, event)
+		case <-time.After(time.Second):
+			t.Fatalf("expected %s", expected)
+		}
+	}
+	select {
+	case event := <-f.events:
+		t.Fatalf("unexpected %s", event)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func assertClosed(t *testing.T, file *os.File) {
+	assert.Error(t, file.Close(), "the file is closed already")
+}
+
+func TestSafeCleanup_Restart(t *testing.T) {
+	system := actor.NewActorSystem()
+	files := newFileActors(t)
+	pid := system.Root.Spawn(files.props())
+
+	system.Root.Send(pid, "fail")
+	system.Root.Send(pid, "after")
+	files.expect(t, "cleanup fail", "restarting", "after")
+	require.Len(t, files.files, 2)
+	assertClosed(t, files.files[0])
+
+	// the new incarnation is not cleaned up when stopped without failing
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	files.expect(t, "stopping")
+	assert.NoError(t, files.files[1].Close())
+}
+
+func TestSafeCleanup_Stop(t *testing.T) {
+	system := actor.NewActorSystem()
+	files := newFileActors(t)
+	stopping := actor.NewOneForOneStrategy(10, time.Second, func(interface{}) actor.Directive {
+		return actor.StopDirective
+	})
+	parent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			ctx.Send(ctx.Spawn(files.props()), "fail")
+		}
+	}).WithSupervisor(stopping))
+	defer func() { _ = system.Root.StopFuture(parent).Wait() }()
+
+	files.expect(t, "cleanup fail", "stopping")
+	require.Len(t, files.files, 1)
+	assertClosed(t, files.files[0])
+}
+
+func TestSafeCleanup_Escalate(t *testing.T) {
+	system := actor.NewActorSystem()
+	files := newFileActors(t)
+	escalating := actor.NewOneForOneStrategy(10, time.Second, func(interface{}) actor.Directive {
+		return actor.EscalateDirective
+	})
+	parentCleanups := make(chan interface{}, 10)
+	parent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok && len(files.files) == 0 {
+			ctx.Send(ctx.Spawn(files.props()), "fail")
+		}
+	}).WithSupervisor(escalating).WithCleanup(func(reason interface{}) {
+		parentCleanups <- reason
+	}))
+	defer func() { _ = system.Root.StopFuture(parent).Wait() }()
+
+	// the parent is restarted, it stops the failed child
+	assert.Equal(t, "fail", <-parentCleanups)
+	files.expect(t, "cleanup fail", "stopping")
+	assertClosed(t, files.files[0])
+	assert.Len(t, parentCleanups, 0)
+}
+
+func TestSafeCleanup_Resume(t *testing.T) {
+	system := actor.NewActorSystem()
+	files := newFileActors(t)
+	resuming := actor.NewOneForOneStrategy(10, time.Second, func(interface{}) actor.Directive {
+		return actor.ResumeDirective
+	})
+	var child *actor.PID
+	parent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			child = ctx.Spawn(files.props())
+			ctx.Send(child, "fail")
+			ctx.Send(child, "after")
+		}
+	}).WithSupervisor(resuming))
+
+	files.expect(t, "after")
+	// the failure was handled by resuming the actor, stopping it later does not clean it up
+	require.NoError(t, system.Root.StopFuture(parent).Wait())
+	files.expect(t, "stopping")
+	assert.NoError(t, files.files[0].Close())
+}
+
+func TestPropsWithCleanup_SwallowsPanics(t *testing.T) {
+	system := actor.NewActorSystem()
+	received := make(chan string, 10)
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			if msg == "fail" {
+				panic(msg)
+			}
+			received <- msg
+		}
+	}).WithCleanup(func(reason interface{}) {
+		received <- "cleanup"
+		panic("cleanup failed")
+	}))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	system.Root.Send(pid, "fail")
+	system.Root.Send(pid, "after")
+	assert.Equal(t, "cleanup", <-received)
+	assert.Equal(t, "after", <-received)
+}
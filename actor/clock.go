@@ -0,0 +1,73 @@
+package actor
+
+import (
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+)
+
+// Clock is the time of an actor system: the receive timeouts, the future timeouts, the delayed sends,
+// the schedulers and the backoff strategy run on it. The tests use a manual clock to control the time.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f after d, the timer has no channel
+	AfterFunc(d time.Duration, f func()) Timer
+	// NewTimer sends the time on the channel of the timer after d
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer of a Clock, it behaves as a time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// RealClock is the system clock, the default clock of the actor systems
+type RealClock struct{}
+
+func (RealClock) Now() time.Time { return time.Now() }
+
+func (RealClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+func (RealClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// WithClock runs the timers of the actor system on clock, rather than on the system clock
+func WithClock(clock Clock) SystemOption {
+	return func(system *ActorSystem) {
+		system.Config.Clock = clock
+	}
+}
+
+// wheelClock runs a timer wheel on a Clock
+type wheelClock struct {
+	clock Clock
+}
+
+func (c wheelClock) Now() time.Time {
+	return c.clock.Now()
+}
+
+func (c wheelClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return c.clock.AfterFunc(d, f).Stop
+}
+
+// newTimerWheel returns the wheel of the timers run on clock, the shared one for the system clock
+func newTimerWheel(clock Clock) *timerwheel.Wheel {
+	if _, ok := clock.(RealClock); ok {
+		return timerwheel.Default
+	}
+	return timerwheel.New(wheelClock{clock: clock})
+}
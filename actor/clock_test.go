@@ -0,0 +1,128 @@
+package actor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tick struct{}
+
+func (*tick) NotInfluenceReceiveTimeout() {}
+
+// spawnForwarding spawns an actor forwarding its messages to the probe, but Started which it handles with started
+func spawnForwarding(system *actor.ActorSystem, probe *testkit.TestProbe, started func(ctx actor.Context)) *actor.PID {
+	return system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			started(ctx)
+		case *actor.ReceiveTimeout, *tick, string:
+			ctx.Send(probe.PID(), msg)
+		}
+	}))
+}
+
+func TestManualClock_ReceiveTimeout(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	system := actor.NewActorSystem(actor.WithClock(clock))
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	pid := spawnForwarding(system, probe, func(ctx actor.Context) {
+		ctx.SetReceiveTimeout(time.Second)
+	})
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	// the ticks do not influence the receive timeout, once one is back the actor is done with the previous messages
+	sync := func() {
+		system.Root.Send(pid, &tick{})
+		probe.ExpectMsg(t, &tick{}, 0)
+	}
+	sync()
+	clock.Advance(999 * time.Millisecond)
+	sync()
+
+	system.Root.Send(pid, "reset")
+	probe.ExpectMsg(t, "reset", 0)
+	sync()
+	clock.Advance(999 * time.Millisecond)
+	sync()
+	clock.Advance(time.Millisecond)
+	probe.ExpectMsg(t, &actor.ReceiveTimeout{}, 0)
+}
+
+func TestManualClock_FutureTimeout(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	system := actor.NewActorSystem(actor.WithClock(clock))
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+
+	future := system.Root.RequestFuture(probe.PID(), "unanswered", time.Second)
+	done := make(chan error, 1)
+	future.Observe(func(_ interface{}, err error) {
+		done <- err
+	})
+	clock.Advance(999 * time.Millisecond)
+	assert.Empty(t, done)
+	clock.Advance(time.Millisecond)
+	assert.Equal(t, actor.ErrTimeout, <-done, "the timeout fires within Advance")
+}
+
+func TestManualClock_SendLater(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	system := actor.NewActorSystem(actor.WithClock(clock))
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	pid := spawnForwarding(system, probe, func(ctx actor.Context) {
+		ctx.SendLater(probe.PID(), "later", time.Hour)
+		ctx.Send(probe.PID(), "now")
+	})
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	probe.ExpectMsg(t, "now", 0)
+	clock.Advance(time.Hour - time.Nanosecond)
+	probe.ExpectNoMsg(t, 10*time.Millisecond)
+	clock.Advance(time.Nanosecond)
+	probe.ExpectMsg(t, "later", 0)
+}
+
+func TestManualClock_BackoffStrategy(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	system := actor.NewActorSystem(actor.WithClock(clock))
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	child := actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Restarting:
+			ctx.Send(probe.PID(), "restarting")
+		case *tick:
+			panic("failed")
+		case string:
+			ctx.Send(probe.PID(), msg)
+		}
+	})
+	parent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			ctx.Spawn(child)
+		case *tick, string:
+			ctx.Forward(ctx.Children()[0])
+		}
+	}).WithSupervisor(actor.NewExponentialBackoffStrategy(time.Minute, time.Second)))
+	defer func() { _ = system.Root.StopFuture(parent).Wait() }()
+
+	// the restarts wait for the failure count times the initial backoff, and a noise below a microsecond
+	for failures := 1; failures <= 2; failures++ {
+		system.Root.Send(parent, &tick{})
+		system.Root.Send(parent, "resumed")
+		require.Eventually(t, func() bool { return clock.Pending() == 1 }, time.Second, time.Millisecond)
+		clock.Advance(time.Duration(failures)*time.Second - time.Nanosecond)
+		probe.ExpectNoMsg(t, 10*time.Millisecond)
+		clock.Advance(time.Microsecond)
+		probe.ExpectMsg(t, "restarting", 0)
+		probe.ExpectMsg(t, "resumed", 0)
+	}
+}
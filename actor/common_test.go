@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -67,18 +68,50 @@ func (m *mockContext) Respond(response interface{}) {
 	m.Called(response)
 }
 
+func (m *mockContext) RespondError(err error) {
+	m.Called(err)
+}
+
 func (m *mockContext) Stash() {
 	m.Called()
 }
 
+func (m *mockContext) Become(receive ReceiveFunc) {
+	m.Called(receive)
+}
+
+func (m *mockContext) BecomeStacked(receive ReceiveFunc) {
+	m.Called(receive)
+}
+
+func (m *mockContext) UnbecomeStacked() {
+	m.Called()
+}
+
+func (m *mockContext) BecomeStashed(receive ReceiveFunc, accept func(message interface{}) bool) {
+	m.Called(receive, accept)
+}
+
 func (m *mockContext) Watch(pid *PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) WatchWith(pid *PID, message interface{}) {
+	m.Called(pid, message)
+}
+
+func (m *mockContext) WatchWithTimeout(pid *PID, d time.Duration) {
+	m.Called(pid, d)
+}
+
 func (m *mockContext) Unwatch(pid *PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) UnwatchAll() {
+	m.Called()
+}
+
 func (m *mockContext) SetReceiveTimeout(d time.Duration) {
 	m.Called(d)
 }
@@ -95,6 +128,43 @@ func (m *mockContext) AwaitFuture(f *Future, cont func(res interface{}, err erro
 	m.Called(f, cont)
 }
 
+func (m *mockContext) StopReason() StopReason {
+	args := m.Called()
+	return args.Get(0).(StopReason)
+}
+
+func (m *mockContext) RunBlocking(run func() (interface{}, error), cont func(res interface{}, err error)) {
+	m.Called(run, cont)
+}
+
+func (m *mockContext) RunBlockingExclusive(run func() (interface{}, error), cont func(res interface{}, err error)) {
+	m.Called(run, cont)
+}
+
+func (m *mockContext) RunBlockingContext(run func(c context.Context) (interface{}, error), cont func(res interface{}, err error)) {
+	m.Called(run, cont)
+}
+
+func (m *mockContext) GoContext() context.Context {
+	args := m.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (m *mockContext) MessageGoContext() context.Context {
+	args := m.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (m *mockContext) SendLater(pid *PID, message interface{}, delay time.Duration) CancelFunc {
+	args := m.Called(pid, message, delay)
+	return args.Get(0).(CancelFunc)
+}
+
+func (m *mockContext) RequestLater(pid *PID, message interface{}, delay time.Duration) CancelFunc {
+	args := m.Called(pid, message, delay)
+	return args.Get(0).(CancelFunc)
+}
+
 //
 // Interface: SenderContext
 //
@@ -140,6 +210,16 @@ func (m *mockContext) RequestFuture(pid *PID, message interface{}, timeout time.
 	return args.Get(0).(*Future)
 }
 
+func (m *mockContext) RequestWithCorrelation(pid *PID, message interface{}, timeout time.Duration) *Future {
+	args := m.Called(pid, message, timeout)
+	return args.Get(0).(*Future)
+}
+
+func (m *mockContext) RequestAggregate(pids []*PID, message interface{}, count int, timeout time.Duration) *AggregatorFuture {
+	args := m.Called(pids, message, count, timeout)
+	return args.Get(0).(*AggregatorFuture)
+}
+
 //
 // Interface: ReceiverContext
 //
@@ -148,6 +228,23 @@ func (m *mockContext) Receive(envelope *MessageEnvelope) {
 	m.Called(envelope)
 }
 
+//
+// Interface: storage
+//
+
+func (m *mockContext) SetLocal(key *LocalKey, value interface{}) {
+	m.Called(key, value)
+}
+
+func (m *mockContext) GetLocal(key *LocalKey) (interface{}, bool) {
+	args := m.Called(key)
+	return args.Get(0), args.Bool(1)
+}
+
+func (m *mockContext) DeleteLocal(key *LocalKey) {
+	m.Called(key)
+}
+
 //
 // Interface: SpawnerContext
 //
@@ -157,6 +254,11 @@ func (m *mockContext) Spawn(p *Props) *PID {
 	return args.Get(0).(*PID)
 }
 
+func (m *mockContext) SpawnChecked(p *Props) (*PID, error) {
+	args := m.Called(p)
+	return args.Get(0).(*PID), args.Error(1)
+}
+
 func (m *mockContext) SpawnPrefix(p *Props, prefix string) *PID {
 	args := m.Called(p, prefix)
 	return args.Get(0).(*PID)
@@ -0,0 +1,176 @@
+package actor
+
+import (
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/mailbox"
+)
+
+// Config is the configuration of an actor system. Its defaults apply to the actors of the system whose props
+// do not set theirs
+type Config struct {
+	// DefaultMailboxProducer produces the mailboxes, unbounded ones when nil
+	DefaultMailboxProducer mailbox.Producer
+	// DefaultDispatcher dispatches the messages to the actors, with a throughput of 300 by default
+	DefaultDispatcher mailbox.Dispatcher
+	// DefaultSupervisorStrategy supervises the actors spawned from the root context and the children of the actors
+	// without strategy, it is DefaultSupervisorStrategy by default
+	DefaultSupervisorStrategy SupervisorStrategy
+	// MailboxStatistics measure the unbounded mailboxes produced when DefaultMailboxProducer is nil
+	MailboxStatistics []mailbox.Statistics
+	// DeadLetterThrottleCount dead letters are logged every DeadLetterThrottleInterval at most, all when zero
+	DeadLetterThrottleCount    int
+	DeadLetterThrottleInterval time.Duration
+	// Clock runs the timers of the system, they run on the timer wheel shared by the process with the RealClock
+	// and on a wheel of their own otherwise
+	Clock Clock
+	// BlockingPoolSize is the number of the calls of Context.RunBlocking run at once, the others wait for one to
+	// return. It is DefaultBlockingPoolSize by default
+	BlockingPoolSize int
+	// ResponseCheck checks the responses of the actors whose props do not set their ResponseCheck, it is off
+	// by default
+	ResponseCheck ResponseCheck
+	// MaxActors is the number of the live actors of the system above which the spawns are refused with a
+	// QuotaExceededError, they are not limited when zero
+	MaxActors int
+	// ShutdownPhaseTimeout is how long ActorSystem.Shutdown waits for the actors of a phase to stop before it stops
+	// the next phase, it is DefaultShutdownPhaseTimeout by default
+	ShutdownPhaseTimeout time.Duration
+	// EnvelopePooling recycles the envelopes of the requests once the actors processed them, see MessageEnvelope
+	// for their ownership. EnvelopePoolDebug poisons the released envelopes instead of recycling them, to catch
+	// their use after release in tests
+	EnvelopePooling   bool
+	EnvelopePoolDebug bool
+	// CopyOnSend sends deep copies of the messages to the local actors, see CopyMessage, so that the actors cannot
+	// mutate the messages their senders kept. CopyOnSendStrict panics on the messages which cannot be fully copied
+	// rather than sharing their channels and functions
+	CopyOnSend       bool
+	CopyOnSendStrict bool
+	// FullPathResponses sends the responses of the actors to the local futures as the other messages. By default the
+	// futures are completed directly by Respond, unless the actor has sender middlewares
+	FullPathResponses bool
+}
+
+// ConfigOption configures a Config
+type ConfigOption func(*Config)
+
+// NewConfig returns the default configuration of an actor system, configured with opts
+func NewConfig(opts ...ConfigOption) *Config {
+	config := &Config{
+		DefaultDispatcher:         defaultDispatcher,
+		DefaultSupervisorStrategy: defaultSupervisionStrategy,
+		Clock:                     RealClock{},
+		BlockingPoolSize:          DefaultBlockingPoolSize,
+		ResponseCheck:             ResponseCheckOff,
+		ShutdownPhaseTimeout:      DefaultShutdownPhaseTimeout,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// WithDefaultMailboxProducer produces the mailboxes of the actors with producer
+func WithDefaultMailboxProducer(producer mailbox.Producer) ConfigOption {
+	return func(config *Config) {
+		config.DefaultMailboxProducer = producer
+	}
+}
+
+// WithDefaultDispatcher dispatches the messages to the actors with dispatcher
+func WithDefaultDispatcher(dispatcher mailbox.Dispatcher) ConfigOption {
+	return func(config *Config) {
+		config.DefaultDispatcher = dispatcher
+	}
+}
+
+// WithDefaultSupervisorStrategy supervises the actors with strategy
+func WithDefaultSupervisorStrategy(strategy SupervisorStrategy) ConfigOption {
+	return func(config *Config) {
+		config.DefaultSupervisorStrategy = strategy
+	}
+}
+
+// WithMailboxStatistics measures the default mailboxes with stats
+func WithMailboxStatistics(stats ...mailbox.Statistics) ConfigOption {
+	return func(config *Config) {
+		config.MailboxStatistics = append(config.MailboxStatistics, stats...)
+	}
+}
+
+// WithBlockingPoolSize runs size calls of Context.RunBlocking at once at most
+func WithBlockingPoolSize(size int) ConfigOption {
+	return func(config *Config) {
+		config.BlockingPoolSize = size
+	}
+}
+
+// WithResponseCheck checks the responses of the actors with check, unless their props set theirs
+func WithResponseCheck(check ResponseCheck) ConfigOption {
+	return func(config *Config) {
+		config.ResponseCheck = check
+	}
+}
+
+// WithMaxActors refuses the spawns while the system has max live actors
+func WithMaxActors(max int) ConfigOption {
+	return func(config *Config) {
+		config.MaxActors = max
+	}
+}
+
+// WithShutdownPhaseTimeout waits timeout at most for the actors of each phase of the shutdown to stop
+func WithShutdownPhaseTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.ShutdownPhaseTimeout = timeout
+	}
+}
+
+// WithEnvelopePooling recycles the envelopes of the requests
+func WithEnvelopePooling() ConfigOption {
+	return func(config *Config) {
+		config.EnvelopePooling = true
+	}
+}
+
+// WithEnvelopePoolDebug pools the envelopes of the requests and poisons them once released, the envelopes used
+// after their release panic
+func WithEnvelopePoolDebug() ConfigOption {
+	return func(config *Config) {
+		config.EnvelopePooling = true
+		config.EnvelopePoolDebug = true
+	}
+}
+
+// WithCopyOnSend sends deep copies of the messages to the local actors
+func WithCopyOnSend() ConfigOption {
+	return func(config *Config) {
+		config.CopyOnSend = true
+	}
+}
+
+// WithCopyOnSendStrict sends deep copies of the messages to the local actors, and panics on the messages which
+// cannot be fully copied
+func WithCopyOnSendStrict() ConfigOption {
+	return func(config *Config) {
+		config.CopyOnSend = true
+		config.CopyOnSendStrict = true
+	}
+}
+
+// WithFullPathResponses sends the responses to the local futures as the other messages, even without sender middlewares
+func WithFullPathResponses() ConfigOption {
+	return func(config *Config) {
+		config.FullPathResponses = true
+	}
+}
+
+func (config *Config) produceMailbox() mailbox.Mailbox {
+	if config.DefaultMailboxProducer != nil {
+		return config.DefaultMailboxProducer()
+	}
+	if len(config.MailboxStatistics) > 0 {
+		return mailbox.Unbounded(config.MailboxStatistics...)()
+	}
+	return defaultMailboxProducer()
+}
@@ -0,0 +1,75 @@
+package actor_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/stretchr/testify/assert"
+)
+
+// spawnFailing spawns an actor reporting its strings to the probe, which fails on "fail"
+func spawnFailing(system *actor.ActorSystem, probe *testkit.TestProbe) *actor.PID {
+	return system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			if msg == "fail" {
+				panic(msg)
+			}
+			ctx.Send(probe.PID(), msg)
+		}
+	}))
+}
+
+func TestConfig_DefaultSupervisorStrategyPerSystem(t *testing.T) {
+	stopping := actor.NewOneForOneStrategy(10, time.Second, func(interface{}) actor.Directive {
+		return actor.StopDirective
+	})
+	stoppingSystem := actor.NewActorSystemWithConfig(actor.NewConfig(actor.WithDefaultSupervisorStrategy(stopping)))
+	restartingSystem := actor.NewActorSystem()
+
+	stoppingProbe := testkit.NewTestProbe(stoppingSystem)
+	defer stoppingProbe.Stop()
+	stopped := spawnFailing(stoppingSystem, stoppingProbe)
+	stoppingProbe.Watch(stopped)
+	restartingProbe := testkit.NewTestProbe(restartingSystem)
+	defer restartingProbe.Stop()
+	restarted := spawnFailing(restartingSystem, restartingProbe)
+	defer func() { _ = restartingSystem.Root.StopFuture(restarted).Wait() }()
+
+	stoppingSystem.Root.Send(stopped, "fail")
+	restartingSystem.Root.Send(restarted, "fail")
+	restartingSystem.Root.Send(restarted, "after restart")
+
+	stoppingProbe.ExpectTerminated(t, stopped, 0)
+	restartingProbe.ExpectMsg(t, "after restart", 0)
+}
+
+type countingStatistics struct {
+	posted int32
+}
+
+func (s *countingStatistics) MailboxStarted()             {}
+func (s *countingStatistics) MessagePosted(interface{})   { atomic.AddInt32(&s.posted, 1) }
+func (s *countingStatistics) MessageReceived(interface{}) {}
+func (s *countingStatistics) MailboxEmpty()               {}
+
+func TestConfig_MailboxStatistics(t *testing.T) {
+	stats := &countingStatistics{}
+	config := actor.NewConfig(actor.WithMailboxStatistics(stats))
+	system := actor.NewActorSystemWithConfig(config)
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	pid := spawnFailing(system, probe)
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	system.Root.Send(pid, "measured")
+	probe.ExpectMsg(t, "measured", 0)
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&stats.posted), int32(1))
+
+	// the config is copied by the system
+	config.MailboxStatistics = nil
+	assert.Len(t, system.Config.MailboxStatistics, 1)
+	assert.Len(t, actor.NewActorSystem().Config.MailboxStatistics, 0)
+}
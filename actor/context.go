@@ -1,6 +1,9 @@
 package actor
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Context contains contextual information for actors
 type Context interface {
@@ -11,6 +14,7 @@ type Context interface {
 	receiverPart
 	spawnerPart
 	stopperPart
+	storagePart
 }
 
 type SenderContext interface {
@@ -23,6 +27,7 @@ type ReceiverContext interface {
 	infoPart
 	receiverPart
 	messagePart
+	storagePart
 }
 
 type SpawnerContext interface {
@@ -47,27 +52,60 @@ type basePart interface {
 	// ReceiveTimeout returns the current timeout
 	ReceiveTimeout() time.Duration
 
+	// StopReason returns why the actor is stopping, it is meaningful from Stopping on
+	StopReason() StopReason
+
 	// Returns a slice of the actors children
 	Children() []*PID
 
-	// Respond sends a response to the to the current `Sender`
+	// Respond sends a response to the to the current `Sender`, with the correlation id of the current message
 	// If the Sender is nil, the actor will panic
 	Respond(response interface{})
 
+	// RespondError fails the request of the current `Sender` with err, its future returns an ActorError
+	RespondError(err error)
+
 	// Stash stashes the current message on a stack for reprocessing when the actor restarts
 	Stash()
 
+	// Become replaces the receive of the actor with receive, PoisonPill is still handled before it
+	Become(receive ReceiveFunc)
+
+	// BecomeStacked pushes receive on top of the current receive, UnbecomeStacked returns to the previous one
+	BecomeStacked(receive ReceiveFunc)
+
+	// UnbecomeStacked pops the current receive, the receive of the actor is back once the stack is empty
+	UnbecomeStacked()
+
+	// BecomeStashed replaces the receive of the actor with receive and stashes the user messages accept rejects,
+	// the stashed messages are processed again on the next behavior switch, before the next messages of the mailbox
+	BecomeStashed(receive ReceiveFunc, accept func(message interface{}) bool)
+
 	// Watch registers the actor as a monitor for the specified PID
 	Watch(pid *PID)
 
+	// WatchWith registers the actor as a monitor for the specified PID, the actor receives message
+	// rather than Terminated when it terminates. The registration survives the restarts of the actor
+	WatchWith(pid *PID, message interface{})
+
+	// WatchWithTimeout watches pid, and unwatches it when neither its Terminated nor a sign of life arrived within d:
+	// the actor receives WatchTimedOut rather than waiting forever, e.g. on a flaky link. The messages received from
+	// pid and its heartbeats are signs of life, each one gives it d more. The timeout does not survive the restarts of
+	// the actor, the watch does
+	WatchWithTimeout(pid *PID, d time.Duration)
+
 	// Unwatch unregisters the actor as a monitor for the specified PID
 	Unwatch(pid *PID)
 
+	// UnwatchAll unwatches the actors the actor watches, it still receives Terminated when its children stop
+	UnwatchAll()
+
 	// SetReceiveTimeout sets the inactivity timeout, after which a ReceiveTimeout message will be sent to the actor.
 	// A duration of less than 1ms will disable the inactivity timer.
 	//
 	// If a message is received before the duration d, the timer will be reset. If the message conforms to
-	// the NotInfluenceReceiveTimeout interface, the timer will not be reset
+	// the NotInfluenceReceiveTimeout interface, or is exempt by Props.WithReceiveTimeoutExemptTypes or
+	// Props.WithReceiveTimeoutExemption, the timer will not be reset
 	SetReceiveTimeout(d time.Duration)
 
 	CancelReceiveTimeout()
@@ -76,6 +114,33 @@ type basePart interface {
 	Forward(pid *PID)
 
 	AwaitFuture(f *Future, continuation func(res interface{}, err error))
+
+	// RunBlocking calls run on the blocking pool of the actor system, then invokes continuation with its result in
+	// the actor context. The actor keeps processing its messages meanwhile
+	RunBlocking(run func() (interface{}, error), continuation func(res interface{}, err error))
+
+	// RunBlockingExclusive is RunBlocking, but the actor processes no other user message until continuation returned
+	RunBlockingExclusive(run func() (interface{}, error), continuation func(res interface{}, err error))
+
+	// RunBlockingContext is RunBlocking, run receives the MessageGoContext of the current message. The continuation is
+	// not invoked once the actor stopped, the context of run is cancelled then
+	RunBlockingContext(run func(c context.Context) (interface{}, error), continuation func(res interface{}, err error))
+
+	// GoContext returns a context.Context cancelled when the actor stops, before it receives Stopping, for the
+	// libraries taking one. It is created on first use
+	GoContext() context.Context
+
+	// MessageGoContext returns the GoContext of the actor, with the deadline of the current message when it has a
+	// TTL, see MessageEnvelope.SetTTL
+	MessageGoContext() context.Context
+
+	// SendLater sends a message to the given PID after delay. It is cancelled when the actor stops first,
+	// unless the props keep the delayed sends
+	SendLater(pid *PID, message interface{}, delay time.Duration) CancelFunc
+
+	// RequestLater sends a message to the given PID after delay, with the actor as the sender.
+	// It is cancelled when the actor stops first, unless the props keep the delayed sends
+	RequestLater(pid *PID, message interface{}, delay time.Duration) CancelFunc
 }
 
 type messagePart interface {
@@ -99,8 +164,28 @@ type senderPart interface {
 	// Request sends a message to the given PID and also provides a Sender PID
 	RequestWithCustomSender(pid *PID, message interface{}, sender *PID)
 
-	// RequestFuture sends a message to a given PID and returns a Future
+	// RequestFuture sends a message to a given PID and returns a Future. The future fails with an ActorError
+	// when the actor answers with RespondError
 	RequestFuture(pid *PID, message interface{}, timeout time.Duration) *Future
+
+	// RequestWithCorrelation sends a message to the given PID with a new correlation id, and returns a Future
+	// completing with the response carrying it back
+	RequestWithCorrelation(pid *PID, message interface{}, timeout time.Duration) *Future
+
+	// RequestAggregate sends a message to each of the given PIDs with the same correlation id, and returns
+	// an AggregatorFuture collecting count responses carrying it back
+	RequestAggregate(pids []*PID, message interface{}, count int, timeout time.Duration) *AggregatorFuture
+}
+
+type storagePart interface {
+	// SetLocal stores value under key in the actor, see LocalKey for how long it is kept
+	SetLocal(key *LocalKey, value interface{})
+
+	// GetLocal returns the value stored under key in the actor
+	GetLocal(key *LocalKey) (interface{}, bool)
+
+	// DeleteLocal removes the value stored under key in the actor
+	DeleteLocal(key *LocalKey)
 }
 
 type receiverPart interface {
@@ -111,6 +196,10 @@ type spawnerPart interface {
 	// Spawn starts a new child actor based on props and named with a unique id
 	Spawn(props *Props) *PID
 
+	// SpawnChecked starts a new child actor based on props and named with a unique id, it returns the error Spawn
+	// panics with, such as ErrTooManyChildren or a QuotaExceededError
+	SpawnChecked(props *Props) (*PID, error)
+
 	// SpawnPrefix starts a new child actor based on props and named using a prefix followed by a unique id
 	SpawnPrefix(props *Props, prefix string) *PID
 
@@ -0,0 +1,232 @@
+package actor_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+)
+
+// spawnReporting spawns an actor reporting its strings and ints to the probe, after handling them with receive
+func spawnReporting(system *actor.ActorSystem, probe *testkit.TestProbe, receive actor.ReceiveFunc) *actor.PID {
+	return system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case string, int:
+			ctx.Send(probe.PID(), msg)
+			receive(ctx)
+		}
+	}))
+}
+
+func TestContext_BecomeStacked(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	var stacked actor.ReceiveFunc
+	stacked = func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			if msg == "pop" {
+				ctx.UnbecomeStacked()
+				return
+			}
+			if msg == "push" {
+				ctx.BecomeStacked(stacked)
+			}
+			ctx.Send(probe.PID(), "stacked "+msg)
+		}
+	}
+	pid := spawnReporting(system, probe, func(ctx actor.Context) {
+		if ctx.Message() == "push" {
+			ctx.BecomeStacked(stacked)
+		}
+	})
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	for _, msg := range []string{"push", "a", "push", "pop", "b", "pop", "c"} {
+		system.Root.Send(pid, msg)
+	}
+	for _, expected := range []string{"push", "stacked a", "stacked push", "stacked b", "c"} {
+		probe.ExpectMsg(t, expected, 0)
+	}
+}
+
+func TestContext_BecomeStashed(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	var initial actor.ReceiveFunc
+	ints := func(ctx actor.Context) {
+		if n, ok := ctx.Message().(int); ok {
+			ctx.Send(probe.PID(), n)
+			if n == 0 {
+				ctx.Become(initial)
+			}
+		}
+	}
+	initial = func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Send(probe.PID(), msg)
+			if msg == "stash" {
+				ctx.BecomeStashed(ints, func(message interface{}) bool {
+					_, ok := message.(int)
+					return ok
+				})
+			}
+		}
+	}
+	pid := system.Root.Spawn(actor.PropsFromFunc(initial))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	// the strings stashed are processed after the switch, in their order and before the ones still in the mailbox
+	for _, msg := range []interface{}{"stash", "a", "b", 1, "c", 0, "d"} {
+		system.Root.Send(pid, msg)
+	}
+	for _, expected := range []interface{}{"stash", 1, 0, "a", "b", "c", "d"} {
+		probe.ExpectMsg(t, expected, 0)
+	}
+}
+
+func TestContext_RestartResetsBehavior(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	failing := func(ctx actor.Context) {
+		if ctx.Message() == "boom" {
+			panic("boom")
+		}
+	}
+	pid := spawnReporting(system, probe, func(ctx actor.Context) {
+		if ctx.Message() == "become" {
+			ctx.BecomeStashed(failing, func(message interface{}) bool {
+				return message == "boom"
+			})
+		}
+	})
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	// the restarted actor receives with its own receive again, the messages stashed included
+	for _, msg := range []string{"become", "x", "boom", "y"} {
+		system.Root.Send(pid, msg)
+	}
+	for _, expected := range []string{"become", "x", "y"} {
+		probe.ExpectMsg(t, expected, 0)
+	}
+}
+
+type (
+	placeOrder struct {
+		ID     string
+		Amount int
+	}
+	payOrder struct {
+		ID     string
+		Amount int
+	}
+	shipOrder   struct{ ID string }
+	cancelOrder struct{ ID string }
+	pause       struct{}
+	resume      struct{}
+)
+
+// orderProcessor processes one order at a time: it is placed, paid then shipped, or cancelled before the payment.
+// The orders placed meanwhile wait for the current one, and none is accepted while paused
+type orderProcessor struct {
+	events *actor.PID
+	order  *placeOrder
+}
+
+func (p *orderProcessor) Receive(ctx actor.Context) {
+	p.idle(ctx)
+}
+
+func (p *orderProcessor) report(ctx actor.Context, format string, args ...interface{}) {
+	ctx.Send(p.events, fmt.Sprintf(format, args...))
+}
+
+func (p *orderProcessor) idle(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *placeOrder:
+		p.order = msg
+		p.report(ctx, "placed %s", msg.ID)
+		ctx.BecomeStashed(p.awaitingPayment, func(message interface{}) bool {
+			switch message.(type) {
+			case *payOrder, *cancelOrder:
+				return true
+			}
+			return false
+		})
+	case *pause:
+		ctx.BecomeStacked(p.paused)
+	}
+}
+
+func (p *orderProcessor) awaitingPayment(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *payOrder:
+		if msg.Amount < p.order.Amount {
+			p.report(ctx, "insufficient %s", msg.ID)
+			return
+		}
+		p.report(ctx, "paid %s", msg.ID)
+		ctx.BecomeStashed(p.shipping, func(message interface{}) bool {
+			_, ok := message.(*shipOrder)
+			return ok
+		})
+	case *cancelOrder:
+		p.report(ctx, "cancelled %s", msg.ID)
+		ctx.Become(p.idle)
+	}
+}
+
+func (p *orderProcessor) shipping(ctx actor.Context) {
+	if msg, ok := ctx.Message().(*shipOrder); ok {
+		p.report(ctx, "shipped %s", msg.ID)
+		p.order = nil
+		ctx.Become(p.idle)
+	}
+}
+
+func (p *orderProcessor) paused(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *placeOrder:
+		p.report(ctx, "rejected %s", msg.ID)
+	case *resume:
+		ctx.UnbecomeStacked()
+	}
+}
+
+func TestExample_OrderProcessingFSM(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	pid := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return &orderProcessor{events: probe.PID()}
+	}))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	for _, msg := range []interface{}{
+		&placeOrder{ID: "o1", Amount: 10},
+		&placeOrder{ID: "o2", Amount: 5},
+		&payOrder{ID: "o1", Amount: 5},
+		&payOrder{ID: "o1", Amount: 10},
+		&shipOrder{ID: "o1"},
+		&cancelOrder{ID: "o2"},
+		&pause{},
+		&placeOrder{ID: "o3", Amount: 1},
+		&resume{},
+		&placeOrder{ID: "o4", Amount: 1},
+	} {
+		system.Root.Send(pid, msg)
+	}
+	for _, expected := range []string{
+		"placed o1", "insufficient o1", "paid o1", "shipped o1",
+		"placed o2", "cancelled o2",
+		"rejected o3",
+		"placed o4",
+	} {
+		probe.ExpectMsg(t, expected, 0)
+	}
+	probe.ExpectNoMsg(t, 0)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
 )
 
 type setReceiveTimeoutActor struct {
@@ -33,11 +34,18 @@ func ExampleContext_setReceiveTimeout() {
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 
+	// the time of the actor system is advanced manually rather than waited for
+	clock := testkit.NewManualClock(time.Now())
+	system := actor.NewActorSystem(actor.WithClock(clock))
 	pid := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return &setReceiveTimeoutActor{wg} }))
 	defer func() {
 		_ = system.Root.StopFuture(pid).Wait()
 	}()
+	for clock.Pending() == 0 {
+		time.Sleep(time.Millisecond) // wait for the actor to set its receive timeout
+	}
 
+	clock.Advance(10 * time.Millisecond)
 	wg.Wait() // wait for the ReceiveTimeout message
 
 	// Output: timed out
@@ -0,0 +1,158 @@
+package actor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// CorrelationIDHeader is the header of a correlated request, Respond carries it back with the response
+const CorrelationIDHeader = "correlation-id"
+
+// CorrelatedResponse is a response collected by an AggregatorFuture
+type CorrelatedResponse struct {
+	// Sender is the responder, when it responded with Respond
+	Sender  *PID
+	Message interface{}
+}
+
+// AggregatorFuture collects the responses to a request sent to several responders, e.g. through a broadcast router.
+// The responses are matched on the correlation id of the request, the other messages are sent to the dead letters
+type AggregatorFuture struct {
+	actorSystem   *ActorSystem
+	pid           *PID
+	correlationID string
+	count         int
+
+	mu        sync.Mutex
+	responses []*CorrelatedResponse
+	err       error
+	t         Timer
+	done      chan struct{}
+}
+
+// NewAggregatorFuture creates an AggregatorFuture completing once it collected count responses,
+// or with ErrTimeout after d when d is not negative
+func NewAggregatorFuture(actorSystem *ActorSystem, count int, d time.Duration) *AggregatorFuture {
+	f := &AggregatorFuture{actorSystem: actorSystem, count: count, done: make(chan struct{})}
+	pid, ok := actorSystem.ProcessRegistry.Add(f, "aggregator"+actorSystem.ProcessRegistry.NextId())
+	if !ok {
+		plog.Error("failed to register aggregator future process", log.Stringer("pid", pid))
+	}
+	f.pid = pid
+	f.correlationID = pid.Id
+	if count <= 0 {
+		f.complete(nil)
+		return f
+	}
+	if d >= 0 {
+		t := actorSystem.Config.Clock.AfterFunc(d, func() {
+			if f.complete(ErrTimeout) {
+				actorSystem.SystemEventStream.Publish(&FutureTimeoutEvent{PID: pid})
+			}
+		})
+		f.mu.Lock()
+		f.t = t
+		f.mu.Unlock()
+	}
+	return f
+}
+
+// PID to the backing process of the future, the responses are sent to it
+func (f *AggregatorFuture) PID() *PID {
+	return f.pid
+}
+
+// CorrelationID is the id of the request whose responses are collected
+func (f *AggregatorFuture) CorrelationID() string {
+	return f.correlationID
+}
+
+// Result waits for the future to complete, and returns the responses collected in their order of arrival.
+// The responses collected until then are returned along with ErrTimeout
+func (f *AggregatorFuture) Result() ([]*CorrelatedResponse, error) {
+	<-f.done
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.responses, f.err
+}
+
+// Wait waits for the future to complete
+func (f *AggregatorFuture) Wait() error {
+	_, err := f.Result()
+	return err
+}
+
+func (f *AggregatorFuture) SendUserMessage(pid *PID, message interface{}) {
+	header, msg, sender := UnwrapEnvelope(message)
+	if !correlated(header, f.correlationID) {
+		f.actorSystem.DeadLetter.SendUserMessage(pid, message)
+		return
+	}
+	f.mu.Lock()
+	if f.isDone() {
+		f.mu.Unlock()
+		f.actorSystem.DeadLetter.SendUserMessage(pid, message)
+		return
+	}
+	f.responses = append(f.responses, &CorrelatedResponse{Sender: sender, Message: msg})
+	full := len(f.responses) >= f.count
+	f.mu.Unlock()
+	if full {
+		f.complete(nil)
+	}
+}
+
+func (f *AggregatorFuture) SendSystemMessage(*PID, interface{}) {}
+
+func (f *AggregatorFuture) Stop(*PID) {
+	f.complete(nil)
+}
+
+// complete completes the future with err, it returns false when it was already completed
+func (f *AggregatorFuture) complete(err error) bool {
+	f.mu.Lock()
+	if f.isDone() {
+		f.mu.Unlock()
+		return false
+	}
+	f.err = err
+	if f.t != nil {
+		f.t.Stop()
+	}
+	close(f.done)
+	f.mu.Unlock()
+	f.actorSystem.ProcessRegistry.Remove(f.pid)
+	return true
+}
+
+func (f *AggregatorFuture) isDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// correlated tells if header carries the correlation id
+func correlated(header ReadonlyMessageHeader, correlationID string) bool {
+	return header != nil && header.Get(CorrelationIDHeader) == correlationID
+}
+
+// correlatedEnvelope envelops a request with its correlation id
+func correlatedEnvelope(message interface{}, correlationID string, sender *PID) *MessageEnvelope {
+	return &MessageEnvelope{
+		Header:  messageHeader{CorrelationIDHeader: correlationID},
+		Message: message,
+		Sender:  sender,
+	}
+}
+
+// newCorrelatedFuture creates a Future completing with the first response carrying its correlation id
+func newCorrelatedFuture(actorSystem *ActorSystem, d time.Duration) *Future {
+	future := NewFuture(actorSystem, d)
+	future.correlationID = future.pid.Id
+	return future
+}
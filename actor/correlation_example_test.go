@@ -0,0 +1,45 @@
+package actor_test
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/router"
+)
+
+type query struct{ Q string }
+
+// The coordinator broadcasts a query to five responders, one of them does not answer in time:
+// the aggregator collects the four answers and then times out
+func ExampleRootContext_RequestAggregate() {
+	var responders []*actor.PID
+	for i := 1; i <= 5; i++ {
+		i := i
+		responders = append(responders, system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+			if msg, ok := ctx.Message().(*query); ok && i != 5 {
+				ctx.Respond(fmt.Sprintf("%s from %d", msg.Q, i))
+			}
+		})))
+	}
+	broadcast := system.Root.Spawn(router.NewBroadcastGroup(responders...))
+
+	responses, err := system.Root.RequestAggregate([]*actor.PID{broadcast}, &query{Q: "ping"}, len(responders), 50*time.Millisecond).Result()
+	var answers []string
+	for _, response := range responses {
+		answers = append(answers, response.Message.(string))
+	}
+	sort.Strings(answers)
+	for _, answer := range answers {
+		fmt.Println(answer)
+	}
+	fmt.Println(err)
+
+	// Output:
+	// ping from 1
+	// ping from 2
+	// ping from 3
+	// ping from 4
+	// future: timeout
+}
@@ -0,0 +1,93 @@
+package actor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spawnResponder spawns an actor sending "noise" to the sender of its strings before responding with them
+func spawnResponder(system *actor.ActorSystem) *actor.PID {
+	return system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Send(ctx.Sender(), "noise")
+			ctx.Respond(msg)
+		}
+	}))
+}
+
+func TestRequestWithCorrelation_MatchesResponse(t *testing.T) {
+	system := actor.NewActorSystem()
+	pid := spawnResponder(system)
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+	deadLetters := testkit.NewTestProbe(system)
+	defer deadLetters.Stop()
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if deadLetter, ok := evt.(*actor.DeadLetterEvent); ok {
+			system.Root.Send(deadLetters.PID(), deadLetter.Message)
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	res, err := system.Root.RequestWithCorrelation(pid, "hello", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res)
+	deadLetters.ExpectMsg(t, "noise", 0)
+
+	// the uncorrelated future takes the first message
+	res, err = system.Root.RequestFuture(pid, "hello", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "noise", res)
+}
+
+func TestRequestAggregate(t *testing.T) {
+	system := actor.NewActorSystem()
+	first, second := spawnResponder(system), spawnResponder(system)
+	defer func() { _ = system.Root.StopFuture(first).Wait() }()
+	defer func() { _ = system.Root.StopFuture(second).Wait() }()
+
+	future := system.Root.RequestAggregate([]*actor.PID{first, second}, "hello", 2, time.Second)
+	responses, err := future.Result()
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	var senders []string
+	for _, response := range responses {
+		assert.Equal(t, "hello", response.Message)
+		senders = append(senders, response.Sender.Id)
+	}
+	assert.ElementsMatch(t, []string{first.Id, second.Id}, senders)
+	assert.NotEmpty(t, future.CorrelationID())
+}
+
+func TestRequestAggregate_FromActor(t *testing.T) {
+	system := actor.NewActorSystem()
+	responder := spawnResponder(system)
+	defer func() { _ = system.Root.StopFuture(responder).Wait() }()
+	results := make(chan []*actor.CorrelatedResponse, 1)
+	coordinator := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			responses, _ := ctx.RequestAggregate([]*actor.PID{responder, responder}, "hi", 2, time.Second).Result()
+			results <- responses
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(coordinator).Wait() }()
+
+	assert.Len(t, <-results, 2)
+}
+
+func TestAggregatorFuture_Timeout(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	system := actor.NewActorSystem(actor.WithClock(clock))
+	responder := spawnResponder(system)
+	defer func() { _ = system.Root.StopFuture(responder).Wait() }()
+
+	future := system.Root.RequestAggregate([]*actor.PID{responder}, "hello", 3, time.Second)
+	clock.Advance(time.Second)
+	responses, err := future.Result()
+	assert.Equal(t, actor.ErrTimeout, err)
+	assert.LessOrEqual(t, len(responses), 1)
+}
@@ -1,7 +1,9 @@
 package actor
 
 import (
-	"github.com/AsynkronIT/protoactor-go/log"
+	"errors"
+	"fmt"
+	"sync/atomic"
 )
 
 type deadLetterProcess struct {
@@ -15,20 +17,25 @@ func NewDeadLetter(actorSystem *ActorSystem) *deadLetterProcess {
 	}
 
 	actorSystem.ProcessRegistry.Add(dp, "deadletter")
-	_ = actorSystem.EventStream.Subscribe(func(msg interface{}) {
-		if deadLetter, ok := msg.(*DeadLetterEvent); ok {
-			plog.Debug("[DeadLetter]", log.Stringer("pid", deadLetter.PID), log.Message(deadLetter.Message), log.Stringer("sender", deadLetter.Sender))
+	// dead letters are published on the hot path of the senders, the throttled ones are logged asynchronously
+	throttle := newDeadLetterThrottle(actorSystem.Config.DeadLetterThrottleCount, actorSystem.Config.DeadLetterThrottleInterval, actorSystem.eventLog)
+	actorSystem.eventLog.subscribe(func(msg interface{}) {
+		if actorSystem.eventLog.enabled() {
+			throttle.handle(msg.(*DeadLetterEvent))
 		}
+	}).WithPredicate(func(msg interface{}) bool {
+		_, ok := msg.(*DeadLetterEvent)
+		return ok
 	})
 
 	// this subscriber may not be deactivated.
 	// it ensures that Watch commands that reach a stopped actor gets a Terminated message back.
 	// This can happen if one actor tries to Watch a PID, while another thread sends a Stop message.
-	actorSystem.EventStream.Subscribe(func(msg interface{}) {
+	actorSystem.SystemEventStream.Subscribe(func(msg interface{}) {
 		if deadLetter, ok := msg.(*DeadLetterEvent); ok {
 			if m, ok := deadLetter.Message.(*Watch); ok {
 				// we know that this is a local actor since we get it on our own event stream, thus the address is not terminated
-				m.Watcher.sendSystemMessage(actorSystem, &Terminated{AddressTerminated: false, Who: deadLetter.PID})
+				m.Watcher.sendSystemMessage(actorSystem, &Terminated{AddressTerminated: false, Who: deadLetter.PID, Why: TerminatedReason_NotFound})
 			}
 		}
 	})
@@ -36,24 +43,120 @@ func NewDeadLetter(actorSystem *ActorSystem) *deadLetterProcess {
 	return dp
 }
 
+// DeadLetterReason tells why a message was not delivered
+type DeadLetterReason int
+
+const (
+	// DeadLetterUndeliverable is the reason of the messages sent to a nonexistent PID
+	DeadLetterUndeliverable DeadLetterReason = iota
+	// DeadLetterExpired is the reason of the messages whose TTL expired before they were received
+	DeadLetterExpired
+	// DeadLetterRateLimited is the reason of the messages rejected by a rate limit
+	DeadLetterRateLimited
+	// DeadLetterCircuitOpen is the reason of the messages short-circuited by an open circuit breaker
+	DeadLetterCircuitOpen
+	// DeadLetterStoppedBeforeProcessing is the reason of the messages left in the mailbox of an actor that stopped,
+	// see Props.WithStopDraining
+	DeadLetterStoppedBeforeProcessing
+	// DeadLetterTooLarge is the reason of the messages rejected by a size guard
+	DeadLetterTooLarge
+	// DeadLetterPoisonMessage is the reason of the messages that failed their actor after being redelivered as many
+	// times as allowed, see RestartWithRedeliveryDirective
+	DeadLetterPoisonMessage
+	// DeadLetterMailboxFull is the reason of the messages rejected by a bounded mailbox, see mailbox.Fair
+	DeadLetterMailboxFull
+	// DeadLetterRejected is the reason of the messages a receiver middleware rejected as invalid
+	DeadLetterRejected
+	// DeadLetterNotAcknowledged is the reason of the messages sent to a remote endpoint with an acknowledgment
+	// which did not come after as many attempts as allowed
+	DeadLetterNotAcknowledged
+)
+
+func (r DeadLetterReason) String() string {
+	switch r {
+	case DeadLetterExpired:
+		return "Expired"
+	case DeadLetterRateLimited:
+		return "RateLimited"
+	case DeadLetterCircuitOpen:
+		return "CircuitOpen"
+	case DeadLetterStoppedBeforeProcessing:
+		return "StoppedBeforeProcessing"
+	case DeadLetterTooLarge:
+		return "TooLarge"
+	case DeadLetterPoisonMessage:
+		return "PoisonMessage"
+	case DeadLetterMailboxFull:
+		return "MailboxFull"
+	case DeadLetterRejected:
+		return "Rejected"
+	case DeadLetterNotAcknowledged:
+		return "NotAcknowledged"
+	}
+	return "Undeliverable"
+}
+
 // A DeadLetterEvent is published via event.Publish when a message is sent to a nonexistent PID
 type DeadLetterEvent struct {
-	PID     *PID        // The invalid process, to which the message was sent
-	Message interface{} // The message that could not be delivered
-	Sender  *PID        // the process that sent the Message
+	PID     *PID                  // The invalid process, to which the message was sent
+	Message interface{}           // The message that could not be delivered
+	Sender  *PID                  // the process that sent the Message
+	Reason  DeadLetterReason      // why the Message was not delivered
+	Header  ReadonlyMessageHeader // the header of the Message, nil or empty when it had none
+
+	redelivered int32
+}
+
+// Redeliver tells that a subscriber delivers the message again, the future of its sender is not failed with a
+// DeadLetterError then. It is called from a synchronous subscriber
+func (e *DeadLetterEvent) Redeliver() {
+	atomic.StoreInt32(&e.redelivered, 1)
+}
+
+// ErrDeadLetter is the error of the futures whose request was dead-lettered, see DeadLetterError
+var ErrDeadLetter = errors.New("future: dead letter")
+
+// DeadLetterError fails the local futures whose request to PID was dead-lettered because PID does not exist or
+// stopped before processing it, as soon as it was rather than letting them time out. It is ErrDeadLetter
+type DeadLetterError struct {
+	PID    *PID
+	Reason DeadLetterReason
+}
+
+func (e *DeadLetterError) Error() string {
+	return fmt.Sprintf("future: request to %v dead-lettered, %v", e.PID, e.Reason)
+}
+
+func (e *DeadLetterError) Is(target error) bool {
+	return target == ErrDeadLetter
 }
 
 func (dp *deadLetterProcess) SendUserMessage(pid *PID, message interface{}) {
-	_, msg, sender := UnwrapEnvelope(message)
-	dp.actorSystem.EventStream.Publish(&DeadLetterEvent{
+	dp.SendUserMessageWithReason(pid, message, DeadLetterUndeliverable)
+}
+
+// SendUserMessageWithReason publishes the message to pid which was not delivered for reason
+func (dp *deadLetterProcess) SendUserMessageWithReason(pid *PID, message interface{}, reason DeadLetterReason) {
+	header, msg, sender := UnwrapEnvelope(message)
+	event := &DeadLetterEvent{
 		PID:     pid,
 		Message: msg,
 		Sender:  sender,
-	})
+		Reason:  reason,
+		Header:  header,
+	}
+	dp.actorSystem.SystemEventStream.Publish(event)
+	redelivered := atomic.LoadInt32(&event.redelivered) == 1
+	if !redelivered && (reason == DeadLetterUndeliverable || reason == DeadLetterStoppedBeforeProcessing) {
+		// no one answers the request, whereas the senders rejecting it answer with their error
+		if future, ok := FutureOf(dp.actorSystem, sender); ok {
+			future.Fail(&DeadLetterError{PID: pid, Reason: reason})
+		}
+	}
 }
 
 func (dp *deadLetterProcess) SendSystemMessage(pid *PID, message interface{}) {
-	dp.actorSystem.EventStream.Publish(&DeadLetterEvent{
+	dp.actorSystem.SystemEventStream.Publish(&DeadLetterEvent{
 		PID:     pid,
 		Message: message,
 	})
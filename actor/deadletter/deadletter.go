@@ -0,0 +1,229 @@
+// Package deadletter keeps the last dead letters of an actor system, to inspect them and requeue them
+// to their target once it is back
+package deadletter
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+)
+
+// Name is the name of the management actor, to reach it on a remote node
+const Name = "deadletters"
+
+// ErrNotFound is returned when requeuing an entry which is not kept, or not anymore
+var ErrNotFound = errors.New("deadletter: entry not found")
+
+// Entry is a dead letter kept by the store
+type Entry struct {
+	ID         uint64                 `json:"id"`
+	Target     *actor.PID             `json:"target"`
+	Message    interface{}            `json:"message"`
+	Header     map[string]string      `json:"header,omitempty"`
+	Sender     *actor.PID             `json:"sender,omitempty"`
+	Reason     actor.DeadLetterReason `json:"reason"`
+	CapturedAt time.Time              `json:"capturedAt"`
+}
+
+type config struct {
+	capacity int
+	ttl      time.Duration
+	reasons  map[actor.DeadLetterReason]bool
+	target   func(pid *actor.PID) bool
+}
+
+// Option configures a Store
+type Option func(*config)
+
+// WithCapacity keeps the last n dead letters, 1000 by default
+func WithCapacity(n int) Option {
+	return func(c *config) {
+		c.capacity = n
+	}
+}
+
+// WithTTL forgets the dead letters kept longer than ttl, they are kept until evicted by default
+func WithTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.ttl = ttl
+	}
+}
+
+// WithReasons only keeps the dead letters of the reasons, all are kept by default
+func WithReasons(reasons ...actor.DeadLetterReason) Option {
+	return func(c *config) {
+		c.reasons = make(map[actor.DeadLetterReason]bool, len(reasons))
+		for _, reason := range reasons {
+			c.reasons[reason] = true
+		}
+	}
+}
+
+// WithTargetMatching only keeps the dead letters whose target matches
+func WithTargetMatching(match func(pid *actor.PID) bool) Option {
+	return func(c *config) {
+		c.target = match
+	}
+}
+
+// Store keeps the last dead letters of an actor system, the system messages excluded
+type Store struct {
+	system  *actor.ActorSystem
+	config  config
+	sub     *eventstream.Subscription
+	mu      sync.Mutex
+	entries []*Entry // oldest first
+	nextID  uint64
+}
+
+// New starts keeping the dead letters of system, until closed
+func New(system *actor.ActorSystem, opts ...Option) *Store {
+	s := &Store{
+		system: system,
+		config: config{capacity: 1000},
+	}
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+	s.sub = system.SystemEventStream.Subscribe(func(evt interface{}) {
+		s.capture(evt.(*actor.DeadLetterEvent))
+	}).WithPredicate(func(evt interface{}) bool {
+		deadLetter, ok := evt.(*actor.DeadLetterEvent)
+		return ok && s.accepts(deadLetter)
+	})
+	return s
+}
+
+// Close stops keeping the dead letters, the ones kept can still be listed and requeued
+func (s *Store) Close() {
+	s.system.SystemEventStream.Unsubscribe(s.sub)
+}
+
+func (s *Store) accepts(deadLetter *actor.DeadLetterEvent) bool {
+	if _, ok := deadLetter.Message.(actor.SystemMessage); ok {
+		return false
+	}
+	if s.config.reasons != nil && !s.config.reasons[deadLetter.Reason] {
+		return false
+	}
+	return s.config.target == nil || s.config.target(deadLetter.PID)
+}
+
+func (s *Store) capture(deadLetter *actor.DeadLetterEvent) {
+	if s.config.capacity <= 0 {
+		return
+	}
+	entry := &Entry{
+		Target:     deadLetter.PID,
+		Message:    deadLetter.Message,
+		Sender:     deadLetter.Sender,
+		Reason:     deadLetter.Reason,
+		CapturedAt: s.system.Clock().Now(),
+	}
+	if deadLetter.Header != nil && deadLetter.Header.Length() > 0 {
+		entry.Header = deadLetter.Header.ToMap()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	entry.ID = s.nextID
+	s.expire()
+	if len(s.entries) >= s.config.capacity {
+		s.entries = append(s.entries[:0], s.entries[len(s.entries)-s.config.capacity+1:]...)
+	}
+	s.entries = append(s.entries, entry)
+}
+
+// expire forgets the entries older than the TTL, the lock held
+func (s *Store) expire() {
+	if s.config.ttl <= 0 {
+		return
+	}
+	deadline := s.system.Clock().Now().Add(-s.config.ttl)
+	i := 0
+	for i < len(s.entries) && s.entries[i].CapturedAt.Before(deadline) {
+		i++
+	}
+	if i > 0 {
+		s.entries = append(s.entries[:0], s.entries[i:]...)
+	}
+}
+
+// ListDeadLetters returns the dead letters kept, the oldest first
+func (s *Store) ListDeadLetters() []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	entries := make([]*Entry, len(s.entries))
+	copy(entries, s.entries)
+	return entries
+}
+
+// Requeue sends the dead letter of id again to its target, with its header and sender, and forgets it.
+// The target is resolved again, to reach the actor respawned under the same name
+func (s *Store) Requeue(id uint64) error {
+	s.mu.Lock()
+	s.expire()
+	var entry *Entry
+	for i, e := range s.entries {
+		if e.ID == id {
+			entry = e
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+	if entry == nil {
+		return ErrNotFound
+	}
+
+	s.system.Root.Send(actor.NewPID(entry.Target.Address, entry.Target.Id), &actor.MessageEnvelope{
+		Header:  entry.Header,
+		Message: entry.Message,
+		Sender:  entry.Sender,
+	})
+	return nil
+}
+
+// ListRequest asks the management actor for a ListResponse
+type ListRequest struct{}
+
+// ListResponse lists the dead letters kept, the oldest first
+type ListResponse struct {
+	Entries []*Entry `json:"entries"`
+}
+
+// RequeueRequest asks the management actor to requeue the dead letter of ID
+type RequeueRequest struct {
+	ID uint64 `json:"id"`
+}
+
+// RequeueResponse answers a RequeueRequest, Error is empty when requeued
+type RequeueResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Spawn spawns the management actor of the store
+func Spawn(system *actor.ActorSystem, store *Store) (*actor.PID, error) {
+	return system.Root.SpawnNamed(Props(store), Name)
+}
+
+// Props are the props of the management actor of the store
+func Props(store *Store) *actor.Props {
+	return actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *ListRequest:
+			ctx.Respond(&ListResponse{Entries: store.ListDeadLetters()})
+		case *RequeueRequest:
+			res := &RequeueResponse{}
+			if err := store.Requeue(msg.ID); err != nil {
+				res.Error = err.Error()
+			}
+			ctx.Respond(res)
+		}
+	})
+}
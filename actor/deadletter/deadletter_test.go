@@ -0,0 +1,116 @@
+package deadletter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spawnWorker spawns the worker named name, reporting its strings with their tenant header to the probe
+func spawnWorker(t *testing.T, system *actor.ActorSystem, probe *testkit.TestProbe, name string) *actor.PID {
+	pid, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Send(probe.PID(), msg+"@"+ctx.MessageHeader().Get("tenant"))
+		}
+	}), name)
+	require.NoError(t, err)
+	return pid
+}
+
+func TestStore_RequeueToRespawnedActor(t *testing.T) {
+	system := actor.NewActorSystem()
+	store := New(system)
+	defer store.Close()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+
+	pid := spawnWorker(t, system, probe, "worker")
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+
+	for _, msg := range []string{"a", "b"} {
+		system.Root.Send(pid, &actor.MessageEnvelope{Header: map[string]string{"tenant": "acme"}, Message: msg})
+	}
+	entries := store.ListDeadLetters()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].Message)
+	assert.Equal(t, map[string]string{"tenant": "acme"}, entries[0].Header)
+	assert.Equal(t, pid, entries[1].Target)
+
+	respawned := spawnWorker(t, system, probe, "worker")
+	defer func() { _ = system.Root.StopFuture(respawned).Wait() }()
+	for _, entry := range entries {
+		require.NoError(t, store.Requeue(entry.ID))
+	}
+	probe.ExpectMsg(t, "a@acme", 0)
+	probe.ExpectMsg(t, "b@acme", 0)
+	assert.Empty(t, store.ListDeadLetters())
+	assert.Equal(t, ErrNotFound, store.Requeue(entries[0].ID))
+}
+
+func TestStore_RetentionAndFilters(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	system := actor.NewActorSystem(actor.WithClock(clock))
+	store := New(system,
+		WithCapacity(2),
+		WithTTL(time.Minute),
+		WithTargetMatching(func(pid *actor.PID) bool { return pid.Id != "ignored" }))
+	defer store.Close()
+
+	target := system.NewLocalPID("gone")
+	for _, msg := range []string{"a", "b", "c"} {
+		system.Root.Send(target, msg)
+	}
+	system.Root.Send(system.NewLocalPID("ignored"), "d")
+	entries := store.ListDeadLetters()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "b", entries[0].Message)
+	assert.Equal(t, "c", entries[1].Message)
+
+	clock.Advance(30 * time.Second)
+	system.Root.Send(target, "e")
+	clock.Advance(31 * time.Second)
+	entries = store.ListDeadLetters()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "e", entries[0].Message)
+}
+
+func TestStore_Reasons(t *testing.T) {
+	system := actor.NewActorSystem()
+	store := New(system, WithReasons(actor.DeadLetterExpired))
+	defer store.Close()
+
+	system.Root.Send(system.NewLocalPID("gone"), "undeliverable")
+	assert.Empty(t, store.ListDeadLetters())
+}
+
+func TestManagementActor(t *testing.T) {
+	system := actor.NewActorSystem()
+	store := New(system)
+	defer store.Close()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	pid, err := Spawn(system, store)
+	require.NoError(t, err)
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	system.Root.Send(system.NewLocalPID("worker"), "a")
+	res, err := system.Root.RequestFuture(pid, &ListRequest{}, time.Second).Result()
+	require.NoError(t, err)
+	entries := res.(*ListResponse).Entries
+	require.Len(t, entries, 1)
+
+	worker := spawnWorker(t, system, probe, "worker")
+	defer func() { _ = system.Root.StopFuture(worker).Wait() }()
+	res, err = system.Root.RequestFuture(pid, &RequeueRequest{ID: entries[0].ID}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, &RequeueResponse{}, res)
+	probe.ExpectMsg(t, "a@", 0)
+
+	res, err = system.Root.RequestFuture(pid, &RequeueRequest{ID: entries[0].ID}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, &RequeueResponse{Error: ErrNotFound.Error()}, res)
+}
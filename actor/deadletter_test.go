@@ -1,9 +1,14 @@
 package actor
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/AsynkronIT/protoactor-go/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeadLetterAfterStop(t *testing.T) {
@@ -35,3 +40,80 @@ func TestDeadLetterWatchRespondsWithTerminate(t *testing.T) {
 	pid.sendSystemMessage(system, &Watch{Watcher: f.PID()})
 	assertFutureSuccess(f, t)
 }
+
+func TestDeadLetterPublishedToSystemEventStream(t *testing.T) {
+	systemEvents := eventstream.NewEventStream()
+	actorSystem := NewActorSystem(WithSystemEventStream(systemEvents))
+	var onSystem, onApplication int
+	systemEvents.Subscribe(func(msg interface{}) {
+		if _, ok := msg.(*DeadLetterEvent); ok {
+			onSystem++
+		}
+	})
+	actorSystem.EventStream.Subscribe(func(msg interface{}) {
+		if _, ok := msg.(*DeadLetterEvent); ok {
+			onApplication++
+		}
+	})
+
+	pid := actorSystem.Root.Spawn(PropsFromProducer(NewBlackHoleActor))
+	_ = actorSystem.Root.StopFuture(pid).Wait()
+	actorSystem.Root.Send(pid, "hello")
+
+	assert.Equal(t, 1, onSystem)
+	assert.Equal(t, 0, onApplication)
+}
+
+// deadLetterRequestTimeout is the timeout of the requests which are dead lettered, they fail long before it
+const deadLetterRequestTimeout = 30 * time.Second
+
+// requestDeadLetter requests pid and returns the error of the request, and how long it took to fail
+func requestDeadLetter(t *testing.T, pid *PID) (*DeadLetterError, time.Duration) {
+	start := time.Now()
+	_, err := rootContext.RequestFuture(pid, "hello", deadLetterRequestTimeout).Result()
+	elapsed := time.Since(start)
+	require.True(t, errors.Is(err, ErrDeadLetter), "%v", err)
+	var deadLetter *DeadLetterError
+	require.True(t, errors.As(err, &deadLetter))
+	return deadLetter, elapsed
+}
+
+func TestDeadLetterNotLoggedWhenDisabled(t *testing.T) {
+	defer plog.SetLevel(plog.Level())
+	plog.SetLevel(log.InfoLevel)
+	system := NewActorSystem()
+	system.Root.Send(system.NewLocalPID("missing"), "hello")
+	assert.Nil(t, system.eventLog.logger, "no logger goroutine while the dead letters are not logged")
+}
+
+func TestDeadLetterFailsFuture_NonexistentPID(t *testing.T) {
+	pid := system.NewLocalPID("never-existed")
+	err, elapsed := requestDeadLetter(t, pid)
+	assert.Equal(t, pid, err.PID)
+	assert.Equal(t, DeadLetterUndeliverable, err.Reason)
+	assert.Less(t, int64(elapsed), int64(deadLetterRequestTimeout/10), "the request failed before its timeout")
+}
+
+func TestDeadLetterFailsFuture_StoppedPID(t *testing.T) {
+	pid := rootContext.Spawn(PropsFromProducer(NewBlackHoleActor))
+	require.NoError(t, rootContext.StopFuture(pid).Wait())
+	err, elapsed := requestDeadLetter(t, pid)
+	assert.Equal(t, pid.Id, err.PID.Id)
+	assert.Less(t, int64(elapsed), int64(deadLetterRequestTimeout/10), "the request failed before its timeout")
+}
+
+func TestDeadLetterRedeliveredDoesNotFailFuture(t *testing.T) {
+	pid := system.NewLocalPID("redelivered")
+	sub := system.SystemEventStream.Subscribe(func(msg interface{}) {
+		if deadLetter, ok := msg.(*DeadLetterEvent); ok && deadLetter.PID.Id == pid.Id {
+			deadLetter.Redeliver()
+			sender := deadLetter.Sender
+			time.AfterFunc(10*time.Millisecond, func() { rootContext.Send(sender, "redelivered") })
+		}
+	})
+	defer system.SystemEventStream.Unsubscribe(sub)
+
+	res, err := rootContext.RequestFuture(pid, "hello", testTimeout).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "redelivered", res)
+}
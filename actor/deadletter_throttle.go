@@ -0,0 +1,119 @@
+package actor
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// WithDeadLetterThrottle logs at most count dead letters every interval. The others are summed up by target at the end
+// of the interval, they are still published to the event stream. All dead letters are logged by default.
+func WithDeadLetterThrottle(count int, interval time.Duration) SystemOption {
+	return func(system *ActorSystem) {
+		system.Config.DeadLetterThrottleCount = count
+		system.Config.DeadLetterThrottleInterval = interval
+	}
+}
+
+// suppressedDeadLetters are the dead letters to a target which were not logged during an interval
+type suppressedDeadLetters struct {
+	pid              *PID
+	count            int
+	firstMessageType string
+}
+
+// deadLetterThrottle decides which dead letters are logged, and reports the ones which are not
+type deadLetterThrottle struct {
+	count    int
+	interval time.Duration
+	log      func(deadLetter *DeadLetterEvent)
+	report   func(suppressed *suppressedDeadLetters, interval time.Duration)
+
+	mu          sync.Mutex
+	windowStart time.Time
+	logged      int
+	suppressed  map[string]*suppressedDeadLetters
+	order       []string // the targets in the order they were first suppressed
+	timer       *time.Timer
+}
+
+// newDeadLetterThrottle returns a throttle logging to events. The dead letters are logged with the type of their
+// message, the event log does not keep the messages
+func newDeadLetterThrottle(count int, interval time.Duration, events *eventLog) *deadLetterThrottle {
+	return &deadLetterThrottle{
+		count:    count,
+		interval: interval,
+		log: func(deadLetter *DeadLetterEvent) {
+			events.log("[DeadLetter]", log.Stringer("pid", deadLetter.PID), log.String("messageType", typeName(deadLetter.Message)),
+				log.Stringer("sender", deadLetter.Sender), log.Stringer("reason", deadLetter.Reason))
+		},
+		report: func(suppressed *suppressedDeadLetters, interval time.Duration) {
+			events.log("[DeadLetter] suppressed", log.Int("count", suppressed.count), log.Stringer("pid", suppressed.pid),
+				log.Duration("interval", interval), log.String("firstMessageType", suppressed.firstMessageType))
+		},
+		suppressed: make(map[string]*suppressedDeadLetters),
+	}
+}
+
+// handle logs deadLetter, unless count dead letters were already logged in the current interval
+func (t *deadLetterThrottle) handle(deadLetter *DeadLetterEvent) {
+	if t.count <= 0 {
+		t.log(deadLetter)
+		return
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	if now.Sub(t.windowStart) >= t.interval {
+		t.flush()
+		t.windowStart = now
+		t.logged = 0
+	}
+	if t.logged < t.count {
+		t.logged++
+		t.mu.Unlock()
+		t.log(deadLetter)
+		return
+	}
+	defer t.mu.Unlock()
+
+	key := deadLetter.PID.String()
+	s, ok := t.suppressed[key]
+	if !ok {
+		s = &suppressedDeadLetters{pid: deadLetter.PID, firstMessageType: typeName(deadLetter.Message)}
+		t.suppressed[key] = s
+		t.order = append(t.order, key)
+	}
+	s.count++
+
+	// the suppressed dead letters are reported at the end of the interval, even when no other one follows
+	if t.timer == nil {
+		t.timer = time.AfterFunc(t.windowStart.Add(t.interval).Sub(now), func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.flush()
+		})
+	}
+}
+
+// flush reports the dead letters suppressed so far, it is called with the lock held
+func (t *deadLetterThrottle) flush() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	for _, key := range t.order {
+		t.report(t.suppressed[key], t.interval)
+		delete(t.suppressed, key)
+	}
+	t.order = nil
+}
+
+func typeName(message interface{}) string {
+	if message == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(message).String()
+}
@@ -0,0 +1,94 @@
+package actor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedThrottle records the dead letters a throttle logs and reports
+type recordedThrottle struct {
+	*deadLetterThrottle
+	mu       sync.Mutex
+	logged   int
+	reported []suppressedDeadLetters
+}
+
+func newRecordedThrottle(count int, interval time.Duration) *recordedThrottle {
+	r := &recordedThrottle{deadLetterThrottle: newDeadLetterThrottle(count, interval, nil)}
+	r.deadLetterThrottle.log = func(*DeadLetterEvent) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.logged++
+	}
+	r.deadLetterThrottle.report = func(suppressed *suppressedDeadLetters, interval time.Duration) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.reported = append(r.reported, *suppressed)
+	}
+	return r
+}
+
+func (r *recordedThrottle) counts() (int, []suppressedDeadLetters) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.logged, append([]suppressedDeadLetters(nil), r.reported...)
+}
+
+func TestDeadLetterThrottle_SummarizesStormByTarget(t *testing.T) {
+	throttle := newRecordedThrottle(3, 50*time.Millisecond)
+	a, b := NewPID("nonhost", "a"), NewPID("nonhost", "b")
+	for i := 0; i < 1000; i++ {
+		throttle.handle(&DeadLetterEvent{PID: a, Message: "hello"})
+		if i%2 == 0 {
+			throttle.handle(&DeadLetterEvent{PID: b, Message: &Watch{}})
+		}
+	}
+
+	// the summary is reported at the end of the interval
+	require.Eventually(t, func() bool {
+		_, reported := throttle.counts()
+		return len(reported) == 2
+	}, time.Second, 5*time.Millisecond)
+	logged, reported := throttle.counts()
+	assert.Equal(t, 3, logged)
+	assert.Equal(t, "nonhost/a", reported[0].pid.String())
+	assert.Equal(t, "string", reported[0].firstMessageType)
+	assert.Equal(t, "nonhost/b", reported[1].pid.String())
+	assert.Equal(t, "*actor.Watch", reported[1].firstMessageType)
+	assert.Equal(t, 1500-3, reported[0].count+reported[1].count)
+
+	// a new interval logs again
+	throttle.handle(&DeadLetterEvent{PID: a, Message: "hello"})
+	logged, _ = throttle.counts()
+	assert.Equal(t, 4, logged)
+}
+
+func TestDeadLetterThrottle_LogsAllByDefault(t *testing.T) {
+	throttle := newRecordedThrottle(0, 0)
+	for i := 0; i < 100; i++ {
+		throttle.handle(&DeadLetterEvent{PID: NewPID("nonhost", "a"), Message: "hello"})
+	}
+	logged, reported := throttle.counts()
+	assert.Equal(t, 100, logged)
+	assert.Empty(t, reported)
+}
+
+func TestDeadLetterThrottle_PublishesAllDeadLetters(t *testing.T) {
+	actorSystem := NewActorSystem(WithDeadLetterThrottle(1, time.Hour))
+	var published int
+	actorSystem.EventStream.Subscribe(func(msg interface{}) {
+		if _, ok := msg.(*DeadLetterEvent); ok {
+			published++
+		}
+	})
+
+	pid := actorSystem.NewLocalPID("missing")
+	for i := 0; i < 100; i++ {
+		actorSystem.Root.Send(pid, "hello")
+	}
+	assert.Equal(t, 100, published)
+}
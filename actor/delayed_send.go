@@ -0,0 +1,98 @@
+package actor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+)
+
+// CancelFunc cancels a delayed send
+type CancelFunc func()
+
+// delayedSends are the messages an actor sends later, they are cancelled when it stops
+type delayedSends struct {
+	mu      sync.Mutex
+	nextID  int
+	pending map[int]func()
+	stopped bool
+}
+
+// add sends with send after delay on wheel, unless cancelled before
+func (d *delayedSends) add(wheel *timerwheel.Wheel, delay time.Duration, send func()) CancelFunc {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopped {
+		return func() {}
+	}
+	if d.pending == nil {
+		d.pending = make(map[int]func())
+	}
+
+	id := d.nextID
+	d.nextID++
+	// the lock is held while scheduling, so that the timer firing right away finds the pending send
+	d.pending[id] = wheel.Schedule(delay, 0, false, func() {
+		if d.remove(id) {
+			send()
+		}
+	})
+	return func() {
+		d.mu.Lock()
+		cancel, ok := d.pending[id]
+		delete(d.pending, id)
+		d.mu.Unlock()
+		if ok {
+			cancel()
+		}
+	}
+}
+
+func (d *delayedSends) remove(id int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.pending[id]
+	delete(d.pending, id)
+	return ok
+}
+
+// cancelAll cancels the pending sends, none is added anymore
+func (d *delayedSends) cancelAll() {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.stopped = true
+	d.mu.Unlock()
+
+	for _, cancel := range pending {
+		cancel()
+	}
+}
+
+func (ctx *actorContext) SendLater(pid *PID, message interface{}, delay time.Duration) CancelFunc {
+	return ctx.later(delay, func() {
+		ctx.sendUserMessage(pid, message)
+	})
+}
+
+func (ctx *actorContext) RequestLater(pid *PID, message interface{}, delay time.Duration) CancelFunc {
+	env := &MessageEnvelope{
+		Header:  nil,
+		Message: message,
+		Sender:  ctx.Self(),
+	}
+	return ctx.later(delay, func() {
+		ctx.sendUserMessage(pid, env)
+	})
+}
+
+func (ctx *actorContext) later(delay time.Duration, send func()) CancelFunc {
+	if ctx.props.keepDelayedSends {
+		return ctx.actorSystem.timers.Schedule(delay, 0, false, send)
+	}
+	extras := ctx.ensureExtras()
+	if extras.delayed == nil {
+		extras.delayed = &delayedSends{}
+	}
+	return extras.delayed.add(ctx.actorSystem.timers, delay, send)
+}
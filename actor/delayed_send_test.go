@@ -0,0 +1,150 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sendLater struct {
+	to    *PID
+	delay time.Duration
+}
+
+// spawnProbe spawns an actor forwarding the strings it receives to the returned channel
+func spawnProbe() (*PID, chan string) {
+	ch := make(chan string, 10)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ch <- msg
+		}
+	}))
+	return pid, ch
+}
+
+func laterProps() *Props {
+	return PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(*sendLater); ok {
+			ctx.SendLater(msg.to, "later", msg.delay)
+		}
+	})
+}
+
+func TestSendLater(t *testing.T) {
+	probe, ch := spawnProbe()
+	defer rootContext.Stop(probe)
+	pid := rootContext.Spawn(laterProps())
+	defer rootContext.Stop(pid)
+
+	start := time.Now()
+	rootContext.Send(pid, &sendLater{to: probe, delay: 20 * time.Millisecond})
+	select {
+	case msg := <-ch:
+		assert.Equal(t, "later", msg)
+		assert.True(t, time.Since(start) >= 20*time.Millisecond)
+	case <-time.After(testTimeout):
+		assert.Fail(t, "timed out")
+	}
+}
+
+func TestRequestLater_Cancel(t *testing.T) {
+	senders := make(chan *PID, 1)
+	probe := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			senders <- ctx.Sender()
+		}
+	}))
+	defer rootContext.Stop(probe)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			ctx.RequestLater(probe, "first", 10*time.Millisecond)
+			cancel := ctx.RequestLater(probe, "second", 10*time.Millisecond)
+			cancel()
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	select {
+	case sender := <-senders:
+		assert.Equal(t, pid, sender)
+	case <-time.After(testTimeout):
+		assert.Fail(t, "timed out")
+	}
+	select {
+	case <-senders:
+		assert.Fail(t, "cancelled request received")
+	case <-time.After(30 * time.Millisecond):
+	}
+}
+
+func TestSendLater_CancelledOnStop(t *testing.T) {
+	probe, ch := spawnProbe()
+	defer rootContext.Stop(probe)
+	pid := rootContext.Spawn(laterProps())
+
+	rootContext.Send(pid, &sendLater{to: probe, delay: 20 * time.Millisecond})
+	require.NoError(t, rootContext.PoisonFuture(pid).Wait())
+	select {
+	case <-ch:
+		assert.Fail(t, "message sent after the sender stopped")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSendLater_KeptOnStop(t *testing.T) {
+	probe, ch := spawnProbe()
+	defer rootContext.Stop(probe)
+	pid := rootContext.Spawn(laterProps().WithCancelDelayedSendsOnStop(false))
+
+	rootContext.Send(pid, &sendLater{to: probe, delay: 20 * time.Millisecond})
+	require.NoError(t, rootContext.PoisonFuture(pid).Wait())
+	select {
+	case msg := <-ch:
+		assert.Equal(t, "later", msg)
+	case <-time.After(testTimeout):
+		assert.Fail(t, "timed out")
+	}
+}
+
+func TestTTL_ExpiresWhileQueued(t *testing.T) {
+	received := make(chan string, 10)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			if msg == "slow" {
+				time.Sleep(30 * time.Millisecond)
+			}
+			received <- msg
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	expired := make(chan *DeadLetterEvent, 10)
+	sub := system.SystemEventStream.Subscribe(func(evt interface{}) {
+		if deadLetter, ok := evt.(*DeadLetterEvent); ok && deadLetter.PID.Equal(pid) {
+			expired <- deadLetter
+		}
+	})
+	defer system.SystemEventStream.Unsubscribe(sub)
+
+	rootContext.Send(pid, "slow")
+	short := &MessageEnvelope{Message: "short"}
+	short.SetTTL(10 * time.Millisecond)
+	rootContext.Send(pid, short)
+	long := &MessageEnvelope{Message: "long"}
+	long.SetTTL(testTimeout)
+	rootContext.Send(pid, long)
+
+	assert.Equal(t, "slow", <-received)
+	assert.Equal(t, "long", <-received)
+	select {
+	case deadLetter := <-expired:
+		assert.Equal(t, "short", deadLetter.Message)
+		assert.Equal(t, DeadLetterExpired, deadLetter.Reason)
+	case <-time.After(testTimeout):
+		assert.Fail(t, "timed out")
+	}
+	assert.Empty(t, expired)
+}
@@ -0,0 +1,222 @@
+package actor
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PendingAsk is a request whose future did not complete yet
+type PendingAsk struct {
+	// Caller is the actor which asked, nil when asked from the root context
+	Caller *PID `json:"caller,omitempty"`
+	// Goroutine is the goroutine which asked from the root context
+	Goroutine   uint64        `json:"goroutine,omitempty"`
+	Target      *PID          `json:"target,omitempty"`
+	Future      *PID          `json:"future"`
+	MessageType string        `json:"messageType,omitempty"`
+	Started     time.Time     `json:"started"`
+	Age         time.Duration `json:"age"`
+	// Blocked tells if the caller waits in Future.Wait or Future.Result, Awaited if it awaits with AwaitFuture
+	Blocked bool `json:"blocked"`
+	Awaited bool `json:"awaited"`
+}
+
+// Deadlock is a circular wait between actors blocked on the futures of their requests to each other
+type Deadlock struct {
+	Actors []*PID        `json:"actors"`
+	Asks   []*PendingAsk `json:"asks"`
+}
+
+type pendingAsk struct {
+	caller    *PID
+	goroutine uint64
+	target    *PID
+	future    *PID
+	message   string
+	started   time.Time
+	blocked   int32
+	awaited   int32
+}
+
+// Diagnostics records the pending asks of an actor system while enabled, to find who waits on whom
+// when the system wedges. It costs an atomic check per request while disabled
+type Diagnostics struct {
+	system  *ActorSystem
+	enabled int32
+	asks    sync.Map // future id -> *pendingAsk
+}
+
+// WithDiagnostics enables the diagnostics of the actor system from its start
+func WithDiagnostics() SystemOption {
+	return func(system *ActorSystem) {
+		system.Diagnostics.Enable()
+	}
+}
+
+func newDiagnostics(system *ActorSystem) *Diagnostics {
+	return &Diagnostics{system: system}
+}
+
+// Enable starts recording the asks, the ones pending already are not known
+func (d *Diagnostics) Enable() {
+	atomic.StoreInt32(&d.enabled, 1)
+}
+
+// Disable stops recording the asks and forgets the pending ones
+func (d *Diagnostics) Disable() {
+	atomic.StoreInt32(&d.enabled, 0)
+	d.asks.Range(func(key, _ interface{}) bool {
+		d.asks.Delete(key)
+		return true
+	})
+}
+
+func (d *Diagnostics) Enabled() bool {
+	return atomic.LoadInt32(&d.enabled) == 1
+}
+
+// record registers the ask of f unless it already completed
+func (d *Diagnostics) record(caller *PID, target *PID, message interface{}, f *Future) {
+	ask := &pendingAsk{
+		caller:  caller,
+		target:  target,
+		future:  f.pid,
+		started: d.system.Config.Clock.Now(),
+	}
+	if caller == nil {
+		ask.goroutine = goroutineID()
+	}
+	if message != nil {
+		ask.message = reflect.TypeOf(message).String()
+	}
+
+	f.cond.L.Lock()
+	defer f.cond.L.Unlock()
+	if f.done || f.ask != nil {
+		return
+	}
+	f.ask = ask
+	d.asks.Store(f.pid.Id, ask)
+}
+
+// awaited marks the ask of f awaited by caller, the futures not requested are recorded without target
+func (d *Diagnostics) awaited(caller *PID, f *Future) {
+	d.record(caller, nil, nil, f)
+	f.cond.L.Lock()
+	if f.ask != nil {
+		atomic.StoreInt32(&f.ask.awaited, 1)
+	}
+	f.cond.L.Unlock()
+}
+
+func (d *Diagnostics) remove(ask *pendingAsk) {
+	d.asks.Delete(ask.future.Id)
+}
+
+// DumpPendingAsks returns the asks pending, the oldest first
+func (d *Diagnostics) DumpPendingAsks() []*PendingAsk {
+	now := d.system.Config.Clock.Now()
+	var asks []*PendingAsk
+	d.asks.Range(func(_, value interface{}) bool {
+		ask := value.(*pendingAsk)
+		asks = append(asks, &PendingAsk{
+			Caller:      ask.caller,
+			Goroutine:   ask.goroutine,
+			Target:      ask.target,
+			Future:      ask.future,
+			MessageType: ask.message,
+			Started:     ask.started,
+			Age:         now.Sub(ask.started),
+			Blocked:     atomic.LoadInt32(&ask.blocked) > 0,
+			Awaited:     atomic.LoadInt32(&ask.awaited) > 0,
+		})
+		return true
+	})
+	sort.SliceStable(asks, func(i, j int) bool {
+		return asks[i].Started.Before(asks[j].Started)
+	})
+	return asks
+}
+
+// DetectDeadlocks returns the circular waits between the actors blocked on their pending asks
+func (d *Diagnostics) DetectDeadlocks() []*Deadlock {
+	return FindDeadlocks(d.DumpPendingAsks())
+}
+
+// FindDeadlocks returns the circular waits between the actors blocked on asks
+func FindDeadlocks(asks []*PendingAsk) []*Deadlock {
+	// an actor blocks on one future at a time, so each actor waits on one other at most
+	waits := make(map[string]*PendingAsk)
+	for _, ask := range asks {
+		if ask.Blocked && ask.Caller != nil && ask.Target != nil {
+			waits[ask.Caller.String()] = ask
+		}
+	}
+
+	var deadlocks []*Deadlock
+	seen := make(map[string]bool)
+	for start := range waits {
+		path := make(map[string]int)
+		var chain []*PendingAsk
+		for key := start; ; {
+			if seen[key] {
+				break
+			}
+			if i, ok := path[key]; ok {
+				deadlocks = append(deadlocks, newDeadlock(chain[i:]))
+				break
+			}
+			ask, ok := waits[key]
+			if !ok {
+				break
+			}
+			path[key] = len(chain)
+			chain = append(chain, ask)
+			key = ask.Target.String()
+		}
+		for key := range path {
+			seen[key] = true
+		}
+	}
+	sort.Slice(deadlocks, func(i, j int) bool {
+		return deadlocks[i].Actors[0].String() < deadlocks[j].Actors[0].String()
+	})
+	return deadlocks
+}
+
+// newDeadlock returns the cycle of asks starting from its smallest actor, to report each cycle the same way
+func newDeadlock(cycle []*PendingAsk) *Deadlock {
+	first := 0
+	for i, ask := range cycle {
+		if ask.Caller.String() < cycle[first].Caller.String() {
+			first = i
+		}
+	}
+	deadlock := &Deadlock{}
+	for i := range cycle {
+		ask := cycle[(first+i)%len(cycle)]
+		deadlock.Actors = append(deadlock.Actors, ask.Caller)
+		deadlock.Asks = append(deadlock.Asks, ask)
+	}
+	return deadlock
+}
+
+var goroutinePrefix = []byte("goroutine ")
+
+// goroutineID parses the id of the current goroutine from its stack, it is only called while diagnosing
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, goroutinePrefix)
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
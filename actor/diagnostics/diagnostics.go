@@ -0,0 +1,137 @@
+// Package diagnostics answers the dumps of the pending asks and deadlocks of an actor system,
+// which records them once its Diagnostics are enabled, the tree of its actors and the watchers of an actor
+package diagnostics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// Name is the name of the debug actor, to reach it on a remote node
+const Name = "diagnostics"
+
+// DumpRequest asks the debug actor for a Report
+type DumpRequest struct{}
+
+// Report is the state of the asks and the quotas of an actor system when dumped
+type Report struct {
+	Address     string              `json:"address"`
+	Enabled     bool                `json:"enabled"`
+	GeneratedAt time.Time           `json:"generatedAt"`
+	PendingAsks []*actor.PendingAsk `json:"pendingAsks"`
+	Deadlocks   []*actor.Deadlock   `json:"deadlocks"`
+	Quotas      actor.QuotaStats    `json:"quotas"`
+}
+
+// InspectTimeout is how long an actor is waited for when walking the tree, it is reported unresponsive beyond
+var InspectTimeout = 100 * time.Millisecond
+
+// Spawn spawns the debug actor of system. Register its Props as a kind of the remote of system
+// to spawn it on demand from another node
+func Spawn(system *actor.ActorSystem) (*actor.PID, error) {
+	return system.Root.SpawnNamed(Props(system), Name)
+}
+
+// Props are the props of the debug actor of system
+func Props(system *actor.ActorSystem) *actor.Props {
+	return actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *DumpRequest:
+			ctx.Respond(Dump(system))
+		case *ActorTreeRequest:
+			// the actors are waited for while walking the tree, the debug actor among them
+			sender := ctx.Sender()
+			go func() {
+				system.Root.Send(sender, Tree(system, msg.Root, int(msg.Depth)))
+			}()
+		case *WatchersRequest:
+			sender := ctx.Sender()
+			go func() {
+				system.Root.Send(sender, Watchers(system, msg.Pid, int(msg.MaxWatchers)))
+			}()
+		}
+	})
+}
+
+// Dump returns the report of system
+func Dump(system *actor.ActorSystem) *Report {
+	asks := system.Diagnostics.DumpPendingAsks()
+	return &Report{
+		Address:     system.Address(),
+		Enabled:     system.Diagnostics.Enabled(),
+		GeneratedAt: system.Clock().Now(),
+		PendingAsks: asks,
+		Deadlocks:   actor.FindDeadlocks(asks),
+		Quotas:      system.Quotas(),
+	}
+}
+
+// Tree returns the tree of the actors of system under root, the top level actors when root is nil,
+// cut below depth levels unless depth is zero
+func Tree(system *actor.ActorSystem, root *actor.PID, depth int) *ActorTreeResponse {
+	res := &ActorTreeResponse{Address: system.Address()}
+	roots := system.TopLevelActors()
+	if root != nil {
+		roots = []*actor.PID{root}
+	}
+	sortPIDs(roots)
+	for _, pid := range roots {
+		if node := inspect(system, pid, 1, depth); node != nil {
+			res.Nodes = append(res.Nodes, node)
+		}
+	}
+	if root != nil && len(res.Nodes) == 0 {
+		res.Error = actor.ErrNotLocalActor.Error()
+	}
+	return res
+}
+
+// Watchers returns the watches of the local actor pid of system, with at most maxWatchers of its watchers
+func Watchers(system *actor.ActorSystem, pid *actor.PID, maxWatchers int) *WatchersResponse {
+	res := &WatchersResponse{Pid: pid}
+	info, err := system.InspectWatchers(pid, maxWatchers, InspectTimeout)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.WatcherCount = int32(info.WatcherCount)
+	res.Watchers = info.Watchers
+	res.WatchingCount = int32(info.WatchingCount)
+	return res
+}
+
+// inspect returns the node of pid at level, nil when it stopped meanwhile
+func inspect(system *actor.ActorSystem, pid *actor.PID, level, depth int) *ActorNode {
+	info, err := system.Inspect(pid, InspectTimeout)
+	if err != nil {
+		return nil
+	}
+	node := &ActorNode{
+		Pid:           info.PID,
+		Type:          info.Type,
+		State:         string(info.State),
+		ChildrenCount: int32(len(info.Children)),
+		MailboxLength: int32(info.MailboxLength),
+		RestartCount:  int32(info.Restarts),
+		WatcherCount:  int32(info.Watchers),
+		MaxChildren:   int32(info.MaxChildren),
+	}
+	if depth > 0 && level >= depth {
+		return node
+	}
+	sortPIDs(info.Children)
+	for _, child := range info.Children {
+		if childNode := inspect(system, child, level+1, depth); childNode != nil {
+			node.Children = append(node.Children, childNode)
+		}
+	}
+	return node
+}
+
+func sortPIDs(pids []*actor.PID) {
+	sort.Slice(pids, func(i, j int) bool {
+		return pids[i].Id < pids[j].Id
+	})
+}
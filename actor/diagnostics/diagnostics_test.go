@@ -0,0 +1,201 @@
+package diagnostics
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pingPong asks its peer and blocks on the answer, two of them asking each other deadlock
+type pingPong struct {
+	peer *actor.PID
+}
+
+func (p *pingPong) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.PID:
+		p.peer = msg
+	case string:
+		if msg == "ping" {
+			_, _ = ctx.RequestFuture(p.peer, "pong", time.Minute).Result()
+		}
+	}
+}
+
+func TestDump_Deadlock(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	system := actor.NewActorSystem(actor.WithClock(clock), actor.WithDiagnostics())
+	debug, err := Spawn(system)
+	require.NoError(t, err)
+	props := actor.PropsFromProducer(func() actor.Actor { return &pingPong{} })
+	a, b := system.Root.Spawn(props), system.Root.Spawn(props)
+	system.Root.Send(a, b)
+	system.Root.Send(b, a)
+
+	system.Root.Send(a, "ping")
+	system.Root.Send(b, "ping")
+	require.Eventually(t, func() bool {
+		return len(system.Diagnostics.DetectDeadlocks()) == 1
+	}, time.Second, time.Millisecond)
+	clock.Advance(time.Second)
+
+	res, err := system.Root.RequestFuture(debug, &DumpRequest{}, -1).Result()
+	require.NoError(t, err)
+	report := res.(*Report)
+	assert.True(t, report.Enabled)
+	assert.Equal(t, 3, report.Quotas.LiveActors, "the debug actor and the two others")
+	require.Len(t, report.Deadlocks, 1)
+	assert.ElementsMatch(t, []*actor.PID{a, b}, report.Deadlocks[0].Actors)
+	require.Len(t, report.PendingAsks, 3, "the dump request itself is pending")
+	for _, ask := range report.PendingAsks[:2] {
+		assert.True(t, ask.Blocked)
+		assert.Equal(t, "string", ask.MessageType)
+		assert.Equal(t, time.Second, ask.Age)
+	}
+
+	// the timeouts release the actors
+	clock.Advance(time.Minute)
+	assert.Empty(t, system.Diagnostics.DetectDeadlocks())
+	_ = system.Root.StopFuture(a).Wait()
+	_ = system.Root.StopFuture(b).Wait()
+}
+
+// treeParent spawns its children on start, each child spawns the given number of grandchildren
+type treeParent struct {
+	grandchildren []int
+}
+
+func (p *treeParent) Receive(ctx actor.Context) {
+	if _, ok := ctx.Message().(*actor.Started); ok {
+		for i, n := range p.grandchildren {
+			n := n
+			ctx.SpawnNamed(actor.PropsFromProducer(func() actor.Actor { return &treeParent{grandchildren: make([]int, n)} }),
+				"child"+strconv.Itoa(i))
+		}
+	}
+	if ctx.Message() == "fail" {
+		panic("fail")
+	}
+}
+
+func spawnTree(t *testing.T, system *actor.ActorSystem) *actor.PID {
+	pid, err := system.Root.SpawnNamed(actor.PropsFromProducer(func() actor.Actor {
+		return &treeParent{grandchildren: []int{0, 2}}
+	}), "parent")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = system.Root.StopFuture(pid).Wait() })
+	return pid
+}
+
+func TestTree_DepthAndSubtree(t *testing.T) {
+	system := actor.NewActorSystem()
+	parent := spawnTree(t, system)
+	require.Eventually(t, func() bool {
+		return len(Tree(system, parent, 0).Nodes[0].Children) == 2
+	}, time.Second, time.Millisecond)
+
+	res := Tree(system, parent, 2)
+	require.Len(t, res.Nodes, 1)
+	node := res.Nodes[0]
+	assert.Equal(t, parent, node.Pid)
+	assert.Equal(t, "*diagnostics.treeParent", node.Type)
+	assert.Equal(t, "alive", node.State)
+	assert.Equal(t, int32(2), node.ChildrenCount)
+	require.Len(t, node.Children, 2)
+	assert.Equal(t, "parent/child0", node.Children[0].Pid.Id)
+	assert.Equal(t, int32(2), node.Children[1].ChildrenCount)
+	assert.Empty(t, node.Children[1].Children, "cut below the depth")
+
+	assert.Len(t, Tree(system, parent, 0).Nodes[0].Children[1].Children, 2)
+	assert.Equal(t, actor.ErrNotLocalActor.Error(), Tree(system, system.NewLocalPID("missing"), 0).Error)
+}
+
+func TestTree_RestartsAndUnresponsive(t *testing.T) {
+	system := actor.NewActorSystem()
+	parent := spawnTree(t, system)
+	release := make(chan struct{})
+	blocked := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "block" {
+			<-release
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(blocked).Wait() }()
+	defer close(release)
+
+	system.Root.Send(parent, "fail")
+	system.Root.Send(blocked, "block")
+	system.Root.Send(blocked, "waiting")
+	require.Eventually(t, func() bool {
+		info, err := system.Inspect(parent, time.Second)
+		return err == nil && info.Restarts == 1
+	}, time.Second, time.Millisecond)
+
+	nodes := Tree(system, nil, 1).Nodes
+	require.Len(t, nodes, 2)
+	assert.Equal(t, blocked.Id, nodes[0].Pid.Id)
+	assert.Equal(t, "unresponsive", nodes[0].State)
+	assert.Equal(t, int32(1), nodes[0].MailboxLength)
+	assert.Equal(t, parent.Id, nodes[1].Pid.Id)
+	assert.Equal(t, int32(1), nodes[1].RestartCount)
+}
+
+func TestTree_OverRemote(t *testing.T) {
+	inspected := actor.NewActorSystem()
+	inspectedRemote := remote.NewRemote(inspected, remote.Configure("localhost", 0))
+	inspectedRemote.Start()
+	defer inspectedRemote.Shutdown(false)
+	debug, err := Spawn(inspected)
+	require.NoError(t, err)
+	defer func() { _ = inspected.Root.StopFuture(debug).Wait() }()
+	parent := spawnTree(t, inspected)
+
+	client := actor.NewActorSystem()
+	clientRemote := remote.NewRemote(client, remote.Configure("localhost", 0))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	res, err := client.Root.RequestFuture(actor.NewPID(inspected.Address(), Name), &ActorTreeRequest{Depth: 1}, 5*time.Second).Result()
+	require.NoError(t, err)
+	tree := res.(*ActorTreeResponse)
+	assert.Equal(t, inspected.Address(), tree.Address)
+	var ids []string
+	for _, node := range tree.Nodes {
+		ids = append(ids, node.Pid.Id)
+		assert.Empty(t, node.Children)
+	}
+	assert.Equal(t, []string{Name, parent.Id}, ids)
+}
+
+func TestWatchers(t *testing.T) {
+	system := actor.NewActorSystem()
+	debug, err := Spawn(system)
+	require.NoError(t, err)
+	defer func() { _ = system.Root.StopFuture(debug).Wait() }()
+	watched := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	defer func() { _ = system.Root.StopFuture(watched).Wait() }()
+	for i := 0; i < 3; i++ {
+		watcher := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+			if _, ok := ctx.Message().(*actor.Started); ok {
+				ctx.Watch(watched)
+			}
+		}))
+		defer func() { _ = system.Root.StopFuture(watcher).Wait() }()
+	}
+	require.Eventually(t, func() bool {
+		return Tree(system, watched, 0).Nodes[0].WatcherCount == 3
+	}, time.Second, time.Millisecond)
+
+	res, err := system.Root.RequestFuture(debug, &WatchersRequest{Pid: watched, MaxWatchers: 2}, time.Second).Result()
+	require.NoError(t, err)
+	watchers := res.(*WatchersResponse)
+	assert.Empty(t, watchers.Error)
+	assert.Equal(t, int32(3), watchers.WatcherCount)
+	assert.Len(t, watchers.Watchers, 2)
+	assert.Equal(t, actor.ErrNotLocalActor.Error(), Watchers(system, system.NewLocalPID("missing"), 0).Error)
+}
@@ -0,0 +1,1906 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: protos.proto
+
+/*
+Package diagnostics is a generated protocol buffer package.
+
+It is generated from these files:
+
+	protos.proto
+
+It has these top-level messages:
+
+	ActorTreeRequest
+	ActorNode
+	ActorTreeResponse
+	WatchersRequest
+	WatchersResponse
+*/
+package diagnostics
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import actor "github.com/AsynkronIT/protoactor-go/actor"
+
+import strings "strings"
+import reflect "reflect"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// ActorTreeRequest asks the debug actor for the actors under root, the top level actors when root is not set.
+// The tree is cut below depth levels, the whole tree is answered when depth is zero
+type ActorTreeRequest struct {
+	Root  *actor.PID `protobuf:"bytes,1,opt,name=root" json:"root,omitempty"`
+	Depth int32      `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+}
+
+func (m *ActorTreeRequest) Reset()                    { *m = ActorTreeRequest{} }
+func (*ActorTreeRequest) ProtoMessage()               {}
+func (*ActorTreeRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{0} }
+
+func (m *ActorTreeRequest) GetRoot() *actor.PID {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *ActorTreeRequest) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+type ActorNode struct {
+	Pid           *actor.PID   `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
+	Type          string       `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	State         string       `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	ChildrenCount int32        `protobuf:"varint,4,opt,name=children_count,json=childrenCount,proto3" json:"children_count,omitempty"`
+	MailboxLength int32        `protobuf:"varint,5,opt,name=mailbox_length,json=mailboxLength,proto3" json:"mailbox_length,omitempty"`
+	RestartCount  int32        `protobuf:"varint,6,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	Children      []*ActorNode `protobuf:"bytes,7,rep,name=children" json:"children,omitempty"`
+	WatcherCount  int32        `protobuf:"varint,8,opt,name=watcher_count,json=watcherCount,proto3" json:"watcher_count,omitempty"`
+	// max_children is the limit of the children of the actor, zero when they are not limited
+	MaxChildren int32 `protobuf:"varint,9,opt,name=max_children,json=maxChildren,proto3" json:"max_children,omitempty"`
+}
+
+func (m *ActorNode) Reset()                    { *m = ActorNode{} }
+func (*ActorNode) ProtoMessage()               {}
+func (*ActorNode) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{1} }
+
+func (m *ActorNode) GetPid() *actor.PID {
+	if m != nil {
+		return m.Pid
+	}
+	return nil
+}
+
+func (m *ActorNode) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ActorNode) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ActorNode) GetChildrenCount() int32 {
+	if m != nil {
+		return m.ChildrenCount
+	}
+	return 0
+}
+
+func (m *ActorNode) GetMailboxLength() int32 {
+	if m != nil {
+		return m.MailboxLength
+	}
+	return 0
+}
+
+func (m *ActorNode) GetRestartCount() int32 {
+	if m != nil {
+		return m.RestartCount
+	}
+	return 0
+}
+
+func (m *ActorNode) GetChildren() []*ActorNode {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func (m *ActorNode) GetWatcherCount() int32 {
+	if m != nil {
+		return m.WatcherCount
+	}
+	return 0
+}
+
+func (m *ActorNode) GetMaxChildren() int32 {
+	if m != nil {
+		return m.MaxChildren
+	}
+	return 0
+}
+
+type ActorTreeResponse struct {
+	Address string       `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Nodes   []*ActorNode `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+	Error   string       `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *ActorTreeResponse) Reset()                    { *m = ActorTreeResponse{} }
+func (*ActorTreeResponse) ProtoMessage()               {}
+func (*ActorTreeResponse) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{2} }
+
+func (m *ActorTreeResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ActorTreeResponse) GetNodes() []*ActorNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *ActorTreeResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// WatchersRequest asks the debug actor for the watches of the local actor pid, with at most max_watchers of its watchers
+type WatchersRequest struct {
+	Pid         *actor.PID `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
+	MaxWatchers int32      `protobuf:"varint,2,opt,name=max_watchers,json=maxWatchers,proto3" json:"max_watchers,omitempty"`
+}
+
+func (m *WatchersRequest) Reset()                    { *m = WatchersRequest{} }
+func (*WatchersRequest) ProtoMessage()               {}
+func (*WatchersRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{3} }
+
+func (m *WatchersRequest) GetPid() *actor.PID {
+	if m != nil {
+		return m.Pid
+	}
+	return nil
+}
+
+func (m *WatchersRequest) GetMaxWatchers() int32 {
+	if m != nil {
+		return m.MaxWatchers
+	}
+	return 0
+}
+
+type WatchersResponse struct {
+	Pid           *actor.PID   `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
+	WatcherCount  int32        `protobuf:"varint,2,opt,name=watcher_count,json=watcherCount,proto3" json:"watcher_count,omitempty"`
+	Watchers      []*actor.PID `protobuf:"bytes,3,rep,name=watchers" json:"watchers,omitempty"`
+	WatchingCount int32        `protobuf:"varint,4,opt,name=watching_count,json=watchingCount,proto3" json:"watching_count,omitempty"`
+	Error         string       `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *WatchersResponse) Reset()                    { *m = WatchersResponse{} }
+func (*WatchersResponse) ProtoMessage()               {}
+func (*WatchersResponse) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{4} }
+
+func (m *WatchersResponse) GetPid() *actor.PID {
+	if m != nil {
+		return m.Pid
+	}
+	return nil
+}
+
+func (m *WatchersResponse) GetWatcherCount() int32 {
+	if m != nil {
+		return m.WatcherCount
+	}
+	return 0
+}
+
+func (m *WatchersResponse) GetWatchers() []*actor.PID {
+	if m != nil {
+		return m.Watchers
+	}
+	return nil
+}
+
+func (m *WatchersResponse) GetWatchingCount() int32 {
+	if m != nil {
+		return m.WatchingCount
+	}
+	return 0
+}
+
+func (m *WatchersResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ActorTreeRequest)(nil), "diagnostics.ActorTreeRequest")
+	proto.RegisterType((*ActorNode)(nil), "diagnostics.ActorNode")
+	proto.RegisterType((*ActorTreeResponse)(nil), "diagnostics.ActorTreeResponse")
+	proto.RegisterType((*WatchersRequest)(nil), "diagnostics.WatchersRequest")
+	proto.RegisterType((*WatchersResponse)(nil), "diagnostics.WatchersResponse")
+}
+func (this *ActorTreeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ActorTreeRequest)
+	if !ok {
+		that2, ok := that.(ActorTreeRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Root.Equal(that1.Root) {
+		return false
+	}
+	if this.Depth != that1.Depth {
+		return false
+	}
+	return true
+}
+func (this *ActorNode) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ActorNode)
+	if !ok {
+		that2, ok := that.(ActorNode)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Pid.Equal(that1.Pid) {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if this.State != that1.State {
+		return false
+	}
+	if this.ChildrenCount != that1.ChildrenCount {
+		return false
+	}
+	if this.MailboxLength != that1.MailboxLength {
+		return false
+	}
+	if this.RestartCount != that1.RestartCount {
+		return false
+	}
+	if len(this.Children) != len(that1.Children) {
+		return false
+	}
+	for i := range this.Children {
+		if !this.Children[i].Equal(that1.Children[i]) {
+			return false
+		}
+	}
+	if this.WatcherCount != that1.WatcherCount {
+		return false
+	}
+	if this.MaxChildren != that1.MaxChildren {
+		return false
+	}
+	return true
+}
+func (this *ActorTreeResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ActorTreeResponse)
+	if !ok {
+		that2, ok := that.(ActorTreeResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Address != that1.Address {
+		return false
+	}
+	if len(this.Nodes) != len(that1.Nodes) {
+		return false
+	}
+	for i := range this.Nodes {
+		if !this.Nodes[i].Equal(that1.Nodes[i]) {
+			return false
+		}
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	return true
+}
+func (this *WatchersRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*WatchersRequest)
+	if !ok {
+		that2, ok := that.(WatchersRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Pid.Equal(that1.Pid) {
+		return false
+	}
+	if this.MaxWatchers != that1.MaxWatchers {
+		return false
+	}
+	return true
+}
+func (this *WatchersResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*WatchersResponse)
+	if !ok {
+		that2, ok := that.(WatchersResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Pid.Equal(that1.Pid) {
+		return false
+	}
+	if this.WatcherCount != that1.WatcherCount {
+		return false
+	}
+	if len(this.Watchers) != len(that1.Watchers) {
+		return false
+	}
+	for i := range this.Watchers {
+		if !this.Watchers[i].Equal(that1.Watchers[i]) {
+			return false
+		}
+	}
+	if this.WatchingCount != that1.WatchingCount {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	return true
+}
+func (this *ActorTreeRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&diagnostics.ActorTreeRequest{")
+	if this.Root != nil {
+		s = append(s, "Root: "+fmt.Sprintf("%#v", this.Root)+",\n")
+	}
+	s = append(s, "Depth: "+fmt.Sprintf("%#v", this.Depth)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActorNode) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 13)
+	s = append(s, "&diagnostics.ActorNode{")
+	if this.Pid != nil {
+		s = append(s, "Pid: "+fmt.Sprintf("%#v", this.Pid)+",\n")
+	}
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
+	s = append(s, "ChildrenCount: "+fmt.Sprintf("%#v", this.ChildrenCount)+",\n")
+	s = append(s, "MailboxLength: "+fmt.Sprintf("%#v", this.MailboxLength)+",\n")
+	s = append(s, "RestartCount: "+fmt.Sprintf("%#v", this.RestartCount)+",\n")
+	if this.Children != nil {
+		s = append(s, "Children: "+fmt.Sprintf("%#v", this.Children)+",\n")
+	}
+	s = append(s, "WatcherCount: "+fmt.Sprintf("%#v", this.WatcherCount)+",\n")
+	s = append(s, "MaxChildren: "+fmt.Sprintf("%#v", this.MaxChildren)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActorTreeResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&diagnostics.ActorTreeResponse{")
+	s = append(s, "Address: "+fmt.Sprintf("%#v", this.Address)+",\n")
+	if this.Nodes != nil {
+		s = append(s, "Nodes: "+fmt.Sprintf("%#v", this.Nodes)+",\n")
+	}
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *WatchersRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&diagnostics.WatchersRequest{")
+	if this.Pid != nil {
+		s = append(s, "Pid: "+fmt.Sprintf("%#v", this.Pid)+",\n")
+	}
+	s = append(s, "MaxWatchers: "+fmt.Sprintf("%#v", this.MaxWatchers)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *WatchersResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&diagnostics.WatchersResponse{")
+	if this.Pid != nil {
+		s = append(s, "Pid: "+fmt.Sprintf("%#v", this.Pid)+",\n")
+	}
+	s = append(s, "WatcherCount: "+fmt.Sprintf("%#v", this.WatcherCount)+",\n")
+	if this.Watchers != nil {
+		s = append(s, "Watchers: "+fmt.Sprintf("%#v", this.Watchers)+",\n")
+	}
+	s = append(s, "WatchingCount: "+fmt.Sprintf("%#v", this.WatchingCount)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringProtos(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *ActorTreeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActorTreeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Root != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Root.Size()))
+		n1, err := m.Root.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if m.Depth != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Depth))
+	}
+	return i, nil
+}
+
+func (m *ActorNode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActorNode) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pid != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
+		n2, err := m.Pid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if len(m.Type) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	if len(m.State) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.State)))
+		i += copy(dAtA[i:], m.State)
+	}
+	if m.ChildrenCount != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.ChildrenCount))
+	}
+	if m.MailboxLength != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.MailboxLength))
+	}
+	if m.RestartCount != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.RestartCount))
+	}
+	if len(m.Children) > 0 {
+		for _, msg := range m.Children {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintProtos(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.WatcherCount != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.WatcherCount))
+	}
+	if m.MaxChildren != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.MaxChildren))
+	}
+	return i, nil
+}
+
+func (m *ActorTreeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActorTreeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if len(m.Nodes) > 0 {
+		for _, msg := range m.Nodes {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintProtos(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	return i, nil
+}
+
+func (m *WatchersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchersRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pid != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
+		n3, err := m.Pid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.MaxWatchers != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.MaxWatchers))
+	}
+	return i, nil
+}
+
+func (m *WatchersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchersResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pid != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
+		n4, err := m.Pid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.WatcherCount != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.WatcherCount))
+	}
+	if len(m.Watchers) > 0 {
+		for _, msg := range m.Watchers {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintProtos(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.WatchingCount != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.WatchingCount))
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	return i, nil
+}
+
+func encodeVarintProtos(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ActorTreeRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Root != nil {
+		l = m.Root.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.Depth != 0 {
+		n += 1 + sovProtos(uint64(m.Depth))
+	}
+	return n
+}
+
+func (m *ActorNode) Size() (n int) {
+	var l int
+	_ = l
+	if m.Pid != nil {
+		l = m.Pid.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.ChildrenCount != 0 {
+		n += 1 + sovProtos(uint64(m.ChildrenCount))
+	}
+	if m.MailboxLength != 0 {
+		n += 1 + sovProtos(uint64(m.MailboxLength))
+	}
+	if m.RestartCount != 0 {
+		n += 1 + sovProtos(uint64(m.RestartCount))
+	}
+	if len(m.Children) > 0 {
+		for _, e := range m.Children {
+			l = e.Size()
+			n += 1 + l + sovProtos(uint64(l))
+		}
+	}
+	if m.WatcherCount != 0 {
+		n += 1 + sovProtos(uint64(m.WatcherCount))
+	}
+	if m.MaxChildren != 0 {
+		n += 1 + sovProtos(uint64(m.MaxChildren))
+	}
+	return n
+}
+
+func (m *ActorTreeResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovProtos(uint64(l))
+		}
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *WatchersRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Pid != nil {
+		l = m.Pid.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.MaxWatchers != 0 {
+		n += 1 + sovProtos(uint64(m.MaxWatchers))
+	}
+	return n
+}
+
+func (m *WatchersResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Pid != nil {
+		l = m.Pid.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.WatcherCount != 0 {
+		n += 1 + sovProtos(uint64(m.WatcherCount))
+	}
+	if len(m.Watchers) > 0 {
+		for _, e := range m.Watchers {
+			l = e.Size()
+			n += 1 + l + sovProtos(uint64(l))
+		}
+	}
+	if m.WatchingCount != 0 {
+		n += 1 + sovProtos(uint64(m.WatchingCount))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func sovProtos(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozProtos(x uint64) (n int) {
+	return sovProtos(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ActorTreeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActorTreeRequest{`,
+		`Root:` + strings.Replace(fmt.Sprintf("%v", this.Root), "PID", "actor.PID", 1) + `,`,
+		`Depth:` + fmt.Sprintf("%v", this.Depth) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActorNode) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActorNode{`,
+		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`State:` + fmt.Sprintf("%v", this.State) + `,`,
+		`ChildrenCount:` + fmt.Sprintf("%v", this.ChildrenCount) + `,`,
+		`MailboxLength:` + fmt.Sprintf("%v", this.MailboxLength) + `,`,
+		`RestartCount:` + fmt.Sprintf("%v", this.RestartCount) + `,`,
+		`Children:` + strings.Replace(fmt.Sprintf("%v", this.Children), "ActorNode", "ActorNode", 1) + `,`,
+		`WatcherCount:` + fmt.Sprintf("%v", this.WatcherCount) + `,`,
+		`MaxChildren:` + fmt.Sprintf("%v", this.MaxChildren) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActorTreeResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActorTreeResponse{`,
+		`Address:` + fmt.Sprintf("%v", this.Address) + `,`,
+		`Nodes:` + strings.Replace(fmt.Sprintf("%v", this.Nodes), "ActorNode", "ActorNode", 1) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WatchersRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WatchersRequest{`,
+		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
+		`MaxWatchers:` + fmt.Sprintf("%v", this.MaxWatchers) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WatchersResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WatchersResponse{`,
+		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
+		`WatcherCount:` + fmt.Sprintf("%v", this.WatcherCount) + `,`,
+		`Watchers:` + strings.Replace(fmt.Sprintf("%v", this.Watchers), "PID", "actor.PID", 1) + `,`,
+		`WatchingCount:` + fmt.Sprintf("%v", this.WatchingCount) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringProtos(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ActorTreeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActorTreeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActorTreeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Root == nil {
+				m.Root = &actor.PID{}
+			}
+			if err := m.Root.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Depth", wireType)
+			}
+			m.Depth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Depth |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActorNode) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActorNode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActorNode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pid == nil {
+				m.Pid = &actor.PID{}
+			}
+			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChildrenCount", wireType)
+			}
+			m.ChildrenCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChildrenCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MailboxLength", wireType)
+			}
+			m.MailboxLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MailboxLength |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RestartCount", wireType)
+			}
+			m.RestartCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RestartCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Children", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Children = append(m.Children, &ActorNode{})
+			if err := m.Children[len(m.Children)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WatcherCount", wireType)
+			}
+			m.WatcherCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WatcherCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxChildren", wireType)
+			}
+			m.MaxChildren = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxChildren |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActorTreeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActorTreeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActorTreeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, &ActorNode{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pid == nil {
+				m.Pid = &actor.PID{}
+			}
+			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxWatchers", wireType)
+			}
+			m.MaxWatchers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxWatchers |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pid == nil {
+				m.Pid = &actor.PID{}
+			}
+			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WatcherCount", wireType)
+			}
+			m.WatcherCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WatcherCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Watchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Watchers = append(m.Watchers, &actor.PID{})
+			if err := m.Watchers[len(m.Watchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WatchingCount", wireType)
+			}
+			m.WatchingCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WatchingCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipProtos(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthProtos
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowProtos
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipProtos(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthProtos = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowProtos   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() { proto.RegisterFile("protos.proto", fileDescriptorProtos) }
+
+var fileDescriptorProtos = []byte{
+	// 487 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xb1, 0x8e, 0xd3, 0x30,
+	0x18, 0xc7, 0xeb, 0xa4, 0xb9, 0x6b, 0xbf, 0xf6, 0xe0, 0xb0, 0x10, 0x8a, 0x10, 0xb2, 0x4a, 0x10,
+	0xa8, 0xc3, 0x91, 0x4a, 0x45, 0x62, 0x3f, 0x8e, 0x81, 0x93, 0x10, 0x42, 0xd1, 0x49, 0x8c, 0x55,
+	0x9a, 0x58, 0x49, 0x44, 0x6b, 0xe7, 0x6c, 0x57, 0xf4, 0x36, 0x1e, 0x81, 0xc7, 0xe0, 0x19, 0x18,
+	0x99, 0x18, 0x6f, 0x64, 0xa4, 0x61, 0x61, 0xbc, 0x47, 0x40, 0xb1, 0x9d, 0x50, 0xa0, 0xea, 0x14,
+	0x7f, 0xff, 0xfc, 0xf3, 0xb3, 0xbf, 0xbf, 0xbf, 0xc0, 0xb0, 0x14, 0x5c, 0x71, 0x19, 0xea, 0x07,
+	0x1e, 0xa4, 0x45, 0x9c, 0x31, 0x2e, 0x55, 0x91, 0xc8, 0xfb, 0xcf, 0xb3, 0x42, 0xe5, 0xab, 0x79,
+	0x98, 0xf0, 0xe5, 0xe4, 0x54, 0x5e, 0xb1, 0xf7, 0x82, 0xb3, 0xf3, 0x8b, 0x89, 0x76, 0xc6, 0x89,
+	0xe2, 0xe2, 0x69, 0xc6, 0x27, 0x7a, 0x31, 0xd9, 0x86, 0x04, 0xaf, 0xe0, 0xf8, 0xb4, 0x56, 0x2f,
+	0x04, 0xa5, 0x11, 0xbd, 0x5c, 0x51, 0xa9, 0x30, 0x81, 0xae, 0xe0, 0x5c, 0xf9, 0x68, 0x84, 0xc6,
+	0x83, 0x29, 0x84, 0xfa, 0xb3, 0xf0, 0xed, 0xf9, 0xcb, 0x48, 0xeb, 0xf8, 0x2e, 0x78, 0x29, 0x2d,
+	0x55, 0xee, 0x3b, 0x23, 0x34, 0xf6, 0x22, 0x53, 0x04, 0x5f, 0x1d, 0xe8, 0x6b, 0xd4, 0x1b, 0x9e,
+	0x52, 0xfc, 0x00, 0xdc, 0xb2, 0x48, 0x77, 0x20, 0x6a, 0x19, 0x63, 0xe8, 0xaa, 0xab, 0x92, 0x6a,
+	0x40, 0x3f, 0xd2, 0xeb, 0x9a, 0x2a, 0x55, 0xac, 0xa8, 0xef, 0x6a, 0xd1, 0x14, 0xf8, 0x31, 0xdc,
+	0x4a, 0xf2, 0x62, 0x91, 0x0a, 0xca, 0x66, 0x09, 0x5f, 0x31, 0xe5, 0x77, 0xf5, 0xa6, 0x47, 0x8d,
+	0x7a, 0x56, 0x8b, 0xb5, 0x6d, 0x19, 0x17, 0x8b, 0x39, 0x5f, 0xcf, 0x16, 0x94, 0x65, 0x2a, 0xf7,
+	0x3d, 0x63, 0xb3, 0xea, 0x6b, 0x2d, 0xe2, 0x47, 0x70, 0x24, 0xa8, 0x54, 0xb1, 0x50, 0x16, 0x76,
+	0xa0, 0x5d, 0x43, 0x2b, 0x1a, 0xd6, 0x14, 0x7a, 0x0d, 0xdc, 0x3f, 0x1c, 0xb9, 0xe3, 0xc1, 0xf4,
+	0x5e, 0xb8, 0x15, 0x75, 0xd8, 0x36, 0x19, 0xb5, 0xbe, 0x1a, 0xfc, 0x21, 0x56, 0x49, 0x4e, 0x85,
+	0x05, 0xf7, 0x0c, 0xd8, 0x8a, 0x06, 0xfc, 0x10, 0x86, 0xcb, 0x78, 0x3d, 0x6b, 0xe1, 0x7d, 0xed,
+	0x19, 0x2c, 0xe3, 0xf5, 0x99, 0x95, 0x82, 0x4b, 0xb8, 0xb3, 0x75, 0x1d, 0xb2, 0xe4, 0x4c, 0x52,
+	0xec, 0xc3, 0x61, 0x9c, 0xa6, 0x82, 0x4a, 0xa9, 0xf3, 0xec, 0x47, 0x4d, 0x89, 0x4f, 0xc0, 0x63,
+	0x3c, 0xa5, 0xd2, 0x77, 0xf6, 0x9e, 0xd3, 0x98, 0xea, 0x84, 0xa9, 0x10, 0x5c, 0x34, 0x09, 0xeb,
+	0x22, 0x88, 0xe0, 0xf6, 0x3b, 0x73, 0x4a, 0xd9, 0x0c, 0xc0, 0xfe, 0xcb, 0xb3, 0x6d, 0xd8, 0xd6,
+	0xa4, 0xef, 0xb4, 0x6d, 0x34, 0x9c, 0xe0, 0x0b, 0x82, 0xe3, 0x3f, 0x50, 0xdb, 0xc6, 0x7e, 0xea,
+	0x7f, 0x09, 0x3a, 0x3b, 0x12, 0x7c, 0x02, 0xbd, 0x76, 0x5b, 0x77, 0xe4, 0xfe, 0xc3, 0x69, 0xdf,
+	0xd5, 0xe3, 0xa0, 0xd7, 0x05, 0xcb, 0xfe, 0x9e, 0x9a, 0x46, 0x35, 0xb8, 0x36, 0x10, 0x6f, 0x2b,
+	0x90, 0x17, 0x27, 0xd7, 0x1b, 0xd2, 0xf9, 0xbe, 0x21, 0x9d, 0x9b, 0x0d, 0x41, 0x1f, 0x2b, 0x82,
+	0x3e, 0x57, 0x04, 0x7d, 0xab, 0x08, 0xba, 0xae, 0x08, 0xfa, 0x51, 0x11, 0xf4, 0xab, 0x22, 0x9d,
+	0x9b, 0x8a, 0xa0, 0x4f, 0x3f, 0x49, 0x67, 0x7e, 0xa0, 0xff, 0xa3, 0x67, 0xbf, 0x07, 0x00, 0x8d,
+	0xa2, 0x8a, 0x85, 0x9c, 0x03, 0x00, 0x00,
+}
@@ -0,0 +1,64 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics_PendingAsks(t *testing.T) {
+	system := NewActorSystem()
+	var requests []*PID
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			requests = append(requests, ctx.Sender())
+		case int:
+			ctx.Send(requests[msg], "done")
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	system.Root.RequestFuture(pid, "disabled", time.Second)
+	system.Diagnostics.Enable()
+	first := system.Root.RequestFuture(pid, "first", time.Second)
+	second := system.Root.RequestFuture(pid, "second", time.Second)
+
+	asks := system.Diagnostics.DumpPendingAsks()
+	require.Len(t, asks, 2)
+	assert.Equal(t, first.PID(), asks[0].Future)
+	assert.Equal(t, second.PID(), asks[1].Future)
+	assert.Equal(t, pid, asks[0].Target)
+	assert.Equal(t, "string", asks[0].MessageType)
+	assert.Nil(t, asks[0].Caller)
+	assert.NotZero(t, asks[0].Goroutine)
+	assert.False(t, asks[0].Blocked)
+
+	system.Root.Send(pid, 1)
+	require.NoError(t, first.Wait())
+	asks = system.Diagnostics.DumpPendingAsks()
+	require.Len(t, asks, 1)
+	assert.Equal(t, second.PID(), asks[0].Future)
+
+	system.Diagnostics.Disable()
+	assert.Empty(t, system.Diagnostics.DumpPendingAsks())
+}
+
+func TestFindDeadlocks(t *testing.T) {
+	a, b, c, d := NewPID("local", "a"), NewPID("local", "b"), NewPID("local", "c"), NewPID("local", "d")
+	ask := func(caller, target *PID, blocked bool) *PendingAsk {
+		return &PendingAsk{Caller: caller, Target: target, Blocked: blocked}
+	}
+	ca, ab, bc, dc := ask(c, a, true), ask(a, b, true), ask(b, c, true), ask(d, c, true)
+
+	// d waits on the cycle without being part of it
+	deadlocks := FindDeadlocks([]*PendingAsk{dc, ca, bc, ab, ask(nil, a, true)})
+	require.Len(t, deadlocks, 1)
+	assert.Equal(t, []*PID{a, b, c}, deadlocks[0].Actors)
+	assert.Equal(t, []*PendingAsk{ab, bc, ca}, deadlocks[0].Asks)
+
+	// the actors awaiting do not block
+	assert.Empty(t, FindDeadlocks([]*PendingAsk{ab, ask(b, a, false)}))
+}
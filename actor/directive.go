@@ -17,4 +17,8 @@ const (
 
 	// EscalateDirective instructs the supervisor to escalate handling of the failure to the actor'pids parent supervisor
 	EscalateDirective
+
+	// RestartWithRedeliveryDirective instructs the supervisor to restart the actor as RestartDirective does,
+	// the new instance then receives the message the actor failed with again, see Props.WithMaxRedeliveries
+	RestartWithRedeliveryDirective
 )
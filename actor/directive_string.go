@@ -4,9 +4,9 @@ package actor
 
 import "fmt"
 
-const _Directive_name = "ResumeDirectiveRestartDirectiveStopDirectiveEscalateDirective"
+const _Directive_name = "ResumeDirectiveRestartDirectiveStopDirectiveEscalateDirectiveRestartWithRedeliveryDirective"
 
-var _Directive_index = [...]uint8{0, 15, 31, 44, 61}
+var _Directive_index = [...]uint8{0, 15, 31, 44, 61, 91}
 
 func (i Directive) String() string {
 	if i < 0 || i >= Directive(len(_Directive_index)-1) {
@@ -0,0 +1,84 @@
+package actor
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrEnvelopeReleased is the panic of the use of a pooled envelope after its release, in debug mode
+var ErrEnvelopeReleased = errors.New("actor: use of a released message envelope")
+
+// releasedMessage is the message of the envelopes poisoned once released
+type releasedMessage struct{}
+
+// envelopePool recycles the envelopes of the requests, see Config.EnvelopePooling
+type envelopePool struct {
+	pool  sync.Pool
+	debug bool
+}
+
+func newEnvelopePool(debug bool) *envelopePool {
+	return &envelopePool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return &MessageEnvelope{}
+			},
+		},
+		debug: debug,
+	}
+}
+
+func (p *envelopePool) get(message interface{}, sender *PID) *MessageEnvelope {
+	if p.debug {
+		// the poisoned envelopes are never reused
+		return &MessageEnvelope{Message: message, Sender: sender, pool: p}
+	}
+	envelope := p.pool.Get().(*MessageEnvelope)
+	envelope.Message, envelope.Sender, envelope.pool = message, sender, p
+	return envelope
+}
+
+func (p *envelopePool) put(envelope *MessageEnvelope) {
+	if p.debug {
+		envelope.checkReleased()
+		envelope.Header, envelope.Message, envelope.Sender = nil, releasedMessage{}, nil
+		atomic.StoreInt32(&envelope.released, 1)
+		return
+	}
+	*envelope = MessageEnvelope{}
+	p.pool.Put(envelope)
+}
+
+// checkReleased panics with ErrEnvelopeReleased if the envelope was released in debug mode
+func (envelope *MessageEnvelope) checkReleased() {
+	if atomic.LoadInt32(&envelope.released) == 1 {
+		panic(ErrEnvelopeReleased)
+	}
+}
+
+// newEnvelope returns the envelope of a request, it is drawn from the pool when the system pools the envelopes
+func (as *ActorSystem) newEnvelope(message interface{}, sender *PID) *MessageEnvelope {
+	if as.envelopes == nil {
+		return &MessageEnvelope{Message: message, Sender: sender}
+	}
+	return as.envelopes.get(message, sender)
+}
+
+// releaseEnvelope returns the pooled envelope of the message the actor processed, the envelopes handed to the
+// middlewares and decorators are theirs
+func (ctx *actorContext) releaseEnvelope(message interface{}) {
+	envelope, ok := message.(*MessageEnvelope)
+	if !ok || envelope.pool == nil || ctx.props.receiverMiddlewareChain != nil || ctx.props.contextDecoratorChain != nil {
+		return
+	}
+	envelope.pool.put(envelope)
+}
+
+// keptMessage returns the current message to keep once it is processed, the pooled envelopes are copied
+func (ctx *actorContext) keptMessage() interface{} {
+	if envelope, ok := ctx.messageOrEnvelope.(*MessageEnvelope); ok && envelope.pool != nil {
+		return envelope.Copy()
+	}
+	return ctx.messageOrEnvelope
+}
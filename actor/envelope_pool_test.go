@@ -0,0 +1,130 @@
+package actor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func echoProps() *Props {
+	return PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Respond(msg)
+		}
+	})
+}
+
+func TestEnvelopePool_ReleasedOnceProcessed(t *testing.T) {
+	system := NewActorSystemWithConfig(NewConfig(WithEnvelopePoolDebug()))
+	echo := system.Root.Spawn(echoProps())
+	defer system.Root.Stop(echo)
+
+	future := NewFuture(system, testTimeout)
+	envelope := system.newEnvelope("hello", future.PID())
+	system.Root.Send(echo, envelope)
+	res, err := future.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&envelope.released) == 1
+	}, testTimeout, time.Millisecond)
+	assert.Equal(t, releasedMessage{}, envelope.Message, "the released envelope is poisoned")
+	assert.Nil(t, envelope.Sender)
+	assert.PanicsWithValue(t, ErrEnvelopeReleased, func() { envelope.Copy() })
+	assert.PanicsWithValue(t, ErrEnvelopeReleased, func() { system.envelopes.put(envelope) }, "an envelope is released once")
+}
+
+func TestEnvelopePool_KeptMessagesAreCopied(t *testing.T) {
+	system := NewActorSystemWithConfig(NewConfig(WithEnvelopePoolDebug()))
+	echo := system.Root.Spawn(echoProps())
+	defer system.Root.Stop(echo)
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			ctx.Forward(echo)
+		case int:
+			ctx.AwaitFuture(ctx.RequestFuture(echo, "awaited", testTimeout), func(res interface{}, err error) {
+				ctx.Respond(res)
+			})
+		case bool:
+			ctx.RunBlocking(func() (interface{}, error) {
+				return msg, nil
+			}, func(res interface{}, err error) {
+				ctx.Respond(res)
+			})
+		}
+	}))
+	defer system.Root.Stop(pid)
+
+	for msg, expected := range map[interface{}]interface{}{"forwarded": "forwarded", 1: "awaited", true: true} {
+		res, err := system.Root.RequestFuture(pid, msg, testTimeout).Result()
+		require.NoError(t, err, "%v", msg)
+		assert.Equal(t, expected, res)
+	}
+}
+
+func TestEnvelopePool_StashedMessagesAreCopied(t *testing.T) {
+	system := NewActorSystemWithConfig(NewConfig(WithEnvelopePoolDebug()))
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			ctx.Respond(msg)
+		case bool:
+			ctx.BecomeStashed(func(ctx Context) {
+				if _, ok := ctx.Message().(int); ok {
+					ctx.UnbecomeStacked()
+				}
+			}, func(message interface{}) bool {
+				_, ok := message.(int)
+				return ok
+			})
+		}
+	}))
+	defer system.Root.Stop(pid)
+
+	system.Root.Send(pid, true)
+	future := system.Root.RequestFuture(pid, "stashed", testTimeout)
+	system.Root.Send(pid, 1)
+	res, err := future.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "stashed", res)
+}
+
+// benchmarkRequestRespond measures b.N requests of an actor answered by another one
+func benchmarkRequestRespond(b *testing.B, opts ...ConfigOption) {
+	system := NewActorSystemWithConfig(NewConfig(opts...))
+	echo := system.Root.Spawn(echoProps())
+	done := make(chan struct{})
+	requests := 0
+	requester := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *Started:
+		case string:
+			if requests == b.N {
+				close(done)
+				return
+			}
+			requests++
+			ctx.Request(echo, "ping")
+		}
+	}))
+	b.ReportAllocs()
+	b.ResetTimer()
+	system.Root.Send(requester, "start")
+	<-done
+	b.StopTimer()
+	system.Root.Stop(requester)
+	system.Root.Stop(echo)
+}
+
+func BenchmarkRequestRespond_Unpooled(b *testing.B) {
+	benchmarkRequestRespond(b)
+}
+
+func BenchmarkRequestRespond_Pooled(b *testing.B) {
+	benchmarkRequestRespond(b, WithEnvelopePooling())
+}
@@ -0,0 +1,35 @@
+package actor
+
+import "errors"
+
+// ActorError is the error of a request the actor failed with Context.RespondError, as returned by its future
+type ActorError struct {
+	Message string
+	// Code classifies the error for the requester, empty when unset
+	Code string
+	// Retryable tells the requester whether the request may succeed when sent again
+	Retryable bool
+}
+
+func (e *ActorError) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+	return e.Code + ": " + e.Message
+}
+
+// NewErrorResponse returns the response failing a request with err. Its code and retryability are the ones of the
+// ActorError err is or wraps, if any
+func NewErrorResponse(err error) *ErrorResponse {
+	res := &ErrorResponse{Message: err.Error()}
+	var actorErr *ActorError
+	if errors.As(err, &actorErr) {
+		res.Message, res.Code, res.Retryable = actorErr.Message, actorErr.Code, actorErr.Retryable
+	}
+	return res
+}
+
+// Err returns the ActorError of the response
+func (m *ErrorResponse) Err() error {
+	return &ActorError{Message: m.Message, Code: m.Code, Retryable: m.Retryable}
+}
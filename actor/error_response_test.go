@@ -0,0 +1,44 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondError(t *testing.T) {
+	notFound := &ActorError{Message: "config not found", Code: "NotFound", Retryable: true}
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			ctx.RespondError(errors.New(msg))
+		case int:
+			ctx.RespondError(fmt.Errorf("loading config %v: %w", msg, notFound))
+		}
+	}))
+	defer func() { _ = rootContext.StopFuture(pid).Wait() }()
+
+	res, err := rootContext.RequestFuture(pid, "failed", testTimeout).Result()
+	assert.Nil(t, res)
+	assert.Equal(t, &ActorError{Message: "failed"}, err)
+
+	err = rootContext.RequestFuture(pid, 1, testTimeout).Wait()
+	var actorErr *ActorError
+	require.True(t, errors.As(err, &actorErr))
+	assert.Equal(t, notFound, actorErr, "the code and retryability of the wrapped ActorError")
+	assert.EqualError(t, err, "NotFound: config not found")
+
+	awaited := make(chan error, 1)
+	caller := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			ctx.AwaitFuture(ctx.RequestFuture(pid, "awaited", testTimeout), func(_ interface{}, err error) {
+				awaited <- err
+			})
+		}
+	}))
+	defer func() { _ = rootContext.StopFuture(caller).Wait() }()
+	assert.Equal(t, &ActorError{Message: "awaited"}, <-awaited)
+}
@@ -0,0 +1,66 @@
+package actor
+
+import (
+	"sync"
+
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// eventLogQueueSize is the number of dead letter and supervision log entries queued to be logged,
+// the oldest ones are dropped beyond
+const eventLogQueueSize = 1024
+
+// eventLogEntry is a log entry queued to the event log, it holds the fields to log rather than the event
+type eventLogEntry struct {
+	msg    string
+	fields []log.Field
+}
+
+// eventLog logs the dead letters and supervision events on its own goroutine, as they are published on the hot paths
+// of the senders and the failing actors. The goroutine starts with the first entry, and ActorSystem.Shutdown stops it
+type eventLog struct {
+	systemStream  *eventstream.EventStream
+	subscriptions []*eventstream.Subscription
+	entries       *eventstream.EventStream
+	start         sync.Once
+	logger        *eventstream.Subscription
+}
+
+func newEventLog(system *ActorSystem) *eventLog {
+	return &eventLog{systemStream: system.SystemEventStream, entries: eventstream.NewEventStream()}
+}
+
+// subscribe subscribes fn to the events of the system event stream until the event log stops
+func (l *eventLog) subscribe(fn func(evt interface{})) *eventstream.Subscription {
+	sub := l.systemStream.Subscribe(fn)
+	l.subscriptions = append(l.subscriptions, sub)
+	return sub
+}
+
+// enabled tells whether the entries are logged, the callers check it before building them
+func (l *eventLog) enabled() bool {
+	return plog.Enabled(log.DebugLevel)
+}
+
+// log queues an entry to be logged
+func (l *eventLog) log(msg string, fields ...log.Field) {
+	l.start.Do(func() {
+		l.logger = l.entries.SubscribeAsync(func(evt interface{}) {
+			entry := evt.(*eventLogEntry)
+			plog.Debug(entry.msg, entry.fields...)
+		}, eventLogQueueSize, eventstream.DropOldest)
+	})
+	l.entries.Publish(&eventLogEntry{msg: msg, fields: fields})
+}
+
+// stop unsubscribes the event log, the entries queued are discarded and the entries logged afterwards are ignored
+func (l *eventLog) stop() {
+	for _, sub := range l.subscriptions {
+		l.systemStream.Unsubscribe(sub)
+	}
+	l.start.Do(func() {})
+	if l.logger != nil {
+		l.entries.Unsubscribe(l.logger)
+	}
+}
@@ -0,0 +1,35 @@
+package actor
+
+import (
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+)
+
+// SubscribePID sends the events of the event stream of actorSystem to pid, as messages.
+// The subscription is removed once the actor terminates, WithPredicate filters the events sent.
+func SubscribePID(actorSystem *ActorSystem, pid *PID) *eventstream.Subscription {
+	return subscribePID(actorSystem, pid, func(send func(evt interface{})) *eventstream.Subscription {
+		return actorSystem.EventStream.Subscribe(send)
+	})
+}
+
+// subscribePID subscribes pid with subscribe, and watches it to unsubscribe it
+func subscribePID(actorSystem *ActorSystem, pid *PID, subscribe func(send func(evt interface{})) *eventstream.Subscription) *eventstream.Subscription {
+	sub := subscribe(func(evt interface{}) {
+		// the events sent to the actor once it stopped are dead letters, they are not sent again
+		if deadLetter, ok := evt.(*DeadLetterEvent); ok && deadLetter.PID.Equal(pid) {
+			return
+		}
+		actorSystem.Root.Send(pid, evt)
+	})
+
+	actorSystem.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *Started:
+			ctx.Watch(pid)
+		case *Terminated:
+			actorSystem.EventStream.Unsubscribe(sub)
+			ctx.Stop(ctx.Self())
+		}
+	}))
+	return sub
+}
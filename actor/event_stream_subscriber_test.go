@@ -0,0 +1,61 @@
+//go:build go1.18
+
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type subscribedEvent struct{ value int }
+
+func TestSubscribePIDTo_DeliversMatchingEvents(t *testing.T) {
+	received := make(chan interface{}, 10)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case *subscribedEvent, string:
+			received <- msg
+		}
+	}))
+	defer func() { _ = rootContext.StopFuture(pid).Wait() }()
+
+	sub := SubscribePIDTo[*subscribedEvent](system, pid)
+	defer system.EventStream.Unsubscribe(sub)
+	system.EventStream.Publish("ignored")
+	system.EventStream.Publish(&subscribedEvent{value: 1})
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, &subscribedEvent{value: 1}, msg)
+	case <-time.After(testTimeout):
+		t.Fatal("event not delivered")
+	}
+	assert.Empty(t, received)
+}
+
+func TestSubscribePID_UnsubscribesWhenActorStops(t *testing.T) {
+	pid := rootContext.Spawn(PropsFromProducer(NewBlackHoleActor))
+	sub := SubscribePID(system, pid)
+	require.True(t, sub.Active())
+
+	require.NoError(t, rootContext.StopFuture(pid).Wait())
+	assert.Eventually(t, func() bool { return !sub.Active() }, testTimeout, time.Millisecond)
+
+	deadLetters := make(chan *DeadLetterEvent, 10)
+	watcher := SubscribePIDTo[*DeadLetterEvent](system, rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if deadLetter, ok := ctx.Message().(*DeadLetterEvent); ok && deadLetter.PID.Equal(pid) {
+			deadLetters <- deadLetter
+		}
+	})))
+	defer system.EventStream.Unsubscribe(watcher)
+
+	system.EventStream.Publish(&subscribedEvent{value: 2})
+	select {
+	case deadLetter := <-deadLetters:
+		t.Fatalf("event sent to the stopped actor: %v", deadLetter.Message)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
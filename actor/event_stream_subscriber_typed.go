@@ -0,0 +1,17 @@
+//go:build go1.18
+
+package actor
+
+import (
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+)
+
+// SubscribePIDTo sends the events of the event stream of actorSystem assignable to T to pid, as messages.
+// The subscription is removed once the actor terminates.
+func SubscribePIDTo[T any](actorSystem *ActorSystem, pid *PID) *eventstream.Subscription {
+	return subscribePID(actorSystem, pid, func(send func(evt interface{})) *eventstream.Subscription {
+		return eventstream.SubscribeTo(actorSystem.EventStream, func(evt T) {
+			send(evt)
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package actor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/AsynkronIT/protoactor-go/mailbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tenantHeader = "tenant"
+
+// tenantActor counts the messages of the tenants, the messages of b take work to process. It holds its mailbox
+// until hold is closed
+type tenantActor struct {
+	a, b int32
+	work time.Duration
+	hold chan struct{}
+	done chan struct{}
+}
+
+func (t *tenantActor) Receive(ctx Context) {
+	switch ctx.Message() {
+	case "hold":
+		<-t.hold
+	case "a":
+		atomic.AddInt32(&t.a, 1)
+	case "b":
+		for start := time.Now(); time.Since(start) < t.work; {
+		}
+		if atomic.AddInt32(&t.b, 1) == 100 {
+			close(t.done)
+		}
+	}
+}
+
+func sendAs(system *ActorSystem, pid *PID, tenant string) {
+	env := &MessageEnvelope{Message: tenant}
+	env.SetHeader(tenantHeader, tenant)
+	system.Root.Send(pid, env)
+}
+
+// latencyOfB returns how long the 100 messages of b take to be processed after the flood of a
+func latencyOfB(t *testing.T, system *ActorSystem, flood int) (time.Duration, int32) {
+	a := &tenantActor{work: 50 * time.Microsecond, hold: make(chan struct{}), done: make(chan struct{})}
+	pid := system.Root.Spawn(PropsFromProducer(func() Actor { return a }).
+		WithMailbox(mailbox.Fair(HeaderClassifier(tenantHeader), 0)))
+	// both tenants queue their messages before the actor processes any, however slow the flood is to send
+	system.Root.Send(pid, "hold")
+	for i := 0; i < flood; i++ {
+		sendAs(system, pid, "a")
+	}
+	for i := 0; i < 100; i++ {
+		sendAs(system, pid, "b")
+	}
+
+	start := time.Now()
+	close(a.hold)
+	select {
+	case <-a.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the messages of b were not processed")
+	}
+	latency, processedOfA := time.Since(start), atomic.LoadInt32(&a.a)
+
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&a.a) == int32(flood) }, 10*time.Second, time.Millisecond)
+	_ = system.Root.StopFuture(pid).Wait()
+	return latency, processedOfA
+}
+
+func TestFairMailbox_TenantIsNotStarved(t *testing.T) {
+	system := NewActorSystem()
+	isolated, _ := latencyOfB(t, system, 0)
+	flooded, processedOfA := latencyOfB(t, system, 100000)
+
+	assert.Less(t, int64(flooded), int64(5*isolated+10*time.Millisecond), "isolated %v, flooded %v", isolated, flooded)
+	assert.Less(t, int(processedOfA), 100000/2, "the messages of b do not wait for the flood of a")
+}
+
+func TestFairMailbox_OverflowIsDeadLetter(t *testing.T) {
+	events := eventstream.NewEventStream()
+	system := NewActorSystem(WithSystemEventStream(events))
+	deadLetters := make(chan *DeadLetterEvent, 10)
+	events.Subscribe(func(evt interface{}) {
+		if evt, ok := evt.(*DeadLetterEvent); ok {
+			deadLetters <- evt
+		}
+	})
+	release := make(chan struct{})
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if ctx.Message() == "a" {
+			<-release
+		}
+	}).WithMailbox(mailbox.Fair(HeaderClassifier(tenantHeader), 1)))
+	defer system.Root.Stop(pid)
+
+	sendAs(system, pid, "a") // being processed
+	require.Eventually(t, func() bool {
+		sendAs(system, pid, "a") // queued, then rejected
+		return len(deadLetters) > 0
+	}, testTimeout, time.Millisecond)
+	sendAs(system, pid, "b")
+	close(release)
+
+	evt := <-deadLetters
+	assert.Equal(t, DeadLetterMailboxFull, evt.Reason)
+	assert.Equal(t, pid, evt.PID)
+	assert.Equal(t, "a", evt.Header.Get(tenantHeader))
+	assert.Len(t, deadLetters, 0, "the other tenant has room")
+}
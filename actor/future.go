@@ -5,7 +5,6 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
-	"unsafe"
 
 	"github.com/AsynkronIT/protoactor-go/log"
 )
@@ -25,7 +24,7 @@ func NewFuture(actorSystem *ActorSystem, d time.Duration) *Future {
 
 	ref.pid = pid
 	if d >= 0 {
-		tp := time.AfterFunc(d, func() {
+		tp := actorSystem.Config.Clock.AfterFunc(d, func() {
 			ref.cond.L.Lock()
 			if ref.done {
 				ref.cond.L.Unlock()
@@ -34,13 +33,25 @@ func NewFuture(actorSystem *ActorSystem, d time.Duration) *Future {
 			ref.err = ErrTimeout
 			ref.cond.L.Unlock()
 			ref.Stop(pid)
+			actorSystem.SystemEventStream.Publish(&FutureTimeoutEvent{PID: pid})
 		})
-		atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&ref.t)), unsafe.Pointer(tp))
+		ref.cond.L.Lock()
+		if ref.done {
+			tp.Stop()
+		} else {
+			ref.t = tp
+		}
+		ref.cond.L.Unlock()
 	}
 
 	return &ref.Future
 }
 
+// FutureTimeoutEvent is published to the system event stream when a future times out
+type FutureTimeoutEvent struct {
+	PID *PID
+}
+
 type Future struct {
 	actorSystem *ActorSystem
 	pid         *PID
@@ -49,9 +60,14 @@ type Future struct {
 	done        bool
 	result      interface{}
 	err         error
-	t           *time.Timer
+	t           Timer
 	pipes       []*PID
 	completions []func(res interface{}, err error)
+	ask         *pendingAsk // recorded while diagnosing
+	// correlationID is the id the response of a correlated request carries, set before the request is sent
+	correlationID string
+	// header is the header the response carried, if any
+	header messageHeader
 }
 
 // PID to the backing actor for the Future result
@@ -89,23 +105,79 @@ func (f *Future) sendToPipes() {
 
 func (f *Future) wait() {
 	f.cond.L.Lock()
+	if ask := f.ask; ask != nil && !f.done {
+		atomic.AddInt32(&ask.blocked, 1)
+		defer atomic.AddInt32(&ask.blocked, -1)
+	}
 	for !f.done {
 		f.cond.Wait()
 	}
 	f.cond.L.Unlock()
 }
 
-// Result waits for the future to resolve
+// Result waits for the future to resolve. The error is an ActorError when the response was an ErrorResponse
 func (f *Future) Result() (interface{}, error) {
 	f.wait()
 	return f.result, f.err
 }
 
+// ResponseHeader waits for the future to resolve and returns the header of the response, nil when it had none
+func (f *Future) ResponseHeader() ReadonlyMessageHeader {
+	f.wait()
+	if f.header == nil {
+		return nil
+	}
+	return f.header
+}
+
 func (f *Future) Wait() error {
 	f.wait()
 	return f.err
 }
 
+// FutureOf returns the future whose PID is pid, e.g. the sender of a request seen by a sender middleware
+func FutureOf(actorSystem *ActorSystem, pid *PID) (*Future, bool) {
+	if pid == nil {
+		return nil, false
+	}
+	process, _ := actorSystem.ProcessRegistry.Get(pid)
+	ref, ok := process.(*futureProcess)
+	if !ok {
+		return nil, false
+	}
+	return &ref.Future, true
+}
+
+// Observe calls observer with the result or the error of the future once it completes,
+// observer must not block
+func (f *Future) Observe(observer func(res interface{}, err error)) {
+	f.continueWith(observer)
+}
+
+// Fail completes the future with err, unless it is already completed
+func (f *Future) Fail(err error) {
+	f.cond.L.Lock()
+	if f.done {
+		f.cond.L.Unlock()
+		return
+	}
+	f.err = err
+	f.cond.L.Unlock()
+	f.stop()
+}
+
+// Complete completes the future with res as if it was its response, unless it is already completed
+func (f *Future) Complete(res interface{}) {
+	f.cond.L.Lock()
+	if f.done {
+		f.cond.L.Unlock()
+		return
+	}
+	f.result = res
+	f.cond.L.Unlock()
+	f.stop()
+}
+
 func (f *Future) continueWith(continuation func(res interface{}, err error)) {
 	f.cond.L.Lock()
 	defer f.cond.L.Unlock() // use defer as the continuation could blow up
@@ -122,8 +194,27 @@ type futureProcess struct {
 }
 
 func (ref *futureProcess) SendUserMessage(pid *PID, message interface{}) {
-	_, msg, _ := UnwrapEnvelope(message)
-	ref.result = msg
+	header, msg, _ := UnwrapEnvelope(message)
+	if ref.correlationID != "" && !correlated(header, ref.correlationID) {
+		ref.actorSystem.DeadLetter.SendUserMessage(pid, message)
+		return
+	}
+	ref.cond.L.Lock()
+	if ref.done {
+		// a late message, through a PID which cached the future
+		ref.cond.L.Unlock()
+		ref.actorSystem.DeadLetter.SendUserMessage(pid, message)
+		return
+	}
+	if res, ok := msg.(*ErrorResponse); ok {
+		ref.err = res.Err()
+	} else {
+		ref.result = msg
+	}
+	if header != nil && header.Length() > 0 {
+		ref.header = header.ToMap()
+	}
+	ref.cond.L.Unlock()
 	ref.Stop(pid)
 }
 
@@ -133,23 +224,29 @@ func (ref *futureProcess) SendSystemMessage(pid *PID, message interface{}) {
 }
 
 func (ref *futureProcess) Stop(pid *PID) {
-	ref.cond.L.Lock()
-	if ref.done {
-		ref.cond.L.Unlock()
+	ref.stop()
+}
+
+func (f *Future) stop() {
+	f.cond.L.Lock()
+	if f.done {
+		f.cond.L.Unlock()
 		return
 	}
 
-	ref.done = true
-	tp := (*time.Timer)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&ref.t))))
-	if tp != nil {
-		tp.Stop()
+	f.done = true
+	if f.t != nil {
+		f.t.Stop()
+	}
+	f.actorSystem.ProcessRegistry.Remove(f.pid)
+	if f.ask != nil {
+		f.actorSystem.Diagnostics.remove(f.ask)
 	}
-	ref.actorSystem.ProcessRegistry.Remove(pid)
 
-	ref.sendToPipes()
-	ref.runCompletions()
-	ref.cond.L.Unlock()
-	ref.cond.Signal()
+	f.sendToPipes()
+	f.runCompletions()
+	f.cond.L.Unlock()
+	f.cond.Signal()
 }
 
 // TODO: we could replace "pipes" with this
@@ -1,6 +1,7 @@
 package actor_test
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -32,3 +33,22 @@ func ExampleFuture_PipeTo() {
 
 	// Output: hello world
 }
+
+func ExampleContext_RespondError() {
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			ctx.RespondError(&actor.ActorError{Message: "config not found", Code: "NotFound"})
+		}
+	}))
+
+	_, err := system.Root.RequestFuture(pid, "load config", time.Second).Result()
+	var actorErr *actor.ActorError
+	if errors.As(err, &actorErr) {
+		fmt.Println(actorErr.Code, actorErr.Retryable)
+	}
+	fmt.Println(err)
+
+	// Output:
+	// NotFound false
+	// NotFound: config not found
+}
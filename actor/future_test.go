@@ -1,11 +1,14 @@
 package actor
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFuture_PipeTo_Message(t *testing.T) {
@@ -85,6 +88,72 @@ func TestNewFuture_TimeoutNoRace(t *testing.T) {
 	_, _ = future.Result()
 }
 
+func TestFutureOf_ObserveAndFail(t *testing.T) {
+	future := NewFuture(system, testTimeout)
+	found, ok := FutureOf(system, future.PID())
+	require.True(t, ok)
+	_, ok = FutureOf(system, system.NewLocalPID("nonexistent"))
+	assert.False(t, ok)
+
+	observed := make(chan error, 2)
+	found.Observe(func(res interface{}, err error) { observed <- err })
+	found.Fail(ErrTimeout)
+	found.Fail(errors.New("ignored, the future is completed"))
+	found.Observe(func(res interface{}, err error) { observed <- err })
+
+	assert.Equal(t, ErrTimeout, future.Wait())
+	assert.Equal(t, ErrTimeout, <-observed)
+	assert.Equal(t, ErrTimeout, <-observed)
+	_, ok = FutureOf(system, future.PID())
+	assert.False(t, ok, "the completed future is removed")
+}
+
+func TestFuture_Complete(t *testing.T) {
+	future := NewFuture(system, testTimeout)
+	future.Complete("done")
+	future.Complete("ignored, the future is completed")
+	future.Fail(ErrTimeout)
+
+	res, err := future.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "done", res)
+}
+
+func TestRequestFutureWithContext(t *testing.T) {
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok && msg != "ignore" {
+			expiresAt, _ := ctx.MessageGoContext().Deadline()
+			ctx.Respond(&MessageEnvelope{
+				Header:  map[string]string{"echo": ctx.MessageHeader().Get("echo"), "deadline": expiresAt.String()},
+				Message: msg,
+			})
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	deadline := time.Now().Add(testTimeout)
+	c, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	future := rootContext.RequestFutureWithContext(c, pid, &MessageEnvelope{Header: map[string]string{"echo": "hi"}, Message: "hello"})
+	res, err := future.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res)
+	assert.Equal(t, "hi", future.ResponseHeader().Get("echo"))
+	assert.Equal(t, time.Unix(0, deadline.UnixNano()).String(), future.ResponseHeader().Get("deadline"), "the request expires with ctx")
+
+	// the future fails once ctx is done
+	c, cancel = context.WithCancel(context.Background())
+	future = rootContext.RequestFutureWithContext(c, pid, "ignore")
+	cancel()
+	assert.Equal(t, context.Canceled, future.Wait())
+	assert.Nil(t, future.ResponseHeader())
+
+	c, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = rootContext.RequestFutureWithContext(c, pid, "ignore").Wait()
+	assert.True(t, err == ErrTimeout || err == context.DeadlineExceeded, err)
+}
+
 func assertFutureSuccess(future *Future, t *testing.T) interface{} {
 	res, err := future.Result()
 	assert.NoError(t, err, "timed out")
@@ -0,0 +1,73 @@
+package actor
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// goContext is the context.Context of an actor, and the one of the message being processed
+type goContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// message is derived for the current message, nil until asked for. It is released by its deadline or the
+	// cancellation of ctx rather than after the message, the blocking calls may outlive it
+	message       context.Context
+	messageCancel context.CancelFunc
+}
+
+func (ctx *actorContext) GoContext() context.Context {
+	extras := ctx.ensureExtras()
+	if extras.goContext == nil {
+		c, cancel := context.WithCancel(context.Background())
+		extras.goContext = &goContext{ctx: c, cancel: cancel}
+		if atomic.LoadInt32(&ctx.state) >= stateStopping {
+			cancel()
+		}
+	}
+	return extras.goContext.ctx
+}
+
+func (ctx *actorContext) MessageGoContext() context.Context {
+	parent := ctx.GoContext()
+	envelope, ok := ctx.messageOrEnvelope.(*MessageEnvelope)
+	if !ok || envelope.Header == nil {
+		return parent
+	}
+	expiresAt, ok := envelope.ExpiresAt()
+	if !ok {
+		return parent
+	}
+	g := ctx.extras.goContext
+	if g.message == nil {
+		g.message, g.messageCancel = context.WithDeadline(parent, expiresAt)
+	}
+	return g.message
+}
+
+// resetMessageGoContext forgets the context of the previous message
+func (ctx *actorContext) resetMessageGoContext() {
+	if ctx.extras != nil && ctx.extras.goContext != nil {
+		ctx.extras.goContext.message, ctx.extras.goContext.messageCancel = nil, nil
+	}
+}
+
+// cancelGoContext cancels the context of the stopping actor, before it receives Stopping
+func (ctx *actorContext) cancelGoContext() {
+	if ctx.extras != nil && ctx.extras.goContext != nil {
+		ctx.extras.goContext.cancel()
+	}
+}
+
+func (ctx *actorContext) RunBlockingContext(run func(c context.Context) (interface{}, error), cont func(res interface{}, err error)) {
+	c := ctx.MessageGoContext()
+	ctx.runBlocking(func() (interface{}, error) {
+		return run(c)
+	}, func(res interface{}, err error) {
+		if atomic.LoadInt32(&ctx.state) == stateStopped {
+			// run was aborted by the stop of the actor
+			return
+		}
+		cont(res, err)
+	}, false)
+}
@@ -0,0 +1,120 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type slowQuery struct{}
+
+// slowDB is a database client whose queries take a minute, unless their context is done first
+type slowDB struct {
+	started chan struct{}
+}
+
+func (db *slowDB) Query(c context.Context) (interface{}, error) {
+	db.started <- struct{}{}
+	select {
+	case <-time.After(time.Minute):
+		return "rows", nil
+	case <-c.Done():
+		return nil, c.Err()
+	}
+}
+
+func TestGoContext_CancelledWhenStopping(t *testing.T) {
+	db := &slowDB{started: make(chan struct{}, 1)}
+	results := make(chan error, 1)
+	stopping := make(chan error, 1)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *slowQuery:
+			ctx.RunBlockingContext(db.Query, func(res interface{}, err error) {
+				results <- err
+			})
+		case *Stopping:
+			stopping <- ctx.GoContext().Err()
+		}
+	}))
+	rootContext.Send(pid, &slowQuery{})
+	<-db.started
+
+	start := time.Now()
+	require.NoError(t, rootContext.StopFuture(pid).Wait())
+	assert.Equal(t, context.Canceled, <-stopping, "the context is cancelled before Stopping")
+	select {
+	case err := <-results:
+		t.Fatalf("the continuation ran after the actor stopped: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	assert.Less(t, int64(time.Since(start)), int64(time.Second), "the query aborted promptly")
+}
+
+func TestGoContext_QueryAbortedBeforeContinuation(t *testing.T) {
+	db := &slowDB{started: make(chan struct{}, 1)}
+	aborted := make(chan error, 1)
+	var goCtx context.Context
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*slowQuery); ok {
+			goCtx = ctx.GoContext()
+			ctx.RunBlocking(func() (interface{}, error) {
+				res, err := db.Query(goCtx)
+				aborted <- err
+				return res, err
+			}, func(res interface{}, err error) {})
+		}
+	}))
+	rootContext.Send(pid, &slowQuery{})
+	<-db.started
+	rootContext.Stop(pid)
+
+	select {
+	case err := <-aborted:
+		assert.True(t, errors.Is(err, context.Canceled))
+	case <-time.After(testTimeout):
+		t.Fatal("the query was not cancelled")
+	}
+}
+
+func TestMessageGoContext_Deadline(t *testing.T) {
+	db := &slowDB{started: make(chan struct{}, 2)}
+	results := make(chan error, 2)
+	deadlines := make(chan bool, 2)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*slowQuery); ok {
+			_, hasDeadline := ctx.MessageGoContext().Deadline()
+			deadlines <- hasDeadline
+			ctx.RunBlockingContext(db.Query, func(res interface{}, err error) {
+				results <- err
+			})
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	envelope := WrapEnvelope(&slowQuery{})
+	envelope.SetTTL(20 * time.Millisecond)
+	rootContext.Send(pid, envelope)
+	assert.True(t, <-deadlines)
+	select {
+	case err := <-results:
+		assert.Equal(t, context.DeadlineExceeded, err)
+	case <-time.After(testTimeout):
+		t.Fatal("the query did not time out")
+	}
+
+	// without a TTL the query runs until the actor stops
+	rootContext.Send(pid, &slowQuery{})
+	assert.False(t, <-deadlines)
+	<-db.started
+	<-db.started
+	select {
+	case err := <-results:
+		t.Fatalf("the query ended: %v", err)
+	case <-time.After(30 * time.Millisecond):
+	}
+}
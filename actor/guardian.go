@@ -50,12 +50,17 @@ type guardianProcess struct {
 }
 
 func (g *guardianProcess) SendUserMessage(pid *PID, message interface{}) {
+	if _, ok := message.(*ChildRestarted); ok {
+		// the guardians keep no state for their children
+		return
+	}
 	panic(errors.New("guardian actor cannot receive any user messages"))
 }
 
 func (g *guardianProcess) SendSystemMessage(pid *PID, message interface{}) {
 	if msg, ok := message.(*Failure); ok {
-		g.strategy.HandleFailure(g.guardians.actorSystem, g, msg.Who, msg.RestartStats, msg.Reason, msg.Message)
+		supervisor := &failureSupervisor{Supervisor: g, system: g.guardians.actorSystem, failure: msg}
+		g.strategy.HandleFailure(g.guardians.actorSystem, supervisor, msg.Who, msg.RestartStats, msg.Reason, msg.Message)
 	}
 }
 
@@ -77,9 +82,15 @@ func (g *guardianProcess) RestartChildren(pids ...*PID) {
 	}
 }
 
+func (g *guardianProcess) RestartChildrenWithRedelivery(pids ...*PID) {
+	for _, pid := range pids {
+		pid.sendSystemMessage(g.guardians.actorSystem, restartWithRedeliveryMessage)
+	}
+}
+
 func (g *guardianProcess) StopChildren(pids ...*PID) {
 	for _, pid := range pids {
-		pid.sendSystemMessage(g.guardians.actorSystem, stopMessage)
+		pid.sendSystemMessage(g.guardians.actorSystem, &Stop{Reason: StopReason_SupervisorStopped})
 	}
 }
 
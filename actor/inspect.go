@@ -0,0 +1,112 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ActorState is the lifecycle state of an inspected actor
+type ActorState string
+
+const (
+	ActorAlive      ActorState = "alive"
+	ActorRestarting ActorState = "restarting"
+	ActorStopping   ActorState = "stopping"
+	ActorStopped    ActorState = "stopped"
+	// ActorUnresponsive is the state of an actor busy with a message for longer than it was inspected
+	ActorUnresponsive ActorState = "unresponsive"
+)
+
+// ActorInfo is the state of an actor when inspected
+type ActorInfo struct {
+	PID   *PID
+	Type  string
+	State ActorState
+	// Children are unknown when the actor is unresponsive
+	Children      []*PID
+	MailboxLength int
+	Restarts      int
+	Watchers      int
+	// MaxChildren is the limit of the children of the actor, zero when they are not limited
+	MaxChildren int
+	// History are the last messages the actor processed, when it has a message history
+	History []MessageRecord
+}
+
+// ErrNotLocalActor is returned when inspecting a process which is not a local actor
+var ErrNotLocalActor = errors.New("actor: not a local actor")
+
+// inspectActor asks an actor for its ActorInfo, it is answered between two messages
+type inspectActor struct {
+	reply chan *ActorInfo
+}
+
+func (*inspectActor) SystemMessage() {}
+
+// Inspect returns the state of the local actor pid. The actor answers between two of its messages,
+// it is reported unresponsive when it does not answer within timeout
+func (as *ActorSystem) Inspect(pid *PID, timeout time.Duration) (*ActorInfo, error) {
+	process, ok := as.ProcessRegistry.GetLocal(pid.Id)
+	if !ok {
+		return nil, ErrNotLocalActor
+	}
+	actorProcess, ok := process.(*ActorProcess)
+	if !ok {
+		return nil, ErrNotLocalActor
+	}
+
+	request := &inspectActor{reply: make(chan *ActorInfo, 1)}
+	actorProcess.SendSystemMessage(pid, request)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var info *ActorInfo
+	select {
+	case info = <-request.reply:
+	case <-timer.C:
+		info = &ActorInfo{PID: pid, State: ActorUnresponsive}
+	}
+	if mb, ok := actorProcess.mailbox.(interface{ UserMessageCount() int }); ok {
+		info.MailboxLength = mb.UserMessageCount()
+	}
+	return info, nil
+}
+
+// TopLevelActors returns the local actors spawned from the root context
+func (as *ActorSystem) TopLevelActors() []*PID {
+	var pids []*PID
+	as.ProcessRegistry.forEachLocal(func(id string, process Process) {
+		if actorProcess, ok := process.(*ActorProcess); ok && actorProcess.parentPID() == nil && atomic.LoadInt32(&actorProcess.dead) == 0 {
+			pids = append(pids, as.NewLocalPID(id))
+		}
+	})
+	return pids
+}
+
+func (ctx *actorContext) inspect() *ActorInfo {
+	info := &ActorInfo{
+		PID:         ctx.self,
+		Type:        fmt.Sprintf("%T", ctx.actor),
+		Children:    ctx.Children(),
+		MaxChildren: ctx.props.maxChildren,
+	}
+	if ctx.extras != nil {
+		info.Restarts = ctx.extras.restarts
+		info.Watchers = ctx.extras.watchers.Len()
+	}
+	if history := ctx.messageHistory(); history != nil {
+		info.History = history.get()
+	}
+	switch atomic.LoadInt32(&ctx.state) {
+	case stateRestarting:
+		info.State = ActorRestarting
+	case stateStopping:
+		info.State = ActorStopping
+	case stateStopped:
+		info.State = ActorStopped
+	default:
+		info.State = ActorAlive
+	}
+	return info
+}
@@ -0,0 +1,187 @@
+package actor
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// ActorHeartbeat is published on the system event stream by the actors with a liveness, see Props.WithLiveness,
+// when they start and then every interval while they make progress
+type ActorHeartbeat struct {
+	PID             *PID
+	LastMessageType string
+	Processed       uint64
+	timeout         time.Duration // how long the liveness watchdog waits for the next heartbeat
+}
+
+// ActorUnresponsiveEvent is published on the system event stream when an actor with a liveness published no
+// heartbeat for longer than its timeout while it is still alive: it is stuck processing a message. It is published
+// once until the actor publishes heartbeats again
+type ActorUnresponsiveEvent struct {
+	PID             *PID
+	LastMessageType string
+	Processed       uint64
+	Silence         time.Duration
+}
+
+type livenessConfig struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// liveness publishes the heartbeats of an actor, when it processed a message or from a timer while it is idle
+type liveness struct {
+	system   *ActorSystem
+	pid      *PID
+	interval time.Duration
+	timeout  time.Duration
+
+	processed uint64
+	busy      int32
+	stopped   int32
+	lastBeat  int64 // unix nanoseconds
+	lastType  atomic.Value
+	timer     Timer
+}
+
+func (ctx *actorContext) startLiveness() {
+	l := &liveness{
+		system:   ctx.actorSystem,
+		pid:      ctx.self,
+		interval: ctx.props.liveness.interval,
+		timeout:  ctx.props.liveness.timeout,
+	}
+	l.lastType.Store("")
+	ctx.ensureExtras().liveness = l
+	l.beat()
+	l.timer = ctx.actorSystem.Config.Clock.AfterFunc(l.interval, l.tick)
+	ctx.actorSystem.liveness.start()
+}
+
+// begin marks the actor as processing a message, it publishes no heartbeat until it is done
+func (l *liveness) begin() {
+	atomic.StoreInt32(&l.busy, 1)
+}
+
+func (l *liveness) end(message interface{}) {
+	_, msg, _ := UnwrapEnvelope(message)
+	l.lastType.Store(fmt.Sprintf("%T", msg))
+	atomic.AddUint64(&l.processed, 1)
+	atomic.StoreInt32(&l.busy, 0)
+	if l.system.Config.Clock.Now().UnixNano()-atomic.LoadInt64(&l.lastBeat) >= int64(l.interval) {
+		l.beat()
+	}
+}
+
+// idle marks the actor as done with its message, when it failed processing it
+func (l *liveness) idle() {
+	atomic.StoreInt32(&l.busy, 0)
+}
+
+func (l *liveness) tick() {
+	if atomic.LoadInt32(&l.stopped) == 1 {
+		return
+	}
+	if atomic.LoadInt32(&l.busy) == 0 {
+		l.beat()
+	}
+	l.timer.Reset(l.interval)
+}
+
+func (l *liveness) stop() {
+	atomic.StoreInt32(&l.stopped, 1)
+	l.timer.Stop()
+}
+
+func (l *liveness) beat() {
+	atomic.StoreInt64(&l.lastBeat, l.system.Config.Clock.Now().UnixNano())
+	l.system.SystemEventStream.Publish(&ActorHeartbeat{
+		PID:             l.pid,
+		LastMessageType: l.lastType.Load().(string),
+		Processed:       atomic.LoadUint64(&l.processed),
+		timeout:         l.timeout,
+	})
+}
+
+// livenessWatchdog tracks the heartbeats of the actors, it publishes ActorUnresponsiveEvent for the actors silent
+// longer than their timeout while they are still registered
+type livenessWatchdog struct {
+	system *ActorSystem
+	once   sync.Once
+
+	mu     sync.Mutex
+	actors map[string]*watchedActor // by PID id
+}
+
+type watchedActor struct {
+	last         *ActorHeartbeat
+	lastSeen     time.Time
+	timer        Timer
+	unresponsive bool
+}
+
+func newLivenessWatchdog(system *ActorSystem) *livenessWatchdog {
+	return &livenessWatchdog{system: system, actors: make(map[string]*watchedActor)}
+}
+
+// start subscribes the watchdog to the heartbeats, once the first actor with a liveness starts
+func (w *livenessWatchdog) start() {
+	w.once.Do(func() {
+		w.system.SystemEventStream.Subscribe(func(evt interface{}) {
+			w.heartbeat(evt.(*ActorHeartbeat))
+		}).WithPredicate(func(evt interface{}) bool {
+			_, ok := evt.(*ActorHeartbeat)
+			return ok
+		})
+	})
+}
+
+func (w *livenessWatchdog) heartbeat(beat *ActorHeartbeat) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	clock := w.system.Config.Clock
+	watched, ok := w.actors[beat.PID.Id]
+	if !ok {
+		watched = &watchedActor{}
+		id := beat.PID.Id
+		watched.timer = clock.AfterFunc(beat.timeout, func() { w.expire(id) })
+		w.actors[id] = watched
+	} else {
+		watched.timer.Reset(beat.timeout)
+	}
+	if watched.unresponsive {
+		plog.Info("actor is responsive again", log.Stringer("pid", beat.PID))
+	}
+	watched.last, watched.lastSeen, watched.unresponsive = beat, clock.Now(), false
+}
+
+func (w *livenessWatchdog) expire(id string) {
+	w.mu.Lock()
+	watched, ok := w.actors[id]
+	if !ok || watched.unresponsive {
+		w.mu.Unlock()
+		return
+	}
+	if _, registered := w.system.ProcessRegistry.GetLocal(id); !registered {
+		// it stopped
+		delete(w.actors, id)
+		w.mu.Unlock()
+		return
+	}
+	watched.unresponsive = true
+	event := &ActorUnresponsiveEvent{
+		PID:             watched.last.PID,
+		LastMessageType: watched.last.LastMessageType,
+		Processed:       watched.last.Processed,
+		Silence:         w.system.Config.Clock.Now().Sub(watched.lastSeen),
+	}
+	w.mu.Unlock()
+
+	plog.Error("actor is unresponsive", log.Stringer("pid", event.PID), log.String("lastMessage", event.LastMessageType),
+		log.Duration("silence", event.Silence))
+	w.system.SystemEventStream.Publish(event)
+}
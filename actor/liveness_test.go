@@ -0,0 +1,61 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveness_BlockedActorIsUnresponsive(t *testing.T) {
+	events := eventstream.NewEventStream()
+	system := NewActorSystem(WithSystemEventStream(events))
+	heartbeats := make(chan *ActorHeartbeat, 100)
+	unresponsive := make(chan *ActorUnresponsiveEvent, 10)
+	events.Subscribe(func(evt interface{}) {
+		switch evt := evt.(type) {
+		case *ActorHeartbeat:
+			heartbeats <- evt
+		case *ActorUnresponsiveEvent:
+			unresponsive <- evt
+		}
+	})
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if d, ok := ctx.Message().(time.Duration); ok {
+			time.Sleep(d)
+		}
+	}).WithLiveness(10*time.Millisecond, 50*time.Millisecond))
+
+	// the idle actor keeps beating
+	system.Root.Send(pid, "hello")
+	time.Sleep(100 * time.Millisecond)
+	assert.GreaterOrEqual(t, len(heartbeats), 5)
+	assert.Len(t, unresponsive, 0)
+
+	system.Root.Send(pid, 200*time.Millisecond)
+	var evt *ActorUnresponsiveEvent
+	select {
+	case evt = <-unresponsive:
+	case <-time.After(testTimeout):
+		t.Fatal("the blocked actor was not reported")
+	}
+	assert.Equal(t, pid, evt.PID)
+	assert.Equal(t, "string", evt.LastMessageType)
+	assert.Equal(t, uint64(2), evt.Processed, "Started and hello")
+	assert.GreaterOrEqual(t, int64(evt.Silence), int64(50*time.Millisecond))
+
+	// it beats again once unblocked, and is not reported once stopped
+	require.Eventually(t, func() bool {
+		for len(heartbeats) > 0 {
+			if beat := <-heartbeats; beat.LastMessageType == "time.Duration" {
+				return true
+			}
+		}
+		return false
+	}, testTimeout, time.Millisecond)
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, unresponsive, 0)
+}
@@ -0,0 +1,57 @@
+package actor
+
+// LocalKey identifies a value stored in an actor with SetLocal, keys are compared by identity so that
+// the values of two middlewares never collide. Declare a key once, typically in a package variable or
+// when the middleware is built, and share it between the incarnations of the actors.
+//
+// The values are owned by the actor: they are only read and written from the actor while it processes a message,
+// and the storage holds them until the actor stops, after its Stopped message. They are dropped when the actor
+// restarts, before its Started message, unless KeepOnRestart is set. A value referencing resources to release
+// must be released by its owner on Stopped, or on Restarting when it is not kept
+type LocalKey struct {
+	// Name describes the key, it is not used to tell keys apart
+	Name string
+	// KeepOnRestart keeps the value across the restarts of the actor
+	KeepOnRestart bool
+}
+
+// GetOrSetLocal returns the value stored under key in the actor, it stores the value create returns when there is none
+func GetOrSetLocal(ctx ReceiverContext, key *LocalKey, create func() interface{}) interface{} {
+	if value, ok := ctx.GetLocal(key); ok {
+		return value
+	}
+	value := create()
+	ctx.SetLocal(key, value)
+	return value
+}
+
+func (ctx *actorContext) SetLocal(key *LocalKey, value interface{}) {
+	extras := ctx.ensureExtras()
+	if extras.locals == nil {
+		extras.locals = make(map[*LocalKey]interface{})
+	}
+	extras.locals[key] = value
+}
+
+func (ctx *actorContext) GetLocal(key *LocalKey) (interface{}, bool) {
+	if ctx.extras == nil {
+		return nil, false
+	}
+	value, ok := ctx.extras.locals[key]
+	return value, ok
+}
+
+func (ctx *actorContext) DeleteLocal(key *LocalKey) {
+	if ctx.extras != nil {
+		delete(ctx.extras.locals, key)
+	}
+}
+
+// dropLocals forgets the values which are not kept across restarts
+func (ctxExt *actorContextExtras) dropLocals() {
+	for key := range ctxExt.locals {
+		if !key.KeepOnRestart {
+			delete(ctxExt.locals, key)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package actor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAliasedSystem returns a system addressed with address, released at the end of the test
+func newAliasedSystem(t *testing.T, address string) *actor.ActorSystem {
+	system := actor.NewActorSystem(actor.WithLocalAddress(address))
+	t.Cleanup(system.ReleaseLocalAddress)
+	return system
+}
+
+func TestWithLocalAddress_SendsAcrossSystems(t *testing.T) {
+	app := newAliasedSystem(t, "app")
+	tooling := newAliasedSystem(t, "tooling")
+	probe := testkit.NewTestProbe(tooling)
+	defer probe.Stop()
+
+	echo := app.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Respond(msg)
+		}
+	}))
+	defer func() { _ = app.Root.StopFuture(echo).Wait() }()
+	assert.Equal(t, "app", echo.Address)
+	assert.Equal(t, "tooling", probe.PID().Address)
+
+	probe.Send(echo, "hello")
+	probe.ExpectMsg(t, "hello", 0)
+	res, err := tooling.Root.RequestFuture(echo, "ask", time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "ask", res)
+
+	probe.Watch(echo)
+	_ = app.Root.StopFuture(echo).Wait()
+	probe.ExpectTerminated(t, echo, 0)
+}
+
+func TestWithLocalAddress_DistinctPIDs(t *testing.T) {
+	app := newAliasedSystem(t, "app")
+	tooling := newAliasedSystem(t, "tooling")
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+
+	a, err := app.Root.SpawnNamed(props, "same")
+	assert.NoError(t, err)
+	b, err := tooling.Root.SpawnNamed(props, "same")
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, b)
+
+	host, port, err := app.GetHostPort()
+	assert.NoError(t, err)
+	assert.Equal(t, "nonhost", host)
+	assert.Equal(t, -1, port)
+}
+
+func TestWithLocalAddress_InUse(t *testing.T) {
+	system := newAliasedSystem(t, "app")
+	assert.Panics(t, func() { actor.NewActorSystem(actor.WithLocalAddress("app")) })
+
+	system.ReleaseLocalAddress()
+	reused := newAliasedSystem(t, "app")
+	assert.Equal(t, "app", reused.Address())
+}
@@ -0,0 +1,97 @@
+package actor_test
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/stretchr/testify/assert"
+)
+
+type locals struct {
+	Kept, Dropped interface{}
+}
+
+var (
+	keptKey    = &actor.LocalKey{Name: "kept", KeepOnRestart: true}
+	droppedKey = &actor.LocalKey{Name: "dropped"}
+)
+
+// spawnLocals spawns an actor storing its strings under both keys, reporting its locals on "get" and on Stopped
+func spawnLocals(system *actor.ActorSystem, probe *testkit.TestProbe) *actor.PID {
+	return system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		report := func() {
+			kept, _ := ctx.GetLocal(keptKey)
+			dropped, _ := ctx.GetLocal(droppedKey)
+			ctx.Send(probe.PID(), &locals{Kept: kept, Dropped: dropped})
+		}
+		switch msg := ctx.Message().(type) {
+		case *actor.Stopped:
+			report()
+		case string:
+			switch msg {
+			case "get":
+				report()
+			case "fail":
+				panic(msg)
+			case "delete":
+				ctx.DeleteLocal(droppedKey)
+			default:
+				ctx.SetLocal(keptKey, msg)
+				ctx.SetLocal(droppedKey, msg)
+			}
+		}
+	}))
+}
+
+func TestLocal_KeptAcrossMessages(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	pid := spawnLocals(system, probe)
+
+	system.Root.Send(pid, "get")
+	probe.ExpectMsg(t, &locals{}, 0)
+	system.Root.Send(pid, "v")
+	system.Root.Send(pid, "get")
+	probe.ExpectMsg(t, &locals{Kept: "v", Dropped: "v"}, 0)
+	system.Root.Send(pid, "delete")
+	system.Root.Send(pid, "get")
+	probe.ExpectMsg(t, &locals{Kept: "v"}, 0)
+
+	// the locals are still there when the actor is stopped
+	assert.NoError(t, system.Root.StopFuture(pid).Wait())
+	probe.ExpectMsg(t, &locals{Kept: "v"}, 0)
+}
+
+func TestLocal_DroppedOnRestartUnlessKept(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	pid := spawnLocals(system, probe)
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	system.Root.Send(pid, "v")
+	system.Root.Send(pid, "fail")
+	system.Root.Send(pid, "get")
+	probe.ExpectMsg(t, &locals{Kept: "v"}, 0)
+}
+
+func TestGetOrSetLocal(t *testing.T) {
+	system := actor.NewActorSystem()
+	created := make(chan int, 10)
+	key := &actor.LocalKey{Name: "counter"}
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			counter := actor.GetOrSetLocal(ctx, key, func() interface{} { return new(int) }).(*int)
+			*counter++
+			created <- *counter
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	system.Root.Send(pid, "a")
+	system.Root.Send(pid, "b")
+	assert.Equal(t, 1, <-created)
+	assert.Equal(t, 2, <-created)
+}
@@ -0,0 +1,197 @@
+package actor
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// Copyable is implemented by the messages copying themselves, CopyMessage uses it rather than reflection
+type Copyable interface {
+	// DeepCopy returns a copy of the message sharing no mutable state with it
+	DeepCopy() interface{}
+}
+
+// UncopyableError is returned by CopyMessage when the message holds values which cannot be copied, as channels,
+// functions and unsafe pointers
+type UncopyableError struct {
+	MessageType string
+	// Path is the first value which could not be copied, e.g. ".Results"
+	Path string
+	Kind reflect.Kind
+}
+
+func (e *UncopyableError) Error() string {
+	return fmt.Sprintf("cannot copy the %v at %v of %v", e.Kind, e.Path, e.MessageType)
+}
+
+// CopyMessage returns a deep copy of message, so that the sender and the receiver share no mutable state. The protobuf
+// messages are copied with proto.Clone, the Copyable ones with DeepCopy and the others by reflection. The PIDs and the
+// unexported fields of structs are shared, they are not meant to be mutated. The envelopes are copied with a copy of
+// their message.
+// The channels, functions and unsafe pointers cannot be copied: they are shared, and an UncopyableError is returned
+// with the copy
+func CopyMessage(message interface{}) (interface{}, error) {
+	if envelope, ok := message.(*MessageEnvelope); ok {
+		copied, err := copyValue(envelope.Message)
+		if envelope.pool != nil {
+			// the pooled envelopes are owned by the system, not by the sender
+			envelope.Message = copied
+			return envelope, err
+		}
+		res := envelope.Copy()
+		res.Message = copied
+		return res, err
+	}
+	return copyValue(message)
+}
+
+func copyValue(message interface{}) (interface{}, error) {
+	switch msg := message.(type) {
+	case nil, string, *PID, SystemMessage, AutoReceiveMessage:
+		return message, nil
+	case Copyable:
+		return msg.DeepCopy(), nil
+	case proto.Message:
+		return proto.Clone(msg), nil
+	}
+	c := &copier{seen: make(map[uintptr]reflect.Value)}
+	res := c.copy(reflect.ValueOf(message))
+	if c.err != nil {
+		c.err.MessageType = fmt.Sprintf("%T", message)
+		return res.Interface(), c.err
+	}
+	return res.Interface(), nil
+}
+
+var (
+	pidType      = reflect.TypeOf((*PID)(nil))
+	copyableType = reflect.TypeOf((*Copyable)(nil)).Elem()
+	protoType    = reflect.TypeOf((*proto.Message)(nil)).Elem()
+)
+
+// copier copies a value by reflection, the pointers seen are copied once so that the copy keeps the aliases and
+// the cycles of the value
+type copier struct {
+	seen map[uintptr]reflect.Value
+	err  *UncopyableError // the first value which could not be copied
+}
+
+func (c *copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		return c.copyPointer(v)
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type()).Elem()
+		res.Set(c.copy(v.Elem()))
+		return res
+	case reflect.Struct:
+		res := reflect.New(v.Type()).Elem()
+		res.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := res.Field(i); field.CanSet() {
+				field.Set(c.copyAt(v.Field(i), func() string { return "." + v.Type().Field(i).Name }))
+			}
+		}
+		return res
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if isPlain(v.Type().Elem().Kind()) {
+			reflect.Copy(res, v)
+			return res
+		}
+		c.copyElements(res, v)
+		return res
+	case reflect.Array:
+		res := reflect.New(v.Type()).Elem()
+		c.copyElements(res, v)
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(iter.Key(), c.copyAt(iter.Value(), func() string { return fmt.Sprintf("[%v]", iter.Key()) }))
+		}
+		return res
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if !v.IsNil() && c.err == nil {
+			c.err = &UncopyableError{Kind: v.Kind()}
+		}
+		return v
+	}
+	return v
+}
+
+// copyAt copies v, the value at segment of its parent. The path of the first value which could not be copied is
+// built as the copy returns from it
+func (c *copier) copyAt(v reflect.Value, segment func() string) reflect.Value {
+	failed := c.err != nil
+	res := c.copy(v)
+	if !failed && c.err != nil {
+		c.err.Path = segment() + c.err.Path
+	}
+	return res
+}
+
+func (c *copier) copyElements(res, v reflect.Value) {
+	for i := 0; i < v.Len(); i++ {
+		res.Index(i).Set(c.copyAt(v.Index(i), func() string { return fmt.Sprintf("[%v]", i) }))
+	}
+}
+
+func (c *copier) copyPointer(v reflect.Value) reflect.Value {
+	if v.IsNil() || v.Type() == pidType {
+		return v
+	}
+	if copied, ok := c.seen[v.Pointer()]; ok {
+		return copied
+	}
+	switch {
+	case v.Type().Implements(copyableType):
+		res := reflect.ValueOf(v.Interface().(Copyable).DeepCopy())
+		if res.Type() == v.Type() {
+			return res
+		}
+	case v.Type().Implements(protoType):
+		return reflect.ValueOf(proto.Clone(v.Interface().(proto.Message)))
+	}
+	res := reflect.New(v.Type().Elem())
+	c.seen[v.Pointer()] = res
+	res.Elem().Set(c.copy(v.Elem()))
+	return res
+}
+
+// isPlain returns whether the values of kind hold no references
+func isPlain(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return true
+	}
+	return false
+}
+
+// copyOnSend copies the messages sent to the local processes when the system copies on send
+func (as *ActorSystem) copyOnSend(pid *PID, message interface{}) interface{} {
+	registry := as.ProcessRegistry
+	if pid.Address != localAddress && pid.Address != registry.Address && pid.Address != registry.localAlias {
+		// the remote messages are serialized
+		return message
+	}
+	copied, err := CopyMessage(message)
+	if err != nil && as.Config.CopyOnSendStrict {
+		panic(err)
+	}
+	return copied
+}
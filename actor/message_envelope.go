@@ -37,10 +37,21 @@ type ReadonlyMessageHeader interface {
 	ToMap() map[string]string
 }
 
+// MessageEnvelope carries a message along with its header and sender.
+//
+// The envelopes of the requests are recycled when Config.EnvelopePooling is on: an envelope is owned by the actor
+// processing it and is released once the actor processed it, it must not be kept by the actor afterwards. Context
+// copies the envelopes it keeps, when forwarding, stashing or awaiting, and a fan out must send copies. The envelopes
+// handed to receiver middlewares and context decorators are not released, they may keep them
 type MessageEnvelope struct {
 	Header  messageHeader
 	Message interface{}
 	Sender  *PID
+
+	// pool is the pool the envelope returns to once processed, nil when it is not pooled
+	pool *envelopePool
+	// released is set once a pooled envelope is released in debug mode
+	released int32
 }
 
 func (envelope *MessageEnvelope) GetHeader(key string) string {
@@ -65,7 +76,17 @@ func WrapEnvelope(message interface{}) *MessageEnvelope {
 	if e, ok := message.(*MessageEnvelope); ok {
 		return e
 	}
-	return &MessageEnvelope{nil, message, nil}
+	return &MessageEnvelope{Message: message}
+}
+
+// Copy returns a copy of the envelope which is not pooled, it can be kept once the envelope is released
+func (envelope *MessageEnvelope) Copy() *MessageEnvelope {
+	envelope.checkReleased()
+	var header messageHeader
+	if envelope.Header != nil {
+		header = envelope.Header.ToMap()
+	}
+	return &MessageEnvelope{Header: header, Message: envelope.Message, Sender: envelope.Sender}
 }
 
 func UnwrapEnvelope(message interface{}) (ReadonlyMessageHeader, interface{}, *PID) {
@@ -95,3 +116,14 @@ func UnwrapEnvelopeSender(message interface{}) *PID {
 	}
 	return nil
 }
+
+// HeaderClassifier returns the classifier of mailbox.Fair keying the messages by the value of their header key,
+// the messages without it share the empty key
+func HeaderClassifier(key string) func(message interface{}) string {
+	return func(message interface{}) string {
+		if env, ok := message.(*MessageEnvelope); ok {
+			return env.GetHeader(key)
+		}
+		return ""
+	}
+}
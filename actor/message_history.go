@@ -0,0 +1,70 @@
+package actor
+
+import (
+	"fmt"
+	"time"
+)
+
+// MessageRecord is a message an actor processed, as recorded in its history, see Props.WithMessageHistory
+type MessageRecord struct {
+	MessageType string
+	Sender      *PID
+	Received    time.Time
+	// Duration is how long the actor processed the message, until it failed for the message it failed with
+	Duration time.Duration
+}
+
+// messageHistory is the ring buffer of the last messages an actor processed, it is only used by the actor
+type messageHistory struct {
+	records []MessageRecord
+	next    int
+	full    bool
+	busy    bool // the last record is the message being processed
+}
+
+func newMessageHistory(size int) *messageHistory {
+	return &messageHistory{records: make([]MessageRecord, size)}
+}
+
+// begin records message, it overwrites the oldest record once the history is full
+func (h *messageHistory) begin(message interface{}, now time.Time) {
+	_, msg, sender := UnwrapEnvelope(message)
+	h.records[h.next] = MessageRecord{MessageType: fmt.Sprintf("%T", msg), Sender: sender, Received: now}
+	h.next++
+	if h.next == len(h.records) {
+		h.next, h.full = 0, true
+	}
+	h.busy = true
+}
+
+// end records how long the message being processed took
+func (h *messageHistory) end(now time.Time) {
+	if !h.busy {
+		return
+	}
+	last := &h.records[(h.next+len(h.records)-1)%len(h.records)]
+	last.Duration = now.Sub(last.Received)
+	h.busy = false
+}
+
+// get returns a copy of the records, the oldest first
+func (h *messageHistory) get() []MessageRecord {
+	if !h.full {
+		return append([]MessageRecord(nil), h.records[:h.next]...)
+	}
+	res := make([]MessageRecord, 0, len(h.records))
+	res = append(res, h.records[h.next:]...)
+	return append(res, h.records[:h.next]...)
+}
+
+// messageHistory returns the history of the actor, nil unless its props have one
+func (ctx *actorContext) messageHistory() *messageHistory {
+	if ctx.props.messageHistory == 0 {
+		return nil
+	}
+	extras := ctx.ensureExtras()
+	if extras.history == nil {
+		extras.history = newMessageHistory(ctx.props.messageHistory)
+	}
+	return extras.history
+}
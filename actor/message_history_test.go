@@ -0,0 +1,84 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type historyPing struct{}
+
+type historyFail struct{}
+
+func historyProps(n int) *Props {
+	return PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *historyFail:
+			panic("failed")
+		case time.Duration:
+			ctx.Respond(true)
+		}
+	}).WithMessageHistory(n)
+}
+
+func TestMessageHistory_InFailure(t *testing.T) {
+	system := NewActorSystem()
+	failures := make(chan *Failure, 10)
+	sub := system.SystemEventStream.Subscribe(func(evt interface{}) {
+		if failure, ok := evt.(*Failure); ok {
+			failures <- failure
+		}
+	})
+	defer system.SystemEventStream.Unsubscribe(sub)
+	pid := system.Root.Spawn(historyProps(6).WithSupervisor(NewOneForOneStrategy(10, time.Second, func(reason interface{}) Directive {
+		return ResumeDirective
+	})))
+	defer system.Root.Stop(pid)
+
+	sender := system.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+	defer system.Root.Stop(sender)
+	for _, msg := range []interface{}{"a", 1, &historyPing{}, 2.5, true, &historyPing{}} {
+		system.Root.RequestWithCustomSender(pid, msg, sender)
+	}
+	system.Root.Send(pid, &historyFail{})
+
+	var failure *Failure
+	select {
+	case failure = <-failures:
+	case <-time.After(testTimeout):
+		t.Fatal("the failure was not published")
+	}
+	assert.Equal(t, pid, failure.Who)
+	require.Len(t, failure.History, 6, "the history is bounded")
+	var types []string
+	for _, record := range failure.History {
+		types = append(types, record.MessageType)
+	}
+	assert.Equal(t, []string{"int", "*actor.historyPing", "float64", "bool", "*actor.historyPing", "*actor.historyFail"}, types)
+	assert.Equal(t, sender, failure.History[0].Sender)
+	assert.Nil(t, failure.History[5].Sender)
+	assert.False(t, failure.History[0].Received.IsZero())
+}
+
+func TestMessageHistory_Inspected(t *testing.T) {
+	pid := rootContext.Spawn(historyProps(10))
+	defer rootContext.Stop(pid)
+	_, err := rootContext.RequestFuture(pid, time.Millisecond, testTimeout).Result()
+	require.NoError(t, err)
+
+	info, err := system.Inspect(pid, testTimeout)
+	require.NoError(t, err)
+	require.Len(t, info.History, 2)
+	assert.Equal(t, "*actor.Started", info.History[0].MessageType)
+	assert.Equal(t, "time.Duration", info.History[1].MessageType)
+	assert.NotNil(t, info.History[1].Sender)
+
+	// the actors without a history record none
+	plain := rootContext.Spawn(PropsFromFunc(func(ctx Context) {}))
+	defer rootContext.Stop(plain)
+	info, err = system.Inspect(plain, testTimeout)
+	require.NoError(t, err)
+	assert.Nil(t, info.History)
+}
@@ -0,0 +1,71 @@
+package actor
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// ErrMessageTooLarge fails the requests whose message is larger than the limit of a size guard
+var ErrMessageTooLarge = &ActorError{Message: "message too large", Code: "TooLarge"}
+
+// Sizer is implemented by the messages estimating their own size in bytes, as the generated protobuf messages do
+type Sizer interface {
+	Size() int
+}
+
+// MessageSize returns the size of message in bytes, false when it cannot be estimated. The size of a Sizer is its
+// own estimate, the size of the other protobuf messages is the size of their encoding
+func MessageSize(message interface{}) (int, bool) {
+	switch msg := message.(type) {
+	case Sizer:
+		return msg.Size(), true
+	case proto.Message:
+		return proto.Size(msg), true
+	case []byte:
+		return len(msg), true
+	case string:
+		return len(msg), true
+	}
+	return 0, false
+}
+
+// LargeMessageEvent is published on the system event stream when a message larger than the limit of a size guard
+// is let through
+type LargeMessageEvent struct {
+	PID         *PID // the target of the message
+	Sender      *PID
+	MessageType string
+	Size        int
+	Limit       int
+	// Summary is the beginning of the message
+	Summary string
+}
+
+// NewLargeMessageEvent returns the event of message sent to pid, summarized in summaryLength bytes at most
+func NewLargeMessageEvent(pid *PID, envelope *MessageEnvelope, size int, limit int, summaryLength int) *LargeMessageEvent {
+	var summary string
+	switch msg := envelope.Message.(type) {
+	case []byte:
+		if len(msg) > summaryLength {
+			msg = msg[:summaryLength]
+		}
+		summary = string(msg)
+	case string:
+		summary = msg
+	default:
+		summary = fmt.Sprintf("%v", msg)
+	}
+	if len(summary) > summaryLength {
+		summary = summary[:summaryLength]
+	}
+	return &LargeMessageEvent{
+		PID:         pid,
+		Sender:      envelope.Sender,
+		MessageType: reflect.TypeOf(envelope.Message).String(),
+		Size:        size,
+		Limit:       limit,
+		Summary:     summary,
+	}
+}
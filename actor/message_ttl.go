@@ -0,0 +1,37 @@
+package actor
+
+import (
+	"strconv"
+	"time"
+)
+
+// ExpiresAtHeader is the header of the messages with a TTL, it holds the time they expire at in Unix nanoseconds
+const ExpiresAtHeader = "proto-expires-at"
+
+// SetTTL expires the message after ttl, a message expired when the actor is about to receive it goes to the dead
+// letters instead, with the DeadLetterExpired reason
+func (envelope *MessageEnvelope) SetTTL(ttl time.Duration) {
+	envelope.SetHeader(ExpiresAtHeader, strconv.FormatInt(time.Now().Add(ttl).UnixNano(), 10))
+}
+
+// ExpiresAt returns the time the message expires at, if it has a TTL
+func (envelope *MessageEnvelope) ExpiresAt() (time.Time, bool) {
+	value := envelope.GetHeader(ExpiresAtHeader)
+	if value == "" {
+		return time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+func expired(message interface{}) bool {
+	env, ok := message.(*MessageEnvelope)
+	if !ok || env.Header == nil {
+		return false
+	}
+	expiresAt, ok := env.ExpiresAt()
+	return ok && time.Now().After(expiresAt)
+}
@@ -23,6 +23,16 @@ type ReceiveTimeout struct{}
 // A Restarting message is sent to an actor when the actor is being restarted by the system due to a failure
 type Restarting struct{}
 
+// ChildRestarted is sent to the parent of an actor once the actor restarted and processed its Started message, so
+// that the parent rebuilds the state it keeps for its previous incarnation. Reason is the failure it was restarted
+// for, nil when it was restarted by hand, and RestartCount the number of its restarts so far.
+// It is only sent for the actors spawned with Props.WithNotifyParentOnRestart(true)
+type ChildRestarted struct {
+	Who          *PID
+	Reason       interface{}
+	RestartCount int
+}
+
 // A Stopping message is sent to an actor prior to the actor being stopped
 type Stopping struct{}
 
@@ -32,14 +42,22 @@ type Stopped struct{}
 // A Started message is sent to an actor once it has been started and ready to begin receiving messages.
 type Started struct{}
 
-// Restart is message sent by the actor system to control the lifecycle of an actor
-type Restart struct{}
+// Restart is message sent by the actor system to control the lifecycle of an actor. The supervisors restarting
+// an actor after a failure attach its Reason and the Message it was processing, see PreRestartAware
+type Restart struct {
+	Reason  interface{}
+	Message interface{}
+}
 
+// Failure is sent to the supervisor of a failed actor. It is also published on the system event stream with the
+// History of the actor, when it has a message history
 type Failure struct {
 	Who          *PID
 	Reason       interface{}
 	RestartStats *RestartStatistics
 	Message      interface{}
+	// History are the last messages the actor processed, the one it failed with last, see Props.WithMessageHistory
+	History []MessageRecord
 }
 
 type continuation struct {
@@ -62,13 +80,18 @@ func (*Restart) SystemMessage()      {}
 func (*continuation) SystemMessage() {}
 
 var (
-	restartingMessage     interface{} = &Restarting{}
-	stoppingMessage       interface{} = &Stopping{}
-	stoppedMessage        interface{} = &Stopped{}
-	poisonPillMessage     interface{} = &PoisonPill{}
-	receiveTimeoutMessage interface{} = &ReceiveTimeout{}
+	restartingMessage          interface{} = &Restarting{}
+	stoppingMessage            interface{} = &Stopping{}
+	stoppedMessage             interface{} = &Stopped{}
+	poisonPillMessage          interface{} = &PoisonPill{}
+	receiveTimeoutMessage      interface{} = &ReceiveTimeout{}
+	receiveTimeoutFiredMessage interface{} = &receiveTimeoutFired{}
 )
 
+// receiveTimeoutFired is posted by the receive timeout timer, the actor receives ReceiveTimeout if it did not stop
+// nor reset the timer meanwhile
+type receiveTimeoutFired struct{}
+
 var (
 	restartMessage        interface{} = &Restart{}
 	startedMessage        interface{} = &Started{}
@@ -0,0 +1,195 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// EnableAudit starts recording the messages of the actor it is sent to
+type EnableAudit struct{}
+
+// DisableAudit stops recording the messages of the actor it is sent to
+type DisableAudit struct{}
+
+// Auditor records the messages received and sent by the actors audited to a sink
+type Auditor struct {
+	sink   Sink
+	cfg    *config
+	actors sync.Map // the *actorState of the actors by PID
+}
+
+// actorState is only accessed by its actor
+type actorState struct {
+	enabled bool
+	via     Via
+}
+
+// New returns an auditor recording to sink
+func New(sink Sink, opts ...Option) *Auditor {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &Auditor{sink: sink, cfg: cfg}
+}
+
+// WithAudit audits the actors spawned with props, their audit is toggled with EnableAudit and DisableAudit
+func (a *Auditor) WithAudit(props *actor.Props) *actor.Props {
+	return props.
+		WithReceiverMiddleware(a.ReceiverMiddleware()).
+		WithSenderMiddleware(a.SenderMiddleware()).
+		WithContextDecorator(a.ContextDecorator())
+}
+
+// ReceiverMiddleware records the messages received and handles EnableAudit and DisableAudit.
+// The lifecycle and system messages are not recorded
+func (a *Auditor) ReceiverMiddleware() actor.ReceiverMiddleware {
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch envelope.Message.(type) {
+			case *actor.Started:
+				// the audit of a restarted actor stays as it was
+				a.actors.LoadOrStore(c.Self().String(), &actorState{enabled: a.cfg.enabled})
+				next(c, envelope)
+				return
+			case *actor.Stopped:
+				a.actors.Delete(c.Self().String())
+				next(c, envelope)
+				return
+			case actor.SystemMessage, actor.AutoReceiveMessage:
+				next(c, envelope)
+				return
+			case *EnableAudit:
+				if state := a.state(c.Self()); state != nil {
+					state.enabled = true
+				}
+				return
+			case *DisableAudit:
+				if state := a.state(c.Self()); state != nil {
+					state.enabled = false
+				}
+				return
+			}
+
+			if state := a.state(c.Self()); state != nil && state.enabled {
+				a.record(&Record{
+					Direction: Received,
+					Actor:     c.Self().String(),
+					Sender:    pidString(envelope.Sender),
+				}, envelope)
+			}
+			next(c, envelope)
+		}
+	}
+}
+
+// SenderMiddleware records the messages sent
+func (a *Auditor) SenderMiddleware() actor.SenderMiddleware {
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			if state := a.state(c.Self()); state != nil && state.enabled {
+				via := state.via
+				if via == "" {
+					via = ViaSend
+					if envelope.Sender != nil {
+						via = ViaRequest
+					}
+				}
+				a.record(&Record{
+					Direction: Sent,
+					Via:       via,
+					Actor:     c.Self().String(),
+					Sender:    pidString(envelope.Sender),
+					Target:    pidString(target),
+				}, envelope)
+			}
+			next(c, target, envelope)
+		}
+	}
+}
+
+// ContextDecorator tells the sender middleware which messages are responses or forwarded
+func (a *Auditor) ContextDecorator() actor.ContextDecorator {
+	return func(next actor.ContextDecoratorFunc) actor.ContextDecoratorFunc {
+		return func(ctx actor.Context) actor.Context {
+			return next(&auditContext{Context: ctx, auditor: a})
+		}
+	}
+}
+
+type auditContext struct {
+	actor.Context
+	auditor *Auditor
+}
+
+func (ctx *auditContext) Respond(response interface{}) {
+	defer ctx.via(ViaRespond)()
+	ctx.Context.Respond(response)
+}
+
+func (ctx *auditContext) Forward(pid *actor.PID) {
+	defer ctx.via(ViaForward)()
+	ctx.Context.Forward(pid)
+}
+
+// via marks the messages sent until the returned func is called
+func (ctx *auditContext) via(via Via) func() {
+	state := ctx.auditor.state(ctx.Self())
+	if state == nil {
+		return func() {}
+	}
+	state.via = via
+	return func() { state.via = "" }
+}
+
+func (a *Auditor) state(pid *actor.PID) *actorState {
+	if pid == nil {
+		return nil
+	}
+	state, ok := a.actors.Load(pid.String())
+	if !ok {
+		return nil
+	}
+	return state.(*actorState)
+}
+
+func (a *Auditor) record(record *Record, envelope *actor.MessageEnvelope) {
+	record.Time = time.Now()
+	record.MessageType = fmt.Sprintf("%T", envelope.Message)
+	if len(envelope.Header) > 0 {
+		record.Header = envelope.Header.ToMap()
+	}
+	if a.cfg.maxPayload > 0 {
+		record.Payload, record.Truncated = a.payload(record, envelope.Message)
+	}
+	if err := a.sink.Write(record); err != nil {
+		logger.Error("failed to write audit record", log.String("actor", record.Actor), log.Error(err))
+	}
+}
+
+func (a *Auditor) payload(record *Record, message interface{}) (string, bool) {
+	if a.cfg.redact != nil {
+		message = a.cfg.redact(message)
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("failed to capture audit payload", log.String("actor", record.Actor), log.Error(err))
+		return "", false
+	}
+	if len(data) > a.cfg.maxPayload {
+		return string(data[:a.cfg.maxPayload]), true
+	}
+	return string(data), false
+}
+
+func pidString(pid *actor.PID) string {
+	if pid == nil {
+		return ""
+	}
+	return pid.String()
+}
@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type start struct{}
+
+type ping struct {
+	Text     string
+	Password string
+}
+
+type pong struct {
+	Text string
+}
+
+type done struct{}
+
+func redactPassword(message interface{}) interface{} {
+	if p, ok := message.(*ping); ok {
+		redacted := *p
+		redacted.Password = "***"
+		return &redacted
+	}
+	return message
+}
+
+// spawnFlow spawns a client requesting a server, which forwards the requests to a logger and answers them.
+// The client tells the probe once it got the answer
+func spawnFlow(t *testing.T, system *actor.ActorSystem, auditor *Auditor) (client, server *actor.PID, finished chan struct{}) {
+	finished = make(chan struct{}, 10)
+	probe, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*done); ok {
+			finished <- struct{}{}
+		}
+	}), "probe")
+	require.NoError(t, err)
+	logger, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {}), "logger")
+	require.NoError(t, err)
+	server, err = system.Root.SpawnNamed(auditor.WithAudit(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*ping); ok {
+			ctx.Forward(logger)
+			ctx.Respond(&pong{Text: "the answer to " + msg.Text + " is longer than the payload limit"})
+		}
+	})), "server")
+	require.NoError(t, err)
+	client, err = system.Root.SpawnNamed(auditor.WithAudit(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *start:
+			ctx.Request(server, &ping{Text: "hi", Password: "secret"})
+		case *pong:
+			ctx.Send(probe, &done{})
+		}
+	})), "client")
+	require.NoError(t, err)
+	return client, server, finished
+}
+
+func run(t *testing.T, system *actor.ActorSystem, client *actor.PID, finished chan struct{}) {
+	env := &actor.MessageEnvelope{Message: &start{}}
+	env.SetHeader("trace", "1")
+	system.Root.Send(client, env)
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out")
+	}
+}
+
+func TestAudit_ReplayRecordedFlow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := OpenJSONLFile(path)
+	require.NoError(t, err)
+	system := actor.NewActorSystem()
+	client, server, finished := spawnFlow(t, system, New(sink, WithPayloads(48), WithRedaction(redactPassword)))
+
+	run(t, system, client, finished)
+	system.Root.Send(client, &EnableAudit{})
+	system.Root.Send(server, &EnableAudit{})
+	run(t, system, client, finished)
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	recorded, err := ReadJSONL(file)
+	require.NoError(t, err)
+	golden, err := os.Open(filepath.Join("testdata", "flow.jsonl"))
+	require.NoError(t, err)
+	defer golden.Close()
+	expected, err := ReadJSONL(golden)
+	require.NoError(t, err)
+
+	for i := range recorded {
+		assert.False(t, recorded[i].Time.IsZero())
+		recorded[i].Time = time.Time{}
+	}
+	assert.Equal(t, expected, recorded)
+}
+
+func TestAudit_Toggle(t *testing.T) {
+	sink := NewRingSink(100)
+	system := actor.NewActorSystem()
+	client, _, finished := spawnFlow(t, system, New(sink, WithEnabled(true)))
+
+	run(t, system, client, finished)
+	assert.Len(t, sink.Records(), 7)
+
+	system.Root.Send(client, &DisableAudit{})
+	run(t, system, client, finished)
+	assert.Len(t, sink.Records(), 10, "only the server is audited")
+	for _, record := range sink.Records()[7:] {
+		assert.Equal(t, "nonhost/server", record.Actor)
+		assert.Empty(t, record.Payload)
+	}
+}
+
+func TestRingSink(t *testing.T) {
+	sink := NewRingSink(2)
+	for _, messageType := range []string{"a", "b", "c"} {
+		require.NoError(t, sink.Write(&Record{MessageType: messageType}))
+	}
+	assert.Equal(t, []Record{{MessageType: "b"}, {MessageType: "c"}}, sink.Records())
+}
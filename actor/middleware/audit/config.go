@@ -0,0 +1,32 @@
+package audit
+
+type config struct {
+	enabled    bool
+	maxPayload int
+	redact     func(message interface{}) interface{}
+}
+
+// Option configures the audit
+type Option func(*config)
+
+// WithEnabled audits the actors from their start, rather than once they receive EnableAudit
+func WithEnabled(enabled bool) Option {
+	return func(c *config) {
+		c.enabled = enabled
+	}
+}
+
+// WithPayloads captures the JSON of the messages, cut after maxBytes, the payloads are not captured by default
+func WithPayloads(maxBytes int) Option {
+	return func(c *config) {
+		c.maxPayload = maxBytes
+	}
+}
+
+// WithRedaction captures the payload of the message returned by redact rather than the message,
+// e.g. a copy without its secrets
+func WithRedaction(redact func(message interface{}) interface{}) Option {
+	return func(c *config) {
+		c.redact = redact
+	}
+}
@@ -0,0 +1,5 @@
+package audit
+
+import "github.com/AsynkronIT/protoactor-go/log"
+
+var logger = log.New(log.ErrorLevel, "[AUDIT]")
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Direction tells whether the actor audited received or sent a message
+type Direction string
+
+const (
+	Received Direction = "received"
+	Sent     Direction = "sent"
+)
+
+// Via tells how a message was sent
+type Via string
+
+const (
+	ViaSend    Via = "send"
+	ViaRequest Via = "request"
+	ViaRespond Via = "respond"
+	ViaForward Via = "forward"
+)
+
+// Record is a message received or sent by an actor audited
+type Record struct {
+	Time        time.Time         `json:"time"`
+	Direction   Direction         `json:"direction"`
+	Via         Via               `json:"via,omitempty"`
+	Actor       string            `json:"actor"`
+	Sender      string            `json:"sender,omitempty"`
+	Target      string            `json:"target,omitempty"`
+	MessageType string            `json:"message_type"`
+	Header      map[string]string `json:"header,omitempty"`
+	// Payload is the JSON of the message, when the payloads are captured
+	Payload string `json:"payload,omitempty"`
+	// Truncated tells the Payload was cut at the size limit
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// ReadJSONL reads the records written by a JSONL sink
+func ReadJSONL(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// Sink stores the records of the actors audited, it is called concurrently by the actors
+type Sink interface {
+	Write(record *Record) error
+}
+
+// JSONLSink writes the records as JSON lines
+type JSONLSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// NewJSONLSink writes the records as JSON lines to w
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{encoder: json.NewEncoder(w)}
+}
+
+// OpenJSONLFile appends the records as JSON lines to the file at path, which is created if needed
+func OpenJSONLFile(path string) (*JSONLSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	sink := NewJSONLSink(file)
+	sink.closer = file
+	return sink, nil
+}
+
+func (s *JSONLSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.encoder.Encode(record)
+}
+
+// Close closes the file of the sink opened with OpenJSONLFile
+func (s *JSONLSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// RingSink keeps the last records in memory
+type RingSink struct {
+	mu      sync.Mutex
+	records []Record
+	next    int
+	full    bool
+}
+
+// NewRingSink keeps the last capacity records
+func NewRingSink(capacity int) *RingSink {
+	return &RingSink{records: make([]Record, capacity)}
+}
+
+func (s *RingSink) Write(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.records) == 0 {
+		return nil
+	}
+	s.records[s.next] = *record
+	s.next = (s.next + 1) % len(s.records)
+	if s.next == 0 {
+		s.full = true
+	}
+	return nil
+}
+
+// Records returns the records kept, from the oldest
+func (s *RingSink) Records() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.full {
+		return append([]Record(nil), s.records[:s.next]...)
+	}
+	return append(append([]Record(nil), s.records[s.next:]...), s.records[:s.next]...)
+}
@@ -0,0 +1,144 @@
+// Package baggage carries configured message headers, such as tenant or correlation ids, across the actor hops:
+// the actors capture them from the messages they receive and stamp them on the messages they send,
+// and the actors they spawn inherit them
+package baggage
+
+import (
+	"sync"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/middleware/propagator"
+)
+
+// baggages holds the baggage of the actors by id, a baggage is replaced rather than modified
+var baggages = sync.Map{}
+
+func get(id string) map[string]string {
+	value, ok := baggages.Load(id)
+	if !ok {
+		return nil
+	}
+	return value.(map[string]string)
+}
+
+// pick returns baggage with the values of the keys in header, and whether they changed it
+func pick(header actor.ReadonlyMessageHeader, keys []string, baggage map[string]string) (map[string]string, bool) {
+	if header == nil || header.Length() == 0 {
+		return baggage, false
+	}
+	var picked map[string]string
+	for _, key := range keys {
+		value := header.Get(key)
+		if value == "" || value == baggage[key] {
+			continue
+		}
+		if picked == nil {
+			picked = make(map[string]string, len(keys))
+			for k, v := range baggage {
+				picked[k] = v
+			}
+		}
+		picked[key] = value
+	}
+	if picked == nil {
+		return baggage, false
+	}
+	return picked, true
+}
+
+type baggageContext struct {
+	actor.Context
+	keys []string
+}
+
+// Receive captures the keys from the header of the message before it is received
+func (ctx *baggageContext) Receive(envelope *actor.MessageEnvelope) {
+	id := ctx.Self().Id
+	if baggage, changed := pick(envelope.Header, ctx.keys, get(id)); changed {
+		baggages.Store(id, baggage)
+	}
+
+	ctx.Context.Receive(envelope)
+
+	if _, ok := envelope.Message.(*actor.Stopped); ok {
+		baggages.Delete(id)
+	}
+}
+
+// ContextDecorator captures the keys from the headers of the messages the actor receives,
+// they are kept until another message carries other values
+func ContextDecorator(keys ...string) actor.ContextDecorator {
+	return func(next actor.ContextDecoratorFunc) actor.ContextDecoratorFunc {
+		return func(ctx actor.Context) actor.Context {
+			return next(&baggageContext{Context: ctx, keys: keys})
+		}
+	}
+}
+
+// SenderMiddleware stamps the baggage of the actor on the messages it sends, unless they carry the keys already.
+// The messages sent from the root context carry its headers of the keys
+func SenderMiddleware(keys ...string) actor.SenderMiddleware {
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			var baggage map[string]string
+			if self := c.Self(); self != nil {
+				baggage = get(self.Id)
+			} else {
+				baggage, _ = pick(c.MessageHeader(), keys, nil)
+			}
+			for key, value := range baggage {
+				if envelope.GetHeader(key) == "" {
+					envelope.SetHeader(key, value)
+				}
+			}
+			next(c, target, envelope)
+		}
+	}
+}
+
+// inherit makes the actors spawned inherit the baggage of their parent
+func inherit(keys []string) actor.SpawnMiddleware {
+	return func(next actor.SpawnFunc) actor.SpawnFunc {
+		return func(actorSystem *actor.ActorSystem, id string, props *actor.Props, parentContext actor.SpawnerContext) (*actor.PID, error) {
+			var baggage map[string]string
+			if self := parentContext.Self(); self != nil {
+				baggage = get(self.Id)
+			} else if sender, ok := parentContext.(actor.SenderContext); ok {
+				baggage, _ = pick(sender.MessageHeader(), keys, nil)
+			}
+			if baggage == nil {
+				return next(actorSystem, id, props, parentContext)
+			}
+			// an actor with the same id exists already when the spawn fails, it keeps its baggage
+			_, exists := baggages.LoadOrStore(id, baggage)
+			pid, err := next(actorSystem, id, props, parentContext)
+			if err != nil && !exists {
+				baggages.Delete(id)
+			}
+			return pid, err
+		}
+	}
+}
+
+// BaggageMiddleware carries the keys across the actors spawned and their descendants,
+// it is used with RootContext.WithSpawnMiddleware to carry them globally
+func BaggageMiddleware(keys ...string) actor.SpawnMiddleware {
+	inherited := inherit(keys)
+	propagated := propagator.New().
+		WithItselfForwarded().
+		WithSpawnMiddleware(inherited).
+		WithSenderMiddleware(SenderMiddleware(keys...)).
+		WithContextDecorator(ContextDecorator(keys...)).
+		SpawnMiddleware
+	return func(next actor.SpawnFunc) actor.SpawnFunc {
+		return propagated(inherited(next))
+	}
+}
+
+// WithBaggage carries the keys across the actors spawned with props and their descendants
+func WithBaggage(props *actor.Props, keys ...string) *actor.Props {
+	return props.
+		WithSenderMiddleware(SenderMiddleware(keys...)).
+		WithContextDecorator(ContextDecorator(keys...)).
+		WithSpawnMiddleware(BaggageMiddleware(keys...))
+}
@@ -0,0 +1,105 @@
+package baggage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+)
+
+var keys = []string{"tenant", "correlation-id"}
+
+func headers(ctx actor.Context) string {
+	header := ctx.MessageHeader()
+	return fmt.Sprintf("tenant=%s correlation-id=%s other=%s", header.Get("tenant"), header.Get("correlation-id"), header.Get("other"))
+}
+
+// chain spawns a child on start, which asks the replier and reports the headers of the reply.
+// The replier reports the headers of the request
+func chain(probe *testkit.TestProbe, replier *actor.PID) *actor.Props {
+	child := actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			ctx.Request(replier, "hello")
+		case string:
+			ctx.Send(probe.PID(), "child got "+headers(ctx))
+		}
+	})
+	return actor.PropsFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "start" {
+			ctx.Spawn(child)
+		}
+	})
+}
+
+func replierProps(probe *testkit.TestProbe) *actor.Props {
+	return actor.PropsFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "hello" {
+			ctx.Send(probe.PID(), "replier got "+headers(ctx))
+			ctx.Respond("reply")
+		}
+	})
+}
+
+func start(root *actor.RootContext, pid *actor.PID) {
+	envelope := &actor.MessageEnvelope{Message: "start"}
+	envelope.SetHeader("tenant", "acme")
+	envelope.SetHeader("correlation-id", "42")
+	envelope.SetHeader("other", "dropped")
+	root.Send(pid, envelope)
+}
+
+func expectBaggage(t *testing.T, probe *testkit.TestProbe) {
+	probe.ExpectMsg(t, "replier got tenant=acme correlation-id=42 other=", 0)
+	probe.ExpectMsg(t, "child got tenant=acme correlation-id=42 other=", 0)
+}
+
+func TestWithBaggage_ThreeHops(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	replier := system.Root.Spawn(WithBaggage(replierProps(probe), keys...))
+	pid := system.Root.Spawn(WithBaggage(chain(probe, replier), keys...))
+
+	start(system.Root, pid)
+	expectBaggage(t, probe)
+	_ = system.Root.StopFuture(pid).Wait()
+	_ = system.Root.StopFuture(replier).Wait()
+}
+
+func TestBaggageMiddleware_ThreeHops(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	root := actor.NewRootContext(system, nil).WithSpawnMiddleware(BaggageMiddleware(keys...))
+	replier := root.Spawn(replierProps(probe))
+	pid := root.Spawn(chain(probe, replier))
+
+	start(root, pid)
+	expectBaggage(t, probe)
+
+	// the baggage stays until other values are received
+	root.Send(pid, "start")
+	expectBaggage(t, probe)
+	envelope := &actor.MessageEnvelope{Message: "start"}
+	envelope.SetHeader("tenant", "initech")
+	root.Send(pid, envelope)
+	probe.ExpectMsg(t, "replier got tenant=initech correlation-id=42 other=", 0)
+	probe.ExpectMsg(t, "child got tenant=initech correlation-id=42 other=", 0)
+	_ = root.StopFuture(pid).Wait()
+	_ = root.StopFuture(replier).Wait()
+}
+
+func TestSenderMiddleware_RootHeaders(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	root := actor.NewRootContext(system, map[string]string{"tenant": "acme", "other": "dropped"}).
+		WithSenderMiddleware(SenderMiddleware(keys...))
+	replier := root.Spawn(replierProps(probe))
+
+	root.Send(replier, "hello")
+	probe.ExpectMsg(t, "replier got tenant=acme correlation-id= other=", 0)
+	_ = root.StopFuture(replier).Wait()
+}
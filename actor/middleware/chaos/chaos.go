@@ -0,0 +1,241 @@
+// Package chaos injects faults in the flow of messages to test the resilience of the actors: the messages are
+// dropped, delayed, duplicated, reordered or dead lettered, and the actors panic, as its rules say.
+// The faults are drawn from a seeded source, the same messages get the same faults in the same order.
+package chaos
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// SetRules replaces the rules of the chaos whose control actor it is sent to, which responds with its Rules
+type SetRules struct {
+	Rules []Rule
+}
+
+// SetRate sets the rate of the rules injecting Fault, the control actor responds with its Rules
+type SetRate struct {
+	Fault Fault
+	Rate  float64
+}
+
+// GetRules requests the Rules of the control actor
+type GetRules struct{}
+
+// Rules are the rules in effect
+type Rules struct {
+	Rules []Rule
+}
+
+// redelivery is sent to the actor receiving a message later
+type redelivery struct {
+	envelope *actor.MessageEnvelope
+}
+
+// flushHeld receives the messages held for reordering
+type flushHeld struct{}
+
+// Chaos injects the faults of its rules in the actors it is applied to, its rules are changed at runtime
+// through its control actor
+type Chaos struct {
+	pid    *actor.PID
+	rules  atomic.Value // []Rule, replaced as a whole
+	mu     sync.Mutex
+	rng    *rand.Rand
+	actors sync.Map // the *actorState of the actors by PID
+}
+
+// actorState is only accessed by its actor
+type actorState struct {
+	held []*actor.MessageEnvelope
+}
+
+// New returns a chaos injecting the faults of rules, drawn from seed, and spawns its control actor
+func New(system *actor.ActorSystem, seed int64, rules ...Rule) *Chaos {
+	c := &Chaos{rng: rand.New(rand.NewSource(seed))}
+	c.rules.Store(copyRules(rules))
+	c.pid = system.Root.Spawn(actor.PropsFromFunc(c.control))
+	return c
+}
+
+// PID is the control actor, it receives SetRules, SetRate and GetRules
+func (c *Chaos) PID() *actor.PID {
+	return c.pid
+}
+
+// WithChaos injects the faults in the actors spawned with props
+func (c *Chaos) WithChaos(props *actor.Props) *actor.Props {
+	return props.
+		WithReceiverMiddleware(c.ReceiverMiddleware()).
+		WithSenderMiddleware(c.SenderMiddleware())
+}
+
+func (c *Chaos) control(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *SetRules:
+		c.rules.Store(copyRules(msg.Rules))
+	case *SetRate:
+		rules := copyRules(c.loadRules())
+		for i := range rules {
+			if rules[i].Fault == msg.Fault {
+				rules[i].Rate = msg.Rate
+			}
+		}
+		c.rules.Store(rules)
+	case *GetRules:
+	default:
+		return
+	}
+	if ctx.Sender() != nil {
+		ctx.Respond(&Rules{Rules: copyRules(c.loadRules())})
+	}
+}
+
+// ReceiverMiddleware injects all the faults but DeadLetter in the messages received.
+// The lifecycle and system messages are left alone
+func (c *Chaos) ReceiverMiddleware() actor.ReceiverMiddleware {
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(rc actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch msg := envelope.Message.(type) {
+			case *actor.Stopped:
+				c.actors.Delete(rc.Self().String())
+				next(rc, envelope)
+				return
+			case actor.SystemMessage, actor.AutoReceiveMessage:
+				next(rc, envelope)
+				return
+			case *redelivery:
+				next(rc, msg.envelope)
+				return
+			case *flushHeld:
+				c.flush(rc, next)
+				return
+			}
+
+			rule := c.roll(rc.Self(), envelope.Message, false)
+			if rule == nil {
+				next(rc, envelope)
+				return
+			}
+			c.publish(rc.ActorSystem(), rule.Fault, rc.Self(), envelope.Message)
+
+			switch rule.Fault {
+			case Drop:
+			case Delay:
+				ctx, ok := rc.(actor.Context)
+				if !ok {
+					next(rc, envelope)
+					return
+				}
+				ctx.SendLater(rc.Self(), &redelivery{envelope: envelope}, c.delay(rule))
+			case Duplicate:
+				next(rc, envelope)
+				next(rc, envelope)
+			case Panic:
+				panic(&InjectedPanic{Message: envelope.Message})
+			case Reorder:
+				c.hold(rc, next, envelope, rule)
+			}
+		}
+	}
+}
+
+// SenderMiddleware injects DeadLetter in the messages sent, the rules match the targets
+func (c *Chaos) SenderMiddleware() actor.SenderMiddleware {
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(sc actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			switch envelope.Message.(type) {
+			case *redelivery, *flushHeld:
+				next(sc, target, envelope)
+				return
+			}
+
+			if rule := c.roll(target, envelope.Message, true); rule != nil {
+				system := sc.ActorSystem()
+				c.publish(system, rule.Fault, target, envelope.Message)
+				system.DeadLetter.SendUserMessage(target, envelope)
+				return
+			}
+			next(sc, target, envelope)
+		}
+	}
+}
+
+func (c *Chaos) loadRules() []Rule {
+	return c.rules.Load().([]Rule)
+}
+
+// roll returns the first rule matching message to pid whose fault is drawn, the sender rules are the DeadLetter ones
+func (c *Chaos) roll(pid *actor.PID, message interface{}, sender bool) *Rule {
+	for _, rule := range c.loadRules() {
+		if (rule.Fault == DeadLetter) != sender || (rule.Match != nil && !rule.Match(pid, message)) {
+			continue
+		}
+		if c.float64() < rule.Rate {
+			return &rule
+		}
+	}
+	return nil
+}
+
+func (c *Chaos) float64() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rng.Float64()
+}
+
+func (c *Chaos) delay(rule *Rule) time.Duration {
+	if rule.MaxDelay <= rule.MinDelay {
+		return rule.MinDelay
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return rule.MinDelay + time.Duration(c.rng.Int63n(int64(rule.MaxDelay-rule.MinDelay)))
+}
+
+// hold holds envelope until the window of rule fills up or its max delay elapses
+func (c *Chaos) hold(rc actor.ReceiverContext, next actor.ReceiverFunc, envelope *actor.MessageEnvelope, rule *Rule) {
+	state := c.state(rc.Self())
+	state.held = append(state.held, envelope)
+	if len(state.held) >= rule.Window {
+		c.flush(rc, next)
+		return
+	}
+	if len(state.held) == 1 {
+		ctx, ok := rc.(actor.Context)
+		if !ok {
+			c.flush(rc, next)
+			return
+		}
+		ctx.SendLater(rc.Self(), &flushHeld{}, rule.MaxDelay)
+	}
+}
+
+// flush receives the messages held, shuffled
+func (c *Chaos) flush(rc actor.ReceiverContext, next actor.ReceiverFunc) {
+	state := c.state(rc.Self())
+	held := state.held
+	state.held = nil
+
+	c.mu.Lock()
+	c.rng.Shuffle(len(held), func(i, j int) {
+		held[i], held[j] = held[j], held[i]
+	})
+	c.mu.Unlock()
+	for _, envelope := range held {
+		next(rc, envelope)
+	}
+}
+
+func (c *Chaos) state(pid *actor.PID) *actorState {
+	state, _ := c.actors.LoadOrStore(pid.String(), &actorState{})
+	return state.(*actorState)
+}
+
+func (c *Chaos) publish(system *actor.ActorSystem, fault Fault, pid *actor.PID, message interface{}) {
+	system.EventStream.Publish(&FaultInjectedEvent{Fault: fault, PID: pid, Message: message})
+}
@@ -0,0 +1,203 @@
+package chaos
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spawnReceiver spawns an actor forwarding the strings it receives, and answering the requests
+func spawnReceiver(system *actor.ActorSystem, chaos *Chaos) (*actor.PID, chan string) {
+	received := make(chan string, 100)
+	pid := system.Root.Spawn(chaos.WithChaos(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			received <- msg
+			if ctx.Sender() != nil {
+				ctx.Respond(msg)
+			}
+		}
+	})))
+	return pid, received
+}
+
+func subscribeFaults(system *actor.ActorSystem) (chan *FaultInjectedEvent, func()) {
+	faults := make(chan *FaultInjectedEvent, 100)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if injected, ok := evt.(*FaultInjectedEvent); ok {
+			faults <- injected
+		}
+	})
+	return faults, func() { system.EventStream.Unsubscribe(sub) }
+}
+
+func receive(t *testing.T, received chan string, count int) []string {
+	var messages []string
+	for i := 0; i < count; i++ {
+		select {
+		case msg := <-received:
+			messages = append(messages, msg)
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out", "after %v", messages)
+		}
+	}
+	return messages
+}
+
+func TestChaos_Drop(t *testing.T) {
+	system := actor.NewActorSystem()
+	faults, unsubscribe := subscribeFaults(system)
+	defer unsubscribe()
+	chaos := New(system, 1, Rule{Fault: Drop, Rate: 1, Match: MessageType("")})
+	pid, received := spawnReceiver(system, chaos)
+
+	system.Root.Send(pid, "dropped")
+	system.Root.Send(pid, 1)
+	_ = system.Root.PoisonFuture(pid).Wait()
+
+	assert.Empty(t, received)
+	require.Len(t, faults, 1)
+	assert.Equal(t, &FaultInjectedEvent{Fault: Drop, PID: pid, Message: "dropped"}, <-faults)
+}
+
+func TestChaos_Duplicate(t *testing.T) {
+	system := actor.NewActorSystem()
+	chaos := New(system, 1, Rule{Fault: Duplicate, Rate: 1})
+	pid, received := spawnReceiver(system, chaos)
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	system.Root.Send(pid, "twice")
+	assert.Equal(t, []string{"twice", "twice"}, receive(t, received, 2))
+}
+
+func TestChaos_Delay(t *testing.T) {
+	system := actor.NewActorSystem()
+	chaos := New(system, 1, Rule{Fault: Delay, Rate: 1, MinDelay: 50 * time.Millisecond, MaxDelay: 60 * time.Millisecond})
+	pid, _ := spawnReceiver(system, chaos)
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	start := time.Now()
+	res, err := system.Root.RequestFuture(pid, "later", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "later", res, "the sender is kept")
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+}
+
+func TestChaos_Reorder(t *testing.T) {
+	system := actor.NewActorSystem()
+	chaos := New(system, 1, Rule{Fault: Reorder, Rate: 1, Window: 5, MaxDelay: 50 * time.Millisecond})
+	pid, received := spawnReceiver(system, chaos)
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	var sent []string
+	for i := 0; i < 5; i++ {
+		sent = append(sent, fmt.Sprint(i))
+		system.Root.Send(pid, sent[i])
+	}
+	shuffled := receive(t, received, 5)
+	assert.ElementsMatch(t, sent, shuffled)
+	assert.NotEqual(t, sent, shuffled)
+
+	// the window does not fill up
+	system.Root.Send(pid, "a")
+	system.Root.Send(pid, "b")
+	assert.ElementsMatch(t, []string{"a", "b"}, receive(t, received, 2))
+}
+
+func TestChaos_DeadLetter(t *testing.T) {
+	system := actor.NewActorSystem()
+	deadLetters := make(chan *actor.DeadLetterEvent, 10)
+	sub := system.SystemEventStream.Subscribe(func(evt interface{}) {
+		if deadLetter, ok := evt.(*actor.DeadLetterEvent); ok {
+			deadLetters <- deadLetter
+		}
+	})
+	defer system.SystemEventStream.Unsubscribe(sub)
+	chaos := New(system, 1, Rule{Fault: DeadLetter, Rate: 1, Match: ActorName("target")})
+	other, received := spawnReceiver(system, New(system, 1))
+	defer func() { _ = system.Root.PoisonFuture(other).Wait() }()
+	target, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			received <- msg
+		}
+	}), "target")
+	require.NoError(t, err)
+	defer func() { _ = system.Root.PoisonFuture(target).Wait() }()
+
+	sender := system.Root.Spawn(chaos.WithChaos(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			ctx.Send(target, "lost")
+			ctx.Send(other, "delivered")
+		}
+	})))
+	defer func() { _ = system.Root.PoisonFuture(sender).Wait() }()
+
+	assert.Equal(t, []string{"delivered"}, receive(t, received, 1))
+	select {
+	case deadLetter := <-deadLetters:
+		assert.Equal(t, "lost", deadLetter.Message)
+		assert.Equal(t, "target", deadLetter.PID.Id)
+		assert.Equal(t, actor.DeadLetterUndeliverable, deadLetter.Reason)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out")
+	}
+}
+
+func TestChaos_Seeded(t *testing.T) {
+	run := func() []string {
+		system := actor.NewActorSystem()
+		chaos := New(system, 42, Rule{Fault: Drop, Rate: 0.5})
+		pid, received := spawnReceiver(system, chaos)
+		for i := 0; i < 20; i++ {
+			system.Root.Send(pid, fmt.Sprint(i))
+		}
+		_ = system.Root.PoisonFuture(pid).Wait()
+		close(received)
+		var messages []string
+		for msg := range received {
+			messages = append(messages, msg)
+		}
+		return messages
+	}
+
+	first := run()
+	assert.NotEmpty(t, first)
+	assert.True(t, len(first) < 20)
+	assert.Equal(t, first, run(), "the same seed drops the same messages")
+}
+
+func TestChaos_Control(t *testing.T) {
+	system := actor.NewActorSystem()
+	faults, unsubscribe := subscribeFaults(system)
+	defer unsubscribe()
+	chaos := New(system, 1, Rule{Fault: Drop, Rate: 0}, Rule{Fault: Duplicate, Rate: 0})
+	pid, received := spawnReceiver(system, chaos)
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	system.Root.Send(pid, "kept")
+	assert.Equal(t, []string{"kept"}, receive(t, received, 1))
+
+	res, err := system.Root.RequestFuture(chaos.PID(), &SetRate{Fault: Drop, Rate: 1}, time.Second).Result()
+	require.NoError(t, err)
+	require.IsType(t, &Rules{}, res)
+	assert.Equal(t, []float64{1, 0}, []float64{res.(*Rules).Rules[0].Rate, res.(*Rules).Rules[1].Rate})
+	system.Root.Send(pid, "dropped")
+	select {
+	case injected := <-faults:
+		assert.Equal(t, Drop, injected.Fault)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out")
+	}
+
+	_, err = system.Root.RequestFuture(chaos.PID(), &SetRules{Rules: []Rule{{Fault: Duplicate, Rate: 1}}}, time.Second).Result()
+	require.NoError(t, err)
+	system.Root.Send(pid, "twice")
+	assert.Equal(t, []string{"twice", "twice"}, receive(t, received, 2))
+
+	res, err = system.Root.RequestFuture(chaos.PID(), &GetRules{}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Len(t, res.(*Rules).Rules, 1)
+}
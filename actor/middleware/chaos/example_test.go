@@ -0,0 +1,135 @@
+package chaos
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type job struct {
+	ID int
+}
+
+// a supervisor restarting its worker with a backoff keeps the work flowing while the worker panics
+func TestExample_BackoffSupervisorSurvivesPanics(t *testing.T) {
+	system := actor.NewActorSystem()
+	faults, unsubscribe := subscribeFaults(system)
+	defer unsubscribe()
+	chaos := New(system, 7, Rule{Fault: Panic, Rate: 0.3, Match: And(ActorName("*/worker"), MessageType(&job{}))})
+
+	done := make(chan int, 100)
+	var restarts int32
+	worker := chaos.WithChaos(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Restarting:
+			atomic.AddInt32(&restarts, 1)
+		case *job:
+			done <- msg.ID
+		}
+	}))
+	supervisor := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			_, _ = ctx.SpawnNamed(worker, "worker")
+		case *job:
+			ctx.Forward(ctx.Children()[0])
+		}
+	}).WithSupervisor(actor.NewExponentialBackoffStrategy(time.Second, time.Millisecond)))
+	defer func() { _ = system.Root.PoisonFuture(supervisor).Wait() }()
+
+	const jobs = 30
+	for i := 0; i < jobs; i++ {
+		system.Root.Send(supervisor, &job{ID: i})
+	}
+	completed, panicked := 0, 0
+	for completed+panicked < jobs {
+		select {
+		case <-done:
+			completed++
+		case <-faults:
+			panicked++
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out", "%v completed and %v panicked", completed, panicked)
+		}
+	}
+	assert.NotZero(t, panicked)
+	assert.NotZero(t, completed)
+
+	// the chaos is ramped down, the worker is restarted and healthy
+	_, err := system.Root.RequestFuture(chaos.PID(), &SetRate{Fault: Panic, Rate: 0}, time.Second).Result()
+	require.NoError(t, err)
+	system.Root.Send(supervisor, &job{ID: jobs})
+	select {
+	case id := <-done:
+		assert.Equal(t, jobs, id)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out")
+	}
+	assert.Equal(t, int32(panicked), atomic.LoadInt32(&restarts))
+}
+
+type ack struct {
+	ID int
+}
+
+type retry struct{}
+
+// a sender retrying until its messages are acknowledged delivers them all while they are dropped
+func TestExample_AtLeastOnceSurvivesDrops(t *testing.T) {
+	system := actor.NewActorSystem()
+	faults, unsubscribe := subscribeFaults(system)
+	defer unsubscribe()
+	chaos := New(system, 7, Rule{Fault: Drop, Rate: 0.5, Match: MessageType(&job{})})
+
+	received := make(chan int, 100)
+	receiver := system.Root.Spawn(chaos.WithChaos(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*job); ok {
+			received <- msg.ID
+			ctx.Respond(&ack{ID: msg.ID})
+		}
+	})))
+	defer func() { _ = system.Root.PoisonFuture(receiver).Wait() }()
+
+	const jobs = 10
+	delivered := make(chan struct{})
+	pending := make(map[int]bool)
+	sender := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			for i := 0; i < jobs; i++ {
+				pending[i] = true
+				ctx.Request(receiver, &job{ID: i})
+			}
+			ctx.SendLater(ctx.Self(), &retry{}, 10*time.Millisecond)
+		case *retry:
+			for id := range pending {
+				ctx.Request(receiver, &job{ID: id})
+			}
+			ctx.SendLater(ctx.Self(), &retry{}, 10*time.Millisecond)
+		case *ack:
+			if pending[msg.ID] {
+				delete(pending, msg.ID)
+				if len(pending) == 0 {
+					close(delivered)
+				}
+			}
+		}
+	}))
+	defer func() { _ = system.Root.PoisonFuture(sender).Wait() }()
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out")
+	}
+	unique := make(map[int]bool)
+	for len(received) > 0 {
+		unique[<-received] = true
+	}
+	assert.Len(t, unique, jobs)
+	assert.NotEmpty(t, faults, "messages were dropped")
+}
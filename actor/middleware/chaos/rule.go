@@ -0,0 +1,110 @@
+package chaos
+
+import (
+	"path"
+	"reflect"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// Fault is a fault injected in the flow of messages
+type Fault int
+
+const (
+	// Drop drops the message received
+	Drop Fault = iota
+	// Delay receives the message after a random delay between MinDelay and MaxDelay
+	Delay
+	// Duplicate receives the message twice
+	Duplicate
+	// Panic panics the actor receiving the message with an *InjectedPanic
+	Panic
+	// Reorder holds the messages received until Window of them are held, they are received shuffled then.
+	// The messages are held for MaxDelay at most, the window does not need to fill up
+	Reorder
+	// DeadLetter sends the message to the dead letters rather than to its target, as if the target did not exist
+	DeadLetter
+)
+
+func (f Fault) String() string {
+	switch f {
+	case Drop:
+		return "Drop"
+	case Delay:
+		return "Delay"
+	case Duplicate:
+		return "Duplicate"
+	case Panic:
+		return "Panic"
+	case Reorder:
+		return "Reorder"
+	case DeadLetter:
+		return "DeadLetter"
+	}
+	return "Unknown"
+}
+
+// Matcher selects the messages a rule applies to, pid is the actor receiving the message
+type Matcher func(pid *actor.PID, message interface{}) bool
+
+// MessageType matches the messages of the type of sample
+func MessageType(sample interface{}) Matcher {
+	messageType := reflect.TypeOf(sample)
+	return func(_ *actor.PID, message interface{}) bool {
+		return reflect.TypeOf(message) == messageType
+	}
+}
+
+// ActorName matches the messages of the actors whose id matches pattern, with the syntax of path.Match
+func ActorName(pattern string) Matcher {
+	return func(pid *actor.PID, _ interface{}) bool {
+		matched, _ := path.Match(pattern, pid.Id)
+		return matched
+	}
+}
+
+// And matches the messages matched by all the matchers
+func And(matchers ...Matcher) Matcher {
+	return func(pid *actor.PID, message interface{}) bool {
+		for _, match := range matchers {
+			if !match(pid, message) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Rule injects its fault in the messages it matches with the probability Rate, between 0 and 1
+type Rule struct {
+	Fault Fault
+	Rate  float64
+	// Match selects the messages, all the user messages when nil
+	Match Matcher
+	// MinDelay and MaxDelay bound the delay of Delay, MaxDelay is how long Reorder holds the messages
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// Window is the number of messages Reorder shuffles together
+	Window int
+}
+
+// InjectedPanic is the reason of the panics injected
+type InjectedPanic struct {
+	Message interface{}
+}
+
+func (p *InjectedPanic) Error() string {
+	return "chaos: injected panic"
+}
+
+// FaultInjectedEvent is published on the event stream for each fault injected, PID is the actor receiving Message
+type FaultInjectedEvent struct {
+	Fault   Fault
+	PID     *actor.PID
+	Message interface{}
+}
+
+func copyRules(rules []Rule) []Rule {
+	return append([]Rule(nil), rules...)
+}
@@ -0,0 +1,239 @@
+package middleware
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+)
+
+// ErrCircuitOpen completes the futures of the requests short-circuited by an open circuit breaker
+var ErrCircuitOpen = errors.New("circuit breaker: circuit open")
+
+// CircuitState is the state of the circuit of a target
+type CircuitState int
+
+const (
+	// CircuitClosed lets the messages through and counts the failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen short-circuits the messages until the cooldown elapses
+	CircuitOpen
+	// CircuitHalfOpen lets a probe request through, its outcome closes or opens the circuit again
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "Open"
+	case CircuitHalfOpen:
+		return "HalfOpen"
+	}
+	return "Closed"
+}
+
+// CircuitStateChangedEvent is published on the event stream when the circuit of a target changes state
+type CircuitStateChangedEvent struct {
+	Target string
+	From   CircuitState
+	To     CircuitState
+}
+
+// TargetSelector returns the key of the circuit protecting target, or false when target is not protected
+type TargetSelector func(target *actor.PID) (string, bool)
+
+// TargetPID protects each target with its own circuit
+func TargetPID(target *actor.PID) (string, bool) {
+	return target.String(), true
+}
+
+// TargetAddress protects the targets of each address with a circuit, the local targets are not protected
+func TargetAddress(localAddress string) TargetSelector {
+	return func(target *actor.PID) (string, bool) {
+		return target.Address, target.Address != localAddress
+	}
+}
+
+type circuitBreakerConfig struct {
+	selector  TargetSelector
+	threshold int
+	cooldown  time.Duration
+	failure   func(res interface{}, err error) bool
+	clock     timerwheel.Clock
+}
+
+// CircuitBreakerOption configures a circuit breaker
+type CircuitBreakerOption func(*circuitBreakerConfig)
+
+// WithTargetSelector chooses the targets protected and the circuits protecting them, each target has its own by default
+func WithTargetSelector(selector TargetSelector) CircuitBreakerOption {
+	return func(c *circuitBreakerConfig) {
+		c.selector = selector
+	}
+}
+
+// WithFailureThreshold opens the circuit after threshold failures in a row, 5 by default
+func WithFailureThreshold(threshold int) CircuitBreakerOption {
+	return func(c *circuitBreakerConfig) {
+		c.threshold = threshold
+	}
+}
+
+// WithCooldown is how long the circuit stays open before it lets a probe through, 10 seconds by default
+func WithCooldown(cooldown time.Duration) CircuitBreakerOption {
+	return func(c *circuitBreakerConfig) {
+		c.cooldown = cooldown
+	}
+}
+
+// WithFailure decides which responses are failures, by default the timeouts and the responses which are errors,
+// such as remote.ErrDeadLetter
+func WithFailure(failure func(res interface{}, err error) bool) CircuitBreakerOption {
+	return func(c *circuitBreakerConfig) {
+		c.failure = failure
+	}
+}
+
+func withCircuitBreakerClock(clock timerwheel.Clock) CircuitBreakerOption {
+	return func(c *circuitBreakerConfig) {
+		c.clock = clock
+	}
+}
+
+func isFailure(res interface{}, err error) bool {
+	if err != nil {
+		return true
+	}
+	_, isError := res.(error)
+	return isError
+}
+
+// CircuitBreaker is a sender middleware which stops sending to the targets which keep failing. The outcome of the
+// requests made with futures is observed, after too many failures in a row the circuit of their target opens:
+// the requests complete right away with ErrCircuitOpen, and the other messages go to the dead letters with the
+// DeadLetterCircuitOpen reason. Once the cooldown elapsed a probe request is let through, the circuit closes
+// when it succeeds and opens again else. The messages sent without future are never observed.
+func CircuitBreaker(opts ...CircuitBreakerOption) actor.SenderMiddleware {
+	cfg := &circuitBreakerConfig{
+		selector:  TargetPID,
+		threshold: 5,
+		cooldown:  10 * time.Second,
+		failure:   isFailure,
+		clock:     timerwheel.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	breaker := &circuitBreaker{cfg: cfg, circuits: make(map[string]*circuit)}
+
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			key, ok := cfg.selector(target)
+			if !ok {
+				next(c, target, envelope)
+				return
+			}
+
+			system := c.ActorSystem()
+			future, isRequest := actor.FutureOf(system, envelope.Sender)
+			allowed, probe, transition := breaker.allow(key, isRequest)
+			breaker.publish(system, key, transition)
+			if !allowed {
+				if isRequest {
+					future.Fail(ErrCircuitOpen)
+				} else {
+					system.DeadLetter.SendUserMessageWithReason(target, envelope, actor.DeadLetterCircuitOpen)
+				}
+				return
+			}
+
+			if isRequest {
+				future.Observe(func(res interface{}, err error) {
+					breaker.publish(system, key, breaker.record(key, probe, !cfg.failure(res, err)))
+				})
+			}
+			next(c, target, envelope)
+		}
+	}
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+}
+
+type transition struct {
+	from, to CircuitState
+}
+
+type circuitBreaker struct {
+	cfg      *circuitBreakerConfig
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// allow returns whether a message to key is let through, and whether it is the probe of the half open circuit
+func (b *circuitBreaker) allow(key string, request bool) (allowed bool, probe bool, changed *transition) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+
+	switch c.state {
+	case CircuitClosed:
+		return true, false, nil
+	case CircuitOpen:
+		if !request || b.cfg.clock.Now().Sub(c.openedAt) < b.cfg.cooldown {
+			return false, false, nil
+		}
+		c.state = CircuitHalfOpen
+		return true, true, &transition{CircuitOpen, CircuitHalfOpen}
+	}
+	// the probe is in flight
+	return false, false, nil
+}
+
+// record records the outcome of a request to key
+func (b *circuitBreaker) record(key string, probe bool, success bool) *transition {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuits[key]
+
+	switch {
+	case probe && success:
+		c.state = CircuitClosed
+		c.failures = 0
+		return &transition{CircuitHalfOpen, CircuitClosed}
+	case probe:
+		c.state = CircuitOpen
+		c.openedAt = b.cfg.clock.Now()
+		return &transition{CircuitHalfOpen, CircuitOpen}
+	case c.state != CircuitClosed:
+		// a request sent before the circuit opened
+		return nil
+	case success:
+		c.failures = 0
+		return nil
+	}
+
+	c.failures++
+	if c.failures < b.cfg.threshold {
+		return nil
+	}
+	c.state = CircuitOpen
+	c.openedAt = b.cfg.clock.Now()
+	return &transition{CircuitClosed, CircuitOpen}
+}
+
+func (b *circuitBreaker) publish(system *actor.ActorSystem, key string, t *transition) {
+	if t == nil {
+		return
+	}
+	system.EventStream.Publish(&CircuitStateChangedEvent{Target: key, From: t.from, To: t.to})
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDownstream = errors.New("downstream failed")
+
+// spawnFlaky spawns an actor answering errDownstream while failing is set
+func spawnFlaky(system *actor.ActorSystem, failing *int32) (*actor.PID, *int32) {
+	var received int32
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			atomic.AddInt32(&received, 1)
+			if atomic.LoadInt32(failing) == 1 {
+				ctx.Respond(errDownstream)
+			} else if ctx.Sender() != nil {
+				ctx.Respond("ok")
+			}
+		}
+	}))
+	return pid, &received
+}
+
+func subscribeTransitions(system *actor.ActorSystem) (chan *CircuitStateChangedEvent, func()) {
+	transitions := make(chan *CircuitStateChangedEvent, 10)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if changed, ok := evt.(*CircuitStateChangedEvent); ok {
+			transitions <- changed
+		}
+	})
+	return transitions, func() { system.EventStream.Unsubscribe(sub) }
+}
+
+func assertTransition(t *testing.T, transitions chan *CircuitStateChangedEvent, target *actor.PID, from, to CircuitState) {
+	select {
+	case changed := <-transitions:
+		assert.Equal(t, &CircuitStateChangedEvent{Target: target.String(), From: from, To: to}, changed)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out", "waiting for %v to %v", from, to)
+	}
+}
+
+func TestCircuitBreaker_Cycle(t *testing.T) {
+	system := actor.NewActorSystem()
+	transitions, unsubscribe := subscribeTransitions(system)
+	defer unsubscribe()
+	deadLetters := make(chan *actor.DeadLetterEvent, 10)
+	sub := system.SystemEventStream.Subscribe(func(evt interface{}) {
+		if deadLetter, ok := evt.(*actor.DeadLetterEvent); ok && deadLetter.Reason == actor.DeadLetterCircuitOpen {
+			deadLetters <- deadLetter
+		}
+	})
+	defer system.SystemEventStream.Unsubscribe(sub)
+
+	failing := int32(1)
+	pid, received := spawnFlaky(system, &failing)
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+	clock := timerwheel.NewFakeClock(time.Now())
+	root := actor.NewRootContext(system, nil, CircuitBreaker(
+		WithFailureThreshold(3), WithCooldown(time.Minute), withCircuitBreakerClock(clock)))
+	request := func() (interface{}, error) {
+		return root.RequestFuture(pid, "request", time.Second).Result()
+	}
+
+	// closed, the failures are counted
+	for i := 0; i < 3; i++ {
+		res, err := request()
+		require.NoError(t, err)
+		assert.Equal(t, errDownstream, res)
+	}
+	assertTransition(t, transitions, pid, CircuitClosed, CircuitOpen)
+
+	// open, the messages are short-circuited
+	_, err := request()
+	assert.Equal(t, ErrCircuitOpen, err)
+	root.Send(pid, "tell")
+	select {
+	case deadLetter := <-deadLetters:
+		assert.Equal(t, "tell", deadLetter.Message)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out")
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(received))
+
+	// half open, the failed probe opens the circuit again
+	clock.Advance(time.Minute)
+	_, err = request()
+	assert.NoError(t, err)
+	assertTransition(t, transitions, pid, CircuitOpen, CircuitHalfOpen)
+	assertTransition(t, transitions, pid, CircuitHalfOpen, CircuitOpen)
+	_, err = request()
+	assert.Equal(t, ErrCircuitOpen, err, "the cooldown starts over")
+
+	// half open, the successful probe closes the circuit
+	atomic.StoreInt32(&failing, 0)
+	clock.Advance(time.Minute)
+	res, err := request()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res)
+	assertTransition(t, transitions, pid, CircuitOpen, CircuitHalfOpen)
+	assertTransition(t, transitions, pid, CircuitHalfOpen, CircuitClosed)
+
+	res, err = request()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res)
+	assert.Equal(t, int32(6), atomic.LoadInt32(received))
+	assert.Empty(t, transitions)
+}
+
+func TestCircuitBreaker_ShortCircuitsWhileProbing(t *testing.T) {
+	system := actor.NewActorSystem()
+	release := make(chan struct{})
+	slow := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			<-release
+			ctx.Respond(errDownstream)
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(slow).Wait() }()
+	clock := timerwheel.NewFakeClock(time.Now())
+	root := actor.NewRootContext(system, nil, CircuitBreaker(
+		WithFailureThreshold(1), WithCooldown(time.Minute), withCircuitBreakerClock(clock)))
+
+	first := root.RequestFuture(slow, "request", time.Second)
+	release <- struct{}{}
+	require.NoError(t, first.Wait())
+	clock.Advance(time.Minute)
+
+	probe := root.RequestFuture(slow, "probe", time.Second)
+	assert.Equal(t, ErrCircuitOpen, root.RequestFuture(slow, "request", time.Second).Wait())
+	release <- struct{}{}
+	require.NoError(t, probe.Wait())
+}
+
+func TestCircuitBreaker_Timeouts(t *testing.T) {
+	system := actor.NewActorSystem()
+	transitions, unsubscribe := subscribeTransitions(system)
+	defer unsubscribe()
+	silent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	defer func() { _ = system.Root.StopFuture(silent).Wait() }()
+	healthy := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			ctx.Respond("ok")
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(healthy).Wait() }()
+	root := actor.NewRootContext(system, nil, CircuitBreaker(WithFailureThreshold(2)))
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, actor.ErrTimeout, root.RequestFuture(silent, "request", 10*time.Millisecond).Wait())
+	}
+	assertTransition(t, transitions, silent, CircuitClosed, CircuitOpen)
+	assert.Equal(t, ErrCircuitOpen, root.RequestFuture(silent, "request", time.Second).Wait())
+
+	res, err := root.RequestFuture(healthy, "request", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res, "the other targets have their own circuit")
+}
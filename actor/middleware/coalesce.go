@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// CoalesceKey returns the key of the question asked by message, false when the requests with message are not coalesced
+type CoalesceKey func(message interface{}) (string, bool)
+
+// Coalesce is a sender middleware which coalesces the identical requests in flight: a request made with a future
+// while a request with the same key is in flight to the same target is not sent, its future completes with the
+// response of the request in flight. Its failure, e.g. its timeout, fails all the futures waiting on it, which
+// still time out on their own meanwhile. The key is released once the request in flight completes.
+// The requests of all the senders using the middleware are coalesced together, the messages sent without future
+// are never coalesced
+func Coalesce(keyFn CoalesceKey) actor.SenderMiddleware {
+	c := &coalescer{inFlight: make(map[string][]*actor.Future)}
+
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(ctx actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			future, isRequest := actor.FutureOf(ctx.ActorSystem(), envelope.Sender)
+			if !isRequest {
+				next(ctx, target, envelope)
+				return
+			}
+			key, ok := keyFn(envelope.Message)
+			if !ok {
+				next(ctx, target, envelope)
+				return
+			}
+
+			key = target.String() + "/" + key
+			if !c.join(key, future) {
+				return
+			}
+			future.Observe(func(res interface{}, err error) {
+				for _, waiter := range c.release(key) {
+					if err != nil {
+						waiter.Fail(err)
+					} else {
+						waiter.Complete(res)
+					}
+				}
+			})
+			next(ctx, target, envelope)
+		}
+	}
+}
+
+type coalescer struct {
+	mu       sync.Mutex
+	inFlight map[string][]*actor.Future // the futures waiting on the request in flight, by key
+}
+
+// join returns true when future makes the request of key, false when it waits on the request in flight
+func (c *coalescer) join(key string, future *actor.Future) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	waiters, ok := c.inFlight[key]
+	c.inFlight[key] = append(waiters, future)
+	return !ok
+}
+
+// release returns the futures waiting on the request of key which completed
+func (c *coalescer) release(key string) []*actor.Future {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	waiters := c.inFlight[key]
+	delete(c.inFlight, key)
+	// the first one made the request
+	return waiters[1:]
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func messageKey(message interface{}) (string, bool) {
+	key, ok := message.(string)
+	return key, ok
+}
+
+// spawnLoader spawns an actor answering the configs it loads once released
+func spawnLoader(system *actor.ActorSystem, release chan struct{}) (*actor.PID, *int32) {
+	var received int32
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			atomic.AddInt32(&received, 1)
+			<-release
+			ctx.Respond("loaded " + msg)
+		}
+	}))
+	return pid, &received
+}
+
+func TestCoalesce_IdenticalRequestsSentOnce(t *testing.T) {
+	system := actor.NewActorSystem()
+	root := actor.NewRootContext(system, nil).WithSenderMiddleware(Coalesce(messageKey))
+	release := make(chan struct{})
+	loader, received := spawnLoader(system, release)
+
+	futures := make([]*actor.Future, 100)
+	var wg sync.WaitGroup
+	for i := range futures {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			futures[i] = root.RequestFuture(loader, "config", time.Second)
+		}(i)
+	}
+	wg.Wait()
+	close(release)
+
+	for _, future := range futures {
+		res, err := future.Result()
+		require.NoError(t, err)
+		assert.Equal(t, "loaded config", res)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(received))
+
+	// the key is released once answered
+	res, err := root.RequestFuture(loader, "config", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "loaded config", res)
+	assert.Equal(t, int32(2), atomic.LoadInt32(received))
+}
+
+func TestCoalesce_FailurePropagatesToAllWaiters(t *testing.T) {
+	system := actor.NewActorSystem()
+	root := actor.NewRootContext(system, nil).WithSenderMiddleware(Coalesce(messageKey))
+	release := make(chan struct{})
+	defer close(release)
+	loader, received := spawnLoader(system, release)
+
+	first := root.RequestFuture(loader, "config", 20*time.Millisecond)
+	waiting := root.RequestFuture(loader, "config", time.Minute)
+	other := root.RequestFuture(loader, "other", 20*time.Millisecond)
+
+	assert.Equal(t, actor.ErrTimeout, first.Wait())
+	assert.Equal(t, actor.ErrTimeout, waiting.Wait(), "the timeout of the request in flight")
+	assert.Equal(t, actor.ErrTimeout, other.Wait())
+	assert.Equal(t, int32(1), atomic.LoadInt32(received), "the other config is queued behind the first one")
+}
+
+func TestCoalesce_WaitersAwaitingFromActors(t *testing.T) {
+	system := actor.NewActorSystem()
+	release := make(chan struct{})
+	loader, received := spawnLoader(system, release)
+
+	requested, results := make(chan struct{}, 10), make(chan interface{}, 10)
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			ctx.AwaitFuture(ctx.RequestFuture(loader, "config", time.Second), func(res interface{}, err error) {
+				assert.NoError(t, err)
+				results <- res
+			})
+			requested <- struct{}{}
+		}
+	}).WithSenderMiddleware(Coalesce(messageKey))
+	for i := 0; i < 10; i++ {
+		system.Root.Spawn(props)
+	}
+	for i := 0; i < 10; i++ {
+		<-requested
+	}
+	close(release)
+
+	for i := 0; i < 10; i++ {
+		select {
+		case res := <-results:
+			assert.Equal(t, "loaded config", res)
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out")
+		}
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(received))
+}
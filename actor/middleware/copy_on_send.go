@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// CopyOnSend is a sender middleware sending deep copies of the messages, see actor.CopyMessage, so that the targets
+// cannot mutate the messages the actor kept. Use actor.WithCopyOnSend to copy the messages of all the actors
+func CopyOnSend() actor.SenderMiddleware {
+	return copyOnSend(false)
+}
+
+// CopyOnSendStrict is CopyOnSend, but it panics on the messages which cannot be fully copied rather than sharing
+// their channels and functions
+func CopyOnSendStrict() actor.SenderMiddleware {
+	return copyOnSend(true)
+}
+
+func copyOnSend(strict bool) actor.SenderMiddleware {
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			copied, err := actor.CopyMessage(envelope)
+			if err != nil && strict {
+				panic(err)
+			}
+			next(c, target, copied.(*actor.MessageEnvelope))
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+type basket struct {
+	Items []string
+}
+
+func TestCopyOnSend(t *testing.T) {
+	system := actor.NewActorSystem()
+	received := make(chan *basket, 1)
+	release := make(chan struct{})
+	target := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			<-release
+		case *basket:
+			received <- msg
+		}
+	}))
+	defer system.Root.Stop(target)
+
+	sender := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*basket); ok {
+			ctx.Send(target, msg)
+			msg.Items[0] = "mutated"
+			close(release)
+		}
+	}).WithSenderMiddleware(CopyOnSend()))
+	defer system.Root.Stop(sender)
+
+	system.Root.Send(target, "busy")
+	system.Root.Send(sender, &basket{Items: []string{"apple"}})
+	assert.Equal(t, []string{"apple"}, (<-received).Items)
+}
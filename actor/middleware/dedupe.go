@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// MessageIDHeader is the header holding the id of a message, which its retries keep
+const MessageIDHeader = "message-id"
+
+// DedupeKey returns the key identifying the message of envelope, false when the message is not deduplicated
+type DedupeKey func(envelope *actor.MessageEnvelope) (string, bool)
+
+// MessageIDKey identifies the messages by their MessageIDHeader, the messages without it are not deduplicated
+func MessageIDKey(envelope *actor.MessageEnvelope) (string, bool) {
+	id := envelope.GetHeader(MessageIDHeader)
+	return id, id != ""
+}
+
+// DuplicateDropped is published on the event stream when a duplicate is dropped
+type DuplicateDropped struct {
+	PID     *actor.PID
+	Key     string
+	Message interface{}
+}
+
+type dedupeConfig struct {
+	resetOnRestart bool
+}
+
+// DedupeOption configures a deduplication
+type DedupeOption func(*dedupeConfig)
+
+// WithDedupeResetOnRestart forgets the messages seen by an actor when it restarts,
+// they are remembered across its restarts by default
+func WithDedupeResetOnRestart() DedupeOption {
+	return func(c *dedupeConfig) {
+		c.resetOnRestart = true
+	}
+}
+
+// Dedupe is a receiver middleware dropping the messages whose key was handled by the actor among its last windowSize
+// keys, within ttl. The key is the MessageIDHeader when keyFn is nil, and a message is seen once handled without
+// panicking, so that the retry of a failed message is received again. The keys are forgotten when the actor stops.
+// The lifecycle and system messages are not deduplicated
+func Dedupe(windowSize int, ttl time.Duration, keyFn DedupeKey, opts ...DedupeOption) actor.ReceiverMiddleware {
+	if keyFn == nil {
+		keyFn = MessageIDKey
+	}
+	cfg := &dedupeConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	windows := &actor.LocalKey{Name: "dedupe", KeepOnRestart: !cfg.resetOnRestart}
+
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch envelope.Message.(type) {
+			case actor.SystemMessage, actor.AutoReceiveMessage:
+				next(c, envelope)
+				return
+			}
+			key, ok := keyFn(envelope)
+			if !ok || windowSize <= 0 {
+				next(c, envelope)
+				return
+			}
+
+			window := actor.GetOrSetLocal(c, windows, func() interface{} {
+				return newDedupeWindow(windowSize, ttl)
+			}).(*dedupeWindow)
+			now := c.ActorSystem().Clock().Now()
+			if window.seen(key, now) {
+				c.ActorSystem().EventStream.Publish(&DuplicateDropped{PID: c.Self(), Key: key, Message: envelope.Message})
+				return
+			}
+			next(c, envelope)
+			window.add(key, now)
+		}
+	}
+}
+
+type dedupeEntry struct {
+	key  string
+	seen time.Time
+}
+
+// dedupeWindow holds the last keys seen by an actor, the most recent first.
+// It is stored in the actor and only used by it
+type dedupeWindow struct {
+	size int
+	ttl  time.Duration
+
+	entries *list.List
+	keys    map[string]*list.Element
+}
+
+func newDedupeWindow(size int, ttl time.Duration) *dedupeWindow {
+	return &dedupeWindow{
+		size:    size,
+		ttl:     ttl,
+		entries: list.New(),
+		keys:    make(map[string]*list.Element),
+	}
+}
+
+// seen returns whether key was seen within the TTL, the expired keys are forgotten
+func (w *dedupeWindow) seen(key string, now time.Time) bool {
+	w.expire(now)
+	_, ok := w.keys[key]
+	return ok
+}
+
+// add remembers key, the least recently seen key is forgotten beyond the size of the window
+func (w *dedupeWindow) add(key string, now time.Time) {
+	if e, ok := w.keys[key]; ok {
+		e.Value.(*dedupeEntry).seen = now
+		w.entries.MoveToFront(e)
+		return
+	}
+	w.keys[key] = w.entries.PushFront(&dedupeEntry{key: key, seen: now})
+	if w.entries.Len() > w.size {
+		w.remove(w.entries.Back())
+	}
+}
+
+func (w *dedupeWindow) expire(now time.Time) {
+	if w.ttl <= 0 {
+		return
+	}
+	for e := w.entries.Back(); e != nil && now.Sub(e.Value.(*dedupeEntry).seen) >= w.ttl; e = w.entries.Back() {
+		w.remove(e)
+	}
+}
+
+func (w *dedupeWindow) remove(e *list.Element) {
+	w.entries.Remove(e)
+	delete(w.keys, e.Value.(*dedupeEntry).key)
+}
+
+func (w *dedupeWindow) len() int {
+	return w.entries.Len()
+}
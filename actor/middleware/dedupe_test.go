@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/stretchr/testify/assert"
+)
+
+type dedupeFixture struct {
+	system  *actor.ActorSystem
+	clock   *testkit.ManualClock
+	probe   *testkit.TestProbe
+	dropped chan *DuplicateDropped
+	pid     *actor.PID
+}
+
+// spawnDeduped spawns an actor deduplicated by middleware reporting its strings to the probe, which panics on "boom"
+func spawnDeduped(t *testing.T, middleware actor.ReceiverMiddleware) *dedupeFixture {
+	f := &dedupeFixture{clock: testkit.NewManualClock(time.Now()), dropped: make(chan *DuplicateDropped, 100)}
+	f.system = actor.NewActorSystem(actor.WithClock(f.clock))
+	f.probe = testkit.NewTestProbe(f.system)
+	t.Cleanup(f.probe.Stop)
+	sub := f.system.EventStream.Subscribe(func(evt interface{}) {
+		if dropped, ok := evt.(*DuplicateDropped); ok {
+			f.dropped <- dropped
+		}
+	})
+	t.Cleanup(func() { f.system.EventStream.Unsubscribe(sub) })
+	f.pid = f.system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			if msg == "boom" {
+				panic(msg)
+			}
+			ctx.Send(f.probe.PID(), msg)
+		}
+	}).WithReceiverMiddleware(middleware))
+	t.Cleanup(func() { _ = f.system.Root.StopFuture(f.pid).Wait() })
+	return f
+}
+
+func (f *dedupeFixture) send(message string, id string) {
+	f.system.Root.Send(f.pid, &actor.MessageEnvelope{Header: map[string]string{MessageIDHeader: id}, Message: message})
+}
+
+func TestDedupe_DropsDuplicates(t *testing.T) {
+	f := spawnDeduped(t, Dedupe(10, time.Minute, nil))
+
+	f.send("a", "1")
+	f.send("a again", "1")
+	f.system.Root.Send(f.pid, "no id")
+	f.system.Root.Send(f.pid, "no id")
+	f.send("b", "2")
+
+	for _, expected := range []string{"a", "no id", "no id", "b"} {
+		f.probe.ExpectMsg(t, expected, 0)
+	}
+	dropped := <-f.dropped
+	assert.Equal(t, &DuplicateDropped{PID: f.pid, Key: "1", Message: "a again"}, dropped)
+}
+
+func TestDedupe_WindowEviction(t *testing.T) {
+	f := spawnDeduped(t, Dedupe(2, 0, nil))
+
+	f.send("1", "1")
+	f.send("2", "2")
+	f.send("1 again", "1") // dropped, handled among the last 2
+	f.send("3", "3")       // evicts 1, the least recently handled
+	f.send("2 again", "2")
+	f.send("1 again", "1")
+
+	for _, expected := range []string{"1", "2", "3", "1 again"} {
+		f.probe.ExpectMsg(t, expected, 0)
+	}
+	assert.Equal(t, "1", (<-f.dropped).Key)
+	assert.Equal(t, "2", (<-f.dropped).Key)
+}
+
+func TestDedupe_TTL(t *testing.T) {
+	f := spawnDeduped(t, Dedupe(10, time.Second, nil))
+
+	f.send("a", "1")
+	f.probe.ExpectMsg(t, "a", 0)
+	f.clock.Advance(999 * time.Millisecond)
+	f.send("dropped", "1")
+	assert.Equal(t, "dropped", (<-f.dropped).Message)
+	f.clock.Advance(time.Millisecond)
+	f.send("expired", "1")
+	f.probe.ExpectMsg(t, "expired", 0)
+}
+
+func TestDedupe_AcrossRestart(t *testing.T) {
+	f := spawnDeduped(t, Dedupe(10, time.Minute, nil))
+
+	f.send("a", "1")
+	f.send("boom", "2")
+	f.send("a again", "1")
+	f.send("boom", "2") // the failed message was not seen, its retry fails again
+	f.send("b", "3")
+	f.probe.ExpectMsg(t, "a", 0)
+	f.probe.ExpectMsg(t, "b", 0)
+	assert.Equal(t, "1", (<-f.dropped).Key)
+}
+
+func TestDedupe_ResetOnRestart(t *testing.T) {
+	f := spawnDeduped(t, Dedupe(10, time.Minute, nil, WithDedupeResetOnRestart()))
+
+	f.send("a", "1")
+	f.system.Root.Send(f.pid, "boom")
+	f.send("a again", "1")
+	f.probe.ExpectMsg(t, "a", 0)
+	f.probe.ExpectMsg(t, "a again", 0)
+}
+
+func TestDedupeWindow_Bounded(t *testing.T) {
+	w := newDedupeWindow(3, time.Second)
+	now := time.Now()
+	for _, key := range []string{"a", "b", "c", "d", "a"} {
+		w.add(key, now)
+	}
+	assert.Equal(t, 3, w.len())
+	assert.False(t, w.seen("b", now))
+	assert.True(t, w.seen("a", now))
+	assert.False(t, w.seen("a", now.Add(time.Second)))
+	assert.Equal(t, 0, w.len())
+}
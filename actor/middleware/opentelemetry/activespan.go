@@ -0,0 +1,47 @@
+package opentelemetry
+
+import (
+	"context"
+	"sync"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// receiveSpan is the span of the message an actor is receiving
+type receiveSpan struct {
+	ctx     context.Context
+	span    trace.Span
+	request trace.SpanContext // the span which sent the message
+	sender  *actor.PID
+}
+
+var activeSpan = sync.Map{}
+
+func getActiveSpan(pid *actor.PID) *receiveSpan {
+	if pid == nil {
+		return nil
+	}
+	value, ok := activeSpan.Load(pid)
+	if !ok {
+		return nil
+	}
+	return value.(*receiveSpan)
+}
+
+func setActiveSpan(pid *actor.PID, span *receiveSpan) {
+	activeSpan.Store(pid, span)
+}
+
+func clearActiveSpan(pid *actor.PID) {
+	activeSpan.Delete(pid)
+}
+
+// Context returns a context holding the span of the message the actor is receiving,
+// so that the work it does is traced as part of the message
+func Context(c actor.Context) context.Context {
+	if active := getActiveSpan(c.Self()); active != nil {
+		return active.ctx
+	}
+	return context.Background()
+}
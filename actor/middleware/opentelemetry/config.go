@@ -0,0 +1,46 @@
+package opentelemetry
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/AsynkronIT/protoactor-go/actor/middleware/opentelemetry"
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures the tracing middleware
+type Option func(*config)
+
+// WithTracerProvider creates the spans with provider rather than the global one
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithPropagator carries the trace context in the message headers with propagator rather than the global one
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *config) tracer() trace.Tracer {
+	return c.tracerProvider.Tracer(instrumentationName)
+}
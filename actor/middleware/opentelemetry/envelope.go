@@ -0,0 +1,51 @@
+package opentelemetry
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// messageHeaderCarrier reads the trace context from the header of a received message
+type messageHeaderCarrier struct {
+	header actor.ReadonlyMessageHeader
+}
+
+func (carrier *messageHeaderCarrier) Get(key string) string {
+	if carrier.header == nil {
+		return ""
+	}
+	return carrier.header.Get(key)
+}
+
+func (carrier *messageHeaderCarrier) Set(key, value string) {}
+
+func (carrier *messageHeaderCarrier) Keys() []string {
+	if carrier.header == nil {
+		return nil
+	}
+	return carrier.header.Keys()
+}
+
+var _ propagation.TextMapCarrier = &messageHeaderCarrier{}
+
+// messageEnvelopeCarrier writes the trace context to the header of a sent message
+type messageEnvelopeCarrier struct {
+	envelope *actor.MessageEnvelope
+}
+
+func (carrier *messageEnvelopeCarrier) Get(key string) string {
+	return carrier.envelope.GetHeader(key)
+}
+
+func (carrier *messageEnvelopeCarrier) Set(key, value string) {
+	carrier.envelope.SetHeader(key, value)
+}
+
+func (carrier *messageEnvelopeCarrier) Keys() []string {
+	if carrier.envelope.Header == nil {
+		return nil
+	}
+	return carrier.envelope.Header.Keys()
+}
+
+var _ propagation.TextMapCarrier = &messageEnvelopeCarrier{}
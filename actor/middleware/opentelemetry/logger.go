@@ -0,0 +1,5 @@
+package opentelemetry
+
+import "github.com/AsynkronIT/protoactor-go/log"
+
+var logger = log.New(log.ErrorLevel, "[TRACING]")
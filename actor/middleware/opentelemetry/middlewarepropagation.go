@@ -0,0 +1,24 @@
+package opentelemetry
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/middleware/propagator"
+)
+
+// TracingMiddleware traces the messages the actors spawned and their descendants send and receive,
+// it is used with RootContext.WithSpawnMiddleware
+func TracingMiddleware(opts ...Option) actor.SpawnMiddleware {
+	return propagator.New().
+		WithItselfForwarded().
+		WithSenderMiddleware(SenderMiddleware(opts...)).
+		WithReceiverMiddleware(ReceiverMiddleware(opts...)).
+		SpawnMiddleware
+}
+
+// WithTracing traces the messages the actors spawned with props and their descendants send and receive
+func WithTracing(props *actor.Props, opts ...Option) *actor.Props {
+	return props.
+		WithSenderMiddleware(SenderMiddleware(opts...)).
+		WithReceiverMiddleware(ReceiverMiddleware(opts...)).
+		WithSpawnMiddleware(TracingMiddleware(opts...))
+}
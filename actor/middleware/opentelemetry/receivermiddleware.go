@@ -0,0 +1,56 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ReceiverMiddleware starts a consumer span named after the type of the messages received, child of the span which
+// sent them if their header carries its context. The failures the actor escalates while receiving them are recorded
+// on the span. The lifecycle and system messages are not traced.
+func ReceiverMiddleware(opts ...Option) actor.ReceiverMiddleware {
+	cfg := newConfig(opts)
+	tracer := cfg.tracer()
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch envelope.Message.(type) {
+			case actor.SystemMessage, actor.AutoReceiveMessage:
+				next(c, envelope)
+				return
+			}
+
+			parent := cfg.propagator.Extract(context.Background(), &messageHeaderCarrier{header: envelope.Header})
+			ctx, span := tracer.Start(parent, fmt.Sprintf("%T", envelope.Message),
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.String("protoactor.pid", c.Self().String()),
+					attribute.String("protoactor.actor_type", fmt.Sprintf("%T", c.Actor())),
+					attribute.String("protoactor.message_type", fmt.Sprintf("%T", envelope.Message)),
+				))
+			setActiveSpan(c.Self(), &receiveSpan{
+				ctx:     ctx,
+				span:    span,
+				request: trace.SpanContextFromContext(parent),
+				sender:  envelope.Sender,
+			})
+
+			defer func() {
+				clearActiveSpan(c.Self())
+				if r := recover(); r != nil {
+					// the actor escalates the failure once the panic is rethrown
+					span.RecordError(fmt.Errorf("%v", r))
+					span.SetStatus(codes.Error, fmt.Sprint(r))
+					span.End()
+					panic(r)
+				}
+				span.End()
+			}()
+			next(c, envelope)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SenderMiddleware starts a producer span for the messages sent, child of the span of the message being received if
+// any, and injects its context in the message header. The responses to the sender of the message being received are
+// linked to the span of the request.
+func SenderMiddleware(opts ...Option) actor.SenderMiddleware {
+	cfg := newConfig(opts)
+	tracer := cfg.tracer()
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			parent := context.Background()
+			var links []trace.Link
+			response := false
+			if active := getActiveSpan(c.Self()); active != nil {
+				parent = active.ctx
+				if active.sender != nil && active.sender.Equal(target) && active.request.IsValid() {
+					response = true
+					links = append(links, trace.Link{SpanContext: active.request})
+				}
+			}
+
+			ctx, span := tracer.Start(parent, fmt.Sprintf("send %T", envelope.Message),
+				trace.WithSpanKind(trace.SpanKindProducer),
+				trace.WithLinks(links...),
+				trace.WithAttributes(
+					attribute.String("protoactor.target", target.String()),
+					attribute.String("protoactor.message_type", fmt.Sprintf("%T", envelope.Message)),
+					attribute.Bool("protoactor.request", envelope.Sender != nil),
+					attribute.Bool("protoactor.response", response),
+				))
+			if self := c.Self(); self != nil {
+				span.SetAttributes(attribute.String("protoactor.pid", self.String()))
+			}
+			if isDeadLetter(c.ActorSystem(), target) {
+				logger.Debug("OUTBOUND Dead letter", log.Stringer("PID", c.Self()), log.Stringer("Target", target))
+				span.AddEvent("deadletter")
+				span.SetAttributes(attribute.Bool("protoactor.deadletter", true))
+			}
+
+			cfg.propagator.Inject(ctx, &messageEnvelopeCarrier{envelope: envelope})
+			next(c, target, envelope)
+			span.End()
+		}
+	}
+}
+
+// isDeadLetter tells whether target is a local process which does not exist
+func isDeadLetter(system *actor.ActorSystem, target *actor.PID) bool {
+	if target.Address != system.Address() {
+		return false
+	}
+	_, ok := system.ProcessRegistry.GetLocal(target.Id)
+	return !ok
+}
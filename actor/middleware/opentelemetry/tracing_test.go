@@ -0,0 +1,153 @@
+package opentelemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type hopA struct{}
+type hopB struct{}
+type ask struct{}
+type answer struct{}
+type fail struct{}
+
+func newTracing() (*tracetest.InMemoryExporter, []Option) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return exporter, []Option{WithTracerProvider(provider), WithPropagator(propagation.TraceContext{})}
+}
+
+// spans waits for count spans to be exported and returns them by name
+func spans(t *testing.T, exporter *tracetest.InMemoryExporter, count int) map[string]tracetest.SpanStub {
+	require.Eventually(t, func() bool { return len(exporter.GetSpans()) >= count }, 5*time.Second, time.Millisecond)
+	byName := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		byName[span.Name] = span
+	}
+	return byName
+}
+
+func assertChildOf(t *testing.T, parent, child tracetest.SpanStub) {
+	assert.Equal(t, parent.SpanContext.TraceID(), child.SpanContext.TraceID(), child.Name)
+	assert.Equal(t, parent.SpanContext.SpanID(), child.Parent.SpanID(), "%s is not a child of %s", child.Name, parent.Name)
+}
+
+func hasAttribute(span tracetest.SpanStub, kv attribute.KeyValue) bool {
+	for _, a := range span.Attributes {
+		if a == kv {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTracing_AcrossActorsAndRemote(t *testing.T) {
+	exporter, opts := newTracing()
+
+	received := make(chan struct{}, 1)
+	remoteSystem := actor.NewActorSystem()
+	remoteNode := remote.NewRemote(remoteSystem, remote.Configure("localhost", 0))
+	remoteNode.Start()
+	defer remoteNode.Shutdown(false)
+	c, err := remoteSystem.Root.SpawnNamed(WithTracing(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.PID); ok {
+			received <- struct{}{}
+		}
+	}), opts...), "c")
+	require.NoError(t, err)
+
+	system := actor.NewActorSystem()
+	node := remote.NewRemote(system, remote.Configure("localhost", 0))
+	node.Start()
+	defer node.Shutdown(false)
+	root := actor.NewRootContext(system, nil, SenderMiddleware(opts...)).WithSpawnMiddleware(TracingMiddleware(opts...))
+	b := root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*hopB); ok {
+			ctx.Send(c, &actor.PID{Id: "hop"})
+		}
+	}))
+	a := root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*hopA); ok {
+			ctx.Send(b, &hopB{})
+		}
+	}))
+
+	root.Send(a, &hopA{})
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out")
+	}
+
+	byName := spans(t, exporter, 6)
+	chain := []string{"send *opentelemetry.hopA", "*opentelemetry.hopA", "send *opentelemetry.hopB", "*opentelemetry.hopB", "send *actor.PID", "*actor.PID"}
+	for i, name := range chain {
+		require.Contains(t, byName, name)
+		if i > 0 {
+			assertChildOf(t, byName[chain[i-1]], byName[name])
+		}
+	}
+	assert.False(t, byName[chain[0]].Parent.IsValid(), "the root send starts the trace")
+}
+
+func TestTracing_LinksResponsesToRequests(t *testing.T) {
+	exporter, opts := newTracing()
+	system := actor.NewActorSystem()
+	root := actor.NewRootContext(system, nil, SenderMiddleware(opts...))
+	pid := root.Spawn(WithTracing(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*ask); ok {
+			ctx.Respond(&answer{})
+		}
+	}), opts...))
+	_, err := root.RequestFuture(pid, &ask{}, 5*time.Second).Result()
+	require.NoError(t, err)
+
+	byName := spans(t, exporter, 3)
+	request, response := byName["send *opentelemetry.ask"], byName["send *opentelemetry.answer"]
+	assert.True(t, hasAttribute(request, attribute.Bool("protoactor.request", true)))
+	assert.True(t, hasAttribute(response, attribute.Bool("protoactor.response", true)))
+	require.Len(t, response.Links, 1)
+	assert.Equal(t, request.SpanContext.SpanID(), response.Links[0].SpanContext.SpanID())
+	assertChildOf(t, byName["*opentelemetry.ask"], response)
+}
+
+func TestTracing_AnnotatesDeadLetters(t *testing.T) {
+	exporter, opts := newTracing()
+	system := actor.NewActorSystem()
+	stopped := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	require.NoError(t, system.Root.StopFuture(stopped).Wait())
+
+	actor.NewRootContext(system, nil, SenderMiddleware(opts...)).Send(stopped, &hopA{})
+
+	span := spans(t, exporter, 1)["send *opentelemetry.hopA"]
+	assert.True(t, hasAttribute(span, attribute.Bool("protoactor.deadletter", true)))
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "deadletter", span.Events[0].Name)
+}
+
+func TestTracing_RecordsFailures(t *testing.T) {
+	exporter, opts := newTracing()
+	system := actor.NewActorSystem()
+	pid := system.Root.Spawn(WithTracing(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*fail); ok {
+			panic("failed")
+		}
+	}), opts...))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	system.Root.Send(pid, &fail{})
+
+	span := spans(t, exporter, 1)["*opentelemetry.fail"]
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Equal(t, "failed", span.Status.Description)
+}
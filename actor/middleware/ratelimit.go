@@ -0,0 +1,259 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+)
+
+// RateLimitKey returns the key of the bucket a message takes its token from
+type RateLimitKey func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) string
+
+// SenderKey limits the rate of each sender, the messages without sender share a bucket
+func SenderKey(c actor.ReceiverContext, envelope *actor.MessageEnvelope) string {
+	if envelope.Sender == nil {
+		return ""
+	}
+	return envelope.Sender.String()
+}
+
+// MessageTypeKey limits the rate of each type of message
+func MessageTypeKey(c actor.ReceiverContext, envelope *actor.MessageEnvelope) string {
+	return fmt.Sprintf("%T", envelope.Message)
+}
+
+// HeaderKey limits the rate of each value of the header name, e.g. a tenant
+func HeaderKey(name string) RateLimitKey {
+	return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) string {
+		return envelope.GetHeader(name)
+	}
+}
+
+// OverLimitPolicy decides what happens to the messages over the limit
+type OverLimitPolicy int
+
+const (
+	// DropOverLimit sends the messages over the limit to the dead letters, with the DeadLetterRateLimited reason
+	DropOverLimit OverLimitPolicy = iota
+	// DelayOverLimit receives the messages over the limit later, once their bucket has a token for them.
+	// The messages which would wait longer than the max delay are dropped
+	DelayOverLimit
+	// RespondOverLimit answers the messages over the limit with RateLimitExceeded, the messages without sender are dropped
+	RespondOverLimit
+)
+
+// RateLimitExceeded is the response to the messages over the limit with the RespondOverLimit policy
+type RateLimitExceeded struct {
+	Key        string
+	RetryAfter time.Duration
+}
+
+type rateLimitConfig struct {
+	key        RateLimitKey
+	policy     OverLimitPolicy
+	maxDelay   time.Duration
+	idleExpiry time.Duration
+	clock      timerwheel.Clock
+}
+
+// RateLimitOption configures a rate limit
+type RateLimitOption func(*rateLimitConfig)
+
+// WithRateLimitKey chooses the bucket of the messages, they are limited by sender by default
+func WithRateLimitKey(key RateLimitKey) RateLimitOption {
+	return func(c *rateLimitConfig) {
+		c.key = key
+	}
+}
+
+// WithOverLimitPolicy decides what happens to the messages over the limit, they are dropped by default
+func WithOverLimitPolicy(policy OverLimitPolicy) RateLimitOption {
+	return func(c *rateLimitConfig) {
+		c.policy = policy
+	}
+}
+
+// WithMaxDelay is how long the DelayOverLimit policy delays a message at most, one minute by default
+func WithMaxDelay(maxDelay time.Duration) RateLimitOption {
+	return func(c *rateLimitConfig) {
+		c.maxDelay = maxDelay
+	}
+}
+
+// WithIdleExpiry forgets the buckets of the keys idle for expiry, one minute by default
+func WithIdleExpiry(expiry time.Duration) RateLimitOption {
+	return func(c *rateLimitConfig) {
+		c.idleExpiry = expiry
+	}
+}
+
+func withRateLimitClock(clock timerwheel.Clock) RateLimitOption {
+	return func(c *rateLimitConfig) {
+		c.clock = clock
+	}
+}
+
+// RateLimit is a receiver middleware limiting the rate of the messages each actor receives, with a token bucket per key
+// holding burst tokens and refilled with perSecond tokens per second. The lifecycle and system messages are not limited.
+func RateLimit(perSecond float64, burst int, opts ...RateLimitOption) actor.ReceiverMiddleware {
+	cfg := &rateLimitConfig{
+		key:        SenderKey,
+		policy:     DropOverLimit,
+		maxDelay:   time.Minute,
+		idleExpiry: time.Minute,
+		clock:      timerwheel.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	limiter := newRateLimiter(perSecond, burst, cfg)
+
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch envelope.Message.(type) {
+			case actor.SystemMessage, actor.AutoReceiveMessage:
+				next(c, envelope)
+				return
+			}
+			if limiter.delivered(envelope) {
+				next(c, envelope)
+				return
+			}
+
+			key := cfg.key(c, envelope)
+			wait, ok := limiter.take(c.Self().String()+"/"+key, cfg.policy == DelayOverLimit)
+			if ok && wait == 0 {
+				next(c, envelope)
+				return
+			}
+
+			switch cfg.policy {
+			case DelayOverLimit:
+				if ctx, isContext := c.(actor.Context); ok && isContext {
+					limiter.delay(envelope, wait)
+					ctx.SendLater(c.Self(), envelope, wait)
+					return
+				}
+			case RespondOverLimit:
+				if envelope.Sender != nil {
+					c.ActorSystem().Root.Send(envelope.Sender, &RateLimitExceeded{Key: key, RetryAfter: wait})
+					return
+				}
+			}
+			c.ActorSystem().DeadLetter.SendUserMessageWithReason(c.Self(), envelope, actor.DeadLetterRateLimited)
+		}
+	}
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type rateLimiter struct {
+	perSecond float64
+	burst     float64
+	cfg       *rateLimitConfig
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	delayed   map[*actor.MessageEnvelope]time.Time
+}
+
+func newRateLimiter(perSecond float64, burst int, cfg *rateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		perSecond: perSecond,
+		burst:     float64(burst),
+		cfg:       cfg,
+		buckets:   make(map[string]*bucket),
+		lastSweep: cfg.clock.Now(),
+		delayed:   make(map[*actor.MessageEnvelope]time.Time),
+	}
+}
+
+// take takes a token from the bucket of key, or returns how long until it has one. With reserve the token
+// is taken ahead of time when it is available within the max delay, and the wait until then is returned
+func (l *rateLimiter) take(key string, reserve bool) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.cfg.clock.Now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	l.refill(b, now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+
+	wait := l.cfg.maxDelay + 1
+	if l.perSecond > 0 {
+		wait = time.Duration(math.Ceil((1 - b.tokens) / l.perSecond * float64(time.Second)))
+	}
+	if reserve && wait <= l.cfg.maxDelay {
+		b.tokens--
+		return wait, true
+	}
+	return wait, false
+}
+
+func (l *rateLimiter) refill(b *bucket, now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed.Seconds()*l.perSecond)
+		b.last = now
+	}
+}
+
+// sweep forgets the full buckets idle for the idle expiry, they are the same as new ones,
+// and the delayed messages which were never delivered because their actor stopped
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.cfg.idleExpiry {
+		return
+	}
+	l.lastSweep = now
+	for envelope, due := range l.delayed {
+		if now.Sub(due) >= l.cfg.idleExpiry {
+			delete(l.delayed, envelope)
+		}
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) < l.cfg.idleExpiry {
+			continue
+		}
+		l.refill(b, now)
+		if b.tokens >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// delay marks envelope as delayed by wait, it took its token already
+func (l *rateLimiter) delay(envelope *actor.MessageEnvelope, wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.delayed[envelope] = l.cfg.clock.Now().Add(wait)
+}
+
+// delivered returns whether envelope is delivered after its delay
+func (l *rateLimiter) delivered(envelope *actor.MessageEnvelope) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.delayed[envelope]
+	delete(l.delayed, envelope)
+	return ok
+}
+
+func (l *rateLimiter) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
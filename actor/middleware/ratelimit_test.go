@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spawnLimited spawns an actor limited by middleware which answers and forwards the strings it receives
+func spawnLimited(system *actor.ActorSystem, middleware actor.ReceiverMiddleware) (*actor.PID, chan string) {
+	received := make(chan string, 1000)
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			received <- msg
+			if ctx.Sender() != nil {
+				ctx.Respond(msg)
+			}
+		}
+	}).WithReceiverMiddleware(middleware))
+	return pid, received
+}
+
+func subscribeRateLimited(system *actor.ActorSystem) (chan *actor.DeadLetterEvent, func()) {
+	deadLetters := make(chan *actor.DeadLetterEvent, 1000)
+	sub := system.SystemEventStream.Subscribe(func(evt interface{}) {
+		if deadLetter, ok := evt.(*actor.DeadLetterEvent); ok && deadLetter.Reason == actor.DeadLetterRateLimited {
+			deadLetters <- deadLetter
+		}
+	})
+	return deadLetters, func() { system.SystemEventStream.Unsubscribe(sub) }
+}
+
+func TestRateLimit_Drop(t *testing.T) {
+	system := actor.NewActorSystem()
+	deadLetters, unsubscribe := subscribeRateLimited(system)
+	defer unsubscribe()
+	pid, received := spawnLimited(system, RateLimit(0.001, 2, WithRateLimitKey(MessageTypeKey)))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	for i := 0; i < 3; i++ {
+		system.Root.Send(pid, fmt.Sprint(i))
+	}
+	system.Root.Send(pid, &actor.PID{Id: "other type"})
+
+	assert.Equal(t, "0", <-received)
+	assert.Equal(t, "1", <-received)
+	select {
+	case deadLetter := <-deadLetters:
+		assert.Equal(t, "2", deadLetter.Message)
+		assert.Equal(t, pid, deadLetter.PID)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out")
+	}
+	assert.Empty(t, deadLetters)
+}
+
+func TestRateLimit_Delay(t *testing.T) {
+	system := actor.NewActorSystem()
+	pid, received := spawnLimited(system, RateLimit(20, 1, WithOverLimitPolicy(DelayOverLimit)))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		system.Root.Send(pid, fmt.Sprint(i))
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case msg := <-received:
+			assert.Equal(t, fmt.Sprint(i), msg, "the delayed messages keep their order")
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out")
+		}
+	}
+	assert.True(t, time.Since(start) >= 100*time.Millisecond, "the third message waits for two tokens")
+}
+
+func TestRateLimit_DelayBeyondMaxDelay(t *testing.T) {
+	system := actor.NewActorSystem()
+	deadLetters, unsubscribe := subscribeRateLimited(system)
+	defer unsubscribe()
+	pid, received := spawnLimited(system, RateLimit(1, 1, WithOverLimitPolicy(DelayOverLimit), WithMaxDelay(100*time.Millisecond)))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	system.Root.Send(pid, "first")
+	system.Root.Send(pid, "second")
+
+	assert.Equal(t, "first", <-received)
+	select {
+	case deadLetter := <-deadLetters:
+		assert.Equal(t, "second", deadLetter.Message)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out")
+	}
+}
+
+func TestRateLimit_Respond(t *testing.T) {
+	system := actor.NewActorSystem()
+	pid, _ := spawnLimited(system, RateLimit(1, 1, WithOverLimitPolicy(RespondOverLimit), WithRateLimitKey(HeaderKey("tenant"))))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	request := func(tenant, msg string) (interface{}, error) {
+		future := actor.NewFuture(system, time.Second)
+		env := &actor.MessageEnvelope{Message: msg, Sender: future.PID()}
+		env.SetHeader("tenant", tenant)
+		system.Root.Send(pid, env)
+		return future.Result()
+	}
+	res, err := request("a", "first")
+	require.NoError(t, err)
+	assert.Equal(t, "first", res)
+
+	res, err = request("a", "second")
+	require.NoError(t, err)
+	require.IsType(t, &RateLimitExceeded{}, res)
+	assert.Equal(t, "a", res.(*RateLimitExceeded).Key)
+	assert.InDelta(t, time.Second, res.(*RateLimitExceeded).RetryAfter, float64(100*time.Millisecond))
+
+	res, err = request("b", "third")
+	require.NoError(t, err)
+	assert.Equal(t, "third", res, "the tenants have their own bucket")
+}
+
+func TestRateLimit_ConcurrentSenders(t *testing.T) {
+	system := actor.NewActorSystem()
+	deadLetters, unsubscribe := subscribeRateLimited(system)
+	defer unsubscribe()
+	pid, received := spawnLimited(system, RateLimit(0.001, 5))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	const senders, messages = 10, 20
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func(sender *actor.PID) {
+			defer wg.Done()
+			for j := 0; j < messages; j++ {
+				system.Root.RequestWithCustomSender(pid, "hello", sender)
+			}
+		}(system.NewLocalPID(fmt.Sprint("sender", i)))
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool {
+		return len(received) == senders*5 && len(deadLetters) == senders*(messages-5)
+	}, time.Second, time.Millisecond)
+	perSender := make(map[string]int)
+	for len(deadLetters) > 0 {
+		perSender[(<-deadLetters).Sender.Id]++
+	}
+	assert.Len(t, perSender, senders)
+	for sender, count := range perSender {
+		assert.Equal(t, messages-5, count, sender)
+	}
+}
+
+func TestRateLimiter_ExpiresIdleBuckets(t *testing.T) {
+	clock := timerwheel.NewFakeClock(time.Now())
+	cfg := &rateLimitConfig{maxDelay: 2 * time.Minute, idleExpiry: time.Minute}
+	withRateLimitClock(clock)(cfg)
+	limiter := newRateLimiter(1.0/60, 1, cfg)
+
+	_, ok := limiter.take("a", false)
+	assert.True(t, ok)
+	for i := 0; i < 2; i++ {
+		_, ok = limiter.take("b", true)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 2, limiter.len())
+
+	clock.Advance(time.Minute)
+	_, ok = limiter.take("c", false)
+	assert.True(t, ok)
+	assert.Equal(t, 2, limiter.len(), "a is full and forgotten, b is not full yet")
+
+	clock.Advance(2 * time.Minute)
+	_, ok = limiter.take("c", false)
+	assert.True(t, ok)
+	assert.Equal(t, 1, limiter.len())
+}
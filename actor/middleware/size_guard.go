@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// OversizePolicy decides what happens to the messages larger than the limit of a size guard
+type OversizePolicy int
+
+const (
+	// RejectOversized drops the messages to the dead letters with the DeadLetterTooLarge reason,
+	// the requests fail with actor.ErrMessageTooLarge
+	RejectOversized OversizePolicy = iota
+	// PublishOversized lets the messages through and publishes an actor.LargeMessageEvent summarizing them
+	PublishOversized
+)
+
+type sizeGuardConfig struct {
+	policy        OversizePolicy
+	summaryLength int
+}
+
+// SizeGuardOption configures a size guard
+type SizeGuardOption func(*sizeGuardConfig)
+
+// WithOversizePolicy decides what happens to the messages over the limit, they are rejected by default
+func WithOversizePolicy(policy OversizePolicy) SizeGuardOption {
+	return func(c *sizeGuardConfig) {
+		c.policy = policy
+	}
+}
+
+// WithSummaryLength is the length of the summary of the messages let through, 256 bytes by default
+func WithSummaryLength(length int) SizeGuardOption {
+	return func(c *sizeGuardConfig) {
+		c.summaryLength = length
+	}
+}
+
+type sizeGuard struct {
+	limit int
+	cfg   *sizeGuardConfig
+}
+
+func newSizeGuard(limit int, opts []SizeGuardOption) *sizeGuard {
+	cfg := &sizeGuardConfig{summaryLength: 256}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &sizeGuard{limit: limit, cfg: cfg}
+}
+
+// allow returns whether the message of envelope to target is let through
+func (g *sizeGuard) allow(system *actor.ActorSystem, target *actor.PID, envelope *actor.MessageEnvelope) bool {
+	size, ok := actor.MessageSize(envelope.Message)
+	if !ok || size <= g.limit {
+		return true
+	}
+	if g.cfg.policy == PublishOversized {
+		system.SystemEventStream.Publish(actor.NewLargeMessageEvent(target, envelope, size, g.limit, g.cfg.summaryLength))
+		return true
+	}
+
+	system.DeadLetter.SendUserMessageWithReason(target, envelope, actor.DeadLetterTooLarge)
+	if future, ok := actor.FutureOf(system, envelope.Sender); ok {
+		future.Fail(actor.ErrMessageTooLarge)
+	} else if envelope.Sender != nil {
+		system.Root.Send(envelope.Sender, actor.NewErrorResponse(actor.ErrMessageTooLarge))
+	}
+	return false
+}
+
+// SenderSizeGuard is a sender middleware guarding the targets from the messages larger than limit bytes, as estimated
+// by actor.MessageSize. The messages whose size cannot be estimated are let through
+func SenderSizeGuard(limit int, opts ...SizeGuardOption) actor.SenderMiddleware {
+	guard := newSizeGuard(limit, opts)
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			if guard.allow(c.ActorSystem(), target, envelope) {
+				next(c, target, envelope)
+			}
+		}
+	}
+}
+
+// ReceiverSizeGuard is a receiver middleware guarding the actor from the messages larger than limit bytes, as
+// SenderSizeGuard. The requests rejected are answered with an actor.ErrorResponse. The lifecycle and system messages
+// are not guarded
+func ReceiverSizeGuard(limit int, opts ...SizeGuardOption) actor.ReceiverMiddleware {
+	guard := newSizeGuard(limit, opts)
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch envelope.Message.(type) {
+			case actor.SystemMessage, actor.AutoReceiveMessage:
+				next(c, envelope)
+				return
+			}
+			if guard.allow(c.ActorSystem(), c.Self(), envelope) {
+				next(c, envelope)
+			}
+		}
+	}
+}
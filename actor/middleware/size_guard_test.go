@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sizedMessage struct {
+	size int
+}
+
+func (m *sizedMessage) Size() int {
+	return m.size
+}
+
+// spawnEcho spawns an actor answering the messages it receives with themselves
+func spawnEcho(system *actor.ActorSystem, middleware ...actor.ReceiverMiddleware) (*actor.PID, *int32) {
+	var received int32
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case string, []byte, *sizedMessage, int:
+			atomic.AddInt32(&received, 1)
+			if ctx.Sender() != nil {
+				ctx.Respond(ctx.Message())
+			}
+		}
+	}).WithReceiverMiddleware(middleware...))
+	return pid, &received
+}
+
+func subscribeTooLarge(system *actor.ActorSystem) chan *actor.DeadLetterEvent {
+	deadLetters := make(chan *actor.DeadLetterEvent, 10)
+	system.EventStream.Subscribe(func(msg interface{}) {
+		if deadLetter, ok := msg.(*actor.DeadLetterEvent); ok && deadLetter.Reason == actor.DeadLetterTooLarge {
+			deadLetters <- deadLetter
+		}
+	})
+	return deadLetters
+}
+
+func TestSenderSizeGuard_Rejects(t *testing.T) {
+	system := actor.NewActorSystem()
+	deadLetters := subscribeTooLarge(system)
+	root := actor.NewRootContext(system, nil).WithSenderMiddleware(SenderSizeGuard(100))
+	pid, received := spawnEcho(system)
+
+	err := root.RequestFuture(pid, strings.Repeat("x", 101), time.Second).Wait()
+	assert.Equal(t, actor.ErrMessageTooLarge, err)
+	root.Send(pid, &sizedMessage{size: 1000})
+	assert.Equal(t, strings.Repeat("x", 101), (<-deadLetters).Message)
+	assert.Equal(t, &sizedMessage{size: 1000}, (<-deadLetters).Message)
+
+	// the small messages and the ones of unknown size are let through
+	for _, msg := range []interface{}{strings.Repeat("x", 100), []byte("small"), 1} {
+		res, err := root.RequestFuture(pid, msg, time.Second).Result()
+		require.NoError(t, err)
+		assert.Equal(t, msg, res)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(received))
+}
+
+func TestReceiverSizeGuard_RejectsRequests(t *testing.T) {
+	system := actor.NewActorSystem()
+	deadLetters := subscribeTooLarge(system)
+	pid, received := spawnEcho(system, ReceiverSizeGuard(100))
+
+	err := system.Root.RequestFuture(pid, make([]byte, 101), time.Second).Wait()
+	var actorErr *actor.ActorError
+	require.True(t, errors.As(err, &actorErr))
+	assert.Equal(t, "TooLarge", actorErr.Code)
+	assert.Equal(t, pid, (<-deadLetters).PID)
+
+	_, err = system.Root.RequestFuture(pid, make([]byte, 100), time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(received))
+}
+
+func TestSizeGuard_PublishesLargeMessages(t *testing.T) {
+	system := actor.NewActorSystem()
+	events := make(chan *actor.LargeMessageEvent, 10)
+	system.EventStream.Subscribe(func(msg interface{}) {
+		if e, ok := msg.(*actor.LargeMessageEvent); ok {
+			events <- e
+		}
+	})
+	pid, received := spawnEcho(system, ReceiverSizeGuard(100, WithOversizePolicy(PublishOversized), WithSummaryLength(10)))
+
+	res, err := system.Root.RequestFuture(pid, strings.Repeat("large ", 20), time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("large ", 20), res)
+	assert.Equal(t, int32(1), atomic.LoadInt32(received))
+	e := <-events
+	assert.Equal(t, pid, e.PID)
+	assert.Equal(t, "string", e.MessageType)
+	assert.Equal(t, 120, e.Size)
+	assert.Equal(t, 100, e.Limit)
+	assert.Equal(t, "large larg", e.Summary)
+}
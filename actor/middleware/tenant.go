@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// ErrMissingTenant fails the requests a strict TenantGuard rejected
+var ErrMissingTenant = &actor.ActorError{Message: "missing tenant header", Code: "MissingTenant"}
+
+type tenantGuardConfig struct {
+	strict        bool
+	defaultTenant string
+}
+
+// TenantGuardOption configures a tenant guard
+type TenantGuardOption func(*tenantGuardConfig)
+
+// WithStrictTenant rejects the messages without the tenant header
+func WithStrictTenant() TenantGuardOption {
+	return func(c *tenantGuardConfig) {
+		c.strict = true
+	}
+}
+
+// WithDefaultTenant receives the messages without the tenant header as messages of tenant, unless the guard is strict
+func WithDefaultTenant(tenant string) TenantGuardOption {
+	return func(c *tenantGuardConfig) {
+		c.defaultTenant = tenant
+	}
+}
+
+// TenantGuard is a receiver middleware enforcing the tenant header of the messages the actor receives.
+// A strict guard drops the messages without it to the dead letters with the DeadLetterRejected reason, and fails the
+// requests with ErrMissingTenant. Otherwise they are let through, with the default tenant when there is one.
+// The lifecycle and system messages are not guarded. The messages can be received fairly across the tenants with a
+// mailbox.Fair mailbox keyed by actor.HeaderClassifier(header)
+func TenantGuard(header string, opts ...TenantGuardOption) actor.ReceiverMiddleware {
+	cfg := &tenantGuardConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch envelope.Message.(type) {
+			case actor.SystemMessage, actor.AutoReceiveMessage, *actor.ReceiveTimeout:
+				next(c, envelope)
+				return
+			}
+			if envelope.GetHeader(header) != "" {
+				next(c, envelope)
+				return
+			}
+			if !cfg.strict {
+				if cfg.defaultTenant != "" {
+					// the header of the envelope may be shared with other messages
+					envelope = &actor.MessageEnvelope{Header: envelope.Header.ToMap(), Message: envelope.Message, Sender: envelope.Sender}
+					envelope.SetHeader(header, cfg.defaultTenant)
+				}
+				next(c, envelope)
+				return
+			}
+
+			system := c.ActorSystem()
+			system.DeadLetter.SendUserMessageWithReason(c.Self(), envelope, actor.DeadLetterRejected)
+			if future, ok := actor.FutureOf(system, envelope.Sender); ok {
+				future.Fail(ErrMissingTenant)
+			} else if envelope.Sender != nil {
+				system.Root.Send(envelope.Sender, actor.NewErrorResponse(ErrMissingTenant))
+			}
+		}
+	}
+}
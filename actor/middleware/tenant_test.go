@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spawnTenantEcho spawns an actor answering the messages it receives with their tenant
+func spawnTenantEcho(system *actor.ActorSystem, guard actor.ReceiverMiddleware) *actor.PID {
+	return system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			ctx.Respond(ctx.MessageHeader().Get("tenant"))
+		}
+	}).WithReceiverMiddleware(guard))
+}
+
+func requestAs(system *actor.ActorSystem, pid *actor.PID, tenant string) (interface{}, error) {
+	future := actor.NewFuture(system, time.Second)
+	env := &actor.MessageEnvelope{Message: "hello", Sender: future.PID()}
+	if tenant != "" {
+		env.SetHeader("tenant", tenant)
+	}
+	system.Root.Send(pid, env)
+	return future.Result()
+}
+
+func TestTenantGuard_Strict(t *testing.T) {
+	system := actor.NewActorSystem()
+	deadLetters := make(chan *actor.DeadLetterEvent, 10)
+	system.EventStream.Subscribe(func(msg interface{}) {
+		if deadLetter, ok := msg.(*actor.DeadLetterEvent); ok && deadLetter.Reason == actor.DeadLetterRejected {
+			deadLetters <- deadLetter
+		}
+	})
+	pid := spawnTenantEcho(system, TenantGuard("tenant", WithStrictTenant(), WithDefaultTenant("ignored")))
+
+	_, err := requestAs(system, pid, "")
+	assert.Equal(t, ErrMissingTenant, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "hello", (<-deadLetters).Message)
+
+	res, err := requestAs(system, pid, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", res)
+}
+
+func TestTenantGuard_DefaultTenant(t *testing.T) {
+	system := actor.NewActorSystem()
+	pid := spawnTenantEcho(system, TenantGuard("tenant", WithDefaultTenant("shared")))
+
+	res, err := requestAs(system, pid, "")
+	require.NoError(t, err)
+	assert.Equal(t, "shared", res)
+	res, err = requestAs(system, pid, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", res, "the tenant of the message is kept")
+
+	// without a default tenant the messages are let through as they are
+	pid = spawnTenantEcho(system, TenantGuard("tenant"))
+	res, err = requestAs(system, pid, "")
+	require.NoError(t, err)
+	assert.Equal(t, "", res)
+}
@@ -0,0 +1,143 @@
+package actor_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// workerActor reports its messages to the probe, and cannot passivate while it has work pending
+type workerActor struct {
+	probe   *actor.PID
+	pending int
+}
+
+func (w *workerActor) CanPassivate() bool {
+	return w.pending == 0
+}
+
+func (w *workerActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case string:
+		switch msg {
+		case "work":
+			w.pending++
+		case "done":
+			w.pending--
+		}
+		ctx.Send(w.probe, msg)
+	case *actor.ReceiveTimeout, *types.Empty, *types.StringValue:
+		ctx.Send(w.probe, msg)
+	}
+}
+
+type workerFixture struct {
+	system *actor.ActorSystem
+	clock  *testkit.ManualClock
+	probe  *testkit.TestProbe
+	pid    *actor.PID
+}
+
+// spawnWorker spawns a worker passivated once idle during idle, watched by the probe. The props are configured
+// with configure
+func spawnWorker(t *testing.T, idle time.Duration, configure ...func(props *actor.Props) *actor.Props) *workerFixture {
+	f := &workerFixture{clock: testkit.NewManualClock(time.Now())}
+	f.system = actor.NewActorSystem(actor.WithClock(f.clock))
+	f.probe = testkit.NewTestProbe(f.system)
+	t.Cleanup(f.probe.Stop)
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &workerActor{probe: f.probe.PID()}
+	}).WithIdlePassivation(idle)
+	for _, configure := range configure {
+		props = configure(props)
+	}
+	f.pid = f.system.Root.Spawn(props)
+	f.probe.Watch(f.pid)
+	return f
+}
+
+// advance moves the clock once the idle timer of the worker is armed again
+func (f *workerFixture) advance(t *testing.T, d time.Duration) {
+	require.Eventually(t, func() bool { return f.clock.Pending() == 1 }, time.Second, time.Millisecond)
+	f.clock.Advance(d)
+}
+
+// expectPassivated expects the termination of the worker, the system message may overtake the ReceiveTimeout reported
+func (f *workerFixture) expectPassivated(t *testing.T) {
+	expected := &actor.Terminated{Who: f.pid, Why: actor.TerminatedReason_Passivated}
+	f.probe.FishForMessage(t, func(message interface{}) bool {
+		if _, ok := message.(*actor.ReceiveTimeout); ok {
+			return false
+		}
+		return assert.Equal(t, expected, message)
+	}, 0)
+}
+
+func TestIdlePassivation_TrafficResetsIdleClock(t *testing.T) {
+	f := spawnWorker(t, time.Second)
+
+	for i := 0; i < 5; i++ {
+		f.advance(t, 900*time.Millisecond)
+		f.probe.Send(f.pid, "ping")
+		f.probe.ExpectMsg(t, "ping", 0)
+	}
+	f.advance(t, 999*time.Millisecond)
+	f.probe.ExpectNoMsg(t, 10*time.Millisecond)
+	f.advance(t, time.Millisecond)
+	f.expectPassivated(t)
+}
+
+func TestIdlePassivation_CanPassivateVeto(t *testing.T) {
+	f := spawnWorker(t, time.Second)
+
+	f.probe.Send(f.pid, "work")
+	f.probe.ExpectMsg(t, "work", 0)
+	f.advance(t, time.Second)
+	f.probe.ExpectMsg(t, &actor.ReceiveTimeout{}, 0)
+	f.probe.ExpectNoMsg(t, 10*time.Millisecond)
+
+	f.probe.Send(f.pid, "done")
+	f.probe.ExpectMsg(t, "done", 0)
+	f.advance(t, time.Second)
+	f.expectPassivated(t)
+}
+
+func TestIdlePassivation_StopIsNotPassivation(t *testing.T) {
+	f := spawnWorker(t, time.Second)
+
+	f.system.Root.Stop(f.pid)
+	f.probe.ExpectMsg(t, &actor.Terminated{Who: f.pid, Why: actor.TerminatedReason_Stopped}, 0)
+}
+
+func TestIdlePassivation_ExemptTypesDoNotResetIdleClock(t *testing.T) {
+	f := spawnWorker(t, time.Second, func(props *actor.Props) *actor.Props {
+		return props.WithReceiveTimeoutExemptTypes(reflect.TypeOf(&types.Empty{}))
+	})
+
+	// the heartbeats of a foreign type do not keep the worker alive
+	f.advance(t, 900*time.Millisecond)
+	f.probe.Send(f.pid, &types.Empty{})
+	f.probe.ExpectMsg(t, &types.Empty{}, 0)
+	f.advance(t, 100*time.Millisecond)
+	f.expectPassivated(t)
+}
+
+func TestIdlePassivation_NonExemptTypesResetIdleClock(t *testing.T) {
+	f := spawnWorker(t, time.Second, func(props *actor.Props) *actor.Props {
+		return props.WithReceiveTimeoutExemptTypes(reflect.TypeOf(&types.Empty{}))
+	})
+
+	f.advance(t, 900*time.Millisecond)
+	f.probe.Send(f.pid, &types.StringValue{Value: "work"})
+	f.probe.ExpectMsg(t, &types.StringValue{Value: "work"}, 0)
+	f.advance(t, 999*time.Millisecond)
+	f.probe.ExpectNoMsg(t, 10*time.Millisecond)
+	f.advance(t, time.Millisecond)
+	f.expectPassivated(t)
+}
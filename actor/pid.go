@@ -38,6 +38,9 @@ func (pid *PID) ref(actorSystem *ActorSystem) Process {
 
 // sendUserMessage sends a messages asynchronously to the PID
 func (pid *PID) sendUserMessage(actorSystem *ActorSystem, message interface{}) {
+	if actorSystem.Config.CopyOnSend {
+		message = actorSystem.copyOnSend(pid, message)
+	}
 	pid.ref(actorSystem).SendUserMessage(pid, message)
 }
 
@@ -45,6 +48,17 @@ func (pid *PID) sendSystemMessage(actorSystem *ActorSystem, message interface{})
 	pid.ref(actorSystem).SendSystemMessage(pid, message)
 }
 
+// stop stops the actor of pid for reason, other processes stop as they do on Stop
+func (pid *PID) stop(actorSystem *ActorSystem, reason StopReason) {
+	ref := pid.ref(actorSystem)
+	if actor, ok := ref.(*ActorProcess); ok {
+		atomic.StoreInt32(&actor.dead, 1)
+		actor.SendSystemMessage(pid, &Stop{Reason: reason})
+		return
+	}
+	ref.Stop(pid)
+}
+
 func (pid *PID) String() string {
 	if pid == nil {
 		return "nil"
@@ -1,14 +1,21 @@
 package actor
 
-import "fmt"
-
+// PIDSet is a set of PIDs iterated in their insertion order
 type PIDSet struct {
-	pids   []*PID
-	lookup map[string]*PID
+	// pids holds nil where PIDs were removed, until the set is compacted
+	pids    []*PID
+	lookup  map[pidKey]int // the indexes of the PIDs in pids
+	removed int
+}
+
+// pidKey is comparable, so that looking a PID up does not allocate a key
+type pidKey struct {
+	address string
+	id      string
 }
 
-func (p *PIDSet) key(pid *PID) string {
-	return fmt.Sprintf("%v:%v", pid.Address, pid.Id)
+func keyOf(pid *PID) pidKey {
+	return pidKey{address: pid.Address, id: pid.Id}
 }
 
 // NewPIDSet returns a new PIDSet with the given pids.
@@ -22,21 +29,13 @@ func NewPIDSet(pids ...*PID) *PIDSet {
 
 func (p *PIDSet) ensureInit() {
 	if p.lookup == nil {
-		p.lookup = make(map[string]*PID)
-	}
-}
-
-func (p *PIDSet) indexOf(v *PID) int {
-	for i, pid := range p.pids {
-		if v.Equal(pid) {
-			return i
-		}
+		p.lookup = make(map[pidKey]int)
 	}
-	return -1
 }
 
 func (p *PIDSet) Contains(v *PID) bool {
-	return p.lookup[p.key(v)] != nil
+	_, ok := p.lookup[keyOf(v)]
+	return ok
 }
 
 // Add adds the element v to the set
@@ -45,34 +44,56 @@ func (p *PIDSet) Add(v *PID) {
 	if p.Contains(v) {
 		return
 	}
-	p.lookup[p.key(v)] = v
+	p.lookup[keyOf(v)] = len(p.pids)
 	p.pids = append(p.pids, v)
 }
 
 // Remove removes v from the set and returns true if them element existed
 func (p *PIDSet) Remove(v *PID) bool {
-	p.ensureInit()
-	i := p.indexOf(v)
-	if i == -1 {
+	key := keyOf(v)
+	i, ok := p.lookup[key]
+	if !ok {
 		return false
 	}
+	delete(p.lookup, key)
 
-	delete(p.lookup, p.key(v))
-
-	p.pids = append(p.pids[:i], p.pids[i+1:]...)
-
+	p.pids[i] = nil
+	p.removed++
+	if p.removed > len(p.pids)/2 {
+		p.compact()
+	}
 	return true
 }
 
+// compact drops the removed PIDs from pids
+func (p *PIDSet) compact() {
+	if p.removed == 0 {
+		return
+	}
+	pids := p.pids[:0]
+	for _, pid := range p.pids {
+		if pid != nil {
+			p.lookup[keyOf(pid)] = len(pids)
+			pids = append(pids, pid)
+		}
+	}
+	for i := len(pids); i < len(p.pids); i++ {
+		p.pids[i] = nil
+	}
+	p.pids = pids
+	p.removed = 0
+}
+
 // Len returns the number of elements in the set
 func (p *PIDSet) Len() int {
-	return len(p.pids)
+	return len(p.pids) - p.removed
 }
 
 // Clear removes all the elements in the set
 func (p *PIDSet) Clear() {
 	p.pids = p.pids[:0]
-	p.lookup = make(map[string]*PID)
+	p.lookup = make(map[pidKey]int)
+	p.removed = 0
 }
 
 // Empty reports whether the set is empty
@@ -80,22 +101,66 @@ func (p *PIDSet) Empty() bool {
 	return p.Len() == 0
 }
 
-// Values returns all the elements of the set as a slice
+// Values returns all the elements of the set as a new slice, the set can be changed while ranging over it
 func (p *PIDSet) Values() []*PID {
-	return p.pids
+	values := make([]*PID, 0, p.Len())
+	for _, pid := range p.pids {
+		if pid != nil {
+			values = append(values, pid)
+		}
+	}
+	return values
 }
 
-// ForEach invokes f for every element of the set
+// ForEach invokes f for every element of the set, f must not change the set
 func (p *PIDSet) ForEach(f func(i int, pid *PID)) {
-	for i, pid := range p.pids {
-		f(i, pid)
+	i := 0
+	for _, pid := range p.pids {
+		if pid != nil {
+			f(i, pid)
+			i++
+		}
 	}
 }
 
+// Get returns the element at index in the insertion order. It does not change the set, so that it may be called
+// concurrently, it walks past the removed PIDs until the set is compacted
 func (p *PIDSet) Get(index int) *PID {
-	return p.pids[index]
+	if p.removed == 0 {
+		return p.pids[index]
+	}
+	for _, pid := range p.pids {
+		if pid == nil {
+			continue
+		}
+		if index == 0 {
+			return pid
+		}
+		index--
+	}
+	panic("actor: PIDSet index out of range")
 }
 
 func (p *PIDSet) Clone() *PIDSet {
-	return NewPIDSet(p.pids...)
+	return NewPIDSet(p.Values()...)
+}
+
+// Union returns a set of the elements of p followed by the ones of other not in p
+func (p *PIDSet) Union(other *PIDSet) *PIDSet {
+	union := p.Clone()
+	other.ForEach(func(_ int, pid *PID) {
+		union.Add(pid)
+	})
+	return union
+}
+
+// Intersect returns a set of the elements of p which are in other, in the order of p
+func (p *PIDSet) Intersect(other *PIDSet) *PIDSet {
+	intersection := &PIDSet{}
+	p.ForEach(func(_ int, pid *PID) {
+		if other.Contains(pid) {
+			intersection.Add(pid)
+		}
+	})
+	return intersection
 }
@@ -2,6 +2,7 @@ package actor
 
 import (
 	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,6 +55,36 @@ func TestPIDSet_AddMap(t *testing.T) {
 	assert.Equal(t, 1, s.Len())
 }
 
+func TestPIDSet_RemoveKeepsOrder(t *testing.T) {
+	s := NewPIDSet(NewPID("nohost", "p1"), NewPID("nohost", "p2"), NewPID("nohost", "p3"))
+	assert.True(t, s.Remove(NewPID("nohost", "p2")))
+	assert.False(t, s.Remove(NewPID("nohost", "p2")))
+	assert.False(t, s.Remove(NewPID("otherhost", "p1")))
+	assert.Equal(t, []*PID{NewPID("nohost", "p1"), NewPID("nohost", "p3")}, s.Values())
+	assert.False(t, s.Contains(NewPID("nohost", "p2")))
+}
+
+func TestPIDSet_UnionIntersect(t *testing.T) {
+	p1, p2, p3, p4 := NewPID("nohost", "p1"), NewPID("nohost", "p2"), NewPID("nohost", "p3"), NewPID("otherhost", "p1")
+	a := NewPIDSet(p3, p1, p2)
+	b := NewPIDSet(p4, p2, p3)
+
+	assert.Equal(t, []*PID{p3, p1, p2, p4}, a.Union(b).Values())
+	assert.Equal(t, []*PID{p3, p2}, a.Intersect(b).Values())
+	assert.Equal(t, []*PID{p2, p3}, b.Intersect(a).Values())
+	assert.True(t, a.Intersect(NewPIDSet()).Empty())
+	assert.Equal(t, 3, a.Len(), "the operands are left as is")
+}
+
+func TestPIDSet_NoAllocation(t *testing.T) {
+	s := NewPIDSet(pids[:100]...)
+	member := NewPID("nohost", "p50")
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		s.Contains(member)
+		s.Add(member)
+	}))
+}
+
 var pids []*PID
 
 func init() {
@@ -116,3 +147,81 @@ func pidSetAddRemove(b *testing.B, data []*PID) {
 		}
 	}
 }
+
+func BenchmarkPIDSet_Contains(b *testing.B) {
+	s := NewPIDSet(pids[:500]...)
+	member := NewPID("nohost", "p250")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(member)
+	}
+}
+
+func BenchmarkPIDSet_AddExisting(b *testing.B) {
+	s := NewPIDSet(pids[:500]...)
+	member := NewPID("nohost", "p250")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Add(member)
+	}
+}
+
+func TestPIDSet_ValuesIsACopy(t *testing.T) {
+	p1, p2, p3 := NewPID("nohost", "p1"), NewPID("nohost", "p2"), NewPID("nohost", "p3")
+	s := NewPIDSet(p1, p2, p3)
+	var ranged []*PID
+	for _, pid := range s.Values() {
+		ranged = append(ranged, pid)
+		s.Remove(pid)
+	}
+	assert.Equal(t, []*PID{p1, p2, p3}, ranged, "removing while ranging skips none")
+	assert.True(t, s.Empty())
+
+	s.Add(p1)
+	s.Values()[0] = p2
+	assert.Equal(t, []*PID{p1}, s.Values())
+}
+
+func TestPIDSet_RemoveCompacts(t *testing.T) {
+	s := NewPIDSet(pids[:10]...)
+	for i := 0; i < 10; i += 2 {
+		s.Remove(pids[i])
+	}
+	s.Remove(pids[9])
+	expected := []*PID{pids[1], pids[3], pids[5], pids[7]}
+	assert.Equal(t, expected, s.Values())
+	assert.Len(t, s.pids, 4, "the removed PIDs are dropped once they are half the set")
+	for i, pid := range expected {
+		assert.Equal(t, pid, s.Get(i))
+	}
+	s.ForEach(func(i int, pid *PID) {
+		assert.Equal(t, expected[i], pid)
+	})
+
+	s.Remove(pids[3])
+	s.Add(pids[3])
+	assert.Equal(t, []*PID{pids[1], pids[5], pids[7], pids[3]}, s.Values())
+	assert.Equal(t, pids[3], s.Get(3))
+	assert.True(t, s.Remove(pids[3]))
+	assert.Equal(t, 3, s.Len())
+}
+
+func TestPIDSet_GetConcurrentlyAfterRemove(t *testing.T) {
+	s := NewPIDSet(NewPID("local", "a"), NewPID("local", "b"), NewPID("local", "c"), NewPID("local", "d"))
+	s.Remove(NewPID("local", "a"))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.Equal(t, "b", s.Get(0).Id)
+				assert.Equal(t, "d", s.Get(2).Id)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,144 @@
+package actor_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failWith makes a preRestartActor panic with its reason
+type failWith struct {
+	reason interface{}
+}
+
+// preRestart is what a preRestartActor was told before restarting
+type preRestart struct {
+	incarnation int
+	reason      interface{}
+	message     interface{}
+}
+
+type preRestartActor struct {
+	incarnation int
+	restarts    chan preRestart
+	restarting  chan struct{}
+}
+
+func (a *preRestartActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Restarting:
+		a.restarting <- struct{}{}
+	case *failWith:
+		panic(msg.reason)
+	}
+}
+
+func (a *preRestartActor) PreRestart(reason interface{}, message interface{}) {
+	a.restarts <- preRestart{incarnation: a.incarnation, reason: reason, message: message}
+}
+
+// preRestartProps produces preRestartActors numbering their incarnations
+func preRestartProps(restarts chan preRestart, restarting chan struct{}) *actor.Props {
+	incarnations := 0
+	return actor.PropsFromProducer(func() actor.Actor {
+		incarnations++
+		return &preRestartActor{incarnation: incarnations, restarts: restarts, restarting: restarting}
+	})
+}
+
+func expectPreRestart(t *testing.T, restarts chan preRestart) preRestart {
+	select {
+	case r := <-restarts:
+		return r
+	case <-time.After(time.Second):
+		t.Fatal("PreRestart was not called")
+		return preRestart{}
+	}
+}
+
+func TestPreRestart_ReasonAndMessage(t *testing.T) {
+	system := actor.NewActorSystem()
+	restarts, restarting := make(chan preRestart, 10), make(chan struct{}, 10)
+	pid := system.Root.Spawn(preRestartProps(restarts, restarting))
+	defer system.Root.Stop(pid)
+
+	reason := errors.New("boom")
+	msg := &failWith{reason: reason}
+	system.Root.Send(pid, msg)
+	r := expectPreRestart(t, restarts)
+	assert.Equal(t, 1, r.incarnation, "the failed incarnation is told")
+	assert.Same(t, reason, r.reason)
+	assert.Same(t, msg, r.message)
+
+	// the message is unwrapped from its envelope
+	envelope := &actor.MessageEnvelope{Header: map[string]string{"k": "v"}, Message: &failWith{reason: "again"}}
+	system.Root.Send(pid, envelope)
+	r = expectPreRestart(t, restarts)
+	assert.Equal(t, 2, r.incarnation)
+	assert.Equal(t, "again", r.reason)
+	assert.Same(t, envelope.Message, r.message)
+	assert.Len(t, restarting, 0, "Restarting is not delivered to the actors told in PreRestart")
+}
+
+func TestPreRestart_Redelivery(t *testing.T) {
+	system := actor.NewActorSystem()
+	restarts, restarting := make(chan preRestart, 10), make(chan struct{}, 10)
+	parent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if props, ok := ctx.Message().(*actor.Props); ok {
+			ctx.Respond(ctx.Spawn(props))
+		}
+	}).WithSupervisor(redelivering))
+	defer system.Root.Stop(parent)
+	res, err := system.Root.RequestFuture(parent, preRestartProps(restarts, restarting).WithMaxRedeliveries(1), time.Second).Result()
+	require.NoError(t, err)
+
+	msg := &failWith{reason: "redelivered"}
+	system.Root.Send(res.(*actor.PID), msg)
+	for i := 1; i <= 2; i++ {
+		r := expectPreRestart(t, restarts)
+		assert.Equal(t, i, r.incarnation)
+		assert.Equal(t, "redelivered", r.reason)
+		assert.Same(t, msg, r.message)
+	}
+}
+
+func TestPreRestart_AllForOneSiblings(t *testing.T) {
+	system := actor.NewActorSystem()
+	failedRestarts, siblingRestarts := make(chan preRestart, 10), make(chan preRestart, 10)
+	restarting := make(chan struct{}, 10)
+	parent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if props, ok := ctx.Message().(*actor.Props); ok {
+			ctx.Respond(ctx.Spawn(props))
+		}
+	}).WithSupervisor(actor.NewAllForOneStrategy(10, 0, actor.DefaultDecider)))
+	defer system.Root.Stop(parent)
+	spawn := func(props *actor.Props) *actor.PID {
+		res, err := system.Root.RequestFuture(parent, props, time.Second).Result()
+		require.NoError(t, err)
+		return res.(*actor.PID)
+	}
+	failed := spawn(preRestartProps(failedRestarts, restarting))
+	spawn(preRestartProps(siblingRestarts, restarting))
+	spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Restarting); ok {
+			restarting <- struct{}{}
+		}
+	}))
+
+	msg := &failWith{reason: "sibling failed"}
+	system.Root.Send(failed, msg)
+	for _, restarts := range []chan preRestart{failedRestarts, siblingRestarts} {
+		r := expectPreRestart(t, restarts)
+		assert.Equal(t, "sibling failed", r.reason)
+		assert.Same(t, msg, r.message)
+	}
+	select {
+	case <-restarting:
+	case <-time.After(time.Second):
+		t.Fatal("the actors without PreRestart still receive Restarting")
+	}
+}
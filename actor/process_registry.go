@@ -1,28 +1,172 @@
 package actor
 
 import (
+	"sync"
 	"sync/atomic"
-
-	cmap "github.com/orcaman/concurrent-map"
 )
 
 type ProcessRegistryValue struct {
 	ActorSystem    *ActorSystem
 	SequenceID     uint64
 	Address        string
-	LocalPIDs      cmap.ConcurrentMap
 	RemoteHandlers []AddressResolver
+
+	// LocalPIDs holds the local processes, they are added and removed with Add and Remove
+	LocalPIDs *ProcessMap
+
+	localAlias    string
+	idBatches     sync.Pool
+	sequentialIds bool
+	liveActors    int64
+}
+
+// registryShardCount is the number of shards of the local processes, a power of 2
+const registryShardCount = 256
+
+// ProcessMap is a map of the processes by id, sharded by the hash of the ids with a lock per shard
+type ProcessMap struct {
+	shards [registryShardCount]registryShard
+}
+
+// registryShard holds the local processes whose id hashes to it, padded to its own cache line
+type registryShard struct {
+	sync.RWMutex
+	processes map[string]Process
+	_         [32]byte
+}
+
+// idBatchSize is the number of ids reserved at once from the sequence, to spare the contention on it
+const idBatchSize = 1024
+
+// idBatch is a range of reserved ids, next included and end excluded
+type idBatch struct {
+	next, end uint64
+}
+
+// WithSequentialIds generates the ids of the unnamed processes from a single sequence, as did the former versions,
+// so that they are increasing in the order the processes are spawned. They are unique but unordered by default
+func WithSequentialIds() SystemOption {
+	return func(system *ActorSystem) {
+		system.ProcessRegistry.sequentialIds = true
+	}
 }
 
 var (
 	localAddress = "nonhost"
+	// localRegistries are the registries of the systems of this process by their local address,
+	// to deliver the messages between them directly
+	localRegistries sync.Map
 )
 
+// WithLocalAddress addresses the processes of the system with address instead of the default nonhost, so that
+// several systems of the same process have distinct PIDs. The messages between them are delivered directly.
+// It panics when another system of the process uses the address, see ActorSystem.ReleaseLocalAddress
+func WithLocalAddress(address string) SystemOption {
+	return func(system *ActorSystem) {
+		if address == localAddress {
+			return
+		}
+		if _, loaded := localRegistries.LoadOrStore(address, system.ProcessRegistry); loaded {
+			panic("actor: local address " + address + " is already in use")
+		}
+		system.ProcessRegistry.Address = address
+		system.ProcessRegistry.localAlias = address
+	}
+}
+
+// ReleaseLocalAddress releases the address of the system set by WithLocalAddress, so that another system
+// can use it once this one is done with
+func (as *ActorSystem) ReleaseLocalAddress() {
+	if alias := as.ProcessRegistry.localAlias; alias != "" {
+		localRegistries.Delete(alias)
+	}
+}
+
+// isLocalAlias tells if address is the local address of a system of the process
+func isLocalAlias(address string) bool {
+	_, ok := localRegistries.Load(address)
+	return ok
+}
+
 func NewProcessRegistry(actorSystem *ActorSystem) *ProcessRegistryValue {
-	return &ProcessRegistryValue{
+	pr := &ProcessRegistryValue{
 		ActorSystem: actorSystem,
 		Address:     localAddress,
-		LocalPIDs:   cmap.New(),
+		LocalPIDs:   newProcessMap(),
+	}
+	pr.idBatches.New = func() interface{} {
+		return &idBatch{}
+	}
+	return pr
+}
+
+func newProcessMap() *ProcessMap {
+	m := &ProcessMap{}
+	for i := range m.shards {
+		m.shards[i].processes = make(map[string]Process)
+	}
+	return m
+}
+
+// shard returns the shard of id, hashed with FNV-1a
+func (m *ProcessMap) shard(id string) *registryShard {
+	hash := uint32(2166136261)
+	for i := 0; i < len(id); i++ {
+		hash ^= uint32(id[i])
+		hash *= 16777619
+	}
+	return &m.shards[hash&(registryShardCount-1)]
+}
+
+// Get returns the process registered under id
+func (m *ProcessMap) Get(id string) (interface{}, bool) {
+	shard := m.shard(id)
+	shard.RLock()
+	process, ok := shard.processes[id]
+	shard.RUnlock()
+	return process, ok
+}
+
+// Has tells whether a process is registered under id
+func (m *ProcessMap) Has(id string) bool {
+	_, ok := m.Get(id)
+	return ok
+}
+
+// Count returns the number of the processes
+func (m *ProcessMap) Count() int {
+	count := 0
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.RLock()
+		count += len(shard.processes)
+		shard.RUnlock()
+	}
+	return count
+}
+
+// Keys returns the ids of the processes
+func (m *ProcessMap) Keys() []string {
+	var keys []string
+	m.IterCb(func(id string, _ interface{}) {
+		keys = append(keys, id)
+	})
+	return keys
+}
+
+// IterCb calls fn with the processes, shard by shard. fn is called without the locks held, it may use the map
+func (m *ProcessMap) IterCb(fn func(id string, process interface{})) {
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.RLock()
+		processes := make(map[string]Process, len(shard.processes))
+		for id, process := range shard.processes {
+			processes[id] = process
+		}
+		shard.RUnlock()
+		for id, process := range processes {
+			fn(id, process)
+		}
 	}
 }
 
@@ -55,22 +199,82 @@ func uint64ToId(u uint64) string {
 	return string(buf[i:])
 }
 
+// NextId returns a unique id for an unnamed process. The ids are taken from batches of the sequence cached
+// per processor, unless WithSequentialIds
 func (pr *ProcessRegistryValue) NextId() string {
-	counter := atomic.AddUint64(&pr.SequenceID, 1)
-	return uint64ToId(counter)
+	if pr.sequentialIds {
+		return uint64ToId(atomic.AddUint64(&pr.SequenceID, 1))
+	}
+	batch := pr.idBatches.Get().(*idBatch)
+	if batch.next == batch.end {
+		end := atomic.AddUint64(&pr.SequenceID, idBatchSize) + 1
+		batch.next, batch.end = end-idBatchSize, end
+	}
+	id := batch.next
+	batch.next++
+	pr.idBatches.Put(batch)
+	return uint64ToId(id)
 }
 
+// Add registers process under id, it returns false when id is registered already or the actors are over the
+// Config.MaxActors of the system, see TryAdd
 func (pr *ProcessRegistryValue) Add(process Process, id string) (*PID, bool) {
-	return &PID{
+	pid, err := pr.TryAdd(process, id)
+	return pid, err == nil
+}
+
+// TryAdd registers process under id. It returns ErrNameExists when id is registered already, and a
+// QuotaExceededError when process is an actor while the live actors of the system reached its Config.MaxActors
+func (pr *ProcessRegistryValue) TryAdd(process Process, id string) (*PID, error) {
+	pid := &PID{
 		Address: pr.Address,
 		Id:      id,
-	}, pr.LocalPIDs.SetIfAbsent(id, process)
+	}
+	_, isActor := process.(*ActorProcess)
+	if isActor {
+		live := atomic.AddInt64(&pr.liveActors, 1)
+		if max := pr.maxActors(); max > 0 && live > int64(max) {
+			atomic.AddInt64(&pr.liveActors, -1)
+			return pid, pr.ActorSystem.quotaExceeded(QuotaActors, max, nil, id)
+		}
+	}
+	shard := pr.LocalPIDs.shard(id)
+	shard.Lock()
+	_, exists := shard.processes[id]
+	if !exists {
+		shard.processes[id] = process
+	}
+	shard.Unlock()
+	if exists {
+		if isActor {
+			atomic.AddInt64(&pr.liveActors, -1)
+		}
+		return pid, ErrNameExists
+	}
+	return pid, nil
+}
+
+func (pr *ProcessRegistryValue) maxActors() int {
+	if pr.ActorSystem == nil || pr.ActorSystem.Config == nil {
+		return 0
+	}
+	return pr.ActorSystem.Config.MaxActors
+}
+
+// LiveActors returns the number of the actors registered
+func (pr *ProcessRegistryValue) LiveActors() int {
+	return int(atomic.LoadInt64(&pr.liveActors))
 }
 
 func (pr *ProcessRegistryValue) Remove(pid *PID) {
-	ref, _ := pr.LocalPIDs.Pop(pid.Id)
+	shard := pr.LocalPIDs.shard(pid.Id)
+	shard.Lock()
+	ref := shard.processes[pid.Id]
+	delete(shard.processes, pid.Id)
+	shard.Unlock()
 	if l, ok := ref.(*ActorProcess); ok {
 		atomic.StoreInt32(&l.dead, 1)
+		atomic.AddInt64(&pr.liveActors, -1)
 	}
 }
 
@@ -78,7 +282,10 @@ func (pr *ProcessRegistryValue) Get(pid *PID) (Process, bool) {
 	if pid == nil {
 		return pr.ActorSystem.DeadLetter, false
 	}
-	if pid.Address != localAddress && pid.Address != pr.Address {
+	if pid.Address != localAddress && pid.Address != pr.Address && pid.Address != pr.localAlias {
+		if other, ok := localRegistries.Load(pid.Address); ok {
+			return other.(*ProcessRegistryValue).GetLocal(pid.Id)
+		}
 		for _, handler := range pr.RemoteHandlers {
 			ref, ok := handler(pid)
 			if ok {
@@ -87,17 +294,23 @@ func (pr *ProcessRegistryValue) Get(pid *PID) (Process, bool) {
 		}
 		return pr.ActorSystem.DeadLetter, false
 	}
-	ref, ok := pr.LocalPIDs.Get(pid.Id)
-	if !ok {
-		return pr.ActorSystem.DeadLetter, false
-	}
-	return ref.(Process), true
+	return pr.GetLocal(pid.Id)
+}
+
+// forEachLocal calls fn with the local processes, shard by shard
+func (pr *ProcessRegistryValue) forEachLocal(fn func(id string, process Process)) {
+	pr.LocalPIDs.IterCb(func(id string, process interface{}) {
+		fn(id, process.(Process))
+	})
 }
 
 func (pr *ProcessRegistryValue) GetLocal(id string) (Process, bool) {
-	ref, ok := pr.LocalPIDs.Get(id)
+	shard := pr.LocalPIDs.shard(id)
+	shard.RLock()
+	ref, ok := shard.processes[id]
+	shard.RUnlock()
 	if !ok {
 		return pr.ActorSystem.DeadLetter, false
 	}
-	return ref.(Process), true
+	return ref, true
 }
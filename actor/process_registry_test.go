@@ -1,7 +1,10 @@
 package actor
 
 import (
+	"runtime"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,3 +48,113 @@ func BenchmarkUint64ToString2(b *testing.B) {
 	}
 	ss = s
 }
+
+func TestProcessRegistry_NextIdUniqueAcrossGoroutines(t *testing.T) {
+	pr := NewActorSystem().ProcessRegistry
+	const goroutines, perGoroutine = 16, 3 * idBatchSize
+	ids := make(chan string, goroutines*perGoroutine)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				ids <- pr.NextId()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool, goroutines*perGoroutine)
+	for id := range ids {
+		assert.False(t, seen[id], "duplicate id %s", id)
+		seen[id] = true
+	}
+	assert.Len(t, seen, goroutines*perGoroutine)
+}
+
+func TestProcessRegistry_SequentialIds(t *testing.T) {
+	pr := NewActorSystem(WithSequentialIds()).ProcessRegistry
+	first := pr.SequenceID
+	for i := uint64(1); i <= 3; i++ {
+		assert.Equal(t, uint64ToId(first+i), pr.NextId())
+	}
+}
+
+func TestProcessRegistry_AddRemoveGet(t *testing.T) {
+	system := NewActorSystem()
+	pr := system.ProcessRegistry
+	process := &ActorProcess{}
+
+	pid, ok := pr.Add(process, "registered")
+	assert.True(t, ok)
+	_, ok = pr.Add(&ActorProcess{}, "registered")
+	assert.False(t, ok)
+	ref, ok := pr.Get(pid)
+	assert.True(t, ok)
+	assert.Same(t, process, ref)
+	local, ok := pr.LocalPIDs.Get("registered")
+	assert.True(t, ok)
+	assert.Same(t, process, local)
+	assert.Contains(t, pr.LocalPIDs.Keys(), "registered")
+	assert.Equal(t, len(pr.LocalPIDs.Keys()), pr.LocalPIDs.Count())
+
+	pr.Remove(pid)
+	assert.Equal(t, int32(1), process.dead)
+	ref, ok = pr.GetLocal("registered")
+	assert.False(t, ok)
+	assert.False(t, pr.LocalPIDs.Has("registered"))
+	assert.Equal(t, system.DeadLetter, ref)
+}
+
+// runWith64Goroutines runs body in parallel on 64 goroutines or more
+func runWith64Goroutines(b *testing.B, body func(pb *testing.PB)) {
+	b.SetParallelism((64 + runtime.GOMAXPROCS(0) - 1) / runtime.GOMAXPROCS(0))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(body)
+}
+
+func BenchmarkProcessRegistry_NextId(b *testing.B) {
+	for _, bm := range []struct {
+		name    string
+		options []SystemOption
+	}{{"batched", nil}, {"sequential", []SystemOption{WithSequentialIds()}}} {
+		b.Run(bm.name, func(b *testing.B) {
+			pr := NewActorSystem(bm.options...).ProcessRegistry
+			runWith64Goroutines(b, func(pb *testing.PB) {
+				for pb.Next() {
+					ss = pr.NextId()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkProcessRegistry_AddRemove(b *testing.B) {
+	pr := NewActorSystem().ProcessRegistry
+	process := &ActorProcess{}
+	runWith64Goroutines(b, func(pb *testing.PB) {
+		for pb.Next() {
+			pid, _ := pr.Add(process, pr.NextId())
+			pr.Remove(pid)
+		}
+	})
+}
+
+func BenchmarkProcessRegistry_Get(b *testing.B) {
+	pr := NewActorSystem().ProcessRegistry
+	pids := make([]*PID, 1024)
+	for i := range pids {
+		pids[i], _ = pr.Add(&ActorProcess{}, pr.NextId())
+	}
+	var n uint64
+	runWith64Goroutines(b, func(pb *testing.PB) {
+		i := atomic.AddUint64(&n, 1)
+		for pb.Next() {
+			pr.Get(pids[i%uint64(len(pids))])
+			i++
+		}
+	})
+}
@@ -2,6 +2,8 @@ package actor
 
 import (
 	"errors"
+	"reflect"
+	"time"
 
 	"github.com/AsynkronIT/protoactor-go/mailbox"
 )
@@ -19,12 +21,13 @@ var (
 	defaultMailboxProducer = mailbox.Unbounded()
 	defaultSpawner         = func(actorSystem *ActorSystem, id string, props *Props, parentContext SpawnerContext) (*PID, error) {
 		ctx := newActorContext(actorSystem, props, parentContext.Self())
-		mb := props.produceMailbox()
-		dp := props.getDispatcher()
+		mb := props.produceMailbox(actorSystem)
+		dp := props.getDispatcher(actorSystem)
 		proc := NewActorProcess(mb)
-		pid, absent := actorSystem.ProcessRegistry.Add(proc, id)
-		if !absent {
-			return pid, ErrNameExists
+		proc.setParent(parentContext.Self())
+		pid, err := actorSystem.ProcessRegistry.TryAdd(proc, id)
+		if err != nil {
+			return pid, err
 		}
 		ctx.self = pid
 		mb.Start()
@@ -60,6 +63,20 @@ type Props struct {
 	spawnMiddlewareChain    SpawnFunc
 	contextDecorator        []ContextDecorator
 	contextDecoratorChain   ContextDecoratorFunc
+	keepDelayedSends        bool
+	idlePassivation         time.Duration
+	cleanup                 func(reason interface{})
+	stopDraining            StopDraining
+	watcherLimit            int
+	standby                 *standbyPool
+	maxRedeliveries         int
+	responseCheck           ResponseCheck
+	budget                  *messageBudget
+	liveness                *livenessConfig
+	maxChildren             int
+	receiveTimeoutExemption *receiveTimeoutExemption
+	messageHistory          int
+	notifyRestarts          bool
 }
 
 func (props *Props) getSpawner() SpawnFunc {
@@ -69,16 +86,16 @@ func (props *Props) getSpawner() SpawnFunc {
 	return props.spawner
 }
 
-func (props *Props) getDispatcher() mailbox.Dispatcher {
+func (props *Props) getDispatcher(actorSystem *ActorSystem) mailbox.Dispatcher {
 	if props.dispatcher == nil {
-		return defaultDispatcher
+		return actorSystem.Config.DefaultDispatcher
 	}
 	return props.dispatcher
 }
 
-func (props *Props) getSupervisor() SupervisorStrategy {
+func (props *Props) getSupervisor(actorSystem *ActorSystem) SupervisorStrategy {
 	if props.supervisionStrategy == nil {
-		return defaultSupervisionStrategy
+		return actorSystem.Config.DefaultSupervisorStrategy
 	}
 	return props.supervisionStrategy
 }
@@ -90,9 +107,9 @@ func (props *Props) getContextDecoratorChain() ContextDecoratorFunc {
 	return props.contextDecoratorChain
 }
 
-func (props *Props) produceMailbox() mailbox.Mailbox {
+func (props *Props) produceMailbox(actorSystem *ActorSystem) mailbox.Mailbox {
 	if props.mailboxProducer == nil {
-		return defaultMailboxProducer()
+		return actorSystem.Config.produceMailbox()
 	}
 	return props.mailboxProducer()
 }
@@ -177,6 +194,122 @@ func (props *Props) WithFunc(f ReceiveFunc) *Props {
 	return props
 }
 
+// WithCancelDelayedSendsOnStop decides whether the messages sent later by the actor are cancelled when it stops
+// before they are sent, they are by default
+func (props *Props) WithCancelDelayedSendsOnStop(cancel bool) *Props {
+	props.keepDelayedSends = !cancel
+	return props
+}
+
+// WithIdlePassivation stops the actor once it received no message during d, unless it is PassivationAware
+// and cannot passivate. Its watchers receive a Terminated with the Passivated reason
+func (props *Props) WithIdlePassivation(d time.Duration) *Props {
+	props.idlePassivation = d
+	return props
+}
+
+// WithCleanup calls cleanup when the actor is restarted or stopped after a failure, unless it is SafeCleanup,
+// e.g. to release the resources of an actor spawned from a func
+func (props *Props) WithCleanup(cleanup func(reason interface{})) *Props {
+	props.cleanup = cleanup
+	return props
+}
+
+// WithStopDraining decides what the actor does with the user messages already in its mailbox when it is stopped,
+// they are discarded by default. A PoisonPill stops the actor once the messages before it are processed
+func (props *Props) WithStopDraining(mode StopDraining) *Props {
+	props.stopDraining = mode
+	return props
+}
+
+// WithWatcherLimit publishes WatcherLimitExceeded when more than limit actors watch the actor,
+// to find the watchers which forget to unwatch it
+func (props *Props) WithWatcherLimit(limit int) *Props {
+	props.watcherLimit = limit
+	return props
+}
+
+// WithWarmStandby keeps n incarnations of the actor ready, produced and warmed up in the background, see WarmupActor.
+// The spawns and restarts take an incarnation from the standby, which is replenished once the first actor spawned
+func (props *Props) WithWarmStandby(n int) *Props {
+	props.standby = nil
+	if n > 0 {
+		props.standby = newStandbyPool(n)
+	}
+	return props
+}
+
+// WithMaxRedeliveries sets how many times the message the actor failed with is redelivered when it is restarted with
+// RestartWithRedeliveryDirective, DefaultMaxRedeliveries when max is not positive.
+// A message failing the actor once more is a poison message: it is a dead letter and a PoisonMessageEvent is published
+func (props *Props) WithMaxRedeliveries(max int) *Props {
+	props.maxRedeliveries = max
+	return props
+}
+
+// WithResponseCheck checks whether the actor responds to the requests awaited by a future, overriding the
+// ResponseCheck of the actor system. It finds the requests which time out during development, but reports the
+// requests answered later with a message sent to the sender kept aside
+func (props *Props) WithResponseCheck(check ResponseCheck) *Props {
+	props.responseCheck = check
+	return props
+}
+
+// WithMessageBudget sets the time the actor is expected to process a message within. The messages processed longer
+// than soft are reported with a SlowMessageEvent, sampling the stack of the actor while it still processes them,
+// unless soft is zero.
+// The messages processed longer than hard are reported with a BudgetExceededEvent, unless hard is zero, and the actor
+// fails with a BudgetExceededError once it exceeded hard maxViolations messages in a row, unless maxViolations is zero.
+// The actor is not preempted: it keeps its dispatcher goroutine until it returns
+func (props *Props) WithMessageBudget(soft, hard time.Duration, maxViolations int) *Props {
+	props.budget = &messageBudget{soft: soft, hard: hard, maxViolations: maxViolations}
+	return props
+}
+
+// WithLiveness makes the actor publish an ActorHeartbeat every interval while it makes progress, and an
+// ActorUnresponsiveEvent to be published when it published none for longer than timeout while it is alive: it is stuck
+// processing a message, whereas it did not fail
+func (props *Props) WithLiveness(interval, timeout time.Duration) *Props {
+	props.liveness = &livenessConfig{interval: interval, timeout: timeout}
+	return props
+}
+
+// WithMaxChildren refuses the spawns of the actor with ErrTooManyChildren while it has n live children,
+// they are allowed again as its children terminate
+func (props *Props) WithMaxChildren(n int) *Props {
+	props.maxChildren = n
+	return props
+}
+
+// WithMessageHistory records the last n messages the actor processed, their type, sender, time and processing
+// duration but not their payload. The history is in the ActorInfo of the actor when inspected, and in the Failure
+// published on the system event stream when the actor fails
+func (props *Props) WithMessageHistory(n int) *Props {
+	props.messageHistory = n
+	return props
+}
+
+// WithNotifyParentOnRestart decides whether the parent of the actor receives a ChildRestarted each time the actor
+// restarts, it does not by default
+func (props *Props) WithNotifyParentOnRestart(notify bool) *Props {
+	props.notifyRestarts = notify
+	return props
+}
+
+// WithReceiveTimeoutExemptTypes does not reset the receive timeout of the actor on the messages of types, as if
+// they were NotInfluenceReceiveTimeout, e.g. the heartbeats of types it cannot change. The interface types exempt
+// the messages implementing them
+func (props *Props) WithReceiveTimeoutExemptTypes(types ...reflect.Type) *Props {
+	props.receiveTimeoutExemption = props.receiveTimeoutExemption.with(types, nil)
+	return props
+}
+
+// WithReceiveTimeoutExemption does not reset the receive timeout of the actor on the messages exempt tells
+func (props *Props) WithReceiveTimeoutExemption(exempt func(message interface{}) bool) *Props {
+	props.receiveTimeoutExemption = props.receiveTimeoutExemption.with(nil, exempt)
+	return props
+}
+
 func (props *Props) WithSpawnMiddleware(middleware ...SpawnMiddleware) *Props {
 	props.spawnMiddleware = append(props.spawnMiddleware, middleware...)
 
@@ -191,6 +324,19 @@ func (props *Props) WithSpawnMiddleware(middleware ...SpawnMiddleware) *Props {
 	return props
 }
 
+// Clone returns a copy of the props that can be configured further without affecting the original
+func (props *Props) Clone() *Props {
+	clone := *props
+	clone.receiverMiddleware = append([]ReceiverMiddleware(nil), props.receiverMiddleware...)
+	clone.senderMiddleware = append([]SenderMiddleware(nil), props.senderMiddleware...)
+	clone.spawnMiddleware = append([]SpawnMiddleware(nil), props.spawnMiddleware...)
+	clone.contextDecorator = append([]ContextDecorator(nil), props.contextDecorator...)
+	if props.standby != nil {
+		clone.standby = newStandbyPool(props.standby.size)
+	}
+	return &clone
+}
+
 // PropsFromProducer creates a props with the given actor producer assigned
 func PropsFromProducer(producer Producer) *Props {
 	return &Props{
@@ -0,0 +1,97 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// Quota is a limit on the spawns
+type Quota string
+
+const (
+	// QuotaChildren is the number of the children of an actor, see Props.WithMaxChildren
+	QuotaChildren Quota = "children"
+	// QuotaActors is the number of the live actors of a system, see Config.MaxActors
+	QuotaActors Quota = "actors"
+)
+
+// ErrTooManyChildren is returned when spawning a child of an actor which has the maximum of children of its props
+var ErrTooManyChildren = errors.New("actor: the parent has its maximum of children")
+
+// QuotaExceededError is returned when spawning an actor while the system has its Config.MaxActors live actors
+type QuotaExceededError struct {
+	Quota Quota
+	Limit int
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("actor: quota of %d %s exceeded", e.Limit, e.Quota)
+}
+
+// IsQuotaExceeded tells if err refused a spawn over a quota
+func IsQuotaExceeded(err error) bool {
+	var quota *QuotaExceededError
+	return errors.Is(err, ErrTooManyChildren) || errors.As(err, &quota)
+}
+
+// QuotaExceededEvent is published on the system event stream when a spawn is refused over a quota. Parent is the
+// actor refused a child, it is nil when the system refused the actor
+type QuotaExceededEvent struct {
+	Quota  Quota
+	Limit  int
+	Parent *PID
+	Name   string
+}
+
+// QuotaStats are the usage of the quotas of a system
+type QuotaStats struct {
+	LiveActors int
+	MaxActors  int
+	// RefusedChildren and RefusedActors are the numbers of the spawns refused over each quota
+	RefusedChildren uint64
+	RefusedActors   uint64
+}
+
+type quotaCounters struct {
+	refusedChildren uint64
+	refusedActors   uint64
+}
+
+// Quotas returns the usage of the quotas of the system
+func (as *ActorSystem) Quotas() QuotaStats {
+	return QuotaStats{
+		LiveActors:      as.ProcessRegistry.LiveActors(),
+		MaxActors:       as.Config.MaxActors,
+		RefusedChildren: atomic.LoadUint64(&as.quotas.refusedChildren),
+		RefusedActors:   atomic.LoadUint64(&as.quotas.refusedActors),
+	}
+}
+
+// quotaExceeded publishes the refusal of the spawn of name over quota, and returns its error
+func (as *ActorSystem) quotaExceeded(quota Quota, limit int, parent *PID, name string) error {
+	var err error
+	if quota == QuotaChildren {
+		atomic.AddUint64(&as.quotas.refusedChildren, 1)
+		err = ErrTooManyChildren
+	} else {
+		atomic.AddUint64(&as.quotas.refusedActors, 1)
+		err = &QuotaExceededError{Quota: quota, Limit: limit}
+	}
+	plog.Info("spawn refused over quota", log.String("quota", string(quota)), log.Int("limit", limit), log.String("name", name))
+	if as.SystemEventStream != nil {
+		as.SystemEventStream.Publish(&QuotaExceededEvent{Quota: quota, Limit: limit, Parent: parent, Name: name})
+	}
+	return err
+}
+
+// checkMaxChildren refuses the spawn of name while the actor has the maximum of children of its props
+func (ctx *actorContext) checkMaxChildren(name string) error {
+	limit := ctx.props.maxChildren
+	if limit <= 0 || ctx.extras == nil || ctx.extras.children.Len() < limit {
+		return nil
+	}
+	return ctx.actorSystem.quotaExceeded(QuotaChildren, limit, ctx.self, name)
+}
@@ -0,0 +1,123 @@
+package actor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// quotaEvents returns a system configured with opts and the QuotaExceededEvent it publishes
+func quotaEvents(opts ...ConfigOption) (*ActorSystem, chan *QuotaExceededEvent) {
+	events := eventstream.NewEventStream()
+	system := NewActorSystemWithConfig(NewConfig(opts...), WithSystemEventStream(events))
+	exceeded := make(chan *QuotaExceededEvent, 100)
+	events.Subscribe(func(evt interface{}) {
+		if evt, ok := evt.(*QuotaExceededEvent); ok {
+			exceeded <- evt
+		}
+	})
+	return system, exceeded
+}
+
+// spawnResult is the answer of a checkedSpawner
+type spawnResult struct {
+	pid *PID
+	err error
+}
+
+// checkedSpawner spawns a child for every Props it receives and answers with the result
+func checkedSpawner(ctx Context) {
+	if props, ok := ctx.Message().(*Props); ok {
+		pid, err := ctx.SpawnChecked(props)
+		ctx.Respond(&spawnResult{pid: pid, err: err})
+	}
+}
+
+func TestMaxChildren(t *testing.T) {
+	system, exceeded := quotaEvents()
+	parent := system.Root.Spawn(PropsFromFunc(checkedSpawner).WithMaxChildren(2))
+	defer system.Root.Stop(parent)
+	spawn := func() *spawnResult {
+		res, err := system.Root.RequestFuture(parent, PropsFromFunc(func(ctx Context) {}), testTimeout).Result()
+		require.NoError(t, err)
+		return res.(*spawnResult)
+	}
+
+	first, second := spawn(), spawn()
+	require.NoError(t, first.err)
+	require.NoError(t, second.err)
+	refused := spawn()
+	assert.Equal(t, ErrTooManyChildren, refused.err, "the parent does not panic")
+	assert.Nil(t, refused.pid)
+	evt := <-exceeded
+	assert.Equal(t, QuotaChildren, evt.Quota)
+	assert.Equal(t, 2, evt.Limit)
+	assert.Equal(t, parent, evt.Parent)
+	info, err := system.Inspect(parent, testTimeout)
+	require.NoError(t, err)
+	assert.Len(t, info.Children, 2)
+	assert.Equal(t, 2, info.MaxChildren)
+	assert.Equal(t, uint64(1), system.Quotas().RefusedChildren)
+
+	// the terminated children release their place
+	require.NoError(t, system.Root.StopFuture(first.pid).Wait())
+	require.Eventually(t, func() bool {
+		info, err := system.Inspect(parent, testTimeout)
+		return err == nil && len(info.Children) == 1
+	}, testTimeout, testTimeout/100)
+	assert.NoError(t, spawn().err)
+	assert.Equal(t, ErrTooManyChildren, spawn().err)
+}
+
+func TestMaxActors_ConcurrentSpawns(t *testing.T) {
+	system, exceeded := quotaEvents(WithMaxActors(20))
+	props := PropsFromFunc(func(ctx Context) {})
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		spawned []*PID
+		errs    []error
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pid, err := system.Root.SpawnChecked(props)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				spawned = append(spawned, pid)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, spawned, 20)
+	require.Len(t, errs, 30)
+	var quota *QuotaExceededError
+	require.True(t, errors.As(errs[0], &quota))
+	assert.Equal(t, &QuotaExceededError{Quota: QuotaActors, Limit: 20}, quota)
+	assert.True(t, IsQuotaExceeded(errs[0]))
+	assert.Len(t, exceeded, 30)
+	evt := <-exceeded
+	assert.Equal(t, QuotaActors, evt.Quota)
+	assert.Nil(t, evt.Parent)
+	assert.Equal(t, QuotaStats{LiveActors: 20, MaxActors: 20, RefusedActors: 30}, system.Quotas())
+	assert.Panics(t, func() { system.Root.Spawn(props) })
+
+	// the stopped actors release their place, the names taken are not counted
+	require.NoError(t, system.Root.StopFuture(spawned[0]).Wait())
+	assert.Equal(t, 19, system.Quotas().LiveActors)
+	_, err := system.Root.SpawnNamed(props, spawned[1].Id)
+	assert.Equal(t, ErrNameExists, err)
+	_, err = system.Root.SpawnChecked(props)
+	assert.NoError(t, err)
+	assert.Equal(t, 20, system.Quotas().LiveActors)
+}
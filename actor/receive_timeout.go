@@ -0,0 +1,57 @@
+package actor
+
+import (
+	"reflect"
+	"sync"
+)
+
+// receiveTimeoutExemption tells the messages which do not reset the receive timeout of an actor, besides the
+// NotInfluenceReceiveTimeout ones
+type receiveTimeoutExemption struct {
+	types     []reflect.Type
+	exempt    func(message interface{}) bool
+	decisions sync.Map // the decision of types for each type of message, by reflect.Type
+}
+
+// with returns a copy of the exemption, the props cloned keep theirs
+func (e *receiveTimeoutExemption) with(types []reflect.Type, exempt func(message interface{}) bool) *receiveTimeoutExemption {
+	res := &receiveTimeoutExemption{types: append([]reflect.Type(nil), types...), exempt: exempt}
+	if e != nil {
+		res.types = append(append([]reflect.Type(nil), e.types...), types...)
+		if res.exempt == nil {
+			res.exempt = e.exempt
+		}
+	}
+	return res
+}
+
+func (e *receiveTimeoutExemption) exempts(message interface{}) bool {
+	if e.exempt != nil && e.exempt(message) {
+		return true
+	}
+	if len(e.types) == 0 {
+		return false
+	}
+	typ := reflect.TypeOf(message)
+	if decision, ok := e.decisions.Load(typ); ok {
+		return decision.(bool)
+	}
+	decision := false
+	for _, exempt := range e.types {
+		if typ == exempt || (exempt.Kind() == reflect.Interface && typ != nil && typ.Implements(exempt)) {
+			decision = true
+			break
+		}
+	}
+	e.decisions.Store(typ, decision)
+	return decision
+}
+
+// influencesReceiveTimeout tells if message resets the receive timeout of the actor
+func (ctx *actorContext) influencesReceiveTimeout(message interface{}) bool {
+	if _, ok := message.(NotInfluenceReceiveTimeout); ok {
+		return false
+	}
+	exemption := ctx.props.receiveTimeoutExemption
+	return exemption == nil || !exemption.exempts(UnwrapEnvelopeMessage(message))
+}
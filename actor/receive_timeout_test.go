@@ -0,0 +1,37 @@
+package actor
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type heartbeat struct{}
+
+func (heartbeat) String() string { return "heartbeat" }
+
+func TestReceiveTimeoutExemption(t *testing.T) {
+	props := PropsFromFunc(func(ctx Context) {}).
+		WithReceiveTimeoutExemptTypes(reflect.TypeOf((*fmt.Stringer)(nil)).Elem()).
+		WithReceiveTimeoutExemption(func(message interface{}) bool {
+			return message == "ping"
+		})
+	exemption := props.receiveTimeoutExemption
+
+	assert.True(t, exemption.exempts(heartbeat{}), "the interface types exempt their implementations")
+	assert.True(t, exemption.exempts(&PID{}))
+	assert.True(t, exemption.exempts("ping"))
+	assert.False(t, exemption.exempts("pong"))
+	assert.False(t, exemption.exempts(nil))
+	decision, cached := exemption.decisions.Load(reflect.TypeOf(heartbeat{}))
+	assert.True(t, cached, "the decision is cached by type")
+	assert.Equal(t, true, decision)
+
+	// the clones are configured on their own
+	clone := props.Clone().WithReceiveTimeoutExemptTypes(reflect.TypeOf(0))
+	assert.True(t, clone.receiveTimeoutExemption.exempts(1))
+	assert.True(t, clone.receiveTimeoutExemption.exempts("ping"))
+	assert.False(t, exemption.exempts(1))
+}
@@ -0,0 +1,100 @@
+package actor
+
+import (
+	"strconv"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// RedeliveriesHeader is the message header counting the redeliveries of a message that failed its actor,
+// see RestartWithRedeliveryDirective
+const RedeliveriesHeader = "actor-redeliveries"
+
+// DefaultMaxRedeliveries is the number of redeliveries of a failing message when the props do not set it,
+// see Props.WithMaxRedeliveries
+const DefaultMaxRedeliveries = 3
+
+// PoisonMessageEvent is published on the system event stream when a message failed its actor after being
+// redelivered as many times as allowed. The message is then a dead letter with the DeadLetterPoisonMessage reason
+type PoisonMessageEvent struct {
+	PID          *PID
+	Message      interface{}
+	Sender       *PID
+	Reason       interface{} // the reason of the last failure
+	Redeliveries int
+}
+
+// Redeliveries returns how many times the message being processed was redelivered after failing the actor
+func Redeliveries(ctx Context) int {
+	n, _ := strconv.Atoi(ctx.MessageHeader().Get(RedeliveriesHeader))
+	return n
+}
+
+// restartWithRedelivery restarts a failed actor, which redelivers the message it failed with to itself
+type restartWithRedelivery struct {
+	restart *Restart
+}
+
+func (*restartWithRedelivery) SystemMessage() {}
+
+var restartWithRedeliveryMessage interface{} = &restartWithRedelivery{restart: &Restart{}}
+
+// redeliverable tells if the message an actor failed with can be processed again
+func redeliverable(message interface{}) bool {
+	switch message.(type) {
+	case nil, SystemMessage, AutoReceiveMessage:
+		return false
+	}
+	return true
+}
+
+func (ctx *actorContext) handleRestartWithRedelivery(msg *restartWithRedelivery) {
+	if ctx.extras != nil && ctx.extras.failed && ctx.extras.failedMessage != nil {
+		ctx.extras.redelivery = ctx.redeliveryOf(ctx.extras.failedMessage, ctx.extras.failure)
+	}
+	ctx.handleRestart(msg.restart)
+}
+
+// redeliveryOf returns the envelope redelivering message, or nil when it was redelivered as many times as allowed
+func (ctx *actorContext) redeliveryOf(message interface{}, reason interface{}) *MessageEnvelope {
+	header, msg, sender := UnwrapEnvelope(message)
+	redeliveries := 0
+	if header != nil {
+		redeliveries, _ = strconv.Atoi(header.Get(RedeliveriesHeader))
+	}
+
+	max := ctx.props.maxRedeliveries
+	if max <= 0 {
+		max = DefaultMaxRedeliveries
+	}
+	if redeliveries >= max {
+		plog.Info("poison message dropped", log.Stringer("pid", ctx.self), log.TypeOf("type", msg), log.Int("redeliveries", redeliveries))
+		ctx.actorSystem.SystemEventStream.Publish(&PoisonMessageEvent{
+			PID:          ctx.self,
+			Message:      msg,
+			Sender:       sender,
+			Reason:       reason,
+			Redeliveries: redeliveries,
+		})
+		ctx.actorSystem.DeadLetter.SendUserMessageWithReason(ctx.self, message, DeadLetterPoisonMessage)
+		return nil
+	}
+
+	// the header of the failed envelope may be shared with other messages
+	envelope := &MessageEnvelope{Message: msg, Sender: sender}
+	if header != nil {
+		envelope.Header = header.ToMap()
+	}
+	envelope.SetHeader(RedeliveriesHeader, strconv.Itoa(redeliveries+1))
+	return envelope
+}
+
+// redeliver posts the message the previous incarnation failed with to the mailbox of the restarted actor
+func (ctx *actorContext) redeliver() {
+	if ctx.extras == nil || ctx.extras.redelivery == nil {
+		return
+	}
+	envelope := ctx.extras.redelivery
+	ctx.extras.redelivery = nil
+	ctx.self.sendUserMessage(ctx.actorSystem, envelope)
+}
@@ -0,0 +1,100 @@
+package actor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyMessage fails its actor the first failures times it is received
+type flakyMessage struct {
+	failures int
+}
+
+var redelivering = actor.NewOneForOneStrategy(10, time.Second, func(interface{}) actor.Directive {
+	return actor.RestartWithRedeliveryDirective
+})
+
+// spawnRedelivered spawns an actor supervised with RestartWithRedeliveryDirective, which responds to the messages it
+// processed with their redeliveries
+func spawnRedelivered(t *testing.T, system *actor.ActorSystem, props func(*actor.Props) *actor.Props) (*actor.PID, *int) {
+	attempts := 0
+	child := props(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *flakyMessage:
+			attempts++
+			if attempts <= msg.failures {
+				panic("flaky")
+			}
+			ctx.Respond(actor.Redeliveries(ctx))
+		case string:
+			ctx.Respond(actor.Redeliveries(ctx))
+		}
+	}))
+	children := make(chan *actor.PID, 1)
+	parent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			children <- ctx.Spawn(child)
+		}
+	}).WithSupervisor(redelivering))
+	t.Cleanup(func() { _ = system.Root.StopFuture(parent).Wait() })
+	return <-children, &attempts
+}
+
+func TestRestartWithRedelivery_RedeliversFailedMessage(t *testing.T) {
+	system := actor.NewActorSystem()
+	pid, attempts := spawnRedelivered(t, system, func(props *actor.Props) *actor.Props { return props })
+
+	res, err := system.Root.RequestFuture(pid, &flakyMessage{failures: 2}, time.Second).Result()
+	require.NoError(t, err, "the sender of the redelivered message gets the response")
+	assert.Equal(t, 2, res)
+	assert.Equal(t, 3, *attempts)
+
+	res, err = system.Root.RequestFuture(pid, "after", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, 0, res)
+}
+
+func TestRestartWithRedelivery_DeadLettersPoisonMessage(t *testing.T) {
+	events := eventstream.NewEventStream()
+	system := actor.NewActorSystem(actor.WithSystemEventStream(events))
+	poisoned := make(chan *actor.PoisonMessageEvent, 1)
+	deadLetters := make(chan *actor.DeadLetterEvent, 1)
+	events.Subscribe(func(evt interface{}) {
+		switch evt := evt.(type) {
+		case *actor.PoisonMessageEvent:
+			poisoned <- evt
+		case *actor.DeadLetterEvent:
+			deadLetters <- evt
+		}
+	})
+	pid, attempts := spawnRedelivered(t, system, func(props *actor.Props) *actor.Props {
+		return props.WithMaxRedeliveries(2)
+	})
+
+	poison := &flakyMessage{failures: 100}
+	system.Root.Send(pid, poison)
+	select {
+	case evt := <-poisoned:
+		assert.Equal(t, pid, evt.PID)
+		assert.Equal(t, poison, evt.Message)
+		assert.Equal(t, "flaky", evt.Reason)
+		assert.Equal(t, 2, evt.Redeliveries)
+	case <-time.After(time.Second):
+		t.Fatal("no PoisonMessageEvent")
+	}
+	deadLetter := <-deadLetters
+	assert.Equal(t, poison, deadLetter.Message)
+	assert.Equal(t, actor.DeadLetterPoisonMessage, deadLetter.Reason)
+	assert.Equal(t, "2", deadLetter.Header.Get(actor.RedeliveriesHeader))
+
+	// the actor restarted without the poison message
+	res, err := system.Root.RequestFuture(pid, "after", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, 0, res)
+	assert.Equal(t, 3, *attempts)
+}
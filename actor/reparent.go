@@ -0,0 +1,157 @@
+package actor
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrReparentCycle is returned when re-parenting an actor under itself or one of its descendants
+	ErrReparentCycle = errors.New("actor: the new parent is the actor or one of its descendants")
+	// ErrReparentStopping is returned when re-parenting an actor which stops, or whose parent stops or restarts
+	ErrReparentStopping = errors.New("actor: the actor or its parent is stopping")
+)
+
+// releaseChild asks the parent of child to give it up to newParent, it is answered once the child moved or refused
+type releaseChild struct {
+	child     *PID
+	newParent *PID
+	reply     chan error
+}
+
+func (*releaseChild) SystemMessage() {}
+
+// reparent moves the actor under newParent
+type reparent struct {
+	newParent *PID
+	reply     chan error
+}
+
+func (*reparent) SystemMessage() {}
+
+// adoptChild adds child to the children of the actor
+type adoptChild struct {
+	child *PID
+}
+
+func (*adoptChild) SystemMessage() {}
+
+// childReleased removes child from the children of its previous parent, once it moved
+type childReleased struct {
+	child *PID
+}
+
+func (*childReleased) SystemMessage() {}
+
+// Reparent moves the local actor child under the local actor newParent, along with its own children, without
+// restarting it nor losing the messages of its mailbox. The child moves between two of its messages: its failures
+// are escalated to its new parent from then on, and its new parent receives its Terminated when it stops. Its previous
+// parent gives it up between two of its own messages, it refuses to while it is stopping or restarting and stops the
+// child anyway. The watches of the actors are kept.
+// It returns once the child moved, or ErrTimeout when it did not within timeout, in which case it may still move
+func (as *ActorSystem) Reparent(child, newParent *PID, timeout time.Duration) error {
+	childProcess, ok := as.localActor(child)
+	if !ok {
+		return ErrNotLocalActor
+	}
+	if _, ok := as.localActor(newParent); !ok {
+		return ErrNotLocalActor
+	}
+	for ancestor := newParent; ancestor != nil; {
+		if ancestor.Equal(child) {
+			return ErrReparentCycle
+		}
+		process, ok := as.localActor(ancestor)
+		if !ok {
+			break
+		}
+		ancestor = process.parentPID()
+	}
+
+	reply := make(chan error, 1)
+	if parent := childProcess.parentPID(); parent != nil {
+		parent.sendSystemMessage(as, &releaseChild{child: child, newParent: newParent, reply: reply})
+	} else {
+		child.sendSystemMessage(as, &reparent{newParent: newParent, reply: reply})
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-reply:
+		return err
+	case <-timer.C:
+		return ErrTimeout
+	}
+}
+
+// localActor returns the process of the local actor pid, unless it is dead
+func (as *ActorSystem) localActor(pid *PID) (*ActorProcess, bool) {
+	if pid == nil {
+		return nil, false
+	}
+	process, ok := as.ProcessRegistry.GetLocal(pid.Id)
+	if !ok {
+		return nil, false
+	}
+	actorProcess, ok := process.(*ActorProcess)
+	if !ok || atomic.LoadInt32(&actorProcess.dead) == 1 {
+		return nil, false
+	}
+	return actorProcess, true
+}
+
+// handleReleaseChild gives up a child to its new parent, unless the actor is stopping or restarting its children.
+// The child stays among its children until it moved, a child refusing to move while it stops is still waited for
+func (ctx *actorContext) handleReleaseChild(msg *releaseChild) {
+	if atomic.LoadInt32(&ctx.state) >= stateRestarting {
+		msg.reply <- ErrReparentStopping
+		return
+	}
+	if ctx.extras == nil || !ctx.extras.children.Contains(msg.child) {
+		// it moved meanwhile
+		msg.reply <- ErrReparentStopping
+		return
+	}
+	msg.child.sendSystemMessage(ctx.actorSystem, &reparent{newParent: msg.newParent, reply: msg.reply})
+}
+
+// handleReparent moves the actor under its new parent, which adopts it before it receives anything else from it
+func (ctx *actorContext) handleReparent(msg *reparent) {
+	if atomic.LoadInt32(&ctx.state) >= stateStopping {
+		// its previous parent still receives its Terminated
+		msg.reply <- ErrReparentStopping
+		return
+	}
+	previous := ctx.parent
+	ctx.parent = msg.newParent
+	if process, ok := ctx.actorSystem.localActor(ctx.self); ok {
+		process.setParent(msg.newParent)
+	}
+	msg.newParent.sendSystemMessage(ctx.actorSystem, &adoptChild{child: ctx.self})
+	if previous != nil {
+		previous.sendSystemMessage(ctx.actorSystem, &childReleased{child: ctx.self})
+	}
+	msg.reply <- nil
+}
+
+// handleChildReleased removes a child which moved under another parent, the actor may then complete its stop or
+// restart waiting for its children
+func (ctx *actorContext) handleChildReleased(msg *childReleased) {
+	if ctx.extras == nil || !ctx.extras.children.Contains(msg.child) {
+		return
+	}
+	ctx.extras.removeChild(msg.child)
+	if atomic.LoadInt32(&ctx.state) >= stateRestarting {
+		ctx.tryRestartOrTerminate()
+	}
+}
+
+// handleAdoptChild adds a child moved under the actor, it is stopped along with the others when the actor stops
+// or restarts meanwhile
+func (ctx *actorContext) handleAdoptChild(msg *adoptChild) {
+	ctx.ensureExtras().addChild(msg.child)
+	if atomic.LoadInt32(&ctx.state) >= stateRestarting {
+		msg.child.stop(ctx.actorSystem, StopReason_ParentStopped)
+	}
+}
@@ -0,0 +1,162 @@
+package actor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// foster is a parent actor spawning the children it is asked for, and reporting their termination
+type foster struct {
+	terminated chan *PID
+}
+
+func (f *foster) Receive(ctx Context) {
+	switch msg := ctx.Message().(type) {
+	case *Props:
+		ctx.Respond(ctx.Spawn(msg))
+	case *Terminated:
+		f.terminated <- msg.Who
+	}
+}
+
+func spawnFoster(supervisor SupervisorStrategy) (*PID, *foster) {
+	f := &foster{terminated: make(chan *PID, 10)}
+	props := PropsFromProducer(func() Actor { return f })
+	if supervisor != nil {
+		props = props.WithSupervisor(supervisor)
+	}
+	return rootContext.Spawn(props), f
+}
+
+func spawnChildOf(t *testing.T, parent *PID, props *Props) *PID {
+	res, err := rootContext.RequestFuture(parent, props, testTimeout).Result()
+	require.NoError(t, err)
+	return res.(*PID)
+}
+
+func childrenOf(t *testing.T, pid *PID) []*PID {
+	info, err := system.Inspect(pid, testTimeout)
+	require.NoError(t, err)
+	return info.Children
+}
+
+func TestReparent_FailuresGoToTheNewParent(t *testing.T) {
+	var started, received int32
+	childProps := PropsFromFunc(func(ctx Context) {
+		switch ctx.Message() {
+		case startedMessage:
+			atomic.AddInt32(&started, 1)
+		case "slow":
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&received, 1)
+		case "fail":
+			panic("failed after moving")
+		}
+	})
+	oldParent, _ := spawnFoster(nil)
+	defer rootContext.Stop(oldParent)
+	failures := make(chan interface{}, 1)
+	newParent, adoptive := spawnFoster(NewOneForOneStrategy(10, 0, func(reason interface{}) Directive {
+		failures <- reason
+		return StopDirective
+	}))
+	defer rootContext.Stop(newParent)
+	child := spawnChildOf(t, oldParent, childProps)
+
+	// the messages queued meanwhile are processed
+	for i := 0; i < 5; i++ {
+		rootContext.Send(child, "slow")
+	}
+	require.NoError(t, system.Reparent(child, newParent, testTimeout))
+	assert.Empty(t, childrenOf(t, oldParent))
+	assert.Equal(t, []*PID{child}, childrenOf(t, newParent))
+	rootContext.Send(child, "fail")
+
+	select {
+	case reason := <-failures:
+		assert.Equal(t, "failed after moving", reason)
+	case <-time.After(testTimeout):
+		t.Fatal("the new parent did not supervise the child")
+	}
+	select {
+	case who := <-adoptive.terminated:
+		assert.Equal(t, child, who)
+	case <-time.After(testTimeout):
+		t.Fatal("the new parent was not notified of the termination")
+	}
+	assert.Equal(t, int32(5), atomic.LoadInt32(&received))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&started), "the child is not restarted")
+	assert.Empty(t, childrenOf(t, newParent))
+}
+
+func TestReparent_ChildOutlivesItsPreviousParent(t *testing.T) {
+	oldParent, previous := spawnFoster(nil)
+	newParent, _ := spawnFoster(nil)
+	defer rootContext.Stop(newParent)
+	child := spawnChildOf(t, oldParent, PropsFromFunc(func(ctx Context) {
+		if ctx.Message() == "ping" {
+			ctx.Respond("pong")
+		}
+	}))
+
+	require.NoError(t, system.Reparent(child, newParent, testTimeout))
+	require.NoError(t, rootContext.StopFuture(oldParent).Wait())
+	res, err := rootContext.RequestFuture(child, "ping", testTimeout).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "pong", res)
+	assert.Len(t, previous.terminated, 0)
+
+	// the top level actors move too
+	top := rootContext.Spawn(PropsFromFunc(func(ctx Context) {}))
+	require.NoError(t, system.Reparent(top, child, testTimeout))
+	assert.NotContains(t, system.TopLevelActors(), top)
+	require.NoError(t, rootContext.StopFuture(newParent).Wait())
+	_, err = system.Inspect(top, testTimeout)
+	assert.Equal(t, ErrNotLocalActor, err, "the moved actors stop with their new ancestors")
+}
+
+func TestReparent_Refused(t *testing.T) {
+	parent, _ := spawnFoster(nil)
+	defer rootContext.Stop(parent)
+	child := spawnChildOf(t, parent, PropsFromProducer(func() Actor { return &foster{terminated: make(chan *PID, 10)} }))
+	grandchild := spawnChildOf(t, child, PropsFromFunc(func(ctx Context) {}))
+
+	assert.Equal(t, ErrReparentCycle, system.Reparent(child, child, testTimeout))
+	assert.Equal(t, ErrReparentCycle, system.Reparent(parent, grandchild, testTimeout))
+	assert.Equal(t, ErrNotLocalActor, system.Reparent(child, NewPID("remote:8080", "parent"), testTimeout))
+	assert.Equal(t, ErrNotLocalActor, system.Reparent(NewPID(system.Address(), "missing"), parent, testTimeout))
+}
+
+func TestReparent_RefusedWhileStopping(t *testing.T) {
+	oldParent, previous := spawnFoster(nil)
+	defer rootContext.Stop(oldParent)
+	newParent, _ := spawnFoster(nil)
+	defer rootContext.Stop(newParent)
+	child := spawnChildOf(t, oldParent, PropsFromProducer(func() Actor { return &foster{terminated: make(chan *PID, 10)} }))
+	// the grandchild holds the child stopping until released
+	release := make(chan struct{})
+	spawnChildOf(t, child, PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Stopping); ok {
+			<-release
+		}
+	}))
+
+	// the child stops after Reparent checked it, before it receives the reparent
+	rootContext.Stop(child)
+	reply := make(chan error, 1)
+	oldParent.sendSystemMessage(system, &releaseChild{child: child, newParent: newParent, reply: reply})
+	assert.Equal(t, ErrReparentStopping, <-reply)
+	assert.Equal(t, []*PID{child}, childrenOf(t, oldParent), "the previous parent still waits for the child")
+	close(release)
+	select {
+	case who := <-previous.terminated:
+		assert.Equal(t, child.Id, who.Id)
+	case <-time.After(testTimeout):
+		t.Fatal("the previous parent did not receive the Terminated of the child")
+	}
+	assert.Empty(t, childrenOf(t, newParent))
+}
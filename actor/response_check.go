@@ -0,0 +1,107 @@
+package actor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// ResponseCheck tells whether the actors are checked to respond to the requests awaited by a future, as a development
+// aid finding the requests which time out as the actor never responds. It is off by default
+type ResponseCheck int
+
+const (
+	// ResponseCheckDefault checks the responses of the actor as configured for the actor system, see
+	// WithResponseCheck. It is the default of the props
+	ResponseCheckDefault ResponseCheck = iota
+	// ResponseCheckOff does not check the responses
+	ResponseCheckOff
+	// ResponseCheckWarn logs the requests the actor processed without responding, and publishes a NoResponseEvent
+	ResponseCheckWarn
+	// ResponseCheckFailFast is ResponseCheckWarn, and fails the requests with ErrNoResponse, so that their futures
+	// do not wait until they time out
+	ResponseCheckFailFast
+)
+
+// ErrNoResponse fails the requests an actor processed without responding, see ResponseCheckFailFast
+var ErrNoResponse = &ActorError{Message: "the actor processed the request without responding", Code: "NoResponse"}
+
+// NoResponseEvent is published on the system event stream when an actor processed a request awaited by a future
+// without responding, forwarding it or deferring its response. It is only published by the actors checked
+// with ResponseCheckWarn or ResponseCheckFailFast
+type NoResponseEvent struct {
+	PID         *PID
+	Sender      *PID
+	ActorType   string
+	MessageType string
+}
+
+// futurePrefixes are the prefixes of the ids of the processes awaiting responses
+var futurePrefixes = []string{"future$", "aggregator$"}
+
+// IsFuture tells if pid is a future, or another process awaiting responses rather than an actor
+func IsFuture(pid *PID) bool {
+	if pid == nil {
+		return false
+	}
+	for _, prefix := range futurePrefixes {
+		if strings.HasPrefix(pid.Id, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctx *actorContext) responseCheck() ResponseCheck {
+	if ctx.props.responseCheck != ResponseCheckDefault {
+		return ctx.props.responseCheck
+	}
+	return ctx.actorSystem.Config.ResponseCheck
+}
+
+// awaitResponse tells if the response to message is checked, and marks it as awaiting one
+func (ctx *actorContext) awaitResponse(message interface{}) bool {
+	check := ctx.responseCheck()
+	if check != ResponseCheckWarn && check != ResponseCheckFailFast {
+		return false
+	}
+	if _, _, sender := UnwrapEnvelope(message); !IsFuture(sender) {
+		return false
+	}
+	ctx.ensureExtras().awaitingResponse = true
+	return true
+}
+
+// responded marks the current message as answered, or as answered later
+func (ctx *actorContext) responded() {
+	if ctx.extras != nil {
+		ctx.extras.awaitingResponse = false
+	}
+}
+
+// checkResponse reports the request message if the actor processed it without responding
+func (ctx *actorContext) checkResponse(message interface{}) {
+	if !ctx.extras.awaitingResponse {
+		return
+	}
+	ctx.extras.awaitingResponse = false
+
+	_, msg, sender := UnwrapEnvelope(message)
+	event := &NoResponseEvent{
+		PID:         ctx.self,
+		Sender:      sender,
+		ActorType:   fmt.Sprintf("%T", ctx.actor),
+		MessageType: fmt.Sprintf("%T", msg),
+	}
+	plog.Info("actor processed a request without responding", log.Stringer("pid", ctx.self),
+		log.String("actor", event.ActorType), log.String("message", event.MessageType))
+	ctx.actorSystem.SystemEventStream.Publish(event)
+
+	if ctx.responseCheck() == ResponseCheckFailFast {
+		current := ctx.messageOrEnvelope
+		ctx.messageOrEnvelope = message
+		ctx.RespondError(ErrNoResponse)
+		ctx.messageOrEnvelope = current
+	}
+}
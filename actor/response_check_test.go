@@ -0,0 +1,101 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type silentActor struct{}
+
+func (silentActor) Receive(ctx Context) {
+	switch ctx.Message() {
+	case "respond":
+		ctx.Respond("response")
+	case "await":
+		ctx.AwaitFuture(ctx.RequestFuture(ctx.Self(), "respond", testTimeout), func(res interface{}, err error) {
+			ctx.Respond(res)
+		})
+	}
+}
+
+// checkedSystem returns a system configured with opts, and the NoResponseEvents it publishes
+func checkedSystem(opts ...ConfigOption) (*ActorSystem, chan *NoResponseEvent) {
+	events := eventstream.NewEventStream()
+	system := NewActorSystemWithConfig(NewConfig(opts...), WithSystemEventStream(events))
+	noResponses := make(chan *NoResponseEvent, 10)
+	events.Subscribe(func(evt interface{}) {
+		if evt, ok := evt.(*NoResponseEvent); ok {
+			noResponses <- evt
+		}
+	})
+	return system, noResponses
+}
+
+func TestResponseCheck_Warn(t *testing.T) {
+	system, noResponses := checkedSystem()
+	pid := system.Root.Spawn(PropsFromProducer(func() Actor { return silentActor{} }).WithResponseCheck(ResponseCheckWarn))
+
+	_, err := system.Root.RequestFuture(pid, "ignored", 20*time.Millisecond).Result()
+	assert.Equal(t, ErrTimeout, err, "the request is not answered")
+	require.Len(t, noResponses, 1)
+	evt := <-noResponses
+	assert.Equal(t, pid, evt.PID)
+	assert.True(t, IsFuture(evt.Sender))
+	assert.Equal(t, "actor.silentActor", evt.ActorType)
+	assert.Equal(t, "string", evt.MessageType)
+}
+
+func TestResponseCheck_FailFast(t *testing.T) {
+	system, noResponses := checkedSystem(WithResponseCheck(ResponseCheckFailFast))
+	pid := system.Root.Spawn(PropsFromProducer(func() Actor { return silentActor{} }))
+
+	_, err := system.Root.RequestFuture(pid, "ignored", testTimeout).Result()
+	var actorErr *ActorError
+	require.True(t, errors.As(err, &actorErr), "the request fails before it times out")
+	assert.Equal(t, ErrNoResponse.Code, actorErr.Code)
+	assert.Len(t, noResponses, 1)
+}
+
+func TestResponseCheck_AnsweredRequests(t *testing.T) {
+	system, noResponses := checkedSystem(WithResponseCheck(ResponseCheckFailFast))
+	pid := system.Root.Spawn(PropsFromProducer(func() Actor { return silentActor{} }))
+	forwarder := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			ctx.Forward(pid)
+		}
+	}))
+
+	for _, target := range []*PID{pid, forwarder} {
+		for _, message := range []string{"respond", "await"} {
+			res, err := system.Root.RequestFuture(target, message, testTimeout).Result()
+			require.NoError(t, err, message)
+			assert.Equal(t, "response", res)
+		}
+	}
+	// the messages not sent by a future are not checked
+	system.Root.Send(pid, "ignored")
+	_, _ = system.Root.RequestFuture(pid, "respond", testTimeout).Result()
+	assert.Len(t, noResponses, 0)
+}
+
+func TestResponseCheck_PropsOverrideSystem(t *testing.T) {
+	system, noResponses := checkedSystem(WithResponseCheck(ResponseCheckWarn))
+	pid := system.Root.Spawn(PropsFromProducer(func() Actor { return silentActor{} }).WithResponseCheck(ResponseCheckOff))
+
+	_, err := system.Root.RequestFuture(pid, "ignored", 20*time.Millisecond).Result()
+	assert.Equal(t, ErrTimeout, err)
+	assert.Len(t, noResponses, 0)
+}
+
+func TestIsFuture(t *testing.T) {
+	future := NewFuture(system, testTimeout)
+	assert.True(t, IsFuture(future.PID()))
+	assert.True(t, IsFuture(NewPID("remote:8080", "future$abc")))
+	assert.False(t, IsFuture(rootContext.Spawn(PropsFromProducer(func() Actor { return silentActor{} }))))
+	assert.False(t, IsFuture(nil))
+}
@@ -1,6 +1,10 @@
 package actor
 
-import "time"
+import (
+	"context"
+	"strconv"
+	"time"
+)
 
 type RootContext struct {
 	actorSystem      *ActorSystem
@@ -8,6 +12,7 @@ type RootContext struct {
 	spawnMiddleware  SpawnFunc
 	headers          messageHeader
 	guardianStrategy SupervisorStrategy
+	shutdownPhase    *int
 }
 
 func NewRootContext(actorSystem *ActorSystem, header map[string]string, middleware ...SenderMiddleware) *RootContext {
@@ -99,26 +104,80 @@ func (rc *RootContext) Request(pid *PID, message interface{}) {
 }
 
 func (rc *RootContext) RequestWithCustomSender(pid *PID, message interface{}, sender *PID) {
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  sender,
-	}
-	rc.sendUserMessage(pid, env)
+	rc.sendUserMessage(pid, rc.actorSystem.newEnvelope(message, sender))
 }
 
 // RequestFuture sends a message to a given PID and returns a Future
 func (rc *RootContext) RequestFuture(pid *PID, message interface{}, timeout time.Duration) *Future {
 	future := NewFuture(rc.actorSystem, timeout)
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  future.PID(),
+	if rc.actorSystem.Diagnostics.Enabled() {
+		rc.actorSystem.Diagnostics.record(nil, pid, message, future)
+	}
+	env := rc.actorSystem.newEnvelope(message, future.PID())
+	rc.sendUserMessage(pid, env)
+	return future
+}
+
+// RequestFutureWithContext sends a message to a given PID and returns a Future failing with the error of ctx once
+// ctx is done. The message expires with the deadline of ctx, if any, and may be an envelope carrying headers
+func (rc *RootContext) RequestFutureWithContext(ctx context.Context, pid *PID, message interface{}) *Future {
+	timeout := time.Duration(-1)
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		if timeout = time.Until(deadline); timeout < 0 {
+			timeout = 0
+		}
+	}
+	future := NewFuture(rc.actorSystem, timeout)
+	if rc.actorSystem.Diagnostics.Enabled() {
+		rc.actorSystem.Diagnostics.record(nil, pid, message, future)
+	}
+	var env *MessageEnvelope
+	if e, ok := message.(*MessageEnvelope); ok {
+		env = e.Copy()
+		env.Sender = future.PID()
+	} else {
+		env = rc.actorSystem.newEnvelope(message, future.PID())
+	}
+	if hasDeadline {
+		env.SetHeader(ExpiresAtHeader, strconv.FormatInt(deadline.UnixNano(), 10))
+	}
+	if done := ctx.Done(); done != nil {
+		completed := make(chan struct{})
+		future.Observe(func(interface{}, error) { close(completed) })
+		go func() {
+			select {
+			case <-done:
+				future.Fail(ctx.Err())
+			case <-completed:
+			}
+		}()
 	}
 	rc.sendUserMessage(pid, env)
 	return future
 }
 
+// RequestWithCorrelation sends a message to a given PID with a new correlation id, and returns a Future
+// completing with the response carrying it back
+func (rc *RootContext) RequestWithCorrelation(pid *PID, message interface{}, timeout time.Duration) *Future {
+	future := newCorrelatedFuture(rc.actorSystem, timeout)
+	if rc.actorSystem.Diagnostics.Enabled() {
+		rc.actorSystem.Diagnostics.record(nil, pid, message, future)
+	}
+	rc.sendUserMessage(pid, correlatedEnvelope(message, future.correlationID, future.PID()))
+	return future
+}
+
+// RequestAggregate sends a message to each of the given PIDs with the same correlation id, and returns
+// an AggregatorFuture collecting count responses carrying it back
+func (rc *RootContext) RequestAggregate(pids []*PID, message interface{}, count int, timeout time.Duration) *AggregatorFuture {
+	future := NewAggregatorFuture(rc.actorSystem, count, timeout)
+	for _, pid := range pids {
+		rc.sendUserMessage(pid, correlatedEnvelope(message, future.correlationID, future.PID()))
+	}
+	return future
+}
+
 func (rc *RootContext) sendUserMessage(pid *PID, message interface{}) {
 	if rc.senderMiddleware != nil {
 		// Request based middleware
@@ -142,6 +201,11 @@ func (rc *RootContext) Spawn(props *Props) *PID {
 	return pid
 }
 
+// SpawnChecked starts a new actor based on props and named with a unique id, it returns the error Spawn panics with
+func (rc *RootContext) SpawnChecked(props *Props) (*PID, error) {
+	return rc.SpawnNamed(props, rc.actorSystem.ProcessRegistry.NextId())
+}
+
 // SpawnPrefix starts a new actor based on props and named using a prefix followed by a unique id
 func (rc *RootContext) SpawnPrefix(props *Props, prefix string) *PID {
 	pid, err := rc.SpawnNamed(props, prefix+rc.actorSystem.ProcessRegistry.NextId())
@@ -161,10 +225,17 @@ func (rc *RootContext) SpawnNamed(props *Props, name string) (*PID, error) {
 	if props.guardianStrategy != nil {
 		rootContext = rc.Copy().WithGuardian(props.guardianStrategy)
 	}
+	var pid *PID
+	var err error
 	if rootContext.spawnMiddleware != nil {
-		return rc.spawnMiddleware(rc.actorSystem, name, props, rootContext)
+		pid, err = rc.spawnMiddleware(rc.actorSystem, name, props, rootContext)
+	} else {
+		pid, err = props.spawn(rc.actorSystem, name, rootContext)
+	}
+	if err == nil && rc.shutdownPhase != nil {
+		rc.actorSystem.setShutdownPhase(pid, *rc.shutdownPhase)
 	}
-	return props.spawn(rc.actorSystem, name, rootContext)
+	return pid, err
 }
 
 //
@@ -0,0 +1,120 @@
+package actor
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+const (
+	// DefaultShutdownPhase is the phase of the top level actors spawned without one, it is stopped last
+	DefaultShutdownPhase = math.MaxInt32
+	// DefaultShutdownPhaseTimeout is the default Config.ShutdownPhaseTimeout
+	DefaultShutdownPhaseTimeout = 10 * time.Second
+)
+
+// ShutdownReport tells how the phases of a shutdown went, in the order they were stopped
+type ShutdownReport struct {
+	Phases []*ShutdownPhaseReport
+}
+
+// ShutdownPhaseReport tells how the actors of a phase stopped. Stragglers are the actors which did not stop within
+// the timeout of the phase, they are still stopping
+type ShutdownPhaseReport struct {
+	Phase      int
+	Stopped    []*PID
+	Stragglers []*PID
+	Elapsed    time.Duration
+}
+
+// Stragglers returns the actors which did not stop within the timeout of their phase
+func (r *ShutdownReport) Stragglers() []*PID {
+	var stragglers []*PID
+	for _, phase := range r.Phases {
+		stragglers = append(stragglers, phase.Stragglers...)
+	}
+	return stragglers
+}
+
+// WithShutdownPhase stops the actors spawned from the root context in phase of the shutdown of the system, the lower
+// phases first, see ActorSystem.Shutdown
+func (rc *RootContext) WithShutdownPhase(phase int) *RootContext {
+	rc.shutdownPhase = &phase
+	return rc
+}
+
+func (as *ActorSystem) setShutdownPhase(pid *PID, phase int) {
+	if process, ok := as.localActor(pid); ok {
+		process.phase.Store(phase)
+	}
+}
+
+// shutdownPhase returns the phase of the top level actor process
+func (ref *ActorProcess) shutdownPhase() int {
+	if phase, ok := ref.phase.Load().(int); ok {
+		return phase
+	}
+	return DefaultShutdownPhase
+}
+
+// Shutdown stops the top level actors phase by phase, the lower phases first and the actors spawned without a phase
+// last, see RootContext.WithShutdownPhase. The actors of a phase are stopped at once along with their children, the
+// next phase is stopped once they terminated or after Config.ShutdownPhaseTimeout, the actors which did not stop by
+// then are reported as stragglers. The dead letters and supervision events are no longer logged afterwards
+func (as *ActorSystem) Shutdown() *ShutdownReport {
+	phases := make(map[int][]*PID)
+	for _, pid := range as.TopLevelActors() {
+		if process, ok := as.localActor(pid); ok {
+			phase := process.shutdownPhase()
+			phases[phase] = append(phases[phase], pid)
+		}
+	}
+	order := make([]int, 0, len(phases))
+	for phase := range phases {
+		order = append(order, phase)
+	}
+	sort.Ints(order)
+
+	report := &ShutdownReport{}
+	for _, phase := range order {
+		report.Phases = append(report.Phases, as.shutdownPhase(phase, phases[phase]))
+	}
+	as.eventLog.stop()
+	return report
+}
+
+// shutdownPhase stops pids and waits for their termination
+func (as *ActorSystem) shutdownPhase(phase int, pids []*PID) *ShutdownPhaseReport {
+	type result struct {
+		pid *PID
+		err error
+	}
+	start := as.Config.Clock.Now()
+	results := make(chan result, len(pids))
+	for _, pid := range pids {
+		future := NewFuture(as, as.Config.ShutdownPhaseTimeout)
+		pid.sendSystemMessage(as, &Watch{Watcher: future.pid})
+		as.Root.Stop(pid)
+		go func(pid *PID) {
+			results <- result{pid: pid, err: future.Wait()}
+		}(pid)
+	}
+
+	report := &ShutdownPhaseReport{Phase: phase}
+	for range pids {
+		res := <-results
+		if res.err != nil {
+			report.Stragglers = append(report.Stragglers, res.pid)
+		} else {
+			report.Stopped = append(report.Stopped, res.pid)
+		}
+	}
+	report.Elapsed = as.Config.Clock.Now().Sub(start)
+	if len(report.Stragglers) > 0 {
+		plog.Error("actors did not stop within the shutdown phase timeout", log.Int("phase", phase),
+			log.Int("stragglers", len(report.Stragglers)), log.Duration("timeout", as.Config.ShutdownPhaseTimeout))
+	}
+	return report
+}
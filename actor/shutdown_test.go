@@ -0,0 +1,115 @@
+package actor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stopLog records the order the actors stopped in
+type stopLog struct {
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (l *stopLog) add(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stopped = append(l.stopped, name)
+}
+
+func (l *stopLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.stopped...)
+}
+
+// slowStopper takes delay to stop, it logs it once it is done
+func (l *stopLog) slowStopper(name string, delay time.Duration) *Props {
+	return PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Stopping); ok {
+			time.Sleep(delay)
+			l.add(name)
+		}
+	})
+}
+
+func pidIDs(pids ...*PID) []string {
+	res := make([]string, 0, len(pids))
+	for _, pid := range pids {
+		res = append(res, pid.Id)
+	}
+	return res
+}
+
+func TestShutdown_PhasesInOrder(t *testing.T) {
+	system := NewActorSystem()
+	log := &stopLog{}
+	writer := system.Root.Copy().WithShutdownPhase(3).Spawn(log.slowStopper("writer", 0))
+	infrastructure := system.Root.Spawn(log.slowStopper("infrastructure", 0))
+	ingestion := system.Root.Copy().WithShutdownPhase(1)
+	ingest1 := ingestion.Spawn(log.slowStopper("ingestion", 30*time.Millisecond))
+	ingest2 := ingestion.Spawn(log.slowStopper("ingestion", 10*time.Millisecond))
+	processor := system.Root.Copy().WithShutdownPhase(2).Spawn(log.slowStopper("processor", 20*time.Millisecond))
+
+	report := system.Shutdown()
+	assert.Equal(t, []string{"ingestion", "ingestion", "processor", "writer", "infrastructure"}, log.get())
+	require.Len(t, report.Phases, 4)
+	assert.Equal(t, 1, report.Phases[0].Phase)
+	assert.ElementsMatch(t, pidIDs(ingest1, ingest2), pidIDs(report.Phases[0].Stopped...))
+	assert.GreaterOrEqual(t, int64(report.Phases[0].Elapsed), int64(30*time.Millisecond), "the phase waits for its slowest actor")
+	assert.Equal(t, pidIDs(processor), pidIDs(report.Phases[1].Stopped...))
+	assert.Equal(t, pidIDs(writer), pidIDs(report.Phases[2].Stopped...))
+	assert.Equal(t, DefaultShutdownPhase, report.Phases[3].Phase)
+	assert.Equal(t, pidIDs(infrastructure), pidIDs(report.Phases[3].Stopped...))
+	assert.Empty(t, report.Stragglers())
+	assert.Empty(t, system.TopLevelActors())
+}
+
+func TestShutdown_PhaseTimeout(t *testing.T) {
+	system := NewActorSystemWithConfig(NewConfig(WithShutdownPhaseTimeout(50 * time.Millisecond)))
+	log := &stopLog{}
+	stuck := system.Root.Copy().WithShutdownPhase(1).Spawn(log.slowStopper("stuck", 300*time.Millisecond))
+	quick := system.Root.Copy().WithShutdownPhase(1).Spawn(log.slowStopper("quick", 0))
+	next := system.Root.Copy().WithShutdownPhase(2).Spawn(log.slowStopper("next", 0))
+
+	report := system.Shutdown()
+	require.Len(t, report.Phases, 2)
+	assert.Equal(t, pidIDs(quick), pidIDs(report.Phases[0].Stopped...))
+	assert.Equal(t, pidIDs(stuck), pidIDs(report.Phases[0].Stragglers...))
+	assert.Less(t, int64(report.Phases[0].Elapsed), int64(300*time.Millisecond), "the stragglers are not waited for")
+	assert.Equal(t, pidIDs(next), pidIDs(report.Phases[1].Stopped...))
+	assert.Equal(t, pidIDs(stuck), pidIDs(report.Stragglers()...))
+	assert.Equal(t, []string{"quick", "next"}, log.get(), "the next phase does not wait for the stragglers")
+
+	require.Eventually(t, func() bool {
+		return len(log.get()) == 3
+	}, testTimeout, 10*time.Millisecond, "the stragglers still stop")
+}
+
+func TestShutdown_StopsEventLog(t *testing.T) {
+	defer plog.SetLevel(plog.Level())
+	plog.SetLevel(log.DebugLevel)
+	system := NewActorSystem()
+	assert.Nil(t, system.eventLog.logger, "the logger starts with the first entry")
+	system.Root.Send(system.NewLocalPID("missing"), "hello")
+	require.NotNil(t, system.eventLog.logger)
+	subscriptions := append(system.eventLog.subscriptions, system.eventLog.logger)
+	require.Len(t, subscriptions, 3)
+
+	system.Shutdown()
+	for _, sub := range subscriptions {
+		assert.False(t, sub.Active())
+	}
+}
+
+func TestShutdown_EventLogNeverStarted(t *testing.T) {
+	system := NewActorSystem()
+	system.Shutdown()
+	system.Root.Send(system.NewLocalPID("missing"), "hello")
+	assert.Nil(t, system.eventLog.logger)
+}
@@ -0,0 +1,81 @@
+package actor
+
+import "time"
+
+// StopDraining tells what a stopping actor does with the user messages already in its mailbox,
+// see Props.WithStopDraining
+type StopDraining struct {
+	drain   bool
+	timeout time.Duration // the longest drain, 0 for no limit
+}
+
+var (
+	// Discard stops the actor right away, the messages in its mailbox are dead letters with the
+	// DeadLetterStoppedBeforeProcessing reason. It is the default
+	Discard = StopDraining{}
+	// DrainAll processes the messages already in the mailbox when the actor is stopped before it receives Stopping
+	DrainAll = StopDraining{drain: true}
+)
+
+// DrainFor drains the mailbox as DrainAll for at most timeout, the messages still in the mailbox then are discarded
+func DrainFor(timeout time.Duration) StopDraining {
+	return StopDraining{drain: true, timeout: timeout}
+}
+
+// drained is posted to the mailbox of a draining actor, the messages before it were in the mailbox when it was stopped.
+// It is a mailbox.BarrierMessage, so that the mailboxes which reorder their messages deliver it after them
+type drained struct {
+	_ byte // not zero-size, distinct from the other zero-size values
+}
+
+func (*drained) BarrierMessage() {}
+
+// drainTimeout ends the drain of an actor stopped with DrainFor
+type drainTimeout struct{}
+
+func (*drainTimeout) SystemMessage() {}
+
+var (
+	drainedMessage      interface{} = &drained{}
+	drainTimeoutMessage interface{} = &drainTimeout{}
+)
+
+// drain stops the actor once it processed the messages in its mailbox, it returns false when the actor stops right away
+func (ctx *actorContext) drain() bool {
+	mode := ctx.props.stopDraining
+	// a failed actor has its mailbox suspended, it drains nothing
+	if !mode.drain || ctx.extras != nil && ctx.extras.failed {
+		return false
+	}
+	extras := ctx.ensureExtras()
+	if extras.draining {
+		return true
+	}
+	extras.draining = true
+	if mode.timeout > 0 {
+		extras.drainTimer = ctx.actorSystem.Config.Clock.AfterFunc(mode.timeout, func() {
+			ctx.self.sendSystemMessage(ctx.actorSystem, drainTimeoutMessage)
+		})
+	}
+	// straight to the mailbox, it is not copied on send
+	ctx.self.ref(ctx.actorSystem).SendUserMessage(ctx.self, drainedMessage)
+	return true
+}
+
+// endDrain stops the draining actor
+func (ctx *actorContext) endDrain() {
+	if ctx.extras != nil && ctx.extras.draining {
+		ctx.stop()
+	}
+}
+
+func (ctx *actorContext) cancelDrain() {
+	if ctx.extras == nil || !ctx.extras.draining {
+		return
+	}
+	ctx.extras.draining = false
+	if ctx.extras.drainTimer != nil {
+		ctx.extras.drainTimer.Stop()
+		ctx.extras.drainTimer = nil
+	}
+}
@@ -0,0 +1,115 @@
+package actor
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/mailbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stopWithBacklog stops an actor with 100 messages in its mailbox, it returns how many it processed and how many
+// were dead letters
+func stopWithBacklog(t *testing.T, mode StopDraining, receive func(i int)) (processed, deadLettered int32) {
+	system := NewActorSystem()
+	blocked, release := make(chan struct{}), make(chan struct{})
+	props := PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			close(blocked)
+			<-release
+		case int:
+			atomic.AddInt32(&processed, 1)
+			receive(msg)
+		}
+	}).WithStopDraining(mode)
+	pid := system.Root.Spawn(props)
+	system.EventStream.Subscribe(func(msg interface{}) {
+		if deadLetter, ok := msg.(*DeadLetterEvent); ok && deadLetter.PID.Equal(pid) {
+			assert.Equal(t, DeadLetterStoppedBeforeProcessing, deadLetter.Reason)
+			atomic.AddInt32(&deadLettered, 1)
+		}
+	})
+
+	system.Root.Send(pid, "block")
+	<-blocked
+	for i := 0; i < 100; i++ {
+		system.Root.Send(pid, i)
+	}
+	stopped := system.Root.StopFuture(pid)
+	close(release)
+	require.NoError(t, stopped.Wait())
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&processed)+atomic.LoadInt32(&deadLettered) == 100
+	}, time.Second, time.Millisecond)
+	return atomic.LoadInt32(&processed), atomic.LoadInt32(&deadLettered)
+}
+
+func TestStopDraining(t *testing.T) {
+	cases := []struct {
+		name         string
+		mode         StopDraining
+		processed    int32
+		deadLettered int32
+	}{
+		{"Discard", Discard, 0, 100},
+		{"DrainAll", DrainAll, 100, 0},
+		// the 50th message outlasts the drain
+		{"DrainFor", DrainFor(50 * time.Millisecond), 50, 50},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			processed, deadLettered := stopWithBacklog(t, c.mode, func(i int) {
+				if i == 49 {
+					time.Sleep(200 * time.Millisecond)
+				}
+			})
+			assert.Equal(t, c.processed, processed)
+			assert.Equal(t, c.deadLettered, deadLettered)
+		})
+	}
+}
+
+func TestStopDraining_StoppingFollowsTheDrainedMessages(t *testing.T) {
+	var received []interface{}
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case int, *Stopping:
+			received = append(received, msg)
+		}
+	}).WithStopDraining(DrainAll))
+	rootContext.Send(pid, 1)
+	rootContext.Send(pid, 2)
+	require.NoError(t, rootContext.StopFuture(pid).Wait())
+	assert.Equal(t, []interface{}{1, 2, &Stopping{}}, received)
+}
+
+func TestStopDraining_FairMailboxAndCopyOnSend(t *testing.T) {
+	system := NewActorSystemWithConfig(NewConfig(WithCopyOnSend()))
+	blocked, release := make(chan struct{}), make(chan struct{})
+	var processed int32
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case string:
+			close(blocked)
+			<-release
+		case int:
+			atomic.AddInt32(&processed, 1)
+		}
+	}).WithStopDraining(DrainFor(5 * time.Second)).WithMailbox(mailbox.Fair(HeaderClassifier(tenantHeader), 0)))
+
+	system.Root.Send(pid, "block")
+	<-blocked
+	for i := 0; i < 100; i++ {
+		env := &MessageEnvelope{Message: i}
+		env.SetHeader(tenantHeader, strconv.Itoa(i%4))
+		system.Root.Send(pid, env)
+	}
+	stopped := system.Root.StopFuture(pid)
+	close(release)
+	require.NoError(t, stopped.Wait())
+	assert.Equal(t, int32(100), atomic.LoadInt32(&processed))
+}
@@ -0,0 +1,83 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stopReasons records the reasons an actor observes in Stopping and Stopped
+type stopReasons chan StopReason
+
+func (reasons stopReasons) props() *Props {
+	return PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *Stopping, *Stopped:
+			reasons <- ctx.StopReason()
+		case string:
+			panic("fail")
+		}
+	})
+}
+
+func (reasons stopReasons) expect(t *testing.T, expected StopReason) {
+	for _, message := range []string{"Stopping", "Stopped"} {
+		select {
+		case reason := <-reasons:
+			assert.Equal(t, expected, reason, message)
+		case <-time.After(testTimeout):
+			t.Fatalf("no %s", message)
+		}
+	}
+}
+
+func TestStopReason_Stop(t *testing.T) {
+	reasons := make(stopReasons, 2)
+	pid := rootContext.Spawn(reasons.props())
+	rootContext.Stop(pid)
+	reasons.expect(t, StopReason_StopRequested)
+}
+
+func TestStopReason_PoisonPill(t *testing.T) {
+	reasons := make(stopReasons, 2)
+	pid := rootContext.Spawn(reasons.props())
+	rootContext.Poison(pid)
+	reasons.expect(t, StopReason_PoisonPilled)
+
+	// the sender of a PoisonPill can tell why it stops the actor
+	pid = rootContext.Spawn(reasons.props())
+	rootContext.Send(pid, &PoisonPill{Reason: StopReason_SystemShutdown})
+	reasons.expect(t, StopReason_SystemShutdown)
+}
+
+func TestStopReason_ParentStopped(t *testing.T) {
+	reasons := make(stopReasons, 2)
+	parent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			ctx.Spawn(reasons.props())
+		}
+	}))
+	rootContext.Stop(parent)
+	reasons.expect(t, StopReason_ParentStopped)
+}
+
+func TestStopReason_SupervisorStopped(t *testing.T) {
+	reasons := make(stopReasons, 2)
+	stopping := NewOneForOneStrategy(10, time.Second, func(interface{}) Directive {
+		return StopDirective
+	})
+	parent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			ctx.Send(ctx.Spawn(reasons.props()), "fail")
+		}
+	}).WithSupervisor(stopping))
+	defer rootContext.Stop(parent)
+	reasons.expect(t, StopReason_SupervisorStopped)
+}
+
+func TestStopReason_Passivation(t *testing.T) {
+	reasons := make(stopReasons, 2)
+	rootContext.Spawn(reasons.props().WithIdlePassivation(10 * time.Millisecond))
+	reasons.expect(t, StopReason_Passivation)
+}
@@ -38,6 +38,23 @@ func (strategy *allForOneStrategy) HandleFailure(actorSystem *ActorSystem, super
 			logFailure(actorSystem, child, reason, RestartDirective)
 			supervisor.RestartChildren(children...)
 		}
+	case RestartWithRedeliveryDirective:
+		children := supervisor.Children()
+		// try restart the all the children, only the failing child receives the failed message again
+		if strategy.shouldStop(rs) {
+			logFailure(actorSystem, child, reason, StopDirective)
+			supervisor.StopChildren(children...)
+		} else {
+			logFailure(actorSystem, child, reason, RestartWithRedeliveryDirective)
+			siblings := make([]*PID, 0, len(children))
+			for _, pid := range children {
+				if !pid.Equal(child) {
+					siblings = append(siblings, pid)
+				}
+			}
+			supervisor.RestartChildren(siblings...)
+			supervisor.RestartChildrenWithRedelivery(child)
+		}
 	case StopDirective:
 		children := supervisor.Children()
 		// stop all the children, no need to involve the crs
@@ -27,7 +27,7 @@ func (strategy *exponentialBackoffStrategy) HandleFailure(actorSystem *ActorSyst
 	backoff := rs.FailureCount() * int(strategy.initialBackoff.Nanoseconds())
 	noise := rand.Intn(500)
 	dur := time.Duration(backoff + noise)
-	time.AfterFunc(dur, func() {
+	actorSystem.Clock().AfterFunc(dur, func() {
 		supervisor.RestartChildren(child)
 	})
 }
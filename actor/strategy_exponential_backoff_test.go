@@ -21,7 +21,7 @@ func TestExponentialBackoffStrategy_setFailureCount(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.n, func(t *testing.T) {
 			s := &exponentialBackoffStrategy{backoffWindow: 10 * time.Second}
-			rs := &RestartStatistics{[]time.Time{}}
+			rs := &RestartStatistics{failureTimes: []time.Time{}}
 			for i := 0; i < tc.fc; i++ {
 				rs.failureTimes = append(rs.failureTimes, time.Now().Add(-tc.ft))
 			}
@@ -37,6 +37,15 @@ func (strategy *oneForOne) HandleFailure(actorSystem *ActorSystem, supervisor Su
 			logFailure(actorSystem, child, reason, RestartDirective)
 			supervisor.RestartChildren(child)
 		}
+	case RestartWithRedeliveryDirective:
+		// try restart the failing child, which receives the failed message again
+		if strategy.shouldStop(rs) {
+			logFailure(actorSystem, child, reason, StopDirective)
+			supervisor.StopChildren(child)
+		} else {
+			logFailure(actorSystem, child, reason, RestartWithRedeliveryDirective)
+			supervisor.RestartChildrenWithRedelivery(child)
+		}
 	case StopDirective:
 		// stop the failing child, no need to involve the crs
 		logFailure(actorSystem, child, reason, directive)
@@ -0,0 +1,88 @@
+package subprocess
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// LineCodec translates the messages of the actor to the input of the process, and its output to messages
+type LineCodec interface {
+	// Encode returns the line or frame written to the process for message, without the line end or length prefix
+	Encode(message interface{}) ([]byte, error)
+	// Decode returns the message of a line or frame the process wrote
+	Decode(data []byte) (interface{}, error)
+}
+
+// StringCodec writes the string messages as they are, the output of the process is read as strings
+type StringCodec struct{}
+
+func (StringCodec) Encode(message interface{}) ([]byte, error) {
+	switch msg := message.(type) {
+	case string:
+		return []byte(msg), nil
+	case []byte:
+		return msg, nil
+	}
+	return nil, fmt.Errorf("subprocess: cannot encode %T as a string", message)
+}
+
+func (StringCodec) Decode(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+// Framing delimits the messages written to and read from a process
+type Framing int
+
+const (
+	// Lines ends each message with a new line, the messages must not contain one
+	Lines Framing = iota
+	// LengthPrefixed prefixes each message with its length, as a 4 bytes big endian integer
+	LengthPrefixed
+)
+
+// maxLineSize is the longest line read from a process
+const maxLineSize = 1024 * 1024
+
+func (f Framing) write(w io.Writer, data []byte) error {
+	if f == LengthPrefixed {
+		var prefix [4]byte
+		binary.BigEndian.PutUint32(prefix[:], uint32(len(data)))
+		data = append(prefix[:], data...)
+	} else {
+		data = append(data[:len(data):len(data)], '\n')
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// reader returns the function reading the next message of r, io.EOF once r is closed
+func (f Framing) reader(r io.Reader) func() ([]byte, error) {
+	if f == LengthPrefixed {
+		return func() ([]byte, error) {
+			var prefix [4]byte
+			if _, err := io.ReadFull(r, prefix[:]); err != nil {
+				return nil, err
+			}
+			data := make([]byte, binary.BigEndian.Uint32(prefix[:]))
+			if _, err := io.ReadFull(r, data); err != nil {
+				return nil, err
+			}
+			return data, nil
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	return func() ([]byte, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		// the buffer of the scanner is reused by the next scan
+		return append([]byte(nil), scanner.Bytes()...), nil
+	}
+}
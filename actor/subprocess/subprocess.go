@@ -0,0 +1,234 @@
+// Package subprocess runs OS processes as actors, so that they are supervised by the actor tree.
+// The messages sent to the actor are written to the input of its process, the messages the process writes are
+// sent to the parent of the actor. A process exiting with an error fails the actor, which the supervisor strategy of
+// its parent restarts, stops or escalates as for any failing actor
+package subprocess
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync/atomic"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// DefaultGracePeriod is how long a stopping process has to exit before it is killed, when not configured
+const DefaultGracePeriod = 5 * time.Second
+
+// ExitError is the reason of the failure of an actor whose process exited with an error
+type ExitError struct {
+	Path string
+	Code int // -1 when the process was killed by a signal
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("subprocess: %s exited: %v", e.Path, e.Err)
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+type config struct {
+	framing     Framing
+	output      *actor.PID
+	gracePeriod time.Duration
+}
+
+// Option configures the actor of a process
+type Option func(*config)
+
+// WithFraming delimits the messages written to and read from the process with framing, Lines by default
+func WithFraming(framing Framing) Option {
+	return func(c *config) {
+		c.framing = framing
+	}
+}
+
+// WithOutput sends the messages the process writes to pid, they are sent to the parent of the actor by default,
+// and dropped when the actor is spawned from the root context
+func WithOutput(pid *actor.PID) Option {
+	return func(c *config) {
+		c.output = pid
+	}
+}
+
+// WithGracePeriod gives a stopping process d to exit before it is killed, DefaultGracePeriod by default
+func WithGracePeriod(d time.Duration) Option {
+	return func(c *config) {
+		c.gracePeriod = d
+	}
+}
+
+// NewSubprocessProps returns the props of an actor running the process of cmd, translating its messages with codec.
+// The process is started when the actor starts, and again with a new Cmd from cmd when it restarts.
+// It is asked to exit when the actor stops or restarts: its input is closed and it is interrupted,
+// and it is killed if it still runs after the grace period.
+// A process exiting with an error fails the actor with an ExitError, a process exiting successfully stops it
+func NewSubprocessProps(cmd func() *exec.Cmd, codec LineCodec, opts ...Option) *actor.Props {
+	c := config{
+		framing:     Lines,
+		gracePeriod: DefaultGracePeriod,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return actor.PropsFromProducer(func() actor.Actor {
+		return &subprocessActor{cmd: cmd, codec: codec, config: c}
+	})
+}
+
+// process is a running process of the actor
+type process struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// exited is closed once the process exited and its output was read
+	exited chan struct{}
+	// stopping is set when the actor asks the process to exit, the end of the process is then expected
+	stopping int32
+}
+
+// output is a message the process wrote
+type output struct {
+	process *process
+	message interface{}
+}
+
+// exited tells the actor that its process exited
+type exited struct {
+	process *process
+	err     error
+}
+
+type subprocessActor struct {
+	cmd     func() *exec.Cmd
+	codec   LineCodec
+	config  config
+	process *process
+}
+
+func (a *subprocessActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		a.start(ctx)
+	case *actor.Stopping, *actor.Restarting:
+		a.stop()
+	case *actor.Stopped, actor.AutoReceiveMessage, actor.SystemMessage, *actor.ReceiveTimeout:
+	case *output:
+		if target := a.outputTo(ctx); msg.process == a.process && target != nil {
+			ctx.Request(target, msg.message)
+		}
+	case *exited:
+		if msg.process == a.process {
+			a.exited(ctx, msg.err)
+		}
+	default:
+		a.write(msg)
+	}
+}
+
+func (a *subprocessActor) start(ctx actor.Context) {
+	cmd := a.cmd()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		panic(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		panic(err)
+	}
+	if err := cmd.Start(); err != nil {
+		panic(err)
+	}
+
+	p := &process{cmd: cmd, stdin: stdin, exited: make(chan struct{})}
+	a.process = p
+	system, self := ctx.ActorSystem(), ctx.Self()
+	go func() {
+		defer close(p.exited)
+		read := a.config.framing.reader(stdout)
+		for {
+			data, err := read()
+			if err != nil {
+				if err != io.EOF {
+					plog.Error("failed to read the output of the process", log.String("path", cmd.Path), log.Error(err))
+				}
+				break
+			}
+			message, err := a.codec.Decode(data)
+			if err != nil {
+				plog.Error("failed to decode the output of the process", log.String("path", cmd.Path), log.Error(err))
+				continue
+			}
+			if atomic.LoadInt32(&p.stopping) == 0 {
+				system.Root.Send(self, &output{process: p, message: message})
+			}
+		}
+		err := cmd.Wait()
+		if atomic.LoadInt32(&p.stopping) == 0 {
+			system.Root.Send(self, &exited{process: p, err: err})
+		}
+	}()
+}
+
+func (a *subprocessActor) write(message interface{}) {
+	data, err := a.codec.Encode(message)
+	if err != nil {
+		panic(err)
+	}
+	if err := a.config.framing.write(a.process.stdin, data); err != nil {
+		panic(fmt.Errorf("subprocess: failed to write to %s: %w", a.process.cmd.Path, err))
+	}
+}
+
+func (a *subprocessActor) outputTo(ctx actor.Context) *actor.PID {
+	if a.config.output != nil {
+		return a.config.output
+	}
+	return ctx.Parent()
+}
+
+func (a *subprocessActor) exited(ctx actor.Context, err error) {
+	path := a.process.cmd.Path
+	a.process = nil
+	if err == nil {
+		plog.Debug("process exited", log.String("path", path))
+		ctx.Stop(ctx.Self())
+		return
+	}
+
+	code := -1
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		code = exitErr.ExitCode()
+	}
+	// fails the actor, its supervisor decides what comes next
+	panic(&ExitError{Path: path, Code: code, Err: err})
+}
+
+// stop asks the process to exit, and kills it once the grace period passed
+func (a *subprocessActor) stop() {
+	p := a.process
+	if p == nil {
+		return
+	}
+	a.process = nil
+	atomic.StoreInt32(&p.stopping, 1)
+
+	_ = p.stdin.Close()
+	if err := p.cmd.Process.Signal(os.Interrupt); err != nil {
+		// interrupts are not supported everywhere, the process exited already otherwise
+		_ = p.cmd.Process.Kill()
+	}
+	select {
+	case <-p.exited:
+	case <-time.After(a.config.gracePeriod):
+		plog.Info("killing the process which did not exit within the grace period", log.String("path", p.cmd.Path))
+		_ = p.cmd.Process.Kill()
+		<-p.exited
+	}
+}
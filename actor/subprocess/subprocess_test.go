@@ -0,0 +1,202 @@
+package subprocess
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const helperEnv = "SUBPROCESS_TEST_HELPER"
+
+// TestMain runs the test binary as the helper process when it is started by helper
+func TestMain(m *testing.M) {
+	switch os.Getenv(helperEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "lines":
+		echoLines(false)
+	case "stubborn":
+		echoLines(true)
+	case "frames":
+		echoFrames()
+	}
+}
+
+// echoLines echoes the lines it reads, it exits with code n on "exit n".
+// A stubborn helper ignores interrupts and the end of its input
+func echoLines(stubborn bool) {
+	if stubborn {
+		signal.Ignore(os.Interrupt)
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var code int
+		if _, err := fmt.Sscanf(scanner.Text(), "exit %d", &code); err == nil {
+			os.Exit(code)
+		}
+		fmt.Println("echo " + scanner.Text())
+	}
+	if stubborn {
+		select {}
+	}
+	os.Exit(0)
+}
+
+// echoFrames echoes the length prefixed frames it reads
+func echoFrames() {
+	read := LengthPrefixed.reader(os.Stdin)
+	for {
+		data, err := read()
+		if err != nil {
+			os.Exit(0)
+		}
+		_ = LengthPrefixed.write(os.Stdout, append([]byte("echo "), data...))
+	}
+}
+
+// helper returns the command running the test binary as the helper of mode
+func helper(mode string) func() *exec.Cmd {
+	return func() *exec.Cmd {
+		cmd := exec.Command(os.Args[0])
+		cmd.Env = append(os.Environ(), helperEnv+"="+mode)
+		return cmd
+	}
+}
+
+// spawnParent spawns the actor of props as the child of an actor forwarding the output of the process to outputs
+func spawnParent(t *testing.T, system *actor.ActorSystem, props *actor.Props, strategy actor.SupervisorStrategy) (*actor.PID, chan interface{}) {
+	outputs := make(chan interface{}, 10)
+	children := make(chan *actor.PID, 1)
+	parent := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			children <- ctx.Spawn(props)
+		case string:
+			outputs <- msg
+		}
+	}).WithSupervisor(strategy))
+	t.Cleanup(func() { _ = system.Root.StopFuture(parent).Wait() })
+	return <-children, outputs
+}
+
+func expectOutput(t *testing.T, outputs chan interface{}, expected string) {
+	select {
+	case output := <-outputs:
+		assert.Equal(t, expected, output)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no output %s", expected)
+	}
+}
+
+func TestSubprocess_Lines(t *testing.T) {
+	system := actor.NewActorSystem()
+	pid, outputs := spawnParent(t, system, NewSubprocessProps(helper("lines"), StringCodec{}), actor.DefaultSupervisorStrategy())
+
+	system.Root.Send(pid, "hello")
+	system.Root.Send(pid, "world")
+	expectOutput(t, outputs, "echo hello")
+	expectOutput(t, outputs, "echo world")
+}
+
+func TestSubprocess_LengthPrefixedFrames(t *testing.T) {
+	system := actor.NewActorSystem()
+	props := NewSubprocessProps(helper("frames"), StringCodec{}, WithFraming(LengthPrefixed))
+	pid, outputs := spawnParent(t, system, props, actor.DefaultSupervisorStrategy())
+
+	system.Root.Send(pid, "multi\nline")
+	expectOutput(t, outputs, "echo multi\nline")
+}
+
+func TestSubprocess_FailedExitIsSupervised(t *testing.T) {
+	system := actor.NewActorSystem()
+	failures := make(chan interface{}, 10)
+	restarting := actor.NewOneForOneStrategy(10, time.Second, func(reason interface{}) actor.Directive {
+		failures <- reason
+		return actor.RestartDirective
+	})
+	pid, outputs := spawnParent(t, system, NewSubprocessProps(helper("lines"), StringCodec{}), restarting)
+
+	system.Root.Send(pid, "exit 3")
+	select {
+	case reason := <-failures:
+		require.IsType(t, &ExitError{}, reason)
+		assert.Equal(t, 3, reason.(*ExitError).Code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the exit of the process did not fail the actor")
+	}
+
+	// the restarted actor runs a new process
+	system.Root.Send(pid, "again")
+	expectOutput(t, outputs, "echo again")
+}
+
+func TestSubprocess_SuccessfulExitStopsActor(t *testing.T) {
+	system := actor.NewActorSystem()
+	pid, _ := spawnParent(t, system, NewSubprocessProps(helper("lines"), StringCodec{}), actor.DefaultSupervisorStrategy())
+
+	stopped := make(chan struct{})
+	system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			ctx.Watch(pid)
+			ctx.Send(pid, "exit 0")
+		case *actor.Terminated:
+			close(stopped)
+		}
+	}))
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the actor did not stop")
+	}
+}
+
+func TestSubprocess_StopKillsProcessAfterGracePeriod(t *testing.T) {
+	system := actor.NewActorSystem()
+	grace := 100 * time.Millisecond
+	props := NewSubprocessProps(helper("stubborn"), StringCodec{}, WithGracePeriod(grace))
+	pid, outputs := spawnParent(t, system, props, actor.DefaultSupervisorStrategy())
+	system.Root.Send(pid, "ready")
+	expectOutput(t, outputs, "echo ready")
+
+	start := time.Now()
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(grace), "the process ignoring the interrupt is killed after the grace period")
+}
+
+func TestSubprocess_StopEndsProcessWithinGracePeriod(t *testing.T) {
+	system := actor.NewActorSystem()
+	props := NewSubprocessProps(helper("lines"), StringCodec{}, WithGracePeriod(time.Minute))
+	pid, outputs := spawnParent(t, system, props, actor.DefaultSupervisorStrategy())
+	system.Root.Send(pid, "ready")
+	expectOutput(t, outputs, "echo ready")
+
+	start := time.Now()
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second), "the process exits when interrupted")
+}
+
+func TestFraming_Lines(t *testing.T) {
+	r, w := io.Pipe()
+	go func() {
+		_ = Lines.write(w, []byte("a"))
+		_ = Lines.write(w, []byte("b"))
+		_ = w.Close()
+	}()
+	read := Lines.reader(r)
+	var lines []string
+	for data, err := read(); err == nil; data, err = read() {
+		lines = append(lines, string(data))
+	}
+	assert.Equal(t, "a,b", strings.Join(lines, ","))
+}
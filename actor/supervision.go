@@ -17,12 +17,36 @@ type Supervisor interface {
 	Children() []*PID
 	EscalateFailure(reason interface{}, message interface{})
 	RestartChildren(pids ...*PID)
+	RestartChildrenWithRedelivery(pids ...*PID)
 	StopChildren(pids ...*PID)
 	ResumeChildren(pids ...*PID)
 }
 
+// failureSupervisor is the supervisor handed to the strategies handling failure, the restarts it sends carry it
+type failureSupervisor struct {
+	Supervisor
+	system  *ActorSystem
+	failure *Failure
+}
+
+func (s *failureSupervisor) restart() *Restart {
+	return &Restart{Reason: s.failure.Reason, Message: s.failure.Message}
+}
+
+func (s *failureSupervisor) RestartChildren(pids ...*PID) {
+	for _, pid := range pids {
+		pid.sendSystemMessage(s.system, s.restart())
+	}
+}
+
+func (s *failureSupervisor) RestartChildrenWithRedelivery(pids ...*PID) {
+	for _, pid := range pids {
+		pid.sendSystemMessage(s.system, &restartWithRedelivery{restart: s.restart()})
+	}
+}
+
 func logFailure(actorSystem *ActorSystem, child *PID, reason interface{}, directive Directive) {
-	actorSystem.EventStream.Publish(&SupervisorEvent{
+	actorSystem.SystemEventStream.Publish(&SupervisorEvent{
 		Child:     child,
 		Reason:    reason,
 		Directive: directive,
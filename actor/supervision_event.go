@@ -1,10 +1,12 @@
 package actor
 
 import (
+	"fmt"
+
 	"github.com/AsynkronIT/protoactor-go/log"
 )
 
-// SupervisorEvent is sent on the EventStream when a supervisor have applied a directive to a failing child actor
+// SupervisorEvent is sent on the SystemEventStream when a supervisor have applied a directive to a failing child actor
 type SupervisorEvent struct {
 	Child     *PID
 	Reason    interface{}
@@ -12,9 +14,16 @@ type SupervisorEvent struct {
 }
 
 func SubscribeSupervision(actorSystem *ActorSystem) {
-	_ = actorSystem.EventStream.Subscribe(func(evt interface{}) {
-		if supervisorEvent, ok := evt.(*SupervisorEvent); ok {
-			plog.Debug("[SUPERVISION]", log.Stringer("actor", supervisorEvent.Child), log.Stringer("directive", supervisorEvent.Directive), log.Object("reason", supervisorEvent.Reason))
+	// supervision events are published by the failing actors, they are logged asynchronously
+	actorSystem.eventLog.subscribe(func(evt interface{}) {
+		if !actorSystem.eventLog.enabled() {
+			return
 		}
+		supervisorEvent := evt.(*SupervisorEvent)
+		actorSystem.eventLog.log("[SUPERVISION]", log.Stringer("actor", supervisorEvent.Child), log.Stringer("directive", supervisorEvent.Directive),
+			log.String("reason", fmt.Sprint(supervisorEvent.Reason)))
+	}).WithPredicate(func(evt interface{}) bool {
+		_, ok := evt.(*SupervisorEvent)
+		return ok
 	})
 }
@@ -0,0 +1,163 @@
+package testkit
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// ManualClock is an actor.Clock whose time only moves when advanced, the actor systems run on it with actor.WithClock.
+// The timers due fire synchronously within Advance, in the order of their deadlines
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    int
+	timers []*manualTimer
+}
+
+var _ actor.Clock = (*ManualClock)(nil)
+
+type manualTimer struct {
+	clock  *ManualClock
+	at     time.Time
+	seq    int // orders the timers due at the same time
+	fn     func()
+	c      chan time.Time
+	active bool
+}
+
+// NewManualClock returns a clock at now
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// set moves the clock to now without firing the timers
+func (c *ManualClock) set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) actor.Timer {
+	return c.add(d, f, nil)
+}
+
+func (c *ManualClock) NewTimer(d time.Duration) actor.Timer {
+	return c.add(d, nil, make(chan time.Time, 1))
+}
+
+func (c *ManualClock) add(d time.Duration, fn func(), ch chan time.Time) *manualTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &manualTimer{clock: c, fn: fn, c: ch}
+	c.schedule(t, d)
+	return t
+}
+
+// schedule arms t to fire after d, it is called with the lock held
+func (c *ManualClock) schedule(t *manualTimer, d time.Duration) {
+	if !t.active {
+		c.timers = append(c.timers, t)
+	}
+	c.seq++
+	t.seq = c.seq
+	t.at = c.now.Add(d)
+	t.active = true
+}
+
+// Pending returns the number of timers armed, e.g. to wait until an actor armed its timer before advancing the time
+func (c *ManualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// Advance moves the time forward by d and fires the timers due on the way, the timers armed by the ones fired
+// fire too when they are due. The timers due now fire with a zero d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+	for c.fireNext(end) {
+	}
+	c.mu.Lock()
+	c.now = end
+	c.mu.Unlock()
+}
+
+// fireNext fires the first timer due by end
+func (c *ManualClock) fireNext(end time.Time) bool {
+	c.mu.Lock()
+	if len(c.timers) == 0 {
+		c.mu.Unlock()
+		return false
+	}
+	sort.Slice(c.timers, func(i, j int) bool {
+		if c.timers[i].at.Equal(c.timers[j].at) {
+			return c.timers[i].seq < c.timers[j].seq
+		}
+		return c.timers[i].at.Before(c.timers[j].at)
+	})
+	t := c.timers[0]
+	if t.at.After(end) {
+		c.mu.Unlock()
+		return false
+	}
+	c.remove(t)
+	if t.at.After(c.now) {
+		c.now = t.at
+	}
+	now := c.now
+	c.mu.Unlock()
+
+	if t.fn != nil {
+		t.fn()
+	} else {
+		select {
+		case t.c <- now:
+		default:
+		}
+	}
+	return true
+}
+
+// remove disarms t, it is called with the lock held
+func (c *ManualClock) remove(t *manualTimer) bool {
+	if !t.active {
+		return false
+	}
+	t.active = false
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *manualTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.active
+	t.clock.schedule(t, d)
+	return active
+}
@@ -0,0 +1,49 @@
+package testkit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Now()
+	clock := NewManualClock(start)
+	var fired []string
+	record := func(name string) func() {
+		return func() {
+			fired = append(fired, name)
+			assert.Equal(t, start.Add(2*time.Second), clock.Now(), "the timers fire at their time")
+		}
+	}
+
+	clock.AfterFunc(2*time.Second, record("first"))
+	clock.AfterFunc(2*time.Second, record("second"))
+	stopped := clock.AfterFunc(time.Second, record("stopped"))
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+	clock.AfterFunc(time.Second, func() {
+		clock.AfterFunc(time.Second, record("armed while advancing"))
+	})
+	timer := clock.NewTimer(time.Minute)
+	assert.Equal(t, 4, clock.Pending())
+
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, []string{"first", "second", "armed while advancing"}, fired)
+	assert.Equal(t, start.Add(2*time.Second), clock.Now())
+	assert.Empty(t, timer.C())
+
+	assert.True(t, timer.Reset(time.Second))
+	clock.Advance(time.Second)
+	select {
+	case now := <-timer.C():
+		assert.Equal(t, start.Add(3*time.Second), now)
+	default:
+		assert.Fail(t, "the timer did not fire")
+	}
+	assert.False(t, timer.Reset(0), "the timer fired")
+	clock.Advance(0)
+	assert.Len(t, timer.C(), 1)
+	assert.Zero(t, clock.Pending())
+}
@@ -0,0 +1,24 @@
+package testkit
+
+import "time"
+
+// Config holds the defaults of the probes
+type Config struct {
+	// Timeout is how long the expectations wait for a message when given no timeout
+	Timeout time.Duration
+	// NoMsgTimeout is how long ExpectNoMsg waits when given no duration
+	NoMsgTimeout time.Duration
+}
+
+// DefaultConfig is used by the expectations given no timeout, tests may change it before creating their probes
+var DefaultConfig = Config{
+	Timeout:      3 * time.Second,
+	NoMsgTimeout: 100 * time.Millisecond,
+}
+
+func orDefault(timeout, defaultTimeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	return defaultTimeout
+}
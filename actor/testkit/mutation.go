@@ -0,0 +1,180 @@
+package testkit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// checksumHeader carries the checksum of a message from its sender to its receiver
+const checksumHeader = "testkit-checksum"
+
+// Mutation is a message which changed between its send and its receive
+type Mutation struct {
+	MessageType string
+	Sender      *actor.PID
+	Target      *actor.PID
+}
+
+// MutationDetector finds the messages mutated while they are in flight, by the senders which kept them or by the
+// other actors they were sent to. Its sender middleware checksums the messages when they are sent, its receiver
+// middleware checksums them again when they are received and records a Mutation when they differ.
+// The checksums walk the messages by reflection, unexported fields included, the detector is meant for tests
+type MutationDetector struct {
+	mu        sync.Mutex
+	mutations []Mutation
+}
+
+func NewMutationDetector() *MutationDetector {
+	return &MutationDetector{}
+}
+
+// SenderMiddleware checksums the messages sent
+func (d *MutationDetector) SenderMiddleware() actor.SenderMiddleware {
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			envelope.SetHeader(checksumHeader, strconv.FormatUint(checksum(envelope.Message), 16))
+			next(c, target, envelope)
+		}
+	}
+}
+
+// ReceiverMiddleware checks the messages received against their checksum, the messages sent without the sender
+// middleware are not checked
+func (d *MutationDetector) ReceiverMiddleware() actor.ReceiverMiddleware {
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			if sent := envelope.GetHeader(checksumHeader); sent != "" && sent != strconv.FormatUint(checksum(envelope.Message), 16) {
+				d.mu.Lock()
+				d.mutations = append(d.mutations, Mutation{
+					MessageType: fmt.Sprintf("%T", envelope.Message),
+					Sender:      envelope.Sender,
+					Target:      c.Self(),
+				})
+				d.mu.Unlock()
+			}
+			next(c, envelope)
+		}
+	}
+}
+
+// Mutations returns the mutations found so far
+func (d *MutationDetector) Mutations() []Mutation {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Mutation(nil), d.mutations...)
+}
+
+// AssertNoMutations fails the test when messages were mutated in flight
+func (d *MutationDetector) AssertNoMutations(t testing.TB) {
+	t.Helper()
+	for _, m := range d.Mutations() {
+		t.Errorf("a %v sent to %v was mutated in flight", m.MessageType, m.Target)
+	}
+}
+
+// checksum hashes the value of message, following its pointers
+func checksum(message interface{}) uint64 {
+	h := &hasher{seen: make(map[uintptr]int)}
+	h.hash(reflect.ValueOf(message))
+	return h.sum()
+}
+
+type hasher struct {
+	buf  []byte
+	seen map[uintptr]int // the pointers hashed already by visit order, to stop at the cycles
+}
+
+func (h *hasher) sum() uint64 {
+	f := fnv.New64a()
+	_, _ = f.Write(h.buf)
+	return f.Sum64()
+}
+
+func (h *hasher) uint(u uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], u)
+	h.buf = append(h.buf, b[:]...)
+}
+
+func (h *hasher) hash(v reflect.Value) {
+	if !v.IsValid() {
+		h.uint(0)
+		return
+	}
+	h.uint(uint64(v.Kind()))
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.uint(1)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.uint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.uint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.uint(math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		h.uint(math.Float64bits(real(v.Complex())))
+		h.uint(math.Float64bits(imag(v.Complex())))
+	case reflect.String:
+		h.uint(uint64(v.Len()))
+		h.buf = append(h.buf, v.String()...)
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			h.uint(0)
+			return
+		}
+		if v.Kind() == reflect.Ptr {
+			// the pointers seen are hashed by their order rather than their address, for the copies to match
+			if order, ok := h.seen[v.Pointer()]; ok {
+				h.uint(1)
+				h.uint(uint64(order))
+				return
+			}
+			h.seen[v.Pointer()] = len(h.seen)
+			h.uint(2)
+		}
+		h.hash(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if strings.HasPrefix(v.Type().Field(i).Name, "XXX_") {
+				// the protobuf caches, e.g. of the size of the message
+				continue
+			}
+			h.hash(v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		h.uint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			h.hash(v.Index(i))
+		}
+	case reflect.Map:
+		// the entries are summed, their order is random. Each sees the pointers seen before the map only, for its
+		// hash not to depend on the order
+		var entries uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := &hasher{seen: make(map[uintptr]int, len(h.seen))}
+			for p, order := range h.seen {
+				entry.seen[p] = order
+			}
+			entry.hash(iter.Key())
+			entry.hash(iter.Value())
+			entries += entry.sum()
+		}
+		h.uint(uint64(v.Len()))
+		h.uint(entries)
+	default:
+		// channels, functions and unsafe pointers are compared by identity
+		h.uint(uint64(v.Pointer()))
+	}
+}
@@ -0,0 +1,75 @@
+package testkit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cart struct {
+	Items  map[string]int
+	Owner  *actor.PID
+	Parent *cart
+}
+
+// sendWhileBusy sends the cart to an actor busy with another message, mutates it with mutate, then lets the actor
+// receive it
+func sendWhileBusy(t *testing.T, system *actor.ActorSystem, detector *MutationDetector, mutate func(c *cart)) {
+	release := make(chan struct{})
+	received := make(chan struct{})
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case string:
+			<-release
+		case *cart:
+			close(received)
+		}
+	}).WithReceiverMiddleware(detector.ReceiverMiddleware()))
+	defer system.Root.Stop(pid)
+
+	root := system.Root.Copy().WithSenderMiddleware(detector.SenderMiddleware())
+	root.Send(pid, "busy")
+	c := &cart{Items: map[string]int{"apple": 1, "pear": 2}}
+	c.Parent = c
+	root.Send(pid, c)
+	mutate(c)
+	close(release)
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatal("the cart was not received")
+	}
+}
+
+func TestMutationDetector(t *testing.T) {
+	system := actor.NewActorSystem()
+	detector := NewMutationDetector()
+	sendWhileBusy(t, system, detector, func(c *cart) {})
+	detector.AssertNoMutations(t)
+
+	sendWhileBusy(t, system, detector, func(c *cart) { c.Items["apple"]++ })
+	mutations := detector.Mutations()
+	require.Len(t, mutations, 1)
+	assert.Equal(t, "*testkit.cart", mutations[0].MessageType)
+
+	// the copies on send are not mutated
+	system = actor.NewActorSystemWithConfig(actor.NewConfig(actor.WithCopyOnSend()))
+	detector = NewMutationDetector()
+	sendWhileBusy(t, system, detector, func(c *cart) { c.Items["apple"]++ })
+	detector.AssertNoMutations(t)
+}
+
+func TestChecksum(t *testing.T) {
+	c := &cart{Items: map[string]int{"a": 1, "b": 2, "c": 3}}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, checksum(c), checksum(&cart{Items: map[string]int{"c": 3, "b": 2, "a": 1}}))
+	}
+	before := checksum(c)
+	c.Parent = c
+	assert.NotEqual(t, before, checksum(c))
+	c.Items["a"] = 0
+	assert.NotEqual(t, before, checksum(c))
+}
@@ -0,0 +1,182 @@
+// Package testkit helps testing actors: a TestProbe is an actor whose messages the tests expect,
+// rather than receiving them through channels and sleeps.
+package testkit
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// watch asks the probe to watch a PID
+type watch struct {
+	pid *actor.PID
+}
+
+// TestProbe is an actor queueing the messages it receives, the expectations take them from the queue in order.
+// Its PID is used as a sender, a watch target, a routee or a remote target like any other
+type TestProbe struct {
+	system *actor.ActorSystem
+	pid    *actor.PID
+	cfg    Config
+
+	mu       sync.Mutex
+	queue    []*actor.MessageEnvelope
+	received chan struct{} // signaled when a message is queued
+	last     *actor.MessageEnvelope
+}
+
+// NewTestProbe spawns a probe in system, with the timeouts of DefaultConfig
+func NewTestProbe(system *actor.ActorSystem) *TestProbe {
+	probe := &TestProbe{
+		system:   system,
+		cfg:      DefaultConfig,
+		received: make(chan struct{}, 1),
+	}
+	probe.pid = system.Root.Spawn(actor.PropsFromFunc(probe.receive))
+	return probe
+}
+
+// PID is the PID of the probe
+func (p *TestProbe) PID() *actor.PID {
+	return p.pid
+}
+
+// Stop stops the probe and waits until it stopped
+func (p *TestProbe) Stop() {
+	_ = p.system.Root.StopFuture(p.pid).Wait()
+}
+
+// Sender is the sender of the last message expected
+func (p *TestProbe) Sender() *actor.PID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.last == nil {
+		return nil
+	}
+	return p.last.Sender
+}
+
+// Send sends message to pid with the probe as sender
+func (p *TestProbe) Send(pid *actor.PID, message interface{}) {
+	p.system.Root.RequestWithCustomSender(pid, message, p.pid)
+}
+
+// Reply sends message to the sender of the last message expected
+func (p *TestProbe) Reply(message interface{}) {
+	if sender := p.Sender(); sender != nil {
+		p.Send(sender, message)
+	}
+}
+
+// Watch makes the probe watch pid, ExpectTerminated expects its termination
+func (p *TestProbe) Watch(pid *actor.PID) {
+	_ = p.system.Root.RequestFuture(p.pid, &watch{pid: pid}, p.cfg.Timeout).Wait()
+}
+
+func (p *TestProbe) receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started, *actor.Stopping, *actor.Stopped, *actor.Restarting:
+		return
+	case *watch:
+		ctx.Watch(msg.pid)
+		ctx.Respond(msg)
+		return
+	}
+
+	envelope := &actor.MessageEnvelope{Message: ctx.Message(), Sender: ctx.Sender()}
+	p.mu.Lock()
+	p.queue = append(p.queue, envelope)
+	p.mu.Unlock()
+	select {
+	case p.received <- struct{}{}:
+	default:
+	}
+}
+
+// next takes the next message from the queue, waiting until the deadline
+func (p *TestProbe) next(deadline time.Time) (*actor.MessageEnvelope, bool) {
+	for {
+		p.mu.Lock()
+		if len(p.queue) > 0 {
+			envelope := p.queue[0]
+			p.queue[0] = nil
+			p.queue = p.queue[1:]
+			p.last = envelope
+			p.mu.Unlock()
+			return envelope, true
+		}
+		p.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(deadline))
+		select {
+		case <-p.received:
+			timer.Stop()
+		case <-timer.C:
+			return nil, false
+		}
+	}
+}
+
+// ExpectMsgMatching expects the next message to match pred within timeout, the default timeout when 0, and returns it
+func (p *TestProbe) ExpectMsgMatching(t testing.TB, pred func(message interface{}) bool, timeout time.Duration) interface{} {
+	t.Helper()
+	timeout = orDefault(timeout, p.cfg.Timeout)
+	envelope, ok := p.next(time.Now().Add(timeout))
+	if !ok {
+		t.Fatalf("timeout (%v) while waiting for a message", timeout)
+		return nil
+	}
+	if !pred(envelope.Message) {
+		t.Fatalf("unexpected message %#v", envelope.Message)
+	}
+	return envelope.Message
+}
+
+// ExpectMsg expects the next message to equal expected within timeout, the default timeout when 0, and returns it
+func (p *TestProbe) ExpectMsg(t testing.TB, expected interface{}, timeout time.Duration) interface{} {
+	t.Helper()
+	return p.ExpectMsgMatching(t, func(message interface{}) bool {
+		return reflect.DeepEqual(expected, message)
+	}, timeout)
+}
+
+// ExpectNoMsg expects no message during duration, the default one when 0
+func (p *TestProbe) ExpectNoMsg(t testing.TB, duration time.Duration) {
+	t.Helper()
+	if envelope, ok := p.next(time.Now().Add(orDefault(duration, p.cfg.NoMsgTimeout))); ok {
+		t.Fatalf("unexpected message %#v", envelope.Message)
+	}
+}
+
+// ExpectTerminated expects the next message to be the termination of pid, which the probe watches
+func (p *TestProbe) ExpectTerminated(t testing.TB, pid *actor.PID, timeout time.Duration) {
+	t.Helper()
+	p.ExpectMsgMatching(t, func(message interface{}) bool {
+		terminated, ok := message.(*actor.Terminated)
+		return ok && terminated.Who.Equal(pid)
+	}, timeout)
+}
+
+// FishForMessage skips the messages until one matches pred within timeout, the default timeout when 0, and returns it
+func (p *TestProbe) FishForMessage(t testing.TB, pred func(message interface{}) bool, timeout time.Duration) interface{} {
+	t.Helper()
+	timeout = orDefault(timeout, p.cfg.Timeout)
+	deadline := time.Now().Add(timeout)
+	var skipped []string
+	for {
+		envelope, ok := p.next(deadline)
+		if !ok {
+			t.Fatalf("timeout (%v) while fishing for a message, skipped %v", timeout, skipped)
+			return nil
+		}
+		if pred(envelope.Message) {
+			return envelope.Message
+		}
+		skipped = append(skipped, fmt.Sprintf("%#v", envelope.Message))
+	}
+}
@@ -0,0 +1,141 @@
+package testkit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/AsynkronIT/protoactor-go/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var system = actor.NewActorSystem()
+
+// fakeT records the failures of the expectations rather than failing the test
+type fakeT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Fatalf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func TestTestProbe_ExpectMsg(t *testing.T) {
+	probe := NewTestProbe(system)
+	defer probe.Stop()
+
+	system.Root.Send(probe.PID(), "hello")
+	system.Root.Send(probe.PID(), &actor.PID{Id: "world"})
+	assert.Equal(t, "hello", probe.ExpectMsg(t, "hello", 0))
+	probe.ExpectMsgMatching(t, func(message interface{}) bool {
+		pid, ok := message.(*actor.PID)
+		return ok && pid.Id == "world"
+	}, time.Second)
+	probe.ExpectNoMsg(t, 10*time.Millisecond)
+}
+
+func TestTestProbe_Failures(t *testing.T) {
+	probe := NewTestProbe(system)
+	defer probe.Stop()
+	fake := &fakeT{}
+
+	assert.Nil(t, probe.ExpectMsg(fake, "hello", 10*time.Millisecond))
+	system.Root.Send(probe.PID(), "unexpected")
+	probe.ExpectMsg(fake, "hello", 0)
+	system.Root.Send(probe.PID(), "unexpected")
+	probe.ExpectNoMsg(fake, 0)
+
+	assert.Equal(t, []string{
+		"timeout (10ms) while waiting for a message",
+		`unexpected message "unexpected"`,
+		`unexpected message "unexpected"`,
+	}, fake.failures)
+}
+
+func TestTestProbe_SenderAndReply(t *testing.T) {
+	probe := NewTestProbe(system)
+	defer probe.Stop()
+	other := NewTestProbe(system)
+	defer other.Stop()
+
+	future := system.Root.RequestFuture(probe.PID(), "ping", time.Second)
+	probe.ExpectMsg(t, "ping", 0)
+	probe.Reply("pong")
+	res, err := future.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "pong", res)
+
+	probe.Send(other.PID(), "hi")
+	other.ExpectMsg(t, "hi", 0)
+	assert.Equal(t, probe.PID(), other.Sender())
+}
+
+func TestTestProbe_ExpectTerminated(t *testing.T) {
+	probe := NewTestProbe(system)
+	defer probe.Stop()
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+
+	probe.Watch(pid)
+	system.Root.Stop(pid)
+	probe.ExpectTerminated(t, pid, 0)
+}
+
+func TestTestProbe_FishForMessage(t *testing.T) {
+	probe := NewTestProbe(system)
+	defer probe.Stop()
+
+	for i := 0; i < 5; i++ {
+		system.Root.Send(probe.PID(), i)
+	}
+	assert.Equal(t, 3, probe.FishForMessage(t, func(message interface{}) bool {
+		return message == 3
+	}, 0))
+	probe.ExpectMsg(t, 4, 0)
+
+	fake := &fakeT{}
+	system.Root.Send(probe.PID(), 5)
+	probe.FishForMessage(fake, func(message interface{}) bool { return false }, 10*time.Millisecond)
+	assert.Equal(t, []string{"timeout (10ms) while fishing for a message, skipped [5]"}, fake.failures)
+}
+
+func TestTestProbe_Routee(t *testing.T) {
+	probes := []*TestProbe{NewTestProbe(system), NewTestProbe(system)}
+	for _, probe := range probes {
+		defer probe.Stop()
+	}
+	group := system.Root.Spawn(router.NewBroadcastGroup(probes[0].PID(), probes[1].PID()))
+	defer system.Root.Stop(group)
+
+	system.Root.Send(group, "broadcast")
+	for _, probe := range probes {
+		probe.ExpectMsg(t, "broadcast", 0)
+	}
+}
+
+func TestTestProbe_RemoteTarget(t *testing.T) {
+	network := remotetest.NewNetwork()
+	nodes := make([]*actor.ActorSystem, 2)
+	for i := range nodes {
+		nodes[i] = actor.NewActorSystem()
+		r := remote.NewRemote(nodes[i], remote.Configure(fmt.Sprint("node", i), 0).WithTransport(network.Transport()))
+		r.Start()
+		defer r.Shutdown(false)
+	}
+	probe := NewTestProbe(nodes[1])
+	defer probe.Stop()
+
+	future := nodes[0].Root.RequestFuture(probe.PID(), &remote.ActorPidRequest{Name: "a"}, time.Second)
+	probe.ExpectMsg(t, &remote.ActorPidRequest{Name: "a"}, 0)
+	assert.Equal(t, nodes[0].Address(), probe.Sender().Address)
+	probe.Reply(&remote.ActorPidResponse{StatusCode: 1})
+	res, err := future.Result()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), res.(*remote.ActorPidResponse).StatusCode)
+}
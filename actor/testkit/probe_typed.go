@@ -0,0 +1,19 @@
+//go:build go1.18
+
+package testkit
+
+import (
+	"testing"
+	"time"
+)
+
+// ExpectMsg expects the next message of probe to be a T within timeout, the default timeout when 0, and returns it
+func ExpectMsg[T any](t testing.TB, probe *TestProbe, timeout time.Duration) T {
+	t.Helper()
+	message := probe.ExpectMsgMatching(t, func(message interface{}) bool {
+		_, ok := message.(T)
+		return ok
+	}, timeout)
+	typed, _ := message.(T)
+	return typed
+}
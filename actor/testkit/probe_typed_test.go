@@ -0,0 +1,23 @@
+//go:build go1.18
+
+package testkit
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectMsg(t *testing.T) {
+	probe := NewTestProbe(system)
+	defer probe.Stop()
+
+	system.Root.Send(probe.PID(), &actor.PID{Id: "a"})
+	assert.Equal(t, "a", ExpectMsg[*actor.PID](t, probe, 0).Id)
+
+	fake := &fakeT{}
+	system.Root.Send(probe.PID(), "b")
+	assert.Nil(t, ExpectMsg[*actor.PID](fake, probe, 0))
+	assert.Equal(t, []string{`unexpected message "b"`}, fake.failures)
+}
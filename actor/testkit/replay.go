@@ -0,0 +1,303 @@
+package testkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/middleware/audit"
+	"github.com/AsynkronIT/protoactor-go/mailbox"
+)
+
+// Replay feeds the messages an actor received, as recorded by the audit middleware with their payloads, to a new
+// incarnation of the actor, to reproduce its behavior. The messages are processed synchronously in the order they
+// were recorded, on a ManualClock set to the time they were recorded at. The messages the actor sends are captured
+// rather than delivered, the senders of the requests recorded are replaced by probes which do receive them
+type Replay struct {
+	props *actor.Props
+	types map[string]reflect.Type
+	actor string
+
+	system  *actor.ActorSystem
+	clock   *ManualClock
+	mailbox *replayMailbox
+	pid     *actor.PID
+	probes  map[string]*TestProbe
+
+	mu   sync.Mutex
+	sent []*SentMessage
+}
+
+// SentMessage is a message the actor replayed sent
+type SentMessage struct {
+	Target  *actor.PID
+	Sender  *actor.PID
+	Header  map[string]string
+	Message interface{}
+}
+
+// ReplayError is returned when the actor replayed fails processing a record
+type ReplayError struct {
+	// Index is the index of the record in the records replayed
+	Index  int
+	Record audit.Record
+	Reason interface{}
+}
+
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf("testkit: replay of record %d (%s) failed: %v", e.Index, e.Record.MessageType, e.Reason)
+}
+
+// ReplayOption configures a Replay
+type ReplayOption func(*Replay)
+
+// WithReplayTypes decodes the payloads recorded for the types of messages, which are e.g. &Deposit{}
+func WithReplayTypes(messages ...interface{}) ReplayOption {
+	return func(r *Replay) {
+		for _, message := range messages {
+			r.types[fmt.Sprintf("%T", message)] = reflect.TypeOf(message)
+		}
+	}
+}
+
+// WithReplayedActor replays the messages received by the actor recorded as name, e.g. "nonhost/account". The
+// messages of the actor of the first record received are replayed by default
+func WithReplayedActor(name string) ReplayOption {
+	return func(r *Replay) {
+		r.actor = name
+	}
+}
+
+// NewReplay replays the records to an actor spawned from props, once Run
+func NewReplay(props *actor.Props, opts ...ReplayOption) *Replay {
+	r := &Replay{
+		types:   make(map[string]reflect.Type),
+		clock:   NewManualClock(time.Time{}),
+		mailbox: &replayMailbox{},
+		probes:  make(map[string]*TestProbe),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	config := actor.NewConfig()
+	config.Clock = r.clock
+	r.system = actor.NewActorSystemWithConfig(config)
+	r.props = props.Clone().
+		WithMailbox(func() mailbox.Mailbox { return r.mailbox }).
+		WithSenderMiddleware(r.capture)
+	return r
+}
+
+// RunJSONL replays the records of a JSONL audit sink
+func (r *Replay) RunJSONL(reader io.Reader) error {
+	records, err := audit.ReadJSONL(reader)
+	if err != nil {
+		return err
+	}
+	return r.Run(records)
+}
+
+// Run replays the messages received in records, it stops at the first record the actor fails to process and
+// returns a ReplayError. It can be run again to replay more records to the same incarnation
+func (r *Replay) Run(records []audit.Record) error {
+	for i, record := range records {
+		if record.Direction != audit.Received {
+			continue
+		}
+		if r.actor == "" {
+			r.actor = record.Actor
+		}
+		if record.Actor != r.actor {
+			continue
+		}
+		message, err := r.decode(record)
+		if err != nil {
+			return &ReplayError{Index: i, Record: record, Reason: err}
+		}
+		if r.pid == nil {
+			r.start(record)
+		}
+		if record.Time.After(r.clock.Now()) {
+			r.clock.Advance(record.Time.Sub(r.clock.Now()))
+		}
+
+		envelope := &actor.MessageEnvelope{Message: message}
+		for k, v := range record.Header {
+			envelope.SetHeader(k, v)
+		}
+		if record.Sender != "" {
+			envelope.Sender = r.Probe(record.Sender).PID()
+		}
+		if reason := r.mailbox.invoke(envelope); reason != nil {
+			return &ReplayError{Index: i, Record: record, Reason: reason}
+		}
+	}
+	return nil
+}
+
+func (r *Replay) decode(record audit.Record) (interface{}, error) {
+	typ, ok := r.types[record.MessageType]
+	if !ok {
+		return nil, fmt.Errorf("message type %s not registered", record.MessageType)
+	}
+	if record.Payload == "" {
+		return nil, errors.New("payload not recorded")
+	}
+	if record.Truncated {
+		return nil, errors.New("payload truncated")
+	}
+	if typ.Kind() == reflect.Ptr {
+		message := reflect.New(typ.Elem())
+		if err := json.Unmarshal([]byte(record.Payload), message.Interface()); err != nil {
+			return nil, err
+		}
+		return message.Interface(), nil
+	}
+	message := reflect.New(typ)
+	if err := json.Unmarshal([]byte(record.Payload), message.Interface()); err != nil {
+		return nil, err
+	}
+	return message.Elem().Interface(), nil
+}
+
+// start spawns the actor once the clock is set to the time of the first record
+func (r *Replay) start(first audit.Record) {
+	r.clock.set(first.Time)
+	r.pid = r.system.Root.Spawn(r.props)
+	r.mailbox.invoke(nil)
+}
+
+// capture is the sender middleware of the actor replayed, only the messages to the probes are delivered
+func (r *Replay) capture(next actor.SenderFunc) actor.SenderFunc {
+	return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+		sent := &SentMessage{Target: target, Sender: envelope.Sender, Message: envelope.Message}
+		if len(envelope.Header) > 0 {
+			sent.Header = envelope.Header.ToMap()
+		}
+		r.mu.Lock()
+		r.sent = append(r.sent, sent)
+		r.mu.Unlock()
+		if r.isProbe(target) {
+			next(c, target, envelope)
+		}
+	}
+}
+
+func (r *Replay) isProbe(pid *actor.PID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, probe := range r.probes {
+		if probe.PID().Equal(pid) {
+			return true
+		}
+	}
+	return false
+}
+
+// Probe returns the probe standing for the sender recorded, e.g. "nonhost/client"
+func (r *Replay) Probe(sender string) *TestProbe {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	probe, ok := r.probes[sender]
+	if !ok {
+		probe = NewTestProbe(r.system)
+		r.probes[sender] = probe
+	}
+	return probe
+}
+
+// Actor returns the incarnation of the actor replayed, nil until it is run
+func (r *Replay) Actor() actor.Actor {
+	if r.pid == nil {
+		return nil
+	}
+	return r.mailbox.invoker.(actor.Context).Actor()
+}
+
+// Sent returns the messages the actor sent, responded or forwarded, in order
+func (r *Replay) Sent() []*SentMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*SentMessage(nil), r.sent...)
+}
+
+// Posted returns the messages posted to the actor during the replay, e.g. by its timers, they are not processed
+// since the recording has the ones it received
+func (r *Replay) Posted() []interface{} {
+	r.mailbox.mu.Lock()
+	defer r.mailbox.mu.Unlock()
+	return append([]interface{}(nil), r.mailbox.posted...)
+}
+
+// Stop stops the actor replayed and the probes
+func (r *Replay) Stop() {
+	if r.pid != nil {
+		r.system.Root.Stop(r.pid)
+		r.mailbox.invoke(nil)
+	}
+	for _, probe := range r.probes {
+		probe.Stop()
+	}
+}
+
+// replayMailbox hands the messages to its invoker on the goroutine of the replay
+type replayMailbox struct {
+	invoker mailbox.MessageInvoker
+
+	mu     sync.Mutex
+	system []interface{}
+	posted []interface{}
+}
+
+func (m *replayMailbox) PostUserMessage(message interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.posted = append(m.posted, message)
+}
+
+func (m *replayMailbox) PostSystemMessage(message interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.system = append(m.system, message)
+}
+
+func (m *replayMailbox) RegisterHandlers(invoker mailbox.MessageInvoker, dispatcher mailbox.Dispatcher) {
+	m.invoker = invoker
+}
+
+func (m *replayMailbox) Start() {}
+
+// invoke processes the system messages posted, then message if not nil and the system messages it posted. It
+// returns the reason of the failure of the actor
+func (m *replayMailbox) invoke(message interface{}) (reason interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			reason = r
+		}
+	}()
+	m.invokeSystem()
+	if message != nil {
+		m.invoker.InvokeUserMessage(message)
+		m.invokeSystem()
+	}
+	return nil
+}
+
+func (m *replayMailbox) invokeSystem() {
+	for {
+		m.mu.Lock()
+		if len(m.system) == 0 {
+			m.mu.Unlock()
+			return
+		}
+		message := m.system[0]
+		m.system = m.system[1:]
+		m.mu.Unlock()
+		m.invoker.InvokeSystemMessage(message)
+	}
+}
@@ -0,0 +1,149 @@
+package testkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/middleware/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deposit struct {
+	Amount int
+}
+
+type withdraw struct {
+	Amount int
+}
+
+type balance struct {
+	Amount int
+}
+
+type insufficientFunds struct {
+	Missing int
+}
+
+// account has the bug to reproduce: it panics on the negative withdrawals
+type account struct {
+	balance      int
+	lastActivity time.Time
+}
+
+func (a *account) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *deposit:
+		a.balance += msg.Amount
+	case *withdraw:
+		if msg.Amount < 0 {
+			panic("negative withdrawal")
+		}
+		if msg.Amount > a.balance {
+			ctx.Respond(&insufficientFunds{Missing: msg.Amount - a.balance})
+			return
+		}
+		a.balance -= msg.Amount
+	default:
+		return
+	}
+	a.lastActivity = ctx.ActorSystem().Clock().Now()
+	ctx.Respond(&balance{Amount: a.balance})
+}
+
+func accountProps() *actor.Props {
+	return actor.PropsFromProducer(func() actor.Actor { return &account{} })
+}
+
+// recordAccount records the messages received by an account in production
+func recordAccount(t *testing.T) (path string, client string) {
+	path = filepath.Join(t.TempDir(), "account.jsonl")
+	sink, err := audit.OpenJSONLFile(path)
+	require.NoError(t, err)
+	defer sink.Close()
+	system := actor.NewActorSystem()
+	auditor := audit.New(sink, audit.WithEnabled(true), audit.WithPayloads(1024))
+	pid, err := system.Root.SpawnNamed(auditor.WithAudit(accountProps()), "account")
+	require.NoError(t, err)
+	probe := NewTestProbe(system)
+	defer probe.Stop()
+
+	probe.Send(pid, &deposit{Amount: 100})
+	probe.ExpectMsg(t, &balance{Amount: 100}, 0)
+	time.Sleep(10 * time.Millisecond)
+	probe.Send(pid, &withdraw{Amount: 30})
+	probe.ExpectMsg(t, &balance{Amount: 70}, 0)
+	probe.Send(pid, &withdraw{Amount: 500})
+	probe.ExpectMsg(t, &insufficientFunds{Missing: 430}, 0)
+	probe.Send(pid, &withdraw{Amount: -1})
+	// the account restarted
+	probe.Send(pid, &deposit{Amount: 5})
+	probe.ExpectMsg(t, &balance{Amount: 5}, 0)
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	return path, probe.PID().String()
+}
+
+func TestReplay_RecordedAccount(t *testing.T) {
+	path, client := recordAccount(t)
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	records, err := audit.ReadJSONL(file)
+	require.NoError(t, err)
+
+	replay := NewReplay(accountProps(), WithReplayTypes(&deposit{}, &withdraw{}))
+	defer replay.Stop()
+	err = replay.Run(records)
+
+	// the bug is reproduced on the fourth message received
+	var failure *ReplayError
+	require.True(t, errors.As(err, &failure))
+	assert.Equal(t, "negative withdrawal", failure.Reason)
+	assert.Equal(t, "*testkit.withdraw", failure.Record.MessageType)
+	assert.Equal(t, `{"Amount":-1}`, failure.Record.Payload)
+
+	// the state of the account before the failure, at the time it was recorded
+	state := replay.Actor().(*account)
+	assert.Equal(t, 70, state.balance)
+	var received []audit.Record
+	for _, record := range records {
+		if record.Direction == audit.Received {
+			received = append(received, record)
+		}
+	}
+	require.Len(t, received, 5)
+	assert.Equal(t, received[3], failure.Record)
+	assert.True(t, received[1].Time.Equal(state.lastActivity), "the replay runs on the clock of the recording")
+
+	// the responses went to the probe standing for the client
+	probe := replay.Probe(client)
+	probe.ExpectMsg(t, &balance{Amount: 100}, 0)
+	probe.ExpectMsg(t, &balance{Amount: 70}, 0)
+	probe.ExpectMsg(t, &insufficientFunds{Missing: 430}, 0)
+	sent := replay.Sent()
+	require.Len(t, sent, 3)
+	assert.Equal(t, probe.PID(), sent[0].Target)
+	assert.Equal(t, &balance{Amount: 100}, sent[0].Message)
+}
+
+func TestReplay_UndecodableRecords(t *testing.T) {
+	replay := NewReplay(accountProps(), WithReplayTypes(&deposit{}))
+	defer replay.Stop()
+
+	for expected, record := range map[string]audit.Record{
+		"message type *testkit.withdraw not registered": {MessageType: "*testkit.withdraw", Payload: `{"Amount":1}`},
+		"payload not recorded":                          {MessageType: "*testkit.deposit"},
+		"payload truncated":                             {MessageType: "*testkit.deposit", Payload: `{"Amo`, Truncated: true},
+	} {
+		record.Direction, record.Actor = audit.Received, "nonhost/account"
+		err := replay.Run([]audit.Record{record})
+		var failure *ReplayError
+		require.True(t, errors.As(err, &failure), expected)
+		assert.Equal(t, expected, failure.Reason.(error).Error())
+	}
+	assert.Nil(t, replay.Actor(), "the actor is not spawned until a record is replayed")
+}
@@ -0,0 +1,60 @@
+package actor
+
+import "sync/atomic"
+
+// WarmupActor actors are warmed up by Warmup before they receive Started, e.g. to load their model or open their
+// pools. It runs in the background for the actors spawned with warm standby, concurrently with the other actors and
+// before the actor has a Context: it must only initialize the actor itself, without the state of other actors
+type WarmupActor interface {
+	Warmup()
+}
+
+// standbyPool keeps incarnations of the actors of a props ready, see Props.WithWarmStandby
+type standbyPool struct {
+	size    int
+	actors  chan Actor
+	filling int32
+}
+
+func newStandbyPool(size int) *standbyPool {
+	return &standbyPool{size: size, actors: make(chan Actor, size)}
+}
+
+// get returns an incarnation from the pool, or a new one when the pool is empty, and replenishes the pool
+func (p *standbyPool) get(producer Producer) Actor {
+	var actor Actor
+	select {
+	case actor = <-p.actors:
+	default:
+		actor = incarnate(producer)
+	}
+	p.fill(producer)
+	return actor
+}
+
+// fill replenishes the pool in the background unless it is being replenished already
+func (p *standbyPool) fill(producer Producer) {
+	if !atomic.CompareAndSwapInt32(&p.filling, 0, 1) {
+		return
+	}
+	go func() {
+		for {
+			for len(p.actors) < p.size {
+				p.actors <- incarnate(producer)
+			}
+			atomic.StoreInt32(&p.filling, 0)
+			// an incarnation taken meanwhile, and not replenished as the pool was being replenished
+			if len(p.actors) == p.size || !atomic.CompareAndSwapInt32(&p.filling, 0, 1) {
+				return
+			}
+		}
+	}()
+}
+
+func incarnate(producer Producer) Actor {
+	actor := producer()
+	if warmup, ok := actor.(WarmupActor); ok {
+		warmup.Warmup()
+	}
+	return actor
+}
@@ -0,0 +1,89 @@
+package actor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const warmupTime = 100 * time.Millisecond
+
+// slowStarter takes warmupTime to warm up
+type slowStarter struct {
+	warm    bool
+	warmups *int32
+}
+
+func (a *slowStarter) Warmup() {
+	time.Sleep(warmupTime)
+	atomic.AddInt32(a.warmups, 1)
+	a.warm = true
+}
+
+func (a *slowStarter) Receive(ctx Context) {
+	switch ctx.Message() {
+	case "fail":
+		panic("fail")
+	case "ping":
+		ctx.Respond(a.warm)
+	}
+}
+
+func slowStarterProps() (*Props, *int32) {
+	var warmups int32
+	return PropsFromProducer(func() Actor { return &slowStarter{warmups: &warmups} }), &warmups
+}
+
+// restartLatency returns how long pid takes to answer once it failed
+func restartLatency(t *testing.T, pid *PID) time.Duration {
+	start := time.Now()
+	rootContext.Send(pid, "fail")
+	res, err := rootContext.RequestFuture(pid, "ping", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, true, res, "the actor is warmed up")
+	return time.Since(start)
+}
+
+func TestWarmStandby_RestartLatency(t *testing.T) {
+	cold, _ := slowStarterProps()
+	pid := rootContext.Spawn(cold)
+	defer func() { _ = rootContext.StopFuture(pid).Wait() }()
+	assert.GreaterOrEqual(t, int64(restartLatency(t, pid)), int64(warmupTime))
+
+	warm, warmups := slowStarterProps()
+	warm.WithWarmStandby(2)
+	pid = rootContext.Spawn(warm)
+	defer func() { _ = rootContext.StopFuture(pid).Wait() }()
+	require.Eventually(t, func() bool { return len(warm.standby.actors) == 2 }, time.Second, time.Millisecond)
+	for i := 0; i < 3; i++ {
+		assert.Less(t, int64(restartLatency(t, pid)), int64(warmupTime/2))
+		require.Eventually(t, func() bool { return len(warm.standby.actors) == 2 }, time.Second, time.Millisecond, "replenished")
+	}
+	assert.Equal(t, int32(6), atomic.LoadInt32(warmups), "the first actor and its restarts, with the standby")
+}
+
+func TestWarmStandby_SpawnsFromStandby(t *testing.T) {
+	props, warmups := slowStarterProps()
+	props.WithWarmStandby(3)
+	first := rootContext.Spawn(props)
+	defer func() { _ = rootContext.StopFuture(first).Wait() }()
+	require.Eventually(t, func() bool { return len(props.standby.actors) == 3 }, time.Second, time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		pid := rootContext.Spawn(props)
+		defer func() { _ = rootContext.StopFuture(pid).Wait() }()
+		res, err := rootContext.RequestFuture(pid, "ping", time.Second).Result()
+		require.NoError(t, err)
+		assert.Equal(t, true, res)
+	}
+	assert.Less(t, int64(time.Since(start)), int64(warmupTime))
+
+	clone := props.Clone()
+	assert.NotSame(t, props.standby, clone.standby, "the clone has its own standby")
+	assert.Empty(t, clone.standby.actors)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(warmups) == 7 }, time.Second, time.Millisecond)
+}
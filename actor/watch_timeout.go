@@ -0,0 +1,147 @@
+package actor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+)
+
+// WatchTimedOut is received by an actor which watched Who with WatchWithTimeout, when neither the Terminated of Who
+// nor a sign of life from it arrived in time. Who is unwatched
+type WatchTimedOut struct {
+	Who *PID
+}
+
+// watchTimeoutTick checks a timed watch once its timer fired
+type watchTimeoutTick struct {
+	timer *watchTimer
+	seq   int
+}
+
+func (*watchTimeoutTick) SystemMessage() {}
+
+// watchTimer is the timeout of a watch, it is rearmed for the rest of the timeout when the watched actor showed
+// signs of life meanwhile
+type watchTimer struct {
+	who      *PID
+	timeout  time.Duration
+	lastSeen time.Time
+	seq      int // the ticks of the previous arms are ignored
+	cancel   func()
+}
+
+// watchTimeouts are the timed watches of an actor, their signs of life are recorded from the event stream
+type watchTimeouts struct {
+	mu     sync.Mutex
+	timers map[pidKey]*watchTimer // by PID
+	events *eventstream.EventStream
+	sub    *eventstream.Subscription
+}
+
+func (ctx *actorContext) WatchWithTimeout(who *PID, d time.Duration) {
+	ctx.Watch(who)
+	extras := ctx.ensureExtras()
+	if extras.watchTimeouts == nil {
+		extras.watchTimeouts = &watchTimeouts{timers: make(map[pidKey]*watchTimer), events: ctx.actorSystem.SystemEventStream}
+	}
+	w := extras.watchTimeouts
+
+	w.mu.Lock()
+	key := keyOf(who)
+	if t, ok := w.timers[key]; ok {
+		t.cancel()
+	}
+	t := &watchTimer{who: who, timeout: d, lastSeen: ctx.actorSystem.Config.Clock.Now()}
+	w.timers[key] = t
+	ctx.armWatchTimer(t, d)
+	w.mu.Unlock()
+
+	// the subscription is changed on the actor goroutine only, without the lock the heartbeats take
+	if w.sub == nil {
+		clock := ctx.actorSystem.Config.Clock
+		w.sub = w.events.Subscribe(func(evt interface{}) {
+			if beat, ok := evt.(*ActorHeartbeat); ok {
+				w.alive(beat.PID, clock.Now())
+			}
+		}).WithPredicate(func(evt interface{}) bool {
+			_, ok := evt.(*ActorHeartbeat)
+			return ok
+		})
+	}
+}
+
+// armWatchTimer checks t after d, the lock of the watch timeouts is held
+func (ctx *actorContext) armWatchTimer(t *watchTimer, d time.Duration) {
+	t.seq++
+	tick := &watchTimeoutTick{timer: t, seq: t.seq}
+	self, system := ctx.self, ctx.actorSystem
+	t.cancel = system.timers.Schedule(d, 0, false, func() {
+		self.sendSystemMessage(system, tick)
+	})
+}
+
+func (ctx *actorContext) handleWatchTimeoutTick(tick *watchTimeoutTick) {
+	if ctx.extras == nil || ctx.extras.watchTimeouts == nil {
+		return
+	}
+	w := ctx.extras.watchTimeouts
+	t := tick.timer
+	w.mu.Lock()
+	if w.timers[keyOf(t.who)] != t || t.seq != tick.seq {
+		// cancelled or rearmed meanwhile
+		w.mu.Unlock()
+		return
+	}
+	if silence := ctx.actorSystem.Config.Clock.Now().Sub(t.lastSeen); silence < t.timeout {
+		ctx.armWatchTimer(t, t.timeout-silence)
+		w.mu.Unlock()
+		return
+	}
+	w.mu.Unlock()
+
+	ctx.Unwatch(t.who)
+	ctx.InvokeUserMessage(&WatchTimedOut{Who: t.who})
+}
+
+// alive records a sign of life of who, if it is watched with a timeout
+func (w *watchTimeouts) alive(who *PID, now time.Time) {
+	w.mu.Lock()
+	if t, ok := w.timers[keyOf(who)]; ok {
+		t.lastSeen = now
+	}
+	w.mu.Unlock()
+}
+
+// remove cancels the timeout of the watch of who, if any
+func (w *watchTimeouts) remove(who *PID) {
+	w.mu.Lock()
+	key := keyOf(who)
+	if t, ok := w.timers[key]; ok {
+		t.cancel()
+		delete(w.timers, key)
+	}
+	empty := len(w.timers) == 0
+	w.mu.Unlock()
+	if empty {
+		w.unsubscribe()
+	}
+}
+
+// clear cancels the timeouts, the watches remain
+func (w *watchTimeouts) clear() {
+	w.mu.Lock()
+	for key, t := range w.timers {
+		t.cancel()
+		delete(w.timers, key)
+	}
+	w.mu.Unlock()
+	w.unsubscribe()
+}
+
+func (w *watchTimeouts) unsubscribe() {
+	if w.sub != nil {
+		w.events.Unsubscribe(w.sub)
+		w.sub = nil
+	}
+}
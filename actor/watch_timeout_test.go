@@ -0,0 +1,141 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchFor struct {
+	who *PID
+	d   time.Duration
+}
+
+type watcherFail struct{}
+
+// timedWatcher watches with a timeout and reports the outcome
+func timedWatcher(events chan interface{}) *Props {
+	return PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case *watchFor:
+			ctx.WatchWithTimeout(msg.who, msg.d)
+		case *watcherFail:
+			panic("boom")
+		case *WatchTimedOut, *Terminated, string:
+			events <- msg
+		}
+	})
+}
+
+func idleActor() *PID {
+	return rootContext.Spawn(PropsFromFunc(func(ctx Context) {}))
+}
+
+func expectNoEvent(t *testing.T, events chan interface{}, d time.Duration) {
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected %#v", e)
+	case <-time.After(d):
+	}
+}
+
+func TestWatchWithTimeout_TimesOut(t *testing.T) {
+	events := make(chan interface{}, 10)
+	target := idleActor()
+	watcher := rootContext.Spawn(timedWatcher(events))
+	defer rootContext.Stop(watcher)
+
+	rootContext.Send(watcher, &watchFor{who: target, d: 30 * time.Millisecond})
+	assert.Equal(t, &WatchTimedOut{Who: target}, nextEvent(t, events))
+
+	// the target was unwatched
+	require.NoError(t, rootContext.StopFuture(target).Wait())
+	expectNoEvent(t, events, 30*time.Millisecond)
+}
+
+func TestWatchWithTimeout_CancelledByTerminated(t *testing.T) {
+	events := make(chan interface{}, 10)
+	target := idleActor()
+	watcher := rootContext.Spawn(timedWatcher(events))
+	defer rootContext.Stop(watcher)
+
+	rootContext.Send(watcher, &watchFor{who: target, d: 50 * time.Millisecond})
+	rootContext.Stop(target)
+	terminated, ok := nextEvent(t, events).(*Terminated)
+	require.True(t, ok)
+	assert.Equal(t, target, terminated.Who)
+	expectNoEvent(t, events, 80*time.Millisecond)
+}
+
+func TestWatchWithTimeout_SignsOfLife(t *testing.T) {
+	events := make(chan interface{}, 100)
+	watcher := rootContext.Spawn(timedWatcher(events))
+	defer rootContext.Stop(watcher)
+
+	// the target sends messages to the watcher for a while
+	stop := make(chan struct{})
+	target := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			go func() {
+				for {
+					select {
+					case <-stop:
+						return
+					case <-time.After(10 * time.Millisecond):
+						rootContext.RequestWithCustomSender(watcher, "alive", ctx.Self())
+					}
+				}
+			}()
+		}
+	}))
+	defer rootContext.Stop(target)
+
+	rootContext.Send(watcher, &watchFor{who: target, d: 40 * time.Millisecond})
+	deadline := time.After(120 * time.Millisecond)
+	for done := false; !done; {
+		select {
+		case e := <-events:
+			require.Equal(t, "alive", e)
+		case <-deadline:
+			done = true
+		}
+	}
+
+	close(stop)
+	for {
+		if e := nextEvent(t, events); e != "alive" {
+			assert.Equal(t, &WatchTimedOut{Who: target}, e)
+			return
+		}
+	}
+}
+
+func TestWatchWithTimeout_Heartbeats(t *testing.T) {
+	events := make(chan interface{}, 10)
+	target := rootContext.Spawn(PropsFromFunc(func(ctx Context) {}).WithLiveness(10*time.Millisecond, time.Second))
+	defer rootContext.Stop(target)
+	watcher := rootContext.Spawn(timedWatcher(events))
+	defer rootContext.Stop(watcher)
+
+	rootContext.Send(watcher, &watchFor{who: target, d: 40 * time.Millisecond})
+	expectNoEvent(t, events, 120*time.Millisecond)
+}
+
+func TestWatchWithTimeout_ClearedOnRestart(t *testing.T) {
+	events := make(chan interface{}, 10)
+	target := idleActor()
+	watcher := rootContext.Spawn(timedWatcher(events))
+	defer rootContext.Stop(watcher)
+
+	rootContext.Send(watcher, &watchFor{who: target, d: 30 * time.Millisecond})
+	rootContext.Send(watcher, &watcherFail{})
+	expectNoEvent(t, events, 80*time.Millisecond)
+
+	// the watch survived the restart
+	rootContext.Stop(target)
+	terminated, ok := nextEvent(t, events).(*Terminated)
+	require.True(t, ok)
+	assert.Equal(t, target, terminated.Who)
+}
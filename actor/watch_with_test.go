@@ -0,0 +1,103 @@
+package actor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+)
+
+type (
+	watchWith struct {
+		pid     *actor.PID
+		message interface{}
+	}
+	watchPlain   struct{ pid *actor.PID }
+	unwatchPlain struct{ pid *actor.PID }
+	failWatcher  struct{}
+	serviceDown  struct{ Name string }
+)
+
+// spawnWatcher spawns a watcher forwarding what it receives to the probe, it acknowledges its watch commands
+func spawnWatcher(system *actor.ActorSystem, probe *testkit.TestProbe) *actor.PID {
+	return system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started, *actor.Stopping, *actor.Stopped, *actor.Restarting:
+		case *watchWith:
+			ctx.WatchWith(msg.pid, msg.message)
+			ctx.Respond(true)
+		case *watchPlain:
+			ctx.Watch(msg.pid)
+			ctx.Respond(true)
+		case *unwatchPlain:
+			ctx.Unwatch(msg.pid)
+			ctx.Respond(true)
+		case *failWatcher:
+			panic("failed")
+		default:
+			ctx.Send(probe.PID(), msg)
+		}
+	}))
+}
+
+func command(t *testing.T, system *actor.ActorSystem, pid *actor.PID, message interface{}) {
+	if _, err := system.Root.RequestFuture(pid, message, time.Second).Result(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func spawnIdle(system *actor.ActorSystem) *actor.PID {
+	return system.Root.Spawn(actor.PropsFromFunc(func(actor.Context) {}))
+}
+
+func TestWatchWith_DistinctPIDs(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	watcher := spawnWatcher(system, probe)
+	defer func() { _ = system.Root.StopFuture(watcher).Wait() }()
+	cache, db, plain := spawnIdle(system), spawnIdle(system), spawnIdle(system)
+
+	command(t, system, watcher, &watchWith{pid: cache, message: "cache down"})
+	command(t, system, watcher, &watchWith{pid: db, message: &serviceDown{Name: "db"}})
+	command(t, system, watcher, &watchPlain{pid: plain})
+
+	_ = system.Root.StopFuture(db).Wait()
+	probe.ExpectMsg(t, &serviceDown{Name: "db"}, 0)
+	_ = system.Root.StopFuture(cache).Wait()
+	probe.ExpectMsg(t, "cache down", 0)
+	_ = system.Root.StopFuture(plain).Wait()
+	probe.ExpectMsg(t, &actor.Terminated{Who: plain}, 0)
+}
+
+func TestWatchWith_UnwatchClearsMessage(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	watcher := spawnWatcher(system, probe)
+	defer func() { _ = system.Root.StopFuture(watcher).Wait() }()
+	pid := spawnIdle(system)
+
+	command(t, system, watcher, &watchWith{pid: pid, message: "down"})
+	command(t, system, watcher, &unwatchPlain{pid: pid})
+	command(t, system, watcher, &watchPlain{pid: pid})
+
+	_ = system.Root.StopFuture(pid).Wait()
+	probe.ExpectMsg(t, &actor.Terminated{Who: pid}, 0)
+}
+
+func TestWatchWith_SurvivesRestart(t *testing.T) {
+	system := actor.NewActorSystem()
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
+	watcher := spawnWatcher(system, probe)
+	defer func() { _ = system.Root.StopFuture(watcher).Wait() }()
+	pid := spawnIdle(system)
+
+	command(t, system, watcher, &watchWith{pid: pid, message: "down"})
+	system.Root.Send(watcher, &failWatcher{})
+
+	_ = system.Root.StopFuture(pid).Wait()
+	probe.ExpectMsg(t, "down", 0)
+}
@@ -0,0 +1,91 @@
+package actor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// WatcherLimitExceeded is published on the system event stream when an actor has more watchers than the limit of
+// its props, see Props.WithWatcherLimit. It is published again once the watchers went back within the limit
+type WatcherLimitExceeded struct {
+	PID      *PID
+	Watchers int
+	Limit    int
+}
+
+// WatcherInfo are the watches of an actor when inspected
+type WatcherInfo struct {
+	PID *PID
+	// WatcherCount is the number of actors watching the actor, Watchers are the first of them
+	WatcherCount int
+	Watchers     []*PID
+	// WatchingCount is the number of actors the actor watches
+	WatchingCount int
+}
+
+// ErrInspectTimeout is returned when an inspected actor does not answer in time
+var ErrInspectTimeout = errors.New("actor: inspect timed out")
+
+// inspectWatchers asks an actor for its WatcherInfo, it is answered between two messages
+type inspectWatchers struct {
+	maxWatchers int
+	reply       chan *WatcherInfo
+}
+
+func (*inspectWatchers) SystemMessage() {}
+
+// InspectWatchers returns the watches of the local actor pid, with the PIDs of maxWatchers of its watchers at most.
+// The actor answers between two of its messages, ErrInspectTimeout is returned when it does not answer within timeout
+func (as *ActorSystem) InspectWatchers(pid *PID, maxWatchers int, timeout time.Duration) (*WatcherInfo, error) {
+	process, ok := as.ProcessRegistry.GetLocal(pid.Id)
+	if !ok {
+		return nil, ErrNotLocalActor
+	}
+	actorProcess, ok := process.(*ActorProcess)
+	if !ok {
+		return nil, ErrNotLocalActor
+	}
+
+	request := &inspectWatchers{maxWatchers: maxWatchers, reply: make(chan *WatcherInfo, 1)}
+	actorProcess.SendSystemMessage(pid, request)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case info := <-request.reply:
+		return info, nil
+	case <-timer.C:
+		return nil, ErrInspectTimeout
+	}
+}
+
+func (ctx *actorContext) inspectWatchers(maxWatchers int) *WatcherInfo {
+	info := &WatcherInfo{PID: ctx.self}
+	if ctx.extras == nil {
+		return info
+	}
+	info.WatcherCount = ctx.extras.watchers.Len()
+	info.WatchingCount = ctx.extras.watching.Len()
+	for i := 0; i < info.WatcherCount && i < maxWatchers; i++ {
+		info.Watchers = append(info.Watchers, ctx.extras.watchers.Get(i))
+	}
+	return info
+}
+
+// checkWatcherLimit publishes WatcherLimitExceeded when the watchers of the actor cross the limit of its props
+func (ctx *actorContext) checkWatcherLimit() {
+	limit := ctx.props.watcherLimit
+	if limit <= 0 {
+		return
+	}
+	watchers := ctx.extras.watchers.Len()
+	switch {
+	case watchers <= limit:
+		ctx.extras.watcherLimitReached = false
+	case !ctx.extras.watcherLimitReached:
+		ctx.extras.watcherLimitReached = true
+		plog.Info("actor watched by more actors than its limit", log.Stringer("pid", ctx.self), log.Int("watchers", watchers), log.Int("limit", limit))
+		ctx.actorSystem.SystemEventStream.Publish(&WatcherLimitExceeded{PID: ctx.self, Watchers: watchers, Limit: limit})
+	}
+}
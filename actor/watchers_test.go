@@ -0,0 +1,118 @@
+package actor
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watcherCount returns the number of watchers of pid
+func watcherCount(t *testing.T, system *ActorSystem, pid *PID) int {
+	info, err := system.InspectWatchers(pid, 0, time.Second)
+	require.NoError(t, err)
+	return info.WatcherCount
+}
+
+func TestWatcherLimit_PublishedWhenCrossed(t *testing.T) {
+	system := NewActorSystem()
+	events := make(chan *WatcherLimitExceeded, 10)
+	system.EventStream.Subscribe(func(msg interface{}) {
+		if e, ok := msg.(*WatcherLimitExceeded); ok {
+			events <- e
+		}
+	})
+	watched := system.Root.Spawn(PropsFromFunc(func(ctx Context) {}).WithWatcherLimit(2))
+	watchers := make([]*PID, 4)
+	for i := range watchers {
+		watchers[i] = system.NewLocalPID("watcher" + strconv.Itoa(i))
+		watched.sendSystemMessage(system, &Watch{Watcher: watchers[i]})
+	}
+	require.Equal(t, 4, watcherCount(t, system, watched))
+	require.Len(t, events, 1, "published once the limit is crossed")
+	e := <-events
+	assert.Equal(t, watched, e.PID)
+	assert.Equal(t, 3, e.Watchers)
+	assert.Equal(t, 2, e.Limit)
+
+	// published again once back within the limit
+	watched.sendSystemMessage(system, &Unwatch{Watcher: watchers[0]})
+	watched.sendSystemMessage(system, &Unwatch{Watcher: watchers[1]})
+	watched.sendSystemMessage(system, &Watch{Watcher: watchers[0]})
+	require.Equal(t, 3, watcherCount(t, system, watched))
+	assert.Len(t, events, 1)
+}
+
+func TestUnwatchAll(t *testing.T) {
+	system := NewActorSystem()
+	watchees := make([]*PID, 3)
+	for i := range watchees {
+		watchees[i] = system.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+	}
+	terminated := make(chan interface{}, 10)
+	watcher := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			if msg == "watch" {
+				ctx.Watch(watchees[0])
+				ctx.WatchWith(watchees[1], "b stopped")
+				ctx.Watch(watchees[2])
+			} else {
+				ctx.UnwatchAll()
+			}
+		case *Terminated:
+			terminated <- msg
+		}
+	}))
+	watched := func(count int) func() bool {
+		return func() bool {
+			for _, pid := range watchees {
+				if watcherCount(t, system, pid) != count {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	system.Root.Send(watcher, "watch")
+	require.Eventually(t, watched(1), time.Second, time.Millisecond)
+	info, err := system.InspectWatchers(watcher, 0, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, info.WatchingCount)
+
+	system.Root.Send(watcher, "unwatch")
+	require.Eventually(t, watched(0), time.Second, time.Millisecond)
+	info, err = system.InspectWatchers(watcher, 0, time.Second)
+	require.NoError(t, err)
+	assert.Zero(t, info.WatchingCount)
+
+	for _, pid := range watchees {
+		require.NoError(t, system.Root.StopFuture(pid).Wait())
+	}
+	require.NoError(t, system.Root.StopFuture(watcher).Wait())
+	assert.Empty(t, terminated)
+}
+
+func TestWatching_ForgetsTerminatedActors(t *testing.T) {
+	system := NewActorSystem()
+	watched := system.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+	stopped := make(chan struct{})
+	watcher := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *Started:
+			ctx.Watch(watched)
+		case *Terminated:
+			close(stopped)
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(watcher).Wait() }()
+
+	require.NoError(t, system.Root.StopFuture(watched).Wait())
+	<-stopped
+	info, err := system.InspectWatchers(watcher, 0, time.Second)
+	require.NoError(t, err)
+	assert.Zero(t, info.WatchingCount)
+}
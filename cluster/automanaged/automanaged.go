@@ -25,6 +25,7 @@ var (
 	deregisteredMutex          = new(sync.Mutex)
 	activeProviderMutex        = new(sync.Mutex)
 	activeProviderRunningMutex = new(sync.Mutex)
+	knownKindsMutex            = new(sync.Mutex)
 )
 
 type AutoManagedProvider struct {
@@ -192,6 +193,14 @@ func (p *AutoManagedProvider) UpdateTTL() {
 	}()
 }
 
+// UpdateKinds serves the kinds of the member to the other members
+func (p *AutoManagedProvider) UpdateKinds(kinds []string) error {
+	knownKindsMutex.Lock()
+	defer knownKindsMutex.Unlock()
+	p.knownKinds = kinds
+	return nil
+}
+
 func (p *AutoManagedProvider) UpdateClusterState(state cluster.ClusterState) error {
 	plog.Error("not implemented yet")
 	return nil
@@ -402,5 +411,7 @@ func (p *AutoManagedProvider) isActiveProviderRunning() bool {
 }
 
 func (p *AutoManagedProvider) getCurrentNode() *NodeModel {
+	knownKindsMutex.Lock()
+	defer knownKindsMutex.Unlock()
 	return NewNode(p.clusterName, p.address, p.memberPort, p.autoManagePort, p.knownKinds)
 }
@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startClientTestCluster(t *testing.T, network *remotetest.Network, membership *testMembership, kind *Kind) ([]*Cluster, *Cluster) {
+	configure := func(config *Config) {
+		config.WithTimeout(300 * time.Millisecond).WithHandoffGracePeriod(time.Second)
+	}
+	members := make([]*Cluster, 3)
+	for i := range members {
+		members[i] = startConfiguredTestMember(t, network, membership, configure, kind)
+	}
+	client := startConfiguredTestMember(t, network, membership, func(config *Config) {
+		configure(config)
+		config.WithHostKinds(false)
+	}, kind)
+	return members, client
+}
+
+func TestClientMode_ClientNeverReceivesActivations(t *testing.T) {
+	network := remotetest.NewNetwork()
+	membership := newTestMembership()
+	kind, activations, _ := countingKind(0)
+	members, client := startClientTestCluster(t, network, membership, kind)
+	defer client.Shutdown(false)
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+
+	assert.Empty(t, client.GetClusterKinds())
+	for _, m := range members[0].Members() {
+		assert.NotEqual(t, client.ActorSystem.Address(), m.Address())
+	}
+
+	for i := 0; i < 30; i++ {
+		name := "grain" + strconv.Itoa(i)
+		res, err := client.Call(name, "counter", &GrainRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count(t, res))
+
+		pid, statusCode := client.Get(name, "counter")
+		require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+		assert.NotEqual(t, client.ActorSystem.Address(), pid.Address)
+	}
+	assert.Equal(t, int32(30), atomic.LoadInt32(activations))
+	assert.Zero(t, client.PartitionStats().RebalancedIdentities)
+}
+
+func TestClientMode_CallsSucceedWhileMembersChurn(t *testing.T) {
+	network := remotetest.NewNetwork()
+	membership := newTestMembership()
+	kind, _, _ := countingKind(0)
+	members, client := startClientTestCluster(t, network, membership, kind)
+	defer client.Shutdown(false)
+	defer members[0].Shutdown(false)
+	defer members[1].Shutdown(false)
+
+	options := NewGrainCallOptions(client).WithRetry(20).WithRetryBackoff(10 * time.Millisecond)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var calls, failures int
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := 0; ; n++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				_, err := client.Call("grain"+strconv.Itoa((i*100+n)%20), "counter", &GrainRequest{}, options)
+				mutex.Lock()
+				calls++
+				if err != nil {
+					failures++
+				}
+				mutex.Unlock()
+			}
+		}(i)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	joined := startConfiguredTestMember(t, network, membership, func(config *Config) {
+		config.WithTimeout(300 * time.Millisecond).WithHandoffGracePeriod(time.Second)
+	}, kind)
+	defer joined.Shutdown(false)
+	time.Sleep(100 * time.Millisecond)
+	membership.leave(members[2])
+	members[2].Shutdown(false)
+	time.Sleep(500 * time.Millisecond)
+
+	close(stop)
+	wg.Wait()
+	assert.NotZero(t, calls)
+	assert.Zero(t, failures)
+}
@@ -1,13 +1,19 @@
 package cluster
 
 import (
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/net/context"
+
 	"github.com/AsynkronIT/protoactor-go/extensions"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/log"
 	"github.com/AsynkronIT/protoactor-go/remote"
+	cmap "github.com/orcaman/concurrent-map"
 )
 
 var extensionId = extensions.NextExtensionId()
@@ -19,12 +25,30 @@ type Cluster struct {
 	pidCache       *pidCacheValue
 	MemberList     *memberListValue
 	partitionValue *partitionValue
+	rendezvous     *rendezvousValue
+	passivation    *passivationValue
+	identityLookup IdentityLookup
+	pubSub         *PubSub
+	heartbeat      *actor.PID
+	isClient       bool
+	activations    cmap.ConcurrentMap
+	ready          int32
+	readinessDone  chan struct{}
+	readinessOnce  sync.Once
+	clientID       string
+	callSeq        uint64
 }
 
 func New(actorSystem *actor.ActorSystem, config *Config) *Cluster {
 	c := &Cluster{
-		ActorSystem: actorSystem,
-		Config:      config,
+		ActorSystem:    actorSystem,
+		Config:         config,
+		identityLookup: config.IdentityLookup,
+		activations:    cmap.New(),
+		readinessDone:  make(chan struct{}),
+	}
+	if c.identityLookup == nil {
+		c.identityLookup = newPartitionIdentityLookup(c)
 	}
 
 	actorSystem.Extensions.Register(c)
@@ -41,43 +65,65 @@ func GetCluster(actorSystem *actor.ActorSystem) *Cluster {
 	return c.(*Cluster)
 }
 
+// Start starts the cluster member, or the client when the config does not host kinds
 func (c *Cluster) Start() {
 	cfg := c.Config
+	if !cfg.HostKinds {
+		c.StartClient()
+		return
+	}
+
 	c.remote = remote.NewRemote(c.ActorSystem, c.Config.RemoteConfig)
-	for kind, props := range c.Config.Kinds {
-		c.remote.Register(kind, props)
+	for _, kind := range c.Config.Kinds {
+		c.remote.RegisterKind(kind.remoteKind())
 	}
+	c.remote.RegisterKind(remote.NewKind(TopicKind, actor.PropsFromProducer(newTopicActor(c))).
+		WithReceiverMiddleware(activationMiddleware(TopicKind)))
 
 	// TODO: make it possible to become a cluster even if remoting is already started
 	c.remote.Start()
 
 	address := c.ActorSystem.Address()
+	c.clientID = newClientID(address)
 	plog.Info("Starting Proto.Actor cluster", log.String("address", address))
 	kinds := c.remote.GetKnownKinds()
 
-	// for each known kind, spin up a partition-kind actor to handle all requests for that kind
-	c.partitionValue = setupPartition(c, kinds)
+	c.identityLookup.Setup(c, kinds, false)
 	c.pidCache = setupPidCache(c.ActorSystem)
+	c.passivation = setupPassivation(c)
+	c.pubSub = setupPubSub(c)
 	c.MemberList = setupMemberList(c)
+	c.heartbeat = setupFailureDetector(c)
 
-	if err := cfg.ClusterProvider.StartMember(c); err != nil {
-		panic(err)
+	if !c.needsReadiness() {
+		atomic.StoreInt32(&c.ready, 1)
+	}
+	// a provider that cannot register the member as joining registers it once it is ready
+	if _, ok := cfg.ClusterProvider.(ReadinessClusterProvider); ok || c.IsReady() {
+		if err := cfg.ClusterProvider.StartMember(c); err != nil {
+			panic(err)
+		}
 	}
+	c.startReadinessChecks()
 }
 
+// StartClient starts a cluster client, which calls grains without hosting any
 func (c *Cluster) StartClient() {
 	cfg := c.Config
+	c.isClient = true
+	atomic.StoreInt32(&c.ready, 1)
 	c.remote = remote.NewRemote(c.ActorSystem, c.Config.RemoteConfig)
 
 	c.remote.Start()
 
 	address := c.ActorSystem.Address()
+	c.clientID = newClientID(address)
 	plog.Info("Starting Proto.Actor cluster-client", log.String("address", address))
-	kinds := c.remote.GetKnownKinds()
 
-	// for each known kind, spin up a partition-kind actor to handle all requests for that kind
-	c.partitionValue = setupPartition(c, kinds)
+	c.identityLookup.Setup(c, nil, true)
 	c.pidCache = setupPidCache(c.ActorSystem)
+	c.passivation = setupPassivation(c)
+	c.pubSub = setupPubSub(c)
 	c.MemberList = setupMemberList(c)
 
 	if err := cfg.ClusterProvider.StartClient(c); err != nil {
@@ -85,20 +131,36 @@ func (c *Cluster) StartClient() {
 	}
 }
 
+// Shutdown stops the member or client.
+// A graceful shutdown first drains the member: it is marked as leaving, stops accepting activations and passivates
+// its grains, until the other members took over its identities or Config.ShutdownTimeout passed.
+// It then deregisters from the provider and stops remoting. The progress is published as ShutdownProgressEvents.
 func (c *Cluster) Shutdown(graceful bool) {
+	c.stopReadinessChecks()
+	if graceful && !c.isClient {
+		c.drain()
+	}
+
 	if graceful {
 		_ = c.Config.ClusterProvider.Shutdown(graceful)
-		// This is to wait ownership transferring complete.
-		time.Sleep(time.Millisecond * 2000)
+		c.publishShutdownProgress(ShutdownDeregistered, false)
 		c.MemberList.stopMemberList()
 		c.pidCache.stopPidCache()
-		c.partitionValue.stopPartition()
+		c.passivation.stopPassivation()
+		c.pubSub.stopPubSub()
+		if c.heartbeat != nil {
+			_ = c.ActorSystem.Root.StopFuture(c.heartbeat).Wait()
+		}
+		c.identityLookup.Shutdown()
 	}
 
 	c.remote.Shutdown(graceful)
 
 	address := c.ActorSystem.Address()
 	plog.Info("Stopped Proto.Actor cluster", log.String("address", address))
+	if graceful {
+		c.publishShutdownProgress(ShutdownStopped, false)
+	}
 }
 
 // Get a PID to a virtual actor
@@ -108,93 +170,184 @@ func (c *Cluster) Get(name string, kind string) (*actor.PID, remote.ResponseStat
 		return pid, remote.ResponseStatusCodeOK
 	}
 
-	// Get Pid
-	address := c.MemberList.getPartitionMember(name, kind)
-	if address == "" {
-		// No available member found
-		return nil, remote.ResponseStatusCodeUNAVAILABLE
+	pid, statusCode := c.identityLookup.Get(name, kind)
+	if statusCode == remote.ResponseStatusCodeOK {
+		// save cache
+		c.pidCache.addCache(name, pid)
 	}
+	return pid, statusCode
+}
+
+// PartitionStats returns the counters of the identities moved between this member and others.
+// They are zero when the cluster uses another IdentityLookup.
+func (c *Cluster) PartitionStats() PartitionStats {
+	if c.partitionValue == nil {
+		return PartitionStats{}
+	}
+	return c.partitionValue.stats()
+}
+
+// RendezvousStats returns the counters of the grains placed by rendezvous hashing on this member, see
+// NewRendezvousPlacement. They are zero when the cluster uses another IdentityLookup
+func (c *Cluster) RendezvousStats() RendezvousStats {
+	if c.rendezvous == nil {
+		return RendezvousStats{}
+	}
+	return c.rendezvous.stats()
+}
 
-	// package the request as a remote.ActorPidRequest
-	req := &remote.ActorPidRequest{
-		Kind: kind,
-		Name: name,
+// Members returns the status of all members, including their reachability when the failure detector is enabled
+func (c *Cluster) Members() []*MemberStatus {
+	return c.MemberList.getMemberStatuses()
+}
+
+// MemberAddresses returns the addresses of the alive members hosting kind
+func (c *Cluster) MemberAddresses(kind string) []string {
+	return c.MemberList.getMembers(kind)
+}
+
+// Activate spawns a new activation of the grain on a member hosting kind.
+// An IdentityLookup uses it to activate grains that have no activation.
+func (c *Cluster) Activate(name string, kind string) (*actor.PID, remote.ResponseStatusCode) {
+	activator := c.MemberList.getActivatorMember(kind)
+	if activator == "" {
+		return nil, remote.ResponseStatusCodeUNAVAILABLE
 	}
 
-	// ask the DHT partition for this name to give us a PID
-	remotePartition := c.partitionValue.partitionForKind(address, kind)
-	r, err := c.ActorSystem.Root.RequestFuture(remotePartition, req, c.Config.TimeoutTime).Result()
+	res, err := c.remote.SpawnNamed(activator, name, kind, c.Config.TimeoutTime)
 	if err == actor.ErrTimeout {
-		plog.Error("PidCache Pid request timeout", log.String("remote", remotePartition.String()))
 		return nil, remote.ResponseStatusCodeTIMEOUT
 	} else if err != nil {
-		plog.Error("PidCache Pid request error", log.Error(err), log.String("remote", remotePartition.String()))
-		return nil, remote.ResponseStatusCodeERROR
-	}
-
-	response, ok := r.(*remote.ActorPidResponse)
-	if !ok {
+		plog.Error("Failed to activate grain", log.String("name", name), log.String("kind", kind), log.String("address", activator), log.Error(err))
 		return nil, remote.ResponseStatusCodeERROR
 	}
 
-	statusCode := remote.ResponseStatusCode(response.StatusCode)
-	switch statusCode {
-	case remote.ResponseStatusCodeOK:
-		// save cache
-		c.pidCache.addCache(name, response.Pid)
-		// tell the original requester that we have a response
-		return response.Pid, statusCode
-	default:
-		// forward to requester
-		return response.Pid, statusCode
+	statusCode := remote.ResponseStatusCode(res.StatusCode)
+	if statusCode == remote.ResponseStatusCodePROCESSNAMEALREADYEXIST && res.Pid != nil {
+		// the grain is activated already
+		return res.Pid, remote.ResponseStatusCodeOK
 	}
+	return res.Pid, statusCode
 }
 
 // GetClusterKinds Get kinds of virtual actor
 func (c *Cluster) GetClusterKinds() []string {
-	if c.remote == nil {
+	if c.remote == nil || c.isClient {
 		return nil
 	}
 	return c.remote.GetKnownKinds()
 }
 
-// RequestFuture just call context.RequestFuture with retries.
+// Call sends msg to the grain and waits for the response, retrying as configured by callopts.
+// Without callopts, the options registered for the kind with Config.WithCallOptions are used.
+// Every attempt resolves the placement of the grain again, so that retries reach grains that moved.
 func (c *Cluster) Call(name string, kind string, msg interface{}, callopts ...*GrainCallOptions) (interface{}, error) {
-	var _callopts *GrainCallOptions = nil
-	if len(callopts) > 0 {
-		_callopts = callopts[0]
-	} else {
-		_callopts = DefaultGrainCallOptions(c)
+	return c.CallContext(context.Background(), name, kind, msg, callopts...)
+}
+
+// CallContext is Call, but stops waiting and retrying when ctx is done, returning the error of ctx
+func (c *Cluster) CallContext(ctx context.Context, name string, kind string, msg interface{}, callopts ...*GrainCallOptions) (interface{}, error) {
+	_callopts := c.callOptions(kind, callopts...)
+	retryable := _callopts.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
 	}
+	timeout := _callopts.Timeout
+	if timeout <= 0 {
+		timeout = c.Config.TimeoutTime
+	}
+
+	// the attempts of the call share its id, so that the grain can answer the retries of a call it answered already
+	callID := c.clientID + "/" + strconv.FormatUint(atomic.AddUint64(&c.callSeq, 1), 10)
 
-	_context := c.ActorSystem.Root
 	var lastError error
-	for i := 0; i < _callopts.RetryCount; i++ {
+	for i := 0; i == 0 || i < _callopts.RetryCount; i++ {
+		if i > 0 && _callopts.RetryAction != nil {
+			_callopts.RetryAction(i - 1)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		pid, statusCode := c.Get(name, kind)
 		if statusCode != remote.ResponseStatusCodeOK && statusCode != remote.ResponseStatusCodePROCESSNAMEALREADYEXIST {
 			lastError = statusCode.AsError()
-			if statusCode == remote.ResponseStatusCodeTIMEOUT {
-				_callopts.RetryAction(i)
+			if statusCode == remote.ResponseStatusCodeUNAVAILABLE {
+				if err := c.MemberList.placementError(kind); err != nil {
+					return nil, err
+				}
+			}
+			if retryable(lastError) {
 				continue
 			}
-			return nil, statusCode.AsError()
+			return nil, lastError
 		}
 
-		timeout := _callopts.Timeout
-		_resp, err := _context.RequestFuture(pid, msg, timeout).Result()
-		if err != nil {
-			plog.Error("cluster.RequestFuture failed", log.Error(err))
-			lastError = err
-			switch err {
-			case actor.ErrTimeout, remote.ErrTimeout:
-				_callopts.RetryAction(i)
-			case remote.ErrDeadLetter: // TODO: not implemented yet
-				_callopts.RetryAction(i)
-			default:
-				return nil, err
-			}
+		_resp, err := result(ctx, c.request(pid, msg, timeout, callID, _callopts.IdempotencyKey))
+		if err == nil {
+			return _resp, nil
+		}
+		if err == ctx.Err() {
+			return nil, err
 		}
-		return _resp, nil
+
+		plog.Error("cluster.RequestFuture failed", log.Error(err))
+		lastError = err
+		if !retryable(err) {
+			return nil, err
+		}
+		// the grain may have moved, resolve it again
+		c.pidCache.removeCacheByName(name)
 	}
 	return nil, lastError
 }
+
+// newClientID returns the id of the calls of this cluster, unique across its restarts on the same address
+func newClientID(address string) string {
+	return address + "/" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// result waits for the result of f, or until ctx is done
+func result(ctx context.Context, f *actor.Future) (interface{}, error) {
+	if ctx.Done() == nil {
+		return f.Result()
+	}
+
+	var res interface{}
+	var err error
+	done := make(chan struct{})
+	go func() {
+		res, err = f.Result()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return res, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Cluster) callOptions(kind string, callopts ...*GrainCallOptions) *GrainCallOptions {
+	if len(callopts) > 0 && callopts[0] != nil {
+		return callopts[0]
+	}
+	if opts, ok := c.Config.CallOptions[kind]; ok {
+		return opts
+	}
+	return DefaultGrainCallOptions(c)
+}
+
+func (c *Cluster) request(pid *actor.PID, msg interface{}, timeout time.Duration, callID string, idempotencyKey string) *actor.Future {
+	future := actor.NewFuture(c.ActorSystem, timeout)
+	env := &actor.MessageEnvelope{
+		Message: msg,
+		Sender:  future.PID(),
+	}
+	env.SetHeader(CallIDHeader, callID)
+	if idempotencyKey != "" {
+		env.SetHeader(IdempotencyKeyHeader, idempotencyKey)
+	}
+	c.ActorSystem.Root.Send(pid, env)
+	return future
+}
@@ -12,3 +12,25 @@ type ClusterProvider interface {
 	Shutdown(graceful bool) error
 	UpdateClusterState(state ClusterState) error
 }
+
+// LeavingClusterProvider is implemented by providers that can mark the member as leaving before Shutdown
+// removes it, so that the other members stop placing grains on it while it drains.
+// The members it marks are reported with MemberStatus.Leaving set.
+type LeavingClusterProvider interface {
+	Leave() error
+}
+
+// KindsClusterProvider is implemented by providers that can update the kinds of the member they registered, so that
+// the other members place the kinds registered or unregistered at runtime, see Cluster.RegisterKind
+type KindsClusterProvider interface {
+	UpdateKinds(kinds []string) error
+}
+
+// ReadinessClusterProvider is implemented by providers that can register the member as joining until it is ready,
+// so that the other members place no grains on it while it starts, see Cluster.SetReady.
+// They register the member as joining when Cluster.IsReady is false, and Ready marks it as ready.
+// The members they mark are reported with MemberStatus.Joining set.
+// The other providers register the member only once it is ready.
+type ReadinessClusterProvider interface {
+	Ready() error
+}
@@ -1,12 +1,15 @@
 package cluster
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCluster_Call(t *testing.T) {
@@ -38,11 +41,11 @@ func TestCluster_Call(t *testing.T) {
 		assert.Nil(resp)
 	})
 
-	t.Run("timeout", func(t *testing.T) {
+	t.Run("unreachable partition", func(t *testing.T) {
 		msg := struct{}{}
 		callopts := NewGrainCallOptions(c).WithRetry(2).WithTimeout(1 * time.Second)
 		resp, err := c.Call("name", "kind", &msg, callopts)
-		assert.Equal(remote.ErrTimeout, err)
+		assert.Equal(remote.ErrDeadLetter, err, "the requests to the partition fail without waiting for the timeout")
 		assert.Nil(resp)
 	})
 
@@ -65,3 +68,81 @@ func TestCluster_Call(t *testing.T) {
 	})
 	// t.Fatalf("need more testcases for cluster.Call")
 }
+
+func TestCluster_CallRetriesAgainstNewPlacement(t *testing.T) {
+	var activations, requests int32
+	keys := make(chan string, 10)
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			atomic.AddInt32(&activations, 1)
+		case *GrainRequest:
+			keys <- IdempotencyKey(ctx)
+			// the first activation doesn't answer
+			if atomic.AddInt32(&requests, 1) > 1 {
+				ctx.Respond(&GrainResponse{})
+			}
+		}
+	})
+	c := startTestMember(t, remotetest.NewNetwork(), newTestMembership(), NewKind("kind", props))
+	defer c.Shutdown(false)
+
+	retries := 0
+	c.Config.WithCallOptions("kind", NewGrainCallOptions(c).
+		WithTimeout(100*time.Millisecond).
+		WithRetry(3).
+		WithIdempotencyKey("key").
+		WithRetryAction(func(i int) {
+			retries++
+			// move the grain to a new activation
+			pid, _ := c.Get("name", "kind")
+			require.NoError(t, c.ActorSystem.Root.StopFuture(pid).Wait())
+		}))
+
+	resp, err := c.Call("name", "kind", &GrainRequest{})
+	require.NoError(t, err)
+	assert.IsType(t, &GrainResponse{}, resp)
+	assert.Equal(t, 1, retries)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&activations))
+	assert.Equal(t, "key", <-keys)
+	assert.Equal(t, "key", <-keys)
+}
+
+func TestCluster_CallDoesNotRetryOtherErrors(t *testing.T) {
+	var requests int32
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*GrainRequest); ok {
+			atomic.AddInt32(&requests, 1)
+		}
+	})
+	c := startTestMember(t, remotetest.NewNetwork(), newTestMembership(), NewKind("kind", props))
+	defer c.Shutdown(false)
+
+	callopts := NewGrainCallOptions(c).
+		WithTimeout(50 * time.Millisecond).
+		WithRetry(5).
+		WithRetryable(func(err error) bool { return false })
+	resp, err := c.Call("name", "kind", &GrainRequest{}, callopts)
+	assert.Equal(t, actor.ErrTimeout, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestCluster_CallRetriesRetryableErrorResponses(t *testing.T) {
+	var requests int32
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*GrainRequest); ok {
+			if atomic.AddInt32(&requests, 1) < 3 {
+				ctx.RespondError(&actor.ActorError{Message: "warming up", Retryable: true})
+			} else {
+				ctx.RespondError(&actor.ActorError{Message: "not found"})
+			}
+		}
+	})
+	c := startTestMember(t, remotetest.NewNetwork(), newTestMembership(), NewKind("kind", props))
+	defer c.Shutdown(false)
+
+	_, err := c.Call("name", "kind", &GrainRequest{}, NewGrainCallOptions(c).WithRetry(5))
+	assert.Equal(t, &actor.ActorError{Message: "not found"}, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
@@ -13,10 +13,22 @@ type Config struct {
 	ClusterProvider             ClusterProvider
 	RemoteConfig                remote.Config
 	TimeoutTime                 time.Duration
+	HandoffGracePeriod          time.Duration
 	InitialMemberStatusValue    MemberStatusValue
 	MemberStatusValueSerializer MemberStatusValueSerializer
 	MemberStrategyBuilder       func(kind string) MemberStrategy
-	Kinds                       map[string]*actor.Props
+	Kinds                       map[string]*Kind
+	CallOptions                 map[string]*GrainCallOptions
+	IdentityLookup              IdentityLookup
+	PubSub                      *PubSubConfig
+	FailureDetector             *FailureDetectorConfig
+	HostKinds                   bool
+	ShutdownTimeout             time.Duration
+	Metadata                    map[string]string
+	Placements                  map[string]*Placement
+	PlacementStrategies         map[string]PlacementStrategy
+	ManualReadiness             bool
+	ReadinessCheckInterval      time.Duration
 }
 
 func Configure(clusterName string, clusterProvider ClusterProvider, remoteConfig remote.Config, kinds ...*Kind) *Config {
@@ -24,15 +36,23 @@ func Configure(clusterName string, clusterProvider ClusterProvider, remoteConfig
 		Name:                        clusterName,
 		ClusterProvider:             clusterProvider,
 		TimeoutTime:                 time.Second * 5,
+		HandoffGracePeriod:          time.Second * 5,
 		InitialMemberStatusValue:    nil,
 		MemberStatusValueSerializer: &NilMemberStatusValueSerializer{},
 		MemberStrategyBuilder:       newDefaultMemberStrategy,
 		RemoteConfig:                remoteConfig,
-		Kinds:                       make(map[string]*actor.Props),
+		Kinds:                       make(map[string]*Kind),
+		CallOptions:                 make(map[string]*GrainCallOptions),
+		Placements:                  make(map[string]*Placement),
+		PlacementStrategies:         make(map[string]PlacementStrategy),
+		PubSub:                      NewPubSubConfig(),
+		HostKinds:                   true,
+		ShutdownTimeout:             time.Second * 10,
+		ReadinessCheckInterval:      time.Millisecond * 100,
 	}
 
 	for _, kind := range kinds {
-		config.Kinds[kind.Kind] = kind.Props
+		config.Kinds[kind.Kind] = kind
 	}
 
 	return config
@@ -43,9 +63,94 @@ func (c *Config) WithTimeout(t time.Duration) *Config {
 	return c
 }
 
+// WithCallOptions sets the default options of calls to grains of the given kind
+func (c *Config) WithCallOptions(kind string, options *GrainCallOptions) *Config {
+	c.CallOptions[kind] = options
+	return c
+}
+
+// WithIdentityLookup replaces the default identity lookup, which keeps the activations in partitions of the members
+func (c *Config) WithIdentityLookup(lookup IdentityLookup) *Config {
+	c.IdentityLookup = lookup
+	return c
+}
+
+// WithPubSub configures the delivery of the messages published with Cluster.PubSub
+func (c *Config) WithPubSub(config *PubSubConfig) *Config {
+	c.PubSub = config
+	return c
+}
+
+// WithHostKinds sets whether the node is a member hosting the configured kinds, or a client that only calls grains.
+// Clients watch the membership to find the grains, but are not part of the cluster topology,
+// so that they never own partitions nor receive activations.
+func (c *Config) WithHostKinds(hostKinds bool) *Config {
+	c.HostKinds = hostKinds
+	return c
+}
+
+// WithFailureDetector makes the members exchange heartbeats, so that grains are no longer activated on members
+// that stop responding before the provider removes them. It is disabled by default.
+func (c *Config) WithFailureDetector(config *FailureDetectorConfig) *Config {
+	c.FailureDetector = config
+	return c
+}
+
+// WithHandoffGracePeriod sets for how long a member forwards requests for identities that moved to another member
+// after a topology change. Later requests are answered with ResponseStatusCodeOWNERCHANGED.
+func (c *Config) WithHandoffGracePeriod(t time.Duration) *Config {
+	c.HandoffGracePeriod = t
+	return c
+}
+
+// WithShutdownTimeout sets for how long a graceful shutdown waits for the grains of the member to move
+// to the other members, before the member leaves anyway
+func (c *Config) WithShutdownTimeout(t time.Duration) *Config {
+	c.ShutdownTimeout = t
+	return c
+}
+
+// WithMetadata sets the labels the member registers with the provider, such as its region or its hardware.
+// The other members see them in MemberStatus.Metadata and place the kinds constrained by WithPlacement accordingly.
+func (c *Config) WithMetadata(metadata map[string]string) *Config {
+	c.Metadata = metadata
+	return c
+}
+
+// WithPlacement constrains the members owning and activating the grains of kind. Calls to these grains fail with
+// a PlacementError while no member hosting kind satisfies the placement.
+func (c *Config) WithPlacement(kind string, placement *Placement) *Config {
+	c.Placements[kind] = placement
+	return c
+}
+
+// WithPlacementStrategy places the grains of kind with strategy, e.g. NewRendezvousPlacement(), rather than with the
+// partitions. It is used by the default IdentityLookup
+func (c *Config) WithPlacementStrategy(kind string, strategy PlacementStrategy) *Config {
+	c.PlacementStrategies[kind] = strategy
+	return c
+}
+
+// WithManualReadiness makes the member join as joining, until Cluster.SetReady is called.
+// Members are otherwise ready once the readiness checks of their kinds pass, see Kind.WithReadinessCheck.
+func (c *Config) WithManualReadiness(manual bool) *Config {
+	c.ManualReadiness = manual
+	return c
+}
+
+// WithReadinessCheckInterval sets how often the readiness checks of the kinds are run while the member is joining
+func (c *Config) WithReadinessCheckInterval(interval time.Duration) *Config {
+	c.ReadinessCheckInterval = interval
+	return c
+}
+
 type Kind struct {
-	Kind  string
-	Props *actor.Props
+	Kind            string
+	Props           *actor.Props
+	IdleTimeout     time.Duration
+	ReadinessCheck  func() bool
+	CallDedupWindow int
+	persistence     actor.ReceiverMiddleware
 }
 
 func NewKind(kind string, props *actor.Props) *Kind {
@@ -54,3 +159,36 @@ func NewKind(kind string, props *actor.Props) *Kind {
 		Props: props,
 	}
 }
+
+// WithIdleTimeout passivates grains of the kind that have not received a message for the given duration.
+// Zero means grains stay activated until they stop themselves.
+func (k *Kind) WithIdleTimeout(timeout time.Duration) *Kind {
+	k.IdleTimeout = timeout
+	return k
+}
+
+// WithReadinessCheck makes the member join as joining until check returns true, for instance once the caches
+// the grains of the kind use are warm. The members place no grains on a joining member.
+func (k *Kind) WithReadinessCheck(check func() bool) *Kind {
+	k.ReadinessCheck = check
+	return k
+}
+
+// WithCallDedup makes the generated grains of the kind answer the retries of the latest window calls they answered
+// with the same response, instead of invoking their methods again, see CallDedup.
+// It is best-effort: a call retried after the grain passivated or moved runs again.
+func (k *Kind) WithCallDedup(window int) *Kind {
+	k.CallDedupWindow = window
+	return k
+}
+
+func (k *Kind) remoteKind() *remote.Kind {
+	kind := remote.NewKind(k.Kind, k.Props).WithReceiverMiddleware(activationMiddleware(k.Kind))
+	if k.IdleTimeout > 0 {
+		kind.WithReceiverMiddleware(passivationMiddleware(k.Kind, k.IdleTimeout))
+	}
+	if k.persistence != nil {
+		kind.WithReceiverMiddleware(k.persistence)
+	}
+	return kind
+}
@@ -27,6 +27,7 @@ type Provider struct {
 	address               string
 	port                  int
 	knownKinds            []string
+	metadata              map[string]string
 	index                 uint64 // consul blocking index
 	client                *api.Client
 	ttl                   time.Duration
@@ -73,6 +74,7 @@ func (p *Provider) init(c *cluster.Cluster) error {
 	p.address = host
 	p.port = port
 	p.knownKinds = knownKinds
+	p.metadata = c.Config.Metadata
 	return nil
 }
 
@@ -139,6 +141,21 @@ func (p *Provider) Shutdown(graceful bool) error {
 	return nil
 }
 
+// Ready registers the service again without the joining flag
+func (p *Provider) Ready() error {
+	if err := p.registerService(); err != nil {
+		return err
+	}
+	// the check of the service registered again passes once its TTL is refreshed
+	return blockingUpdateTTLFunc(p)
+}
+
+// UpdateKinds registers the service again with the kinds of the member
+func (p *Provider) UpdateKinds(kinds []string) error {
+	p.knownKinds = kinds
+	return p.registerService()
+}
+
 func (p *Provider) UpdateTTL() {
 	go func() {
 		p.updateTTLWaitGroup.Add(1)
@@ -208,15 +225,23 @@ func blockingUpdateTTL(p *Provider) error {
 }
 
 func (p *Provider) registerService() error {
+	// the metadata of the member is registered along the id and the joining flag, whose keys are reserved
+	meta := map[string]string{"id": p.id}
+	for key, value := range p.metadata {
+		if key != "id" && key != "joining" {
+			meta[key] = value
+		}
+	}
+	if !p.cluster.IsReady() {
+		meta["joining"] = "true"
+	}
 	s := &api.AgentServiceRegistration{
 		ID:      p.id,
 		Name:    p.clusterName,
 		Tags:    p.knownKinds,
 		Address: p.address,
 		Port:    p.port,
-		Meta: map[string]string{
-			"id": p.id,
-		},
+		Meta:    meta,
 		Check: &api.AgentServiceCheck{
 			DeregisterCriticalServiceAfter: p.deregisterCritical.String(),
 			TTL:                            p.ttl.String(),
@@ -256,6 +281,8 @@ func (p *Provider) notifyStatuses() {
 			Kinds:       v.Service.Tags,
 			Alive:       len(v.Checks) > 0 && v.Checks.AggregatedStatus() == api.HealthPassing,
 			StatusValue: nil,
+			Metadata:    memberMetadata(v.Service.Meta),
+			Joining:     v.Service.Meta["joining"] == "true",
 		}
 		res[i] = ms
 
@@ -272,6 +299,17 @@ func (p *Provider) notifyStatuses() {
 	p.cluster.ActorSystem.EventStream.Publish(res)
 }
 
+// memberMetadata returns the metadata registered by a member, without its id and joining flag
+func memberMetadata(meta map[string]string) map[string]string {
+	res := make(map[string]string, len(meta))
+	for key, value := range meta {
+		if key != "id" && key != "joining" {
+			res[key] = value
+		}
+	}
+	return res
+}
+
 func (p *Provider) monitorMemberStatusChanges() {
 	go func() {
 		for !p.shutdown {
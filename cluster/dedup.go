@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"container/list"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// CallIDHeader is the message header carrying the id of a grain call, made of the id of the calling cluster and
+// a sequence number. All the attempts of a call carry the same id.
+const CallIDHeader = "cluster-call-id"
+
+// CallID returns the id of the grain call that sent the current message, see CallIDHeader
+func CallID(ctx actor.Context) string {
+	return ctx.MessageHeader().Get(CallIDHeader)
+}
+
+// CallDedup remembers the responses of the latest calls an activation answered, so that the retries of a call whose
+// response was lost are answered with the same response instead of invoking the grain method again.
+// It is best-effort: the responses are forgotten when the grain passivates or moves to another member,
+// and once more calls than its window were answered since.
+type CallDedup struct {
+	window    int
+	calls     *list.List // the ids of the calls, the latest first
+	responses map[string]*list.Element
+}
+
+type dedupedCall struct {
+	id       string
+	response interface{}
+}
+
+// NewCallDedup returns a CallDedup remembering the responses of the latest window calls
+func NewCallDedup(window int) *CallDedup {
+	return &CallDedup{
+		window:    window,
+		calls:     list.New(),
+		responses: make(map[string]*list.Element),
+	}
+}
+
+// NewKindCallDedup returns the CallDedup of an activation of kind, or nil when the kind is not configured with
+// Kind.WithCallDedup. The methods of a nil CallDedup deduplicate nothing.
+func NewKindCallDedup(system *actor.ActorSystem, kind string) *CallDedup {
+	c, ok := system.Extensions.Get(extensionId).(*Cluster)
+	if !ok {
+		return nil
+	}
+	if k, ok := c.Config.Kinds[kind]; ok && k.CallDedupWindow > 0 {
+		return NewCallDedup(k.CallDedupWindow)
+	}
+	return nil
+}
+
+// Response returns the response to the current message when it is the retry of a call already answered
+func (d *CallDedup) Response(ctx actor.Context) (interface{}, bool) {
+	if d == nil {
+		return nil, false
+	}
+	id := CallID(ctx)
+	if id == "" {
+		return nil, false
+	}
+	e, ok := d.responses[id]
+	if !ok {
+		return nil, false
+	}
+	d.calls.MoveToFront(e)
+	return e.Value.(*dedupedCall).response, true
+}
+
+// Respond responds to the current message, remembering the response for the retries of the call
+func (d *CallDedup) Respond(ctx actor.Context, response interface{}) {
+	if id := CallID(ctx); d != nil && id != "" {
+		d.responses[id] = d.calls.PushFront(&dedupedCall{id: id, response: response})
+		if d.calls.Len() > d.window {
+			oldest := d.calls.Back()
+			d.calls.Remove(oldest)
+			delete(d.responses, oldest.Value.(*dedupedCall).id)
+		}
+	}
+	ctx.Respond(response)
+}
@@ -0,0 +1,62 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallDedup_AnswersRetriesWithinWindow(t *testing.T) {
+	system := actor.NewActorSystem()
+	dedup := NewCallDedup(2)
+	invoked := 0
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); !ok {
+			return
+		}
+		if res, ok := dedup.Response(ctx); ok {
+			ctx.Respond(res)
+			return
+		}
+		invoked++
+		dedup.Respond(ctx, invoked)
+	}))
+	call := func(id string) interface{} {
+		future := actor.NewFuture(system, time.Second)
+		env := &actor.MessageEnvelope{Message: "call", Sender: future.PID()}
+		if id != "" {
+			env.SetHeader(CallIDHeader, id)
+		}
+		system.Root.Send(pid, env)
+		res, err := future.Result()
+		require.NoError(t, err)
+		return res
+	}
+
+	assert.Equal(t, 1, call("a"))
+	assert.Equal(t, 2, call("b"))
+	assert.Equal(t, 1, call("a"), "a retry is answered with the first response")
+	assert.Equal(t, 3, call("c"))
+	assert.Equal(t, 4, call("b"), "b was the oldest call of the window")
+	assert.Equal(t, 5, call(""), "calls without id are not deduplicated")
+	assert.Equal(t, 6, call(""))
+}
+
+func TestCallDedup_NilDeduplicatesNothing(t *testing.T) {
+	var dedup *CallDedup
+	assert.Nil(t, NewKindCallDedup(actor.NewActorSystem(), "counter"))
+
+	system := actor.NewActorSystem()
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			_, deduped := dedup.Response(ctx)
+			dedup.Respond(ctx, deduped)
+		}
+	}))
+	res, err := system.Root.RequestFuture(pid, "call", time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, false, res)
+}
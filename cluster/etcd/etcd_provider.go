@@ -0,0 +1,401 @@
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/cluster"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"go.etcd.io/etcd/clientv3"
+	"golang.org/x/net/context"
+)
+
+var (
+	ProviderShuttingDownError = fmt.Errorf("etcd cluster provider is shutting down")
+	plog                      = log.New(log.DebugLevel, "[CLUSTER] [ETCD]")
+)
+
+// memberValue is the value stored under the member key
+type memberValue struct {
+	ID       string            `json:"id"`
+	Host     string            `json:"host"`
+	Port     int               `json:"port"`
+	Kinds    []string          `json:"kinds"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Joining  bool              `json:"joining,omitempty"`
+}
+
+type Provider struct {
+	cluster      *cluster.Cluster
+	client       *clientv3.Client
+	ttl          time.Duration
+	retryDelay   time.Duration
+	root         string
+	id           string
+	clusterName  string
+	address      string
+	port         int
+	knownKinds   []string
+	isMember     bool
+	ctx          context.Context
+	cancel       context.CancelFunc
+	mutex        sync.Mutex
+	leaseID      clientv3.LeaseID
+	members      map[string]*memberValue
+	suspect      bool
+	shutdown     bool
+	clusterError error
+}
+
+// New creates a Provider that connects to the given etcd endpoints
+func New(endpoints ...string) (*Provider, error) {
+	return NewWithConfig(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+}
+
+func NewWithConfig(etcdConfig clientv3.Config) (*Provider, error) {
+	client, err := clientv3.New(etcdConfig)
+	if err != nil {
+		return nil, err
+	}
+	p := &Provider{
+		client:     client,
+		ttl:        10 * time.Second,
+		retryDelay: 1 * time.Second,
+		root:       "/protoactor",
+		members:    make(map[string]*memberValue),
+	}
+	return p, nil
+}
+
+// WithTTL sets the time to live of the member registration.
+// A member whose keep-alive fails for longer than ttl leaves the cluster.
+func (p *Provider) WithTTL(ttl time.Duration) *Provider {
+	p.ttl = ttl
+	return p
+}
+
+// WithRoot sets the key prefix under which clusters are stored
+func (p *Provider) WithRoot(root string) *Provider {
+	p.root = strings.TrimSuffix(root, "/")
+	return p
+}
+
+func (p *Provider) init(c *cluster.Cluster) error {
+	host, port, err := c.ActorSystem.GetHostPort()
+	if err != nil {
+		return err
+	}
+
+	p.cluster = c
+	p.clusterName = c.Config.Name
+	p.address = host
+	p.port = port
+	p.knownKinds = c.GetClusterKinds()
+	// the id changes with every start, so that a restarted member is seen as rejoined
+	p.id = fmt.Sprintf("%v@%v:%v/%v", p.clusterName, host, port, time.Now().UnixNano())
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	return nil
+}
+
+func (p *Provider) StartMember(c *cluster.Cluster) error {
+	if err := p.init(c); err != nil {
+		return err
+	}
+	p.isMember = true
+
+	// register synchronously, so that the local node sees itself in the first topology
+	if err := p.register(); err != nil {
+		return err
+	}
+	p.keepAlive()
+	return p.monitorMemberStatusChanges()
+}
+
+func (p *Provider) StartClient(c *cluster.Cluster) error {
+	if err := p.init(c); err != nil {
+		return err
+	}
+	return p.monitorMemberStatusChanges()
+}
+
+func (p *Provider) Shutdown(graceful bool) error {
+	p.mutex.Lock()
+	if p.shutdown {
+		p.mutex.Unlock()
+		return nil
+	}
+	p.shutdown = true
+	leaseID := p.leaseID
+	p.mutex.Unlock()
+
+	p.cancel()
+	defer p.client.Close()
+	if !graceful || !p.isMember {
+		return nil
+	}
+
+	// revoking the lease deletes the member key
+	ctx, cancel := context.WithTimeout(context.Background(), p.ttl)
+	defer cancel()
+	if _, err := p.client.Revoke(ctx, leaseID); err != nil {
+		plog.Error("Failed to deregister member", log.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (p *Provider) UpdateClusterState(state cluster.ClusterState) error {
+	if p.isShutdown() {
+		return ProviderShuttingDownError
+	}
+	value, err := json.Marshal(state.BannedMembers)
+	if err != nil {
+		plog.Error("Failed to UpdateClusterState. json.Marshal", log.Error(err))
+		return err
+	}
+	ctx, cancel := context.WithTimeout(p.ctx, p.ttl)
+	defer cancel()
+	if _, err := p.client.Put(ctx, p.clusterKey()+"/banned", string(value)); err != nil {
+		plog.Error("Failed to UpdateClusterState.", log.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetHealthStatus returns an error if the cluster health status has problems
+func (p *Provider) GetHealthStatus() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.clusterError
+}
+
+func (p *Provider) clusterKey() string {
+	return p.root + "/" + p.clusterName
+}
+
+func (p *Provider) membersKey() string {
+	return p.clusterKey() + "/members/"
+}
+
+func (p *Provider) memberKey() string {
+	return fmt.Sprintf("%v%v:%v", p.membersKey(), p.address, p.port)
+}
+
+func (p *Provider) isShutdown() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.shutdown
+}
+
+// Ready stores the member key again, without the joining flag
+func (p *Provider) Ready() error {
+	if p.isShutdown() {
+		return ProviderShuttingDownError
+	}
+	p.mutex.Lock()
+	leaseID := p.leaseID
+	p.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(p.ctx, p.ttl)
+	defer cancel()
+	return p.put(ctx, leaseID)
+}
+
+// UpdateKinds stores the member key again with the kinds of the member
+func (p *Provider) UpdateKinds(kinds []string) error {
+	if p.isShutdown() {
+		return ProviderShuttingDownError
+	}
+	p.mutex.Lock()
+	p.knownKinds = kinds
+	leaseID := p.leaseID
+	p.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(p.ctx, p.ttl)
+	defer cancel()
+	return p.put(ctx, leaseID)
+}
+
+// register grants a lease and stores the member key with it
+func (p *Provider) register() error {
+	ctx, cancel := context.WithTimeout(p.ctx, p.ttl)
+	defer cancel()
+	lease, err := p.client.Grant(ctx, int64(p.ttl/time.Second))
+	if err != nil {
+		return err
+	}
+	if err := p.put(ctx, lease.ID); err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	p.leaseID = lease.ID
+	p.mutex.Unlock()
+	return nil
+}
+
+// put stores the member key with the lease, the member is joining until the cluster is ready
+func (p *Provider) put(ctx context.Context, leaseID clientv3.LeaseID) error {
+	value, err := json.Marshal(&memberValue{
+		ID:       p.id,
+		Host:     p.address,
+		Port:     p.port,
+		Kinds:    p.knownKinds,
+		Metadata: p.cluster.Config.Metadata,
+		Joining:  !p.cluster.IsReady(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.client.Put(ctx, p.memberKey(), string(value), clientv3.WithLease(leaseID))
+	return err
+}
+
+// keepAlive refreshes the lease. When etcd can't be reached the member marks itself as suspect,
+// and registers again once etcd is back.
+func (p *Provider) keepAlive() {
+	go func() {
+		for !p.isShutdown() {
+			p.mutex.Lock()
+			leaseID := p.leaseID
+			p.mutex.Unlock()
+
+			ch, err := p.client.KeepAlive(p.ctx, leaseID)
+			if err == nil {
+				for range ch {
+				}
+			}
+			if p.isShutdown() {
+				return
+			}
+
+			plog.Info("Lost etcd lease, marking member as suspect", log.String("id", p.id))
+			p.setSuspect(true, fmt.Errorf("etcd lease %x lost", leaseID))
+			for !p.isShutdown() {
+				if err := p.register(); err != nil {
+					plog.Error("Failed to register member", log.Error(err))
+					time.Sleep(p.retryDelay)
+					continue
+				}
+				plog.Info("Registered member in etcd", log.String("id", p.id))
+				p.setSuspect(false, nil)
+				break
+			}
+		}
+	}()
+}
+
+func (p *Provider) setSuspect(suspect bool, err error) {
+	p.mutex.Lock()
+	p.suspect = suspect
+	p.clusterError = err
+	p.mutex.Unlock()
+	p.publishTopology()
+}
+
+// monitorMemberStatusChanges loads the current members and then follows changes to them
+func (p *Provider) monitorMemberStatusChanges() error {
+	rev, err := p.loadMembers()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for !p.isShutdown() {
+			p.watchMembers(rev)
+			if p.isShutdown() {
+				return
+			}
+
+			// the watch broke, resync
+			time.Sleep(p.retryDelay)
+			if rev, err = p.loadMembers(); err != nil {
+				plog.Error("Failed to load members", log.Error(err))
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *Provider) loadMembers() (int64, error) {
+	ctx, cancel := context.WithTimeout(p.ctx, p.ttl)
+	defer cancel()
+	res, err := p.client.Get(ctx, p.membersKey(), clientv3.WithPrefix())
+	if err != nil {
+		return 0, err
+	}
+
+	members := make(map[string]*memberValue)
+	for _, kv := range res.Kvs {
+		if m := p.decode(kv.Value); m != nil {
+			members[string(kv.Key)] = m
+		}
+	}
+	p.mutex.Lock()
+	p.members = members
+	p.mutex.Unlock()
+
+	p.publishTopology()
+	return res.Header.Revision, nil
+}
+
+func (p *Provider) watchMembers(rev int64) {
+	watch := p.client.Watch(clientv3.WithRequireLeader(p.ctx), p.membersKey(), clientv3.WithPrefix(), clientv3.WithRev(rev+1))
+	for res := range watch {
+		if err := res.Err(); err != nil {
+			plog.Error("Member watch failed", log.Error(err))
+			return
+		}
+
+		p.mutex.Lock()
+		for _, ev := range res.Events {
+			key := string(ev.Kv.Key)
+			switch ev.Type {
+			case clientv3.EventTypePut:
+				if m := p.decode(ev.Kv.Value); m != nil {
+					p.members[key] = m
+				}
+			case clientv3.EventTypeDelete:
+				delete(p.members, key)
+			}
+		}
+		p.mutex.Unlock()
+
+		p.publishTopology()
+	}
+}
+
+func (p *Provider) decode(value []byte) *memberValue {
+	m := &memberValue{}
+	if err := json.Unmarshal(value, m); err != nil {
+		plog.Error("Failed to decode member", log.Error(err))
+		return nil
+	}
+	return m
+}
+
+// publishTopology publishes the complete member list, so that the member list can work out who has left
+func (p *Provider) publishTopology() {
+	p.mutex.Lock()
+	res := make(cluster.TopologyEvent, 0, len(p.members))
+	for _, m := range p.members {
+		res = append(res, &cluster.MemberStatus{
+			MemberID: m.ID,
+			Host:     m.Host,
+			Port:     m.Port,
+			Kinds:    m.Kinds,
+			Alive:    !(p.suspect && m.ID == p.id),
+			Metadata: m.Metadata,
+			Joining:  m.Joining,
+		})
+	}
+	p.mutex.Unlock()
+
+	p.cluster.ActorSystem.EventStream.Publish(res)
+}
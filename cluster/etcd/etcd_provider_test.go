@@ -0,0 +1,161 @@
+package etcd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/cluster"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/embed"
+)
+
+func freeURL(t *testing.T) url.URL {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	u, _ := url.Parse(fmt.Sprintf("http://%v", lis.Addr()))
+	return *u
+}
+
+func startEtcd(t *testing.T) (*embed.Etcd, string, func()) {
+	dir, err := ioutil.TempDir("", "etcd")
+	require.NoError(t, err)
+
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	cfg.Logger = "zap"
+	cfg.LogOutputs = []string{"/dev/null"}
+	clientURL, peerURL := freeURL(t), freeURL(t)
+	cfg.LCUrls, cfg.ACUrls = []url.URL{clientURL}, []url.URL{clientURL}
+	cfg.LPUrls, cfg.APUrls = []url.URL{peerURL}, []url.URL{peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatal("etcd did not start")
+	}
+	return e, clientURL.Host, func() {
+		e.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+type memberEvents chan interface{}
+
+func startMember(t *testing.T, endpoint string) (*cluster.Cluster, *Provider, memberEvents) {
+	return startConfiguredMember(t, endpoint, nil)
+}
+
+// startConfiguredMember starts a member after configure changed its config
+func startConfiguredMember(t *testing.T, endpoint string, configure func(*cluster.Config)) (*cluster.Cluster, *Provider, memberEvents) {
+	provider, err := New(endpoint)
+	require.NoError(t, err)
+	provider.WithTTL(2 * time.Second)
+
+	system := actor.NewActorSystem()
+	events := make(memberEvents, 100)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		switch evt.(type) {
+		case *cluster.MemberJoinedEvent, *cluster.MemberLeftEvent, *cluster.MemberRejoinedEvent,
+			*cluster.MemberUnavailableEvent, *cluster.MemberAvailableEvent, *cluster.MemberReadyEvent:
+			events <- evt
+		}
+	})
+
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	config := cluster.Configure("etcd-test", provider, remote.Configure("127.0.0.1", 0), cluster.NewKind("kind", props))
+	if configure != nil {
+		configure(config)
+	}
+	c := cluster.New(system, config)
+	c.Start()
+	return c, provider, events
+}
+
+func (events memberEvents) expect(t *testing.T, address string, evt interface{}) {
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case e := <-events:
+			if fmt.Sprintf("%T", e) == fmt.Sprintf("%T", evt) && e.(interface{ Name() string }).Name() == address {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("expected %T for %v", evt, address)
+		}
+	}
+}
+
+func TestProvider_MembersJoinAndLeave(t *testing.T) {
+	_, endpoint, stop := startEtcd(t)
+	defer stop()
+
+	c1, _, events := startMember(t, endpoint)
+	defer c1.Shutdown(true)
+	events.expect(t, c1.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+
+	c2, _, _ := startMember(t, endpoint)
+	events.expect(t, c2.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+
+	c2.Shutdown(true)
+	events.expect(t, c2.ActorSystem.Address(), &cluster.MemberLeftEvent{})
+}
+
+func TestProvider_JoiningUntilReady(t *testing.T) {
+	_, endpoint, stop := startEtcd(t)
+	defer stop()
+
+	c1, _, events := startMember(t, endpoint)
+	defer c1.Shutdown(true)
+	c2, _, _ := startConfiguredMember(t, endpoint, func(config *cluster.Config) {
+		config.WithManualReadiness(true)
+	})
+	defer c2.Shutdown(true)
+	events.expect(t, c2.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+	assert.Equal(t, []string{c1.ActorSystem.Address()}, c1.MemberAddresses("kind"))
+
+	c2.SetReady()
+	events.expect(t, c2.ActorSystem.Address(), &cluster.MemberReadyEvent{})
+	assert.ElementsMatch(t, []string{c1.ActorSystem.Address(), c2.ActorSystem.Address()}, c1.MemberAddresses("kind"))
+}
+
+func TestProvider_RestartedMemberRejoins(t *testing.T) {
+	_, endpoint, stop := startEtcd(t)
+	defer stop()
+
+	c1, _, events := startMember(t, endpoint)
+	defer c1.Shutdown(true)
+	c2, p2, _ := startMember(t, endpoint)
+	defer c2.Shutdown(true)
+	events.expect(t, c2.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+
+	// simulate a restart of the process on the same address
+	p2.id = p2.id + "-restarted"
+	require.NoError(t, p2.register())
+	events.expect(t, c2.ActorSystem.Address(), &cluster.MemberRejoinedEvent{})
+}
+
+func TestProvider_SuspectWhenEtcdIsUnavailable(t *testing.T) {
+	e, endpoint, stop := startEtcd(t)
+	defer stop()
+
+	c, p, events := startMember(t, endpoint)
+	defer c.Shutdown(false)
+	events.expect(t, c.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+	assert.NoError(t, p.GetHealthStatus())
+
+	e.Close()
+	events.expect(t, c.ActorSystem.Address(), &cluster.MemberUnavailableEvent{})
+	assert.Error(t, p.GetHealthStatus())
+}
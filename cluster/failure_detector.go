@@ -0,0 +1,266 @@
+package cluster
+
+import (
+	"math"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// heartbeatActorName is the name of the actor exchanging heartbeats with the other members
+const heartbeatActorName = "heartbeat"
+
+// Reachability tells whether the failure detector hears the heartbeats of a member
+type Reachability int
+
+const (
+	// Reachable members send heartbeats as expected
+	Reachable Reachability = iota
+	// Suspect members have missed heartbeats, grains are no longer activated on them
+	Suspect
+	// Unreachable members are considered down until they send heartbeats again or the provider removes them
+	Unreachable
+)
+
+func (r Reachability) String() string {
+	switch r {
+	case Suspect:
+		return "suspect"
+	case Unreachable:
+		return "unreachable"
+	}
+	return "reachable"
+}
+
+// FailureDetectorConfig configures the heartbeats between members and the phi accrual failure detector
+// computing the suspicion level of each member from them.
+//
+// Phi is the suspicion level: a phi of 1 means that a heartbeat this late is missed by mistake with
+// a probability of 10%, a phi of 2 with 1%, and so on.
+type FailureDetectorConfig struct {
+	HeartbeatInterval        time.Duration
+	SuspectThreshold         float64
+	Threshold                float64
+	MinStdDeviation          time.Duration
+	AcceptableHeartbeatPause time.Duration
+	MaxSampleSize            int
+}
+
+func NewFailureDetectorConfig() *FailureDetectorConfig {
+	return &FailureDetectorConfig{
+		HeartbeatInterval:        time.Second,
+		SuspectThreshold:         3,
+		Threshold:                8,
+		MinStdDeviation:          100 * time.Millisecond,
+		AcceptableHeartbeatPause: time.Second,
+		MaxSampleSize:            200,
+	}
+}
+
+// WithHeartbeatInterval sets how often members send heartbeats to each other
+func (config *FailureDetectorConfig) WithHeartbeatInterval(interval time.Duration) *FailureDetectorConfig {
+	config.HeartbeatInterval = interval
+	return config
+}
+
+// WithThreshold sets the phi from which a member is unreachable
+func (config *FailureDetectorConfig) WithThreshold(threshold float64) *FailureDetectorConfig {
+	config.Threshold = threshold
+	return config
+}
+
+// WithSuspectThreshold sets the phi from which a member is suspect
+func (config *FailureDetectorConfig) WithSuspectThreshold(threshold float64) *FailureDetectorConfig {
+	config.SuspectThreshold = threshold
+	return config
+}
+
+// WithMinStdDeviation sets the minimum deviation of the heartbeat intervals, so that small variations
+// of perfectly regular heartbeats do not make members suspect
+func (config *FailureDetectorConfig) WithMinStdDeviation(d time.Duration) *FailureDetectorConfig {
+	config.MinStdDeviation = d
+	return config
+}
+
+// WithAcceptableHeartbeatPause sets how long heartbeats may be late without raising the suspicion level much,
+// e.g. during garbage collection pauses
+func (config *FailureDetectorConfig) WithAcceptableHeartbeatPause(d time.Duration) *FailureDetectorConfig {
+	config.AcceptableHeartbeatPause = d
+	return config
+}
+
+func (config *FailureDetectorConfig) reachability(phi float64) Reachability {
+	switch {
+	case phi >= config.Threshold:
+		return Unreachable
+	case phi >= config.SuspectThreshold:
+		return Suspect
+	}
+	return Reachable
+}
+
+// phiAccrualFailureDetector computes the suspicion level of a member from the intervals between its heartbeats,
+// as described in "The φ Accrual Failure Detector" by Hayashibara et al.
+type phiAccrualFailureDetector struct {
+	config        *FailureDetectorConfig
+	intervals     []float64
+	sum           float64
+	squaredSum    float64
+	lastHeartbeat time.Time
+}
+
+func newPhiAccrualFailureDetector(config *FailureDetectorConfig, now time.Time) *phiAccrualFailureDetector {
+	d := &phiAccrualFailureDetector{
+		config:        config,
+		lastHeartbeat: now,
+	}
+	// until the first heartbeats arrive, expect them at the configured interval
+	mean := float64(config.HeartbeatInterval)
+	d.addInterval(mean - mean/4)
+	d.addInterval(mean + mean/4)
+	return d
+}
+
+func (d *phiAccrualFailureDetector) heartbeat(now time.Time) {
+	d.addInterval(float64(now.Sub(d.lastHeartbeat)))
+	d.lastHeartbeat = now
+}
+
+func (d *phiAccrualFailureDetector) addInterval(interval float64) {
+	if max := d.config.MaxSampleSize; max > 0 && len(d.intervals) >= max {
+		dropped := d.intervals[0]
+		d.intervals = d.intervals[1:]
+		d.sum -= dropped
+		d.squaredSum -= dropped * dropped
+	}
+	d.intervals = append(d.intervals, interval)
+	d.sum += interval
+	d.squaredSum += interval * interval
+}
+
+func (d *phiAccrualFailureDetector) phi(now time.Time) float64 {
+	n := float64(len(d.intervals))
+	mean := d.sum / n
+	stdDeviation := math.Sqrt(math.Max(d.squaredSum/n-mean*mean, 0))
+	stdDeviation = math.Max(stdDeviation, float64(d.config.MinStdDeviation))
+	mean += float64(d.config.AcceptableHeartbeatPause)
+
+	// logistic approximation of the cumulative normal distribution
+	elapsed := float64(now.Sub(d.lastHeartbeat))
+	y := (elapsed - mean) / stdDeviation
+	e := math.Exp(-y * (1.5976 + 0.070566*y*y))
+	if elapsed > mean {
+		return -math.Log10(e / (1 + e))
+	}
+	return -math.Log10(1 - 1/(1+e))
+}
+
+type heartbeatTick struct{}
+
+// heartbeatActor sends heartbeats to all other members, and updates their reachability from the heartbeats it receives
+type heartbeatActor struct {
+	cluster   *Cluster
+	config    *FailureDetectorConfig
+	detectors map[string]*phiAccrualFailureDetector
+	stop      chan struct{}
+}
+
+func setupFailureDetector(cluster *Cluster) *actor.PID {
+	if cluster.Config.FailureDetector == nil {
+		return nil
+	}
+
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &heartbeatActor{
+			cluster:   cluster,
+			config:    cluster.Config.FailureDetector,
+			detectors: make(map[string]*phiAccrualFailureDetector),
+		}
+	}).WithGuardian(actor.RestartingSupervisorStrategy())
+	pid, _ := cluster.ActorSystem.Root.SpawnNamed(props, heartbeatActorName)
+	return pid
+}
+
+func (a *heartbeatActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		a.stop = make(chan struct{})
+		system, self, stop := ctx.ActorSystem(), ctx.Self(), a.stop
+		go func() {
+			ticker := time.NewTicker(a.config.HeartbeatInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					system.Root.Send(self, &heartbeatTick{})
+				}
+			}
+		}()
+	case *actor.Stopping, *actor.Restarting:
+		close(a.stop)
+	case *heartbeatTick:
+		a.tick(ctx)
+	case *Heartbeat:
+		if d, ok := a.detectors[msg.Address]; ok {
+			now := time.Now()
+			d.heartbeat(now)
+			a.update(msg.Address, d.phi(now))
+		}
+	}
+}
+
+func (a *heartbeatActor) tick(ctx actor.Context) {
+	now := time.Now()
+	self := ctx.ActorSystem().Address()
+	members := a.cluster.MemberList.getAllMembers()
+
+	alive := make(map[string]bool, len(members))
+	for _, address := range members {
+		if address == self {
+			continue
+		}
+		alive[address] = true
+		ctx.Send(actor.NewPID(address, heartbeatActorName), &Heartbeat{Address: self})
+
+		d, ok := a.detectors[address]
+		if !ok {
+			a.detectors[address] = newPhiAccrualFailureDetector(a.config, now)
+			continue
+		}
+		a.update(address, d.phi(now))
+	}
+
+	for address := range a.detectors {
+		if !alive[address] {
+			delete(a.detectors, address)
+		}
+	}
+}
+
+func (a *heartbeatActor) update(address string, phi float64) {
+	member, previous := a.cluster.MemberList.updateReachability(address, phi, a.config.reachability(phi))
+	if member == nil || member.Reachability == previous {
+		return
+	}
+
+	meta := MemberMeta{
+		Host:  member.Host,
+		Port:  member.Port,
+		Kinds: member.Kinds,
+	}
+	plog.Info("Member reachability changed", log.String("address", address), log.Stringer("reachability", member.Reachability),
+		log.Float64("phi", phi))
+	var evt interface{}
+	switch member.Reachability {
+	case Suspect:
+		evt = &MemberSuspectEvent{MemberMeta: meta, Phi: phi}
+	case Unreachable:
+		evt = &MemberUnreachableEvent{MemberMeta: meta, Phi: phi}
+	default:
+		evt = &MemberReachableEvent{MemberMeta: meta, Phi: phi}
+	}
+	a.cluster.ActorSystem.EventStream.Publish(evt)
+}
@@ -0,0 +1,181 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// heartbeats feeds a detector with heartbeats at the given intervals, and returns the time of the last one
+func heartbeats(d *phiAccrualFailureDetector, now time.Time, intervals ...time.Duration) time.Time {
+	for _, interval := range intervals {
+		now = now.Add(interval)
+		d.heartbeat(now)
+	}
+	return now
+}
+
+func repeat(n int, intervals ...time.Duration) []time.Duration {
+	res := make([]time.Duration, 0, n*len(intervals))
+	for i := 0; i < n; i++ {
+		res = append(res, intervals...)
+	}
+	return res
+}
+
+func TestPhiAccrualFailureDetector_PhiGrowsWithMissedHeartbeats(t *testing.T) {
+	config := NewFailureDetectorConfig().WithHeartbeatInterval(100 * time.Millisecond).
+		WithMinStdDeviation(10 * time.Millisecond).WithAcceptableHeartbeatPause(0)
+	start := time.Now()
+	d := newPhiAccrualFailureDetector(config, start)
+	last := heartbeats(d, start, repeat(50, 95*time.Millisecond, 105*time.Millisecond)...)
+
+	assert.Less(t, d.phi(last.Add(100*time.Millisecond)), 1.0)
+	previous := 0.0
+	for _, delay := range []time.Duration{110, 130, 150, 200, 300} {
+		phi := d.phi(last.Add(delay * time.Millisecond))
+		assert.Greater(t, phi, previous, "delay %vms", delay)
+		previous = phi
+	}
+	assert.Equal(t, Unreachable, config.reachability(d.phi(last.Add(300*time.Millisecond))))
+}
+
+func TestPhiAccrualFailureDetector_AdaptsToIrregularHeartbeats(t *testing.T) {
+	config := NewFailureDetectorConfig().WithHeartbeatInterval(100 * time.Millisecond).
+		WithMinStdDeviation(10 * time.Millisecond).WithAcceptableHeartbeatPause(0)
+	start := time.Now()
+	regular := newPhiAccrualFailureDetector(config, start)
+	regularLast := heartbeats(regular, start, repeat(50, 100*time.Millisecond)...)
+	jittery := newPhiAccrualFailureDetector(config, start)
+	jitteryLast := heartbeats(jittery, start, repeat(50, 50*time.Millisecond, 150*time.Millisecond)...)
+
+	// the same delay is less suspicious from a member whose heartbeats are known to be late at times
+	assert.Equal(t, Unreachable, config.reachability(regular.phi(regularLast.Add(200*time.Millisecond))))
+	assert.Equal(t, Reachable, config.reachability(jittery.phi(jitteryLast.Add(200*time.Millisecond))))
+}
+
+func TestPhiAccrualFailureDetector_AcceptableHeartbeatPause(t *testing.T) {
+	config := NewFailureDetectorConfig().WithHeartbeatInterval(100 * time.Millisecond).
+		WithMinStdDeviation(10 * time.Millisecond).WithAcceptableHeartbeatPause(time.Second)
+	start := time.Now()
+	d := newPhiAccrualFailureDetector(config, start)
+	last := heartbeats(d, start, repeat(50, 100*time.Millisecond)...)
+
+	assert.Equal(t, Reachable, config.reachability(d.phi(last.Add(time.Second))))
+	assert.Equal(t, Unreachable, config.reachability(d.phi(last.Add(1500*time.Millisecond))))
+}
+
+// reachabilityEvents returns the reachability events of c
+func reachabilityEvents(c *Cluster) chan MemberStatusEvent {
+	events := make(chan MemberStatusEvent, 100)
+	c.ActorSystem.EventStream.Subscribe(func(evt interface{}) {
+		switch evt := evt.(type) {
+		case *MemberSuspectEvent, *MemberUnreachableEvent, *MemberReachableEvent:
+			events <- evt.(MemberStatusEvent)
+		}
+	})
+	return events
+}
+
+func expectReachability(t *testing.T, events chan MemberStatusEvent, address string, expected MemberStatusEvent) {
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case evt := <-events:
+			if evt.(interface{ Name() string }).Name() == address && sameType(evt, expected) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no %T for %v", expected, address)
+		}
+	}
+}
+
+func sameType(a, b interface{}) bool {
+	switch a.(type) {
+	case *MemberSuspectEvent:
+		_, ok := b.(*MemberSuspectEvent)
+		return ok
+	case *MemberUnreachableEvent:
+		_, ok := b.(*MemberUnreachableEvent)
+		return ok
+	case *MemberReachableEvent:
+		_, ok := b.(*MemberReachableEvent)
+		return ok
+	}
+	return false
+}
+
+func startFailureDetectorMembers(t *testing.T, n int) (*remotetest.Network, []*Cluster) {
+	network := remotetest.NewNetwork()
+	membership := newTestMembership()
+	configure := func(config *Config) {
+		config.WithFailureDetector(NewFailureDetectorConfig().WithHeartbeatInterval(50 * time.Millisecond).
+			WithMinStdDeviation(20 * time.Millisecond).WithAcceptableHeartbeatPause(50 * time.Millisecond))
+	}
+	kind, _, _ := countingKind(0)
+	members := make([]*Cluster, n)
+	for i := range members {
+		members[i] = startConfiguredTestMember(t, network, membership, configure, kind)
+	}
+	return network, members
+}
+
+func memberStatus(c *Cluster, address string) *MemberStatus {
+	for _, m := range c.Members() {
+		if m.Address() == address {
+			return m
+		}
+	}
+	return nil
+}
+
+func TestFailureDetector_DelayedHeartbeatsMakeMembersUnreachable(t *testing.T) {
+	network, members := startFailureDetectorMembers(t, 2)
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+	other := members[1].ActorSystem.Address()
+	events := reachabilityEvents(members[0])
+
+	// let the detectors learn the heartbeat interval
+	time.Sleep(300 * time.Millisecond)
+	status := memberStatus(members[0], other)
+	require.NotNil(t, status)
+	assert.Equal(t, Reachable, status.Reachability)
+	assert.Less(t, status.Phi, 1.0)
+
+	// the member may become unreachable without being suspect in between two heartbeats
+	network.SetLatency(time.Second)
+	expectReachability(t, events, other, &MemberUnreachableEvent{})
+	assert.Greater(t, memberStatus(members[0], other).Phi, 8.0)
+
+	// the delayed heartbeats arrive in the end
+	network.SetLatency(0)
+	expectReachability(t, events, other, &MemberReachableEvent{})
+	assert.Equal(t, Reachable, memberStatus(members[0], other).Reachability)
+}
+
+func TestFailureDetector_ActivationsAvoidSuspectMembers(t *testing.T) {
+	network, members := startFailureDetectorMembers(t, 3)
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	isolated := members[2].ActorSystem.Address()
+	network.Partition(members[0].ActorSystem.Address(), isolated)
+	network.Partition(members[1].ActorSystem.Address(), isolated)
+	require.Eventually(t, func() bool {
+		return memberStatus(members[0], isolated).Reachability != Reachable
+	}, 2*time.Second, time.Millisecond)
+
+	// the provider still lists the member
+	assert.Contains(t, members[0].MemberAddresses("counter"), isolated)
+	for i := 0; i < 20; i++ {
+		assert.NotEqual(t, isolated, members[0].MemberList.getActivatorMember("counter"))
+	}
+}
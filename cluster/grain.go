@@ -1,6 +1,15 @@
 package cluster
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+)
+
+// IdempotencyKeyHeader is the message header carrying the idempotency key of a grain call
+const IdempotencyKeyHeader = "cluster-idempotency-key"
 
 type Grain struct {
 	id string
@@ -14,10 +23,18 @@ func (g *Grain) Init(id string) {
 	g.id = id
 }
 
+// IdempotencyKey returns the idempotency key of the grain call that sent the current message.
+// Retried calls carry the same key, so that grains can ignore requests they have already processed.
+func IdempotencyKey(ctx actor.Context) string {
+	return ctx.MessageHeader().Get(IdempotencyKeyHeader)
+}
+
 type GrainCallOptions struct {
-	RetryCount  int
-	Timeout     time.Duration
-	RetryAction func(n int)
+	RetryCount     int
+	Timeout        time.Duration
+	RetryAction    func(n int)
+	Retryable      func(err error) bool
+	IdempotencyKey string
 }
 
 var defaultGrainCallOptions *GrainCallOptions
@@ -37,6 +54,7 @@ func NewGrainCallOptions(cluster *Cluster) *GrainCallOptions {
 			i++
 			time.Sleep(time.Duration(i * i * 50))
 		},
+		Retryable: IsRetryable,
 	}
 }
 
@@ -54,3 +72,38 @@ func (config *GrainCallOptions) WithRetryAction(act func(i int)) *GrainCallOptio
 	config.RetryAction = act
 	return config
 }
+
+// WithRetryBackoff waits before each retry, doubling the wait after every failed attempt
+func (config *GrainCallOptions) WithRetryBackoff(backoff time.Duration) *GrainCallOptions {
+	config.RetryAction = func(i int) {
+		time.Sleep(backoff << uint(i))
+	}
+	return config
+}
+
+// WithRetryable sets the function deciding which errors are retried
+func (config *GrainCallOptions) WithRetryable(retryable func(err error) bool) *GrainCallOptions {
+	config.Retryable = retryable
+	return config
+}
+
+// WithIdempotencyKey sends key with every attempt of the call, see IdempotencyKey
+func (config *GrainCallOptions) WithIdempotencyKey(key string) *GrainCallOptions {
+	config.IdempotencyKey = key
+	return config
+}
+
+// IsRetryable is the default classifier of retryable errors.
+// It retries the errors caused by grains that are moving or unreachable for a moment,
+// and the errors the grains respond as retryable with RespondError.
+func IsRetryable(err error) bool {
+	switch err {
+	case actor.ErrTimeout, remote.ErrTimeout, remote.ErrDeadLetter, remote.ErrUnAvailable, remote.ErrOwnerChanged:
+		return true
+	}
+	if errors.Is(err, actor.ErrDeadLetter) {
+		return true
+	}
+	var actorErr *actor.ActorError
+	return errors.As(err, &actorErr) && actorErr.Retryable
+}
@@ -37,6 +37,10 @@ type GrainContext interface {
 	// Spawn starts a new child actor based on props and named with a unique id
 	Spawn(props *actor.Props) *actor.PID
 
+	// SpawnChecked starts a new child actor based on props and named with a unique id, it returns the error Spawn
+	// panics with
+	SpawnChecked(props *actor.Props) (*actor.PID, error)
+
 	// SpawnPrefix starts a new child actor based on props and named using a prefix followed by a unique id
 	SpawnPrefix(props *actor.Props, prefix string) *actor.PID
 
@@ -0,0 +1,192 @@
+package cluster
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"golang.org/x/net/context"
+)
+
+// ErrGrainStreamTerminated is returned by GrainStream.Recv when the grain stopped before ending the stream
+var ErrGrainStreamTerminated = errors.New("grain stream terminated")
+
+// CallStream sends msg to a grain method streaming its responses, see GrainStreamWriter.
+// Only resolving the grain is retried as configured by callopts, the request itself is sent once.
+func (c *Cluster) CallStream(ctx context.Context, name string, kind string, msg interface{}, callopts ...*GrainCallOptions) (*GrainStream, error) {
+	_callopts := c.callOptions(kind, callopts...)
+	retryable := _callopts.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	var pid *actor.PID
+	for i := 0; pid == nil; i++ {
+		if i > 0 && _callopts.RetryAction != nil {
+			_callopts.RetryAction(i - 1)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var statusCode remote.ResponseStatusCode
+		pid, statusCode = c.Get(name, kind)
+		if statusCode != remote.ResponseStatusCodeOK && statusCode != remote.ResponseStatusCodePROCESSNAMEALREADYEXIST {
+			err := statusCode.AsError()
+			if !retryable(err) || i+1 >= _callopts.RetryCount {
+				return nil, err
+			}
+			pid = nil
+		}
+	}
+
+	s := newGrainStream(ctx, c.ActorSystem, pid)
+	c.ActorSystem.Root.RequestWithCustomSender(pid, msg, s.pid)
+	return s, nil
+}
+
+// GrainStream receives the responses of a streaming grain method
+type GrainStream struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	system    *actor.ActorSystem
+	pid       *actor.PID
+	items     chan interface{}
+	err       error
+	closeOnce sync.Once
+}
+
+func newGrainStream(ctx context.Context, system *actor.ActorSystem, grain *actor.PID) *GrainStream {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &GrainStream{
+		ctx:    ctx,
+		cancel: cancel,
+		system: system,
+		items:  make(chan interface{}),
+	}
+
+	props := actor.PropsFromFunc(func(actx actor.Context) {
+		switch msg := actx.Message().(type) {
+		case *actor.Started:
+			actx.Watch(grain)
+		case *GrainStreamItem:
+			s.push(msg)
+		case *GrainStreamEnd, *GrainErrorResponse:
+			s.push(msg)
+			actx.Stop(actx.Self())
+		case *actor.Terminated:
+			s.push(ErrGrainStreamTerminated)
+			actx.Stop(actx.Self())
+		}
+	})
+	s.pid = system.Root.Spawn(props)
+
+	go func() {
+		<-ctx.Done()
+		system.Root.Stop(s.pid)
+	}()
+	return s
+}
+
+func (s *GrainStream) push(item interface{}) {
+	select {
+	case s.items <- item:
+	case <-s.ctx.Done():
+	}
+}
+
+// Recv returns the data of the next response, or io.EOF when the grain method returned
+func (s *GrainStream) Recv() ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	select {
+	case item := <-s.items:
+		switch item := item.(type) {
+		case *GrainStreamItem:
+			return item.MessageData, nil
+		case *GrainStreamEnd:
+			s.err = io.EOF
+		case *GrainErrorResponse:
+			s.err = errors.New(item.Err)
+		case error:
+			s.err = item
+		}
+	case <-s.ctx.Done():
+		s.err = s.ctx.Err()
+	}
+	s.Close()
+	return nil, s.err
+}
+
+// Close stops receiving the responses, the grain method is notified through the context of its GrainStreamWriter
+func (s *GrainStream) Close() {
+	s.closeOnce.Do(s.cancel)
+}
+
+// GrainStreamWriter sends the responses of a streaming grain method to its caller.
+// Its context is done when the caller closed the stream or is gone.
+type GrainStreamWriter struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	system  *actor.ActorSystem
+	target  *actor.PID
+	watcher *actor.PID
+}
+
+// NewGrainStreamWriter creates a writer sending responses to target, the sender of the request
+func NewGrainStreamWriter(system *actor.ActorSystem, target *actor.PID) *GrainStreamWriter {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &GrainStreamWriter{
+		ctx:    ctx,
+		cancel: cancel,
+		system: system,
+		target: target,
+	}
+	if target == nil {
+		cancel()
+		return w
+	}
+
+	w.watcher = system.Root.Spawn(actor.PropsFromFunc(func(actx actor.Context) {
+		switch actx.Message().(type) {
+		case *actor.Started:
+			actx.Watch(target)
+		case *actor.Terminated:
+			cancel()
+		}
+	}))
+	return w
+}
+
+// Context returns the context of the stream
+func (w *GrainStreamWriter) Context() context.Context {
+	return w.ctx
+}
+
+// Send sends the data of a response, it fails when the context of the stream is done
+func (w *GrainStreamWriter) Send(data []byte) error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	w.system.Root.Send(w.target, &GrainStreamItem{MessageData: data})
+	return nil
+}
+
+// Close ends the stream, with err when it is not nil
+func (w *GrainStreamWriter) Close(err error) {
+	if w.ctx.Err() == nil {
+		if err != nil {
+			w.system.Root.Send(w.target, &GrainErrorResponse{Err: err.Error()})
+		} else {
+			w.system.Root.Send(w.target, &GrainStreamEnd{})
+		}
+	}
+	w.cancel()
+	if w.watcher != nil {
+		w.system.Root.Stop(w.watcher)
+	}
+}
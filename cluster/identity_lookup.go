@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"errors"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/AsynkronIT/protoactor-go/remote"
+)
+
+// IdentityLookup finds the activations of grains, and activates grains that have none
+type IdentityLookup interface {
+	// Setup starts the lookup for a cluster member hosting kinds, or for a cluster client
+	Setup(cluster *Cluster, kinds []string, isClient bool)
+
+	// Shutdown stops the lookup
+	Shutdown()
+
+	// Get returns the activation of the grain, activating it when necessary
+	Get(name string, kind string) (*actor.PID, remote.ResponseStatusCode)
+
+	// RemovePid forgets the activation of a grain, it is called when the grain passivates
+	RemovePid(name string, kind string, pid *actor.PID)
+}
+
+// partitionIdentityLookup is the default IdentityLookup.
+// It places the grains of each kind with the PlacementStrategy selected for the kind, partitions by default.
+type partitionIdentityLookup struct {
+	cluster *Cluster
+}
+
+func newPartitionIdentityLookup(cluster *Cluster) *partitionIdentityLookup {
+	return &partitionIdentityLookup{cluster: cluster}
+}
+
+func (l *partitionIdentityLookup) Setup(cluster *Cluster, kinds []string, isClient bool) {
+	// for each known kind, spin up a partition-kind actor to handle all requests for that kind
+	cluster.partitionValue = setupPartition(cluster, kinds)
+	if !isClient {
+		cluster.rendezvous = setupRendezvous(cluster)
+	}
+}
+
+func (l *partitionIdentityLookup) Shutdown() {
+	l.cluster.partitionValue.stopPartition()
+	if l.cluster.rendezvous != nil {
+		l.cluster.rendezvous.stopRendezvous()
+	}
+}
+
+func (l *partitionIdentityLookup) Get(name string, kind string) (*actor.PID, remote.ResponseStatusCode) {
+	return l.cluster.placementStrategy(kind).Get(l.cluster, name, kind)
+}
+
+func (l *partitionIdentityLookup) RemovePid(name string, kind string, pid *actor.PID) {
+	l.cluster.placementStrategy(kind).RemovePid(l.cluster, name, kind, pid)
+}
+
+func getFromPartition(c *Cluster, name string, kind string) (*actor.PID, remote.ResponseStatusCode) {
+	// Get Pid
+	address := c.MemberList.getPartitionMember(name, kind)
+	if address == "" {
+		// No available member found
+		return nil, remote.ResponseStatusCodeUNAVAILABLE
+	}
+
+	// package the request as a remote.ActorPidRequest
+	req := &remote.ActorPidRequest{
+		Kind: kind,
+		Name: name,
+	}
+
+	// ask the DHT partition for this name to give us a PID
+	remotePartition := c.partitionValue.partitionForKind(address, kind)
+	r, err := c.ActorSystem.Root.RequestFuture(remotePartition, req, c.Config.TimeoutTime).Result()
+	if err == actor.ErrTimeout {
+		plog.Error("PidCache Pid request timeout", log.String("remote", remotePartition.String()))
+		return nil, remote.ResponseStatusCodeTIMEOUT
+	} else if errors.Is(err, actor.ErrDeadLetter) {
+		// the partition actor is not there, the request failed without waiting for the timeout
+		return nil, remote.ResponseStatusCodeDeadLetter
+	} else if err != nil {
+		plog.Error("PidCache Pid request error", log.Error(err), log.String("remote", remotePartition.String()))
+		return nil, remote.ResponseStatusCodeERROR
+	}
+
+	response, ok := r.(*remote.ActorPidResponse)
+	if !ok {
+		return nil, remote.ResponseStatusCodeERROR
+	}
+	return response.Pid, remote.ResponseStatusCode(response.StatusCode)
+}
@@ -0,0 +1,47 @@
+package cluster
+
+import (
+	"errors"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// ErrClientHostsNoKinds is returned when registering a kind on a cluster client
+var ErrClientHostsNoKinds = errors.New("cluster: a client hosts no kinds")
+
+// RegisterKind makes the started member activate kind. The provider is told the kinds of the member when it is a
+// KindsClusterProvider, the other members place the kind on the member once it reported them. The options of the
+// kinds registered at runtime which are read from Config.Kinds, e.g. their dedup window, do not apply
+func (c *Cluster) RegisterKind(kind *Kind) error {
+	if c.remote == nil || c.isClient {
+		return ErrClientHostsNoKinds
+	}
+	c.remote.RegisterKind(kind.remoteKind())
+	if c.partitionValue != nil {
+		c.partitionValue.addKind(kind.Kind)
+	}
+	c.updateProviderKinds()
+	return nil
+}
+
+// UnregisterKind stops the activations of kind on the member, the grains activated keep running until they are
+// passivated. The provider is told like in RegisterKind
+func (c *Cluster) UnregisterKind(kind string) error {
+	if c.remote == nil || c.isClient {
+		return ErrClientHostsNoKinds
+	}
+	c.remote.UnregisterKind(kind)
+	c.updateProviderKinds()
+	return nil
+}
+
+func (c *Cluster) updateProviderKinds() {
+	provider, ok := c.Config.ClusterProvider.(KindsClusterProvider)
+	if !ok {
+		plog.Info("the cluster provider cannot update the kinds of the member", log.TypeOf("provider", c.Config.ClusterProvider))
+		return
+	}
+	if err := provider.UpdateKinds(c.GetClusterKinds()); err != nil {
+		plog.Error("failed to update the kinds of the member", log.Error(err))
+	}
+}
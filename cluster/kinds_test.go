@@ -0,0 +1,34 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCluster_RegisterKindAtRuntime(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	other := NewKind("other", actor.PropsFromFunc(func(ctx actor.Context) {}))
+	c1 := startTestMember(t, network, membership, other)
+	defer c1.Shutdown(false)
+	c2 := startTestMember(t, network, membership, other)
+	defer c2.Shutdown(false)
+
+	kind, activations, _ := countingKind(0)
+	require.NoError(t, c2.RegisterKind(kind))
+	assert.Contains(t, c2.GetClusterKinds(), "counter")
+
+	// the member hosting the kind activates it, whichever member is called
+	for _, c := range []*Cluster{c1, c2} {
+		res, err := c.Call("grain", "counter", &GrainRequest{})
+		require.NoError(t, err)
+		assert.Greater(t, count(t, res), 0)
+	}
+	assert.Equal(t, int32(1), *activations)
+
+	require.NoError(t, c2.UnregisterKind("counter"))
+	assert.NotContains(t, c2.GetClusterKinds(), "counter")
+}
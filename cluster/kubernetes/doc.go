@@ -0,0 +1,34 @@
+/*
+Package kubernetes provides a cluster provider that derives membership from pods.
+
+Every member annotates its own pod with the actor port and the kinds it hosts, and labels it
+with the cluster name. Members then watch the pods matching the label selector in their namespace:
+a ready pod is an alive member, a pod that is not ready is a member that is unavailable,
+and a deleted pod is a member that has left.
+
+The remote must be configured to listen on, or advertise, the pod IP.
+
+The service account of the pods needs the following permissions in its namespace:
+
+	apiVersion: rbac.authorization.k8s.io/v1
+	kind: Role
+	metadata:
+	  name: protoactor
+	rules:
+	- apiGroups: [""]
+	  resources: ["pods"]
+	  verbs: ["get", "list", "watch", "patch"]
+	---
+	apiVersion: rbac.authorization.k8s.io/v1
+	kind: RoleBinding
+	metadata:
+	  name: protoactor
+	subjects:
+	- kind: ServiceAccount
+	  name: default
+	roleRef:
+	  kind: Role
+	  name: protoactor
+	  apiGroup: rbac.authorization.k8s.io
+*/
+package kubernetes
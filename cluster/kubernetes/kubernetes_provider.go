@@ -0,0 +1,386 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/cluster"
+	"github.com/AsynkronIT/protoactor-go/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	LabelCluster       = "protoactor.io/cluster"
+	AnnotationPort     = "protoactor.io/port"
+	AnnotationKinds    = "protoactor.io/kinds"
+	AnnotationBanned   = "protoactor.io/banned"
+	AnnotationLeaving  = "protoactor.io/leaving"
+	AnnotationJoining  = "protoactor.io/joining"
+	AnnotationMetadata = "protoactor.io/metadata"
+	namespaceFilePath  = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+var (
+	ProviderShuttingDownError = fmt.Errorf("kubernetes cluster provider is shutting down")
+	plog                      = log.New(log.DebugLevel, "[CLUSTER] [KUBERNETES]")
+)
+
+type Provider struct {
+	cluster       *cluster.Cluster
+	client        kubernetes.Interface
+	namespace     string
+	podName       string
+	labelSelector string
+	retryDelay    time.Duration
+	clusterName   string
+	port          int
+	knownKinds    []string
+	metadata      map[string]string
+	isMember      bool
+	mutex         sync.Mutex
+	watcher       watch.Interface
+	members       map[types.UID]*cluster.MemberStatus
+	shutdown      bool
+}
+
+// New creates a Provider for the pod it runs in, using the in-cluster configuration.
+// The pod name is read from the HOSTNAME environment variable.
+func New(labelSelector string) (*Provider, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := ioutil.ReadFile(namespaceFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithClient(client, strings.TrimSpace(string(namespace)), os.Getenv("HOSTNAME"), labelSelector), nil
+}
+
+// NewWithClient creates a Provider for the pod podName in namespace.
+// Members are the pods matching labelSelector that are labeled with the cluster name.
+func NewWithClient(client kubernetes.Interface, namespace string, podName string, labelSelector string) *Provider {
+	return &Provider{
+		client:        client,
+		namespace:     namespace,
+		podName:       podName,
+		labelSelector: labelSelector,
+		retryDelay:    1 * time.Second,
+		members:       make(map[types.UID]*cluster.MemberStatus),
+	}
+}
+
+func (p *Provider) init(c *cluster.Cluster) error {
+	_, port, err := c.ActorSystem.GetHostPort()
+	if err != nil {
+		return err
+	}
+
+	p.cluster = c
+	p.clusterName = c.Config.Name
+	p.port = port
+	p.knownKinds = c.GetClusterKinds()
+	p.metadata = c.Config.Metadata
+	return nil
+}
+
+func (p *Provider) StartMember(c *cluster.Cluster) error {
+	if err := p.init(c); err != nil {
+		return err
+	}
+	p.isMember = true
+
+	if err := p.registerPod(); err != nil {
+		return err
+	}
+	return p.monitorMemberStatusChanges()
+}
+
+func (p *Provider) StartClient(c *cluster.Cluster) error {
+	if err := p.init(c); err != nil {
+		return err
+	}
+	return p.monitorMemberStatusChanges()
+}
+
+func (p *Provider) Shutdown(graceful bool) error {
+	p.mutex.Lock()
+	if p.shutdown {
+		p.mutex.Unlock()
+		return nil
+	}
+	p.shutdown = true
+	watcher := p.watcher
+	p.mutex.Unlock()
+
+	if watcher != nil {
+		watcher.Stop()
+	}
+	if !graceful || !p.isMember {
+		return nil
+	}
+
+	// without the port annotation, the pod is no longer a member
+	return p.patchPod(nil, map[string]interface{}{
+		AnnotationPort:     nil,
+		AnnotationKinds:    nil,
+		AnnotationLeaving:  nil,
+		AnnotationJoining:  nil,
+		AnnotationMetadata: nil,
+	})
+}
+
+// Ready removes the joining annotation, the other members start placing grains on the pod
+func (p *Provider) Ready() error {
+	if p.isShutdown() {
+		return ProviderShuttingDownError
+	}
+	return p.patchPod(nil, map[string]interface{}{AnnotationJoining: nil})
+}
+
+// Leave marks the pod as leaving, the other members stop placing grains on it
+func (p *Provider) Leave() error {
+	if p.isShutdown() {
+		return ProviderShuttingDownError
+	}
+	return p.patchPod(nil, map[string]interface{}{AnnotationLeaving: "true"})
+}
+
+// UpdateKinds annotates the pod with the kinds of the member
+func (p *Provider) UpdateKinds(kinds []string) error {
+	if p.isShutdown() {
+		return ProviderShuttingDownError
+	}
+	p.mutex.Lock()
+	p.knownKinds = kinds
+	p.mutex.Unlock()
+	return p.patchPod(nil, map[string]interface{}{AnnotationKinds: strings.Join(kinds, ",")})
+}
+
+func (p *Provider) UpdateClusterState(state cluster.ClusterState) error {
+	if p.isShutdown() {
+		return ProviderShuttingDownError
+	}
+	value, err := json.Marshal(state.BannedMembers)
+	if err != nil {
+		plog.Error("Failed to UpdateClusterState. json.Marshal", log.Error(err))
+		return err
+	}
+	if err := p.patchPod(nil, map[string]interface{}{AnnotationBanned: string(value)}); err != nil {
+		plog.Error("Failed to UpdateClusterState.", log.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (p *Provider) isShutdown() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.shutdown
+}
+
+func (p *Provider) registerPod() error {
+	metadata, err := json.Marshal(p.metadata)
+	if err != nil {
+		return err
+	}
+	annotations := map[string]interface{}{
+		AnnotationPort:     strconv.Itoa(p.port),
+		AnnotationKinds:    strings.Join(p.knownKinds, ","),
+		AnnotationMetadata: string(metadata),
+	}
+	// the pod is joining until the cluster is ready
+	if !p.cluster.IsReady() {
+		annotations[AnnotationJoining] = "true"
+	}
+	return p.patchPod(map[string]interface{}{LabelCluster: p.clusterName}, annotations)
+}
+
+func (p *Provider) patchPod(podLabels map[string]interface{}, annotations map[string]interface{}) error {
+	metadata := map[string]interface{}{}
+	if podLabels != nil {
+		metadata["labels"] = podLabels
+	}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return err
+	}
+	_, err = p.client.CoreV1().Pods(p.namespace).Patch(p.podName, types.MergePatchType, patch)
+	return err
+}
+
+func (p *Provider) selector() (labels.Selector, error) {
+	selector, err := labels.Parse(p.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	clusterSelector := labels.SelectorFromSet(labels.Set{LabelCluster: p.clusterName})
+	requirements, _ := clusterSelector.Requirements()
+	return selector.Add(requirements...), nil
+}
+
+// monitorMemberStatusChanges lists the current pods and then follows changes to them
+func (p *Provider) monitorMemberStatusChanges() error {
+	selector, err := p.selector()
+	if err != nil {
+		return err
+	}
+	resourceVersion, err := p.loadMembers(selector)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for !p.isShutdown() {
+			p.watchMembers(selector, resourceVersion)
+			if p.isShutdown() {
+				return
+			}
+
+			// the watch ended, resync
+			time.Sleep(p.retryDelay)
+			if resourceVersion, err = p.loadMembers(selector); err != nil {
+				plog.Error("Failed to list pods", log.Error(err))
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *Provider) loadMembers(selector labels.Selector) (string, error) {
+	pods, err := p.client.CoreV1().Pods(p.namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", err
+	}
+
+	members := make(map[types.UID]*cluster.MemberStatus)
+	for i := range pods.Items {
+		if m := memberFromPod(&pods.Items[i]); m != nil {
+			members[pods.Items[i].UID] = m
+		}
+	}
+	p.mutex.Lock()
+	p.members = members
+	p.mutex.Unlock()
+
+	p.publishTopology()
+	return pods.ResourceVersion, nil
+}
+
+func (p *Provider) watchMembers(selector labels.Selector, resourceVersion string) {
+	watcher, err := p.client.CoreV1().Pods(p.namespace).Watch(metav1.ListOptions{
+		LabelSelector:   selector.String(),
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		plog.Error("Failed to watch pods", log.Error(err))
+		return
+	}
+
+	p.mutex.Lock()
+	if p.shutdown {
+		p.mutex.Unlock()
+		watcher.Stop()
+		return
+	}
+	p.watcher = watcher
+	p.mutex.Unlock()
+
+	for ev := range watcher.ResultChan() {
+		pod, ok := ev.Object.(*v1.Pod)
+		if !ok {
+			plog.Error("Pod watch failed", log.Object("event", ev.Object))
+			watcher.Stop()
+			return
+		}
+
+		p.mutex.Lock()
+		switch ev.Type {
+		case watch.Added, watch.Modified:
+			// the labels may have changed so that the pod no longer matches
+			if m := memberFromPod(pod); m != nil && selector.Matches(labels.Set(pod.Labels)) {
+				p.members[pod.UID] = m
+			} else {
+				delete(p.members, pod.UID)
+			}
+		case watch.Deleted:
+			delete(p.members, pod.UID)
+		}
+		p.mutex.Unlock()
+
+		p.publishTopology()
+	}
+}
+
+// memberFromPod returns the member status of a pod, or nil when the pod is not a member
+func memberFromPod(pod *v1.Pod) *cluster.MemberStatus {
+	if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" {
+		return nil
+	}
+	port, err := strconv.Atoi(pod.Annotations[AnnotationPort])
+	if err != nil {
+		return nil
+	}
+	var kinds []string
+	if k := pod.Annotations[AnnotationKinds]; k != "" {
+		kinds = strings.Split(k, ",")
+	}
+
+	var metadata map[string]string
+	if m := pod.Annotations[AnnotationMetadata]; m != "" {
+		if err := json.Unmarshal([]byte(m), &metadata); err != nil {
+			plog.Error("Failed to read the metadata of pod", log.String("pod", pod.Name), log.Error(err))
+		}
+	}
+
+	return &cluster.MemberStatus{
+		MemberID: string(pod.UID),
+		Host:     pod.Status.PodIP,
+		Port:     port,
+		Kinds:    kinds,
+		Alive:    isReady(pod),
+		Leaving:  pod.Annotations[AnnotationLeaving] == "true",
+		Joining:  pod.Annotations[AnnotationJoining] == "true",
+		Metadata: metadata,
+	}
+}
+
+func isReady(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// publishTopology publishes the complete member list, so that the member list can work out who has left
+func (p *Provider) publishTopology() {
+	p.mutex.Lock()
+	res := make(cluster.TopologyEvent, 0, len(p.members))
+	for _, m := range p.members {
+		res = append(res, m)
+	}
+	p.mutex.Unlock()
+
+	p.cluster.ActorSystem.EventStream.Publish(res)
+}
@@ -0,0 +1,193 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/cluster"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testNamespace = "default"
+	testCluster   = "k8s-test"
+)
+
+func newPod(name string, ip string, ready bool, labels map[string]string, annotations map[string]string) *v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   testNamespace,
+			UID:         types.UID(name + "-uid"),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Status: v1.PodStatus{
+			PodIP:      ip,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+		},
+	}
+}
+
+func newMemberPod(name string, ip string, port int, ready bool) *v1.Pod {
+	return newPod(name, ip, ready,
+		map[string]string{"app": "test", LabelCluster: testCluster},
+		map[string]string{AnnotationPort: strconv.Itoa(port), AnnotationKinds: "kind"})
+}
+
+type memberEvents chan interface{}
+
+func (events memberEvents) expect(t *testing.T, address string, evt interface{}) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e := <-events:
+			if fmt.Sprintf("%T", e) == fmt.Sprintf("%T", evt) && e.(interface{ Name() string }).Name() == address {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("expected %T for %v", evt, address)
+		}
+	}
+}
+
+func startMember(t *testing.T, client *fake.Clientset) (*cluster.Cluster, *Provider, memberEvents) {
+	return startConfiguredMember(t, client, nil)
+}
+
+// startConfiguredMember starts a member after configure changed its config
+func startConfiguredMember(t *testing.T, client *fake.Clientset, configure func(*cluster.Config)) (*cluster.Cluster, *Provider, memberEvents) {
+	provider := NewWithClient(client, testNamespace, "self", "app=test")
+
+	system := actor.NewActorSystem()
+	events := make(memberEvents, 100)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		switch evt.(type) {
+		case *cluster.MemberJoinedEvent, *cluster.MemberLeftEvent, *cluster.MemberRejoinedEvent,
+			*cluster.MemberUnavailableEvent, *cluster.MemberAvailableEvent, *cluster.MemberLeavingEvent,
+			*cluster.MemberReadyEvent:
+			events <- evt
+		}
+	})
+
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	config := cluster.Configure(testCluster, provider, remote.Configure("127.0.0.1", 0), cluster.NewKind("kind", props))
+	if configure != nil {
+		configure(config)
+	}
+	c := cluster.New(system, config)
+	c.Start()
+
+	// the fake clientset doesn't replay events missed before the watch started
+	require.Eventually(t, func() bool {
+		provider.mutex.Lock()
+		defer provider.mutex.Unlock()
+		return provider.watcher != nil
+	}, time.Second, time.Millisecond)
+	return c, provider, events
+}
+
+func TestProvider_RegistersOwnPod(t *testing.T) {
+	client := fake.NewSimpleClientset(newPod("self", "127.0.0.1", true, map[string]string{"app": "test"}, nil))
+	c, _, events := startMember(t, client)
+	events.expect(t, c.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+
+	pod, err := client.CoreV1().Pods(testNamespace).Get("self", metav1.GetOptions{})
+	require.NoError(t, err)
+	_, port, _ := c.ActorSystem.GetHostPort()
+	assert.Equal(t, testCluster, pod.Labels[LabelCluster])
+	assert.Equal(t, strconv.Itoa(port), pod.Annotations[AnnotationPort])
+	assert.ElementsMatch(t, []string{"kind", cluster.TopicKind}, strings.Split(pod.Annotations[AnnotationKinds], ","))
+
+	c.Shutdown(true)
+	pod, err = client.CoreV1().Pods(testNamespace).Get("self", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, pod.Annotations, AnnotationPort)
+}
+
+func TestProvider_RegistersJoiningPodUntilReady(t *testing.T) {
+	client := fake.NewSimpleClientset(newPod("self", "127.0.0.1", true, map[string]string{"app": "test"}, nil))
+	c, _, events := startConfiguredMember(t, client, func(config *cluster.Config) {
+		config.WithManualReadiness(true)
+	})
+	defer c.Shutdown(false)
+	events.expect(t, c.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+
+	pod, err := client.CoreV1().Pods(testNamespace).Get("self", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "true", pod.Annotations[AnnotationJoining])
+	assert.True(t, memberFromPod(pod).Joining)
+
+	c.SetReady()
+	pod, err = client.CoreV1().Pods(testNamespace).Get("self", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, pod.Annotations, AnnotationJoining)
+	events.expect(t, c.ActorSystem.Address(), &cluster.MemberReadyEvent{})
+}
+
+func TestProvider_FollowsPodEvents(t *testing.T) {
+	client := fake.NewSimpleClientset(newPod("self", "127.0.0.1", true, map[string]string{"app": "test"}, nil))
+	c, _, events := startMember(t, client)
+	defer c.Shutdown(false)
+	events.expect(t, c.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+
+	pods := client.CoreV1().Pods(testNamespace)
+	_, err := pods.Create(newMemberPod("other", "10.0.0.2", 8080, true))
+	require.NoError(t, err)
+	events.expect(t, "10.0.0.2:8080", &cluster.MemberJoinedEvent{})
+
+	_, err = pods.Update(newMemberPod("other", "10.0.0.2", 8080, false))
+	require.NoError(t, err)
+	events.expect(t, "10.0.0.2:8080", &cluster.MemberUnavailableEvent{})
+
+	_, err = pods.Update(newMemberPod("other", "10.0.0.2", 8080, true))
+	require.NoError(t, err)
+	events.expect(t, "10.0.0.2:8080", &cluster.MemberAvailableEvent{})
+
+	leaving := newMemberPod("other", "10.0.0.2", 8080, true)
+	leaving.Annotations[AnnotationLeaving] = "true"
+	_, err = pods.Update(leaving)
+	require.NoError(t, err)
+	events.expect(t, "10.0.0.2:8080", &cluster.MemberLeavingEvent{})
+
+	require.NoError(t, pods.Delete("other", &metav1.DeleteOptions{}))
+	events.expect(t, "10.0.0.2:8080", &cluster.MemberLeftEvent{})
+}
+
+func TestProvider_IgnoresPodsOutsideSelector(t *testing.T) {
+	client := fake.NewSimpleClientset(newPod("self", "127.0.0.1", true, map[string]string{"app": "test"}, nil))
+	c, _, events := startMember(t, client)
+	defer c.Shutdown(false)
+	events.expect(t, c.ActorSystem.Address(), &cluster.MemberJoinedEvent{})
+
+	other := newMemberPod("other", "10.0.0.3", 8080, true)
+	other.Labels["app"] = "unrelated"
+	_, err := client.CoreV1().Pods(testNamespace).Create(other)
+	require.NoError(t, err)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, events, 0)
+}
+
+func TestMemberFromPod_ReadsMetadata(t *testing.T) {
+	pod := newMemberPod("other", "10.0.0.2", 8080, true)
+	pod.Annotations[AnnotationMetadata] = `{"gpu":"true","region":"us-east"}`
+	assert.Equal(t, map[string]string{"gpu": "true", "region": "us-east"}, memberFromPod(pod).Metadata)
+
+	pod.Annotations[AnnotationMetadata] = "not json"
+	assert.Empty(t, memberFromPod(pod).Metadata)
+}
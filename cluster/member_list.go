@@ -16,6 +16,7 @@ type memberListValue struct {
 	memberStrategyByKind map[string]MemberStrategy
 	membershipSub        *eventstream.Subscription
 	cluster              *Cluster
+	leaving              bool
 }
 
 func setupMemberList(cluster *Cluster) *memberListValue {
@@ -67,6 +68,30 @@ func (ml *memberListValue) getPartitionMember(name, kind string) string {
 	return res
 }
 
+// getRendezvousMember returns the alive member hosting kind with the highest rendezvous score for the grain
+func (ml *memberListValue) getRendezvousMember(name, kind string) string {
+	ml.mutex.RLock()
+	defer ml.mutex.RUnlock()
+
+	memberStrategy, ok := ml.memberStrategyByKind[kind]
+	if !ok {
+		return ""
+	}
+	var res string
+	var max uint64
+	for _, m := range memberStrategy.GetAllMembers() {
+		if !m.Alive {
+			continue
+		}
+		address := m.Address()
+		score := rendezvousScore(kind, name, address)
+		if res == "" || score > max || score == max && address < res {
+			res, max = address, score
+		}
+	}
+	return res
+}
+
 func (ml *memberListValue) getActivatorMember(kind string) string {
 	ml.mutex.RLock()
 	defer ml.mutex.RUnlock()
@@ -74,10 +99,75 @@ func (ml *memberListValue) getActivatorMember(kind string) string {
 	var res string
 	if memberStrategy, ok := ml.memberStrategyByKind[kind]; ok {
 		res = memberStrategy.GetActivator()
+		// skip the members the failure detector suspects, unless all are
+		for i := len(memberStrategy.GetAllMembers()); i > 1; i-- {
+			if m, ok := ml.members[res]; !ok || m.Reachability == Reachable {
+				break
+			}
+			res = memberStrategy.GetActivator()
+		}
+	}
+	return res
+}
+
+// getAllMembers returns the addresses of all alive members
+func (ml *memberListValue) getAllMembers() []string {
+	ml.mutex.RLock()
+	defer ml.mutex.RUnlock()
+
+	res := make([]string, 0, len(ml.members))
+	for address, m := range ml.members {
+		if m.Alive {
+			res = append(res, address)
+		}
+	}
+	return res
+}
+
+// getMemberStatuses returns copies of the statuses of all members
+func (ml *memberListValue) getMemberStatuses() []*MemberStatus {
+	ml.mutex.RLock()
+	defer ml.mutex.RUnlock()
+
+	res := make([]*MemberStatus, 0, len(ml.members))
+	for _, m := range ml.members {
+		status := *m
+		res = append(res, &status)
 	}
 	return res
 }
 
+// updateReachability sets the suspicion level of a member, it returns a copy of its status and its previous reachability
+func (ml *memberListValue) updateReachability(address string, phi float64, reachability Reachability) (*MemberStatus, Reachability) {
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	m, ok := ml.members[address]
+	if !ok {
+		return nil, reachability
+	}
+	previous := m.Reachability
+	m.Phi = phi
+	m.Reachability = reachability
+	status := *m
+	return &status, previous
+}
+
+// leave marks this member as leaving, whatever the provider reports from now on
+func (ml *memberListValue) leave() {
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	ml.leaving = true
+	if old, ok := ml.members[ml.cluster.ActorSystem.Address()]; ok && !old.Leaving {
+		new := *old
+		new.Leaving = true
+		ml.members[new.Address()] = &new
+		ml.updateAndNotify(&new, old)
+		ml.placeConstrainedKinds()
+	}
+}
+
 func (ml *memberListValue) updateClusterTopology(m interface{}) {
 	ml.mutex.Lock()
 	defer ml.mutex.Unlock()
@@ -99,11 +189,21 @@ func (ml *memberListValue) updateClusterTopology(m interface{}) {
 	}
 
 	// find all the entries that exist in the new set
+	self := ml.cluster.ActorSystem.Address()
 	for key, new := range tmp {
+		if ml.leaving && key == self {
+			new.Leaving = true
+		}
 		old := ml.members[key]
+		if old != nil {
+			// the reachability is not known to the provider
+			new.Phi = old.Phi
+			new.Reachability = old.Reachability
+		}
 		ml.members[key] = new
 		ml.updateAndNotify(new, old)
 	}
+	ml.placeConstrainedKinds()
 }
 
 // updateAndNotify updates the member strategy and notifies all listeners. This function may only be called with an
@@ -115,14 +215,7 @@ func (ml *memberListValue) updateAndNotify(new *MemberStatus, old *MemberStatus)
 	}
 	if new == nil {
 		// update MemberStrategy
-		for _, k := range old.Kinds {
-			if s, ok := ml.memberStrategyByKind[k]; ok {
-				s.RemoveMember(old)
-				if len(s.GetAllMembers()) == 0 {
-					delete(ml.memberStrategyByKind, k)
-				}
-			}
-		}
+		ml.removeFromStrategies(old)
 
 		// notify left
 		meta := MemberMeta{
@@ -142,12 +235,9 @@ func (ml *memberListValue) updateAndNotify(new *MemberStatus, old *MemberStatus)
 		return
 	}
 	if old == nil {
-		// update MemberStrategy
-		for _, k := range new.Kinds {
-			if _, ok := ml.memberStrategyByKind[k]; !ok {
-				ml.memberStrategyByKind[k] = ml.cluster.Config.MemberStrategyBuilder(k)
-			}
-			ml.memberStrategyByKind[k].AddMember(new)
+		// update MemberStrategy, joining and leaving members get no new grains
+		if placeable(new) {
+			ml.addToStrategies(new)
 		}
 
 		// notify joined
@@ -162,9 +252,44 @@ func (ml *memberListValue) updateAndNotify(new *MemberStatus, old *MemberStatus)
 		return
 	}
 
-	// update MemberStrategy
-	if new.Alive != old.Alive || new.MemberID != old.MemberID || new.StatusValue != nil && !new.StatusValue.IsSame(old.StatusValue) {
+	if new.Leaving && !old.Leaving {
+		ml.removeFromStrategies(old)
+
+		// notify leaving
+		meta := MemberMeta{
+			Host:  new.Host,
+			Port:  new.Port,
+			Kinds: new.Kinds,
+		}
+		leaving := &MemberLeavingEvent{MemberMeta: meta}
+		ml.cluster.ActorSystem.EventStream.PublishUnsafe(leaving)
+
+		return
+	}
+	if placeable(new) && !placeable(old) {
+		ml.addToStrategies(new)
+	} else if placeable(new) && !sameKinds(old.Kinds, new.Kinds) {
+		// the member registered or unregistered kinds at runtime
+		ml.removeFromStrategies(old)
+		ml.addToStrategies(new)
+	}
+	if old.Joining && !new.Joining {
+		// notify ready, the identities the member owns are handed over to it
+		meta := MemberMeta{
+			Host:  new.Host,
+			Port:  new.Port,
+			Kinds: new.Kinds,
+		}
+		ready := &MemberReadyEvent{MemberMeta: meta}
+		ml.cluster.ActorSystem.EventStream.PublishUnsafe(ready)
+	}
+
+	// update MemberStrategy, joining and leaving members are not part of it
+	if placeable(new) && (new.Alive != old.Alive || new.MemberID != old.MemberID || new.StatusValue != nil && !new.StatusValue.IsSame(old.StatusValue)) {
 		for _, k := range new.Kinds {
+			if ml.placement(k) != nil {
+				continue
+			}
 			if _, ok := ml.memberStrategyByKind[k]; !ok {
 				ml.memberStrategyByKind[k] = ml.cluster.Config.MemberStrategyBuilder(k)
 			}
@@ -207,3 +332,112 @@ func (ml *memberListValue) updateAndNotify(new *MemberStatus, old *MemberStatus)
 		ml.cluster.ActorSystem.EventStream.PublishUnsafe(available)
 	}
 }
+
+func sameKinds(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	kinds := make(map[string]bool, len(a))
+	for _, k := range a {
+		kinds[k] = true
+	}
+	for _, k := range b {
+		if !kinds[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// placeable tells whether grains can be placed on the member, it is neither joining nor leaving
+func placeable(m *MemberStatus) bool {
+	return !m.Joining && !m.Leaving
+}
+
+func (ml *memberListValue) addToStrategies(m *MemberStatus) {
+	for _, k := range m.Kinds {
+		if ml.placement(k) != nil {
+			continue
+		}
+		if _, ok := ml.memberStrategyByKind[k]; !ok {
+			ml.memberStrategyByKind[k] = ml.cluster.Config.MemberStrategyBuilder(k)
+		}
+		ml.memberStrategyByKind[k].AddMember(m)
+	}
+}
+
+func (ml *memberListValue) removeFromStrategies(m *MemberStatus) {
+	for _, k := range m.Kinds {
+		if ml.placement(k) != nil {
+			continue
+		}
+		if s, ok := ml.memberStrategyByKind[k]; ok {
+			s.RemoveMember(m)
+			if len(s.GetAllMembers()) == 0 {
+				delete(ml.memberStrategyByKind, k)
+			}
+		}
+	}
+}
+
+func (ml *memberListValue) placement(kind string) *Placement {
+	return ml.cluster.Config.Placements[kind]
+}
+
+// placeConstrainedKinds re-evaluates the members of the kinds with a placement, whose strategies only hold the
+// members satisfying it
+func (ml *memberListValue) placeConstrainedKinds() {
+	for kind, placement := range ml.cluster.Config.Placements {
+		candidates := placement.candidates(kind, ml.members)
+		s, ok := ml.memberStrategyByKind[kind]
+		if ok && sameMembers(s.GetAllMembers(), candidates) {
+			continue
+		}
+		if len(candidates) == 0 {
+			delete(ml.memberStrategyByKind, kind)
+			continue
+		}
+		s = ml.cluster.Config.MemberStrategyBuilder(kind)
+		for _, m := range candidates {
+			s.AddMember(m)
+		}
+		ml.memberStrategyByKind[kind] = s
+	}
+}
+
+// placementError returns a PlacementError when members host kind but none satisfies its placement
+func (ml *memberListValue) placementError(kind string) error {
+	ml.mutex.RLock()
+	defer ml.mutex.RUnlock()
+
+	placement := ml.placement(kind)
+	if placement == nil {
+		return nil
+	}
+	hosted := false
+	for _, m := range ml.members {
+		if !m.Alive || m.Leaving || !hasKind(m, kind) {
+			continue
+		}
+		if placement.allows(m) {
+			return nil
+		}
+		hosted = true
+	}
+	if !hosted {
+		return nil
+	}
+	return &PlacementError{Kind: kind, Placement: placement}
+}
+
+func sameMembers(a, b []*MemberStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Address() != b[i].Address() || a[i].MemberID != b[i].MemberID || a[i].Alive != b[i].Alive {
+			return false
+		}
+	}
+	return true
+}
@@ -9,6 +9,17 @@ type MemberStatus struct {
 	Kinds       []string
 	Alive       bool
 	StatusValue MemberStatusValue
+	// Phi is the suspicion level computed by the failure detector, see FailureDetectorConfig
+	Phi          float64
+	Reachability Reachability
+	// Leaving members are shutting down gracefully, see Cluster.Shutdown.
+	// They neither own identities nor activate grains, but keep serving the grains they host until these moved.
+	Leaving bool
+	// Joining members are starting, they neither own identities nor activate grains until they are ready,
+	// see Cluster.SetReady
+	Joining bool
+	// Metadata are the labels the member registered with the provider, see Config.WithMetadata and Placement
+	Metadata map[string]string
 }
 
 func (m *MemberStatus) Address() string {
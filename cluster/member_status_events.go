@@ -39,6 +39,20 @@ type MemberLeftEvent struct {
 
 func (*MemberLeftEvent) MemberStatusEvent() {}
 
+// MemberLeavingEvent is published when a member starts to shut down gracefully, before it leaves
+type MemberLeavingEvent struct {
+	MemberMeta
+}
+
+func (*MemberLeavingEvent) MemberStatusEvent() {}
+
+// MemberReadyEvent is published when a joining member becomes ready, from then on it owns identities and activates grains
+type MemberReadyEvent struct {
+	MemberMeta
+}
+
+func (*MemberReadyEvent) MemberStatusEvent() {}
+
 type MemberUnavailableEvent struct {
 	MemberMeta
 }
@@ -50,3 +64,24 @@ type MemberAvailableEvent struct {
 }
 
 func (*MemberAvailableEvent) MemberStatusEvent() {}
+
+type MemberSuspectEvent struct {
+	MemberMeta
+	Phi float64
+}
+
+func (*MemberSuspectEvent) MemberStatusEvent() {}
+
+type MemberUnreachableEvent struct {
+	MemberMeta
+	Phi float64
+}
+
+func (*MemberUnreachableEvent) MemberStatusEvent() {}
+
+type MemberReachableEvent struct {
+	MemberMeta
+	Phi float64
+}
+
+func (*MemberReachableEvent) MemberStatusEvent() {}
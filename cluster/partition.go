@@ -1,6 +1,10 @@
 package cluster
 
 import (
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/eventstream"
 	"github.com/AsynkronIT/protoactor-go/log"
@@ -8,9 +12,20 @@ import (
 )
 
 type partitionValue struct {
+	kindsMutex        sync.RWMutex
 	kindPIDMap        map[string]*actor.PID
 	partitionKindsSub *eventstream.Subscription
 	cluster           *Cluster
+	rebalanced        int64
+	forwarded         int64
+}
+
+// PartitionStats counts the work done moving identities between members on topology changes
+type PartitionStats struct {
+	// RebalancedIdentities is the number of identities handed over to a new owner
+	RebalancedIdentities int64
+	// ForwardedRequests is the number of requests forwarded to the new owner during a handoff
+	ForwardedRequests int64
 }
 
 func setupPartition(cluster *Cluster, kinds []string) *partitionValue {
@@ -27,8 +42,7 @@ func setupPartition(cluster *Cluster, kinds []string) *partitionValue {
 	partition.partitionKindsSub = cluster.ActorSystem.EventStream.Subscribe(func(m interface{}) {
 		if mse, ok := m.(MemberStatusEvent); ok {
 			for _, k := range mse.GetKinds() {
-				kindPID := partition.kindPIDMap[k]
-				if kindPID != nil {
+				if kindPID := partition.kindPID(k); kindPID != nil {
 					cluster.ActorSystem.Root.Send(kindPID, m)
 				}
 			}
@@ -38,18 +52,75 @@ func setupPartition(cluster *Cluster, kinds []string) *partitionValue {
 	return partition
 }
 
-func (p *partitionValue) stopPartition() {
+// kindPID returns the partition of kind on this member, nil when it hosts no kind
+func (p *partitionValue) kindPID(kind string) *actor.PID {
+	p.kindsMutex.RLock()
+	defer p.kindsMutex.RUnlock()
+	return p.kindPIDMap[kind]
+}
+
+// kindPIDs returns the partitions of the kinds on this member
+func (p *partitionValue) kindPIDs() []*actor.PID {
+	p.kindsMutex.RLock()
+	defer p.kindsMutex.RUnlock()
+	pids := make([]*actor.PID, 0, len(p.kindPIDMap))
 	for _, kindPID := range p.kindPIDMap {
+		pids = append(pids, kindPID)
+	}
+	return pids
+}
+
+// addKind starts the partition of a kind registered at runtime, the partitions of the kinds unregistered keep
+// the identities they own
+func (p *partitionValue) addKind(kind string) {
+	p.kindsMutex.Lock()
+	defer p.kindsMutex.Unlock()
+	if _, ok := p.kindPIDMap[kind]; !ok {
+		p.kindPIDMap[kind] = p.spawnPartitionActor(kind)
+	}
+}
+
+func (p *partitionValue) stopPartition() {
+	for _, kindPID := range p.kindPIDs() {
 		p.cluster.ActorSystem.Root.StopFuture(kindPID).Wait()
 	}
 	p.cluster.ActorSystem.EventStream.Unsubscribe(p.partitionKindsSub)
 }
 
+func (p *partitionValue) stats() PartitionStats {
+	return PartitionStats{
+		RebalancedIdentities: atomic.LoadInt64(&p.rebalanced),
+		ForwardedRequests:    atomic.LoadInt64(&p.forwarded),
+	}
+}
+
+// handedOver tells whether the partitions of this member own no identities anymore
+func (p *partitionValue) handedOver() bool {
+	for _, kindPID := range p.kindPIDs() {
+		res, err := p.cluster.ActorSystem.Root.RequestFuture(kindPID, &ownedIdentitiesRequest{}, p.cluster.Config.TimeoutTime).Result()
+		if n, ok := res.(int); err != nil || !ok || n > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *partitionValue) partitionForKind(address, kind string) *actor.PID {
 	pid := actor.NewPID(address, "partition-"+kind)
 	return pid
 }
 
+type pendingRequest struct {
+	msg    *remote.ActorPidRequest
+	sender *actor.PID
+}
+
+// handoffExpired is sent by the partition to itself when the handoff grace period ends
+type handoffExpired struct{}
+
+// ownedIdentitiesRequest asks a partition for the number of identities it owns or is activating
+type ownedIdentitiesRequest struct{}
+
 type spawningProcess struct {
 	*actor.Future
 	spawningAddress string
@@ -61,6 +132,8 @@ type partitionActor struct {
 	keyNameMap     map[string]string           // actor/grain key to name
 	spawnings      map[string]*spawningProcess // spawning actor/grain futures
 	kind           string
+	handoffUntil   time.Time        // requests for identities that moved are forwarded until then
+	pending        []pendingRequest // requests waiting for the topology during the handoff
 }
 
 func (p *partitionValue) spawnPartitionActor(kind string) *actor.PID {
@@ -85,22 +158,36 @@ func (state *partitionActor) Receive(context actor.Context) {
 	switch msg := context.Message().(type) {
 	case *actor.Started:
 		plog.Info("Started", log.String("kind", state.kind), log.String("id", context.Self().Id))
+		// the member doesn't know the topology yet
+		state.startHandoff()
 	case *remote.ActorPidRequest:
 		state.spawn(msg, context)
 	case *actor.Terminated:
 		state.terminated(msg)
+	case *handoffExpired:
+		state.retryPending(context)
 	case *TakeOwnership:
 		state.takeOwnership(msg, context)
+	case *PassivateGrain:
+		state.passivated(msg, context)
+	case *ownedIdentitiesRequest:
+		context.Respond(len(state.partition) + len(state.spawnings) + len(state.pending))
 	case *MemberJoinedEvent:
 		state.memberJoined(msg, context)
 	case *MemberRejoinedEvent:
 		state.memberRejoined(msg, context)
 	case *MemberLeftEvent:
 		state.memberLeft(msg, context)
+	case *MemberLeavingEvent:
+		state.memberLeaving(msg, context)
+	case *MemberReadyEvent:
+		state.memberReady(msg, context)
 	case *MemberAvailableEvent:
 		plog.Info("Member available", log.String("kind", state.kind), log.String("name", msg.Name()))
 	case *MemberUnavailableEvent:
 		plog.Info("Member unavailable", log.String("kind", state.kind), log.String("name", msg.Name()))
+	case *MemberSuspectEvent, *MemberUnreachableEvent, *MemberReachableEvent:
+		// activations avoid suspect members, the partitions wait for the provider to remove them
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		// ignore
 	default:
@@ -131,9 +218,28 @@ func (state *partitionActor) spawn(msg *remote.ActorPidRequest, context actor.Co
 		return
 	}
 
+	// The identity moved to another member, the requester has an outdated view of the cluster
+	if owner := state.owner(msg.Name); owner != "" {
+		// a request is forwarded once, members whose views disagree would bounce it to each other
+		if time.Now().Before(state.handoffUntil) && !msg.Forwarded {
+			atomic.AddInt64(&state.partitionValue.forwarded, 1)
+			forwarded := &remote.ActorPidRequest{Name: msg.Name, Kind: msg.Kind, Forwarded: true}
+			envelope := &actor.MessageEnvelope{Header: context.MessageHeader().ToMap(), Message: forwarded, Sender: context.Sender()}
+			context.Send(state.partitionValue.partitionForKind(owner, state.kind), envelope)
+			return
+		}
+		context.Respond(remote.ActorPidRespOwnerChanged)
+		return
+	}
+
 	// Get activator
 	activator := state.partitionValue.cluster.MemberList.getActivatorMember(msg.Kind)
 	if activator == "" {
+		// The topology may be about to change, wait for it
+		if time.Now().Before(state.handoffUntil) {
+			state.buffer(msg, context)
+			return
+		}
 		// No activator currently available, return unavailable
 		context.Respond(remote.ActorPidRespUnavailable)
 		return
@@ -167,6 +273,11 @@ func (state *partitionActor) spawn(msg *remote.ActorPidRequest, context actor.Co
 			state.partition[msg.Name] = pid
 			state.keyNameMap[pid.String()] = msg.Name
 			context.Watch(pid)
+
+			// the identity may have moved while it was spawning
+			if owner := state.owner(msg.Name); owner != "" {
+				state.transferOwnership(msg.Name, owner, context)
+			}
 		}
 
 		context.Respond(response)
@@ -197,7 +308,10 @@ func (state *partitionActor) spawning(msg *remote.ActorPidRequest, activator str
 		return
 	}
 
-	if pidResp.StatusCode == remote.ResponseStatusCodeUNAVAILABLE.ToInt32() && retryLeft != 0 {
+	// the activator may be gone or over its quota, another one is asked
+	retryable := pidResp.StatusCode == remote.ResponseStatusCodeUNAVAILABLE.ToInt32() ||
+		pidResp.StatusCode == remote.ResponseStatusCodeQUOTAEXCEEDED.ToInt32()
+	if retryable && retryLeft != 0 {
 		retryLeft--
 		state.spawning(msg, "", retryLeft, fPid, context)
 		return
@@ -206,6 +320,37 @@ func (state *partitionActor) spawning(msg *remote.ActorPidRequest, activator str
 	context.Send(fPid, pidResp)
 }
 
+// owner returns the address of the member owning name, or an empty string when it is owned by this member
+func (state *partitionActor) owner(name string) string {
+	address := state.partitionValue.cluster.MemberList.getPartitionMember(name, state.kind)
+	if address == state.partitionValue.cluster.ActorSystem.Address() {
+		return ""
+	}
+	return address
+}
+
+func (state *partitionActor) startHandoff() {
+	state.handoffUntil = time.Now().Add(state.partitionValue.cluster.Config.HandoffGracePeriod)
+}
+
+func (state *partitionActor) buffer(msg *remote.ActorPidRequest, context actor.Context) {
+	if len(state.pending) == 0 {
+		self, system := context.Self(), context.ActorSystem()
+		time.AfterFunc(time.Until(state.handoffUntil), func() {
+			system.Root.Send(self, &handoffExpired{})
+		})
+	}
+	state.pending = append(state.pending, pendingRequest{msg: msg, sender: context.Sender()})
+}
+
+// retryPending processes the buffered requests again, with the current topology
+func (state *partitionActor) retryPending(context actor.Context) {
+	for _, p := range state.pending {
+		context.RequestWithCustomSender(context.Self(), p.msg, p.sender)
+	}
+	state.pending = nil
+}
+
 func (state *partitionActor) terminated(msg *actor.Terminated) {
 	// one of the actors we manage died, remove it from the lookup
 	key := msg.Who.String()
@@ -215,6 +360,15 @@ func (state *partitionActor) terminated(msg *actor.Terminated) {
 	}
 }
 
+func (state *partitionActor) passivated(msg *PassivateGrain, context actor.Context) {
+	// forget the passivating grain, so that the next request activates it again
+	if pid := state.partition[msg.Name]; pid != nil && pid.Equal(msg.Pid) {
+		delete(state.partition, msg.Name)
+		delete(state.keyNameMap, pid.String())
+		context.Unwatch(pid)
+	}
+}
+
 func (state *partitionActor) memberRejoined(msg *MemberRejoinedEvent, context actor.Context) {
 	memberAddress := msg.Name()
 
@@ -241,6 +395,8 @@ func (state *partitionActor) memberLeft(msg *MemberLeftEvent, context actor.Cont
 	memberAddress := msg.Name()
 
 	plog.Info("Member left", log.String("kind", state.kind), log.String("name", memberAddress))
+	state.startHandoff()
+	state.retryPending(context)
 
 	// If the left member is self, transfer remaining pids to others
 	if state.partitionValue.cluster.ActorSystem.Address() == memberAddress {
@@ -271,18 +427,31 @@ func (state *partitionActor) memberLeft(msg *MemberLeftEvent, context actor.Cont
 
 func (state *partitionActor) memberJoined(msg *MemberJoinedEvent, context actor.Context) {
 	plog.Info("Member joined", log.String("kind", state.kind), log.String("name", msg.Name()))
+	state.handOver(context)
+}
+
+func (state *partitionActor) memberReady(msg *MemberReadyEvent, context actor.Context) {
+	plog.Info("Member ready", log.String("kind", state.kind), log.String("name", msg.Name()))
+	// the identities are handed over once the member is ready, not when it joins
+	state.handOver(context)
+}
+
+func (state *partitionActor) memberLeaving(msg *MemberLeavingEvent, context actor.Context) {
+	plog.Info("Member leaving", log.String("kind", state.kind), log.String("name", msg.Name()))
+	// the activations on the leaving member are kept until it passivates them
+	state.handOver(context)
+}
+
+// handOver transfers the identities this member no longer owns to their new owners
+func (state *partitionActor) handOver(context actor.Context) {
+	state.startHandoff()
+	state.retryPending(context)
 	for actorID := range state.partition {
-		address := state.partitionValue.cluster.MemberList.getPartitionMember(actorID, state.kind)
-		if address != "" && address != state.partitionValue.cluster.ActorSystem.Address() {
+		if address := state.owner(actorID); address != "" {
 			state.transferOwnership(actorID, address, context)
 		}
 	}
-	for actorID, spawning := range state.spawnings {
-		address := state.partitionValue.cluster.MemberList.getPartitionMember(actorID, state.kind)
-		if address != "" && address != state.partitionValue.cluster.ActorSystem.Address() {
-			context.Send(spawning.PID(), remote.ActorPidRespUnavailable)
-		}
-	}
+	// identities that are still spawning are handed over once they are spawned
 }
 
 func (state *partitionActor) transferOwnership(actorID string, address string, context actor.Context) {
@@ -296,6 +465,7 @@ func (state *partitionActor) transferOwnership(actorID string, address string, c
 	delete(state.partition, actorID)
 	delete(state.keyNameMap, pid.String())
 	context.Unwatch(pid)
+	atomic.AddInt64(&state.partitionValue.rebalanced, 1)
 }
 
 func (state *partitionActor) takeOwnership(msg *TakeOwnership, context actor.Context) {
@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// movedIdentity returns a name that from sees as owned by to
+func movedIdentity(t *testing.T, from *Cluster, to *Cluster) string {
+	for i := 0; i < 1000; i++ {
+		name := fmt.Sprintf("grain-%v", i)
+		if from.MemberList.getPartitionMember(name, "counter") == to.ActorSystem.Address() {
+			return name
+		}
+	}
+	t.Fatal("no identity moved")
+	return ""
+}
+
+func requestPid(t *testing.T, c *Cluster, name string) *remote.ActorPidResponse {
+	partition := c.partitionValue.partitionForKind(c.ActorSystem.Address(), "counter")
+	res, err := c.ActorSystem.Root.RequestFuture(partition, &remote.ActorPidRequest{Kind: "counter", Name: name}, time.Second).Result()
+	require.NoError(t, err)
+	return res.(*remote.ActorPidResponse)
+}
+
+func TestPartition_ForwardsRequestsDuringHandoff(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	kind, _, _ := countingKind(0)
+	c1 := startTestMember(t, network, membership, kind)
+	defer c1.Shutdown(false)
+	c2 := startTestMember(t, network, membership, kind)
+	defer c2.Shutdown(false)
+
+	name := movedIdentity(t, c1, c2)
+	res := requestPid(t, c1, name)
+	assert.Equal(t, remote.ResponseStatusCodeOK.ToInt32(), res.StatusCode)
+	assert.Equal(t, int64(1), c1.PartitionStats().ForwardedRequests)
+}
+
+func TestPartition_ForwardsRequestsOnce(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	kind, _, _ := countingKind(0)
+	c1 := startTestMember(t, network, membership, kind)
+	defer c1.Shutdown(false)
+	c2 := startTestMember(t, network, membership, kind)
+	defer c2.Shutdown(false)
+
+	// a request forwarded by a member which sees c1 as the owner
+	name := movedIdentity(t, c1, c2)
+	partition := c1.partitionValue.partitionForKind(c1.ActorSystem.Address(), "counter")
+	res, err := c1.ActorSystem.Root.RequestFuture(partition, &remote.ActorPidRequest{Kind: "counter", Name: name, Forwarded: true}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, remote.ResponseStatusCodeOWNERCHANGED.ToInt32(), res.(*remote.ActorPidResponse).StatusCode)
+	assert.Equal(t, int64(0), c1.PartitionStats().ForwardedRequests)
+}
+
+func TestPartition_OwnerChangedAfterGracePeriod(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	kind, _, _ := countingKind(0)
+	c1 := startTestMember(t, network, membership, kind)
+	defer c1.Shutdown(false)
+	c1.Config.WithHandoffGracePeriod(0)
+	c2 := startTestMember(t, network, membership, kind)
+	defer c2.Shutdown(false)
+
+	name := movedIdentity(t, c1, c2)
+	res := requestPid(t, c1, name)
+	assert.Equal(t, remote.ResponseStatusCodeOWNERCHANGED.ToInt32(), res.StatusCode)
+	assert.Equal(t, int64(0), c1.PartitionStats().ForwardedRequests)
+}
+
+func TestPartition_HandsOverIdentitiesWhenMemberJoins(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	kind, activations, _ := countingKind(0)
+	c1 := startTestMember(t, network, membership, kind)
+	defer c1.Shutdown(false)
+	for i := 0; i < 20; i++ {
+		_, err := c1.Call(fmt.Sprintf("grain-%v", i), "counter", &GrainRequest{})
+		require.NoError(t, err)
+	}
+
+	c2 := startTestMember(t, network, membership, kind)
+	defer c2.Shutdown(false)
+	require.Eventually(t, func() bool {
+		return c1.PartitionStats().RebalancedIdentities > 0
+	}, time.Second, time.Millisecond)
+
+	// the new owner knows the existing activations
+	name := movedIdentity(t, c1, c2)
+	res := requestPid(t, c2, name)
+	assert.Equal(t, remote.ResponseStatusCodeOK.ToInt32(), res.StatusCode)
+	assert.Equal(t, int32(20), atomic.LoadInt32(activations))
+}
+
+func TestPartition_NoFailedRequestsWhileMemberJoinsUnderLoad(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	membership.skew = 20 * time.Millisecond
+	var members []*Cluster
+	for i := 0; i < 3; i++ {
+		kind, _, _ := countingKind(0)
+		c := startTestMember(t, network, membership, kind)
+		defer c.Shutdown(false)
+		members = append(members, c)
+	}
+
+	callopts := NewGrainCallOptions(members[0]).WithRetry(1).WithTimeout(5 * time.Second)
+	var wg sync.WaitGroup
+	var sent, failed int32
+	stop := make(chan struct{})
+	for i := range members {
+		wg.Add(1)
+		go func(c *Cluster) {
+			defer wg.Done()
+			for j := 0; ; j++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				// new identities always go through the partitions
+				name := fmt.Sprintf("%v-%v", c.ActorSystem.Address(), j)
+				atomic.AddInt32(&sent, 1)
+				res, err := c.Call(name, "counter", &GrainRequest{}, callopts)
+				if err != nil || res == nil {
+					atomic.AddInt32(&failed, 1)
+				}
+			}
+		}(members[i])
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	kind, _, _ := countingKind(0)
+	joined := startTestMember(t, network, membership, kind)
+	defer joined.Shutdown(false)
+	time.Sleep(200 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+
+	var rebalanced int64
+	for _, c := range members {
+		rebalanced += c.PartitionStats().RebalancedIdentities
+	}
+	assert.True(t, atomic.LoadInt32(&sent) > 0)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&failed))
+	assert.True(t, rebalanced > 0, "identities should have moved to the new member")
+}
@@ -0,0 +1,154 @@
+package cluster
+
+import (
+	"strings"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	cmap "github.com/orcaman/concurrent-map"
+)
+
+// activatedNamePrefix is the prefix the activator puts in front of the names of the actors it spawns
+const activatedNamePrefix = "Remote$"
+
+// grainName returns the grain name of an activated grain
+func grainName(pid *actor.PID) string {
+	if i := strings.Index(pid.Id, activatedNamePrefix); i >= 0 {
+		return pid.Id[i+len(activatedNamePrefix):]
+	}
+	return pid.Id
+}
+
+// passivationMiddleware stops grains that have been idle for timeout.
+//
+// A passivating grain first removes itself from its partition, so that new requests activate the grain again,
+// and then stops after processing the messages already in its mailbox.
+// Messages that still reach the stopped activation are redelivered to the new activation.
+func passivationMiddleware(kind string, timeout time.Duration) actor.ReceiverMiddleware {
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(ctx actor.ReceiverContext, env *actor.MessageEnvelope) {
+			switch env.Message.(type) {
+			case *actor.Started:
+				next(ctx, env)
+				if c, ok := ctx.(actor.Context); ok {
+					c.SetReceiveTimeout(timeout)
+				}
+			case *actor.ReceiveTimeout:
+				GetCluster(ctx.ActorSystem()).passivation.passivate(ctx.Self(), kind, actor.StopReason_Passivation)
+			default:
+				next(ctx, env)
+			}
+		}
+	}
+}
+
+type passivatedGrain struct {
+	name string
+	kind string
+}
+
+type redeliverRequest struct {
+	grain   *passivatedGrain
+	pid     *actor.PID
+	message interface{}
+	sender  *actor.PID
+}
+
+type passivationValue struct {
+	cluster *Cluster
+	// passivated grains by PID, kept for a grace period after the grain stopped
+	passivated    cmap.ConcurrentMap
+	redeliverer   *actor.PID
+	deadLetterSub *eventstream.Subscription
+}
+
+func setupPassivation(cluster *Cluster) *passivationValue {
+	passivation := &passivationValue{
+		cluster:    cluster,
+		passivated: cmap.New(),
+	}
+
+	props := actor.PropsFromProducer(newRedeliveryActor(cluster)).WithGuardian(actor.RestartingSupervisorStrategy())
+	passivation.redeliverer, _ = cluster.ActorSystem.Root.SpawnNamed(props, "PassivatedGrainRedelivery")
+
+	passivation.deadLetterSub = cluster.ActorSystem.SystemEventStream.Subscribe(passivation.onDeadLetter).
+		WithPredicate(func(m interface{}) bool {
+			_, ok := m.(*actor.DeadLetterEvent)
+			return ok
+		})
+
+	return passivation
+}
+
+func (p *passivationValue) stopPassivation() {
+	p.cluster.ActorSystem.SystemEventStream.Unsubscribe(p.deadLetterSub)
+	_ = p.cluster.ActorSystem.Root.StopFuture(p.redeliverer).Wait()
+}
+
+// passivate poisons the grain, which stops for reason
+func (p *passivationValue) passivate(pid *actor.PID, kind string, reason actor.StopReason) {
+	grain := &passivatedGrain{name: grainName(pid), kind: kind}
+	key := pid.String()
+	p.passivated.Set(key, grain)
+	time.AfterFunc(p.cluster.Config.TimeoutTime, func() {
+		p.passivated.Remove(key)
+	})
+
+	plog.Debug("Passivating grain", log.String("kind", kind), log.String("name", grain.name))
+
+	p.cluster.identityLookup.RemovePid(grain.name, kind, pid)
+	p.cluster.ActorSystem.Root.Send(pid, &actor.PoisonPill{Reason: reason})
+}
+
+func (p *passivationValue) onDeadLetter(evt interface{}) {
+	deadLetter := evt.(*actor.DeadLetterEvent)
+	switch deadLetter.Message.(type) {
+	case actor.SystemMessage, actor.AutoReceiveMessage:
+		return
+	}
+	if grain, ok := p.passivated.Get(deadLetter.PID.String()); ok {
+		deadLetter.Redeliver()
+		p.cluster.ActorSystem.Root.Send(p.redeliverer, &redeliverRequest{
+			grain:   grain.(*passivatedGrain),
+			pid:     deadLetter.PID,
+			message: deadLetter.Message,
+			sender:  deadLetter.Sender,
+		})
+	}
+}
+
+// redeliveryActor sends messages that reached passivated grains to their new activation, in order
+type redeliveryActor struct {
+	cluster *Cluster
+}
+
+func newRedeliveryActor(cluster *Cluster) actor.Producer {
+	return func() actor.Actor {
+		return &redeliveryActor{
+			cluster: cluster,
+		}
+	}
+}
+
+func (a *redeliveryActor) Receive(ctx actor.Context) {
+	msg, ok := ctx.Message().(*redeliverRequest)
+	if !ok {
+		return
+	}
+
+	a.cluster.pidCache.removeCacheByPid(msg.pid)
+	pid, statusCode := a.cluster.Get(msg.grain.name, msg.grain.kind)
+	if statusCode != remote.ResponseStatusCodeOK && statusCode != remote.ResponseStatusCodePROCESSNAMEALREADYEXIST {
+		plog.Error("Failed to redeliver message to passivated grain", log.String("kind", msg.grain.kind),
+			log.String("name", msg.grain.name), log.Error(statusCode.AsError()))
+		return
+	}
+	if msg.sender != nil {
+		ctx.RequestWithCustomSender(pid, msg.message, msg.sender)
+	} else {
+		ctx.Send(pid, msg.message)
+	}
+}
@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingGrain struct {
+	activations *int32
+	received    *int32
+	count       int
+}
+
+func countingKind(idleTimeout time.Duration) (*Kind, *int32, *int32) {
+	var activations, received int32
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &countingGrain{activations: &activations, received: &received}
+	})
+	return NewKind("counter", props).WithIdleTimeout(idleTimeout), &activations, &received
+}
+
+func (g *countingGrain) Receive(ctx actor.Context) {
+	switch ctx.Message().(type) {
+	case *actor.Started:
+		atomic.AddInt32(g.activations, 1)
+	case *GrainRequest:
+		atomic.AddInt32(g.received, 1)
+		g.count++
+		ctx.Respond(&GrainResponse{MessageData: []byte(strconv.Itoa(g.count))})
+	}
+}
+
+// count returns the count a countingGrain responded with
+func count(t *testing.T, res interface{}) int {
+	n, err := strconv.Atoi(string(res.(*GrainResponse).MessageData))
+	require.NoError(t, err)
+	return n
+}
+
+func TestPassivation_IdleGrainIsPassivated(t *testing.T) {
+	kind, activations, _ := countingKind(20 * time.Millisecond)
+	c := startTestMember(t, remotetest.NewNetwork(), newTestMembership(), kind)
+	defer c.Shutdown(false)
+
+	res, err := c.Call("grain", "counter", &GrainRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count(t, res))
+	res, err = c.Call("grain", "counter", &GrainRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count(t, res))
+
+	require.Eventually(t, func() bool {
+		_, ok := c.pidCache.getCache("grain")
+		return !ok
+	}, time.Second, time.Millisecond)
+
+	// a new activation starts from scratch
+	res, err = c.Call("grain", "counter", &GrainRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count(t, res))
+	assert.Equal(t, int32(2), atomic.LoadInt32(activations))
+}
+
+func TestPassivation_GrainWithoutIdleTimeoutStaysActivated(t *testing.T) {
+	kind, activations, _ := countingKind(0)
+	c := startTestMember(t, remotetest.NewNetwork(), newTestMembership(), kind)
+	defer c.Shutdown(false)
+
+	_, err := c.Call("grain", "counter", &GrainRequest{})
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	res, err := c.Call("grain", "counter", &GrainRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count(t, res))
+	assert.Equal(t, int32(1), atomic.LoadInt32(activations))
+}
+
+func TestPassivation_MessagesRacingTheDeadlineAreNotDropped(t *testing.T) {
+	const idleTimeout = 5 * time.Millisecond
+	kind, activations, received := countingKind(idleTimeout)
+	c := startTestMember(t, remotetest.NewNetwork(), newTestMembership(), kind)
+	defer c.Shutdown(false)
+
+	// no retries, a dropped message fails the call
+	callopts := NewGrainCallOptions(c).WithRetry(1).WithTimeout(2 * time.Second)
+	var wg sync.WaitGroup
+	var sent, failed int32
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for j := 0; j < 100; j++ {
+				atomic.AddInt32(&sent, 1)
+				res, err := c.Call("grain", "counter", &GrainRequest{}, callopts)
+				if err != nil || res == nil {
+					atomic.AddInt32(&failed, 1)
+				}
+				time.Sleep(time.Duration(rnd.Int63n(int64(2 * idleTimeout))))
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&failed))
+	assert.Equal(t, atomic.LoadInt32(&sent), atomic.LoadInt32(received))
+	assert.True(t, atomic.LoadInt32(activations) > 1, "the grain should have been passivated")
+}
+
+func TestPassivation_GrainStopReasons(t *testing.T) {
+	reasons := make(chan actor.StopReason, 10)
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Stopping:
+			reasons <- ctx.StopReason()
+		case *GrainRequest:
+			ctx.Respond(&GrainResponse{})
+		}
+	})
+	kind := NewKind("idle", props).WithIdleTimeout(20 * time.Millisecond)
+	c := startConfiguredTestMember(t, remotetest.NewNetwork(), newTestMembership(), func(config *Config) {
+		// the only member has no one to hand over its grains to
+		config.WithShutdownTimeout(100 * time.Millisecond)
+	}, kind)
+
+	_, err := c.Call("grain", "idle", &GrainRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, actor.StopReason_Passivation, <-reasons)
+
+	_, err = c.Call("grain", "idle", &GrainRequest{})
+	require.NoError(t, err)
+	c.Shutdown(true)
+	assert.Equal(t, actor.StopReason_SystemShutdown, <-reasons, "the grains of a leaving member are stopped for the shutdown")
+}
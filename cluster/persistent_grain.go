@@ -0,0 +1,27 @@
+package cluster
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/persistence"
+)
+
+// PersistenceID returns the name of the events and snapshots of the grain name of kind, see Kind.WithPersistence
+func PersistenceID(kind string, name string) string {
+	return kind + "/" + name
+}
+
+// WithPersistence persists the grains of the kind with provider, as configured by opts. The grains embed
+// persistence.Mixin and call PersistReceive and PersistSnapshot, their events and snapshots are named by PersistenceID.
+// An activation recovers before it processes its first request, the requests received meanwhile are stashed.
+// The grains are snapshotted when they stop with events persisted since their last snapshot, so that they recover
+// from the snapshot when they are activated again after a passivation or on another member.
+func (k *Kind) WithPersistence(provider persistence.Provider, opts ...persistence.Option) *Kind {
+	kind := k.Kind
+	k.persistence = persistence.Using(provider, append([]persistence.Option{
+		persistence.WithName(func(ctx actor.Context) string {
+			return PersistenceID(kind, grainName(ctx.Self()))
+		}),
+		persistence.WithSnapshotOnStop(),
+	}, opts...)...)
+	return k
+}
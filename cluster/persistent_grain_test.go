@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/persistence"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testProto struct{}
+
+func (*testProto) Reset()         {}
+func (*testProto) String() string { return "" }
+func (*testProto) ProtoMessage()  {}
+
+// incremented is the event of a persistentCounter
+type incremented struct{ testProto }
+
+// counted is the snapshot of a persistentCounter
+type counted struct {
+	testProto
+	count int
+}
+
+// persistentCounter is a grain counting the requests it received across its activations
+type persistentCounter struct {
+	persistence.Mixin
+	count       int
+	activations *int32
+}
+
+func (g *persistentCounter) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		atomic.AddInt32(g.activations, 1)
+	case *counted:
+		g.count = msg.count
+	case *incremented:
+		g.count++
+	case *persistence.RequestSnapshot:
+		g.PersistSnapshot(&counted{count: g.count})
+	case *GrainRequest:
+		g.PersistReceive(&incremented{})
+		g.count++
+		ctx.Respond(&GrainResponse{MessageData: []byte(strconv.Itoa(g.count))})
+	}
+}
+
+type inMemoryStore struct {
+	state persistence.ProviderState
+}
+
+func (s *inMemoryStore) GetState() persistence.ProviderState {
+	return s.state
+}
+
+func persistentCounterKind(store *inMemoryStore, idleTimeout time.Duration) (*Kind, *int32) {
+	var activations int32
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &persistentCounter{activations: &activations}
+	})
+	return NewKind("counter", props).WithIdleTimeout(idleTimeout).WithPersistence(store), &activations
+}
+
+func TestPersistentGrain_SurvivesPassivation(t *testing.T) {
+	store := &inMemoryStore{state: persistence.NewInMemoryProvider(100)}
+	kind, activations := persistentCounterKind(store, 50*time.Millisecond)
+	c := startTestMember(t, remotetest.NewNetwork(), newTestMembership(), kind)
+	defer c.Shutdown(false)
+
+	for i := 1; i <= 3; i++ {
+		res, err := c.Call("grain", "counter", &GrainRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, i, count(t, res))
+	}
+	require.Eventually(t, func() bool {
+		snapshot, _, ok := store.state.GetSnapshot(PersistenceID("counter", "grain"))
+		return ok && snapshot.(*counted).count == 3
+	}, time.Second, time.Millisecond, "the grain is snapshotted when it passivates")
+
+	res, err := c.Call("grain", "counter", &GrainRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, count(t, res))
+	assert.Equal(t, int32(2), atomic.LoadInt32(activations))
+}
+
+func TestPersistentGrain_SurvivesMoveToAnotherMember(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	store := &inMemoryStore{state: persistence.NewInMemoryProvider(100)}
+	kind, _ := persistentCounterKind(store, 0)
+	configure := func(config *Config) {
+		config.WithShutdownTimeout(2 * time.Second)
+	}
+	c1 := startConfiguredTestMember(t, network, membership, configure, kind)
+	defer c1.Shutdown(false)
+	c2 := startConfiguredTestMember(t, network, membership, configure, kind)
+
+	// a grain activated on the member leaving
+	var name string
+	for i := 0; name == ""; i++ {
+		require.Less(t, i, 100, "no grain activated on the second member")
+		res, err := c1.Call("grain-"+strconv.Itoa(i), "counter", &GrainRequest{})
+		require.NoError(t, err)
+		require.Equal(t, 1, count(t, res))
+		if pid, _ := c1.Get("grain-"+strconv.Itoa(i), "counter"); pid.Address == c2.ActorSystem.Address() {
+			name = "grain-" + strconv.Itoa(i)
+		}
+	}
+	res, err := c1.Call(name, "counter", &GrainRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 2, count(t, res))
+
+	c2.Shutdown(true)
+	res, err = c1.Call(name, "counter", &GrainRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count(t, res))
+	pid, _ := c1.Get(name, "counter")
+	assert.Equal(t, c1.ActorSystem.Address(), pid.Address)
+}
@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Placement constrains the members activating the grains of a kind, by the metadata they register with the provider.
+// Every member and client must configure the same placements, see Config.WithPlacement
+type Placement struct {
+	// Required labels must all be in the metadata of a member for it to activate or own the grains of the kind
+	Required map[string]string
+	// Preferred labels score the members satisfying the required ones, only the alive members with the highest
+	// score take part in the placement
+	Preferred []PreferredLabel
+}
+
+// PreferredLabel adds Weight to the score of the members whose metadata has Key set to Value
+type PreferredLabel struct {
+	Key    string
+	Value  string
+	Weight int
+}
+
+// NewPlacement returns a placement requiring the given labels
+func NewPlacement(required map[string]string) *Placement {
+	return &Placement{Required: required}
+}
+
+// WithPreferred adds a preferred label weighing weight
+func (p *Placement) WithPreferred(key, value string, weight int) *Placement {
+	p.Preferred = append(p.Preferred, PreferredLabel{Key: key, Value: value, Weight: weight})
+	return p
+}
+
+func (p *Placement) allows(m *MemberStatus) bool {
+	for key, value := range p.Required {
+		if v, ok := m.Metadata[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Placement) score(m *MemberStatus) int {
+	score := 0
+	for _, label := range p.Preferred {
+		if v, ok := m.Metadata[label.Key]; ok && v == label.Value {
+			score += label.Weight
+		}
+	}
+	return score
+}
+
+// candidates returns the members hosting kind that the placement allows and that score the highest
+func (p *Placement) candidates(kind string, members map[string]*MemberStatus) []*MemberStatus {
+	allowed := make([]*MemberStatus, 0)
+	top, alive := 0, false
+	for _, m := range members {
+		if !placeable(m) || !hasKind(m, kind) || !p.allows(m) {
+			continue
+		}
+		allowed = append(allowed, m)
+		// the dead members do not keep the alive ones from being preferred
+		if score := p.score(m); m.Alive && (!alive || score > top) {
+			top, alive = score, true
+		}
+	}
+
+	res := make([]*MemberStatus, 0, len(allowed))
+	for _, m := range allowed {
+		if !alive || p.score(m) >= top {
+			res = append(res, m)
+		}
+	}
+	// the partition hash and round robin depend on the order of the members
+	sortMembers(res)
+	return res
+}
+
+// PlacementError is returned by calls to grains of a kind whose placement no alive member hosting the kind satisfies
+type PlacementError struct {
+	Kind      string
+	Placement *Placement
+}
+
+func (e *PlacementError) Error() string {
+	return fmt.Sprintf("cluster: no member hosting kind %v satisfies its placement, required labels %v", e.Kind, e.Placement.Required)
+}
+
+func hasKind(m *MemberStatus, kind string) bool {
+	for _, k := range m.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func sortMembers(members []*MemberStatus) {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Address() < members[j].Address()
+	})
+}
@@ -0,0 +1,49 @@
+package cluster
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+)
+
+// PlacementStrategy finds the activations of the grains of the kinds it is selected for, and activates the grains
+// which have none. Every member and client must select the same strategies, see Config.WithPlacementStrategy
+type PlacementStrategy interface {
+	// Get returns the activation of the grain, activating it when necessary
+	Get(c *Cluster, name string, kind string) (*actor.PID, remote.ResponseStatusCode)
+
+	// RemovePid forgets the activation of a grain, it is called when the grain passivates
+	RemovePid(c *Cluster, name string, kind string, pid *actor.PID)
+}
+
+// NewPartitionPlacement returns the default strategy. Every identity is owned by the partition of the member its
+// name hashes to, which remembers its activation, activated on any member hosting the kind. The owners hand over
+// the identities on topology changes, the activations stay where they are
+func NewPartitionPlacement() PlacementStrategy {
+	return partitionPlacement{}
+}
+
+type partitionPlacement struct{}
+
+func (partitionPlacement) Get(c *Cluster, name string, kind string) (*actor.PID, remote.ResponseStatusCode) {
+	pid, statusCode := getFromPartition(c, name, kind)
+	if statusCode == remote.ResponseStatusCodeOWNERCHANGED {
+		// the identity moved to another member while the request was in flight, ask the new owner
+		pid, statusCode = getFromPartition(c, name, kind)
+	}
+	return pid, statusCode
+}
+
+func (partitionPlacement) RemovePid(c *Cluster, name string, kind string, pid *actor.PID) {
+	// requests for the grain sent after this message activate it again
+	if address := c.MemberList.getPartitionMember(name, kind); address != "" {
+		c.ActorSystem.Root.Send(c.partitionValue.partitionForKind(address, kind), &PassivateGrain{Pid: pid, Name: name})
+	}
+}
+
+// placementStrategy returns the strategy selected for kind
+func (c *Cluster) placementStrategy(kind string) PlacementStrategy {
+	if s, ok := c.Config.PlacementStrategies[kind]; ok {
+		return s
+	}
+	return NewPartitionPlacement()
+}
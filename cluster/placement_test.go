@@ -0,0 +1,99 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startLabeledMember starts a member hosting the counters constrained to gpu members
+func startLabeledMember(t *testing.T, network *remotetest.Network, membership *testMembership, metadata map[string]string) *Cluster {
+	kind, _, _ := countingKind(0)
+	return startConfiguredTestMember(t, network, membership, func(config *Config) {
+		config.WithMetadata(metadata).WithPlacement("counter", NewPlacement(map[string]string{"gpu": "true"}))
+	}, kind)
+}
+
+func TestPlacement_ActivatesOnlyOnQualifyingMembers(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	c1 := startLabeledMember(t, network, membership, map[string]string{"region": "us-east"})
+	defer c1.Shutdown(false)
+	c2 := startLabeledMember(t, network, membership, map[string]string{"region": "us-east", "gpu": "true"})
+	defer c2.Shutdown(false)
+	c3 := startLabeledMember(t, network, membership, map[string]string{"region": "eu-west"})
+	defer c3.Shutdown(false)
+
+	require.Eventually(t, func() bool { return len(c1.Members()) == 3 }, time.Second, time.Millisecond)
+	for _, m := range c1.Members() {
+		if m.Address() == c2.ActorSystem.Address() {
+			assert.Equal(t, "true", m.Metadata["gpu"])
+		}
+	}
+
+	for i := 0; i < 30; i++ {
+		name := fmt.Sprintf("grain-%v", i)
+		caller := []*Cluster{c1, c2, c3}[i%3]
+		_, err := caller.Call(name, "counter", &GrainRequest{})
+		require.NoError(t, err)
+
+		pid, statusCode := caller.Get(name, "counter")
+		require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+		assert.Equal(t, c2.ActorSystem.Address(), pid.Address, name)
+	}
+}
+
+func TestPlacement_FailsWhenNoMemberQualifies(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	c1 := startLabeledMember(t, network, membership, map[string]string{"region": "us-east"})
+	defer c1.Shutdown(false)
+	c2 := startLabeledMember(t, network, membership, map[string]string{"region": "eu-west"})
+	defer c2.Shutdown(false)
+
+	_, err := c1.Call("grain", "counter", &GrainRequest{})
+	var placementErr *PlacementError
+	require.True(t, errors.As(err, &placementErr), "%v", err)
+	assert.Equal(t, "counter", placementErr.Kind)
+	assert.EqualError(t, err, "cluster: no member hosting kind counter satisfies its placement, required labels map[gpu:true]")
+
+	// the placement is evaluated again when a qualifying member joins
+	c3 := startLabeledMember(t, network, membership, map[string]string{"gpu": "true"})
+	defer c3.Shutdown(false)
+	_, err = c1.Call("grain", "counter", &GrainRequest{})
+	require.NoError(t, err)
+	pid, _ := c1.Get("grain", "counter")
+	assert.Equal(t, c3.ActorSystem.Address(), pid.Address)
+}
+
+func TestPlacement_CandidatesScoredByPreferredLabels(t *testing.T) {
+	member := func(port int, alive bool, metadata map[string]string) *MemberStatus {
+		return &MemberStatus{Host: "node", Port: port, Kinds: []string{"counter"}, Alive: alive, Metadata: metadata}
+	}
+	members := map[string]*MemberStatus{}
+	for _, m := range []*MemberStatus{
+		member(1, true, map[string]string{"region": "us-east"}),
+		member(2, true, map[string]string{"region": "us-east", "disk": "ssd"}),
+		member(3, true, map[string]string{"region": "eu-west", "disk": "ssd"}),
+		member(4, false, map[string]string{"region": "us-east", "disk": "ssd", "zone": "a"}),
+		{Host: "node", Port: 5, Kinds: []string{"other"}, Alive: true, Metadata: map[string]string{"region": "us-east"}},
+	} {
+		members[m.Address()] = m
+	}
+	addresses := func(placement *Placement) []string {
+		var res []string
+		for _, m := range placement.candidates("counter", members) {
+			res = append(res, m.Address())
+		}
+		return res
+	}
+
+	assert.Equal(t, []string{"node:1", "node:2", "node:4"}, addresses(NewPlacement(map[string]string{"region": "us-east"})))
+	preferred := NewPlacement(nil).WithPreferred("region", "us-east", 2).WithPreferred("disk", "ssd", 1)
+	assert.Equal(t, []string{"node:2", "node:4"}, addresses(preferred), "dead members do not outscore the alive ones")
+	assert.Empty(t, addresses(NewPlacement(map[string]string{"gpu": "true"})))
+}
@@ -2,16 +2,36 @@
 // source: protos.proto
 
 /*
-	Package cluster is a generated protocol buffer package.
+Package cluster is a generated protocol buffer package.
 
-	It is generated from these files:
-		protos.proto
+It is generated from these files:
 
-	It has these top-level messages:
-		TakeOwnership
-		GrainRequest
-		GrainResponse
-		GrainErrorResponse
+	protos.proto
+
+It has these top-level messages:
+
+	TakeOwnership
+	GrainRequest
+	GrainResponse
+	GrainErrorResponse
+	GrainStreamItem
+	GrainStreamEnd
+	PassivateGrain
+	SubscriberIdentity
+	Subscription
+	SubscribeRequest
+	SubscribeResponse
+	UnsubscribeRequest
+	UnsubscribeResponse
+	PubSubEnvelope
+	PublishRequest
+	PublishResponse
+	TopicSubscriptionsRequest
+	TopicSubscriptionsResponse
+	PubSubAck
+	Heartbeat
+	ActivationRequest
+	ActivationResponse
 */
 package cluster
 
@@ -115,18 +135,350 @@ func (m *GrainErrorResponse) GetErr() string {
 	return ""
 }
 
+type GrainStreamItem struct {
+	MessageData []byte `protobuf:"bytes,1,opt,name=message_data,json=messageData,proto3" json:"message_data,omitempty"`
+}
+
+func (m *GrainStreamItem) Reset()                    { *m = GrainStreamItem{} }
+func (*GrainStreamItem) ProtoMessage()               {}
+func (*GrainStreamItem) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{4} }
+
+func (m *GrainStreamItem) GetMessageData() []byte {
+	if m != nil {
+		return m.MessageData
+	}
+	return nil
+}
+
+type GrainStreamEnd struct {
+}
+
+func (m *GrainStreamEnd) Reset()                    { *m = GrainStreamEnd{} }
+func (*GrainStreamEnd) ProtoMessage()               {}
+func (*GrainStreamEnd) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{5} }
+
+type PassivateGrain struct {
+	Pid  *actor.PID `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
+	Name string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *PassivateGrain) Reset()                    { *m = PassivateGrain{} }
+func (*PassivateGrain) ProtoMessage()               {}
+func (*PassivateGrain) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{6} }
+
+func (m *PassivateGrain) GetPid() *actor.PID {
+	if m != nil {
+		return m.Pid
+	}
+	return nil
+}
+
+func (m *PassivateGrain) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SubscriberIdentity struct {
+	Pid  *actor.PID `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
+	Name string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind string     `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+}
+
+func (m *SubscriberIdentity) Reset()                    { *m = SubscriberIdentity{} }
+func (*SubscriberIdentity) ProtoMessage()               {}
+func (*SubscriberIdentity) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{7} }
+
+func (m *SubscriberIdentity) GetPid() *actor.PID {
+	if m != nil {
+		return m.Pid
+	}
+	return nil
+}
+
+func (m *SubscriberIdentity) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SubscriberIdentity) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+type Subscription struct {
+	Subscriber  *SubscriberIdentity `protobuf:"bytes,1,opt,name=subscriber" json:"subscriber,omitempty"`
+	AtLeastOnce bool                `protobuf:"varint,2,opt,name=at_least_once,json=atLeastOnce,proto3" json:"at_least_once,omitempty"`
+	Member      string              `protobuf:"bytes,3,opt,name=member,proto3" json:"member,omitempty"`
+}
+
+func (m *Subscription) Reset()                    { *m = Subscription{} }
+func (*Subscription) ProtoMessage()               {}
+func (*Subscription) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{8} }
+
+func (m *Subscription) GetSubscriber() *SubscriberIdentity {
+	if m != nil {
+		return m.Subscriber
+	}
+	return nil
+}
+
+func (m *Subscription) GetAtLeastOnce() bool {
+	if m != nil {
+		return m.AtLeastOnce
+	}
+	return false
+}
+
+func (m *Subscription) GetMember() string {
+	if m != nil {
+		return m.Member
+	}
+	return ""
+}
+
+type SubscribeRequest struct {
+	Subscription *Subscription `protobuf:"bytes,1,opt,name=subscription" json:"subscription,omitempty"`
+}
+
+func (m *SubscribeRequest) Reset()                    { *m = SubscribeRequest{} }
+func (*SubscribeRequest) ProtoMessage()               {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{9} }
+
+func (m *SubscribeRequest) GetSubscription() *Subscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+type SubscribeResponse struct {
+}
+
+func (m *SubscribeResponse) Reset()                    { *m = SubscribeResponse{} }
+func (*SubscribeResponse) ProtoMessage()               {}
+func (*SubscribeResponse) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{10} }
+
+type UnsubscribeRequest struct {
+	Subscriber *SubscriberIdentity `protobuf:"bytes,1,opt,name=subscriber" json:"subscriber,omitempty"`
+}
+
+func (m *UnsubscribeRequest) Reset()                    { *m = UnsubscribeRequest{} }
+func (*UnsubscribeRequest) ProtoMessage()               {}
+func (*UnsubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{11} }
+
+func (m *UnsubscribeRequest) GetSubscriber() *SubscriberIdentity {
+	if m != nil {
+		return m.Subscriber
+	}
+	return nil
+}
+
+type UnsubscribeResponse struct {
+}
+
+func (m *UnsubscribeResponse) Reset()                    { *m = UnsubscribeResponse{} }
+func (*UnsubscribeResponse) ProtoMessage()               {}
+func (*UnsubscribeResponse) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{12} }
+
+type PubSubEnvelope struct {
+	TypeName     string `protobuf:"bytes,1,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	MessageData  []byte `protobuf:"bytes,2,opt,name=message_data,json=messageData,proto3" json:"message_data,omitempty"`
+	SerializerId int32  `protobuf:"varint,3,opt,name=serializer_id,json=serializerId,proto3" json:"serializer_id,omitempty"`
+}
+
+func (m *PubSubEnvelope) Reset()                    { *m = PubSubEnvelope{} }
+func (*PubSubEnvelope) ProtoMessage()               {}
+func (*PubSubEnvelope) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{13} }
+
+func (m *PubSubEnvelope) GetTypeName() string {
+	if m != nil {
+		return m.TypeName
+	}
+	return ""
+}
+
+func (m *PubSubEnvelope) GetMessageData() []byte {
+	if m != nil {
+		return m.MessageData
+	}
+	return nil
+}
+
+func (m *PubSubEnvelope) GetSerializerId() int32 {
+	if m != nil {
+		return m.SerializerId
+	}
+	return 0
+}
+
+type PublishRequest struct {
+	Envelopes []*PubSubEnvelope `protobuf:"bytes,1,rep,name=envelopes" json:"envelopes,omitempty"`
+}
+
+func (m *PublishRequest) Reset()                    { *m = PublishRequest{} }
+func (*PublishRequest) ProtoMessage()               {}
+func (*PublishRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{14} }
+
+func (m *PublishRequest) GetEnvelopes() []*PubSubEnvelope {
+	if m != nil {
+		return m.Envelopes
+	}
+	return nil
+}
+
+type PublishResponse struct {
+}
+
+func (m *PublishResponse) Reset()                    { *m = PublishResponse{} }
+func (*PublishResponse) ProtoMessage()               {}
+func (*PublishResponse) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{15} }
+
+type TopicSubscriptionsRequest struct {
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+}
+
+func (m *TopicSubscriptionsRequest) Reset()      { *m = TopicSubscriptionsRequest{} }
+func (*TopicSubscriptionsRequest) ProtoMessage() {}
+func (*TopicSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorProtos, []int{16}
+}
+
+func (m *TopicSubscriptionsRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+type TopicSubscriptionsResponse struct {
+	Subscriptions []*Subscription `protobuf:"bytes,1,rep,name=subscriptions" json:"subscriptions,omitempty"`
+}
+
+func (m *TopicSubscriptionsResponse) Reset()      { *m = TopicSubscriptionsResponse{} }
+func (*TopicSubscriptionsResponse) ProtoMessage() {}
+func (*TopicSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorProtos, []int{17}
+}
+
+func (m *TopicSubscriptionsResponse) GetSubscriptions() []*Subscription {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+type PubSubAck struct {
+}
+
+func (m *PubSubAck) Reset()                    { *m = PubSubAck{} }
+func (*PubSubAck) ProtoMessage()               {}
+func (*PubSubAck) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{18} }
+
+type Heartbeat struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *Heartbeat) Reset()                    { *m = Heartbeat{} }
+func (*Heartbeat) ProtoMessage()               {}
+func (*Heartbeat) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{19} }
+
+func (m *Heartbeat) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+// ActivationRequest asks the member a grain is placed on by rendezvous hashing for its activation
+type ActivationRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+}
+
+func (m *ActivationRequest) Reset()                    { *m = ActivationRequest{} }
+func (*ActivationRequest) ProtoMessage()               {}
+func (*ActivationRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{20} }
+
+func (m *ActivationRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ActivationRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+// ActivationResponse answers an ActivationRequest with the activation of the grain, or with the owner of the grain
+// when it is placed on another member in the view of the member asked
+type ActivationResponse struct {
+	Pid        *actor.PID `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
+	StatusCode int32      `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Owner      string     `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (m *ActivationResponse) Reset()                    { *m = ActivationResponse{} }
+func (*ActivationResponse) ProtoMessage()               {}
+func (*ActivationResponse) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{21} }
+
+func (m *ActivationResponse) GetPid() *actor.PID {
+	if m != nil {
+		return m.Pid
+	}
+	return nil
+}
+
+func (m *ActivationResponse) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *ActivationResponse) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*TakeOwnership)(nil), "cluster.TakeOwnership")
 	proto.RegisterType((*GrainRequest)(nil), "cluster.GrainRequest")
 	proto.RegisterType((*GrainResponse)(nil), "cluster.GrainResponse")
 	proto.RegisterType((*GrainErrorResponse)(nil), "cluster.GrainErrorResponse")
+	proto.RegisterType((*GrainStreamItem)(nil), "cluster.GrainStreamItem")
+	proto.RegisterType((*GrainStreamEnd)(nil), "cluster.GrainStreamEnd")
+	proto.RegisterType((*PassivateGrain)(nil), "cluster.PassivateGrain")
+	proto.RegisterType((*SubscriberIdentity)(nil), "cluster.SubscriberIdentity")
+	proto.RegisterType((*Subscription)(nil), "cluster.Subscription")
+	proto.RegisterType((*SubscribeRequest)(nil), "cluster.SubscribeRequest")
+	proto.RegisterType((*SubscribeResponse)(nil), "cluster.SubscribeResponse")
+	proto.RegisterType((*UnsubscribeRequest)(nil), "cluster.UnsubscribeRequest")
+	proto.RegisterType((*UnsubscribeResponse)(nil), "cluster.UnsubscribeResponse")
+	proto.RegisterType((*PubSubEnvelope)(nil), "cluster.PubSubEnvelope")
+	proto.RegisterType((*PublishRequest)(nil), "cluster.PublishRequest")
+	proto.RegisterType((*PublishResponse)(nil), "cluster.PublishResponse")
+	proto.RegisterType((*TopicSubscriptionsRequest)(nil), "cluster.TopicSubscriptionsRequest")
+	proto.RegisterType((*TopicSubscriptionsResponse)(nil), "cluster.TopicSubscriptionsResponse")
+	proto.RegisterType((*PubSubAck)(nil), "cluster.PubSubAck")
+	proto.RegisterType((*Heartbeat)(nil), "cluster.Heartbeat")
+	proto.RegisterType((*ActivationRequest)(nil), "cluster.ActivationRequest")
+	proto.RegisterType((*ActivationResponse)(nil), "cluster.ActivationResponse")
 }
 func (this *TakeOwnership) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*TakeOwnership)
@@ -139,10 +491,7 @@ func (this *TakeOwnership) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -156,10 +505,7 @@ func (this *TakeOwnership) Equal(that interface{}) bool {
 }
 func (this *GrainRequest) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*GrainRequest)
@@ -172,10 +518,7 @@ func (this *GrainRequest) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -189,10 +532,7 @@ func (this *GrainRequest) Equal(that interface{}) bool {
 }
 func (this *GrainResponse) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*GrainResponse)
@@ -205,10 +545,7 @@ func (this *GrainResponse) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -219,10 +556,7 @@ func (this *GrainResponse) Equal(that interface{}) bool {
 }
 func (this *GrainErrorResponse) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*GrainErrorResponse)
@@ -235,10 +569,7 @@ func (this *GrainErrorResponse) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -247,255 +578,3073 @@ func (this *GrainErrorResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (m *TakeOwnership) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *GrainStreamItem) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *TakeOwnership) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Pid != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
-		n1, err := m.Pid.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*GrainStreamItem)
+	if !ok {
+		that2, ok := that.(GrainStreamItem)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n1
 	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-
-func (m *GrainRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.MessageData, that1.MessageData) {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
+func (this *GrainStreamEnd) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GrainRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.MethodIndex != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(m.MethodIndex))
+	that1, ok := that.(*GrainStreamEnd)
+	if !ok {
+		that2, ok := that.(GrainStreamEnd)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.MessageData) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(len(m.MessageData)))
-		i += copy(dAtA[i:], m.MessageData)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *GrainResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *PassivateGrain) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *GrainResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.MessageData) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(len(m.MessageData)))
-		i += copy(dAtA[i:], m.MessageData)
+	that1, ok := that.(*PassivateGrain)
+	if !ok {
+		that2, ok := that.(PassivateGrain)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return i, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Pid.Equal(that1.Pid) {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	return true
 }
+func (this *SubscriberIdentity) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GrainErrorResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*SubscriberIdentity)
+	if !ok {
+		that2, ok := that.(SubscriberIdentity)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Pid.Equal(that1.Pid) {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	if this.Kind != that1.Kind {
+		return false
+	}
+	return true
 }
+func (this *Subscription) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GrainErrorResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Err) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintProtos(dAtA, i, uint64(len(m.Err)))
-		i += copy(dAtA[i:], m.Err)
+	that1, ok := that.(*Subscription)
+	if !ok {
+		that2, ok := that.(Subscription)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return i, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Subscriber.Equal(that1.Subscriber) {
+		return false
+	}
+	if this.AtLeastOnce != that1.AtLeastOnce {
+		return false
+	}
+	if this.Member != that1.Member {
+		return false
+	}
+	return true
 }
+func (this *SubscribeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func encodeFixed64Protos(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Protos(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintProtos(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	that1, ok := that.(*SubscribeRequest)
+	if !ok {
+		that2, ok := that.(SubscribeRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func (m *TakeOwnership) Size() (n int) {
-	var l int
-	_ = l
-	if m.Pid != nil {
-		l = m.Pid.Size()
-		n += 1 + l + sovProtos(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovProtos(uint64(l))
+	if !this.Subscription.Equal(that1.Subscription) {
+		return false
 	}
-	return n
+	return true
 }
+func (this *SubscribeResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GrainRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.MethodIndex != 0 {
-		n += 1 + sovProtos(uint64(m.MethodIndex))
+	that1, ok := that.(*SubscribeResponse)
+	if !ok {
+		that2, ok := that.(SubscribeResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	l = len(m.MessageData)
-	if l > 0 {
-		n += 1 + l + sovProtos(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return n
+	return true
 }
+func (this *UnsubscribeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GrainResponse) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.MessageData)
-	if l > 0 {
-		n += 1 + l + sovProtos(uint64(l))
+	that1, ok := that.(*UnsubscribeRequest)
+	if !ok {
+		that2, ok := that.(UnsubscribeRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return n
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Subscriber.Equal(that1.Subscriber) {
+		return false
+	}
+	return true
 }
+func (this *UnsubscribeResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GrainErrorResponse) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Err)
-	if l > 0 {
-		n += 1 + l + sovProtos(uint64(l))
+	that1, ok := that.(*UnsubscribeResponse)
+	if !ok {
+		that2, ok := that.(UnsubscribeResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return n
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
 }
+func (this *PubSubEnvelope) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func sovProtos(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+	that1, ok := that.(*PubSubEnvelope)
+	if !ok {
+		that2, ok := that.(PubSubEnvelope)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	return n
-}
-func sozProtos(x uint64) (n int) {
-	return sovProtos(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *TakeOwnership) String() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := strings.Join([]string{`&TakeOwnership{`,
-		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`}`,
-	}, "")
-	return s
+	if this.TypeName != that1.TypeName {
+		return false
+	}
+	if !bytes.Equal(this.MessageData, that1.MessageData) {
+		return false
+	}
+	if this.SerializerId != that1.SerializerId {
+		return false
+	}
+	return true
 }
-func (this *GrainRequest) String() string {
-	if this == nil {
-		return "nil"
+func (this *PublishRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := strings.Join([]string{`&GrainRequest{`,
+
+	that1, ok := that.(*PublishRequest)
+	if !ok {
+		that2, ok := that.(PublishRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Envelopes) != len(that1.Envelopes) {
+		return false
+	}
+	for i := range this.Envelopes {
+		if !this.Envelopes[i].Equal(that1.Envelopes[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *PublishResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PublishResponse)
+	if !ok {
+		that2, ok := that.(PublishResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (this *TopicSubscriptionsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TopicSubscriptionsRequest)
+	if !ok {
+		that2, ok := that.(TopicSubscriptionsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Topic != that1.Topic {
+		return false
+	}
+	return true
+}
+func (this *TopicSubscriptionsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TopicSubscriptionsResponse)
+	if !ok {
+		that2, ok := that.(TopicSubscriptionsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Subscriptions) != len(that1.Subscriptions) {
+		return false
+	}
+	for i := range this.Subscriptions {
+		if !this.Subscriptions[i].Equal(that1.Subscriptions[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *PubSubAck) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PubSubAck)
+	if !ok {
+		that2, ok := that.(PubSubAck)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (this *Heartbeat) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Heartbeat)
+	if !ok {
+		that2, ok := that.(Heartbeat)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Address != that1.Address {
+		return false
+	}
+	return true
+}
+func (this *ActivationRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ActivationRequest)
+	if !ok {
+		that2, ok := that.(ActivationRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	if this.Kind != that1.Kind {
+		return false
+	}
+	return true
+}
+func (this *ActivationResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ActivationResponse)
+	if !ok {
+		that2, ok := that.(ActivationResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Pid.Equal(that1.Pid) {
+		return false
+	}
+	if this.StatusCode != that1.StatusCode {
+		return false
+	}
+	if this.Owner != that1.Owner {
+		return false
+	}
+	return true
+}
+func (m *TakeOwnership) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TakeOwnership) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pid != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
+		n1, err := m.Pid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
+}
+
+func (m *GrainRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrainRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MethodIndex != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.MethodIndex))
+	}
+	if len(m.MessageData) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.MessageData)))
+		i += copy(dAtA[i:], m.MessageData)
+	}
+	return i, nil
+}
+
+func (m *GrainResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrainResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.MessageData) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.MessageData)))
+		i += copy(dAtA[i:], m.MessageData)
+	}
+	return i, nil
+}
+
+func (m *GrainErrorResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrainErrorResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Err) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Err)))
+		i += copy(dAtA[i:], m.Err)
+	}
+	return i, nil
+}
+
+func (m *GrainStreamItem) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrainStreamItem) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.MessageData) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.MessageData)))
+		i += copy(dAtA[i:], m.MessageData)
+	}
+	return i, nil
+}
+
+func (m *GrainStreamEnd) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrainStreamEnd) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *PassivateGrain) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PassivateGrain) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pid != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
+		n2, err := m.Pid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
+}
+
+func (m *SubscriberIdentity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscriberIdentity) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pid != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
+		n3, err := m.Pid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Kind) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Kind)))
+		i += copy(dAtA[i:], m.Kind)
+	}
+	return i, nil
+}
+
+func (m *Subscription) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Subscription) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Subscriber != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Subscriber.Size()))
+		n4, err := m.Subscriber.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.AtLeastOnce {
+		dAtA[i] = 0x10
+		i++
+		if m.AtLeastOnce {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Member) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Member)))
+		i += copy(dAtA[i:], m.Member)
+	}
+	return i, nil
+}
+
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Subscription != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Subscription.Size()))
+		n5, err := m.Subscription.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	return i, nil
+}
+
+func (m *SubscribeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *UnsubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnsubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Subscriber != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Subscriber.Size()))
+		n6, err := m.Subscriber.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	return i, nil
+}
+
+func (m *UnsubscribeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnsubscribeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *PubSubEnvelope) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PubSubEnvelope) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.TypeName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.TypeName)))
+		i += copy(dAtA[i:], m.TypeName)
+	}
+	if len(m.MessageData) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.MessageData)))
+		i += copy(dAtA[i:], m.MessageData)
+	}
+	if m.SerializerId != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.SerializerId))
+	}
+	return i, nil
+}
+
+func (m *PublishRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Envelopes) > 0 {
+		for _, msg := range m.Envelopes {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintProtos(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PublishResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *TopicSubscriptionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopicSubscriptionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	return i, nil
+}
+
+func (m *TopicSubscriptionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopicSubscriptionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subscriptions) > 0 {
+		for _, msg := range m.Subscriptions {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintProtos(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PubSubAck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PubSubAck) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *Heartbeat) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Heartbeat) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	return i, nil
+}
+
+func (m *ActivationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivationRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Kind) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Kind)))
+		i += copy(dAtA[i:], m.Kind)
+	}
+	return i, nil
+}
+
+func (m *ActivationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivationResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pid != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Pid.Size()))
+		n7, err := m.Pid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.StatusCode != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.StatusCode))
+	}
+	if len(m.Owner) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Owner)))
+		i += copy(dAtA[i:], m.Owner)
+	}
+	return i, nil
+}
+
+func encodeVarintProtos(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *TakeOwnership) Size() (n int) {
+	var l int
+	_ = l
+	if m.Pid != nil {
+		l = m.Pid.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *GrainRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.MethodIndex != 0 {
+		n += 1 + sovProtos(uint64(m.MethodIndex))
+	}
+	l = len(m.MessageData)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *GrainResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.MessageData)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *GrainErrorResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Err)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *GrainStreamItem) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.MessageData)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *GrainStreamEnd) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PassivateGrain) Size() (n int) {
+	var l int
+	_ = l
+	if m.Pid != nil {
+		l = m.Pid.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *SubscriberIdentity) Size() (n int) {
+	var l int
+	_ = l
+	if m.Pid != nil {
+		l = m.Pid.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *Subscription) Size() (n int) {
+	var l int
+	_ = l
+	if m.Subscriber != nil {
+		l = m.Subscriber.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.AtLeastOnce {
+		n += 2
+	}
+	l = len(m.Member)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *SubscribeRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Subscription != nil {
+		l = m.Subscription.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *SubscribeResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *UnsubscribeRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Subscriber != nil {
+		l = m.Subscriber.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *UnsubscribeResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PubSubEnvelope) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TypeName)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	l = len(m.MessageData)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.SerializerId != 0 {
+		n += 1 + sovProtos(uint64(m.SerializerId))
+	}
+	return n
+}
+
+func (m *PublishRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Envelopes) > 0 {
+		for _, e := range m.Envelopes {
+			l = e.Size()
+			n += 1 + l + sovProtos(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PublishResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *TopicSubscriptionsRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *TopicSubscriptionsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Subscriptions) > 0 {
+		for _, e := range m.Subscriptions {
+			l = e.Size()
+			n += 1 + l + sovProtos(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PubSubAck) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *Heartbeat) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivationRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivationResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Pid != nil {
+		l = m.Pid.Size()
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.StatusCode != 0 {
+		n += 1 + sovProtos(uint64(m.StatusCode))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func sovProtos(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozProtos(x uint64) (n int) {
+	return sovProtos(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *TakeOwnership) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TakeOwnership{`,
+		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GrainRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GrainRequest{`,
 		`MethodIndex:` + fmt.Sprintf("%v", this.MethodIndex) + `,`,
 		`MessageData:` + fmt.Sprintf("%v", this.MessageData) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *GrainResponse) String() string {
-	if this == nil {
-		return "nil"
+func (this *GrainResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GrainResponse{`,
+		`MessageData:` + fmt.Sprintf("%v", this.MessageData) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GrainErrorResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GrainErrorResponse{`,
+		`Err:` + fmt.Sprintf("%v", this.Err) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GrainStreamItem) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GrainStreamItem{`,
+		`MessageData:` + fmt.Sprintf("%v", this.MessageData) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GrainStreamEnd) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GrainStreamEnd{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PassivateGrain) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PassivateGrain{`,
+		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SubscriberIdentity) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SubscriberIdentity{`,
+		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Kind:` + fmt.Sprintf("%v", this.Kind) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Subscription) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Subscription{`,
+		`Subscriber:` + strings.Replace(fmt.Sprintf("%v", this.Subscriber), "SubscriberIdentity", "SubscriberIdentity", 1) + `,`,
+		`AtLeastOnce:` + fmt.Sprintf("%v", this.AtLeastOnce) + `,`,
+		`Member:` + fmt.Sprintf("%v", this.Member) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SubscribeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SubscribeRequest{`,
+		`Subscription:` + strings.Replace(fmt.Sprintf("%v", this.Subscription), "Subscription", "Subscription", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SubscribeResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SubscribeResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UnsubscribeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UnsubscribeRequest{`,
+		`Subscriber:` + strings.Replace(fmt.Sprintf("%v", this.Subscriber), "SubscriberIdentity", "SubscriberIdentity", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UnsubscribeResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UnsubscribeResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PubSubEnvelope) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PubSubEnvelope{`,
+		`TypeName:` + fmt.Sprintf("%v", this.TypeName) + `,`,
+		`MessageData:` + fmt.Sprintf("%v", this.MessageData) + `,`,
+		`SerializerId:` + fmt.Sprintf("%v", this.SerializerId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PublishRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PublishRequest{`,
+		`Envelopes:` + strings.Replace(fmt.Sprintf("%v", this.Envelopes), "PubSubEnvelope", "PubSubEnvelope", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PublishResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PublishResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TopicSubscriptionsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TopicSubscriptionsRequest{`,
+		`Topic:` + fmt.Sprintf("%v", this.Topic) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TopicSubscriptionsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TopicSubscriptionsResponse{`,
+		`Subscriptions:` + strings.Replace(fmt.Sprintf("%v", this.Subscriptions), "Subscription", "Subscription", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PubSubAck) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PubSubAck{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Heartbeat) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Heartbeat{`,
+		`Address:` + fmt.Sprintf("%v", this.Address) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActivationRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActivationRequest{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Kind:` + fmt.Sprintf("%v", this.Kind) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActivationResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActivationResponse{`,
+		`Pid:` + strings.Replace(fmt.Sprintf("%v", this.Pid), "PID", "actor.PID", 1) + `,`,
+		`StatusCode:` + fmt.Sprintf("%v", this.StatusCode) + `,`,
+		`Owner:` + fmt.Sprintf("%v", this.Owner) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringProtos(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *TakeOwnership) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TakeOwnership: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TakeOwnership: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pid == nil {
+				m.Pid = &actor.PID{}
+			}
+			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GrainRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrainRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrainRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MethodIndex", wireType)
+			}
+			m.MethodIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MethodIndex |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageData = append(m.MessageData[:0], dAtA[iNdEx:postIndex]...)
+			if m.MessageData == nil {
+				m.MessageData = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GrainResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrainResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrainResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageData = append(m.MessageData[:0], dAtA[iNdEx:postIndex]...)
+			if m.MessageData == nil {
+				m.MessageData = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GrainErrorResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrainErrorResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrainErrorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Err = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GrainStreamItem) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrainStreamItem: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrainStreamItem: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageData = append(m.MessageData[:0], dAtA[iNdEx:postIndex]...)
+			if m.MessageData == nil {
+				m.MessageData = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GrainStreamEnd) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrainStreamEnd: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrainStreamEnd: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PassivateGrain) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PassivateGrain: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PassivateGrain: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pid == nil {
+				m.Pid = &actor.PID{}
+			}
+			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscriberIdentity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscriberIdentity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscriberIdentity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pid == nil {
+				m.Pid = &actor.PID{}
+			}
+			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Subscription) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Subscription: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Subscription: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subscriber", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Subscriber == nil {
+				m.Subscriber = &SubscriberIdentity{}
+			}
+			if err := m.Subscriber.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AtLeastOnce", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AtLeastOnce = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Member = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subscription", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Subscription == nil {
+				m.Subscription = &Subscription{}
+			}
+			if err := m.Subscription.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UnsubscribeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnsubscribeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnsubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subscriber", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Subscriber == nil {
+				m.Subscriber = &SubscriberIdentity{}
+			}
+			if err := m.Subscriber.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UnsubscribeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnsubscribeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnsubscribeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PubSubEnvelope) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubSubEnvelope: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubSubEnvelope: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TypeName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageData = append(m.MessageData[:0], dAtA[iNdEx:postIndex]...)
+			if m.MessageData == nil {
+				m.MessageData = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SerializerId", wireType)
+			}
+			m.SerializerId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SerializerId |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PublishRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Envelopes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Envelopes = append(m.Envelopes, &PubSubEnvelope{})
+			if err := m.Envelopes[len(m.Envelopes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&GrainResponse{`,
-		`MessageData:` + fmt.Sprintf("%v", this.MessageData) + `,`,
-		`}`,
-	}, "")
-	return s
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (this *GrainErrorResponse) String() string {
-	if this == nil {
-		return "nil"
+func (m *PublishResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TopicSubscriptionsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TopicSubscriptionsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TopicSubscriptionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&GrainErrorResponse{`,
-		`Err:` + fmt.Sprintf("%v", this.Err) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringProtos(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *TakeOwnership) Unmarshal(dAtA []byte) error {
+func (m *TopicSubscriptionsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -518,15 +3667,15 @@ func (m *TakeOwnership) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TakeOwnership: wiretype end group for non-group")
+			return fmt.Errorf("proto: TopicSubscriptionsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TakeOwnership: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TopicSubscriptionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Subscriptions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -550,42 +3699,61 @@ func (m *TakeOwnership) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pid == nil {
-				m.Pid = &actor.PID{}
-			}
-			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Subscriptions = append(m.Subscriptions, &Subscription{})
+			if err := m.Subscriptions[len(m.Subscriptions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthProtos
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PubSubAck) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubSubAck: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubSubAck: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtos(dAtA[iNdEx:])
@@ -607,7 +3775,7 @@ func (m *TakeOwnership) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GrainRequest) Unmarshal(dAtA []byte) error {
+func (m *Heartbeat) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -630,36 +3798,17 @@ func (m *GrainRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GrainRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Heartbeat: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GrainRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Heartbeat: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MethodIndex", wireType)
-			}
-			m.MethodIndex = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.MethodIndex |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MessageData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtos
@@ -669,22 +3818,20 @@ func (m *GrainRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtos
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MessageData = append(m.MessageData[:0], dAtA[iNdEx:postIndex]...)
-			if m.MessageData == nil {
-				m.MessageData = []byte{}
-			}
+			m.Address = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -707,7 +3854,7 @@ func (m *GrainRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GrainResponse) Unmarshal(dAtA []byte) error {
+func (m *ActivationRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -730,17 +3877,17 @@ func (m *GrainResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GrainResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ActivationRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GrainResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ActivationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MessageData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtos
@@ -750,22 +3897,49 @@ func (m *GrainResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtos
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MessageData = append(m.MessageData[:0], dAtA[iNdEx:postIndex]...)
-			if m.MessageData == nil {
-				m.MessageData = []byte{}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Kind = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -788,7 +3962,7 @@ func (m *GrainResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GrainErrorResponse) Unmarshal(dAtA []byte) error {
+func (m *ActivationResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -811,15 +3985,67 @@ func (m *GrainErrorResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GrainErrorResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ActivationResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GrainErrorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ActivationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pid == nil {
+				m.Pid = &actor.PID{}
+			}
+			if err := m.Pid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StatusCode", wireType)
+			}
+			m.StatusCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StatusCode |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -844,7 +4070,7 @@ func (m *GrainErrorResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Err = string(dAtA[iNdEx:postIndex])
+			m.Owner = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -975,25 +4201,51 @@ var (
 func init() { proto.RegisterFile("protos.proto", fileDescriptorProtos) }
 
 var fileDescriptorProtos = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0xcf, 0x4b, 0xc3, 0x30,
-	0x1c, 0xc5, 0x1b, 0xe7, 0x0f, 0x96, 0x75, 0x20, 0x39, 0x8d, 0x21, 0x61, 0xee, 0x20, 0x3b, 0xb8,
-	0x16, 0x26, 0x78, 0x9f, 0x4c, 0x64, 0x27, 0xa5, 0xec, 0x3e, 0xd2, 0x36, 0xb6, 0x65, 0x36, 0xa9,
-	0x49, 0x8a, 0x7a, 0xf3, 0x4f, 0xf0, 0xcf, 0xf0, 0x4f, 0xf1, 0xb8, 0xa3, 0x47, 0x1b, 0x2f, 0x1e,
-	0xf7, 0x27, 0x48, 0xbf, 0x15, 0x19, 0xec, 0x94, 0xf7, 0x5e, 0xde, 0xe7, 0x05, 0x82, 0xdd, 0x42,
-	0x49, 0x23, 0xb5, 0x07, 0x07, 0x39, 0x8a, 0x1e, 0x4a, 0x6d, 0xb8, 0xea, 0x8f, 0x93, 0xcc, 0xa4,
-	0x65, 0xe8, 0x45, 0x32, 0xf7, 0x13, 0x99, 0x48, 0x1f, 0xee, 0xc3, 0xf2, 0x1e, 0x1c, 0x18, 0x50,
-	0x0d, 0xd7, 0xbf, 0xdc, 0xaa, 0x4f, 0xf5, 0x8b, 0x58, 0x29, 0x29, 0xe6, 0x8b, 0x06, 0x62, 0x91,
-	0x91, 0x6a, 0x9c, 0x48, 0x1f, 0x84, 0xbf, 0xfd, 0xde, 0x70, 0x8a, 0xbb, 0x0b, 0xb6, 0xe2, 0xb7,
-	0x4f, 0x82, 0x2b, 0x9d, 0x66, 0x05, 0x39, 0xc1, 0xad, 0x22, 0x8b, 0x7b, 0x68, 0x80, 0x46, 0x9d,
-	0x09, 0xf6, 0x00, 0xf1, 0xee, 0xe6, 0xb3, 0xa0, 0x8e, 0x09, 0xc1, 0xfb, 0x82, 0xe5, 0xbc, 0xb7,
-	0x37, 0x40, 0xa3, 0x76, 0x00, 0x7a, 0xb8, 0xc0, 0xee, 0x8d, 0x62, 0x99, 0x08, 0xf8, 0x63, 0xc9,
-	0xb5, 0x21, 0xa7, 0xd8, 0xcd, 0xb9, 0x49, 0x65, 0xbc, 0xcc, 0x44, 0xcc, 0x9f, 0x61, 0xea, 0x20,
-	0xe8, 0x34, 0xd9, 0xbc, 0x8e, 0x9a, 0x8a, 0xd6, 0x2c, 0xe1, 0xcb, 0x98, 0x19, 0x06, 0x73, 0x6e,
-	0x5d, 0x81, 0x6c, 0xc6, 0x0c, 0x1b, 0x4e, 0x70, 0xf7, 0x6f, 0x55, 0x17, 0x52, 0x68, 0xbe, 0xc3,
-	0xa0, 0x5d, 0xe6, 0x0c, 0x13, 0x60, 0xae, 0x95, 0x92, 0xea, 0x1f, 0x3c, 0xc6, 0x2d, 0xae, 0x14,
-	0xf4, 0xdb, 0x41, 0x2d, 0xaf, 0xce, 0xd7, 0x15, 0x75, 0x3e, 0x2b, 0xea, 0x6c, 0x2a, 0xea, 0xbc,
-	0x5a, 0x8a, 0xde, 0x2d, 0x45, 0x1f, 0x96, 0xa2, 0xb5, 0xa5, 0xe8, 0xcb, 0x52, 0xf4, 0x63, 0xa9,
-	0xb3, 0xb1, 0x14, 0xbd, 0x7d, 0x53, 0x27, 0x3c, 0x84, 0x9f, 0xba, 0xf8, 0x0d, 0x00, 0x00, 0xff,
-	0xff, 0x95, 0x67, 0xe6, 0x64, 0xa9, 0x01, 0x00, 0x00,
+	// 722 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcd, 0x52, 0x1b, 0x39,
+	0x10, 0xf6, 0xe0, 0x35, 0xe0, 0xb6, 0xcd, 0xcf, 0xb0, 0xec, 0x7a, 0x61, 0x6b, 0x96, 0xd5, 0xd6,
+	0x6e, 0x71, 0x58, 0xec, 0x5a, 0x36, 0x49, 0x55, 0x8a, 0x93, 0x09, 0x14, 0x71, 0x55, 0x12, 0xc8,
+	0xe0, 0x1c, 0x92, 0x8b, 0x4b, 0x33, 0xa3, 0xd8, 0x2a, 0xdb, 0xd2, 0x44, 0xd2, 0x90, 0x90, 0x53,
+	0x6e, 0xb9, 0xe6, 0x31, 0xf2, 0x28, 0x39, 0x72, 0xcc, 0x31, 0x38, 0x97, 0x1c, 0x79, 0x84, 0xd4,
+	0x48, 0xb2, 0x19, 0x03, 0xf9, 0x3f, 0x4d, 0x77, 0xab, 0xbf, 0xaf, 0xbf, 0x56, 0xab, 0x07, 0xca,
+	0xb1, 0xe0, 0x8a, 0xcb, 0x9a, 0xfe, 0xb8, 0x33, 0x61, 0x3f, 0x91, 0x8a, 0x88, 0x95, 0x8d, 0x0e,
+	0x55, 0xdd, 0x24, 0xa8, 0x85, 0x7c, 0x50, 0xef, 0xf0, 0x0e, 0xaf, 0xeb, 0xf3, 0x20, 0x79, 0xac,
+	0x3d, 0xed, 0x68, 0xcb, 0xe0, 0x56, 0x6e, 0x64, 0xd2, 0x1b, 0xf2, 0x98, 0xf5, 0x04, 0x67, 0xcd,
+	0x96, 0x01, 0xe1, 0x50, 0x71, 0xb1, 0xd1, 0xe1, 0x75, 0x6d, 0xd4, 0xb3, 0xf5, 0x50, 0x03, 0x2a,
+	0x2d, 0xdc, 0x23, 0xfb, 0x4f, 0x19, 0x11, 0xb2, 0x4b, 0x63, 0xf7, 0x77, 0xc8, 0xc7, 0x34, 0xaa,
+	0x3a, 0x6b, 0xce, 0x7a, 0x69, 0x13, 0x6a, 0x1a, 0x52, 0x3b, 0x68, 0xee, 0xf8, 0x69, 0xd8, 0x75,
+	0xe1, 0x27, 0x86, 0x07, 0xa4, 0x3a, 0xb5, 0xe6, 0xac, 0x17, 0x7d, 0x6d, 0xa3, 0x16, 0x94, 0xf7,
+	0x04, 0xa6, 0xcc, 0x27, 0x4f, 0x12, 0x22, 0x95, 0xfb, 0x27, 0x94, 0x07, 0x44, 0x75, 0x79, 0xd4,
+	0xa6, 0x2c, 0x22, 0xcf, 0x34, 0x55, 0xc1, 0x2f, 0x99, 0x58, 0x33, 0x0d, 0x99, 0x14, 0x29, 0x71,
+	0x87, 0xb4, 0x23, 0xac, 0xb0, 0xa6, 0x2b, 0xfb, 0x25, 0x1b, 0xdb, 0xc1, 0x0a, 0xa3, 0x4d, 0xa8,
+	0x58, 0x56, 0x19, 0x73, 0x26, 0xc9, 0x25, 0x8c, 0x73, 0x19, 0xf3, 0x0f, 0xb8, 0x1a, 0xb3, 0x2b,
+	0x04, 0x17, 0x63, 0xe0, 0x02, 0xe4, 0x89, 0x10, 0x3a, 0xbf, 0xe8, 0xa7, 0x26, 0xba, 0x06, 0xf3,
+	0x3a, 0xef, 0x50, 0x09, 0x82, 0x07, 0x4d, 0x45, 0x06, 0x5f, 0xc3, 0xbe, 0x00, 0x73, 0x19, 0xd4,
+	0x2e, 0x8b, 0xd0, 0x36, 0xcc, 0x1d, 0x60, 0x29, 0xe9, 0x11, 0x56, 0x44, 0x1f, 0x7d, 0xc7, 0xed,
+	0x3d, 0x02, 0xf7, 0x30, 0x09, 0x64, 0x28, 0x68, 0x40, 0x44, 0x33, 0x22, 0x4c, 0x51, 0x75, 0xfc,
+	0xed, 0x3c, 0x69, 0xac, 0x47, 0x59, 0x54, 0xcd, 0x9b, 0x58, 0x6a, 0xa3, 0x97, 0x0e, 0x94, 0x2d,
+	0x79, 0xac, 0x28, 0x67, 0xee, 0x16, 0x80, 0x1c, 0x17, 0xb3, 0xec, 0xab, 0x35, 0xfb, 0xe4, 0x6a,
+	0x97, 0x75, 0xf8, 0x99, 0x74, 0x17, 0x41, 0x05, 0xab, 0x76, 0x9f, 0x60, 0xa9, 0xda, 0x9c, 0x85,
+	0xa6, 0xfc, 0xac, 0x5f, 0xc2, 0xea, 0x4e, 0x1a, 0xdb, 0x67, 0x21, 0x71, 0x7f, 0x81, 0xe9, 0x01,
+	0x19, 0xa4, 0xe4, 0x46, 0x87, 0xf5, 0xd0, 0x5d, 0x58, 0x18, 0xb3, 0x8f, 0xde, 0xc9, 0x4d, 0x28,
+	0xcb, 0x8c, 0x38, 0x2b, 0x67, 0xf9, 0xa2, 0x1c, 0x7d, 0xe8, 0x4f, 0xa4, 0xa2, 0x25, 0x58, 0xcc,
+	0xd0, 0x99, 0x39, 0xa3, 0xfb, 0xe0, 0x3e, 0x60, 0xf2, 0x62, 0x95, 0x1f, 0x69, 0x19, 0x2d, 0xc3,
+	0xd2, 0x04, 0xa5, 0xad, 0x94, 0xc0, 0xdc, 0x41, 0x12, 0x1c, 0x26, 0xc1, 0x2e, 0x3b, 0x22, 0x7d,
+	0x1e, 0x13, 0x77, 0x15, 0x8a, 0xea, 0x38, 0x26, 0x6d, 0x3d, 0x16, 0xf3, 0xd2, 0x66, 0xd3, 0xc0,
+	0xbd, 0x74, 0x34, 0x5f, 0x7e, 0xed, 0xee, 0x5f, 0x50, 0x91, 0x44, 0x50, 0xdc, 0xa7, 0xcf, 0x89,
+	0x68, 0x53, 0x33, 0xc6, 0x82, 0x5f, 0x3e, 0x0f, 0x36, 0x23, 0xb4, 0xa7, 0xcb, 0xf6, 0xa9, 0xec,
+	0x8e, 0x9a, 0xbb, 0x0e, 0x45, 0x62, 0x25, 0xc8, 0xaa, 0xb3, 0x96, 0x5f, 0x2f, 0x6d, 0xfe, 0x3a,
+	0xee, 0x6d, 0x52, 0xa2, 0x7f, 0x9e, 0x89, 0x16, 0x61, 0x7e, 0x4c, 0x64, 0x5b, 0xfa, 0x0f, 0x7e,
+	0x6b, 0xf1, 0x98, 0x86, 0xd9, 0x4b, 0x97, 0xa3, 0x32, 0x3f, 0x43, 0x41, 0xa5, 0x87, 0xb6, 0x33,
+	0xe3, 0xa0, 0x87, 0xb0, 0x72, 0x15, 0xc4, 0x6e, 0xdd, 0x16, 0x54, 0xb2, 0x23, 0x1b, 0xc9, 0xfb,
+	0xc4, 0x78, 0x27, 0x73, 0x51, 0x09, 0x8a, 0x46, 0x7d, 0x23, 0xec, 0xa1, 0xbf, 0xa1, 0x78, 0x9b,
+	0x60, 0xa1, 0x02, 0x82, 0x95, 0x5b, 0x85, 0x19, 0x1c, 0x45, 0x82, 0x48, 0x69, 0xc5, 0x8c, 0x5c,
+	0xb4, 0x05, 0x8b, 0x8d, 0x50, 0xa5, 0xbb, 0x98, 0x12, 0x5a, 0xe5, 0xa3, 0x4d, 0x71, 0xae, 0xd8,
+	0x94, 0xa9, 0xcc, 0xa6, 0x50, 0x70, 0xb3, 0x60, 0xdb, 0xc3, 0xe7, 0xb7, 0xf0, 0x0f, 0x28, 0x49,
+	0x85, 0x55, 0x22, 0xdb, 0x21, 0x8f, 0xcc, 0x36, 0x14, 0x7c, 0x30, 0xa1, 0x5b, 0x3c, 0x22, 0xe9,
+	0xb5, 0xf1, 0xf4, 0xbf, 0x6a, 0x77, 0xc1, 0x38, 0xdb, 0xff, 0x9e, 0x9c, 0x7a, 0xb9, 0xb7, 0xa7,
+	0x5e, 0xee, 0xec, 0xd4, 0xcb, 0xbd, 0x18, 0x7a, 0xce, 0xeb, 0xa1, 0xe7, 0xbc, 0x19, 0x7a, 0xce,
+	0xc9, 0xd0, 0x73, 0xde, 0x0d, 0x3d, 0xe7, 0xc3, 0xd0, 0xcb, 0x9d, 0x0d, 0x3d, 0xe7, 0xd5, 0x7b,
+	0x2f, 0x17, 0x4c, 0xeb, 0xdf, 0xf4, 0xff, 0x1f, 0x07, 0x00, 0xba, 0x39, 0x67, 0xf4, 0x26, 0x06,
+	0x00, 0x00,
 }
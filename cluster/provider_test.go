@@ -0,0 +1,153 @@
+package cluster
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+)
+
+// testMembership connects the members of an in-process cluster
+type testMembership struct {
+	mutex   sync.Mutex
+	members map[string]*Cluster
+	// clients receive the topology without being part of it
+	clients map[string]*Cluster
+	leaving map[string]bool
+	joining map[string]bool
+	// skew delays the topology for each further member, so that members disagree for a while
+	skew time.Duration
+}
+
+func newTestMembership() *testMembership {
+	return &testMembership{members: make(map[string]*Cluster), clients: make(map[string]*Cluster), leaving: make(map[string]bool), joining: make(map[string]bool)}
+}
+
+func (m *testMembership) provider() *testProvider {
+	return &testProvider{membership: m}
+}
+
+func (m *testMembership) join(c *Cluster) {
+	m.mutex.Lock()
+	m.members[c.ActorSystem.Address()] = c
+	m.joining[c.ActorSystem.Address()] = !c.IsReady()
+	m.mutex.Unlock()
+	m.publish()
+}
+
+func (m *testMembership) leave(c *Cluster) {
+	m.mutex.Lock()
+	delete(m.members, c.ActorSystem.Address())
+	delete(m.clients, c.ActorSystem.Address())
+	delete(m.leaving, c.ActorSystem.Address())
+	delete(m.joining, c.ActorSystem.Address())
+	m.mutex.Unlock()
+	m.publish()
+}
+
+func (m *testMembership) markLeaving(c *Cluster) {
+	m.mutex.Lock()
+	m.leaving[c.ActorSystem.Address()] = true
+	m.mutex.Unlock()
+	m.publish()
+}
+
+func (m *testMembership) markReady(c *Cluster) {
+	m.mutex.Lock()
+	delete(m.joining, c.ActorSystem.Address())
+	m.mutex.Unlock()
+	m.publish()
+}
+
+func (m *testMembership) watch(c *Cluster) {
+	m.mutex.Lock()
+	m.clients[c.ActorSystem.Address()] = c
+	m.mutex.Unlock()
+	m.publish()
+}
+
+func (m *testMembership) publish() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	topology := make(TopologyEvent, 0, len(m.members))
+	for _, c := range m.members {
+		host, port, _ := c.ActorSystem.GetHostPort()
+		topology = append(topology, &MemberStatus{
+			MemberID: c.ActorSystem.Address(),
+			Host:     host,
+			Port:     port,
+			Kinds:    c.GetClusterKinds(),
+			Alive:    true,
+			Leaving:  m.leaving[c.ActorSystem.Address()],
+			Joining:  m.joining[c.ActorSystem.Address()],
+			Metadata: c.Config.Metadata,
+		})
+	}
+	for _, c := range m.members {
+		c.ActorSystem.EventStream.Publish(topology)
+		time.Sleep(m.skew)
+	}
+	for _, c := range m.clients {
+		c.ActorSystem.EventStream.Publish(topology)
+	}
+}
+
+type testProvider struct {
+	membership *testMembership
+	cluster    *Cluster
+}
+
+func (p *testProvider) StartMember(c *Cluster) error {
+	p.cluster = c
+	p.membership.join(c)
+	return nil
+}
+
+func (p *testProvider) StartClient(c *Cluster) error {
+	p.cluster = c
+	p.membership.watch(c)
+	return nil
+}
+
+func (p *testProvider) Leave() error {
+	p.membership.markLeaving(p.cluster)
+	return nil
+}
+
+func (p *testProvider) Ready() error {
+	p.membership.markReady(p.cluster)
+	return nil
+}
+
+func (p *testProvider) Shutdown(graceful bool) error {
+	p.membership.leave(p.cluster)
+	return nil
+}
+
+func (p *testProvider) UpdateKinds(kinds []string) error {
+	p.membership.publish()
+	return nil
+}
+
+func (p *testProvider) UpdateClusterState(state ClusterState) error {
+	return nil
+}
+
+func startTestMember(t *testing.T, network *remotetest.Network, membership *testMembership, kinds ...*Kind) *Cluster {
+	return startConfiguredTestMember(t, network, membership, nil, kinds...)
+}
+
+// startConfiguredTestMember starts a member after configure changed its config
+func startConfiguredTestMember(t *testing.T, network *remotetest.Network, membership *testMembership, configure func(*Config), kinds ...*Kind) *Cluster {
+	config := Configure("test", membership.provider(), remote.Configure("node", 0).WithTransport(network.Transport()), kinds...)
+	if configure != nil {
+		configure(config)
+	}
+	c := New(actor.NewActorSystem(), config)
+	c.Start()
+	return c
+}
@@ -0,0 +1,625 @@
+package cluster
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/AsynkronIT/protoactor-go/remote"
+)
+
+// TopicKind is the kind of the grains holding the subscriptions of the topics, every member hosts it
+const TopicKind = "prototopic"
+
+// pubSubActorName is the name of the actor answering the topics for the subscriptions made on its member
+const pubSubActorName = "pubsub"
+
+// ErrPubSubStopped is returned by Publish when the cluster shuts down before the message is published
+var ErrPubSubStopped = errors.New("pubsub stopped")
+
+// DeliveryMode tells how messages are delivered to a subscriber
+type DeliveryMode int
+
+const (
+	// AtMostOnce sends every message once
+	AtMostOnce DeliveryMode = iota
+	// AtLeastOnce sends every message as a request, and sends it again until the subscriber responds
+	// or the DeliveryTTL of the message expires
+	AtLeastOnce
+)
+
+// PubSubConfig configures the delivery of the messages published to topics
+type PubSubConfig struct {
+	DeliveryTTL  time.Duration
+	AckTimeout   time.Duration
+	MaxBatchSize int
+}
+
+func NewPubSubConfig() *PubSubConfig {
+	return &PubSubConfig{
+		DeliveryTTL:  time.Minute,
+		AckTimeout:   time.Second * 5,
+		MaxBatchSize: 100,
+	}
+}
+
+// WithDeliveryTTL sets for how long a message is delivered again to a subscriber that does not acknowledge it
+func (config *PubSubConfig) WithDeliveryTTL(ttl time.Duration) *PubSubConfig {
+	config.DeliveryTTL = ttl
+	return config
+}
+
+// WithAckTimeout sets how long a subscriber has to acknowledge a message before it is delivered again
+func (config *PubSubConfig) WithAckTimeout(timeout time.Duration) *PubSubConfig {
+	config.AckTimeout = timeout
+	return config
+}
+
+// WithMaxBatchSize sets how many messages are sent to a topic at once
+func (config *PubSubConfig) WithMaxBatchSize(size int) *PubSubConfig {
+	config.MaxBatchSize = size
+	return config
+}
+
+// PidSubscriber subscribes an actor to a topic
+func PidSubscriber(pid *actor.PID) *SubscriberIdentity {
+	return &SubscriberIdentity{Pid: pid}
+}
+
+// IdentitySubscriber subscribes a grain to a topic
+func IdentitySubscriber(name string, kind string) *SubscriberIdentity {
+	return &SubscriberIdentity{Name: name, Kind: kind}
+}
+
+func (s *SubscriberIdentity) key() string {
+	if s.Pid != nil {
+		return s.Pid.String()
+	}
+	return s.Kind + "/" + s.Name
+}
+
+// PubSub publishes messages to the subscribers of topics anywhere in the cluster.
+//
+// Every topic is a grain holding the subscriptions of the topic, and delivering the published messages to
+// each subscriber in order. The subscriptions made on a member end when the member leaves the cluster,
+// they are collected again from all members when a topic is activated on another member.
+type PubSub struct {
+	cluster *Cluster
+	mutex   sync.Mutex
+	// subscriptions made on this member, by topic and subscriber
+	subscriptions map[string]map[string]*Subscription
+	publishers    map[string]*actor.PID
+	pid           *actor.PID
+	stop          chan struct{}
+}
+
+func setupPubSub(cluster *Cluster) *PubSub {
+	p := &PubSub{
+		cluster:       cluster,
+		subscriptions: make(map[string]map[string]*Subscription),
+		publishers:    make(map[string]*actor.PID),
+		stop:          make(chan struct{}),
+	}
+
+	props := actor.PropsFromFunc(p.receive).WithGuardian(actor.RestartingSupervisorStrategy())
+	p.pid, _ = cluster.ActorSystem.Root.SpawnNamed(props, pubSubActorName)
+
+	return p
+}
+
+func (p *PubSub) stopPubSub() {
+	close(p.stop)
+
+	p.mutex.Lock()
+	publishers := p.publishers
+	p.publishers = make(map[string]*actor.PID)
+	p.mutex.Unlock()
+
+	for _, pid := range publishers {
+		p.cluster.ActorSystem.Root.Stop(pid)
+	}
+	_ = p.cluster.ActorSystem.Root.StopFuture(p.pid).Wait()
+}
+
+// PubSub returns the publish/subscribe of the cluster
+func (c *Cluster) PubSub() *PubSub {
+	return c.pubSub
+}
+
+// Subscribe delivers the messages published to topic to subscriber
+func (p *PubSub) Subscribe(topic string, subscriber *SubscriberIdentity, mode DeliveryMode) error {
+	subscription := &Subscription{
+		Subscriber:  subscriber,
+		AtLeastOnce: mode == AtLeastOnce,
+		Member:      p.cluster.ActorSystem.Address(),
+	}
+	key := subscriber.key()
+
+	p.mutex.Lock()
+	if _, ok := p.subscriptions[topic]; !ok {
+		p.subscriptions[topic] = make(map[string]*Subscription)
+	}
+	p.subscriptions[topic][key] = subscription
+	p.mutex.Unlock()
+
+	if _, err := p.cluster.Call(topic, TopicKind, &SubscribeRequest{Subscription: subscription}); err != nil {
+		p.forget(topic, key)
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe stops the delivery of the messages published to topic to subscriber
+func (p *PubSub) Unsubscribe(topic string, subscriber *SubscriberIdentity) error {
+	p.forget(topic, subscriber.key())
+	_, err := p.cluster.Call(topic, TopicKind, &UnsubscribeRequest{Subscriber: subscriber})
+	return err
+}
+
+// Publish sends message to the subscribers of topic.
+//
+// Messages published at the same time on a member are sent to the topic in batches.
+// Publish returns when the topic has delivered the message to all subscribers, that is when the
+// subscribers in AtLeastOnce mode have acknowledged it or its DeliveryTTL expired.
+func (p *PubSub) Publish(topic string, message interface{}) error {
+	data, typeName, err := remote.Serialize(message, remote.DefaultSerializerID)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	p.cluster.ActorSystem.Root.Send(p.publisher(topic), &publishItem{
+		envelope: &PubSubEnvelope{
+			TypeName:     typeName,
+			MessageData:  data,
+			SerializerId: remote.DefaultSerializerID,
+		},
+		done: done,
+	})
+
+	select {
+	case err := <-done:
+		return err
+	case <-p.stop:
+		return ErrPubSubStopped
+	}
+}
+
+func (p *PubSub) forget(topic string, key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.subscriptions[topic], key)
+	if len(p.subscriptions[topic]) == 0 {
+		delete(p.subscriptions, topic)
+	}
+}
+
+func (p *PubSub) publisher(topic string) *actor.PID {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if pid, ok := p.publishers[topic]; ok {
+		return pid
+	}
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return newTopicPublisher(p.cluster, topic)
+	})
+	pid := p.cluster.ActorSystem.Root.Spawn(props)
+	p.publishers[topic] = pid
+	return pid
+}
+
+func (p *PubSub) receive(ctx actor.Context) {
+	msg, ok := ctx.Message().(*TopicSubscriptionsRequest)
+	if !ok {
+		return
+	}
+
+	p.mutex.Lock()
+	subscriptions := make([]*Subscription, 0, len(p.subscriptions[msg.Topic]))
+	for _, s := range p.subscriptions[msg.Topic] {
+		subscriptions = append(subscriptions, s)
+	}
+	p.mutex.Unlock()
+
+	ctx.Respond(&TopicSubscriptionsResponse{Subscriptions: subscriptions})
+}
+
+type publishItem struct {
+	envelope *PubSubEnvelope
+	done     chan error
+}
+
+type batchPublished struct {
+	items []*publishItem
+	err   error
+}
+
+// topicPublisher sends the messages published on this member to a topic.
+// Messages published while a batch is on its way are sent together in the next batch.
+type topicPublisher struct {
+	cluster    *Cluster
+	topic      string
+	options    *GrainCallOptions
+	pending    []*publishItem
+	publishing bool
+}
+
+func newTopicPublisher(cluster *Cluster, topic string) *topicPublisher {
+	// topics respond once the subscribers acknowledged the messages
+	timeout := cluster.Config.PubSub.DeliveryTTL + cluster.Config.TimeoutTime
+	return &topicPublisher{
+		cluster: cluster,
+		topic:   topic,
+		options: NewGrainCallOptions(cluster).WithTimeout(timeout).WithRetryBackoff(10 * time.Millisecond),
+	}
+}
+
+func (a *topicPublisher) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *publishItem:
+		a.pending = append(a.pending, msg)
+		a.publish(ctx)
+	case *batchPublished:
+		for _, item := range msg.items {
+			item.done <- msg.err
+		}
+		a.publishing = false
+		a.publish(ctx)
+	}
+}
+
+func (a *topicPublisher) publish(ctx actor.Context) {
+	if a.publishing || len(a.pending) == 0 {
+		return
+	}
+
+	n := len(a.pending)
+	if max := a.cluster.Config.PubSub.MaxBatchSize; max > 0 && n > max {
+		n = max
+	}
+	items := a.pending[:n]
+	a.pending = a.pending[n:]
+
+	req := &PublishRequest{Envelopes: make([]*PubSubEnvelope, len(items))}
+	for i, item := range items {
+		req.Envelopes[i] = item.envelope
+	}
+
+	a.publishing = true
+	system, self := ctx.ActorSystem(), ctx.Self()
+	go func() {
+		_, err := a.cluster.Call(a.topic, TopicKind, req, a.options)
+		system.Root.Send(self, &batchPublished{items: items, err: err})
+	}()
+}
+
+type topicSubscription struct {
+	*Subscription
+	delivery *actor.PID
+}
+
+// topicBatch is a published batch that some subscribers have not received yet
+type topicBatch struct {
+	sender    *actor.PID
+	remaining map[string]struct{}
+}
+
+type deliverBatch struct {
+	id          uint64
+	messages    []interface{}
+	atLeastOnce bool
+	expires     time.Time
+}
+
+type deliveryDone struct {
+	id  uint64
+	key string
+}
+
+type redeliver struct{}
+
+// topicActor is the grain of a topic
+type topicActor struct {
+	cluster       *Cluster
+	topic         string
+	subscriptions map[string]*topicSubscription
+	batches       map[uint64]*topicBatch
+	nextBatch     uint64
+	// requests received while the subscriptions are collected from the members
+	stashed       []*actor.MessageEnvelope
+	collecting    int
+	memberLeftSub *eventstream.Subscription
+}
+
+func newTopicActor(cluster *Cluster) actor.Producer {
+	return func() actor.Actor {
+		return &topicActor{
+			cluster:       cluster,
+			subscriptions: make(map[string]*topicSubscription),
+			batches:       make(map[uint64]*topicBatch),
+		}
+	}
+}
+
+func (a *topicActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		a.start(ctx)
+	case *actor.Stopped:
+		ctx.ActorSystem().EventStream.Unsubscribe(a.memberLeftSub)
+	case *SubscribeRequest, *UnsubscribeRequest, *PublishRequest:
+		if a.collecting > 0 {
+			a.stashed = append(a.stashed, &actor.MessageEnvelope{Message: msg, Sender: ctx.Sender()})
+			return
+		}
+		a.handle(ctx, msg, ctx.Sender())
+	case *deliveryDone:
+		a.delivered(ctx, msg.id, msg.key)
+	case *actor.Terminated:
+		for key, s := range a.subscriptions {
+			if s.Subscriber.Pid != nil && s.Subscriber.Pid.String() == msg.Who.String() {
+				a.unsubscribe(ctx, key)
+			}
+		}
+	case *MemberLeftEvent:
+		address := msg.Name()
+		for key, s := range a.subscriptions {
+			if s.Member == address || (s.Subscriber.Pid != nil && s.Subscriber.Pid.Address == address) {
+				a.unsubscribe(ctx, key)
+			}
+		}
+	}
+}
+
+// start collects the subscriptions of the topic from all members
+func (a *topicActor) start(ctx actor.Context) {
+	a.topic = grainName(ctx.Self())
+
+	system, self := ctx.ActorSystem(), ctx.Self()
+	a.memberLeftSub = system.EventStream.Subscribe(func(evt interface{}) {
+		system.Root.Send(self, evt)
+	}).WithPredicate(func(evt interface{}) bool {
+		_, ok := evt.(*MemberLeftEvent)
+		return ok
+	})
+
+	members := a.cluster.MemberAddresses(TopicKind)
+	a.collecting = len(members)
+	for _, address := range members {
+		address := address
+		f := ctx.RequestFuture(actor.NewPID(address, pubSubActorName), &TopicSubscriptionsRequest{Topic: a.topic}, a.cluster.Config.TimeoutTime)
+		ctx.AwaitFuture(f, func(res interface{}, err error) {
+			if res, ok := res.(*TopicSubscriptionsResponse); ok {
+				for _, s := range res.Subscriptions {
+					a.subscribe(ctx, s)
+				}
+			} else {
+				plog.Error("Failed to collect subscriptions", log.String("topic", a.topic), log.String("address", address), log.Error(err))
+			}
+
+			a.collecting--
+			if a.collecting == 0 {
+				stashed := a.stashed
+				a.stashed = nil
+				for _, env := range stashed {
+					a.handle(ctx, env.Message, env.Sender)
+				}
+			}
+		})
+	}
+}
+
+func (a *topicActor) handle(ctx actor.Context, msg interface{}, sender *actor.PID) {
+	var res interface{}
+	switch msg := msg.(type) {
+	case *SubscribeRequest:
+		a.subscribe(ctx, msg.Subscription)
+		res = &SubscribeResponse{}
+	case *UnsubscribeRequest:
+		a.unsubscribe(ctx, msg.Subscriber.key())
+		res = &UnsubscribeResponse{}
+	case *PublishRequest:
+		if a.publish(ctx, msg, sender) {
+			return
+		}
+		res = &PublishResponse{}
+	}
+	if sender != nil {
+		ctx.Send(sender, res)
+	}
+}
+
+// publish hands the batch to the delivery of every subscriber, it returns false when there is no subscriber
+func (a *topicActor) publish(ctx actor.Context, msg *PublishRequest, sender *actor.PID) bool {
+	if len(a.subscriptions) == 0 {
+		return false
+	}
+
+	messages := make([]interface{}, 0, len(msg.Envelopes))
+	for _, env := range msg.Envelopes {
+		m, err := remote.Deserialize(env.MessageData, env.TypeName, env.SerializerId)
+		if err != nil {
+			plog.Error("Failed to deserialize published message", log.String("topic", a.topic), log.String("type", env.TypeName), log.Error(err))
+			continue
+		}
+		messages = append(messages, m)
+	}
+
+	a.nextBatch++
+	batch := &topicBatch{sender: sender, remaining: make(map[string]struct{}, len(a.subscriptions))}
+	a.batches[a.nextBatch] = batch
+	expires := time.Now().Add(a.cluster.Config.PubSub.DeliveryTTL)
+	for key, s := range a.subscriptions {
+		batch.remaining[key] = struct{}{}
+		ctx.Send(s.delivery, &deliverBatch{
+			id:          a.nextBatch,
+			messages:    messages,
+			atLeastOnce: s.AtLeastOnce,
+			expires:     expires,
+		})
+	}
+	return true
+}
+
+func (a *topicActor) subscribe(ctx actor.Context, subscription *Subscription) {
+	key := subscription.Subscriber.key()
+	if s, ok := a.subscriptions[key]; ok {
+		s.Subscription = subscription
+		return
+	}
+
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &subscriberDelivery{cluster: a.cluster, subscriber: subscription.Subscriber, key: key}
+	})
+	a.subscriptions[key] = &topicSubscription{
+		Subscription: subscription,
+		delivery:     ctx.Spawn(props),
+	}
+	if subscription.Subscriber.Pid != nil {
+		ctx.Watch(subscription.Subscriber.Pid)
+	}
+}
+
+func (a *topicActor) unsubscribe(ctx actor.Context, key string) {
+	s, ok := a.subscriptions[key]
+	if !ok {
+		return
+	}
+
+	plog.Debug("Removing subscriber", log.String("topic", a.topic), log.String("subscriber", key))
+	delete(a.subscriptions, key)
+	ctx.Stop(s.delivery)
+	if s.Subscriber.Pid != nil {
+		ctx.Unwatch(s.Subscriber.Pid)
+	}
+	for id := range a.batches {
+		a.delivered(ctx, id, key)
+	}
+}
+
+// delivered responds to the publisher of a batch once every subscriber received it
+func (a *topicActor) delivered(ctx actor.Context, id uint64, key string) {
+	batch, ok := a.batches[id]
+	if !ok {
+		return
+	}
+
+	delete(batch.remaining, key)
+	if len(batch.remaining) == 0 {
+		delete(a.batches, id)
+		if batch.sender != nil {
+			ctx.Send(batch.sender, &PublishResponse{})
+		}
+	}
+}
+
+// subscriberDelivery delivers the batches of a topic to one subscriber, one message at a time
+type subscriberDelivery struct {
+	cluster    *Cluster
+	subscriber *SubscriberIdentity
+	key        string
+	queue      []*deliverBatch
+	// next is the index of the next message of the first batch in the queue
+	next    int
+	attempt int
+}
+
+func (a *subscriberDelivery) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *deliverBatch:
+		a.queue = append(a.queue, msg)
+		if len(a.queue) == 1 {
+			a.deliver(ctx)
+		}
+	case *redeliver:
+		a.deliver(ctx)
+	}
+}
+
+func (a *subscriberDelivery) deliver(ctx actor.Context) {
+	for len(a.queue) > 0 {
+		batch := a.queue[0]
+		if a.next == len(batch.messages) {
+			ctx.Send(ctx.Parent(), &deliveryDone{id: batch.id, key: a.key})
+			a.queue = a.queue[1:]
+			a.next = 0
+			continue
+		}
+
+		message := batch.messages[a.next]
+		pid := a.resolve()
+		if !batch.atLeastOnce {
+			if pid != nil {
+				ctx.Send(pid, message)
+			}
+			a.next++
+			continue
+		}
+
+		if pid == nil {
+			if a.retry(ctx, batch) {
+				return
+			}
+			continue
+		}
+
+		f := ctx.RequestFuture(pid, message, a.cluster.Config.PubSub.AckTimeout)
+		ctx.AwaitFuture(f, func(res interface{}, err error) {
+			if err == nil {
+				a.next++
+				a.attempt = 0
+			} else {
+				if a.subscriber.Pid == nil {
+					// the grain may have moved, resolve it again
+					a.cluster.pidCache.removeCacheByName(a.subscriber.Name)
+				}
+				if a.retry(ctx, batch) {
+					return
+				}
+			}
+			a.deliver(ctx)
+		})
+		return
+	}
+}
+
+// retry delivers the current message again after a backoff. It returns false when the message expired instead.
+func (a *subscriberDelivery) retry(ctx actor.Context, batch *deliverBatch) bool {
+	if time.Now().After(batch.expires) {
+		plog.Info("Dropping expired message", log.String("subscriber", a.key))
+		a.next++
+		a.attempt = 0
+		return false
+	}
+
+	backoff := 10 * time.Millisecond << uint(a.attempt)
+	if backoff > time.Second {
+		backoff = time.Second
+	} else {
+		a.attempt++
+	}
+	system, self := ctx.ActorSystem(), ctx.Self()
+	time.AfterFunc(backoff, func() {
+		system.Root.Send(self, &redeliver{})
+	})
+	return true
+}
+
+func (a *subscriberDelivery) resolve() *actor.PID {
+	if a.subscriber.Pid != nil {
+		return a.subscriber.Pid
+	}
+
+	pid, statusCode := a.cluster.Get(a.subscriber.Name, a.subscriber.Kind)
+	if statusCode != remote.ResponseStatusCodeOK && statusCode != remote.ResponseStatusCodePROCESSNAMEALREADYEXIST {
+		plog.Error("Failed to deliver message to grain", log.String("kind", a.subscriber.Kind),
+			log.String("name", a.subscriber.Name), log.Error(statusCode.AsError()))
+		return nil
+	}
+	return pid
+}
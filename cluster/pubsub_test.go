@@ -0,0 +1,211 @@
+package cluster
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subscriber records the published GrainRequests it receives, and acknowledges them unless ignore says otherwise
+type subscriber struct {
+	mutex    sync.Mutex
+	received []int
+	ignore   func(n int, deliveries int) bool
+}
+
+func (s *subscriber) props() *actor.Props {
+	return actor.PropsFromFunc(func(ctx actor.Context) {
+		msg, ok := ctx.Message().(*GrainRequest)
+		if !ok {
+			return
+		}
+
+		n := int(msg.MethodIndex)
+		s.mutex.Lock()
+		s.received = append(s.received, n)
+		deliveries := 0
+		for _, r := range s.received {
+			if r == n {
+				deliveries++
+			}
+		}
+		s.mutex.Unlock()
+
+		if s.ignore == nil || !s.ignore(n, deliveries) {
+			ctx.Respond(&PubSubAck{})
+		}
+	})
+}
+
+func (s *subscriber) messages() []int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]int(nil), s.received...)
+}
+
+func (s *subscriber) distinct() map[int]bool {
+	res := make(map[int]bool)
+	for _, n := range s.messages() {
+		res[n] = true
+	}
+	return res
+}
+
+func startPubSubMembers(t *testing.T, n int, kinds ...*Kind) (*testMembership, []*Cluster) {
+	network := remotetest.NewNetwork()
+	membership := newTestMembership()
+	configure := func(config *Config) {
+		config.WithTimeout(300 * time.Millisecond).
+			WithPubSub(NewPubSubConfig().WithDeliveryTTL(time.Second).WithAckTimeout(100 * time.Millisecond))
+	}
+	members := make([]*Cluster, n)
+	for i := range members {
+		members[i] = startConfiguredTestMember(t, network, membership, configure, kinds...)
+	}
+	return membership, members
+}
+
+// topicOn returns a topic activated on the member with the given address
+func topicOn(t *testing.T, c *Cluster, address string) string {
+	for i := 0; i < 100; i++ {
+		topic := "topic" + strconv.Itoa(i)
+		pid, _ := c.Get(topic, TopicKind)
+		if pid != nil && pid.Address == address {
+			return topic
+		}
+	}
+	t.Fatal("no topic activated on " + address)
+	return ""
+}
+
+func TestPubSub_DeliversToActorsAndGrainsInOrder(t *testing.T) {
+	grain := &subscriber{}
+	_, members := startPubSubMembers(t, 3, NewKind("subscriber", grain.props()))
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+
+	actorSubscriber := &subscriber{}
+	pid := members[1].ActorSystem.Root.Spawn(actorSubscriber.props())
+	require.NoError(t, members[1].PubSub().Subscribe("orders", PidSubscriber(pid), AtMostOnce))
+	require.NoError(t, members[0].PubSub().Subscribe("orders", IdentitySubscriber("grain", "subscriber"), AtLeastOnce))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, members[2].PubSub().Publish("orders", &GrainRequest{MethodIndex: int32(i)}))
+		}(i)
+		time.Sleep(time.Millisecond)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, grain.messages())
+	require.Eventually(t, func() bool {
+		return len(actorSubscriber.messages()) == 10
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, actorSubscriber.messages())
+}
+
+func TestPubSub_RedeliversUntilAcknowledged(t *testing.T) {
+	_, members := startPubSubMembers(t, 2)
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+
+	s := &subscriber{ignore: func(n int, deliveries int) bool {
+		return deliveries < 3
+	}}
+	pid := members[1].ActorSystem.Root.Spawn(s.props())
+	require.NoError(t, members[1].PubSub().Subscribe("orders", PidSubscriber(pid), AtLeastOnce))
+
+	require.NoError(t, members[0].PubSub().Publish("orders", &GrainRequest{MethodIndex: 1}))
+	require.NoError(t, members[0].PubSub().Publish("orders", &GrainRequest{MethodIndex: 2}))
+	assert.Equal(t, []int{1, 1, 1, 2, 2, 2}, s.messages())
+}
+
+func TestPubSub_StopsDeliveringToStoppedSubscribers(t *testing.T) {
+	_, members := startPubSubMembers(t, 2)
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+
+	s := &subscriber{}
+	pid := members[1].ActorSystem.Root.Spawn(s.props())
+	require.NoError(t, members[1].PubSub().Subscribe("orders", PidSubscriber(pid), AtLeastOnce))
+	require.NoError(t, members[1].ActorSystem.Root.StopFuture(pid).Wait())
+
+	start := time.Now()
+	require.Eventually(t, func() bool {
+		return members[0].PubSub().Publish("orders", &GrainRequest{MethodIndex: 1}) == nil
+	}, time.Second, time.Millisecond)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.Empty(t, s.messages())
+}
+
+func TestPubSub_PrunesSubscribersOfCrashedMember(t *testing.T) {
+	membership, members := startPubSubMembers(t, 3)
+	defer members[0].Shutdown(false)
+	defer members[2].Shutdown(false)
+	topic := topicOn(t, members[0], members[0].ActorSystem.Address())
+
+	crashed := &subscriber{ignore: func(n int, deliveries int) bool {
+		return true
+	}}
+	alive := &subscriber{}
+	require.NoError(t, members[1].PubSub().Subscribe(topic, PidSubscriber(members[1].ActorSystem.Root.Spawn(crashed.props())), AtLeastOnce))
+	require.NoError(t, members[2].PubSub().Subscribe(topic, PidSubscriber(members[2].ActorSystem.Root.Spawn(alive.props())), AtLeastOnce))
+
+	membership.leave(members[1])
+	members[1].Shutdown(false)
+
+	start := time.Now()
+	require.NoError(t, members[0].PubSub().Publish(topic, &GrainRequest{MethodIndex: 1}))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second), "the topic should not wait for the crashed subscriber")
+	assert.Equal(t, []int{1}, alive.messages())
+}
+
+func TestPubSub_DeliversWhileTopicMemberCrashes(t *testing.T) {
+	membership, members := startPubSubMembers(t, 3)
+	defer members[0].Shutdown(false)
+	defer members[1].Shutdown(false)
+	crashing := members[2]
+	topic := topicOn(t, members[0], crashing.ActorSystem.Address())
+
+	s := &subscriber{}
+	pid := members[0].ActorSystem.Root.Spawn(s.props())
+	require.NoError(t, members[0].PubSub().Subscribe(topic, PidSubscriber(pid), AtLeastOnce))
+
+	const messages = 20
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < messages; i++ {
+			assert.NoError(t, members[1].PubSub().Publish(topic, &GrainRequest{MethodIndex: int32(i)}))
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(s.distinct()) >= messages/2
+	}, time.Second, time.Millisecond)
+	membership.leave(crashing)
+	crashing.Shutdown(false)
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("publishing did not complete")
+	}
+	assert.Len(t, s.distinct(), messages)
+
+	moved, _ := members[1].Get(topic, TopicKind)
+	require.NotNil(t, moved)
+	assert.NotEqual(t, crashing.ActorSystem.Address(), moved.Address)
+}
@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// needsReadiness tells whether the member joins as joining, waiting to be ready
+func (c *Cluster) needsReadiness() bool {
+	if c.Config.ManualReadiness {
+		return true
+	}
+	for _, kind := range c.Config.Kinds {
+		if kind.ReadinessCheck != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReady tells whether the member takes part in the placement of grains, see SetReady
+func (c *Cluster) IsReady() bool {
+	return atomic.LoadInt32(&c.ready) == 1
+}
+
+// SetReady makes the joining member ready: the other members start placing grains on it and hand over the identities
+// it owns. The readiness checks of its kinds are no longer waited for.
+func (c *Cluster) SetReady() {
+	if !atomic.CompareAndSwapInt32(&c.ready, 0, 1) {
+		return
+	}
+	c.stopReadinessChecks()
+
+	plog.Info("Member is ready", log.String("address", c.ActorSystem.Address()))
+	if provider, ok := c.Config.ClusterProvider.(ReadinessClusterProvider); ok {
+		if err := provider.Ready(); err != nil {
+			plog.Error("Failed to mark member as ready", log.Error(err))
+		}
+		return
+	}
+	// the provider cannot tell the other members the member is joining, it registers the member now
+	if err := c.Config.ClusterProvider.StartMember(c); err != nil {
+		plog.Error("Failed to start member", log.Error(err))
+	}
+}
+
+// startReadinessChecks runs the readiness checks of the kinds until they all pass, then makes the member ready
+func (c *Cluster) startReadinessChecks() {
+	if c.IsReady() || c.Config.ManualReadiness {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(c.Config.ReadinessCheckInterval)
+		defer ticker.Stop()
+		for !c.readinessChecksPass() {
+			select {
+			case <-ticker.C:
+			case <-c.readinessDone:
+				return
+			}
+		}
+		c.SetReady()
+	}()
+}
+
+func (c *Cluster) readinessChecksPass() bool {
+	for _, kind := range c.Config.Kinds {
+		if kind.ReadinessCheck != nil && !kind.ReadinessCheck() {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Cluster) stopReadinessChecks() {
+	c.readinessOnce.Do(func() {
+		close(c.readinessDone)
+	})
+}
@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// activatedOn returns the address the grain name is activated on, as seen by c
+func activatedOn(t *testing.T, c *Cluster, name string) string {
+	_, err := c.Call(name, "counter", &GrainRequest{})
+	require.NoError(t, err)
+	pid, statusCode := c.Get(name, "counter")
+	require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+	return pid.Address
+}
+
+func TestReadiness_JoiningMemberGetsNoActivations(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	kind, _, _ := countingKind(0)
+	c1 := startTestMember(t, network, membership, kind)
+	defer c1.Shutdown(false)
+
+	var warm int32
+	slowKind, _, _ := countingKind(0)
+	slowKind.WithReadinessCheck(func() bool { return atomic.LoadInt32(&warm) == 1 })
+	c2 := startConfiguredTestMember(t, network, membership, func(config *Config) {
+		config.WithReadinessCheckInterval(time.Millisecond)
+	}, slowKind)
+	defer c2.Shutdown(false)
+
+	require.False(t, c2.IsReady())
+	require.Eventually(t, func() bool {
+		m := memberStatus(c1, c2.ActorSystem.Address())
+		return m != nil && m.Joining
+	}, time.Second, time.Millisecond)
+	for i := 0; i < 30; i++ {
+		name := fmt.Sprintf("grain-%v", i)
+		assert.Equal(t, c1.ActorSystem.Address(), activatedOn(t, []*Cluster{c1, c2}[i%2], name), name)
+	}
+	assert.Zero(t, c1.PartitionStats().RebalancedIdentities, "ownership is not transferred to a joining member")
+
+	atomic.StoreInt32(&warm, 1)
+	require.Eventually(t, c2.IsReady, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return !memberStatus(c1, c2.ActorSystem.Address()).Joining }, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		return c1.PartitionStats().RebalancedIdentities > 0
+	}, time.Second, time.Millisecond)
+
+	// the ready member is a placement target
+	name := movedIdentity(t, c1, c2)
+	assert.Equal(t, c2.ActorSystem.Address(), c1.MemberList.getPartitionMember(name, "counter"))
+}
+
+func TestReadiness_SetReady(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	kind, _, _ := countingKind(0)
+	c1 := startTestMember(t, network, membership, kind)
+	defer c1.Shutdown(false)
+
+	manualKind, _, _ := countingKind(0)
+	c2 := startConfiguredTestMember(t, network, membership, func(config *Config) {
+		config.WithManualReadiness(true)
+	}, manualKind)
+	defer c2.Shutdown(false)
+
+	require.Eventually(t, func() bool {
+		m := memberStatus(c1, c2.ActorSystem.Address())
+		return m != nil && m.Joining
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{c1.ActorSystem.Address()}, c1.MemberList.getMembers("counter"))
+
+	c2.SetReady()
+	assert.True(t, c2.IsReady())
+	require.Eventually(t, func() bool { return len(c1.MemberList.getMembers("counter")) == 2 }, time.Second, time.Millisecond)
+}
+
+func TestReadiness_ProviderWithoutJoiningRegistersOnceReady(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	kind, _, _ := countingKind(0)
+	c1 := startTestMember(t, network, membership, kind)
+	defer c1.Shutdown(false)
+
+	manualKind, _, _ := countingKind(0)
+	c2 := startConfiguredTestMember(t, network, membership, func(config *Config) {
+		// hides Ready from the cluster
+		config.ClusterProvider = struct{ ClusterProvider }{config.ClusterProvider}
+		config.WithManualReadiness(true)
+	}, manualKind)
+	defer c2.Shutdown(false)
+
+	assert.Nil(t, memberStatus(c1, c2.ActorSystem.Address()))
+	c2.SetReady()
+	require.Eventually(t, func() bool {
+		m := memberStatus(c1, c2.ActorSystem.Address())
+		return m != nil && !m.Joining
+	}, time.Second, time.Millisecond)
+}
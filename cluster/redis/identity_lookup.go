@@ -0,0 +1,283 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/cluster"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	goredis "github.com/go-redis/redis/v7"
+)
+
+var plog = log.New(log.DebugLevel, "[CLUSTER] [REDIS]")
+
+var (
+	// compareAndDelete deletes KEYS[1] when its value is ARGV[1]
+	compareAndDelete = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+	// compareAndSet replaces the value ARGV[1] of KEYS[1] with ARGV[2], expiring in ARGV[3] milliseconds
+	compareAndSet = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+end
+return false`)
+)
+
+// placement is the value stored under the key of a grain.
+// While a member activates the grain, the placement only holds the address of that member.
+type placement struct {
+	Address    string `json:"address,omitempty"`
+	Id         string `json:"id,omitempty"`
+	ReservedBy string `json:"reservedBy,omitempty"`
+}
+
+func (p *placement) encode() string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+// IdentityLookup stores the activations of grains in Redis, so that they survive the loss of any member,
+// and grains are activated only once as long as Redis is reachable.
+//
+// The member hosting an activation keeps its key alive. Keys of activations on members that left
+// the cluster are replaced by new activations.
+type IdentityLookup struct {
+	client       goredis.UniversalClient
+	cluster      *cluster.Cluster
+	ttl          time.Duration
+	pollInterval time.Duration
+	prefix       string
+	address      string
+	stop         chan struct{}
+	stopOnce     sync.Once
+}
+
+// New creates an IdentityLookup using client
+func New(client goredis.UniversalClient) *IdentityLookup {
+	return &IdentityLookup{
+		client:       client,
+		ttl:          10 * time.Second,
+		pollInterval: 10 * time.Millisecond,
+		prefix:       "protoactor",
+	}
+}
+
+// WithTTL sets how long the key of an activation lives when its member stops refreshing it
+func (l *IdentityLookup) WithTTL(ttl time.Duration) *IdentityLookup {
+	l.ttl = ttl
+	return l
+}
+
+// WithPrefix sets the prefix of all keys
+func (l *IdentityLookup) WithPrefix(prefix string) *IdentityLookup {
+	l.prefix = prefix
+	return l
+}
+
+func (l *IdentityLookup) Setup(c *cluster.Cluster, kinds []string, isClient bool) {
+	l.cluster = c
+	l.address = c.ActorSystem.Address()
+	l.stop = make(chan struct{})
+	if !isClient {
+		go l.keepAlive()
+	}
+}
+
+func (l *IdentityLookup) Shutdown() {
+	l.stopOnce.Do(func() {
+		close(l.stop)
+	})
+
+	// the activations of this member are gone
+	keys, err := l.client.SMembers(l.memberKey(l.address)).Result()
+	if err != nil {
+		plog.Error("Failed to release activations", log.Error(err))
+		return
+	}
+	for _, key := range keys {
+		l.release(key, l.address, "")
+	}
+	l.client.Del(l.memberKey(l.address))
+}
+
+func (l *IdentityLookup) Get(name string, kind string) (*actor.PID, remote.ResponseStatusCode) {
+	key := l.grainKey(name, kind)
+	deadline := time.Now().Add(l.cluster.Config.TimeoutTime)
+	// the activation of this member whose reservation expired, it yields to the stored one
+	var orphan *actor.PID
+	for time.Now().Before(deadline) {
+		value, err := l.client.Get(key).Result()
+		if err == goredis.Nil {
+			reserved := (&placement{ReservedBy: l.address}).encode()
+			// the reservation outlives the activation, which times out after TimeoutTime
+			ok, err := l.client.SetNX(key, reserved, 2*l.cluster.Config.TimeoutTime).Result()
+			if err != nil {
+				plog.Error("Failed to reserve grain", log.String("key", key), log.Error(err))
+				l.stopOrphan(orphan, nil)
+				return nil, remote.ResponseStatusCodeERROR
+			}
+			if ok {
+				pid, statusCode, stored := l.activate(key, reserved, name, kind)
+				if stored || statusCode != remote.ResponseStatusCodeOK {
+					l.stopOrphan(orphan, pid)
+					return pid, statusCode
+				}
+				// another member may have activated the grain meanwhile
+				l.stopOrphan(orphan, pid)
+				orphan = pid
+				continue
+			}
+			// another member is activating the grain
+			continue
+		} else if err != nil {
+			plog.Error("Failed to get grain", log.String("key", key), log.Error(err))
+			l.stopOrphan(orphan, nil)
+			return nil, remote.ResponseStatusCodeERROR
+		}
+
+		p := &placement{}
+		if err := json.Unmarshal([]byte(value), p); err != nil {
+			plog.Error("Failed to decode grain", log.String("key", key), log.Error(err))
+			l.stopOrphan(orphan, nil)
+			return nil, remote.ResponseStatusCodeERROR
+		}
+		switch {
+		case p.ReservedBy != "":
+			// wait for the member that is activating the grain, the reservation expires if it fails to
+			time.Sleep(l.pollInterval)
+		case !l.isMember(p.Address, kind):
+			// the member is gone, activate the grain again
+			plog.Info("Replacing activation on lost member", log.String("key", key), log.String("value", value))
+			compareAndDelete.Run(l.client, []string{key}, value)
+		default:
+			pid := actor.NewPID(p.Address, p.Id)
+			l.stopOrphan(orphan, pid)
+			return pid, remote.ResponseStatusCodeOK
+		}
+	}
+	l.stopOrphan(orphan, nil)
+	return nil, remote.ResponseStatusCodeTIMEOUT
+}
+
+// stopOrphan stops the activation whose reservation expired, unless it is the activation of the grain. Activating
+// a grain twice on a member returns the same activation
+func (l *IdentityLookup) stopOrphan(orphan *actor.PID, activation *actor.PID) {
+	if orphan == nil || (activation != nil && orphan.Address == activation.Address && orphan.Id == activation.Id) {
+		return
+	}
+	plog.Info("Stopping activation of grain whose reservation expired", log.String("pid", orphan.String()))
+	l.cluster.ActorSystem.Root.Poison(orphan)
+}
+
+func (l *IdentityLookup) RemovePid(name string, kind string, pid *actor.PID) {
+	l.release(l.grainKey(name, kind), pid.Address, pid.Id)
+}
+
+// activate activates the grain reserved by this member, and stores its activation. The activation is not stored
+// when the reservation expired meanwhile
+func (l *IdentityLookup) activate(key string, reserved string, name string, kind string) (*actor.PID, remote.ResponseStatusCode, bool) {
+	pid, statusCode := l.cluster.Activate(name, kind)
+	if statusCode != remote.ResponseStatusCodeOK {
+		compareAndDelete.Run(l.client, []string{key}, reserved)
+		return nil, statusCode, false
+	}
+
+	value := (&placement{Address: pid.Address, Id: pid.Id}).encode()
+	res, err := compareAndSet.Run(l.client, []string{key}, reserved, value, l.ttl.Milliseconds()).Result()
+	if err != nil && err != goredis.Nil {
+		plog.Error("Failed to store grain", log.String("key", key), log.Error(err))
+		l.cluster.ActorSystem.Root.Poison(pid)
+		return nil, remote.ResponseStatusCodeERROR, false
+	}
+	if res == nil {
+		plog.Info("Reservation of grain expired while activating it", log.String("key", key))
+		return pid, remote.ResponseStatusCodeOK, false
+	}
+
+	memberKey := l.memberKey(pid.Address)
+	pipe := l.client.TxPipeline()
+	pipe.SAdd(memberKey, key)
+	pipe.PExpire(memberKey, l.ttl)
+	if _, err := pipe.Exec(); err != nil {
+		plog.Error("Failed to store grain", log.String("key", key), log.Error(err))
+	}
+	return pid, remote.ResponseStatusCodeOK, true
+}
+
+// release deletes the key of an activation on address, with the given id or with any id when id is empty
+func (l *IdentityLookup) release(key string, address string, id string) {
+	value, err := l.client.Get(key).Result()
+	if err == nil {
+		p := &placement{}
+		if json.Unmarshal([]byte(value), p) == nil && p.Address == address && (id == "" || p.Id == id) {
+			compareAndDelete.Run(l.client, []string{key}, value)
+		}
+	}
+	l.client.SRem(l.memberKey(address), key)
+}
+
+// keepAlive refreshes the keys of the activations on this member, and removes those that stopped
+func (l *IdentityLookup) keepAlive() {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			l.refresh()
+		}
+	}
+}
+
+func (l *IdentityLookup) refresh() {
+	memberKey := l.memberKey(l.address)
+	keys, err := l.client.SMembers(memberKey).Result()
+	if err != nil {
+		plog.Error("Failed to refresh activations", log.Error(err))
+		return
+	}
+	for _, key := range keys {
+		value, err := l.client.Get(key).Result()
+		if err != nil && err != goredis.Nil {
+			plog.Error("Failed to refresh activation", log.String("key", key), log.Error(err))
+			continue
+		}
+		p := &placement{}
+		if err == nil && json.Unmarshal([]byte(value), p) == nil && p.Address == l.address {
+			if _, alive := l.cluster.ActorSystem.ProcessRegistry.GetLocal(p.Id); alive {
+				l.client.PExpire(key, l.ttl)
+				continue
+			}
+			// the activation stopped without passivating
+			compareAndDelete.Run(l.client, []string{key}, value)
+		}
+		l.client.SRem(memberKey, key)
+	}
+	l.client.PExpire(memberKey, l.ttl)
+}
+
+func (l *IdentityLookup) isMember(address string, kind string) bool {
+	for _, a := range l.cluster.MemberAddresses(kind) {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *IdentityLookup) grainKey(name string, kind string) string {
+	return fmt.Sprintf("%v:%v:grains:%v:%v", l.prefix, l.cluster.Config.Name, kind, name)
+}
+
+func (l *IdentityLookup) memberKey(address string) string {
+	return fmt.Sprintf("%v:%v:members:%v", l.prefix, l.cluster.Config.Name, address)
+}
@@ -0,0 +1,251 @@
+package redis
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/cluster"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const grainKey = "protoactor:test:grains:kind:name"
+
+// testCluster connects in-process members
+type testCluster struct {
+	mutex   sync.Mutex
+	network *remotetest.Network
+	redis   *miniredis.Miniredis
+	members []*cluster.Cluster
+}
+
+func newTestCluster(t *testing.T) *testCluster {
+	m, err := miniredis.Run()
+	require.NoError(t, err)
+	return &testCluster{network: remotetest.NewNetwork(), redis: m}
+}
+
+func (tc *testCluster) StartMember(c *cluster.Cluster) error {
+	tc.mutex.Lock()
+	tc.members = append(tc.members, c)
+	tc.mutex.Unlock()
+	tc.publish()
+	return nil
+}
+
+func (tc *testCluster) StartClient(c *cluster.Cluster) error {
+	return nil
+}
+
+func (tc *testCluster) Shutdown(graceful bool) error {
+	return nil
+}
+
+func (tc *testCluster) UpdateClusterState(state cluster.ClusterState) error {
+	return nil
+}
+
+func (tc *testCluster) leave(c *cluster.Cluster) {
+	tc.mutex.Lock()
+	for i, m := range tc.members {
+		if m == c {
+			tc.members = append(tc.members[:i], tc.members[i+1:]...)
+			break
+		}
+	}
+	tc.mutex.Unlock()
+	tc.publish()
+}
+
+func (tc *testCluster) publish() {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	topology := make(cluster.TopologyEvent, 0, len(tc.members))
+	for _, c := range tc.members {
+		host, port, _ := c.ActorSystem.GetHostPort()
+		topology = append(topology, &cluster.MemberStatus{
+			MemberID: c.ActorSystem.Address(),
+			Host:     host,
+			Port:     port,
+			Kinds:    c.GetClusterKinds(),
+			Alive:    true,
+		})
+	}
+	for _, c := range tc.members {
+		c.ActorSystem.EventStream.Publish(topology)
+	}
+}
+
+func (tc *testCluster) start(ttl time.Duration, kinds ...*cluster.Kind) (*cluster.Cluster, *IdentityLookup) {
+	lookup := New(goredis.NewClient(&goredis.Options{Addr: tc.redis.Addr()})).WithTTL(ttl)
+	config := cluster.Configure("test", tc, remote.Configure("node", 0).WithTransport(tc.network.Transport()), kinds...).
+		WithIdentityLookup(lookup)
+	c := cluster.New(actor.NewActorSystem(), config)
+	c.Start()
+	return c, lookup
+}
+
+func countingKind() (*cluster.Kind, *int32) {
+	var activations int32
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			atomic.AddInt32(&activations, 1)
+		}
+	})
+	return cluster.NewKind("kind", props), &activations
+}
+
+func TestIdentityLookup_ActivatesGrainOnce(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.redis.Close()
+	kind, activations := countingKind()
+	host, _ := tc.start(10*time.Second, kind)
+	defer host.Shutdown(false)
+	other, _ := tc.start(10 * time.Second)
+	defer other.Shutdown(false)
+
+	var wg sync.WaitGroup
+	pids := make(chan *actor.PID, 20)
+	for i := 0; i < 20; i++ {
+		c := host
+		if i%2 == 0 {
+			c = other
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pid, statusCode := c.Get("name", "kind")
+			assert.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+			pids <- pid
+		}()
+	}
+	wg.Wait()
+	close(pids)
+
+	first := <-pids
+	for pid := range pids {
+		assert.Equal(t, first.String(), pid.String())
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(activations))
+	assert.True(t, tc.redis.Exists(grainKey))
+}
+
+func TestIdentityLookup_PlacementSurvivesLossOfActivatingMember(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.redis.Close()
+	kind, activations := countingKind()
+	host, _ := tc.start(10*time.Second, kind)
+	defer host.Shutdown(false)
+
+	activating, _ := tc.start(10 * time.Second)
+	pid, statusCode := activating.Get("name", "kind")
+	require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+	tc.leave(activating)
+	activating.Shutdown(false)
+
+	other, _ := tc.start(10 * time.Second)
+	defer other.Shutdown(false)
+	res, statusCode := other.Get("name", "kind")
+	require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+	assert.Equal(t, pid.String(), res.String())
+	assert.Equal(t, int32(1), atomic.LoadInt32(activations))
+}
+
+func TestIdentityLookup_ReplacesActivationOnLostMember(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.redis.Close()
+	kind, activations := countingKind()
+	host, _ := tc.start(10*time.Second, kind)
+	defer host.Shutdown(false)
+
+	require.NoError(t, tc.redis.Set(grainKey, (&placement{Address: "gone:1", Id: "activator/Remote$name"}).encode()))
+	pid, statusCode := host.Get("name", "kind")
+	require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+	assert.Equal(t, host.ActorSystem.Address(), pid.Address)
+	assert.Equal(t, int32(1), atomic.LoadInt32(activations))
+}
+
+func TestIdentityLookup_ReleasesPlacementOnPassivation(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.redis.Close()
+	kind, _ := countingKind()
+	host, _ := tc.start(10*time.Second, kind.WithIdleTimeout(20*time.Millisecond))
+	defer host.Shutdown(false)
+
+	_, statusCode := host.Get("name", "kind")
+	require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+	require.True(t, tc.redis.Exists(grainKey))
+	require.Eventually(t, func() bool {
+		return !tc.redis.Exists(grainKey)
+	}, time.Second, time.Millisecond)
+}
+
+func TestIdentityLookup_MemberKeepsItsActivationsAlive(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.redis.Close()
+	kind, _ := countingKind()
+	host, _ := tc.start(300*time.Millisecond, kind)
+	defer host.Shutdown(false)
+
+	_, statusCode := host.Get("name", "kind")
+	require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+	tc.redis.FastForward(200 * time.Millisecond)
+	require.Eventually(t, func() bool {
+		return tc.redis.TTL(grainKey) > 200*time.Millisecond
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestIdentityLookup_YieldsWhenReservationExpiresWhileActivating(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.redis.Close()
+	spawning, release := make(chan struct{}), make(chan struct{})
+	stopped := make(chan *actor.PID, 1)
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Stopped); ok {
+			stopped <- ctx.Self()
+		}
+	}).WithSpawnFunc(func(system *actor.ActorSystem, id string, props *actor.Props, parent actor.SpawnerContext) (*actor.PID, error) {
+		close(spawning)
+		<-release
+		return actor.DefaultSpawner(system, id, props, parent)
+	})
+	host, _ := tc.start(10*time.Second, cluster.NewKind("kind", props))
+	defer host.Shutdown(false)
+
+	res := make(chan *actor.PID, 1)
+	go func() {
+		pid, statusCode := host.Get("name", "kind")
+		assert.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+		res <- pid
+	}()
+
+	// the reservation expires, and another member activates the grain
+	<-spawning
+	tc.redis.FastForward(2*host.Config.TimeoutTime + time.Millisecond)
+	require.False(t, tc.redis.Exists(grainKey))
+	winner := host.ActorSystem.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	defer host.ActorSystem.Root.Stop(winner)
+	require.NoError(t, tc.redis.Set(grainKey, (&placement{Address: winner.Address, Id: winner.Id}).encode()))
+	close(release)
+
+	select {
+	case pid := <-res:
+		assert.Equal(t, winner.String(), pid.String())
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the grain")
+	}
+	select {
+	case pid := <-stopped:
+		assert.NotEqual(t, winner.String(), pid.String(), "the activation yields to the stored one")
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the activation to stop")
+	}
+}
@@ -0,0 +1,260 @@
+package cluster
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync/atomic"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/AsynkronIT/protoactor-go/remote"
+)
+
+// rendezvousActorName is the name of the actor activating the grains placed on a member by rendezvous hashing
+const rendezvousActorName = "rendezvous"
+
+// rendezvousRedirects is how many times a request follows the owners the members answer with
+const rendezvousRedirects = 3
+
+// RendezvousStats counts the work of a member placing grains by rendezvous hashing
+type RendezvousStats struct {
+	// Activations is the number of grains activated on the member
+	Activations int64
+	// Redirects is the number of requests the member answered with the owner of the grain in its view
+	Redirects int64
+	// MovedActivations is the number of activations the member stopped since their grains moved to another member
+	MovedActivations int64
+}
+
+// NewRendezvousPlacement returns the strategy placing each grain on the member hosting the kind with the highest
+// hash of the kind, the identity and the address of the member. There is no table of owners to hand over, a member
+// joining or leaving only moves the grains it gets or had. The member chosen activates the grain, or answers with
+// the owner in its view of the topology when the views differ, and the request follows it. The activations of the
+// grains which moved to another member are stopped, so that each grain has one activation once the views agree
+func NewRendezvousPlacement() PlacementStrategy {
+	return rendezvousPlacement{}
+}
+
+type rendezvousPlacement struct{}
+
+func (rendezvousPlacement) Get(c *Cluster, name string, kind string) (*actor.PID, remote.ResponseStatusCode) {
+	address := c.MemberList.getRendezvousMember(name, kind)
+	for redirects := 0; ; redirects++ {
+		if address == "" {
+			return nil, remote.ResponseStatusCodeUNAVAILABLE
+		}
+		res, statusCode := requestActivation(c, address, name, kind)
+		if statusCode != remote.ResponseStatusCodeOWNERCHANGED || res.Owner == "" || res.Owner == address || redirects == rendezvousRedirects {
+			return res.GetPid(), statusCode
+		}
+		address = res.Owner
+	}
+}
+
+func (rendezvousPlacement) RemovePid(c *Cluster, name string, kind string, pid *actor.PID) {
+	// the activations are known to the member they are on
+	c.ActorSystem.Root.Send(actor.NewPID(pid.Address, rendezvousActorName), &PassivateGrain{Pid: pid, Name: name})
+}
+
+// requestActivation asks the member at address for the activation of the grain
+func requestActivation(c *Cluster, address, name, kind string) (*ActivationResponse, remote.ResponseStatusCode) {
+	target := actor.NewPID(address, rendezvousActorName)
+	r, err := c.ActorSystem.Root.RequestFuture(target, &ActivationRequest{Name: name, Kind: kind}, c.Config.TimeoutTime).Result()
+	if err == actor.ErrTimeout {
+		plog.Error("Activation request timeout", log.String("remote", target.String()))
+		return nil, remote.ResponseStatusCodeTIMEOUT
+	} else if errors.Is(err, actor.ErrDeadLetter) {
+		return nil, remote.ResponseStatusCodeDeadLetter
+	} else if err != nil {
+		plog.Error("Activation request error", log.Error(err), log.String("remote", target.String()))
+		return nil, remote.ResponseStatusCodeERROR
+	}
+
+	response, ok := r.(*ActivationResponse)
+	if !ok {
+		return nil, remote.ResponseStatusCodeERROR
+	}
+	return response, remote.ResponseStatusCode(response.StatusCode)
+}
+
+// rendezvousScore is the score of the member at address for the grain, the grain is placed on the highest
+func rendezvousScore(kind, name, address string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(kind))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(address))
+	// FNV barely mixes the last bytes, which tell the addresses of the members apart
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+type rendezvousValue struct {
+	cluster     *Cluster
+	pid         *actor.PID
+	sub         *eventstream.Subscription
+	activations int64
+	redirects   int64
+	moved       int64
+}
+
+func setupRendezvous(cluster *Cluster) *rendezvousValue {
+	r := &rendezvousValue{cluster: cluster}
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &rendezvousActor{
+			value:       r,
+			activations: make(map[rendezvousKey]*actor.PID),
+			keys:        make(map[string]rendezvousKey),
+			spawnings:   make(map[rendezvousKey]*actor.Future),
+		}
+	}).WithGuardian(actor.RestartingSupervisorStrategy())
+	r.pid, _ = cluster.ActorSystem.Root.SpawnNamed(props, rendezvousActorName)
+
+	r.sub = cluster.ActorSystem.EventStream.Subscribe(func(m interface{}) {
+		cluster.ActorSystem.Root.Send(r.pid, m)
+	}).WithPredicate(func(m interface{}) bool {
+		_, ok := m.(MemberStatusEvent)
+		return ok
+	})
+	return r
+}
+
+func (r *rendezvousValue) stopRendezvous() {
+	r.cluster.ActorSystem.EventStream.Unsubscribe(r.sub)
+	_ = r.cluster.ActorSystem.Root.StopFuture(r.pid).Wait()
+}
+
+func (r *rendezvousValue) stats() RendezvousStats {
+	return RendezvousStats{
+		Activations:      atomic.LoadInt64(&r.activations),
+		Redirects:        atomic.LoadInt64(&r.redirects),
+		MovedActivations: atomic.LoadInt64(&r.moved),
+	}
+}
+
+type rendezvousKey struct {
+	kind string
+	name string
+}
+
+// rendezvousActor activates the grains placed on its member, it is the only one to, which keeps them unique
+type rendezvousActor struct {
+	value       *rendezvousValue
+	activations map[rendezvousKey]*actor.PID
+	keys        map[string]rendezvousKey // activation to grain
+	spawnings   map[rendezvousKey]*actor.Future
+}
+
+func (state *rendezvousActor) Receive(context actor.Context) {
+	switch msg := context.Message().(type) {
+	case *ActivationRequest:
+		state.activate(msg, context)
+	case *PassivateGrain:
+		// the next request activates the grain again
+		if state.forget(msg.Pid) {
+			context.Unwatch(msg.Pid)
+		}
+	case *actor.Terminated:
+		state.forget(msg.Who)
+	case MemberStatusEvent:
+		state.rebalance(context)
+	}
+}
+
+func (state *rendezvousActor) activate(msg *ActivationRequest, context actor.Context) {
+	key := rendezvousKey{kind: msg.Kind, name: msg.Name}
+	if pid := state.activations[key]; pid != nil {
+		context.Respond(&ActivationResponse{Pid: pid})
+		return
+	}
+
+	// the requester has another view of the topology
+	if owner := state.owner(key); owner != "" {
+		atomic.AddInt64(&state.value.redirects, 1)
+		context.Respond(&ActivationResponse{StatusCode: remote.ResponseStatusCodeOWNERCHANGED.ToInt32(), Owner: owner})
+		return
+	}
+
+	if spawning := state.spawnings[key]; spawning != nil {
+		context.AwaitFuture(spawning, func(r interface{}, err error) {
+			context.Respond(activationResponse(r, err))
+		})
+		return
+	}
+
+	c := state.value.cluster
+	spawning := context.RequestFuture(c.remote.ActivatorForAddress(c.ActorSystem.Address()), &remote.ActorPidRequest{Name: msg.Name, Kind: msg.Kind}, c.Config.TimeoutTime)
+	state.spawnings[key] = spawning
+	context.AwaitFuture(spawning, func(r interface{}, err error) {
+		delete(state.spawnings, key)
+		response := activationResponse(r, err)
+		if response.StatusCode == remote.ResponseStatusCodeOK.ToInt32() && state.activations[key] == nil {
+			state.activations[key] = response.Pid
+			state.keys[response.Pid.String()] = key
+			context.Watch(response.Pid)
+			atomic.AddInt64(&state.value.activations, 1)
+		}
+		context.Respond(response)
+	})
+}
+
+// activationResponse converts the response of the activator
+func activationResponse(r interface{}, err error) *ActivationResponse {
+	if err == actor.ErrTimeout {
+		return &ActivationResponse{StatusCode: remote.ResponseStatusCodeTIMEOUT.ToInt32()}
+	}
+	res, ok := r.(*remote.ActorPidResponse)
+	if err != nil || !ok {
+		return &ActivationResponse{StatusCode: remote.ResponseStatusCodeERROR.ToInt32()}
+	}
+	if res.StatusCode == remote.ResponseStatusCodePROCESSNAMEALREADYEXIST.ToInt32() && res.Pid != nil {
+		// the grain is activated already
+		return &ActivationResponse{Pid: res.Pid}
+	}
+	return &ActivationResponse{Pid: res.Pid, StatusCode: res.StatusCode}
+}
+
+// owner returns the address of the member the grain is placed on, or an empty string when it is this member or
+// no member is known yet
+func (state *rendezvousActor) owner(key rendezvousKey) string {
+	c := state.value.cluster
+	address := c.MemberList.getRendezvousMember(key.name, key.kind)
+	if address == c.ActorSystem.Address() {
+		return ""
+	}
+	return address
+}
+
+func (state *rendezvousActor) forget(pid *actor.PID) bool {
+	key, ok := state.keys[pid.String()]
+	if !ok {
+		return false
+	}
+	delete(state.keys, pid.String())
+	if current := state.activations[key]; current != nil && current.Equal(pid) {
+		delete(state.activations, key)
+	}
+	return true
+}
+
+// rebalance stops the activations of the grains placed on another member since the topology changed, they are
+// activated there on their next request
+func (state *rendezvousActor) rebalance(context actor.Context) {
+	for key, pid := range state.activations {
+		if state.owner(key) == "" {
+			continue
+		}
+		plog.Debug("Moving grain", log.String("kind", key.kind), log.String("name", key.name))
+		state.forget(pid)
+		context.Unwatch(pid)
+		context.Poison(pid)
+		atomic.AddInt64(&state.value.moved, 1)
+	}
+}
@@ -0,0 +1,168 @@
+package cluster
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const placedGrains = 90
+
+// startPlacedMember starts a member hosting the counters placed with strategy, counting their activations
+func startPlacedMember(t *testing.T, network *remotetest.Network, membership *testMembership, strategy PlacementStrategy, activations *int32) *Cluster {
+	kind := NewKind("counter", actor.PropsFromProducer(func() actor.Actor {
+		return &countingGrain{activations: activations, received: new(int32)}
+	}))
+	return startConfiguredTestMember(t, network, membership, func(config *Config) {
+		config.WithPlacementStrategy("counter", strategy)
+	}, kind)
+}
+
+// callGrains calls each of the grains placed once
+func callGrains(t *testing.T, c *Cluster) {
+	for i := 0; i < placedGrains; i++ {
+		_, err := c.Call(fmt.Sprintf("grain-%v", i), "counter", &GrainRequest{})
+		require.NoError(t, err)
+	}
+}
+
+// rendezvousOwners returns the member each of the identities is placed on by rendezvous hashing among members
+func rendezvousOwners(identities int, members ...string) []string {
+	owners := make([]string, identities)
+	for i := range owners {
+		var max uint64
+		for _, m := range members {
+			if score := rendezvousScore("counter", fmt.Sprintf("grain-%v", i), m); owners[i] == "" || score > max {
+				owners[i], max = m, score
+			}
+		}
+	}
+	return owners
+}
+
+func TestRendezvousPlacement_MovesOnlyTheIdentitiesOfTheMemberJoiningOrLeaving(t *testing.T) {
+	const identities = 10000
+	before := rendezvousOwners(identities, "node:1", "node:2", "node:3")
+	joined := rendezvousOwners(identities, "node:1", "node:2", "node:3", "node:4")
+	moved := 0
+	for i := range before {
+		if before[i] != joined[i] {
+			moved++
+			assert.Equal(t, "node:4", joined[i], "the identities only move to the member joining")
+		}
+	}
+	assert.InDelta(t, 0.25, float64(moved)/identities, 0.02)
+
+	left := rendezvousOwners(identities, "node:1", "node:3", "node:4")
+	moved = 0
+	for i := range joined {
+		if joined[i] != left[i] {
+			moved++
+			assert.Equal(t, "node:2", joined[i], "only the identities of the member leaving move")
+		}
+	}
+	assert.InDelta(t, 0.25, float64(moved)/identities, 0.02)
+}
+
+func TestRendezvousPlacement_ActivatesOnTheOwner(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	var activations int32
+	c1 := startPlacedMember(t, network, membership, NewRendezvousPlacement(), &activations)
+	defer c1.Shutdown(false)
+	c2 := startPlacedMember(t, network, membership, NewRendezvousPlacement(), &activations)
+	defer c2.Shutdown(false)
+
+	callGrains(t, c1)
+	callGrains(t, c2)
+	assert.Equal(t, int32(placedGrains), atomic.LoadInt32(&activations), "each grain is activated once")
+	for i := 0; i < placedGrains; i++ {
+		name := fmt.Sprintf("grain-%v", i)
+		pid, statusCode := c2.Get(name, "counter")
+		require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+		assert.Equal(t, c1.MemberList.getRendezvousMember(name, "counter"), pid.Address, name)
+	}
+	assert.Equal(t, int64(placedGrains), c1.RendezvousStats().Activations+c2.RendezvousStats().Activations)
+	assert.Zero(t, c1.PartitionStats().RebalancedIdentities+c2.PartitionStats().RebalancedIdentities, "the partitions are not used")
+}
+
+func TestRendezvousPlacement_RedirectsToTheOwnerOfItsView(t *testing.T) {
+	network, membership := remotetest.NewNetwork(), newTestMembership()
+	var activations int32
+	c1 := startPlacedMember(t, network, membership, NewRendezvousPlacement(), &activations)
+	defer c1.Shutdown(false)
+	c2 := startPlacedMember(t, network, membership, NewRendezvousPlacement(), &activations)
+	defer c2.Shutdown(false)
+
+	// a grain c1 places on itself, c2 is asked for it as if it had another view
+	name := ""
+	for i := 0; name == ""; i++ {
+		if candidate := fmt.Sprintf("grain-%v", i); c1.MemberList.getRendezvousMember(candidate, "counter") == c1.ActorSystem.Address() {
+			name = candidate
+		}
+	}
+	res, statusCode := requestActivation(c1, c2.ActorSystem.Address(), name, "counter")
+	require.Equal(t, remote.ResponseStatusCodeOWNERCHANGED, statusCode)
+	assert.Equal(t, c1.ActorSystem.Address(), res.Owner)
+	assert.Nil(t, res.Pid)
+	assert.Equal(t, int64(1), c2.RendezvousStats().Redirects)
+
+	pid, statusCode := c2.Get(name, "counter")
+	require.Equal(t, remote.ResponseStatusCodeOK, statusCode)
+	assert.Equal(t, c1.ActorSystem.Address(), pid.Address)
+}
+
+// TestPlacementStrategies_MemberJoinAndLeave compares the work of the strategies when a member joins and leaves:
+// the partitions hand over the ownership of the identities and keep the activations, rendezvous hashing moves the
+// activations of the identities placed on another member
+func TestPlacementStrategies_MemberJoinAndLeave(t *testing.T) {
+	for name, strategy := range map[string]PlacementStrategy{"partition": NewPartitionPlacement(), "rendezvous": NewRendezvousPlacement()} {
+		t.Run(name, func(t *testing.T) {
+			network, membership := remotetest.NewNetwork(), newTestMembership()
+			var activations int32
+			c1 := startPlacedMember(t, network, membership, strategy, &activations)
+			defer c1.Shutdown(false)
+			c2 := startPlacedMember(t, network, membership, strategy, &activations)
+			defer c2.Shutdown(false)
+			callGrains(t, c1)
+			require.Equal(t, int32(placedGrains), atomic.LoadInt32(&activations))
+
+			moved := func() int64 {
+				total := int64(0)
+				for _, c := range []*Cluster{c1, c2} {
+					total += c.PartitionStats().RebalancedIdentities + c.RendezvousStats().MovedActivations
+				}
+				return total
+			}
+			c3 := startPlacedMember(t, network, membership, strategy, &activations)
+			defer c3.Shutdown(false)
+			require.Eventually(t, func() bool { return moved() > 0 }, time.Second, time.Millisecond)
+			time.Sleep(50 * time.Millisecond)
+			joinFraction := float64(moved()) / placedGrains
+			t.Logf("%v: %.2f of the identities moved when the third member joined", name, joinFraction)
+			assert.InDelta(t, 1.0/3, joinFraction, 0.2)
+
+			callGrains(t, c2)
+			reactivated := atomic.LoadInt32(&activations) - placedGrains
+			if name == "rendezvous" {
+				assert.Equal(t, int32(moved()), reactivated, "the grains moved are activated on their new member")
+				assert.Equal(t, c3.RendezvousStats().Activations, int64(reactivated))
+			} else {
+				assert.Zero(t, reactivated, "the activations stay where they are")
+			}
+
+			// the member leaving only moves the identities it had
+			before := moved()
+			membership.leave(c3)
+			time.Sleep(50 * time.Millisecond)
+			callGrains(t, c1)
+			assert.Equal(t, before, moved(), "the other members keep their identities")
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// ShutdownPhase is a step of the graceful shutdown of a member, see Cluster.Shutdown
+type ShutdownPhase int
+
+const (
+	// ShutdownLeaving members no longer activate grains, and hand over their identities to the other members
+	ShutdownLeaving ShutdownPhase = iota
+	// ShutdownDraining members passivate their grains
+	ShutdownDraining
+	// ShutdownDrained members have no grains left, or the shutdown timeout passed
+	ShutdownDrained
+	// ShutdownDeregistered members are removed from the cluster provider
+	ShutdownDeregistered
+	// ShutdownStopped members have stopped remoting
+	ShutdownStopped
+)
+
+func (p ShutdownPhase) String() string {
+	switch p {
+	case ShutdownLeaving:
+		return "leaving"
+	case ShutdownDraining:
+		return "draining"
+	case ShutdownDrained:
+		return "drained"
+	case ShutdownDeregistered:
+		return "deregistered"
+	}
+	return "stopped"
+}
+
+// ShutdownProgressEvent is published on the event stream of a member as its graceful shutdown progresses
+type ShutdownProgressEvent struct {
+	Address string
+	Phase   ShutdownPhase
+	// Activations is the number of grains still activated on the member
+	Activations int
+	// TimedOut is set when the member was not drained within the shutdown timeout
+	TimedOut bool
+}
+
+// activation is a grain activated on this member
+type activation struct {
+	pid  *actor.PID
+	kind string
+}
+
+// activationMiddleware keeps track of the grains activated on this member, so that they can be drained
+func activationMiddleware(kind string) actor.ReceiverMiddleware {
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(ctx actor.ReceiverContext, env *actor.MessageEnvelope) {
+			switch env.Message.(type) {
+			case *actor.Started:
+				GetCluster(ctx.ActorSystem()).activations.Set(ctx.Self().String(), &activation{pid: ctx.Self(), kind: kind})
+			case *actor.Stopped:
+				GetCluster(ctx.ActorSystem()).activations.Remove(ctx.Self().String())
+			}
+			next(ctx, env)
+		}
+	}
+}
+
+// drain moves the grains of the member to the other members before it leaves the cluster
+func (c *Cluster) drain() {
+	deadline := time.Now().Add(c.Config.ShutdownTimeout)
+
+	c.remote.StopActivations()
+	if provider, ok := c.Config.ClusterProvider.(LeavingClusterProvider); ok {
+		if err := provider.Leave(); err != nil {
+			plog.Error("Failed to mark member as leaving", log.Error(err))
+		}
+	}
+	c.MemberList.leave()
+	c.publishShutdownProgress(ShutdownLeaving, false)
+
+	// the passivated grains are activated again on the other members, as the next messages reach them
+	for item := range c.activations.IterBuffered() {
+		a := item.Val.(*activation)
+		c.passivation.passivate(a.pid, a.kind, actor.StopReason_SystemShutdown)
+	}
+	c.publishShutdownProgress(ShutdownDraining, false)
+
+	for !c.drained() {
+		if time.Now().After(deadline) {
+			plog.Error("Member not drained within the shutdown timeout", log.Int("activations", c.activations.Count()))
+			c.publishShutdownProgress(ShutdownDrained, true)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.publishShutdownProgress(ShutdownDrained, false)
+}
+
+// drained tells whether the grains of the member stopped, and the other members took over its identities.
+// Passivated grains are remembered for Config.TimeoutTime, so that the messages still reaching them are redelivered.
+func (c *Cluster) drained() bool {
+	if c.activations.Count() > 0 || c.passivation.passivated.Count() > 0 {
+		return false
+	}
+	return c.partitionValue == nil || c.partitionValue.handedOver()
+}
+
+func (c *Cluster) publishShutdownProgress(phase ShutdownPhase, timedOut bool) {
+	address := c.ActorSystem.Address()
+	plog.Info("Shutting down", log.String("address", address), log.Stringer("phase", phase))
+	c.ActorSystem.EventStream.Publish(&ShutdownProgressEvent{
+		Address:     address,
+		Phase:       phase,
+		Activations: c.activations.Count(),
+		TimedOut:    timedOut,
+	})
+}
@@ -0,0 +1,102 @@
+package cluster
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdown_GracefulLeaveLosesNoRequests(t *testing.T) {
+	network := remotetest.NewNetwork()
+	membership := newTestMembership()
+	kind, _, _ := countingKind(0)
+	configure := func(config *Config) {
+		config.WithTimeout(300 * time.Millisecond).WithShutdownTimeout(2 * time.Second)
+	}
+	members := make([]*Cluster, 3)
+	for i := range members {
+		members[i] = startConfiguredTestMember(t, network, membership, configure, kind)
+	}
+	defer members[0].Shutdown(false)
+	defer members[1].Shutdown(false)
+
+	var progress []*ShutdownProgressEvent
+	sub := members[2].ActorSystem.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*ShutdownProgressEvent); ok {
+			progress = append(progress, e)
+		}
+	})
+	defer members[2].ActorSystem.EventStream.Unsubscribe(sub)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var calls, failures int
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			caller := members[i%2]
+			for n := 0; ; n++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				_, err := caller.Call("grain"+strconv.Itoa((i*100+n)%20), "counter", &GrainRequest{})
+				mutex.Lock()
+				calls++
+				if err != nil {
+					failures++
+				}
+				mutex.Unlock()
+			}
+		}(i)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	members[2].Shutdown(true)
+	time.Sleep(200 * time.Millisecond)
+
+	close(stop)
+	wg.Wait()
+	assert.NotZero(t, calls)
+	assert.Zero(t, failures)
+
+	require.Len(t, progress, 5)
+	for i, e := range progress {
+		assert.Equal(t, ShutdownPhase(i), e.Phase)
+		assert.Equal(t, members[2].ActorSystem.Address(), e.Address)
+		assert.False(t, e.TimedOut)
+	}
+	assert.Zero(t, progress[ShutdownDrained].Activations)
+}
+
+func TestShutdown_LeavingMemberReceivesNoActivations(t *testing.T) {
+	network := remotetest.NewNetwork()
+	membership := newTestMembership()
+	kind, _, _ := countingKind(0)
+	members := make([]*Cluster, 2)
+	for i := range members {
+		members[i] = startTestMember(t, network, membership, kind)
+		defer members[i].Shutdown(false)
+	}
+
+	members[1].remote.StopActivations()
+	membership.markLeaving(members[1])
+	require.Eventually(t, func() bool {
+		return len(members[0].MemberList.getMembers("counter")) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	for i := 0; i < 20; i++ {
+		_, err := members[0].Call("grain"+strconv.Itoa(i), "counter", &GrainRequest{})
+		require.NoError(t, err)
+		pid, _ := members[0].Get("grain"+strconv.Itoa(i), "counter")
+		assert.Equal(t, members[0].ActorSystem.Address(), pid.Address)
+	}
+}
@@ -0,0 +1,115 @@
+package eventstream
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what happens to the events published to an asynchronous subscriber the queue of which is full
+type OverflowPolicy int
+
+const (
+	// DropOldest drops the oldest event queued to make room for the published one
+	DropOldest OverflowPolicy = iota
+	// DropNewest drops the published event
+	DropNewest
+	// BlockBriefly blocks the publisher up to AsyncBlockTimeout for room in the queue, then drops the published event
+	BlockBriefly
+)
+
+// AsyncBlockTimeout is how long publishers block on the full queues of the subscribers with the BlockBriefly policy
+var AsyncBlockTimeout = 10 * time.Millisecond
+
+// asyncSubscriber runs the handler of a subscription on its own goroutine
+type asyncSubscriber struct {
+	fn       func(evt interface{})
+	queue    chan interface{}
+	policy   OverflowPolicy
+	dropped  uint64
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// SubscribeAsync subscribes fn to the events, which it handles on its own goroutine in the order they are published.
+// Up to queueSize events are queued while it is busy, the policy decides what happens to the others.
+// Unsubscribe discards the queued events, at most the one being handled completes after it returns.
+func (es *EventStream) SubscribeAsync(fn func(evt interface{}), queueSize int, policy OverflowPolicy) *Subscription {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	a := &asyncSubscriber{
+		fn:     fn,
+		queue:  make(chan interface{}, queueSize),
+		policy: policy,
+		stop:   make(chan struct{}),
+	}
+	go a.run()
+
+	return es.subscribe(&Subscription{fn: a.enqueue, async: a})
+}
+
+func (a *asyncSubscriber) run() {
+	for {
+		select {
+		case <-a.stop:
+			return
+		case evt := <-a.queue:
+			// the queued events are discarded once stopped, even when both are ready
+			select {
+			case <-a.stop:
+				return
+			default:
+			}
+			a.fn(evt)
+		}
+	}
+}
+
+func (a *asyncSubscriber) enqueue(evt interface{}) {
+	select {
+	case a.queue <- evt:
+		return
+	default:
+	}
+
+	switch a.policy {
+	case DropOldest:
+		for {
+			select {
+			case <-a.queue:
+				atomic.AddUint64(&a.dropped, 1)
+			default:
+			}
+			select {
+			case a.queue <- evt:
+				return
+			default:
+			}
+		}
+	case BlockBriefly:
+		timer := time.NewTimer(AsyncBlockTimeout)
+		defer timer.Stop()
+		select {
+		case a.queue <- evt:
+			return
+		case <-a.stop:
+		case <-timer.C:
+		}
+	}
+	atomic.AddUint64(&a.dropped, 1)
+}
+
+func (a *asyncSubscriber) close() {
+	a.stopOnce.Do(func() {
+		close(a.stop)
+	})
+}
+
+// Dropped returns the number of events an asynchronous subscriber dropped as its queue was full
+func (s *Subscription) Dropped() uint64 {
+	if s.async == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&s.async.dropped)
+}
@@ -0,0 +1,109 @@
+package eventstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingHandler handles the events once released, it reports them on handled
+func blockingHandler(release chan struct{}, handled chan interface{}) func(evt interface{}) {
+	return func(evt interface{}) {
+		<-release
+		handled <- evt
+	}
+}
+
+func receiveAll(t *testing.T, handled chan interface{}, count int) []interface{} {
+	var res []interface{}
+	for i := 0; i < count; i++ {
+		select {
+		case evt := <-handled:
+			res = append(res, evt)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d events out of %d", len(res), count)
+		}
+	}
+	return res
+}
+
+func TestSubscribeAsync_DoesNotBlockPublisher(t *testing.T) {
+	es := NewEventStream()
+	release, handled := make(chan struct{}), make(chan interface{}, 10)
+	sub := es.SubscribeAsync(blockingHandler(release, handled), 10, DropNewest)
+	defer es.Unsubscribe(sub)
+
+	for i := 0; i < 5; i++ {
+		es.Publish(i)
+	}
+	close(release)
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, receiveAll(t, handled, 5))
+	assert.Equal(t, uint64(0), sub.Dropped())
+}
+
+func TestSubscribeAsync_OverflowPolicies(t *testing.T) {
+	cases := []struct {
+		policy   OverflowPolicy
+		expected []interface{}
+	}{
+		// the first event is being handled when the others are published
+		{DropOldest, []interface{}{0, 3, 4}},
+		{DropNewest, []interface{}{0, 1, 2}},
+		{BlockBriefly, []interface{}{0, 1, 2}},
+	}
+	for _, tc := range cases {
+		es := NewEventStream()
+		release, handled := make(chan struct{}), make(chan interface{}, 10)
+		sub := es.SubscribeAsync(blockingHandler(release, handled), 2, tc.policy)
+
+		es.Publish(0)
+		require.Eventually(t, func() bool { return len(sub.async.queue) == 0 }, time.Second, time.Millisecond)
+		for i := 1; i < 5; i++ {
+			es.Publish(i)
+		}
+		close(release)
+		assert.Equal(t, tc.expected, receiveAll(t, handled, 3), "policy %d", tc.policy)
+		assert.Equal(t, uint64(2), sub.Dropped(), "policy %d", tc.policy)
+		es.Unsubscribe(sub)
+	}
+}
+
+func TestSubscribeAsync_BlockBrieflyWaitsForRoom(t *testing.T) {
+	es := NewEventStream()
+	handled := make(chan interface{}, 10)
+	sub := es.SubscribeAsync(func(evt interface{}) {
+		time.Sleep(time.Millisecond)
+		handled <- evt
+	}, 1, BlockBriefly)
+	defer es.Unsubscribe(sub)
+
+	for i := 0; i < 5; i++ {
+		es.Publish(i)
+	}
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, receiveAll(t, handled, 5))
+	assert.Equal(t, uint64(0), sub.Dropped())
+}
+
+func TestSubscribeAsync_UnsubscribeDiscardsQueue(t *testing.T) {
+	es := NewEventStream()
+	release, handled := make(chan struct{}), make(chan interface{}, 10)
+	sub := es.SubscribeAsync(blockingHandler(release, handled), 10, DropNewest)
+
+	es.Publish(0)
+	require.Eventually(t, func() bool { return len(sub.async.queue) == 0 }, time.Second, time.Millisecond)
+	es.Publish(1)
+	es.Publish(2)
+	es.Unsubscribe(sub)
+	es.Publish(3)
+	close(release)
+
+	// only the event being handled completes
+	assert.Equal(t, []interface{}{0}, receiveAll(t, handled, 1))
+	select {
+	case evt := <-handled:
+		t.Fatalf("handled %v after unsubscribing", evt)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -1,7 +1,9 @@
 package eventstream
 
 import (
+	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 // Predicate is a function used to filter messages before being forwarded to a subscriber
@@ -16,25 +18,76 @@ func NewEventStream() *EventStream {
 type EventStream struct {
 	sync.RWMutex
 	subscriptions []*Subscription
+
+	// dispatch and topicDispatch cache the subscriptions matching every type and topic published,
+	// they are reset when the subscriptions change
+	dispatch      atomic.Value // map[reflect.Type][]*Subscription
+	topicDispatch atomic.Value // map[string][]*Subscription
+	dispatchMu    sync.Mutex
+
+	replay *replayBuffer
 }
 
 func (es *EventStream) Subscribe(fn func(evt interface{})) *Subscription {
+	return es.subscribe(&Subscription{fn: fn})
+}
+
+// subscribe adds sub, it receives the events assignable to its type, or all events when it has none
+func (es *EventStream) subscribe(sub *Subscription) *Subscription {
 	es.Lock()
 	defer es.Unlock()
 
-	sub := &Subscription{
-		es: es,
-		i:  len(es.subscriptions),
-		fn: fn,
-	}
+	sub.es = es
+	sub.i = len(es.subscriptions)
 	es.subscriptions = append(es.subscriptions, sub)
+	es.resetDispatch()
 	return sub
 }
 
+func (es *EventStream) resetDispatch() {
+	es.dispatchMu.Lock()
+	es.dispatch.Store(map[reflect.Type][]*Subscription{})
+	es.topicDispatch.Store(map[string][]*Subscription{})
+	es.dispatchMu.Unlock()
+}
+
+// subscriptionsOf returns the subscriptions to the events of type typ, only the first event of a type is matched
+// against the types of the subscriptions
+func (es *EventStream) subscriptionsOf(typ reflect.Type) []*Subscription {
+	dispatch, _ := es.dispatch.Load().(map[reflect.Type][]*Subscription)
+	if subs, ok := dispatch[typ]; ok {
+		return subs
+	}
+
+	es.dispatchMu.Lock()
+	defer es.dispatchMu.Unlock()
+	var subs []*Subscription
+	for _, s := range es.subscriptions {
+		if s.topic != "" {
+			continue
+		}
+		if s.typ == nil || typ != nil && typ.AssignableTo(s.typ) {
+			subs = append(subs, s)
+		}
+	}
+	// the map is copied, so that it is read without locking
+	dispatch, _ = es.dispatch.Load().(map[reflect.Type][]*Subscription)
+	next := make(map[reflect.Type][]*Subscription, len(dispatch)+1)
+	for k, v := range dispatch {
+		next[k] = v
+	}
+	next[typ] = subs
+	es.dispatch.Store(next)
+	return subs
+}
+
 func (es *EventStream) Unsubscribe(sub *Subscription) {
 	if sub.i == -1 {
 		return
 	}
+	if sub.async != nil {
+		sub.async.close()
+	}
 
 	es.Lock()
 	defer es.Unlock()
@@ -43,10 +96,12 @@ func (es *EventStream) Unsubscribe(sub *Subscription) {
 
 	if l == -1 {
 		es.subscriptions = nil
+		es.resetDispatch()
 		sub.i = -1
 		return
 	}
 
+	es.resetDispatch()
 	es.subscriptions[i] = es.subscriptions[l]
 	es.subscriptions[i].i = i
 	es.subscriptions[l] = nil
@@ -67,9 +122,17 @@ func (es *EventStream) Publish(evt interface{}) {
 }
 
 func (es *EventStream) PublishUnsafe(evt interface{}) {
-	for _, s := range es.subscriptions {
+	var seq uint64
+	if es.replay != nil {
+		seq = es.replay.record(evt)
+	}
+	for _, s := range es.subscriptionsOf(reflect.TypeOf(evt)) {
 		if s.p == nil || s.p(evt) {
-			s.fn(evt)
+			if s.replay != nil {
+				s.replay.live(seq, evt)
+			} else {
+				s.fn(evt)
+			}
 		}
 	}
 }
@@ -78,10 +141,14 @@ func (es *EventStream) PublishUnsafe(evt interface{}) {
 //
 // This value and can be passed to Unsubscribe when the observer is no longer interested in receiving messages
 type Subscription struct {
-	es *EventStream
-	i  int
-	fn func(event interface{})
-	p  Predicate
+	es     *EventStream
+	i      int
+	fn     func(event interface{})
+	p      Predicate
+	typ    reflect.Type
+	topic  string
+	async  *asyncSubscriber
+	replay *replaySubscriber
 }
 
 // WithPredicate sets a predicate to filter messages passed to the subscriber
@@ -92,3 +159,11 @@ func (s *Subscription) WithPredicate(p Predicate) *Subscription {
 	s.p = p
 	return s
 }
+
+// Active tells whether the subscription still receives events
+func (s *Subscription) Active() bool {
+	s.es.RLock()
+	defer s.es.RUnlock()
+
+	return s.i != -1
+}
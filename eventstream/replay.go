@@ -0,0 +1,140 @@
+package eventstream
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReplayedEvent is received by the subscriptions created with SubscribeWithReplay for the events published before them
+type ReplayedEvent struct {
+	Event     interface{}
+	Timestamp time.Time
+}
+
+// bufferedEvent is an event retained by the replay buffer, seq orders the events published to the stream
+type bufferedEvent struct {
+	seq       uint64
+	timestamp time.Time
+	evt       interface{}
+}
+
+// replayBuffer retains the last events published to a stream, in a ring
+type replayBuffer struct {
+	mu     sync.Mutex
+	filter Predicate
+	events []bufferedEvent
+	next   int // the position of the next event in the ring
+	full   bool
+	seq    uint64 // the seq of the last event published
+}
+
+// WithReplayBuffer retains the last capacity events published to the stream for which filter is true, or all when it
+// is nil, so that SubscribeWithReplay replays them. The stream retains no event by default.
+func (es *EventStream) WithReplayBuffer(capacity int, filter Predicate) *EventStream {
+	es.Lock()
+	defer es.Unlock()
+
+	if capacity < 1 {
+		es.replay = nil
+		return es
+	}
+	es.replay = &replayBuffer{filter: filter, events: make([]bufferedEvent, capacity)}
+	return es
+}
+
+// record orders evt, and retains it when it matches the filter
+func (b *replayBuffer) record(evt interface{}) uint64 {
+	retained := b.filter == nil || b.filter(evt)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	if retained {
+		b.events[b.next] = bufferedEvent{seq: b.seq, timestamp: time.Now(), evt: evt}
+		b.next = (b.next + 1) % len(b.events)
+		b.full = b.full || b.next == 0
+	}
+	return b.seq
+}
+
+// history returns the events retained in the order they were published, and the seq of the last event published
+func (b *replayBuffer) history() ([]bufferedEvent, uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var res []bufferedEvent
+	if b.full {
+		res = append(res, b.events[b.next:]...)
+	}
+	res = append(res, b.events[:b.next]...)
+	return res, b.seq
+}
+
+// replaySubscriber hands a subscription over from the replayed events to the live ones,
+// the live events published before the history was taken are either replayed or were published before it subscribed
+type replaySubscriber struct {
+	fn func(evt interface{})
+
+	mu        sync.Mutex
+	replaying bool
+	lastSeq   uint64 // the seq of the last event published when the history was taken
+	pending   []bufferedEvent
+}
+
+// SubscribeWithReplay subscribes fn to the events, it first receives the events retained by the replay buffer of the
+// stream as ReplayedEvent, in the order they were published. The live events follow, without gap nor duplicate.
+func (es *EventStream) SubscribeWithReplay(fn func(evt interface{})) *Subscription {
+	es.RLock()
+	buffer := es.replay
+	es.RUnlock()
+	if buffer == nil {
+		return es.Subscribe(fn)
+	}
+
+	r := &replaySubscriber{fn: fn, replaying: true}
+	sub := es.subscribe(&Subscription{fn: fn, replay: r})
+	history, lastSeq := buffer.history()
+
+	r.mu.Lock()
+	r.lastSeq = lastSeq
+	r.mu.Unlock()
+	for _, e := range history {
+		fn(&ReplayedEvent{Event: e.evt, Timestamp: e.timestamp})
+	}
+
+	// the events published while replaying are received next
+	for {
+		r.mu.Lock()
+		pending := r.pending
+		r.pending = nil
+		if len(pending) == 0 {
+			r.replaying = false
+			r.mu.Unlock()
+			return sub
+		}
+		r.mu.Unlock()
+
+		sort.Slice(pending, func(i, j int) bool { return pending[i].seq < pending[j].seq })
+		for _, e := range pending {
+			if e.seq > lastSeq {
+				fn(e.evt)
+			}
+		}
+	}
+}
+
+// live receives the event published with seq
+func (r *replaySubscriber) live(seq uint64, evt interface{}) {
+	r.mu.Lock()
+	if r.replaying {
+		r.pending = append(r.pending, bufferedEvent{seq: seq, evt: evt})
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+
+	if seq > r.lastSeq {
+		r.fn(evt)
+	}
+}
@@ -0,0 +1,90 @@
+package eventstream
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventStream_SubscribeWithReplay(t *testing.T) {
+	es := NewEventStream().WithReplayBuffer(3, func(evt interface{}) bool {
+		return evt.(int)%2 == 0
+	})
+	for i := 0; i < 10; i++ {
+		es.Publish(i)
+	}
+
+	var replayed, live []interface{}
+	es.SubscribeWithReplay(func(evt interface{}) {
+		if r, ok := evt.(*ReplayedEvent); ok {
+			assert.False(t, r.Timestamp.IsZero())
+			replayed = append(replayed, r.Event)
+		} else {
+			live = append(live, evt)
+		}
+	})
+	es.Publish(10)
+	es.Publish(11)
+
+	// only the last 3 events matching the filter are retained
+	assert.Equal(t, []interface{}{4, 6, 8}, replayed)
+	assert.Equal(t, []interface{}{10, 11}, live)
+}
+
+func TestEventStream_ReplayBufferDisabledByDefault(t *testing.T) {
+	es := NewEventStream()
+	es.Publish(1)
+
+	var received []interface{}
+	es.SubscribeWithReplay(func(evt interface{}) { received = append(received, evt) })
+	es.Publish(2)
+
+	assert.Equal(t, []interface{}{2}, received)
+	assert.Nil(t, es.replay)
+}
+
+func TestEventStream_SubscribeWithReplayWhilePublishing(t *testing.T) {
+	const events = 2000
+	for attempt := 0; attempt < 20; attempt++ {
+		es := NewEventStream().WithReplayBuffer(events, nil)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		started := make(chan struct{})
+		go func() {
+			defer wg.Done()
+			for i := 0; i < events; i++ {
+				if i == events/4 {
+					close(started)
+				}
+				es.Publish(i)
+			}
+		}()
+
+		<-started
+		var mu sync.Mutex
+		var received []int
+		es.SubscribeWithReplay(func(evt interface{}) {
+			if r, ok := evt.(*ReplayedEvent); ok {
+				evt = r.Event
+			}
+			mu.Lock()
+			received = append(received, evt.(int))
+			mu.Unlock()
+		})
+		wg.Wait()
+
+		// all the events are received once, in order, whether they were replayed or live
+		mu.Lock()
+		if !assert.Len(t, received, events) {
+			mu.Unlock()
+			return
+		}
+		for i, v := range received {
+			if !assert.Equal(t, i, v) {
+				break
+			}
+		}
+		mu.Unlock()
+	}
+}
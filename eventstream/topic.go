@@ -0,0 +1,66 @@
+package eventstream
+
+import "strings"
+
+// maxCachedTopics bounds the topics the matching subscriptions are cached for,
+// the subscriptions to the other topics are matched on every publish
+const maxCachedTopics = 1024
+
+// SubscribeTopic subscribes fn to the events published to the topics matching pattern.
+// A pattern ending with "*" matches the topics starting with what precedes it, "orders.*" matches "orders.created"
+// and "*" matches all topics. The subscription receives no event published without a topic.
+func (es *EventStream) SubscribeTopic(pattern string, fn func(evt interface{})) *Subscription {
+	if pattern == "" {
+		panic("eventstream: empty topic pattern")
+	}
+	return es.subscribe(&Subscription{fn: fn, topic: pattern})
+}
+
+// PublishTopic publishes evt to the subscribers of topic, the subscribers without a topic do not receive it
+func (es *EventStream) PublishTopic(topic string, evt interface{}) {
+	es.RLock()
+	defer es.RUnlock()
+
+	for _, s := range es.subscriptionsTo(topic) {
+		if s.p == nil || s.p(evt) {
+			s.fn(evt)
+		}
+	}
+}
+
+// matchTopic tells whether topic matches pattern
+func matchTopic(pattern, topic string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(topic, pattern[:len(pattern)-1])
+	}
+	return pattern == topic
+}
+
+// subscriptionsTo returns the subscriptions to topic, only the first event of a topic is matched
+// against the patterns of the subscriptions
+func (es *EventStream) subscriptionsTo(topic string) []*Subscription {
+	dispatch, _ := es.topicDispatch.Load().(map[string][]*Subscription)
+	if subs, ok := dispatch[topic]; ok {
+		return subs
+	}
+
+	es.dispatchMu.Lock()
+	defer es.dispatchMu.Unlock()
+	var subs []*Subscription
+	for _, s := range es.subscriptions {
+		if s.topic != "" && matchTopic(s.topic, topic) {
+			subs = append(subs, s)
+		}
+	}
+	dispatch, _ = es.topicDispatch.Load().(map[string][]*Subscription)
+	if len(dispatch) >= maxCachedTopics {
+		return subs
+	}
+	next := make(map[string][]*Subscription, len(dispatch)+1)
+	for k, v := range dispatch {
+		next[k] = v
+	}
+	next[topic] = subs
+	es.topicDispatch.Store(next)
+	return subs
+}
@@ -0,0 +1,62 @@
+package eventstream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventStream_PublishTopic(t *testing.T) {
+	es := NewEventStream()
+	var exact, orders, all, untopiced []interface{}
+	es.SubscribeTopic("orders.created", func(evt interface{}) { exact = append(exact, evt) })
+	es.SubscribeTopic("orders.*", func(evt interface{}) { orders = append(orders, evt) })
+	es.SubscribeTopic("*", func(evt interface{}) { all = append(all, evt) })
+	es.Subscribe(func(evt interface{}) { untopiced = append(untopiced, evt) })
+
+	es.PublishTopic("orders.created", 1)
+	es.PublishTopic("orders.shipped.late", 2)
+	es.PublishTopic("payments.received", 3)
+	es.Publish(4)
+
+	assert.Equal(t, []interface{}{1}, exact)
+	assert.Equal(t, []interface{}{1, 2}, orders)
+	assert.Equal(t, []interface{}{1, 2, 3}, all)
+	assert.Equal(t, []interface{}{4}, untopiced)
+}
+
+func TestEventStream_SubscribeTopic_WithPredicateAndUnsubscribe(t *testing.T) {
+	es := NewEventStream()
+	var received []interface{}
+	sub := es.SubscribeTopic("orders.*", func(evt interface{}) { received = append(received, evt) }).
+		WithPredicate(func(evt interface{}) bool { return evt.(int) > 1 })
+
+	es.PublishTopic("orders.created", 1)
+	es.PublishTopic("orders.created", 2)
+	es.Unsubscribe(sub)
+	es.PublishTopic("orders.created", 3)
+
+	assert.Equal(t, []interface{}{2}, received)
+}
+
+func BenchmarkPublishTopic(b *testing.B) {
+	for _, subscribers := range []int{10, 1000} {
+		b.Run(fmt.Sprintf("subscribers=%d", subscribers), func(b *testing.B) {
+			es := NewEventStream()
+			for i := 0; i < subscribers; i++ {
+				es.SubscribeTopic(fmt.Sprintf("topic%d.*", i), func(interface{}) {})
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				es.PublishTopic("topic0.created", i)
+			}
+		})
+	}
+}
+
+func BenchmarkMatchTopic(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		matchTopic("orders.*", "orders.created")
+	}
+}
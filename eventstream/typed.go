@@ -0,0 +1,22 @@
+//go:build go1.18
+
+package eventstream
+
+import "reflect"
+
+// SubscribeTo subscribes fn to the events of es assignable to T, the other events are never matched against it again
+func SubscribeTo[T any](es *EventStream, fn func(evt T)) *Subscription {
+	return es.subscribe(&Subscription{
+		typ: reflect.TypeOf((*T)(nil)).Elem(),
+		fn: func(evt interface{}) {
+			fn(evt.(T))
+		},
+	})
+}
+
+// SubscribeWithPredicate subscribes fn to the events of es assignable to T, for which p is true
+func SubscribeWithPredicate[T any](es *EventStream, fn func(evt T), p func(evt T) bool) *Subscription {
+	return SubscribeTo(es, fn).WithPredicate(func(evt interface{}) bool {
+		return p(evt.(T))
+	})
+}
@@ -0,0 +1,95 @@
+//go:build go1.18
+
+package eventstream
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type joined struct{ name string }
+
+type left struct{ name string }
+
+func (e *joined) String() string { return "joined " + e.name }
+
+func TestSubscribeTo_OnlyReceivesAssignableEvents(t *testing.T) {
+	es := NewEventStream()
+	var names []string
+	var stringers []string
+	SubscribeTo(es, func(evt *joined) { names = append(names, evt.name) })
+	SubscribeTo(es, func(evt fmt.Stringer) { stringers = append(stringers, evt.String()) })
+
+	es.Publish(&joined{name: "a"})
+	es.Publish(&left{name: "b"})
+	es.Publish("c")
+	es.Publish(&joined{name: "d"})
+
+	assert.Equal(t, []string{"a", "d"}, names)
+	assert.Equal(t, []string{"joined a", "joined d"}, stringers)
+}
+
+func TestSubscribeWithPredicate_FiltersTypedEvents(t *testing.T) {
+	es := NewEventStream()
+	var names []string
+	SubscribeWithPredicate(es, func(evt *joined) { names = append(names, evt.name) }, func(evt *joined) bool {
+		return evt.name != "b"
+	})
+
+	es.Publish(&joined{name: "a"})
+	es.Publish(&joined{name: "b"})
+	es.Publish(&left{name: "c"})
+
+	assert.Equal(t, []string{"a"}, names)
+}
+
+func TestSubscribeTo_DispatchFollowsSubscriptionChanges(t *testing.T) {
+	es := NewEventStream()
+	var first, second int
+	s1 := SubscribeTo(es, func(*joined) { first++ })
+	es.Publish(&joined{})
+
+	// the subscriptions matched for the type published are cached, they are matched again once they change
+	s2 := SubscribeTo(es, func(*joined) { second++ })
+	es.Publish(&joined{})
+	es.Unsubscribe(s1)
+	es.Publish(&joined{})
+	es.Unsubscribe(s2)
+	es.Publish(&joined{})
+
+	assert.Equal(t, 2, first)
+	assert.Equal(t, 2, second)
+}
+
+// benchmarkPublish publishes to subscribers of disjoint types, one of which is published
+func benchmarkPublish(b *testing.B, subscribers int) {
+	es := NewEventStream()
+	var evt interface{}
+	received := 0
+	for i := 0; i < subscribers; i++ {
+		typ := reflect.ArrayOf(i, reflect.TypeOf(byte(0)))
+		es.subscribe(&Subscription{typ: typ, fn: func(interface{}) { received++ }})
+		if i == 0 {
+			evt = reflect.New(typ).Elem().Interface()
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		es.Publish(evt)
+	}
+	if received != b.N {
+		b.Fatalf("received %d events out of %d", received, b.N)
+	}
+}
+
+func BenchmarkPublish_DisjointSubscribers(b *testing.B) {
+	for _, subscribers := range []int{10, 1000} {
+		b.Run(fmt.Sprintf("subscribers=%d", subscribers), func(b *testing.B) {
+			benchmarkPublish(b, subscribers)
+		})
+	}
+}
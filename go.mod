@@ -4,12 +4,14 @@ require (
 	github.com/AsynkronIT/goconsole v0.0.0-20160504192649-bfa12eebf716 // indirect
 	github.com/AsynkronIT/gonet v0.0.0-20161127091928-0553637be225
 	github.com/Workiva/go-datastructures v1.0.50
+	github.com/alicebob/miniredis/v2 v2.13.0
 	github.com/armon/go-metrics v0.3.0 // indirect
 	github.com/chzyer/logex v1.1.10 // indirect
 	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e
 	github.com/chzyer/test v0.0.0-20180213035817-a1ea475d72b1 // indirect
 	github.com/couchbase/gocb v1.6.7
 	github.com/emirpasic/gods v1.12.0
+	github.com/go-redis/redis/v7 v7.4.0
 	github.com/gogo/protobuf v1.3.1
 	github.com/golang/protobuf v1.3.2
 	github.com/golang/snappy v0.0.2 // indirect
@@ -23,30 +25,38 @@ require (
 	github.com/hashicorp/golang-lru v0.5.3 // indirect
 	github.com/hashicorp/memberlist v0.1.5 // indirect
 	github.com/hashicorp/serf v0.8.5 // indirect
+	github.com/jackc/pgconn v1.7.0
+	github.com/jackc/pgx/v4 v4.9.0
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/labstack/echo v3.3.10+incompatible
 	github.com/labstack/gommon v0.3.0 // indirect
 	github.com/miekg/dns v1.1.22 // indirect
 	github.com/opentracing/opentracing-go v1.1.0
 	github.com/orcaman/concurrent-map v0.0.0-20190107190726-7ed82d9cb717
+	github.com/ory/dockertest/v3 v3.6.0
+	github.com/prometheus/client_golang v1.0.0
 	github.com/serialx/hashring v0.0.0-20180504054112-49a4782e9908
 	github.com/stretchr/objx v0.3.0 // indirect
-	github.com/stretchr/testify v1.6.1
+	github.com/stretchr/testify v1.7.0
 	github.com/uber/jaeger-client-go v2.25.0+incompatible // indirect
 	github.com/uber/jaeger-lib v2.4.0+incompatible // indirect
+	go.etcd.io/etcd v0.0.0-20200824191128-ae9734ed278b
+	go.opentelemetry.io/otel v1.0.0
+	go.opentelemetry.io/otel/sdk v1.0.0
+	go.opentelemetry.io/otel/trace v1.0.0
 	go.uber.org/atomic v1.7.0 // indirect
-	golang.org/x/crypto v0.0.0-20191117063200-497ca9f6d64f // indirect
+	go.uber.org/zap v1.10.0
 	golang.org/x/net v0.0.0-20191116160921-f9c825593386
 	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e
-	golang.org/x/sys v0.0.0-20191118013547-6254a7c3cac6 // indirect
 	google.golang.org/genproto v0.0.0-20191115221424-83cc0476cb11 // indirect
-	google.golang.org/grpc v1.25.1
-	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
+	google.golang.org/grpc v1.26.0
 	gopkg.in/couchbase/gocbcore.v7 v7.1.18 // indirect
 	gopkg.in/couchbaselabs/gocbconnstr.v1 v1.0.4 // indirect
 	gopkg.in/couchbaselabs/jsonx.v1 v1.0.0 // indirect
-	gopkg.in/yaml.v2 v2.2.5 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776 // indirect
+	k8s.io/api v0.17.4
+	k8s.io/apimachinery v0.17.4
+	k8s.io/client-go v0.17.4
 )
 
 go 1.13
@@ -0,0 +1,99 @@
+package grpcgateway
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+)
+
+// the service of testdata/echo.proto, as protoc-gen-go generates it
+
+type EchoClient interface {
+	Say(ctx context.Context, in *wrappers.StringValue, opts ...grpc.CallOption) (*wrappers.StringValue, error)
+	Count(ctx context.Context, in *wrappers.StringValue, opts ...grpc.CallOption) (*wrappers.Int64Value, error)
+}
+
+type echoClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEchoClient(cc *grpc.ClientConn) EchoClient {
+	return &echoClient{cc}
+}
+
+func (c *echoClient) Say(ctx context.Context, in *wrappers.StringValue, opts ...grpc.CallOption) (*wrappers.StringValue, error) {
+	out := new(wrappers.StringValue)
+	err := c.cc.Invoke(ctx, "/echo.Echo/Say", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) Count(ctx context.Context, in *wrappers.StringValue, opts ...grpc.CallOption) (*wrappers.Int64Value, error) {
+	out := new(wrappers.Int64Value)
+	err := c.cc.Invoke(ctx, "/echo.Echo/Count", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type EchoServer interface {
+	Say(context.Context, *wrappers.StringValue) (*wrappers.StringValue, error)
+	Count(context.Context, *wrappers.StringValue) (*wrappers.Int64Value, error)
+}
+
+func _Echo_Say_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrappers.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).Say(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/echo.Echo/Say",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).Say(ctx, req.(*wrappers.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrappers.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/echo.Echo/Count",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).Count(ctx, req.(*wrappers.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Echo_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "echo.Echo",
+	HandlerType: (*EchoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Say",
+			Handler:    _Echo_Say_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _Echo_Count_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "echo.proto",
+}
@@ -0,0 +1,147 @@
+// Package grpcgateway serves gRPC services with actors: the requests of the unary methods are forwarded to the actors,
+// and their responses returned, without hand-written handlers
+package grpcgateway
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PIDResolver returns the actor serving req, nil when there is none
+type PIDResolver func(ctx context.Context, req proto.Message) *actor.PID
+
+// methodHandler is the handler of a unary method of a grpc.ServiceDesc
+type methodHandler = func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error)
+
+// RegisterActorService registers on server the unary methods of the service desc describes, as generated by protoc.
+// Each request is sent to the actor resolve returns for it with the incoming metadata as its header, and the response
+// of the actor is returned with its header as the metadata. The requests fail after timeout, unless it is 0, or the
+// deadline of the call.
+//
+// The actors fail requests with Context.RespondError: the code of the ActorError is the name of a gRPC code, e.g.
+// NotFound, else the status is Unavailable for the retryable errors and Unknown for the others. The requests to no
+// actor or to actors which stopped are Unavailable, the requests timing out DeadlineExceeded. The streaming methods are
+// not served
+func RegisterActorService(server *grpc.Server, desc *grpc.ServiceDesc, system *actor.ActorSystem, resolve PIDResolver, timeout time.Duration) {
+	g := &gateway{system: system, resolve: resolve, timeout: timeout}
+	service := *desc
+	// the gateway implements no generated interface, the handlers don't use it
+	service.HandlerType = (*interface{})(nil)
+	service.Methods = make([]grpc.MethodDesc, len(desc.Methods))
+	for i, method := range desc.Methods {
+		service.Methods[i] = grpc.MethodDesc{MethodName: method.MethodName, Handler: g.handler(method.Handler)}
+	}
+	if len(desc.Streams) > 0 {
+		plog.Info("streaming methods are not served", log.String("service", desc.ServiceName), log.Int("streams", len(desc.Streams)))
+		service.Streams = nil
+	}
+	server.RegisterService(&service, g)
+}
+
+type gateway struct {
+	system  *actor.ActorSystem
+	resolve PIDResolver
+	timeout time.Duration
+}
+
+// handler returns the handler of a method forwarding its requests to the actors. The generated handler decodes the
+// request into its type and hands it to the interceptor: the interceptor set here forwards it to the actor rather
+// than calling the generated interface
+func (g *gateway) handler(generated methodHandler) methodHandler {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		return generated(srv, ctx, dec, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, _ grpc.UnaryHandler) (interface{}, error) {
+			if interceptor != nil {
+				return interceptor(ctx, req, info, g.forward)
+			}
+			return g.forward(ctx, req)
+		})
+	}
+}
+
+func (g *gateway) forward(ctx context.Context, req interface{}) (interface{}, error) {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "request %T is not a proto message", req)
+	}
+	pid := g.resolve(ctx, message)
+	if pid == nil {
+		return nil, status.Errorf(codes.Unavailable, "no actor serves %T", req)
+	}
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+
+	future := g.system.Root.RequestFutureWithContext(ctx, pid, &actor.MessageEnvelope{Header: headerOf(ctx), Message: req})
+	res, err := future.Result()
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	if header := future.ResponseHeader(); header != nil {
+		if err := grpc.SetHeader(ctx, metadata.New(header.ToMap())); err != nil {
+			plog.Error("failed to set the response metadata", log.Error(err))
+		}
+	}
+	if _, ok := res.(proto.Message); !ok {
+		return nil, status.Errorf(codes.Internal, "the actor responded with %T", res)
+	}
+	return res, nil
+}
+
+// headerOf returns the incoming metadata as a message header, the values of a key are joined with commas
+func headerOf(ctx context.Context) map[string]string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || md.Len() == 0 {
+		return nil
+	}
+	header := make(map[string]string, md.Len())
+	for key, values := range md {
+		// the pseudo headers are not metadata
+		if strings.HasPrefix(key, ":") {
+			continue
+		}
+		header[key] = strings.Join(values, ",")
+	}
+	return header
+}
+
+var codesByName = func() map[string]codes.Code {
+	byName := make(map[string]codes.Code)
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		byName[c.String()] = c
+	}
+	return byName
+}()
+
+// statusOf returns the gRPC status error of the error of a request
+func statusOf(err error) error {
+	var actorErr *actor.ActorError
+	switch {
+	case errors.As(err, &actorErr):
+		if code, ok := codesByName[actorErr.Code]; ok {
+			return status.Error(code, actorErr.Message)
+		}
+		if actorErr.Retryable {
+			return status.Error(codes.Unavailable, actorErr.Error())
+		}
+		return status.Error(codes.Unknown, actorErr.Error())
+	case errors.Is(err, actor.ErrDeadLetter):
+		return status.Error(codes.Unavailable, err.Error())
+	case err == actor.ErrTimeout || errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
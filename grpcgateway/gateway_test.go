@@ -0,0 +1,155 @@
+package grpcgateway
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var system = actor.NewActorSystem()
+
+// echoActor serves Say: it echoes the requests, fails the ones starting with fail: with the code following, and
+// ignores the ones saying ignore
+func echoActor(ctx actor.Context) {
+	msg, ok := ctx.Message().(*wrappers.StringValue)
+	if !ok {
+		return
+	}
+	switch {
+	case msg.Value == "ignore":
+	case strings.HasPrefix(msg.Value, "fail:"):
+		code := strings.TrimPrefix(msg.Value, "fail:")
+		ctx.RespondError(&actor.ActorError{Message: "failed", Code: code, Retryable: code == "Busy"})
+	default:
+		ctx.Respond(&actor.MessageEnvelope{
+			Header:  map[string]string{"x-reply": "re: " + ctx.MessageHeader().Get("x-request")},
+			Message: &wrappers.StringValue{Value: msg.Value},
+		})
+	}
+}
+
+// countActor serves Count
+func countActor(ctx actor.Context) {
+	if msg, ok := ctx.Message().(*wrappers.StringValue); ok {
+		ctx.Respond(&wrappers.Int64Value{Value: int64(len(msg.Value))})
+	}
+}
+
+type fixture struct {
+	client      EchoClient
+	intercepted int32
+	stop        func()
+}
+
+// newFixture serves the echo service with the actors over an in-memory connection
+func newFixture(t *testing.T, timeout time.Duration) *fixture {
+	f := &fixture{}
+	echo := system.Root.Spawn(actor.PropsFromFunc(echoActor))
+	count := system.Root.Spawn(actor.PropsFromFunc(countActor))
+	stopped := system.Root.Spawn(actor.PropsFromFunc(echoActor))
+	require.NoError(t, system.Root.StopFuture(stopped).Wait())
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		atomic.AddInt32(&f.intercepted, 1)
+		return handler(ctx, req)
+	}))
+	RegisterActorService(server, &_Echo_serviceDesc, system, func(ctx context.Context, req proto.Message) *actor.PID {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if target := md.Get("x-target"); len(target) > 0 {
+			if target[0] == "stopped" {
+				return stopped
+			}
+			return nil
+		}
+		if method, _ := grpc.Method(ctx); method == "/echo.Echo/Count" {
+			return count
+		}
+		return echo
+	}, timeout)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.Dial()
+		}))
+	require.NoError(t, err)
+	f.client = NewEchoClient(conn)
+
+	f.stop = func() {
+		_ = conn.Close()
+		server.Stop()
+		system.Root.Stop(echo)
+		system.Root.Stop(count)
+	}
+	return f
+}
+
+func TestRegisterActorService(t *testing.T) {
+	f := newFixture(t, time.Second)
+	defer f.stop()
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request", "hello")
+	res, err := f.client.Say(ctx, &wrappers.StringValue{Value: "hello"}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.Value)
+	assert.Equal(t, []string{"re: hello"}, header.Get("x-reply"), "the headers of the messages are the metadata")
+
+	count, err := f.client.Count(context.Background(), &wrappers.StringValue{Value: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count.Value)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&f.intercepted), "the server interceptor is called")
+}
+
+func TestRegisterActorService_Errors(t *testing.T) {
+	f := newFixture(t, 50*time.Millisecond)
+	defer f.stop()
+
+	for _, tc := range []struct {
+		name   string
+		value  string
+		target string
+		code   codes.Code
+	}{
+		{name: "gRPC code", value: "fail:NotFound", code: codes.NotFound},
+		{name: "retryable", value: "fail:Busy", code: codes.Unavailable},
+		{name: "other", value: "fail:Oops", code: codes.Unknown},
+		{name: "timeout", value: "ignore", code: codes.DeadlineExceeded},
+		{name: "no actor", value: "hello", target: "none", code: codes.Unavailable},
+		{name: "stopped actor", value: "hello", target: "stopped", code: codes.Unavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.target != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-target", tc.target)
+			}
+			_, err := f.client.Say(ctx, &wrappers.StringValue{Value: tc.value})
+			assert.Equal(t, tc.code, status.Code(err), err)
+		})
+	}
+
+	// the deadline of the call bounds the request too
+	g := newFixture(t, time.Minute)
+	defer g.stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := g.client.Say(ctx, &wrappers.StringValue{Value: "ignore"})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
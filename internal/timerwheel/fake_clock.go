@@ -0,0 +1,100 @@
+package timerwheel
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock for the tests, it fires the timers synchronously as the time is advanced
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	fn      func()
+	stopped bool
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{at: c.now.Add(d), fn: f}
+	c.timers = append(c.timers, t)
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		stopped := t.stopped
+		t.stopped = true
+		return !stopped
+	}
+}
+
+// Advance moves the time forward by d, firing the timers on time
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+	for c.fireNext(end) {
+	}
+	c.mu.Lock()
+	c.now = end
+	c.mu.Unlock()
+}
+
+// Suspend moves the time forward by d at once, as a suspended process sees it, the timers due fire late
+func (c *FakeClock) Suspend(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	end := c.now
+	c.mu.Unlock()
+	for c.fireNext(end) {
+	}
+}
+
+// Sleep moves the time forward by d without firing the timers, as a slow callback sees it
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// fireNext fires the first timer due by end
+func (c *FakeClock) fireNext(end time.Time) bool {
+	c.mu.Lock()
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if !t.stopped {
+			pending = append(pending, t)
+		}
+	}
+	c.timers = pending
+	sort.SliceStable(c.timers, func(i, j int) bool { return c.timers[i].at.Before(c.timers[j].at) })
+	if len(c.timers) == 0 || c.timers[0].at.After(end) {
+		c.mu.Unlock()
+		return false
+	}
+
+	t := c.timers[0]
+	c.timers = c.timers[1:]
+	t.stopped = true
+	if t.at.After(c.now) {
+		c.now = t.at
+	}
+	c.mu.Unlock()
+	t.fn()
+	return true
+}
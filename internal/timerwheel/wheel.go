@@ -0,0 +1,162 @@
+package timerwheel
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// timerEntry is a timer of a wheel, it fires once when interval is zero
+type timerEntry struct {
+	at         time.Time
+	epoch      time.Time
+	interval   time.Duration
+	fixedDelay bool
+	ticks      int64
+	fn         func()
+
+	index     int // in the heap, -1 when it is not queued
+	cancelled bool
+}
+
+type timerHeap []*timerEntry
+
+func (h timerHeap) Len() int           { return len(h) }
+func (h timerHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x interface{}) {
+	e := x.(*timerEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *timerHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
+
+// Wheel runs timers with a single clock timer armed for the earliest of them,
+// so that no goroutine is held by the pending timers
+type Wheel struct {
+	clock Clock
+
+	mu      sync.Mutex
+	entries timerHeap
+	armedAt time.Time
+	stop    func() bool
+}
+
+// Default is the wheel shared by the timers of the actors and the schedulers
+var Default = New(RealClock{})
+
+func New(clock Clock) *Wheel {
+	return &Wheel{clock: clock}
+}
+
+// Schedule calls fn after delay, then every interval unless it is zero. The repeated timers fire at a fixed rate:
+// at the initial time plus a multiple of interval, skipping the ticks missed. With fixedDelay, they fire interval
+// after the previous tick completed instead.
+func (w *Wheel) Schedule(delay, interval time.Duration, fixedDelay bool, fn func()) (cancel func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	at := w.clock.Now().Add(delay)
+	e := &timerEntry{at: at, epoch: at, interval: interval, fixedDelay: fixedDelay, fn: fn, index: -1}
+	heap.Push(&w.entries, e)
+	w.arm()
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		e.cancelled = true
+		if e.index >= 0 {
+			heap.Remove(&w.entries, e.index)
+			w.arm()
+		}
+	}
+}
+
+// Len returns the number of pending timers
+func (w *Wheel) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.entries)
+}
+
+// arm makes the clock timer fire for the earliest entry, it is called with the lock held
+func (w *Wheel) arm() {
+	if len(w.entries) == 0 {
+		if w.stop != nil {
+			w.stop()
+			w.stop = nil
+		}
+		return
+	}
+	earliest := w.entries[0].at
+	if w.stop != nil && !w.armedAt.After(earliest) {
+		return
+	}
+	if w.stop != nil {
+		w.stop()
+	}
+	w.armedAt = earliest
+	w.stop = w.clock.AfterFunc(earliest.Sub(w.clock.Now()), w.fire)
+}
+
+// fire runs the entries due, and requeues the repeated ones
+func (w *Wheel) fire() {
+	w.mu.Lock()
+	w.stop = nil
+	now := w.clock.Now()
+	var due []*timerEntry
+	for len(w.entries) > 0 && !w.entries[0].at.After(now) {
+		due = append(due, heap.Pop(&w.entries).(*timerEntry))
+	}
+	w.arm()
+	w.mu.Unlock()
+
+	for _, e := range due {
+		w.mu.Lock()
+		cancelled := e.cancelled
+		w.mu.Unlock()
+		if cancelled {
+			continue
+		}
+
+		e.fn()
+		if e.interval <= 0 {
+			continue
+		}
+
+		w.mu.Lock()
+		if !e.cancelled {
+			e.at = e.next(w.clock.Now())
+			heap.Push(&w.entries, e)
+			w.arm()
+		}
+		w.mu.Unlock()
+	}
+}
+
+// next returns the time the entry fires after the tick completed at now
+func (e *timerEntry) next(now time.Time) time.Time {
+	if e.fixedDelay {
+		return now.Add(e.interval)
+	}
+	e.ticks++
+	at := e.epoch.Add(time.Duration(e.ticks) * e.interval)
+	if at.Before(now) {
+		e.ticks = int64(now.Sub(e.epoch)/e.interval) + 1
+		at = e.epoch.Add(time.Duration(e.ticks) * e.interval)
+	}
+	return at
+}
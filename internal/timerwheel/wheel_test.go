@@ -0,0 +1,122 @@
+package timerwheel
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runs records the times a timer fired
+type runs struct {
+	mu    sync.Mutex
+	clock Clock
+	times []time.Time
+}
+
+func (r *runs) record() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.times = append(r.times, r.clock.Now())
+}
+
+func (r *runs) get() []time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Time(nil), r.times...)
+}
+
+func TestWheel_Once(t *testing.T) {
+	clock := NewFakeClock(time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC))
+	w := New(clock)
+	r := &runs{clock: clock}
+
+	w.Schedule(2*time.Second, 0, false, r.record)
+	cancel := w.Schedule(time.Second, 0, false, r.record)
+	cancel()
+	clock.Advance(time.Minute)
+	assert.Equal(t, []time.Time{time.Date(2021, 6, 15, 10, 0, 2, 0, time.UTC)}, r.get())
+}
+
+func TestWheel_Cancel(t *testing.T) {
+	clock := NewFakeClock(time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC))
+	w := New(clock)
+	r := &runs{clock: clock}
+
+	var cancel func()
+	cancel = w.Schedule(time.Second, time.Second, false, func() {
+		r.record()
+		if len(r.get()) == 3 {
+			cancel()
+		}
+	})
+	clock.Advance(time.Minute)
+	assert.Len(t, r.get(), 3)
+	assert.Zero(t, w.Len())
+}
+
+// tickDrift runs a timer ticking every second for an hour, each tick taking 10ms,
+// and returns the number of ticks and how late the last one was
+func tickDrift(fixedDelay bool) (int, time.Duration) {
+	start := time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	w := New(clock)
+	r := &runs{clock: clock}
+
+	w.Schedule(time.Second, time.Second, fixedDelay, func() {
+		r.record()
+		clock.Sleep(10 * time.Millisecond)
+	})
+	clock.Advance(time.Hour)
+
+	times := r.get()
+	last := times[len(times)-1]
+	return len(times), last.Sub(start.Add(time.Duration(len(times)) * time.Second))
+}
+
+func TestWheel_false(t *testing.T) {
+	ticks, drift := tickDrift(false)
+	assert.Equal(t, 3600, ticks)
+	assert.Zero(t, drift)
+}
+
+func TestWheel_FixedDelay(t *testing.T) {
+	ticks, drift := tickDrift(true)
+	assert.Equal(t, 3564, ticks)
+	assert.Equal(t, 3563*10*time.Millisecond, drift)
+}
+
+func TestWheel_false_SkipsMissedTicks(t *testing.T) {
+	start := time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	w := New(clock)
+	r := &runs{clock: clock}
+
+	w.Schedule(time.Second, time.Second, false, r.record)
+	clock.Advance(time.Second)
+	clock.Suspend(5500 * time.Millisecond)
+	clock.Advance(time.Second)
+	assert.Equal(t, []time.Time{
+		start.Add(time.Second),
+		start.Add(6500 * time.Millisecond),
+		start.Add(7 * time.Second),
+	}, r.get())
+}
+
+func BenchmarkWheel_Schedules(b *testing.B) {
+	w := New(RealClock{})
+	before := runtime.NumGoroutine()
+	cancels := make([]func(), 0, b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cancels = append(cancels, w.Schedule(time.Millisecond, 10*time.Millisecond, false, func() {}))
+	}
+	time.Sleep(50 * time.Millisecond)
+	b.StopTimer()
+	b.ReportMetric(float64(runtime.NumGoroutine()-before), "goroutines")
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
@@ -0,0 +1,237 @@
+// Package kvactor provides an in-process map owned by shard actors, the keys are routed to their shard by a
+// consistent hash router so that the operations on a key run one at a time, in the order they were sent
+package kvactor
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/router"
+)
+
+// ErrStopped is returned when resizing a stopped Store
+var ErrStopped = errors.New("kvactor: the store is stopped")
+
+type config struct {
+	fold       Fold
+	shardProps func(*actor.Props) *actor.Props
+	timeout    time.Duration
+}
+
+// Option configures a Store
+type Option func(*config)
+
+// WithFold sets how Put combines the value put with the current value of the key, e.g. to sum counters. The value
+// put replaces the current one by default
+func WithFold(fold Fold) Option {
+	return func(c *config) {
+		c.fold = fold
+	}
+}
+
+// WithShardProps configures the props of the shard actors, e.g. their mailbox or middlewares
+func WithShardProps(configure func(props *actor.Props) *actor.Props) Option {
+	return func(c *config) {
+		c.shardProps = configure
+	}
+}
+
+// WithTimeout is how long the futures of the operations wait for their shard, five seconds by default
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+	}
+}
+
+// Store is a map sharded by key hash over actors. Each shard owns its keys, the operations on a key are
+// linearizable: they run in the shard one at a time. The operations are asynchronous, their futures complete with
+// the *Entry of their key.
+// A shard keeps its entries in memory only, they are lost when it crashes
+type Store struct {
+	system *actor.ActorSystem
+	config config
+
+	mu      sync.RWMutex
+	shards  []*actor.PID
+	ring    *router.Ring
+	router  *actor.PID
+	stopped bool
+}
+
+// New spawns a Store of shards shard actors
+func New(system *actor.ActorSystem, shards int, opts ...Option) *Store {
+	s := &Store{system: system, config: config{fold: replace, timeout: 5 * time.Second}}
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	for i := 0; i < shards; i++ {
+		s.shards = append(s.shards, s.spawnShard())
+	}
+	s.route()
+	return s
+}
+
+func (s *Store) spawnShard() *actor.PID {
+	fold := s.config.fold
+	props := actor.PropsFromProducer(func() actor.Actor { return newShard(fold) })
+	if s.config.shardProps != nil {
+		props = s.config.shardProps(props)
+	}
+	return s.system.Root.SpawnPrefix(props, "kvshard")
+}
+
+// route spawns the router of the shards
+func (s *Store) route() {
+	s.ring = router.NewRing(actor.NewPIDSet(s.shards...))
+	s.router = s.system.Root.Spawn(router.NewConsistentHashGroup(s.shards...))
+}
+
+func (s *Store) request(message interface{}) *actor.Future {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.system.Root.RequestFuture(s.router, message, s.config.timeout)
+}
+
+// Get reads the value of key
+func (s *Store) Get(key string) *actor.Future {
+	return s.request(&get{key: key})
+}
+
+// Put stores value for key, folded with the current value when the store has a Fold
+func (s *Store) Put(key string, value interface{}) *actor.Future {
+	return s.request(&put{key: key, value: value})
+}
+
+// Update runs fn in the shard of key with its current value, no other operation on the key runs meanwhile. fn must
+// not block, the other keys of the shard wait for it. The future fails when fn panics
+func (s *Store) Update(key string, fn UpdateFunc) *actor.Future {
+	return s.request(&update{key: key, fn: fn})
+}
+
+// Delete removes key, the future completes with the value removed
+func (s *Store) Delete(key string) *actor.Future {
+	return s.request(&remove{key: key})
+}
+
+// MultiGet reads keys with a request per shard rather than per key
+func (s *Store) MultiGet(keys ...string) *MultiGetFuture {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	batches := make(map[string]*multiGet)
+	owners := make(map[string]*actor.PID)
+	for _, key := range keys {
+		owner, _ := s.ring.Get(key)
+		batch, ok := batches[owner.Id]
+		if !ok {
+			batch = &multiGet{}
+			batches[owner.Id] = batch
+			owners[owner.Id] = owner
+		}
+		batch.keys = append(batch.keys, key)
+	}
+
+	future := actor.NewAggregatorFuture(s.system, len(batches), s.config.timeout)
+	for id, batch := range batches {
+		envelope := &actor.MessageEnvelope{Message: batch, Sender: future.PID()}
+		envelope.SetHeader(actor.CorrelationIDHeader, future.CorrelationID())
+		s.system.Root.Send(owners[id], envelope)
+	}
+	return &MultiGetFuture{keys: keys, future: future}
+}
+
+// Resize changes the number of shards, the entries of the keys the ring maps to another shard move to it. The
+// consistent hashing moves about the share of the keys of one shard per shard added or removed. The operations wait
+// for the resize, it returns the number of entries moved
+func (s *Store) Resize(shards int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return 0, ErrStopped
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	resized := append([]*actor.PID(nil), s.shards...)
+	for len(resized) < shards {
+		resized = append(resized, s.spawnShard())
+	}
+	removed := resized[shards:]
+	resized = resized[:shards]
+
+	// the shards hand their entries off before responding, the new owners receive them before the operations. The
+	// shards which did not respond in time still hand them off, the resize carries on and returns the first error
+	ring := router.NewRing(actor.NewPIDSet(resized...))
+	moved := 0
+	var err error
+	for _, pid := range s.shards {
+		res, resErr := s.system.Root.RequestFuture(pid, &rebalance{ring: ring}, s.config.timeout).Result()
+		if resErr != nil {
+			if err == nil {
+				err = resErr
+			}
+			continue
+		}
+		moved += res.(*rebalanced).moved
+	}
+
+	for _, pid := range removed {
+		// after their hand off, and before the router watching them
+		_ = s.system.Root.PoisonFuture(pid).Wait()
+	}
+	s.system.Root.Stop(s.router)
+	s.shards = resized
+	s.route()
+	return moved, err
+}
+
+// Shards returns the number of shards
+func (s *Store) Shards() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.shards)
+}
+
+// Stop stops the shards once they ran the operations sent before, their entries are lost. The operations on a
+// stopped store time out
+func (s *Store) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	for _, pid := range s.shards {
+		_ = s.system.Root.PoisonFuture(pid).Wait()
+	}
+	_ = s.system.Root.StopFuture(s.router).Wait()
+}
+
+// MultiGetFuture completes with the entries of a MultiGet
+type MultiGetFuture struct {
+	keys   []string
+	future *actor.AggregatorFuture
+}
+
+// Result waits for the shards, and returns the entries of the keys in their order. The entries of the shards which
+// did not respond are missing from the ones returned with the error
+func (f *MultiGetFuture) Result() ([]*Entry, error) {
+	responses, err := f.future.Result()
+	byKey := make(map[string]*Entry, len(f.keys))
+	for _, res := range responses {
+		for _, entry := range res.Message.([]*Entry) {
+			byKey[entry.Key] = entry
+		}
+	}
+	entries := make([]*Entry, 0, len(f.keys))
+	for _, key := range f.keys {
+		if entry, ok := byKey[key]; ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, err
+}
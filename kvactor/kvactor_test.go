@@ -0,0 +1,173 @@
+package kvactor
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var system = actor.NewActorSystem()
+
+func entryOf(t *testing.T, f *actor.Future) *Entry {
+	res, err := f.Result()
+	require.NoError(t, err)
+	return res.(*Entry)
+}
+
+func increment(current interface{}, found bool) (interface{}, bool) {
+	if !found {
+		return 1, true
+	}
+	return current.(int) + 1, true
+}
+
+func TestStore(t *testing.T) {
+	s := New(system, 4)
+	defer s.Stop()
+
+	assert.False(t, entryOf(t, s.Get("a")).Found)
+	assert.Equal(t, &Entry{Key: "a", Value: "1", Found: true}, entryOf(t, s.Put("a", "1")))
+	assert.Equal(t, "1", entryOf(t, s.Get("a")).Value)
+	assert.Equal(t, &Entry{Key: "a", Value: "1", Found: true}, entryOf(t, s.Delete("a")))
+	assert.False(t, entryOf(t, s.Get("a")).Found)
+}
+
+func TestStore_UpdateIsLinearizable(t *testing.T) {
+	s := New(system, 4)
+	defer s.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, err := s.Update("hits", increment).Result()
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 500, entryOf(t, s.Get("hits")).Value)
+
+	// the update removes the key unless it keeps it
+	entry := entryOf(t, s.Update("hits", func(interface{}, bool) (interface{}, bool) { return nil, false }))
+	assert.False(t, entry.Found)
+	assert.False(t, entryOf(t, s.Get("hits")).Found)
+}
+
+func TestStore_UpdatePanics(t *testing.T) {
+	s := New(system, 1)
+	defer s.Stop()
+	entryOf(t, s.Put("a", 1))
+
+	_, err := s.Update("a", func(interface{}, bool) (interface{}, bool) { panic("boom") }).Result()
+	assert.EqualError(t, err, "kvactor: the update of a panicked: boom")
+	assert.Equal(t, 1, entryOf(t, s.Get("a")).Value, "the shard kept its entries")
+}
+
+func TestStore_Fold(t *testing.T) {
+	s := New(system, 2, WithFold(func(current interface{}, found bool, value interface{}) interface{} {
+		if !found {
+			return value
+		}
+		return current.(int) + value.(int)
+	}))
+	defer s.Stop()
+
+	for i := 1; i <= 4; i++ {
+		s.Put("sum", i)
+	}
+	assert.Equal(t, 10, entryOf(t, s.Get("sum")).Value)
+}
+
+func TestStore_MultiGet(t *testing.T) {
+	s := New(system, 4)
+	defer s.Stop()
+	for i := 0; i < 20; i++ {
+		entryOf(t, s.Put(strconv.Itoa(i), i))
+	}
+
+	entries, err := s.MultiGet("3", "missing", "17", "3").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, &Entry{Key: "3", Value: 3, Found: true}, entries[0])
+	assert.False(t, entries[1].Found)
+	assert.Equal(t, 17, entries[2].Value)
+	assert.Equal(t, entries[0], entries[3])
+
+	entries, err = s.MultiGet().Result()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestStore_Resize(t *testing.T) {
+	s := New(system, 4)
+	defer s.Stop()
+	const count = 1000
+	for i := 0; i < count; i++ {
+		s.Put(strconv.Itoa(i), i)
+	}
+
+	moved, err := s.Resize(5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, s.Shards())
+	assert.True(t, moved > 0 && moved < count/2, "few keys moved: %v", moved)
+	for i := 0; i < count; i++ {
+		assert.Equal(t, i, entryOf(t, s.Get(strconv.Itoa(i))).Value)
+	}
+
+	_, err = s.Resize(2)
+	require.NoError(t, err)
+	keys := make([]string, count)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	entries, err := s.MultiGet(keys...).Result()
+	require.NoError(t, err)
+	for i, entry := range entries {
+		assert.Equal(t, i, entry.Value)
+	}
+}
+
+func TestStore_ResizeWhileUpdating(t *testing.T) {
+	s := New(system, 2)
+	defer s.Stop()
+
+	var wg sync.WaitGroup
+	var failed int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := s.Update(strconv.Itoa(j), increment).Result(); err != nil {
+					atomic.AddInt32(&failed, 1)
+				}
+			}
+		}(i)
+	}
+	for _, shards := range []int{3, 6, 4} {
+		time.Sleep(time.Millisecond)
+		_, err := s.Resize(shards)
+		require.NoError(t, err)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(0), failed)
+	for j := 0; j < 100; j++ {
+		assert.Equal(t, 10, entryOf(t, s.Get(strconv.Itoa(j))).Value)
+	}
+}
+
+func TestStore_Stopped(t *testing.T) {
+	s := New(system, 2)
+	s.Stop()
+	_, err := s.Resize(3)
+	assert.Equal(t, ErrStopped, err)
+}
@@ -0,0 +1,172 @@
+package kvactor
+
+import (
+	"fmt"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/router"
+)
+
+// Entry is the value of a key, as a shard returns it
+type Entry struct {
+	Key   string
+	Value interface{}
+	// Found is false when the key has no value
+	Found bool
+}
+
+// Fold computes the value a Put stores from the current value of the key, if found, and the value put
+type Fold func(current interface{}, found bool, value interface{}) interface{}
+
+// UpdateFunc computes the new value of a key from its current value, if found. The key is removed unless keep
+type UpdateFunc func(current interface{}, found bool) (value interface{}, keep bool)
+
+// replace is the default Fold, the value put replaces the current one
+func replace(_ interface{}, _ bool, value interface{}) interface{} {
+	return value
+}
+
+// the single key operations are routed by their key through a consistent hash router
+type (
+	get struct {
+		key string
+	}
+	put struct {
+		key   string
+		value interface{}
+	}
+	update struct {
+		key string
+		fn  UpdateFunc
+	}
+	remove struct {
+		key string
+	}
+)
+
+func (m *get) Hash() string    { return m.key }
+func (m *put) Hash() string    { return m.key }
+func (m *update) Hash() string { return m.key }
+func (m *remove) Hash() string { return m.key }
+
+var (
+	_ router.Hasher = (*get)(nil)
+	_ router.Hasher = (*put)(nil)
+	_ router.Hasher = (*update)(nil)
+	_ router.Hasher = (*remove)(nil)
+)
+
+// multiGet reads the keys of a shard at once
+type multiGet struct {
+	keys []string
+}
+
+// rebalance hands the entries of the shard which the ring maps to another shard off to it
+type rebalance struct {
+	ring *router.Ring
+}
+
+// rebalanced is the response to rebalance
+type rebalanced struct {
+	moved int
+}
+
+// handoff carries the entries moved to a shard
+type handoff struct {
+	entries map[string]interface{}
+}
+
+// shard owns the entries of the keys it is routed, its operations are linearizable per key as it runs them one at
+// a time
+type shard struct {
+	fold    Fold
+	entries map[string]interface{}
+}
+
+func newShard(fold Fold) actor.Actor {
+	return &shard{fold: fold, entries: make(map[string]interface{})}
+}
+
+func (s *shard) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *get:
+		ctx.Respond(s.entry(msg.key))
+	case *put:
+		current, found := s.entries[msg.key]
+		s.entries[msg.key] = s.fold(current, found, msg.value)
+		ctx.Respond(s.entry(msg.key))
+	case *update:
+		entry, err := s.update(msg)
+		if err != nil {
+			ctx.RespondError(err)
+			return
+		}
+		ctx.Respond(entry)
+	case *remove:
+		entry := s.entry(msg.key)
+		delete(s.entries, msg.key)
+		ctx.Respond(entry)
+	case *multiGet:
+		entries := make([]*Entry, len(msg.keys))
+		for i, key := range msg.keys {
+			entries[i] = s.entry(key)
+		}
+		ctx.Respond(entries)
+	case *rebalance:
+		ctx.Respond(&rebalanced{moved: s.rebalance(ctx, msg.ring)})
+	case *handoff:
+		for key, value := range msg.entries {
+			s.entries[key] = value
+		}
+	}
+}
+
+func (s *shard) entry(key string) *Entry {
+	value, found := s.entries[key]
+	return &Entry{Key: key, Value: value, Found: found}
+}
+
+// update runs the closure of msg, its panics fail the update rather than restarting the shard, which would lose
+// its entries
+func (s *shard) update(msg *update) (entry *Entry, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("kvactor: the update of %v panicked: %v", msg.key, r)
+		}
+	}()
+	current, found := s.entries[msg.key]
+	value, keep := msg.fn(current, found)
+	if !keep {
+		delete(s.entries, msg.key)
+		return &Entry{Key: msg.key}, nil
+	}
+	s.entries[msg.key] = value
+	return &Entry{Key: msg.key, Value: value, Found: true}, nil
+}
+
+// rebalance sends the entries the ring maps to other shards to them, before responding, so that they receive them
+// before the operations routed with the ring
+func (s *shard) rebalance(ctx actor.Context, ring *router.Ring) int {
+	moved := make(map[string]*handoff)
+	owners := make(map[string]*actor.PID)
+	for key, value := range s.entries {
+		owner, ok := ring.Get(key)
+		if !ok || owner.Equal(ctx.Self()) {
+			continue
+		}
+		h, ok := moved[owner.Id]
+		if !ok {
+			h = &handoff{entries: make(map[string]interface{})}
+			moved[owner.Id] = h
+			owners[owner.Id] = owner
+		}
+		h.entries[key] = value
+		delete(s.entries, key)
+	}
+	count := 0
+	for id, h := range moved {
+		ctx.Send(owners[id], h)
+		count += len(h.entries)
+	}
+	return count
+}
@@ -0,0 +1,81 @@
+package log
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Adapter writes the events of the loggers to another logging library
+type Adapter interface {
+	// Enabled returns whether the events of level are written, the events of the other levels are
+	// never built, nor are their fields converted
+	Enabled(level Level) bool
+	// Log writes evt
+	Log(evt Event)
+}
+
+type adapterHolder struct {
+	adapter Adapter
+}
+
+var (
+	adapterMu  sync.Mutex
+	adapterSub *Subscription
+	adapter    atomic.Value // adapterHolder
+)
+
+// SetAdapter writes the events of all the loggers, e.g. of the actor, remote, cluster and persistence packages,
+// with adapter rather than to stderr. Passing nil writes them to stderr again.
+//
+// SetAdapter is safe to call concurrently
+func SetAdapter(a Adapter) {
+	adapterMu.Lock()
+	defer adapterMu.Unlock()
+
+	if adapterSub != nil {
+		Unsubscribe(adapterSub)
+		adapterSub = nil
+	}
+	adapter.Store(adapterHolder{adapter: a})
+	if a == nil {
+		if sub == nil {
+			sub = Subscribe(defaultLogger.publish)
+		}
+		return
+	}
+	if sub != nil {
+		Unsubscribe(sub)
+		sub = nil
+	}
+	adapterSub = Subscribe(a.Log)
+}
+
+// enabled returns whether the events of level are written by the adapter, when there is one
+func enabled(level Level) bool {
+	holder, _ := adapter.Load().(adapterHolder)
+	return holder.adapter == nil || holder.adapter.Enabled(level)
+}
+
+// Subsystem returns the name of the subsystem which logged the event, from its prefix,
+// e.g. cluster.etcd for [CLUSTER] [ETCD]
+func (evt Event) Subsystem() string {
+	var sb strings.Builder
+	for _, part := range strings.Fields(evt.Prefix) {
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(strings.ToLower(strings.Trim(part, "[]")))
+	}
+	return sb.String()
+}
+
+// EncodeFields encodes the context and the fields of the event via enc
+func (evt Event) EncodeFields(enc Encoder) {
+	for _, f := range evt.Context {
+		f.Encode(enc)
+	}
+	for _, f := range evt.Fields {
+		f.Encode(enc)
+	}
+}
@@ -0,0 +1,62 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingAdapter struct {
+	level  Level
+	events []Event
+}
+
+func (a *recordingAdapter) Enabled(level Level) bool {
+	return level >= a.level
+}
+
+func (a *recordingAdapter) Log(evt Event) {
+	a.events = append(a.events, evt)
+}
+
+func TestSetAdapter(t *testing.T) {
+	adapter := &recordingAdapter{level: InfoLevel}
+	SetAdapter(adapter)
+	defer SetAdapter(nil)
+
+	l := New(DebugLevel, "[CLUSTER] [ETCD]", String("member", "a"))
+	l.Debug("filtered")
+	l.Info("joined", Int("members", 3))
+	l.Error("left")
+
+	if assert.Len(t, adapter.events, 2) {
+		assert.Equal(t, "joined", adapter.events[0].Message)
+		assert.Equal(t, InfoLevel, adapter.events[0].Level)
+		assert.Equal(t, []Field{String("member", "a")}, adapter.events[0].Context)
+		assert.Equal(t, []Field{Int("members", 3)}, adapter.events[0].Fields)
+		assert.Equal(t, "cluster.etcd", adapter.events[0].Subsystem())
+		assert.Equal(t, ErrorLevel, adapter.events[1].Level)
+	}
+}
+
+func TestSetAdapter_Nil(t *testing.T) {
+	adapter := &recordingAdapter{}
+	SetAdapter(adapter)
+	SetAdapter(nil)
+
+	New(DebugLevel, "").Info("to stderr")
+	assert.Empty(t, adapter.events)
+	assert.NotNil(t, sub, "the default writer is restored")
+}
+
+func TestAdapter_DisabledLevelDoesNotAllocate(t *testing.T) {
+	SetAdapter(&recordingAdapter{level: ErrorLevel})
+	defer SetAdapter(nil)
+
+	l := New(DebugLevel, "", Int("bar", 32))
+	allocs := testing.AllocsPerRun(100, func() {
+		l.Debug("foo")
+		l.Info("foo", Int("fum", 1), String("bar", "baz"))
+	})
+	assert.Zero(t, allocs)
+}
@@ -58,20 +58,35 @@ func (l *Logger) SetLevel(level Level) {
 	atomic.StoreInt32((*int32)(&l.level), int32(level))
 }
 
+// Enabled tells whether the events of level are logged, so that the callers can skip building costly fields
+func (l *Logger) Enabled(level Level) bool {
+	return l.Level() <= level && level < OffLevel && enabled(level)
+}
+
 func (l *Logger) Debug(msg string, fields ...Field) {
-	if l.Level() < InfoLevel {
-		es.Publish(Event{Time: time.Now(), Level: DebugLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
+	if l.Enabled(DebugLevel) {
+		l.publish(DebugLevel, msg, fields)
 	}
 }
 
 func (l *Logger) Info(msg string, fields ...Field) {
-	if l.Level() < ErrorLevel {
-		es.Publish(Event{Time: time.Now(), Level: InfoLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
+	if l.Enabled(InfoLevel) {
+		l.publish(InfoLevel, msg, fields)
 	}
 }
 
 func (l *Logger) Error(msg string, fields ...Field) {
-	if l.Level() < OffLevel {
-		es.Publish(Event{Time: time.Now(), Level: ErrorLevel, Prefix: l.prefix, Message: msg, Context: l.context, Fields: fields})
+	if l.Enabled(ErrorLevel) {
+		l.publish(ErrorLevel, msg, fields)
+	}
+}
+
+// publish copies fields, so that they do not escape and the callers do not allocate them when the level is disabled
+func (l *Logger) publish(level Level, msg string, fields []Field) {
+	var copied []Field
+	if len(fields) > 0 {
+		copied = make([]Field, len(fields))
+		copy(copied, fields)
 	}
+	es.Publish(Event{Time: time.Now(), Level: level, Prefix: l.prefix, Message: msg, Context: l.context, Fields: copied})
 }
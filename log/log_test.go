@@ -13,6 +13,16 @@ func TestLogger_With(t *testing.T) {
 	assert.Equal(t, []Field{{key: "first"}, {key: "second"}}, l.context)
 }
 
+func TestLogger_Enabled(t *testing.T) {
+	l := New(InfoLevel, "")
+	assert.False(t, l.Enabled(DebugLevel))
+	assert.True(t, l.Enabled(InfoLevel))
+	assert.True(t, l.Enabled(ErrorLevel))
+
+	l.SetLevel(OffLevel)
+	assert.False(t, l.Enabled(ErrorLevel))
+}
+
 func Benchmark_OffLevel_TwoFields(b *testing.B) {
 	l := New(MinLevel, "")
 	for i := 0; i < b.N; i++ {
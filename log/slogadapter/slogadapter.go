@@ -0,0 +1,96 @@
+//go:build go1.21
+
+// Package slogadapter writes the protoactor logs with log/slog
+package slogadapter
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// SubsystemKey is the key of the attribute holding the subsystem which logged, e.g. actor or remote
+const SubsystemKey = "subsystem"
+
+type adapter struct {
+	logger *slog.Logger
+}
+
+// New returns the adapter writing the logs with logger, it is given to log.SetAdapter
+func New(logger *slog.Logger) log.Adapter {
+	return &adapter{logger: logger}
+}
+
+// Level maps the level of a protoactor log to the slog one
+func Level(level log.Level) slog.Level {
+	switch level {
+	case log.MinLevel, log.DebugLevel:
+		return slog.LevelDebug
+	case log.InfoLevel:
+		return slog.LevelInfo
+	}
+	return slog.LevelError
+}
+
+func (a *adapter) Enabled(level log.Level) bool {
+	return a.logger.Enabled(context.Background(), Level(level))
+}
+
+func (a *adapter) Log(evt log.Event) {
+	enc := &encoder{record: slog.NewRecord(evt.Time, Level(evt.Level), evt.Message, 0)}
+	enc.record.AddAttrs(slog.String(SubsystemKey, evt.Subsystem()))
+	evt.EncodeFields(enc)
+	_ = a.logger.Handler().Handle(context.Background(), enc.record)
+}
+
+// encoder adds the fields of an event as the attributes of a record
+type encoder struct {
+	record slog.Record
+}
+
+func (e *encoder) EncodeBool(key string, val bool) {
+	e.record.AddAttrs(slog.Bool(key, val))
+}
+
+func (e *encoder) EncodeFloat64(key string, val float64) {
+	e.record.AddAttrs(slog.Float64(key, val))
+}
+
+func (e *encoder) EncodeInt(key string, val int) {
+	e.record.AddAttrs(slog.Int(key, val))
+}
+
+func (e *encoder) EncodeInt64(key string, val int64) {
+	e.record.AddAttrs(slog.Int64(key, val))
+}
+
+func (e *encoder) EncodeDuration(key string, val time.Duration) {
+	e.record.AddAttrs(slog.Duration(key, val))
+}
+
+func (e *encoder) EncodeUint(key string, val uint) {
+	e.record.AddAttrs(slog.Uint64(key, uint64(val)))
+}
+
+func (e *encoder) EncodeUint64(key string, val uint64) {
+	e.record.AddAttrs(slog.Uint64(key, val))
+}
+
+func (e *encoder) EncodeString(key string, val string) {
+	e.record.AddAttrs(slog.String(key, val))
+}
+
+func (e *encoder) EncodeObject(key string, val interface{}) {
+	e.record.AddAttrs(slog.Any(key, val))
+}
+
+func (e *encoder) EncodeType(key string, val reflect.Type) {
+	if val == nil {
+		e.record.AddAttrs(slog.String(key, "nil"))
+		return
+	}
+	e.record.AddAttrs(slog.String(key, val.String()))
+}
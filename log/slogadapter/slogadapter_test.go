@@ -0,0 +1,110 @@
+//go:build go1.21
+
+package slogadapter
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureHandler records the records it handles
+type captureHandler struct {
+	level   slog.Level
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *captureHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r.Clone())
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *captureHandler) WithGroup(string) slog.Handler { return h }
+
+func (h *captureHandler) find(message string) (slog.Record, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, r := range h.records {
+		if r.Message == message {
+			return r, true
+		}
+	}
+	return slog.Record{}, false
+}
+
+func attrs(r slog.Record) map[string]slog.Value {
+	values := make(map[string]slog.Value)
+	r.Attrs(func(a slog.Attr) bool {
+		values[a.Key] = a.Value
+		return true
+	})
+	return values
+}
+
+func TestAdapter_SupervisorRestart(t *testing.T) {
+	handler := &captureHandler{level: slog.LevelDebug}
+	log.SetAdapter(New(slog.New(handler)))
+	defer log.SetAdapter(nil)
+
+	system := actor.NewActorSystem()
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			panic("failed")
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+	system.Root.Send(pid, "fail")
+
+	var record slog.Record
+	require.Eventually(t, func() bool {
+		var ok bool
+		record, ok = handler.find("[SUPERVISION]")
+		return ok
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, slog.LevelDebug, record.Level)
+	values := attrs(record)
+	assert.Equal(t, "actor", values[SubsystemKey].String())
+	assert.Equal(t, pid.String(), values["actor"].String())
+	assert.Equal(t, "RestartDirective", values["directive"].String())
+	assert.Equal(t, "failed", values["reason"].Any())
+}
+
+func TestAdapter_Levels(t *testing.T) {
+	handler := &captureHandler{level: slog.LevelInfo}
+	adapter := New(slog.New(handler))
+	assert.False(t, adapter.Enabled(log.DebugLevel))
+	assert.True(t, adapter.Enabled(log.InfoLevel))
+	assert.True(t, adapter.Enabled(log.ErrorLevel))
+
+	log.SetAdapter(adapter)
+	defer log.SetAdapter(nil)
+	l := log.New(log.DebugLevel, "[REMOTE]")
+	l.Debug("filtered")
+	l.Error("failed", log.Duration("after", time.Second), log.TypeOf("type", 1), log.Bool("retry", true))
+
+	record, ok := handler.find("failed")
+	require.True(t, ok)
+	assert.Equal(t, slog.LevelError, record.Level)
+	values := attrs(record)
+	assert.Equal(t, time.Second, values["after"].Duration())
+	assert.Equal(t, "int", values["type"].String())
+	assert.True(t, values["retry"].Bool())
+	_, ok = handler.find("filtered")
+	assert.False(t, ok)
+}
@@ -16,15 +16,18 @@ type ioLogger struct {
 }
 
 var (
-	sub *Subscription
+	sub           *Subscription
+	defaultLogger *ioLogger
 )
 
 func init() {
-	l := &ioLogger{c: make(chan Event, 100), out: os.Stderr}
-	sub = Subscribe(func(evt Event) {
-		l.c <- evt
-	})
-	go l.listenEvent()
+	defaultLogger = &ioLogger{c: make(chan Event, 100), out: os.Stderr}
+	sub = Subscribe(defaultLogger.publish)
+	go defaultLogger.listenEvent()
+}
+
+func (l *ioLogger) publish(evt Event) {
+	l.c <- evt
 }
 
 func (l *ioLogger) listenEvent() {
@@ -0,0 +1,99 @@
+// Package zapadapter writes the protoactor logs with zap
+package zapadapter
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SubsystemKey is the key of the field holding the subsystem which logged, e.g. actor or remote
+const SubsystemKey = "subsystem"
+
+type adapter struct {
+	logger *zap.Logger
+}
+
+// New returns the adapter writing the logs with logger, it is given to log.SetAdapter
+func New(logger *zap.Logger) log.Adapter {
+	return &adapter{logger: logger}
+}
+
+// Level maps the level of a protoactor log to the zap one
+func Level(level log.Level) zapcore.Level {
+	switch level {
+	case log.MinLevel, log.DebugLevel:
+		return zapcore.DebugLevel
+	case log.InfoLevel:
+		return zapcore.InfoLevel
+	}
+	return zapcore.ErrorLevel
+}
+
+func (a *adapter) Enabled(level log.Level) bool {
+	return a.logger.Core().Enabled(Level(level))
+}
+
+func (a *adapter) Log(evt log.Event) {
+	entry := a.logger.Check(Level(evt.Level), evt.Message)
+	if entry == nil {
+		return
+	}
+	entry.Time = evt.Time
+	enc := &encoder{fields: make([]zap.Field, 0, 1+len(evt.Context)+len(evt.Fields))}
+	enc.fields = append(enc.fields, zap.String(SubsystemKey, evt.Subsystem()))
+	evt.EncodeFields(enc)
+	entry.Write(enc.fields...)
+}
+
+// encoder converts the fields of an event to zap fields
+type encoder struct {
+	fields []zap.Field
+}
+
+func (e *encoder) EncodeBool(key string, val bool) {
+	e.fields = append(e.fields, zap.Bool(key, val))
+}
+
+func (e *encoder) EncodeFloat64(key string, val float64) {
+	e.fields = append(e.fields, zap.Float64(key, val))
+}
+
+func (e *encoder) EncodeInt(key string, val int) {
+	e.fields = append(e.fields, zap.Int(key, val))
+}
+
+func (e *encoder) EncodeInt64(key string, val int64) {
+	e.fields = append(e.fields, zap.Int64(key, val))
+}
+
+func (e *encoder) EncodeDuration(key string, val time.Duration) {
+	e.fields = append(e.fields, zap.Duration(key, val))
+}
+
+func (e *encoder) EncodeUint(key string, val uint) {
+	e.fields = append(e.fields, zap.Uint(key, val))
+}
+
+func (e *encoder) EncodeUint64(key string, val uint64) {
+	e.fields = append(e.fields, zap.Uint64(key, val))
+}
+
+func (e *encoder) EncodeString(key string, val string) {
+	e.fields = append(e.fields, zap.String(key, val))
+}
+
+func (e *encoder) EncodeObject(key string, val interface{}) {
+	e.fields = append(e.fields, zap.Any(key, val))
+}
+
+func (e *encoder) EncodeType(key string, val reflect.Type) {
+	if val == nil {
+		e.fields = append(e.fields, zap.String(key, "nil"))
+		return
+	}
+	e.fields = append(e.fields, zap.String(key, val.String()))
+}
@@ -0,0 +1,37 @@
+package zapadapter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAdapter(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	adapter := New(zap.New(core))
+	assert.False(t, adapter.Enabled(log.DebugLevel))
+	assert.True(t, adapter.Enabled(log.ErrorLevel))
+
+	log.SetAdapter(adapter)
+	defer log.SetAdapter(nil)
+	l := log.New(log.DebugLevel, "[CLUSTER]", log.String("member", "a"))
+	l.Debug("filtered")
+	l.Error("failed", log.Int("attempt", 2), log.Error(errors.New("boom")))
+
+	entries := logs.AllUntimed()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+		assert.Equal(t, "failed", entries[0].Message)
+		assert.Equal(t, map[string]interface{}{
+			SubsystemKey: "cluster",
+			"member":     "a",
+			"attempt":    int64(2),
+			"error":      "boom",
+		}, entries[0].ContextMap())
+	}
+}
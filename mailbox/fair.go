@@ -0,0 +1,168 @@
+package mailbox
+
+import (
+	"sync"
+
+	"github.com/AsynkronIT/protoactor-go/internal/queue/mpsc"
+)
+
+// OverflowHandler is implemented by the invokers handling the user messages a bounded mailbox rejects,
+// they are dropped otherwise
+type OverflowHandler interface {
+	HandleOverflow(message interface{})
+}
+
+// offeringQueue is a queue rejecting the messages it has no room for
+type offeringQueue interface {
+	queue
+	// Offer pushes m, it returns false when m is rejected
+	Offer(m interface{}) bool
+}
+
+// fairQueue queues the messages by key, and pops them round robin across the keys. A BarrierMessage pops once
+// the messages before it popped, the messages after it are queued apart until then
+type fairQueue struct {
+	classify func(message interface{}) string
+	capacity int
+
+	mu sync.Mutex
+	keyQueues
+	// the pending barriers, the first one pops once keyQueues is empty
+	barriers []*fairBarrier
+}
+
+// keyQueues holds the messages between two barriers
+type keyQueues struct {
+	queues map[string]*keyQueue
+	// the keys with queued messages, the next one to pop first
+	ready []*keyQueue
+}
+
+// fairBarrier holds a barrier and the messages posted after it, up to the next one
+type fairBarrier struct {
+	message interface{}
+	after   keyQueues
+}
+
+type keyQueue struct {
+	key      string
+	messages []interface{}
+	head     int
+}
+
+func (q *keyQueue) len() int {
+	return len(q.messages) - q.head
+}
+
+func (q *keyQueue) pop() interface{} {
+	m := q.messages[q.head]
+	q.messages[q.head] = nil
+	q.head++
+	// reclaims the popped part once it is the larger one
+	if q.head > len(q.messages)/2 {
+		q.messages = append(q.messages[:0], q.messages[q.head:]...)
+		q.head = 0
+	}
+	return m
+}
+
+func newFairQueue(classify func(message interface{}) string, capacity int) *fairQueue {
+	return &fairQueue{
+		classify:  classify,
+		capacity:  capacity,
+		keyQueues: keyQueues{queues: make(map[string]*keyQueue)},
+	}
+}
+
+// len returns the number of messages of key
+func (q *keyQueues) len(key string) int {
+	if kq, ok := q.queues[key]; ok {
+		return kq.len()
+	}
+	return 0
+}
+
+func (q *keyQueues) push(key string, m interface{}) {
+	kq, ok := q.queues[key]
+	if !ok {
+		kq = &keyQueue{key: key}
+		q.queues[key] = kq
+		q.ready = append(q.ready, kq)
+	}
+	kq.messages = append(kq.messages, m)
+}
+
+func (q *keyQueues) pop() interface{} {
+	if len(q.ready) == 0 {
+		return nil
+	}
+
+	kq := q.ready[0]
+	q.ready[0] = nil
+	q.ready = q.ready[1:]
+	m := kq.pop()
+	if kq.len() > 0 {
+		q.ready = append(q.ready, kq)
+	} else {
+		delete(q.queues, kq.key)
+	}
+	return m
+}
+
+func (q *fairQueue) Push(m interface{}) {
+	q.Offer(m)
+}
+
+func (q *fairQueue) Offer(m interface{}) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := m.(BarrierMessage); ok {
+		q.barriers = append(q.barriers, &fairBarrier{message: m, after: keyQueues{queues: make(map[string]*keyQueue)}})
+		return true
+	}
+
+	key := q.classify(m)
+	last := &q.keyQueues
+	if n := len(q.barriers); n > 0 {
+		last = &q.barriers[n-1].after
+	}
+	if q.capacity > 0 {
+		queued := q.keyQueues.len(key)
+		for _, barrier := range q.barriers {
+			queued += barrier.after.len(key)
+		}
+		if queued >= q.capacity {
+			return false
+		}
+	}
+	last.push(key, m)
+	return true
+}
+
+func (q *fairQueue) Pop() interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if m := q.keyQueues.pop(); m != nil || len(q.barriers) == 0 {
+		return m
+	}
+
+	barrier := q.barriers[0]
+	q.barriers[0] = nil
+	q.barriers = q.barriers[1:]
+	q.keyQueues = barrier.after
+	return barrier.message
+}
+
+// Fair returns a producer of mailboxes which are fair to the keys of their messages, as classify returns them:
+// the messages are queued by key, and received round robin across the keys, so that the messages of a key are not
+// delayed by the many messages of another. A mailbox holds at most capacity messages of a key, without limit when
+// capacity is not positive. The messages over it are rejected to the OverflowHandler of the actor
+func Fair(classify func(message interface{}) string, capacity int, mailboxStats ...Statistics) Producer {
+	return func() Mailbox {
+		return &defaultMailbox{
+			systemMailbox: mpsc.New(),
+			userMailbox:   newFairQueue(classify, capacity),
+			mailboxStats:  mailboxStats,
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package mailbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// byPrefix keys the string messages by their first letter
+func byPrefix(message interface{}) string {
+	return message.(string)[:1]
+}
+
+func TestFairQueue_RoundRobin(t *testing.T) {
+	q := newFairQueue(byPrefix, 0)
+	for _, m := range []string{"a1", "a2", "a3", "b1", "c1", "b2"} {
+		q.Push(m)
+	}
+
+	var popped []interface{}
+	for m := q.Pop(); m != nil; m = q.Pop() {
+		popped = append(popped, m)
+	}
+	assert.Equal(t, []interface{}{"a1", "b1", "c1", "a2", "b2", "a3"}, popped)
+	assert.Empty(t, q.queues, "the keys without messages are forgotten")
+}
+
+type overflowInvoker struct {
+	invoker
+	overflows []interface{}
+}
+
+func (i *overflowInvoker) HandleOverflow(message interface{}) {
+	i.overflows = append(i.overflows, message)
+}
+
+func TestFairMailbox_OverflowPerKey(t *testing.T) {
+	m := Fair(byPrefix, 2)()
+	inv := &overflowInvoker{}
+	m.RegisterHandlers(inv, NewSynchronizedDispatcher(1))
+	m.PostSystemMessage(&SuspendMailbox{})
+
+	for _, message := range []string{"a1", "a2", "a3", "b1"} {
+		m.PostUserMessage(message)
+	}
+	assert.Equal(t, []interface{}{"a3"}, inv.overflows, "only the key over capacity overflows")
+	assert.Equal(t, 3, m.(*defaultMailbox).UserMessageCount())
+}
+
+type barrier string
+
+func (barrier) BarrierMessage() {}
+
+func TestFairQueue_Barrier(t *testing.T) {
+	q := newFairQueue(byPrefix, 3)
+	for _, m := range []interface{}{"a1", "a2", "b1", barrier("x"), "b2", "a3", barrier("y")} {
+		assert.True(t, q.Offer(m))
+	}
+	assert.False(t, q.Offer("a4"), "the messages of a key after a barrier count to its capacity")
+
+	var popped []interface{}
+	for m := q.Pop(); m != nil; m = q.Pop() {
+		popped = append(popped, m)
+	}
+	assert.Equal(t, []interface{}{"a1", "b1", "a2", barrier("x"), "b2", "a3", barrier("y")}, popped)
+}
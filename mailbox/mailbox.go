@@ -54,7 +54,16 @@ func (m *defaultMailbox) PostUserMessage(message interface{}) {
 	for _, ms := range m.mailboxStats {
 		ms.MessagePosted(message)
 	}
-	m.userMailbox.Push(message)
+	if q, ok := m.userMailbox.(offeringQueue); ok {
+		if !q.Offer(message) {
+			if handler, ok := m.invoker.(OverflowHandler); ok {
+				handler.HandleOverflow(message)
+			}
+			return
+		}
+	} else {
+		m.userMailbox.Push(message)
+	}
 	atomic.AddInt32(&m.userMessages, 1)
 	m.schedule()
 }
@@ -68,6 +77,11 @@ func (m *defaultMailbox) PostSystemMessage(message interface{}) {
 	m.schedule()
 }
 
+// UserMessageCount returns the number of user messages waiting in the mailbox
+func (m *defaultMailbox) UserMessageCount() int {
+	return int(atomic.LoadInt32(&m.userMessages))
+}
+
 func (m *defaultMailbox) RegisterHandlers(invoker MessageInvoker, dispatcher Dispatcher) {
 	m.invoker = invoker
 	m.dispatcher = dispatcher
@@ -9,3 +9,9 @@ type ResumeMailbox struct{}
 //
 // This will not be forwarded to the Receive method
 type SuspendMailbox struct{}
+
+// BarrierMessage is implemented by the user messages a mailbox delivers after all the user messages posted before
+// them, whatever the order it delivers its other messages in
+type BarrierMessage interface {
+	BarrierMessage()
+}
@@ -18,6 +18,8 @@ package mailbox
 // GetPriority method return a larger int8 value.
 // Likewise if you'd like to de-prioritize your message, have its GetPriority method
 // return an int8 less than 4.
+//
+// A BarrierMessage is queued at the lowest priority level, after all the messages already queued.
 
 const priorityLevels = 8
 const DefaultPriority = int8(priorityLevels / 2)
@@ -45,7 +47,10 @@ func NewPriorityQueue(queueProducer func() queue) *priorityQueue {
 func (q *priorityQueue) Push(item interface{}) {
 	itemPriority := DefaultPriority
 
-	if priorityItem, ok := item.(PriorityMessage); ok {
+	if _, ok := item.(BarrierMessage); ok {
+		// after all the messages already queued
+		itemPriority = 0
+	} else if priorityItem, ok := item.(PriorityMessage); ok {
 		itemPriority = priorityItem.GetPriority()
 		if itemPriority < 0 {
 			itemPriority = 0
@@ -0,0 +1,47 @@
+package metrics
+
+import "sync"
+
+// otherLabel replaces the label values which are not allowed or exceed the bound
+const otherLabel = "other"
+
+// labelValues bounds the cardinality of a label
+type labelValues struct {
+	mu      sync.RWMutex
+	allowed map[string]bool
+	max     int
+	seen    map[string]bool
+}
+
+func newLabelValues(allowed map[string]bool, max int) *labelValues {
+	return &labelValues{
+		allowed: allowed,
+		max:     max,
+		seen:    make(map[string]bool),
+	}
+}
+
+// value returns value when it is allowed and within the bound, otherLabel else
+func (l *labelValues) value(value string) string {
+	if l.allowed != nil && !l.allowed[value] {
+		return otherLabel
+	}
+
+	l.mu.RLock()
+	seen := l.seen[value]
+	l.mu.RUnlock()
+	if seen {
+		return value
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.seen[value] {
+		return value
+	}
+	if l.max > 0 && len(l.seen) >= l.max {
+		return otherLabel
+	}
+	l.seen[value] = true
+	return value
+}
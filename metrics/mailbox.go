@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/AsynkronIT/protoactor-go/mailbox"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// mailboxLength counts the messages posted to and received from the mailboxes of an actor type
+type mailboxLength struct {
+	posted   int64
+	received int64
+}
+
+func (m *mailboxLength) MailboxStarted() {}
+
+func (m *mailboxLength) MessagePosted(message interface{}) {
+	atomic.AddInt64(&m.posted, 1)
+}
+
+func (m *mailboxLength) MessageReceived(message interface{}) {
+	atomic.AddInt64(&m.received, 1)
+}
+
+func (m *mailboxLength) MailboxEmpty() {}
+
+func (m *mailboxLength) length() int64 {
+	// received is loaded first, so that a message received in between is not counted as negative length
+	received := atomic.LoadInt64(&m.received)
+	return atomic.LoadInt64(&m.posted) - received
+}
+
+// mailboxCollector samples the length of the mailboxes when the metrics are collected
+type mailboxCollector struct {
+	desc    *prometheus.Desc
+	mu      sync.Mutex
+	lengths map[string]*mailboxLength
+}
+
+func newMailboxCollector(namespace string) *mailboxCollector {
+	return &mailboxCollector{
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "mailbox", "length"),
+			"Number of messages waiting in the mailboxes of the actors or being received", []string{"actor_type"}, nil),
+		lengths: make(map[string]*mailboxLength),
+	}
+}
+
+func (c *mailboxCollector) statistics(actorType string) mailbox.Statistics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	length, ok := c.lengths[actorType]
+	if !ok {
+		length = &mailboxLength{}
+		c.lengths[actorType] = length
+	}
+	return length
+}
+
+func (c *mailboxCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *mailboxCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for actorType, length := range c.lengths {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(length.length()), actorType)
+	}
+}
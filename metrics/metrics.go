@@ -0,0 +1,204 @@
+// Package metrics exports the metrics of an actor system to Prometheus
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/middleware/propagator"
+	"github.com/AsynkronIT/protoactor-go/eventstream"
+	"github.com/AsynkronIT/protoactor-go/mailbox"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics are the metrics of an actor system
+type Metrics struct {
+	system   *actor.ActorSystem
+	registry prometheus.Registerer
+
+	actorTypes   *labelValues
+	messageTypes *labelValues
+	addresses    *labelValues
+
+	spawned             *prometheus.CounterVec
+	stopped             *prometheus.CounterVec
+	restarted           *prometheus.CounterVec
+	receiveDuration     *prometheus.HistogramVec
+	mailboxes           *mailboxCollector
+	futureTimeouts      prometheus.Counter
+	deadLetters         *prometheus.CounterVec
+	remoteSent          *prometheus.CounterVec
+	remoteSentBytes     *prometheus.CounterVec
+	remoteReceived      prometheus.Counter
+	remoteReceivedBytes prometheus.Counter
+
+	restarting    sync.Map
+	subscriptions []*eventstream.Subscription
+}
+
+// Install registers the metrics of the actor system of root to registry. The actors spawned from root are measured,
+// root is configured with the spawn middleware of the metrics which is propagated to their children.
+// The dead letters, the future timeouts and the remote endpoints are measured from the event streams.
+func Install(registry prometheus.Registerer, root *actor.RootContext, opts ...Option) (*Metrics, error) {
+	cfg := newConfig(opts)
+	m := &Metrics{
+		system:       root.ActorSystem(),
+		registry:     registry,
+		actorTypes:   newLabelValues(cfg.actorTypes, cfg.maxLabelValues),
+		messageTypes: newLabelValues(nil, cfg.maxLabelValues),
+		addresses:    newLabelValues(nil, cfg.maxLabelValues),
+		spawned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Subsystem: "actor", Name: "spawned_total",
+			Help: "Number of actors spawned",
+		}, []string{"actor_type"}),
+		stopped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Subsystem: "actor", Name: "stopped_total",
+			Help: "Number of actors stopped",
+		}, []string{"actor_type"}),
+		restarted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Subsystem: "actor", Name: "restarted_total",
+			Help: "Number of actors restarted",
+		}, []string{"actor_type"}),
+		receiveDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace, Subsystem: "message", Name: "receive_duration_seconds",
+			Help:    "Time the actors take to receive the messages",
+			Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10),
+		}, []string{"message_type"}),
+		mailboxes: newMailboxCollector(cfg.namespace),
+		futureTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Subsystem: "future", Name: "timeouts_total",
+			Help: "Number of futures timed out",
+		}),
+		deadLetters: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Name: "deadletters_total",
+			Help: "Number of messages sent to dead letters",
+		}, []string{"reason"}),
+		remoteSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Subsystem: "remote", Name: "sent_messages_total",
+			Help: "Number of messages sent to the remote endpoints",
+		}, []string{"address"}),
+		remoteSentBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Subsystem: "remote", Name: "sent_bytes_total",
+			Help: "Size of the messages sent to the remote endpoints",
+		}, []string{"address"}),
+		remoteReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Subsystem: "remote", Name: "received_messages_total",
+			Help: "Number of messages received from the remote endpoints",
+		}),
+		remoteReceivedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Subsystem: "remote", Name: "received_bytes_total",
+			Help: "Size of the messages received from the remote endpoints",
+		}),
+	}
+
+	collectors := m.collectors()
+	for i, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			for _, registered := range collectors[:i] {
+				registry.Unregister(registered)
+			}
+			return nil, err
+		}
+	}
+
+	root.WithSpawnMiddleware(m.SpawnMiddleware())
+	m.subscriptions = []*eventstream.Subscription{
+		m.system.SystemEventStream.Subscribe(m.onSystemEvent),
+		m.system.EventStream.Subscribe(m.onEvent),
+	}
+	return m, nil
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.spawned, m.stopped, m.restarted, m.receiveDuration, m.mailboxes, m.futureTimeouts, m.deadLetters,
+		m.remoteSent, m.remoteSentBytes, m.remoteReceived, m.remoteReceivedBytes,
+	}
+}
+
+// Close unsubscribes from the event streams and unregisters the metrics, the actors already spawned
+// keep their middleware but are no longer exported
+func (m *Metrics) Close() {
+	m.system.SystemEventStream.Unsubscribe(m.subscriptions[0])
+	m.system.EventStream.Unsubscribe(m.subscriptions[1])
+	for _, collector := range m.collectors() {
+		m.registry.Unregister(collector)
+	}
+}
+
+// SpawnMiddleware measures the actors spawned and their children
+func (m *Metrics) SpawnMiddleware() actor.SpawnMiddleware {
+	return propagator.New().
+		WithItselfForwarded().
+		WithReceiverMiddleware(m.ReceiverMiddleware()).
+		SpawnMiddleware
+}
+
+// ReceiverMiddleware counts the lifecycle of the actors and measures the time they take to receive the messages
+func (m *Metrics) ReceiverMiddleware() actor.ReceiverMiddleware {
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch envelope.Message.(type) {
+			case *actor.Started:
+				next(c, envelope)
+				if _, restarting := m.restarting.Load(c.Self().String()); restarting {
+					m.restarting.Delete(c.Self().String())
+					m.restarted.WithLabelValues(m.actorType(c)).Inc()
+				} else {
+					m.spawned.WithLabelValues(m.actorType(c)).Inc()
+				}
+				return
+			case *actor.Restarting:
+				m.restarting.Store(c.Self().String(), struct{}{})
+			case *actor.Stopped:
+				m.restarting.Delete(c.Self().String())
+				m.stopped.WithLabelValues(m.actorType(c)).Inc()
+			}
+
+			switch envelope.Message.(type) {
+			case actor.SystemMessage, actor.AutoReceiveMessage:
+				next(c, envelope)
+				return
+			}
+
+			start := time.Now()
+			next(c, envelope)
+			m.receiveDuration.WithLabelValues(m.messageTypes.value(fmt.Sprintf("%T", envelope.Message))).
+				Observe(time.Since(start).Seconds())
+		}
+	}
+}
+
+// MailboxStatistics returns the statistics sampling the length of the mailboxes of the actors of type actorType,
+// it is given to the mailbox of their props, e.g. mailbox.Unbounded(m.MailboxStatistics("*main.worker"))
+func (m *Metrics) MailboxStatistics(actorType string) mailbox.Statistics {
+	return m.mailboxes.statistics(m.actorTypes.value(actorType))
+}
+
+func (m *Metrics) actorType(c actor.ReceiverContext) string {
+	return m.actorTypes.value(fmt.Sprintf("%T", c.Actor()))
+}
+
+func (m *Metrics) onSystemEvent(evt interface{}) {
+	switch evt := evt.(type) {
+	case *actor.DeadLetterEvent:
+		m.deadLetters.WithLabelValues(evt.Reason.String()).Inc()
+	case *actor.FutureTimeoutEvent:
+		m.futureTimeouts.Inc()
+	}
+}
+
+func (m *Metrics) onEvent(evt interface{}) {
+	switch evt := evt.(type) {
+	case *remote.EndpointBatchSentEvent:
+		address := m.addresses.value(evt.Address)
+		m.remoteSent.WithLabelValues(address).Add(float64(evt.Messages))
+		m.remoteSentBytes.WithLabelValues(address).Add(float64(evt.Bytes))
+	case *remote.EndpointBatchReceivedEvent:
+		m.remoteReceived.Add(float64(evt.Messages))
+		m.remoteReceivedBytes.Add(float64(evt.Bytes))
+	}
+}
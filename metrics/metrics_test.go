@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/mailbox"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type worker struct{}
+
+func (*worker) Receive(ctx actor.Context) {
+	switch ctx.Message().(type) {
+	case string:
+		if ctx.Sender() != nil {
+			ctx.Respond("done")
+		}
+	case int:
+		panic("failed")
+	}
+}
+
+type other struct{}
+
+func (*other) Receive(actor.Context) {}
+
+func install(t *testing.T, opts ...Option) (*prometheus.Registry, *actor.ActorSystem, *Metrics) {
+	registry := prometheus.NewRegistry()
+	system := actor.NewActorSystem()
+	m, err := Install(registry, system.Root, opts...)
+	require.NoError(t, err)
+	return registry, system, m
+}
+
+// scrape returns the metrics served by the registry
+func scrape(t *testing.T, registry *prometheus.Registry) string {
+	server := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	defer server.Close()
+	res, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func assertScraped(t *testing.T, registry *prometheus.Registry, lines ...string) {
+	require.Eventually(t, func() bool {
+		body := scrape(t, registry)
+		for _, line := range lines {
+			if !strings.Contains(body, line+"\n") {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond, "missing %v in\n%s", lines, scrape(t, registry))
+}
+
+func TestMetrics_Actors(t *testing.T) {
+	registry, system, _ := install(t)
+	pid := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return &worker{} }))
+
+	_, err := system.Root.RequestFuture(pid, "work", 5*time.Second).Result()
+	require.NoError(t, err)
+	system.Root.Send(pid, 1)
+	_, err = system.Root.RequestFuture(pid, "work", 5*time.Second).Result()
+	require.NoError(t, err)
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+
+	assertScraped(t, registry,
+		`protoactor_actor_spawned_total{actor_type="*metrics.worker"} 1`,
+		`protoactor_actor_restarted_total{actor_type="*metrics.worker"} 1`,
+		`protoactor_actor_stopped_total{actor_type="*metrics.worker"} 1`,
+		`protoactor_message_receive_duration_seconds_count{message_type="string"} 2`,
+	)
+}
+
+func TestMetrics_DeadLettersAndFutureTimeouts(t *testing.T) {
+	registry, system, _ := install(t)
+	pid := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return &other{} }))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+	stopped := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return &other{} }))
+	require.NoError(t, system.Root.StopFuture(stopped).Wait())
+
+	system.Root.Send(stopped, "lost")
+	expiring := &actor.MessageEnvelope{Message: "expired"}
+	expiring.SetTTL(-time.Second)
+	system.Root.Send(pid, expiring)
+	_, err := system.Root.RequestFuture(pid, "unanswered", time.Millisecond).Result()
+	require.Equal(t, actor.ErrTimeout, err)
+
+	assertScraped(t, registry,
+		`protoactor_deadletters_total{reason="Undeliverable"} 1`,
+		`protoactor_deadletters_total{reason="Expired"} 1`,
+		`protoactor_future_timeouts_total 1`,
+	)
+}
+
+func TestMetrics_MailboxLength(t *testing.T) {
+	registry, system, m := install(t)
+	release := make(chan struct{})
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			<-release
+		}
+	}).WithMailbox(mailbox.Unbounded(m.MailboxStatistics("blocked")))
+	pid := system.Root.Spawn(props)
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	for i := 0; i < 3; i++ {
+		system.Root.Send(pid, "wait")
+	}
+	// the first message is being received, the other ones wait in the mailbox
+	assertScraped(t, registry, `protoactor_mailbox_length{actor_type="blocked"} 3`)
+	close(release)
+	assertScraped(t, registry, `protoactor_mailbox_length{actor_type="blocked"} 0`)
+}
+
+func TestMetrics_Cardinality(t *testing.T) {
+	registry, system, _ := install(t, WithActorTypes("*metrics.worker"), WithMaxLabelValues(1))
+	props := func(producer actor.Producer) *actor.Props { return actor.PropsFromProducer(producer) }
+	w := system.Root.Spawn(props(func() actor.Actor { return &worker{} }))
+	o := system.Root.Spawn(props(func() actor.Actor { return &other{} }))
+	system.Root.Send(w, "first")
+	system.Root.Send(w, struct{}{})
+	require.NoError(t, system.Root.PoisonFuture(w).Wait())
+	require.NoError(t, system.Root.StopFuture(o).Wait())
+
+	assertScraped(t, registry,
+		`protoactor_actor_spawned_total{actor_type="*metrics.worker"} 1`,
+		`protoactor_actor_spawned_total{actor_type="other"} 1`,
+		`protoactor_message_receive_duration_seconds_count{message_type="string"} 1`,
+		`protoactor_message_receive_duration_seconds_count{message_type="other"} 1`,
+	)
+}
+
+func TestMetrics_Remote(t *testing.T) {
+	serverSystem := actor.NewActorSystem()
+	server := remote.NewRemote(serverSystem, remote.Configure("localhost", 0))
+	server.Start()
+	defer server.Shutdown(false)
+	serverRegistry := prometheus.NewRegistry()
+	_, err := Install(serverRegistry, serverSystem.Root)
+	require.NoError(t, err)
+	received := make(chan struct{}, 1)
+	target, err := serverSystem.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.PID); ok {
+			received <- struct{}{}
+		}
+	}), "target")
+	require.NoError(t, err)
+
+	registry, system, _ := install(t)
+	client := remote.NewRemote(system, remote.Configure("localhost", 0))
+	client.Start()
+	defer client.Shutdown(false)
+
+	system.Root.Send(target, &actor.PID{Id: "payload"})
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out")
+	}
+
+	assertScraped(t, registry, `protoactor_remote_sent_messages_total{address="`+target.Address+`"} 1`)
+	assertScraped(t, serverRegistry, `protoactor_remote_received_messages_total 1`)
+	assert.NotContains(t, scrape(t, registry), `protoactor_remote_sent_bytes_total{address="`+target.Address+`"} 0`)
+}
+
+func TestInstall_Twice(t *testing.T) {
+	registry, system, m := install(t)
+	_, err := Install(registry, system.Root)
+	assert.Error(t, err)
+
+	m.Close()
+	_, err = Install(registry, system.Root)
+	assert.NoError(t, err)
+}
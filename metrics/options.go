@@ -0,0 +1,52 @@
+package metrics
+
+const (
+	defaultNamespace      = "protoactor"
+	defaultMaxLabelValues = 100
+)
+
+type config struct {
+	namespace      string
+	actorTypes     map[string]bool
+	maxLabelValues int
+}
+
+// Option configures the metrics
+type Option func(*config)
+
+// WithNamespace prefixes the metric names with namespace rather than protoactor
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithActorTypes labels the metrics of the actors of the given types only, as %T formats them,
+// the other actors are labeled "other"
+func WithActorTypes(actorTypes ...string) Option {
+	return func(c *config) {
+		c.actorTypes = make(map[string]bool, len(actorTypes))
+		for _, actorType := range actorTypes {
+			c.actorTypes[actorType] = true
+		}
+	}
+}
+
+// WithMaxLabelValues bounds the values of each label, the values seen once the bound is reached are labeled "other".
+// It is 100 by default
+func WithMaxLabelValues(max int) Option {
+	return func(c *config) {
+		c.maxLabelValues = max
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		namespace:      defaultNamespace,
+		maxLabelValues: defaultMaxLabelValues,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
@@ -0,0 +1,102 @@
+package persistence
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrPayloadTampered is returned when decoding a payload which was modified, or encrypted with another key
+var ErrPayloadTampered = errors.New("persistence: payload was tampered with")
+
+// KeyProvider provides the AES keys of an AES-GCM codec, identified by ids persisted along with the ciphertexts
+type KeyProvider interface {
+	// CurrentKey returns the key new payloads are encrypted with, and its id
+	CurrentKey() (id string, key []byte)
+	// Key returns the key with id, so that the payloads encrypted before a rotation are decrypted
+	Key(id string) (key []byte, ok bool)
+}
+
+// KeyRing is a KeyProvider holding its keys in memory
+type KeyRing struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewKeyRing returns a KeyRing encrypting with the key currentID of keys
+func NewKeyRing(currentID string, keys map[string][]byte) *KeyRing {
+	return &KeyRing{current: currentID, keys: keys}
+}
+
+func (r *KeyRing) CurrentKey() (string, []byte) {
+	return r.current, r.keys[r.current]
+}
+
+func (r *KeyRing) Key(id string) ([]byte, bool) {
+	key, ok := r.keys[id]
+	return key, ok
+}
+
+type aesGCMCodec struct {
+	keys KeyProvider
+}
+
+// NewAESGCMCodec encrypts payloads with AES-GCM, with the keys of 16, 24 or 32 bytes provided by keys.
+// The encoded payloads are the length and id of the key, the nonce and the ciphertext.
+func NewAESGCMCodec(keys KeyProvider) PayloadCodec {
+	return &aesGCMCodec{keys: keys}
+}
+
+func (c *aesGCMCodec) Encode(data []byte) []byte {
+	id, key := c.keys.CurrentKey()
+	if len(id) > 255 {
+		panic(fmt.Errorf("persistence: key id %v is longer than 255 bytes", id))
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		panic(err)
+	}
+
+	header := append([]byte{byte(len(id))}, id...)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		panic(err)
+	}
+	// the key id is authenticated, so that it is not swapped
+	return aead.Seal(append(header, nonce...), nonce, data, header)
+}
+
+func (c *aesGCMCodec) Decode(data []byte) ([]byte, error) {
+	if len(data) < 1 || len(data) < 1+int(data[0]) {
+		return nil, ErrPayloadTampered
+	}
+	header, data := data[:1+int(data[0])], data[1+int(data[0]):]
+	id := string(header[1:])
+	key, ok := c.keys.Key(id)
+	if !ok {
+		return nil, fmt.Errorf("persistence: unknown key %v", id)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, ErrPayloadTampered
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], header)
+	if err != nil {
+		return nil, ErrPayloadTampered
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
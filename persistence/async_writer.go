@@ -0,0 +1,203 @@
+package persistence
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// persisted is sent by the writer of an actor to the actor once the writes up to seq are durable
+type persisted struct {
+	writer *asyncWriter
+	seq    int
+}
+
+// persistFailed is sent by the writer of an actor to the actor when a write failed, the actor is restarted
+type persistFailed struct {
+	writer *asyncWriter
+	err    error
+}
+
+// write is an event or snapshot queued for the provider
+type write struct {
+	seq int
+	fn  func()
+}
+
+// deferred is a side effect waiting for the writes up to seq to be durable
+type deferred struct {
+	seq int
+	fn  func()
+}
+
+// asyncWriter writes the events and snapshots of an actor in order, off the actor goroutine
+type asyncWriter struct {
+	system *actor.ActorSystem
+	self   *actor.PID
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []write
+	seq     int // the seq of the last queued write
+	durable int // the seq of the last durable write
+	closed  bool
+	failed  bool
+	done    chan struct{}
+}
+
+func newAsyncWriter(system *actor.ActorSystem, self *actor.PID) *asyncWriter {
+	w := &asyncWriter{system: system, self: self, done: make(chan struct{})}
+	w.cond = sync.NewCond(&w.mu)
+	go w.run()
+	return w
+}
+
+// enqueue queues fn after the writes queued so far, and returns its seq
+func (w *asyncWriter) enqueue(fn func()) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.seq++
+	if !w.failed && !w.closed {
+		w.queue = append(w.queue, write{seq: w.seq, fn: fn})
+		w.cond.Signal()
+	}
+	return w.seq
+}
+
+// pending returns the seq of the last queued write, and whether it is not durable yet
+func (w *asyncWriter) pending() (int, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.seq, w.durable < w.seq
+}
+
+// close waits for the queued writes, it returns the seq of the last durable one
+func (w *asyncWriter) close() int {
+	w.mu.Lock()
+	w.closed = true
+	w.cond.Signal()
+	w.mu.Unlock()
+
+	<-w.done
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.durable
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	for {
+		w.mu.Lock()
+		for len(w.queue) == 0 && !w.closed && !w.failed {
+			w.cond.Wait()
+		}
+		if len(w.queue) == 0 || w.failed {
+			w.mu.Unlock()
+			return
+		}
+		batch := w.queue
+		w.queue = nil
+		w.mu.Unlock()
+
+		// the writes queued meanwhile are acknowledged together
+		for _, item := range batch {
+			if err := w.write(item); err != nil {
+				plog.Error("Persisting failed", log.Stringer("pid", w.self), log.Error(err))
+				w.mu.Lock()
+				w.failed = true
+				w.queue = nil
+				closed := w.closed
+				w.mu.Unlock()
+				if !closed {
+					w.system.Root.Send(w.self, &persistFailed{writer: w, err: err})
+				}
+				return
+			}
+		}
+		// the actor runs the side effects itself when it stops or restarts
+		w.mu.Lock()
+		closed := w.closed
+		w.mu.Unlock()
+		if !closed {
+			w.system.Root.Send(w.self, &persisted{writer: w, seq: batch[len(batch)-1].seq})
+		}
+	}
+}
+
+func (w *asyncWriter) write(item write) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			if err, ok = r.(error); !ok {
+				err = fmt.Errorf("persistence: write failed: %v", r)
+			}
+		}
+	}()
+	item.fn()
+
+	w.mu.Lock()
+	w.durable = item.seq
+	w.mu.Unlock()
+	return nil
+}
+
+// DeferUntilPersisted runs fn on the actor once the events and snapshots persisted so far are durable.
+// It runs fn right away with the synchronous persistence, or when they already are.
+// The side effects are dropped when a write fails, as the actor restarts from the events that are durable.
+func (mixin *Mixin) DeferUntilPersisted(fn func()) {
+	if mixin.writer != nil {
+		if seq, ok := mixin.writer.pending(); ok || len(mixin.deferred) > 0 {
+			mixin.deferred = append(mixin.deferred, deferred{seq: seq, fn: fn})
+			return
+		}
+	}
+	fn()
+}
+
+// persist writes the event or snapshot with fn, in the writer of the actor when the persistence is asynchronous
+func (mixin *Mixin) persist(fn func()) {
+	if mixin.writer == nil {
+		fn()
+		return
+	}
+	mixin.writer.enqueue(fn)
+}
+
+// persisted runs the side effects waiting for the writes up to seq
+func (mixin *Mixin) persisted(seq int) {
+	i := 0
+	for ; i < len(mixin.deferred) && mixin.deferred[i].seq <= seq; i++ {
+		mixin.deferred[i].fn()
+	}
+	mixin.deferred = mixin.deferred[i:]
+}
+
+// flush waits for the queued writes before the actor stops or restarts, the side effects of the failed ones are dropped
+func (mixin *Mixin) flush() {
+	if mixin.writer == nil {
+		return
+	}
+	mixin.persisted(mixin.writer.close())
+	mixin.deferred = nil
+	mixin.writer = nil
+}
+
+// handle handles the messages of the writer of the actor, it tells whether msg was one
+func (mixin *Mixin) handle(msg interface{}) bool {
+	switch msg := msg.(type) {
+	case *persisted:
+		if msg.writer == mixin.writer {
+			mixin.persisted(msg.seq)
+		}
+		return true
+	case *persistFailed:
+		if msg.writer == mixin.writer {
+			panic(msg.err)
+		}
+		return true
+	}
+	return false
+}
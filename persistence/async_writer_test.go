@@ -0,0 +1,199 @@
+package persistence
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowState takes delay to persist every event, and fails persisting the event at failAt once
+type slowState struct {
+	ProviderState
+	delay  time.Duration
+	failAt int
+
+	mu      sync.Mutex
+	durable map[int]bool
+}
+
+func newSlowState(delay time.Duration, failAt int) *slowState {
+	return &slowState{ProviderState: NewInMemoryProvider(100), delay: delay, failAt: failAt, durable: make(map[int]bool)}
+}
+
+func (s *slowState) GetState() ProviderState {
+	return s
+}
+
+func (s *slowState) PersistEvent(actorName string, eventIndex int, event proto.Message) {
+	time.Sleep(s.delay)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if eventIndex == s.failAt {
+		s.failAt = -1
+		panic(errors.New("write failed"))
+	}
+	s.ProviderState.PersistEvent(actorName, eventIndex, event)
+	s.durable[eventIndex] = true
+}
+
+func (s *slowState) isDurable(eventIndex int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.durable[eventIndex]
+}
+
+// sideEffect is run by the notifyingActor once the event at eventIndex is persisted
+type sideEffect struct {
+	eventIndex int
+	durable    bool
+}
+
+// notifyingActor notifies the side effects of the events it persists
+type notifyingActor struct {
+	Mixin
+	provider    *slowState
+	sideEffects chan sideEffect
+	restarts    chan struct{}
+	state       string
+}
+
+func (a *notifyingActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *Message:
+		if !a.Recovering() {
+			eventIndex := a.eventIndex
+			a.PersistReceive(msg)
+			a.DeferUntilPersisted(func() {
+				a.sideEffects <- sideEffect{eventIndex: eventIndex, durable: a.provider.isDurable(eventIndex)}
+			})
+		}
+		a.state = msg.state
+	case *Query:
+		ctx.Respond(a.state)
+	case *actor.Restarting:
+		if a.restarts != nil {
+			a.restarts <- struct{}{}
+		}
+	}
+}
+
+var notifyingSeq int32
+
+// spawnNotifying spawns a notifyingActor restarting when it fails, named uniquely after name
+func spawnNotifying(t *testing.T, provider *slowState, sideEffects chan sideEffect, restarts chan struct{}, name string, opts ...Option) *actor.PID {
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &notifyingActor{provider: provider, sideEffects: sideEffects, restarts: restarts}
+	}).WithReceiverMiddleware(Using(provider, opts...))
+	// other tests leave their guardian on system.Root
+	root := actor.NewRootContext(system, nil).WithGuardian(actor.DefaultSupervisorStrategy())
+	pid, err := root.SpawnNamed(props, name+"-"+strconv.Itoa(int(atomic.AddInt32(&notifyingSeq, 1))))
+	require.NoError(t, err)
+	return pid
+}
+
+func askState(t *testing.T, pid *actor.PID) string {
+	res, err := system.Root.RequestFuture(pid, &Query{}, time.Second).Result()
+	require.NoError(t, err)
+	return res.(string)
+}
+
+func TestAsyncPersistence_SideEffectsFollowDurability(t *testing.T) {
+	provider := newSlowState(time.Millisecond, -1)
+	sideEffects := make(chan sideEffect, 100)
+	pid := spawnNotifying(t, provider, sideEffects, nil, "async-side-effects", WithAsyncPersistence())
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	for i := 0; i < 20; i++ {
+		system.Root.Send(pid, newMessage(string(rune('a'+i))))
+	}
+	// the state is updated before the events are durable
+	assert.Equal(t, "t", askState(t, pid))
+
+	for i := 0; i < 20; i++ {
+		select {
+		case effect := <-sideEffects:
+			assert.Equal(t, i, effect.eventIndex)
+			assert.True(t, effect.durable, "side effect of event %d ran before it was durable", i)
+		case <-time.After(time.Second):
+			t.Fatalf("side effect of event %d never ran", i)
+		}
+	}
+}
+
+func TestAsyncPersistence_DoesNotStallMailbox(t *testing.T) {
+	const events = 30
+	elapsed := func(name string, opts ...Option) time.Duration {
+		provider := newSlowState(2*time.Millisecond, -1)
+		pid := spawnNotifying(t, provider, make(chan sideEffect, events), nil, name, opts...)
+		defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+		start := time.Now()
+		for i := 0; i < events; i++ {
+			system.Root.Send(pid, newMessage("a"))
+		}
+		askState(t, pid)
+		return time.Since(start)
+	}
+
+	syncElapsed := elapsed("sync-throughput")
+	asyncElapsed := elapsed("async-throughput", WithAsyncPersistence())
+	assert.Less(t, int64(asyncElapsed), int64(syncElapsed/2), "sync: %v, async: %v", syncElapsed, asyncElapsed)
+}
+
+func TestAsyncPersistence_WriteFailureRestartsFromDurableEvents(t *testing.T) {
+	provider := newSlowState(time.Millisecond, 3)
+	sideEffects := make(chan sideEffect, 100)
+	restarts := make(chan struct{}, 1)
+	pid := spawnNotifying(t, provider, sideEffects, restarts, "async-failure", WithAsyncPersistence())
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	for _, s := range []string{"a", "b", "c", "d", "e", "f"} {
+		system.Root.Send(pid, newMessage(s))
+	}
+
+	// the actor forgets its optimistic state, and replays the durable events
+	select {
+	case <-restarts:
+	case <-time.After(time.Second):
+		t.Fatal("the actor never restarted")
+	}
+	assert.Equal(t, "c", askState(t, pid))
+
+	var indexes []int
+	for len(sideEffects) > 0 {
+		effect := <-sideEffects
+		assert.True(t, effect.durable)
+		indexes = append(indexes, effect.eventIndex)
+	}
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+
+	// the actor goes on from the durable events
+	system.Root.Send(pid, newMessage("g"))
+	select {
+	case effect := <-sideEffects:
+		assert.Equal(t, 3, effect.eventIndex)
+		assert.True(t, effect.durable)
+	case <-time.After(time.Second):
+		t.Fatal("side effect never ran after the restart")
+	}
+}
+
+func TestSyncPersistence_RunsSideEffectsRightAway(t *testing.T) {
+	provider := newSlowState(0, -1)
+	sideEffects := make(chan sideEffect, 1)
+	pid := spawnNotifying(t, provider, sideEffects, nil, "sync-side-effects")
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	system.Root.Send(pid, newMessage("a"))
+	askState(t, pid)
+	require.Len(t, sideEffects, 1)
+	assert.True(t, (<-sideEffects).durable)
+}
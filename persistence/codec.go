@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"fmt"
+	"reflect"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/proto"
+)
+
+// PayloadCodec transforms the serialized events and snapshots of actors before they reach the provider,
+// e.g. to encrypt them
+type PayloadCodec interface {
+	Encode(data []byte) []byte
+	Decode(data []byte) ([]byte, error)
+}
+
+// EncodedPayload is persisted in place of the events and snapshots when a PayloadCodec is used,
+// so that the providers persist it as any other message
+type EncodedPayload struct {
+	TypeName string `protobuf:"bytes,1,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	Data     []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *EncodedPayload) Reset()         { *m = EncodedPayload{} }
+func (m *EncodedPayload) String() string { return proto.CompactTextString(m) }
+func (*EncodedPayload) ProtoMessage()    {}
+
+func init() {
+	proto.RegisterType((*EncodedPayload)(nil), "persistence.EncodedPayload")
+}
+
+func encodePayload(codec PayloadCodec, message proto.Message) proto.Message {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		panic(err)
+	}
+	return &EncodedPayload{TypeName: messageName(message), Data: codec.Encode(data)}
+}
+
+// DecodePayload returns the event or snapshot an EncodedPayload was encoded from with codec, e.g. for projections.
+// Other messages are returned as they are.
+func DecodePayload(codec PayloadCodec, message interface{}) (interface{}, error) {
+	payload, ok := message.(*EncodedPayload)
+	if !ok {
+		return message, nil
+	}
+	if codec == nil {
+		return nil, fmt.Errorf("persistence: no codec to decode a %v", payload.TypeName)
+	}
+	data, err := codec.Decode(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	t := proto.MessageType(payload.TypeName)
+	if t == nil {
+		t = gogoproto.MessageType(payload.TypeName)
+	}
+	if t == nil {
+		return &UnknownEvent{TypeName: payload.TypeName, Data: data}, nil
+	}
+	decoded := reflect.New(t.Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// messageName returns the name a message is registered with, by golang/protobuf or gogo/protobuf
+func messageName(message proto.Message) string {
+	if name := proto.MessageName(message); name != "" {
+		return name
+	}
+	return gogoproto.MessageName(message)
+}
@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oldKey = bytes.Repeat([]byte{1}, 32)
+	newKey = bytes.Repeat([]byte{2}, 32)
+)
+
+// concatenation appends the strings it receives, and snapshots them
+type concatenation struct {
+	Mixin
+	state string
+}
+
+func (a *concatenation) GetState() proto.Message {
+	return &wrappers.BytesValue{Value: []byte(a.state)}
+}
+
+func (a *concatenation) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *wrappers.BytesValue:
+		a.state = string(msg.Value)
+	case *wrappers.StringValue:
+		if !a.Recovering() {
+			a.PersistReceive(msg)
+		}
+		a.state += msg.Value
+	case *logQuery:
+		ctx.Respond(a.state)
+	}
+}
+
+// spawnConcatenation spawns a concatenation, the failures of which are sent to failed
+func spawnConcatenation(t *testing.T, provider Provider, failed chan interface{}, opts ...Option) *actor.PID {
+	guardian := actor.NewOneForOneStrategy(0, 0, func(reason interface{}) actor.Directive {
+		failed <- reason
+		return actor.StopDirective
+	})
+	props := actor.PropsFromProducer(func() actor.Actor { return &concatenation{} }).WithReceiverMiddleware(Using(provider, opts...))
+	pid, err := system.Root.WithGuardian(guardian).SpawnNamed(props, "concatenation")
+	require.NoError(t, err)
+	return pid
+}
+
+func concatenate(t *testing.T, provider Provider, codec PayloadCodec, values ...string) string {
+	pid := spawnConcatenation(t, provider, make(chan interface{}, 1),
+		WithPayloadCodec(codec), WithSnapshotStrategy(EveryNEvents(3)))
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+	for _, v := range values {
+		system.Root.Send(pid, &wrappers.StringValue{Value: v})
+	}
+	res, err := system.Root.RequestFuture(pid, &logQuery{}, time.Second).Result()
+	require.NoError(t, err)
+	return res.(string)
+}
+
+func TestAESGCMCodec_EncryptsWithRotatedKeys(t *testing.T) {
+	state := NewInMemoryProvider(100)
+	provider := &dataStore{providerState: state}
+
+	oldKeys := NewAESGCMCodec(NewKeyRing("old", map[string][]byte{"old": oldKey}))
+	assert.Equal(t, "abcd", concatenate(t, provider, oldKeys, "a", "b", "c", "d"))
+
+	// events and snapshots encrypted with the old key are decrypted after the rotation
+	rotated := NewAESGCMCodec(NewKeyRing("new", map[string][]byte{"old": oldKey, "new": newKey}))
+	assert.Equal(t, "abcdef", concatenate(t, provider, rotated, "e", "f"))
+	assert.Equal(t, "abcdef", concatenate(t, provider, rotated))
+
+	_, err := DecodePayload(oldKeys, state.store["concatenation"].events[5])
+	assert.EqualError(t, err, "persistence: unknown key new")
+
+	for _, e := range state.store["concatenation"].events {
+		payload := e.(*EncodedPayload)
+		assert.Equal(t, "google.protobuf.StringValue", payload.TypeName)
+		for _, v := range []string{"a", "b", "c", "d", "e", "f"} {
+			assert.NotContains(t, string(payload.Data[4:]), "\n\x01"+v)
+		}
+	}
+	snapshot, index, ok := state.GetSnapshot("concatenation")
+	require.True(t, ok)
+	assert.Equal(t, 3, index)
+	decoded, err := DecodePayload(rotated, snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", string(decoded.(*wrappers.BytesValue).Value))
+}
+
+func TestAESGCMCodec_TamperedEventFailsRecovery(t *testing.T) {
+	state := NewInMemoryProvider(100)
+	provider := &dataStore{providerState: state}
+	codec := NewAESGCMCodec(NewKeyRing("key", map[string][]byte{"key": newKey}))
+	concatenate(t, provider, codec, "a", "b")
+
+	payload := state.store["concatenation"].events[1].(*EncodedPayload)
+	payload.Data[len(payload.Data)-1] ^= 1
+
+	failed := make(chan interface{}, 1)
+	spawnConcatenation(t, provider, failed, WithPayloadCodec(codec))
+	select {
+	case reason := <-failed:
+		assert.Equal(t, ErrPayloadTampered, reason)
+	case <-time.After(time.Second):
+		t.Fatal("the recovery did not fail")
+	}
+}
+
+func TestAESGCMCodec_RejectsTruncatedPayloads(t *testing.T) {
+	codec := NewAESGCMCodec(NewKeyRing("key", map[string][]byte{"key": newKey}))
+	encoded := codec.Encode([]byte("event"))
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "event", string(decoded))
+
+	for _, truncated := range [][]byte{nil, encoded[:2], encoded[:10], encoded[:len(encoded)-1]} {
+		_, err := codec.Decode(truncated)
+		assert.Equal(t, ErrPayloadTampered, err)
+	}
+}
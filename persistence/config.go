@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+type config struct {
+	snapshotStrategy      SnapshotStrategy
+	eventAdapter          *EventAdapter
+	unknownEventHandler   UnknownEventHandler
+	recoveryTimeout       time.Duration
+	recoveryFailurePolicy RecoveryFailurePolicy
+	payloadCodec          PayloadCodec
+	asyncPersistence      bool
+	name                  func(ctx actor.Context) string
+	snapshotOnStop        bool
+}
+
+// Option configures the persistence of the actors using the plugin
+type Option func(*config)
+
+// WithSnapshotStrategy snapshots the actors as decided by strategy,
+// instead of at the snapshot interval of the provider
+func WithSnapshotStrategy(strategy SnapshotStrategy) Option {
+	return func(config *config) {
+		config.snapshotStrategy = strategy
+	}
+}
+
+// WithEventAdapter transforms the events replayed to the actors with adapter
+func WithEventAdapter(adapter *EventAdapter) Option {
+	return func(config *config) {
+		config.eventAdapter = adapter
+	}
+}
+
+// WithUnknownEventHandler decides with handler what happens with the unknown events replayed to the actors,
+// they fail their recovery by default
+func WithUnknownEventHandler(handler UnknownEventHandler) Option {
+	return func(config *config) {
+		config.unknownEventHandler = handler
+	}
+}
+
+// WithRecoveryTimeout fails the recovery of the actors when it takes longer than timeout, it never does by default
+func WithRecoveryTimeout(timeout time.Duration) Option {
+	return func(config *config) {
+		config.recoveryTimeout = timeout
+	}
+}
+
+// WithRecoveryFailurePolicy decides with policy what happens when the recovery of the actors fails,
+// they fail too by default
+func WithRecoveryFailurePolicy(policy RecoveryFailurePolicy) Option {
+	return func(config *config) {
+		config.recoveryFailurePolicy = policy
+	}
+}
+
+// WithPayloadCodec encodes the events and snapshots of the actors with codec before they are persisted,
+// and decodes them when they are recovered
+func WithPayloadCodec(codec PayloadCodec) Option {
+	return func(config *config) {
+		config.payloadCodec = codec
+	}
+}
+
+// WithAsyncPersistence persists the events and snapshots of the actors in order in the background,
+// instead of blocking their mailbox until the provider returns. Their state is updated before the events are durable:
+// side effects depending on them are deferred with Mixin.DeferUntilPersisted, and the actors restart from the
+// durable events when a write fails.
+func WithAsyncPersistence() Option {
+	return func(config *config) {
+		config.asyncPersistence = true
+	}
+}
+
+// WithName names the events and snapshots of the actors with the name returned by name, instead of their id
+func WithName(name func(ctx actor.Context) string) Option {
+	return func(config *config) {
+		config.name = name
+	}
+}
+
+// WithSnapshotOnStop snapshots the actors when they stop with events persisted since their last snapshot,
+// so that they recover from the snapshot when they start again
+func WithSnapshotOnStop() Option {
+	return func(config *config) {
+		config.snapshotOnStop = true
+	}
+}
@@ -0,0 +1,88 @@
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Upcaster transforms a persisted event into a newer version of it
+type Upcaster func(oldEvent proto.Message) proto.Message
+
+// EventAdapter transforms the events replayed to actors, so that they only apply the current versions of their events
+type EventAdapter struct {
+	upcasters map[string]Upcaster
+}
+
+func NewEventAdapter() *EventAdapter {
+	return &EventAdapter{upcasters: make(map[string]Upcaster)}
+}
+
+// RegisterUpcaster upcasts the replayed events of type fromTypeName, the protobuf message name, with fn.
+// The upcasters are chained, so that an event is upcasted until no upcaster is registered for its type.
+func (adapter *EventAdapter) RegisterUpcaster(fromTypeName string, fn Upcaster) {
+	adapter.upcasters[fromTypeName] = fn
+}
+
+func (adapter *EventAdapter) upcast(event interface{}) interface{} {
+	// every upcaster applies at most once, so that cycles end
+	for i := 0; i <= len(adapter.upcasters); i++ {
+		message, ok := event.(proto.Message)
+		if !ok {
+			return event
+		}
+		upcaster, ok := adapter.upcasters[proto.MessageName(message)]
+		if !ok {
+			return event
+		}
+		event = upcaster(message)
+	}
+	panic(fmt.Errorf("upcasters of %v form a cycle", proto.MessageName(event.(proto.Message))))
+}
+
+// UnknownEvent is replayed by providers in place of the events the type of which is not registered
+type UnknownEvent struct {
+	TypeName string
+	Data     []byte
+}
+
+// UnknownEventError fails the recovery of an actor, when an unknown event is replayed to it
+type UnknownEventError struct {
+	ActorName  string
+	EventIndex int
+	TypeName   string
+}
+
+func (e *UnknownEventError) Error() string {
+	return fmt.Sprintf("unknown event %v of type %v replayed to actor %v", e.EventIndex, e.TypeName, e.ActorName)
+}
+
+// UnknownEventDirective tells the Mixin what to do with an unknown event
+type UnknownEventDirective int
+
+const (
+	// SkipEvent replays the next event, as if the unknown one did not exist
+	SkipEvent UnknownEventDirective = iota
+	// FailRecovery fails the actor with an UnknownEventError, for its supervisor to decide
+	FailRecovery
+	// DeliverEvent replays the UnknownEvent to the actor, which handles it as it sees fit
+	DeliverEvent
+)
+
+// UnknownEventHandler decides what happens with the unknown events replayed to an actor
+type UnknownEventHandler func(actorName string, eventIndex int, event *UnknownEvent) UnknownEventDirective
+
+// SkipUnknownEvents skips the unknown events
+func SkipUnknownEvents(actorName string, eventIndex int, event *UnknownEvent) UnknownEventDirective {
+	return SkipEvent
+}
+
+// FailOnUnknownEvents fails the recovery of the actors replaying unknown events, it is the default
+func FailOnUnknownEvents(actorName string, eventIndex int, event *UnknownEvent) UnknownEventDirective {
+	return FailRecovery
+}
+
+// DeliverUnknownEvents replays the unknown events to the actors as UnknownEvent
+func DeliverUnknownEvents(actorName string, eventIndex int, event *UnknownEvent) UnknownEventDirective {
+	return DeliverEvent
+}
@@ -0,0 +1,184 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// DepositedV0 is the first version of Deposited, in whole units
+type DepositedV0 struct{ protoMsg }
+
+func (*DepositedV0) XXX_MessageName() string { return "bank.DepositedV0" }
+
+// DepositedV1 is the second version of Deposited, in cents of an implicit currency
+type DepositedV1 struct {
+	protoMsg
+	cents int
+}
+
+func (*DepositedV1) XXX_MessageName() string { return "bank.DepositedV1" }
+
+// Deposited is the current version
+type Deposited struct {
+	protoMsg
+	cents    int
+	currency string
+}
+
+func (*Deposited) XXX_MessageName() string { return "bank.Deposited" }
+
+type balanceQuery struct{}
+
+// account applies the current version of Deposited only
+type account struct {
+	Mixin
+	balance  int
+	unknowns []string
+}
+
+func (a *account) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *Deposited:
+		if msg.currency != "EUR" {
+			panic("unexpected currency " + msg.currency)
+		}
+		a.balance += msg.cents
+	case *UnknownEvent:
+		a.unknowns = append(a.unknowns, msg.TypeName)
+	case *balanceQuery:
+		ctx.Respond(a)
+	}
+}
+
+func bankAdapter() *EventAdapter {
+	adapter := NewEventAdapter()
+	adapter.RegisterUpcaster("bank.DepositedV0", func(e proto.Message) proto.Message {
+		units := map[string]int{"one": 1, "two": 2}[e.(*DepositedV0).state]
+		return &DepositedV1{cents: units * 100}
+	})
+	adapter.RegisterUpcaster("bank.DepositedV1", func(e proto.Message) proto.Message {
+		return &Deposited{cents: e.(*DepositedV1).cents, currency: "EUR"}
+	})
+	return adapter
+}
+
+// replayingState replays events as they are, including those the provider could not decode
+type replayingState struct {
+	ProviderState
+	events []interface{}
+}
+
+func (s *replayingState) GetState() ProviderState {
+	return s
+}
+
+func (s *replayingState) GetEvents(actorName string, eventIndexStart int, eventIndexEnd int, callback func(e interface{})) {
+	for _, e := range s.events[eventIndexStart:] {
+		callback(e)
+	}
+}
+
+// recoverAccount returns the state of an account recovered from provider, or the reason its recovery failed for
+func recoverAccount(t *testing.T, provider Provider, opts ...Option) (*account, interface{}) {
+	failed := make(chan interface{}, 1)
+	guardian := actor.NewOneForOneStrategy(0, 0, func(reason interface{}) actor.Directive {
+		failed <- reason
+		return actor.StopDirective
+	})
+	props := actor.PropsFromProducer(func() actor.Actor { return &account{} }).WithReceiverMiddleware(Using(provider, opts...))
+	pid, err := system.Root.WithGuardian(guardian).SpawnNamed(props, "account")
+	require.NoError(t, err)
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	recovered := make(chan interface{}, 1)
+	go func() {
+		res, _ := system.Root.RequestFuture(pid, &balanceQuery{}, time.Second).Result()
+		recovered <- res
+	}()
+	select {
+	case reason := <-failed:
+		return nil, reason
+	case res := <-recovered:
+		require.NotNil(t, res, "the account neither recovered nor failed")
+		return res.(*account), nil
+	}
+}
+
+func TestEventAdapter_UpcastsMixedEventVersions(t *testing.T) {
+	state := NewInMemoryProvider(100)
+	for i, e := range []proto.Message{
+		&DepositedV0{protoMsg{state: "one"}},
+		&DepositedV1{cents: 50},
+		&Deposited{cents: 25, currency: "EUR"},
+		&DepositedV0{protoMsg{state: "two"}},
+		&DepositedV1{cents: 5},
+	} {
+		state.PersistEvent("account", i, e)
+	}
+
+	a, reason := recoverAccount(t, &dataStore{providerState: state}, WithEventAdapter(bankAdapter()))
+	require.Nil(t, reason)
+	assert.Equal(t, 100+50+25+200+5, a.balance)
+}
+
+func TestEventAdapter_UpcastsEventsAfterSnapshot(t *testing.T) {
+	state := NewInMemoryProvider(100)
+	for i, e := range []proto.Message{
+		&DepositedV1{cents: 1000},
+		&DepositedV0{protoMsg{state: "one"}},
+		&DepositedV1{cents: 7},
+	} {
+		state.PersistEvent("account", i, e)
+	}
+	// the snapshot would restore a balance of 1000, account ignores it
+	state.PersistSnapshot("account", 1, newSnapshot("1000"))
+
+	a, reason := recoverAccount(t, &dataStore{providerState: state}, WithEventAdapter(bankAdapter()))
+	require.Nil(t, reason)
+	assert.Equal(t, 107, a.balance)
+}
+
+func TestEventAdapter_UnknownEvents(t *testing.T) {
+	events := []interface{}{
+		&DepositedV1{cents: 10},
+		&UnknownEvent{TypeName: "bank.Withdrawn"},
+		&Deposited{cents: 20, currency: "EUR"},
+	}
+	provider := func() Provider {
+		return &replayingState{ProviderState: NewInMemoryProvider(100), events: events}
+	}
+	failure := &UnknownEventError{ActorName: "account", EventIndex: 1, TypeName: "bank.Withdrawn"}
+
+	_, reason := recoverAccount(t, provider(), WithEventAdapter(bankAdapter()))
+	assert.Equal(t, failure, reason)
+
+	var handled []int
+	_, reason = recoverAccount(t, provider(), WithUnknownEventHandler(func(actorName string, eventIndex int, event *UnknownEvent) UnknownEventDirective {
+		handled = append(handled, eventIndex)
+		return FailOnUnknownEvents(actorName, eventIndex, event)
+	}))
+	assert.Equal(t, failure, reason)
+	assert.Equal(t, []int{1}, handled)
+
+	a, reason := recoverAccount(t, provider(), WithEventAdapter(bankAdapter()), WithUnknownEventHandler(SkipUnknownEvents))
+	require.Nil(t, reason)
+	assert.Equal(t, 30, a.balance)
+	assert.Empty(t, a.unknowns)
+
+	a, reason = recoverAccount(t, provider(), WithEventAdapter(bankAdapter()), WithUnknownEventHandler(DeliverUnknownEvents))
+	require.Nil(t, reason)
+	assert.Equal(t, 30, a.balance)
+	assert.Equal(t, []string{"bank.Withdrawn"}, a.unknowns)
+}
+
+func TestEventAdapter_UpcasterCycleFails(t *testing.T) {
+	adapter := NewEventAdapter()
+	adapter.RegisterUpcaster("bank.DepositedV1", func(e proto.Message) proto.Message { return &Deposited{} })
+	adapter.RegisterUpcaster("bank.Deposited", func(e proto.Message) proto.Message { return &DepositedV1{} })
+	assert.Panics(t, func() { adapter.upcast(&DepositedV1{}) })
+}
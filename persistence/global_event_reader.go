@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/scheduler"
+)
+
+// PersistedEvent is an event as it was persisted, read across all actors to build projections
+type PersistedEvent struct {
+	ActorName  string
+	EventIndex int
+	// GlobalOffset orders the events of all actors, in the order they were persisted
+	GlobalOffset int64
+	Timestamp    time.Time
+	// Message is the event, or an UnknownEvent if its type is not registered
+	Message interface{}
+}
+
+// GlobalEventReader is implemented by the providers which read the events of all actors
+type GlobalEventReader interface {
+	// ReadAll returns at most limit events from fromGlobalOffset on, and the global offset to read the next events from
+	ReadAll(fromGlobalOffset int64, limit int) ([]PersistedEvent, int64)
+}
+
+// subscriptionBatchSize is the number of events a subscription reads at once
+const subscriptionBatchSize = 100
+
+type pollEvents struct{}
+
+// subscription tails a GlobalEventReader
+type subscription struct {
+	reader       GlobalEventReader
+	offset       int64
+	projection   *actor.PID
+	pollInterval time.Duration
+	cancel       scheduler.CancelFunc
+}
+
+// SubscribeAll spawns an actor sending the *PersistedEvent read by reader from fromGlobalOffset on to projection.
+// It polls reader every pollInterval once it caught up with the persisted events, until it is stopped.
+// Projections checkpoint the GlobalOffset of the events they processed, to subscribe from the next one when they restart.
+func SubscribeAll(context actor.SpawnerContext, reader GlobalEventReader, fromGlobalOffset int64, projection *actor.PID, pollInterval time.Duration) *actor.PID {
+	return context.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return &subscription{
+			reader:       reader,
+			offset:       fromGlobalOffset,
+			projection:   projection,
+			pollInterval: pollInterval,
+		}
+	}))
+}
+
+func (s *subscription) Receive(ctx actor.Context) {
+	switch ctx.Message().(type) {
+	case *actor.Started:
+		ctx.Send(ctx.Self(), &pollEvents{})
+	case *pollEvents:
+		events, next := s.reader.ReadAll(s.offset, subscriptionBatchSize)
+		for i := range events {
+			ctx.Send(s.projection, &events[i])
+		}
+		s.offset = next
+		if len(events) == subscriptionBatchSize {
+			ctx.Send(ctx.Self(), &pollEvents{})
+		} else {
+			s.cancel = scheduler.NewTimerScheduler(ctx).SendOnce(s.pollInterval, ctx.Self(), &pollEvents{})
+		}
+	case *actor.Stopping:
+		if s.cancel != nil {
+			s.cancel()
+		}
+	}
+}
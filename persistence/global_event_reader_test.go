@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryProvider_ReadAll(t *testing.T) {
+	provider := NewInMemoryProvider(100)
+	provider.PersistEvent("a", 0, newMessage("a0"))
+	provider.PersistEvent("b", 0, newMessage("b0"))
+	provider.PersistEvent("a", 1, newMessage("a1"))
+
+	events, next := provider.ReadAll(0, 2)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(2), next)
+	assert.Equal(t, "a", events[0].ActorName)
+	assert.Equal(t, 0, events[0].EventIndex)
+	assert.Equal(t, int64(0), events[0].GlobalOffset)
+	assert.Equal(t, "b0", events[1].Message.(*Message).state)
+	assert.False(t, events[1].Timestamp.Before(events[0].Timestamp))
+
+	events, next = provider.ReadAll(next, 2)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(3), next)
+	assert.Equal(t, 1, events[0].EventIndex)
+	assert.Equal(t, int64(2), events[0].GlobalOffset)
+
+	events, next = provider.ReadAll(next, 2)
+	assert.Empty(t, events)
+	assert.Equal(t, int64(3), next)
+}
+
+func TestSubscribeAll_TailsEvents(t *testing.T) {
+	provider := NewInMemoryProvider(100)
+	for i := 0; i < subscriptionBatchSize+1; i++ {
+		provider.PersistEvent("a", i, newMessage("old"))
+	}
+
+	received := make(chan *PersistedEvent, 200)
+	projection := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if e, ok := ctx.Message().(*PersistedEvent); ok {
+			received <- e
+		}
+	}))
+	defer system.Root.Stop(projection)
+	subscription := SubscribeAll(system.Root, provider, 5, projection, 10*time.Millisecond)
+	defer system.Root.Stop(subscription)
+
+	for offset := int64(5); offset <= subscriptionBatchSize; offset++ {
+		select {
+		case e := <-received:
+			require.Equal(t, offset, e.GlobalOffset)
+		case <-time.After(time.Second):
+			t.Fatalf("event %v not received", offset)
+		}
+	}
+
+	provider.PersistEvent("b", 0, newMessage("new"))
+	select {
+	case e := <-received:
+		assert.Equal(t, "b", e.ActorName)
+		assert.Equal(t, int64(subscriptionBatchSize+1), e.GlobalOffset)
+		assert.Equal(t, "new", e.Message.(*Message).state)
+	case <-time.After(time.Second):
+		t.Fatal("new event not received")
+	}
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 )
@@ -9,19 +10,33 @@ import (
 type entry struct {
 	eventIndex int // the event index right after snapshot
 	snapshot   proto.Message
-	events     []proto.Message
+	events     []proto.Message // by event index, nil once deleted
 }
 
 type InMemoryProvider struct {
 	snapshotInterval int
 	mu               sync.RWMutex
 	store            map[string]*entry // actorName -> a persistence entry
+	all              []*PersistedEvent // the events of all actors, by global offset, nil once deleted
+	states           map[string]*versionedState
 }
 
+// versionedState is the state of a state actor
+type versionedState struct {
+	state   proto.Message
+	version uint64
+}
+
+var (
+	_ GlobalEventReader = (*InMemoryProvider)(nil)
+	_ StateStore        = (*InMemoryProvider)(nil)
+)
+
 func NewInMemoryProvider(snapshotInterval int) *InMemoryProvider {
 	return &InMemoryProvider{
 		snapshotInterval: snapshotInterval,
 		store:            make(map[string]*entry),
+		states:           make(map[string]*versionedState),
 	}
 }
 
@@ -63,25 +78,96 @@ func (provider *InMemoryProvider) PersistSnapshot(actorName string, eventIndex i
 	entry.snapshot = snapshot
 }
 
-func (provider *InMemoryProvider) DeleteSnapshots(actorName string, inclusiveToIndex int) {
-
+// DeleteSnapshots deletes the snapshot of the actor when keepLatest is 0, only the latest one is kept anyway
+func (provider *InMemoryProvider) DeleteSnapshots(actorName string, keepLatest int) {
+	entry, _ := provider.loadOrInit(actorName)
+	if keepLatest == 0 {
+		entry.eventIndex = 0
+		entry.snapshot = nil
+	}
 }
 
 func (provider *InMemoryProvider) GetEvents(actorName string, eventIndexStart int, eventIndexEnd int, callback func(e interface{})) {
 	entry, _ := provider.loadOrInit(actorName)
-	if eventIndexEnd == 0 {
+	if eventIndexEnd == 0 || eventIndexEnd > len(entry.events) {
 		eventIndexEnd = len(entry.events)
 	}
-	for _, e := range entry.events[eventIndexStart:eventIndexEnd] {
-		callback(e)
+	for i := eventIndexStart; i < eventIndexEnd; i++ {
+		// the deleted events are skipped
+		if e := entry.events[i]; e != nil {
+			callback(e)
+		}
 	}
 }
 
 func (provider *InMemoryProvider) PersistEvent(actorName string, eventIndex int, event proto.Message) {
 	entry, _ := provider.loadOrInit(actorName)
-	entry.events = append(entry.events, event)
+	// the events are stored by index, the index restarts from 0 once the actor was purged
+	for len(entry.events) <= eventIndex {
+		entry.events = append(entry.events, nil)
+	}
+	entry.events[eventIndex] = event
+
+	provider.mu.Lock()
+	provider.all = append(provider.all, &PersistedEvent{
+		ActorName:    actorName,
+		EventIndex:   eventIndex,
+		GlobalOffset: int64(len(provider.all)),
+		Timestamp:    time.Now(),
+		Message:      event,
+	})
+	provider.mu.Unlock()
 }
 
-func (provider *InMemoryProvider) DeleteEvents(actorName string, inclusiveToIndex int) {
+func (provider *InMemoryProvider) ReadAll(fromGlobalOffset int64, limit int) ([]PersistedEvent, int64) {
+	provider.mu.RLock()
+	defer provider.mu.RUnlock()
+
+	var res []PersistedEvent
+	offset := fromGlobalOffset
+	for ; offset < int64(len(provider.all)) && len(res) < limit; offset++ {
+		if e := provider.all[offset]; e != nil {
+			res = append(res, *e)
+		}
+	}
+	return res, offset
+}
 
+func (provider *InMemoryProvider) DeleteEventsTo(actorName string, inclusiveToIndex int) {
+	entry, _ := provider.loadOrInit(actorName)
+	for i := 0; i <= inclusiveToIndex && i < len(entry.events); i++ {
+		entry.events[i] = nil
+	}
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	for i, e := range provider.all {
+		if e != nil && e.ActorName == actorName && e.EventIndex <= inclusiveToIndex {
+			provider.all[i] = nil
+		}
+	}
+}
+
+func (provider *InMemoryProvider) LoadState(actorName string) (state proto.Message, version uint64, ok bool) {
+	provider.mu.RLock()
+	defer provider.mu.RUnlock()
+	s, ok := provider.states[actorName]
+	if !ok {
+		return nil, 0, false
+	}
+	// the actors own the states they load and save
+	return proto.Clone(s.state), s.version, true
+}
+
+func (provider *InMemoryProvider) SaveState(actorName string, expectedVersion uint64, state proto.Message) (version uint64, ok bool) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	if s, ok := provider.states[actorName]; ok {
+		version = s.version
+	}
+	if version != expectedVersion {
+		return version, false
+	}
+	provider.states[actorName] = &versionedState{state: proto.Clone(state), version: version + 1}
+	return version + 1, true
 }
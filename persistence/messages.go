@@ -1,8 +1,28 @@
 package persistence
 
+import "time"
+
 type Replay struct{}
 type ReplayComplete struct{}
 type OfferSnapshot struct {
 	Snapshot interface{}
 }
 type RequestSnapshot struct{}
+
+// RecoveryStarted is received by persistent actors before every attempt to recover them
+type RecoveryStarted struct {
+	Attempt int
+}
+
+// RecoveryCompleted is received by persistent actors once they recovered, after ReplayComplete.
+// The messages they received meanwhile are processed next.
+type RecoveryCompleted struct {
+	// Events is the number of events replayed
+	Events   int
+	Duration time.Duration
+}
+
+// RecoveryFailed is received by persistent actors when an attempt to recover them failed
+type RecoveryFailed struct {
+	Err error
+}
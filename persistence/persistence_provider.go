@@ -21,11 +21,13 @@ type ProviderState interface {
 type SnapshotStore interface {
 	GetSnapshot(actorName string) (snapshot interface{}, eventIndex int, ok bool)
 	PersistSnapshot(actorName string, snapshotIndex int, snapshot proto.Message)
-	DeleteSnapshots(actorName string, inclusiveToIndex int)
+	// DeleteSnapshots deletes the snapshots of the actor but the keepLatest latest ones
+	DeleteSnapshots(actorName string, keepLatest int)
 }
 
 type EventStore interface {
 	GetEvents(actorName string, eventIndexStart int, eventIndexEnd int, callback func(e interface{}))
 	PersistEvent(actorName string, eventIndex int, event proto.Message)
-	DeleteEvents(actorName string, inclusiveToIndex int)
+	// DeleteEventsTo deletes the events of the actor up to inclusiveToIndex, GetEvents skips them
+	DeleteEventsTo(actorName string, inclusiveToIndex int)
 }
@@ -1,24 +1,51 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/golang/protobuf/proto"
 )
 
 type persistent interface {
-	init(provider Provider, context actor.Context)
+	init(provider Provider, context actor.Context, config *config)
+	recover()
+	stash(env *actor.MessageEnvelope) bool
+	unstash() []*actor.MessageEnvelope
+	handle(msg interface{}) bool
+	snapshotOnStop()
+	flush()
 	PersistReceive(message proto.Message)
 	PersistSnapshot(snapshot proto.Message)
 	Recovering() bool
 	Name() string
 }
 
+// Snapshotter is implemented by persistent actors producing their snapshots themselves.
+// When the snapshot strategy fires, the Mixin persists the state they return instead of sending them RequestSnapshot.
+type Snapshotter interface {
+	GetState() proto.Message
+}
+
 type Mixin struct {
-	eventIndex    int
-	providerState ProviderState
-	name          string
-	receiver      receiver
-	recovering    bool
+	eventIndex        int
+	providerState     ProviderState
+	name              string
+	receiver          receiver
+	recovering        bool
+	snapshotStrategy  SnapshotStrategy
+	snapshotter       Snapshotter
+	lastSnapshotIndex int
+	lastSnapshotTime  time.Time
+	config            *config
+	context           actor.Context
+	attempts          int
+	recoveryStart     time.Time
+	recoveredEvents   int
+	snapshotRecovered bool
+	stashed           []*actor.MessageEnvelope
+	writer            *asyncWriter
+	deferred          []deferred
 }
 
 // enforces that Mixin implements persistent interface
@@ -34,40 +61,96 @@ func (mixin *Mixin) Name() string {
 }
 
 func (mixin *Mixin) PersistReceive(message proto.Message) {
-	mixin.providerState.PersistEvent(mixin.Name(), mixin.eventIndex, message)
-	if mixin.eventIndex%mixin.providerState.GetSnapshotInterval() == 0 {
-		mixin.receiver.Receive(&actor.MessageEnvelope{Message: &RequestSnapshot{}})
+	event := message
+	if mixin.config.payloadCodec != nil {
+		event = encodePayload(mixin.config.payloadCodec, message)
+	}
+	name, eventIndex := mixin.Name(), mixin.eventIndex
+	mixin.persist(func() {
+		mixin.providerState.PersistEvent(name, eventIndex, event)
+	})
+	if mixin.snapshotStrategy.ShouldSnapshot(mixin.eventIndex, mixin.lastSnapshotIndex, time.Since(mixin.lastSnapshotTime)) {
+		mixin.snapshot()
 	}
 	mixin.eventIndex++
 }
 
+// snapshotOnStop snapshots the recovered actor stopping with events persisted since its last snapshot
+func (mixin *Mixin) snapshotOnStop() {
+	if mixin.providerState == nil || mixin.recovering || mixin.eventIndex == mixin.lastSnapshotIndex {
+		return
+	}
+	mixin.snapshot()
+}
+
+// snapshot persists the state of the actor, produced by itself or requested with RequestSnapshot
+func (mixin *Mixin) snapshot() {
+	if mixin.snapshotter != nil {
+		mixin.PersistSnapshot(mixin.snapshotter.GetState())
+	} else {
+		mixin.receiver.Receive(&actor.MessageEnvelope{Message: &RequestSnapshot{}})
+	}
+}
+
 func (mixin *Mixin) PersistSnapshot(snapshot proto.Message) {
-	mixin.providerState.PersistSnapshot(mixin.Name(), mixin.eventIndex, snapshot)
+	if mixin.config.payloadCodec != nil {
+		snapshot = encodePayload(mixin.config.payloadCodec, snapshot)
+	}
+	name, eventIndex := mixin.Name(), mixin.eventIndex
+	mixin.persist(func() {
+		mixin.providerState.PersistSnapshot(name, eventIndex, snapshot)
+	})
+	mixin.lastSnapshotIndex = mixin.eventIndex
+	mixin.lastSnapshotTime = time.Now()
 }
 
-func (mixin *Mixin) init(provider Provider, context actor.Context) {
+func (mixin *Mixin) init(provider Provider, context actor.Context, config *config) {
 	if mixin.providerState == nil {
 		mixin.providerState = provider.GetState()
 	}
 
-	receiver := context.(receiver)
-
 	mixin.name = context.Self().Id
+	if config.name != nil {
+		mixin.name = config.name(context)
+	}
 	mixin.eventIndex = 0
-	mixin.receiver = receiver
+	mixin.receiver = context.(receiver)
 	mixin.recovering = true
+	mixin.snapshotStrategy = config.snapshotStrategy
+	if mixin.snapshotStrategy == nil {
+		mixin.snapshotStrategy = snapshotInterval(mixin.providerState.GetSnapshotInterval())
+	}
+	mixin.snapshotter, _ = context.Actor().(Snapshotter)
+	mixin.lastSnapshotIndex = 0
+	mixin.lastSnapshotTime = time.Now()
 
-	mixin.providerState.Restart()
-	if snapshot, eventIndex, ok := mixin.providerState.GetSnapshot(mixin.Name()); ok {
-		mixin.eventIndex = eventIndex
-		receiver.Receive(&actor.MessageEnvelope{Message: snapshot})
+	mixin.config = config
+	mixin.context = context
+	mixin.attempts = 0
+	mixin.recoveryStart = time.Now()
+	mixin.recoveredEvents = 0
+	mixin.snapshotRecovered = false
+	mixin.deferred = nil
+	if config.asyncPersistence {
+		mixin.writer = newAsyncWriter(context.ActorSystem(), context.Self())
 	}
-	mixin.providerState.GetEvents(mixin.Name(), mixin.eventIndex, 0 /* 0 means max */, func(e interface{}) {
-		receiver.Receive(&actor.MessageEnvelope{Message: e})
-		mixin.eventIndex++
-	})
-	mixin.recovering = false
-	receiver.Receive(&actor.MessageEnvelope{Message: &ReplayComplete{}})
+
+	mixin.recover()
+}
+
+func (mixin *Mixin) replay(event interface{}) {
+	config := mixin.config
+	if unknown, ok := event.(*UnknownEvent); ok {
+		switch config.unknownEventHandler(mixin.Name(), mixin.eventIndex, unknown) {
+		case SkipEvent:
+			return
+		case FailRecovery:
+			panic(&UnknownEventError{ActorName: mixin.Name(), EventIndex: mixin.eventIndex, TypeName: unknown.TypeName})
+		}
+	} else if config.eventAdapter != nil {
+		event = config.eventAdapter.upcast(event)
+	}
+	mixin.receiver.Receive(&actor.MessageEnvelope{Message: event})
 }
 
 type receiver interface {
@@ -167,3 +167,28 @@ func TestRecovery(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotOnStop_UsesName(t *testing.T) {
+	store := initData(100, 0)
+	props := actor.PropsFromProducer(makeActor).WithReceiverMiddleware(Using(store,
+		WithName(func(ctx actor.Context) string { return "named/" + ctx.Self().Id }),
+		WithSnapshotOnStop()))
+	pid, err := system.Root.SpawnNamed(props, "snapshot-on-stop")
+	require.NoError(t, err)
+	system.Root.Send(pid, newMessage("a"))
+	system.Root.Send(pid, newMessage("b"))
+	require.NoError(t, system.Root.PoisonFuture(pid).Wait())
+
+	snapshot, index, ok := store.providerState.GetSnapshot("named/snapshot-on-stop")
+	require.True(t, ok)
+	assert.Equal(t, 2, index)
+	assert.Equal(t, "b", snapshot.(*Snapshot).state)
+
+	// an actor stopping without new events is not snapshotted again
+	store.providerState.PersistSnapshot("named/snapshot-on-stop", 2, newSnapshot("recovered"))
+	pid, err = system.Root.SpawnNamed(props, "snapshot-on-stop")
+	require.NoError(t, err)
+	require.NoError(t, system.Root.PoisonFuture(pid).Wait())
+	snapshot, _, _ = store.providerState.GetSnapshot("named/snapshot-on-stop")
+	assert.Equal(t, "recovered", snapshot.(*Snapshot).state)
+}
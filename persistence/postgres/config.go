@@ -0,0 +1,22 @@
+package postgres
+
+type postgresConfig struct {
+	snapshotInterval int
+	fetchSize        int
+}
+
+type PostgresOption func(*postgresConfig)
+
+// WithSnapshot sets the snapshot interval reported to the persistence plugin
+func WithSnapshot(interval int) PostgresOption {
+	return func(config *postgresConfig) {
+		config.snapshotInterval = interval
+	}
+}
+
+// WithFetchSize sets the number of events GetEvents reads from the cursor at once, 100 by default
+func WithFetchSize(size int) PostgresOption {
+	return func(config *postgresConfig) {
+		config.fetchSize = size
+	}
+}
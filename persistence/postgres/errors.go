@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConcurrencyError is returned by AppendEvents, and raised by PersistEvent, when another writer already persisted an
+// event at the same index of the actor. Actors would typically restart on it, to recover the events of the other writer.
+type ConcurrencyError struct {
+	ActorName  string
+	EventIndex int
+}
+
+func (e *ConcurrencyError) Error() string {
+	return fmt.Sprintf("concurrent write of event %v of actor %v", e.EventIndex, e.ActorName)
+}
+
+// IsConcurrencyError tells whether err, or the reason an actor failed for, is a ConcurrencyError
+func IsConcurrencyError(err interface{}) bool {
+	e, ok := err.(error)
+	if !ok {
+		return false
+	}
+	var concurrencyErr *ConcurrencyError
+	return errors.As(e, &concurrencyErr)
+}
@@ -0,0 +1,302 @@
+// Package postgres persists actors in PostgreSQL. Its integration tests run with: go test -tags integration
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/AsynkronIT/protoactor-go/persistence"
+	"github.com/golang/protobuf/proto"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	// uniqueViolation is the PostgreSQL error code of a duplicate key
+	uniqueViolation = "23505"
+	// appendLock serializes the appends, so that the global offsets are committed in order and ReadAll skips none
+	appendLock = 0x70726f746f
+)
+
+// Provider persists the events and snapshots of actors in PostgreSQL, in the tables created by Migrate.
+// Failing reads and writes panic, so that the supervisor of the actor decides how to recover.
+type Provider struct {
+	pool             *pgxpool.Pool
+	snapshotInterval int
+	fetchSize        int
+}
+
+var (
+	_ persistence.ProviderState     = (*Provider)(nil)
+	_ persistence.GlobalEventReader = (*Provider)(nil)
+	_ persistence.StateStore        = (*Provider)(nil)
+)
+
+func New(pool *pgxpool.Pool, options ...PostgresOption) *Provider {
+	config := &postgresConfig{fetchSize: 100}
+	for _, option := range options {
+		option(config)
+	}
+
+	return &Provider{
+		pool:             pool,
+		snapshotInterval: config.snapshotInterval,
+		fetchSize:        config.fetchSize,
+	}
+}
+
+func (provider *Provider) GetState() persistence.ProviderState {
+	return provider
+}
+
+func (provider *Provider) Restart() {}
+
+func (provider *Provider) GetSnapshotInterval() int {
+	return provider.snapshotInterval
+}
+
+func (provider *Provider) GetSnapshot(actorName string) (snapshot interface{}, eventIndex int, ok bool) {
+	var messageType string
+	var message []byte
+	err := provider.pool.QueryRow(context.Background(),
+		"SELECT event_index, message_type, message FROM snapshots WHERE actor_name = $1", actorName).
+		Scan(&eventIndex, &messageType, &message)
+	if err == pgx.ErrNoRows {
+		return nil, 0, false
+	}
+	if err != nil {
+		panic(err)
+	}
+	return unmarshal(messageType, message), eventIndex, true
+}
+
+func (provider *Provider) PersistSnapshot(actorName string, snapshotIndex int, snapshot proto.Message) {
+	message, err := proto.Marshal(snapshot)
+	if err != nil {
+		panic(err)
+	}
+	_, err = provider.pool.Exec(context.Background(), `
+		INSERT INTO snapshots (actor_name, event_index, message_type, message) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (actor_name) DO UPDATE
+		SET event_index = excluded.event_index, message_type = excluded.message_type, message = excluded.message`,
+		actorName, snapshotIndex, proto.MessageName(snapshot), message)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// DeleteSnapshots deletes the snapshot of the actor when keepLatest is 0, only the latest one is stored anyway
+func (provider *Provider) DeleteSnapshots(actorName string, keepLatest int) {
+	if keepLatest > 0 {
+		return
+	}
+	_, err := provider.pool.Exec(context.Background(), "DELETE FROM snapshots WHERE actor_name = $1", actorName)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GetEvents calls callback with the events of the actor from eventIndexStart, up to eventIndexEnd excluded or all
+// when it is 0. The events are read through a cursor, so that they are never all held in memory.
+// Events of unregistered types are replayed as persistence.UnknownEvent.
+func (provider *Provider) GetEvents(actorName string, eventIndexStart int, eventIndexEnd int, callback func(e interface{})) {
+	ctx := context.Background()
+	tx, err := provider.pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := "DECLARE events_cursor NO SCROLL CURSOR FOR " +
+		"SELECT message_type, message FROM events WHERE actor_name = $1 AND event_index >= $2"
+	args := []interface{}{actorName, eventIndexStart}
+	if eventIndexEnd > 0 {
+		query += " AND event_index < $3"
+		args = append(args, eventIndexEnd)
+	}
+	if _, err := tx.Exec(ctx, query+" ORDER BY event_index", args...); err != nil {
+		panic(err)
+	}
+
+	fetch := "FETCH " + strconv.Itoa(provider.fetchSize) + " FROM events_cursor"
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			panic(err)
+		}
+		fetched := 0
+		for rows.Next() {
+			var messageType string
+			var message []byte
+			if err := rows.Scan(&messageType, &message); err != nil {
+				rows.Close()
+				panic(err)
+			}
+			callback(event(messageType, message))
+			fetched++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			panic(err)
+		}
+		if fetched < provider.fetchSize {
+			return
+		}
+	}
+}
+
+// PersistEvent appends the event at eventIndex, it panics with a ConcurrencyError when another writer did first
+func (provider *Provider) PersistEvent(actorName string, eventIndex int, event proto.Message) {
+	if err := provider.AppendEvents(actorName, eventIndex, event); err != nil {
+		panic(err)
+	}
+}
+
+// AppendEvents persists the events from eventIndex on in one transaction, either all of them or none.
+// It returns a ConcurrencyError when another writer already persisted an event at one of their indexes.
+// The appends of all actors are serialized, for the global offsets to be committed in order.
+func (provider *Provider) AppendEvents(actorName string, eventIndex int, events ...proto.Message) error {
+	ctx := context.Background()
+	batch := &pgx.Batch{}
+	for i, event := range events {
+		message, err := proto.Marshal(event)
+		if err != nil {
+			return err
+		}
+		batch.Queue("INSERT INTO events (actor_name, event_index, message_type, message) VALUES ($1, $2, $3, $4)",
+			actorName, eventIndex+i, proto.MessageName(event), message)
+	}
+
+	tx, err := provider.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", appendLock); err != nil {
+		return err
+	}
+	results := tx.SendBatch(ctx, batch)
+	for i := range events {
+		if _, err := results.Exec(); err != nil {
+			_ = results.Close()
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == uniqueViolation {
+				return &ConcurrencyError{ActorName: actorName, EventIndex: eventIndex + i}
+			}
+			return err
+		}
+	}
+	if err := results.Close(); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (provider *Provider) DeleteEventsTo(actorName string, inclusiveToIndex int) {
+	_, err := provider.pool.Exec(context.Background(),
+		"DELETE FROM events WHERE actor_name = $1 AND event_index <= $2", actorName, inclusiveToIndex)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (provider *Provider) ReadAll(fromGlobalOffset int64, limit int) ([]persistence.PersistedEvent, int64) {
+	rows, err := provider.pool.Query(context.Background(), `
+		SELECT actor_name, event_index, global_offset, created_at, message_type, message FROM events
+		WHERE global_offset >= $1 ORDER BY global_offset LIMIT $2`, fromGlobalOffset, limit)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var res []persistence.PersistedEvent
+	next := fromGlobalOffset
+	for rows.Next() {
+		var e persistence.PersistedEvent
+		var messageType string
+		var message []byte
+		if err := rows.Scan(&e.ActorName, &e.EventIndex, &e.GlobalOffset, &e.Timestamp, &messageType, &message); err != nil {
+			panic(err)
+		}
+		e.Message = event(messageType, message)
+		res = append(res, e)
+		next = e.GlobalOffset + 1
+	}
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+	return res, next
+}
+
+func (provider *Provider) LoadState(actorName string) (state proto.Message, version uint64, ok bool) {
+	var messageType string
+	var message []byte
+	err := provider.pool.QueryRow(context.Background(),
+		"SELECT version, message_type, message FROM states WHERE actor_name = $1", actorName).
+		Scan(&version, &messageType, &message)
+	if err == pgx.ErrNoRows {
+		return nil, 0, false
+	}
+	if err != nil {
+		panic(err)
+	}
+	return unmarshal(messageType, message), version, true
+}
+
+// SaveState saves the state with a compare-and-set on its version, the first version is inserted
+func (provider *Provider) SaveState(actorName string, expectedVersion uint64, state proto.Message) (version uint64, ok bool) {
+	message, err := proto.Marshal(state)
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+	var tag pgconn.CommandTag
+	if expectedVersion == 0 {
+		tag, err = provider.pool.Exec(ctx, `
+			INSERT INTO states (actor_name, version, message_type, message) VALUES ($1, 1, $2, $3)
+			ON CONFLICT (actor_name) DO NOTHING`,
+			actorName, proto.MessageName(state), message)
+	} else {
+		tag, err = provider.pool.Exec(ctx, `
+			UPDATE states SET version = version + 1, message_type = $3, message = $4
+			WHERE actor_name = $1 AND version = $2`,
+			actorName, expectedVersion, proto.MessageName(state), message)
+	}
+	if err != nil {
+		panic(err)
+	}
+	if tag.RowsAffected() == 1 {
+		return expectedVersion + 1, true
+	}
+
+	// another writer saved it first
+	err = provider.pool.QueryRow(ctx, "SELECT version FROM states WHERE actor_name = $1", actorName).Scan(&version)
+	if err != nil && err != pgx.ErrNoRows {
+		panic(err)
+	}
+	return version, false
+}
+
+// event decodes an event, as an UnknownEvent if its type is not registered
+func event(messageType string, data []byte) interface{} {
+	if proto.MessageType(messageType) == nil {
+		return &persistence.UnknownEvent{TypeName: messageType, Data: data}
+	}
+	return unmarshal(messageType, data)
+}
+
+// unmarshal decodes a message of a registered protobuf type
+func unmarshal(messageType string, data []byte) proto.Message {
+	t := proto.MessageType(messageType)
+	if t == nil {
+		panic(fmt.Errorf("unknown message type %v", messageType))
+	}
+	message := reflect.New(t.Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(data, message); err != nil {
+		panic(err)
+	}
+	return message
+}
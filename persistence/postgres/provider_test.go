@@ -0,0 +1,327 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/persistence"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pool is connected to a PostgreSQL container started for the tests, it is nil when docker is not available
+var pool *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	docker, err := dockertest.NewPool("")
+	if err != nil {
+		fmt.Printf("docker is not available: %v\n", err)
+		os.Exit(m.Run())
+	}
+	resource, err := docker.Run("postgres", "12-alpine", []string{"POSTGRES_PASSWORD=secret", "POSTGRES_DB=protoactor"})
+	if err != nil {
+		fmt.Printf("could not start postgres: %v\n", err)
+		os.Exit(m.Run())
+	}
+	_ = resource.Expire(120)
+
+	url := fmt.Sprintf("postgres://postgres:secret@%v/protoactor?sslmode=disable", resource.GetHostPort("5432/tcp"))
+	err = docker.Retry(func() error {
+		var err error
+		if pool, err = pgxpool.Connect(context.Background(), url); err != nil {
+			return err
+		}
+		_, err = pool.Exec(context.Background(), "SELECT 1")
+		return err
+	})
+	if err == nil {
+		err = Migrate(context.Background(), pool)
+	}
+	if err != nil {
+		fmt.Printf("could not connect to postgres: %v\n", err)
+		_ = docker.Purge(resource)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	pool.Close()
+	_ = docker.Purge(resource)
+	os.Exit(code)
+}
+
+func newProvider(t *testing.T, options ...PostgresOption) *Provider {
+	if pool == nil {
+		t.Skip("docker is not available")
+	}
+	return New(pool, options...)
+}
+
+func str(s string) *wrappers.StringValue {
+	return &wrappers.StringValue{Value: s}
+}
+
+// events returns the values of the events of the actor from eventIndexStart
+func events(provider *Provider, actorName string, eventIndexStart int) []string {
+	var res []string
+	provider.GetEvents(actorName, eventIndexStart, 0, func(e interface{}) {
+		res = append(res, e.(*wrappers.StringValue).Value)
+	})
+	return res
+}
+
+func TestProvider_AppendsAndReadsEvents(t *testing.T) {
+	provider := newProvider(t, WithFetchSize(2))
+
+	require.NoError(t, provider.AppendEvents("append", 0, str("a"), str("b"), str("c")))
+	provider.PersistEvent("append", 3, str("d"))
+	require.NoError(t, provider.AppendEvents("append", 4, str("e")))
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, events(provider, "append", 0))
+	assert.Equal(t, []string{"c", "d", "e"}, events(provider, "append", 2))
+
+	var bounded []string
+	provider.GetEvents("append", 1, 3, func(e interface{}) {
+		bounded = append(bounded, e.(*wrappers.StringValue).Value)
+	})
+	assert.Equal(t, []string{"b", "c"}, bounded)
+	assert.Empty(t, events(provider, "other", 0))
+}
+
+func TestProvider_DetectsConcurrentWriters(t *testing.T) {
+	provider := newProvider(t)
+	require.NoError(t, provider.AppendEvents("concurrent", 0, str("a"), str("b")))
+
+	err := provider.AppendEvents("concurrent", 1, str("x"), str("y"))
+	require.Error(t, err)
+	assert.True(t, IsConcurrencyError(err))
+	assert.Equal(t, &ConcurrencyError{ActorName: "concurrent", EventIndex: 1}, err)
+
+	assert.PanicsWithValue(t, &ConcurrencyError{ActorName: "concurrent", EventIndex: 0}, func() {
+		provider.PersistEvent("concurrent", 0, str("z"))
+	})
+}
+
+func TestProvider_RollsBackConflictingBatch(t *testing.T) {
+	provider := newProvider(t)
+	require.NoError(t, provider.AppendEvents("rollback", 2, str("c")))
+
+	err := provider.AppendEvents("rollback", 0, str("a"), str("b"), str("x"))
+	assert.Equal(t, &ConcurrencyError{ActorName: "rollback", EventIndex: 2}, err)
+	assert.Equal(t, []string{"c"}, events(provider, "rollback", 0))
+}
+
+func TestProvider_KeepsLatestSnapshot(t *testing.T) {
+	provider := newProvider(t)
+	_, _, ok := provider.GetSnapshot("snapshot")
+	assert.False(t, ok)
+
+	provider.PersistSnapshot("snapshot", 3, str("first"))
+	provider.PersistSnapshot("snapshot", 7, str("second"))
+	snapshot, index, ok := provider.GetSnapshot("snapshot")
+	require.True(t, ok)
+	assert.Equal(t, 7, index)
+	assert.True(t, proto.Equal(str("second"), snapshot.(proto.Message)))
+
+	provider.DeleteSnapshots("snapshot", 1)
+	_, _, ok = provider.GetSnapshot("snapshot")
+	assert.True(t, ok)
+	provider.DeleteSnapshots("snapshot", 0)
+	_, _, ok = provider.GetSnapshot("snapshot")
+	assert.False(t, ok)
+}
+
+func TestProvider_DeletesEvents(t *testing.T) {
+	provider := newProvider(t)
+	require.NoError(t, provider.AppendEvents("delete", 0, str("a"), str("b"), str("c")))
+
+	provider.DeleteEventsTo("delete", 1)
+	assert.Equal(t, []string{"c"}, events(provider, "delete", 0))
+	assert.Equal(t, []string{"c"}, events(provider, "delete", 1))
+}
+
+// lastValue is a persistent actor remembering the last value it received
+type lastValue struct {
+	persistence.Mixin
+	value string
+}
+
+func (a *lastValue) GetState() proto.Message {
+	return str(a.value)
+}
+
+func (a *lastValue) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *wrappers.StringValue:
+		if !a.Recovering() {
+			a.PersistReceive(msg)
+		}
+		a.value = msg.Value
+	case *wrappers.BoolValue:
+		ctx.Respond(str(a.value))
+	}
+}
+
+func TestProvider_RecoversPersistentActor(t *testing.T) {
+	provider := newProvider(t, WithFetchSize(3))
+	system := actor.NewActorSystem()
+	props := actor.PropsFromProducer(func() actor.Actor { return &lastValue{} }).
+		WithReceiverMiddleware(persistence.Using(provider, persistence.WithSnapshotStrategy(persistence.EveryNEvents(4))))
+
+	pid, err := system.Root.SpawnNamed(props, "recovered")
+	require.NoError(t, err)
+	for _, s := range []string{"a", "b", "c", "d", "e", "f"} {
+		system.Root.Send(pid, str(s))
+	}
+	require.NoError(t, system.Root.PoisonFuture(pid).Wait())
+
+	_, index, ok := provider.GetSnapshot("recovered")
+	require.True(t, ok)
+	assert.Equal(t, 4, index)
+
+	pid, err = system.Root.SpawnNamed(props, "recovered")
+	require.NoError(t, err)
+	res, err := system.Root.RequestFuture(pid, &wrappers.BoolValue{}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "f", res.(*wrappers.StringValue).Value)
+}
+
+func TestProvider_ReplaysUnregisteredTypesAsUnknownEvents(t *testing.T) {
+	provider := newProvider(t)
+	require.NoError(t, provider.AppendEvents("unknown", 0, str("a")))
+	_, err := pool.Exec(context.Background(),
+		"INSERT INTO events (actor_name, event_index, message_type, message) VALUES ($1, 1, 'bank.Withdrawn', $2)",
+		"unknown", []byte{1, 2})
+	require.NoError(t, err)
+
+	var replayed []interface{}
+	provider.GetEvents("unknown", 0, 0, func(e interface{}) {
+		replayed = append(replayed, e)
+	})
+	require.Len(t, replayed, 2)
+	assert.Equal(t, &persistence.UnknownEvent{TypeName: "bank.Withdrawn", Data: []byte{1, 2}}, replayed[1])
+}
+
+func TestProvider_ReadsAllEventsInOrder(t *testing.T) {
+	provider := newProvider(t)
+	// skip the events of the other tests
+	var offset int64
+	for {
+		events, next := provider.ReadAll(offset, 100)
+		offset = next
+		if len(events) == 0 {
+			break
+		}
+	}
+
+	require.NoError(t, provider.AppendEvents("all-a", 0, str("a0"), str("a1")))
+	require.NoError(t, provider.AppendEvents("all-b", 0, str("b0")))
+
+	events, next := provider.ReadAll(offset, 2)
+	require.Len(t, events, 2)
+	assert.Equal(t, "all-a", events[0].ActorName)
+	assert.Equal(t, 1, events[1].EventIndex)
+	assert.Equal(t, "a1", events[1].Message.(*wrappers.StringValue).Value)
+	assert.WithinDuration(t, time.Now(), events[0].Timestamp, time.Minute)
+
+	events, next = provider.ReadAll(next, 2)
+	require.Len(t, events, 1)
+	assert.Equal(t, "all-b", events[0].ActorName)
+	assert.Equal(t, events[0].GlobalOffset+1, next)
+
+	events, _ = provider.ReadAll(next, 2)
+	assert.Empty(t, events)
+}
+
+func TestProvider_SavesStateWithCompareAndSet(t *testing.T) {
+	provider := newProvider(t)
+	_, _, ok := provider.LoadState("cas")
+	assert.False(t, ok)
+
+	version, ok := provider.SaveState("cas", 0, str("a"))
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), version)
+	version, ok = provider.SaveState("cas", 0, str("b"))
+	assert.False(t, ok, "the first version exists already")
+	assert.Equal(t, uint64(1), version)
+
+	version, ok = provider.SaveState("cas", 1, str("c"))
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), version)
+	version, ok = provider.SaveState("cas", 1, str("d"))
+	assert.False(t, ok, "a stale version")
+	assert.Equal(t, uint64(2), version)
+
+	state, version, ok := provider.LoadState("cas")
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), version)
+	assert.Equal(t, "c", state.(*wrappers.StringValue).Value)
+
+	version, ok = provider.SaveState("cas-missing", 3, str("e"))
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), version)
+}
+
+// owner is a state actor, the last string it received is its state
+type owner struct {
+	persistence.StateMixin
+	value     *wrappers.StringValue
+	conflicts chan *persistence.StateConflict
+}
+
+func (a *owner) GetState() proto.Message      { return a.value }
+func (a *owner) SetState(state proto.Message) { a.value = state.(*wrappers.StringValue) }
+func (a *owner) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *wrappers.StringValue:
+		a.value = msg
+		ctx.Respond(&wrappers.BoolValue{Value: a.SaveState()})
+	case *wrappers.BoolValue:
+		ctx.Respond(&wrappers.UInt64Value{Value: a.StateVersion()})
+	case *persistence.StateConflict:
+		a.conflicts <- msg
+	}
+}
+
+func TestProvider_StateConflictBetweenActivations(t *testing.T) {
+	provider := newProvider(t)
+	conflicts := make(chan *persistence.StateConflict, 1)
+	props := actor.PropsFromProducer(func() actor.Actor { return &owner{conflicts: conflicts} }).
+		WithReceiverMiddleware(persistence.UsingStateStore(provider))
+
+	// the same actor activated by two members which don't see each other, both load the state before either saves
+	var roots []*actor.RootContext
+	var pids []*actor.PID
+	for i := 0; i < 2; i++ {
+		root := actor.NewActorSystem().Root
+		pid, err := root.SpawnNamed(props, "split-brain")
+		require.NoError(t, err)
+		defer root.Stop(pid)
+		res, err := root.RequestFuture(pid, &wrappers.BoolValue{}, time.Second).Result()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), res.(*wrappers.UInt64Value).Value)
+		roots, pids = append(roots, root), append(pids, pid)
+	}
+
+	saved := func(i int, s string) bool {
+		res, err := roots[i].RequestFuture(pids[i], str(s), time.Second).Result()
+		require.NoError(t, err)
+		return res.(*wrappers.BoolValue).Value
+	}
+	assert.True(t, saved(0, "first"))
+	assert.False(t, saved(1, "second"))
+	assert.Equal(t, &persistence.StateConflict{Version: 0, StoredVersion: 1}, <-conflicts)
+
+	state, _, _ := provider.LoadState("split-brain")
+	assert.Equal(t, "first", state.(*wrappers.StringValue).Value)
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Schema creates the tables of the provider. The primary key of events detects concurrent writers of an actor,
+// their global_offset orders the events of all actors for ReadAll. The version of states detects the concurrent
+// writers of a state actor.
+const Schema = `
+CREATE TABLE IF NOT EXISTS events (
+	actor_name    TEXT        NOT NULL,
+	event_index   BIGINT      NOT NULL,
+	message_type  TEXT        NOT NULL,
+	message       BYTEA       NOT NULL,
+	global_offset BIGSERIAL   NOT NULL UNIQUE,
+	created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (actor_name, event_index)
+);
+
+CREATE TABLE IF NOT EXISTS snapshots (
+	actor_name   TEXT   NOT NULL PRIMARY KEY,
+	event_index  BIGINT NOT NULL,
+	message_type TEXT   NOT NULL,
+	message      BYTEA  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS states (
+	actor_name   TEXT   NOT NULL PRIMARY KEY,
+	version      BIGINT NOT NULL,
+	message_type TEXT   NOT NULL,
+	message      BYTEA  NOT NULL
+);
+`
+
+// Migrate creates the tables of the provider if they don't exist yet
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	_, err := pool.Exec(ctx, Schema)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"sync"
 
+	"github.com/AsynkronIT/protoactor-go/persistence"
 	"github.com/couchbase/gocb"
 	"github.com/golang/protobuf/proto"
 )
@@ -45,12 +46,15 @@ func (state *cbState) GetEvents(actorName string, eventIndexStart int, eventInde
 	var row envelope
 	i := eventIndexStart
 	for rows.Next(&row) {
-		e := row.message()
 		if row.EventIndex != i {
 			log.Printf("%v, Invalid actor state, missing event %v", actorName, i)
 			return
 		}
-		callback(e)
+		if proto.MessageType(row.Type) == nil {
+			callback(&persistence.UnknownEvent{TypeName: row.Type, Data: row.Message})
+		} else {
+			callback(row.message())
+		}
 		i++
 	}
 }
@@ -91,7 +95,7 @@ func (state *cbState) PersistEvent(actorName string, eventIndex int, event proto
 	state.persistEnvelope(key, envelope)
 }
 
-func (state *cbState) DeleteEvents(actorName string, inclusiveToIndex int) {
+func (state *cbState) DeleteEventsTo(actorName string, inclusiveToIndex int) {
 	panic("implement me")
 }
 
@@ -101,7 +105,7 @@ func (state *cbState) PersistSnapshot(actorName string, eventIndex int, snapshot
 	state.persistEnvelope(key, envelope)
 }
 
-func (state *cbState) DeleteSnapshots(actorName string, inclusiveToIndex int) {
+func (state *cbState) DeleteSnapshots(actorName string, keepLatest int) {
 	panic("implement me")
 }
 
@@ -7,8 +7,24 @@ import (
 	"github.com/AsynkronIT/protoactor-go/actor"
 )
 
-func Using(provider Provider) func(next actor.ReceiverFunc) actor.ReceiverFunc {
+// Using persists the actors with provider, as configured by opts
+func Using(provider Provider, opts ...Option) func(next actor.ReceiverFunc) actor.ReceiverFunc {
+	config := &config{
+		unknownEventHandler:   FailOnUnknownEvents,
+		recoveryFailurePolicy: StopOnRecoveryFailure,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
 	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		// the messages received while recovering are processed once the actor recovered
+		unstash := func(ctx actor.ReceiverContext, p persistent) {
+			if !p.Recovering() {
+				for _, env := range p.unstash() {
+					next(ctx, env)
+				}
+			}
+		}
 		fn := func(ctx actor.ReceiverContext, env *actor.MessageEnvelope) {
 			switch env.Message.(type) {
 
@@ -19,15 +35,62 @@ func Using(provider Provider) func(next actor.ReceiverFunc) actor.ReceiverFunc {
 				//check if the actor is persistent
 				if p, ok := ctx.Actor().(persistent); ok {
 					//initialize it
-					p.init(provider, ctx.(actor.Context))
+					p.init(provider, ctx.(actor.Context), config)
+					unstash(ctx, p)
 				} else {
 					//not an persistent actor, bail out
 					log.Fatalf("Actor type %v is not persistent", reflect.TypeOf(ctx.Actor()))
 				}
+			case *actor.Stopping, *actor.Restarting:
+				// the events are durable before the actor stops or recovers again
+				if p, ok := ctx.Actor().(persistent); ok {
+					if _, stopping := env.Message.(*actor.Stopping); stopping && config.snapshotOnStop {
+						p.snapshotOnStop()
+					}
+					p.flush()
+				}
+				next(ctx, env)
+			case *retryRecovery:
+				p := ctx.Actor().(persistent)
+				p.recover()
+				unstash(ctx, p)
 			default:
+				if p, ok := ctx.Actor().(persistent); ok && (p.handle(env.Message) || p.stash(env)) {
+					return
+				}
 				next(ctx, env)
 			}
 		}
 		return fn
 	}
 }
+
+// UsingStateStore persists the state of the actors with store, as configured by opts. Of the options, WithName and
+// WithPayloadCodec apply to the state
+func UsingStateStore(store StateStore, opts ...Option) func(next actor.ReceiverFunc) actor.ReceiverFunc {
+	config := &config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(ctx actor.ReceiverContext, env *actor.MessageEnvelope) {
+			switch env.Message.(type) {
+			case *actor.Started:
+				// the actor starts with its state
+				if s, ok := ctx.Actor().(stateful); ok {
+					s.initState(store, ctx.(actor.Context), config)
+				} else {
+					log.Fatalf("Actor type %v is not a state actor", reflect.TypeOf(ctx.Actor()))
+				}
+				next(ctx, env)
+			case *actor.Stopping:
+				next(ctx, env)
+				if s, ok := ctx.Actor().(stateful); ok {
+					s.saveStateOnStop()
+				}
+			default:
+				next(ctx, env)
+			}
+		}
+	}
+}
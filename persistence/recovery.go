@@ -0,0 +1,195 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// ErrRecoveryTimeout fails the recovery of actors, when it takes longer than the recovery timeout
+var ErrRecoveryTimeout = errors.New("persistence: recovery timed out")
+
+// RecoveryDirective tells the Mixin what to do when the recovery of an actor failed
+type RecoveryDirective int
+
+const (
+	// RetryRecovery recovers the events not replayed yet again, after a backoff
+	RetryRecovery RecoveryDirective = iota
+	// StartEmpty gives up the recovery, the actor goes on with the events replayed so far,
+	// which are none when the event store is down
+	StartEmpty
+	// StopRecovery fails the actor with the error of the recovery, for its supervisor to decide
+	StopRecovery
+)
+
+// RecoveryFailurePolicy decides what happens when the attempt-th attempt to recover an actor failed with err,
+// and how long to wait before retrying it
+type RecoveryFailurePolicy func(attempt int, err error) (RecoveryDirective, time.Duration)
+
+// RetryRecoveryWithBackoff retries the recovery maxRetries times, waiting backoff first and doubling it every attempt.
+// The actor then fails.
+func RetryRecoveryWithBackoff(maxRetries int, backoff time.Duration) RecoveryFailurePolicy {
+	return func(attempt int, err error) (RecoveryDirective, time.Duration) {
+		if attempt > maxRetries {
+			return StopRecovery, 0
+		}
+		return RetryRecovery, backoff << uint(attempt-1)
+	}
+}
+
+// StartEmptyOnRecoveryFailure gives up the recovery of actors at the first failure
+func StartEmptyOnRecoveryFailure(attempt int, err error) (RecoveryDirective, time.Duration) {
+	return StartEmpty, 0
+}
+
+// StopOnRecoveryFailure fails the actors at the first failure of their recovery, it is the default
+func StopOnRecoveryFailure(attempt int, err error) (RecoveryDirective, time.Duration) {
+	return StopRecovery, 0
+}
+
+// retryRecovery is sent by the Mixin to its actor, once the backoff before retrying the recovery passed
+type retryRecovery struct{}
+
+// recovered is a snapshot or event read from the provider, or the error that failed the reading
+type recovered struct {
+	snapshot   interface{}
+	eventIndex int
+	event      interface{}
+	err        error
+}
+
+// recover replays the snapshot and events of the actor not replayed yet, and handles the outcome
+func (mixin *Mixin) recover() {
+	mixin.attempts++
+	mixin.receiver.Receive(&actor.MessageEnvelope{Message: &RecoveryStarted{Attempt: mixin.attempts}})
+
+	err := mixin.replayAll()
+	if err == nil {
+		mixin.recovering = false
+		mixin.receiver.Receive(&actor.MessageEnvelope{Message: &ReplayComplete{}})
+		mixin.receiver.Receive(&actor.MessageEnvelope{Message: &RecoveryCompleted{
+			Events:   mixin.recoveredEvents,
+			Duration: time.Since(mixin.recoveryStart),
+		}})
+		return
+	}
+
+	mixin.receiver.Receive(&actor.MessageEnvelope{Message: &RecoveryFailed{Err: err}})
+	directive, backoff := mixin.config.recoveryFailurePolicy(mixin.attempts, err)
+	plog.Error("Recovery failed", log.Stringer("pid", mixin.context.Self()), log.Int("attempt", mixin.attempts), log.Error(err))
+	switch directive {
+	case RetryRecovery:
+		system, self := mixin.context.ActorSystem(), mixin.context.Self()
+		time.AfterFunc(backoff, func() {
+			system.Root.Send(self, &retryRecovery{})
+		})
+	case StartEmpty:
+		mixin.recovering = false
+		mixin.receiver.Receive(&actor.MessageEnvelope{Message: &ReplayComplete{}})
+	default:
+		// the stashed messages go back to the mailbox, the supervisor decides whether they are processed
+		for _, env := range mixin.unstash() {
+			mixin.context.RequestWithCustomSender(mixin.context.Self(), env.Message, env.Sender)
+		}
+		panic(err)
+	}
+}
+
+// replayAll reads the provider in another goroutine, so that the recovery times out even when the provider hangs
+func (mixin *Mixin) replayAll() error {
+	done := make(chan struct{})
+	defer close(done)
+	items := make(chan *recovered, 16)
+	send := func(item *recovered) {
+		select {
+		case items <- item:
+		case <-done:
+		}
+	}
+
+	// a snapshot is only recovered before the events, retries go on from the last replayed event
+	name, eventIndex := mixin.Name(), mixin.eventIndex
+	recoverSnapshot := !mixin.snapshotRecovered && mixin.recoveredEvents == 0
+	go func() {
+		defer close(items)
+		defer func() {
+			if r := recover(); r != nil {
+				err, ok := r.(error)
+				if !ok {
+					err = fmt.Errorf("persistence: recovery failed: %v", r)
+				}
+				send(&recovered{err: err})
+			}
+		}()
+
+		mixin.providerState.Restart()
+		if recoverSnapshot {
+			if snapshot, index, ok := mixin.providerState.GetSnapshot(name); ok {
+				send(&recovered{snapshot: snapshot, eventIndex: index})
+				eventIndex = index
+			}
+		}
+		mixin.providerState.GetEvents(name, eventIndex, 0 /* 0 means max */, func(e interface{}) {
+			send(&recovered{event: e})
+		})
+	}()
+
+	var timeout <-chan time.Time
+	if mixin.config.recoveryTimeout > 0 {
+		timer := time.NewTimer(mixin.config.recoveryTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		select {
+		case item, ok := <-items:
+			switch {
+			case !ok:
+				return nil
+			case item.err != nil:
+				return item.err
+			case item.snapshot != nil:
+				snapshot, err := DecodePayload(mixin.config.payloadCodec, item.snapshot)
+				if err != nil {
+					return err
+				}
+				mixin.snapshotRecovered = true
+				mixin.eventIndex = item.eventIndex
+				mixin.lastSnapshotIndex = item.eventIndex
+				mixin.receiver.Receive(&actor.MessageEnvelope{Message: snapshot})
+			default:
+				event, err := DecodePayload(mixin.config.payloadCodec, item.event)
+				if err != nil {
+					return err
+				}
+				mixin.replay(event)
+				mixin.eventIndex++
+				mixin.recoveredEvents++
+			}
+		case <-timeout:
+			return ErrRecoveryTimeout
+		}
+	}
+}
+
+// stash keeps the messages received while the actor recovers, it tells whether env was stashed
+func (mixin *Mixin) stash(env *actor.MessageEnvelope) bool {
+	if !mixin.recovering {
+		return false
+	}
+	switch env.Message.(type) {
+	case actor.AutoReceiveMessage, actor.SystemMessage:
+		return false
+	}
+	mixin.stashed = append(mixin.stashed, env)
+	return true
+}
+
+func (mixin *Mixin) unstash() []*actor.MessageEnvelope {
+	stashed := mixin.stashed
+	mixin.stashed = nil
+	return stashed
+}
@@ -0,0 +1,165 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStoreDown = errors.New("event store is down")
+
+// failingState fails the first failures calls to GetEvents, and blocks them until release is closed if it is set
+type failingState struct {
+	ProviderState
+	mutex    sync.Mutex
+	failures int
+	release  chan struct{}
+}
+
+func (s *failingState) GetState() ProviderState {
+	return s
+}
+
+func (s *failingState) GetEvents(actorName string, eventIndexStart int, eventIndexEnd int, callback func(e interface{})) {
+	if s.release != nil {
+		<-s.release
+	}
+	s.mutex.Lock()
+	fail := s.failures > 0
+	s.failures--
+	s.mutex.Unlock()
+	if fail {
+		panic(errStoreDown)
+	}
+	s.ProviderState.GetEvents(actorName, eventIndexStart, eventIndexEnd, callback)
+}
+
+func failingStateWithEvents(failures int, states ...string) *failingState {
+	state := NewInMemoryProvider(100)
+	for i, s := range states {
+		state.PersistEvent(ActorName, i, newMessage(s))
+	}
+	return &failingState{ProviderState: state, failures: failures}
+}
+
+type logQuery struct{}
+
+// recoveryLog logs the recovery messages and the events it receives
+type recoveryLog struct {
+	Mixin
+	log []string
+}
+
+func (a *recoveryLog) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *RecoveryStarted:
+		a.log = append(a.log, fmt.Sprintf("started %v", msg.Attempt))
+	case *RecoveryFailed:
+		a.log = append(a.log, "failed: "+msg.Err.Error())
+	case *ReplayComplete:
+		a.log = append(a.log, "replayed")
+	case *RecoveryCompleted:
+		a.log = append(a.log, fmt.Sprintf("completed %v", msg.Events))
+	case *Message:
+		if !a.Recovering() {
+			a.PersistReceive(msg)
+		}
+		a.log = append(a.log, msg.state)
+	case *logQuery:
+		ctx.Respond(append([]string(nil), a.log...))
+	}
+}
+
+// spawnRecoveryLog spawns a recoveryLog, the failures of which are sent to failed
+func spawnRecoveryLog(t *testing.T, provider Provider, failed chan interface{}, opts ...Option) *actor.PID {
+	guardian := actor.NewOneForOneStrategy(0, 0, func(reason interface{}) actor.Directive {
+		failed <- reason
+		return actor.StopDirective
+	})
+	props := actor.PropsFromProducer(func() actor.Actor { return &recoveryLog{} }).WithReceiverMiddleware(Using(provider, opts...))
+	pid, err := system.Root.WithGuardian(guardian).SpawnNamed(props, ActorName)
+	require.NoError(t, err)
+	return pid
+}
+
+func recoveryLogOf(t *testing.T, pid *actor.PID) []string {
+	res, err := system.Root.RequestFuture(pid, &logQuery{}, time.Second).Result()
+	require.NoError(t, err)
+	return res.([]string)
+}
+
+func TestRecovery_RetriesWithBackoff(t *testing.T) {
+	state := failingStateWithEvents(2, "a", "b", "c")
+	pid := spawnRecoveryLog(t, state, make(chan interface{}, 1),
+		WithRecoveryFailurePolicy(RetryRecoveryWithBackoff(3, 10*time.Millisecond)))
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+	system.Root.Send(pid, newMessage("d"))
+
+	// the message sent while recovering is stashed, and processed after the replayed events
+	assert.Equal(t, []string{
+		"started 1", "failed: event store is down",
+		"started 2", "failed: event store is down",
+		"started 3", "a", "b", "c", "replayed", "completed 3",
+		"d",
+	}, recoveryLogOf(t, pid))
+
+	var persisted []string
+	state.ProviderState.GetEvents(ActorName, 0, 0, func(e interface{}) {
+		persisted = append(persisted, e.(*Message).state)
+	})
+	assert.Equal(t, []string{"a", "b", "c", "d"}, persisted)
+}
+
+func TestRecovery_TimesOutAndStartsEmpty(t *testing.T) {
+	state := failingStateWithEvents(0, "a")
+	state.release = make(chan struct{})
+	defer close(state.release)
+	pid := spawnRecoveryLog(t, state, make(chan interface{}, 1),
+		WithRecoveryTimeout(20*time.Millisecond), WithRecoveryFailurePolicy(StartEmptyOnRecoveryFailure))
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+	system.Root.Send(pid, newMessage("b"))
+
+	assert.Equal(t, []string{"started 1", "failed: " + ErrRecoveryTimeout.Error(), "replayed", "b"}, recoveryLogOf(t, pid))
+}
+
+func TestRecovery_StopsActorByDefault(t *testing.T) {
+	failed := make(chan interface{}, 1)
+	spawnRecoveryLog(t, failingStateWithEvents(1, "a"), failed)
+
+	select {
+	case reason := <-failed:
+		assert.Equal(t, errStoreDown, reason)
+	case <-time.After(time.Second):
+		t.Fatal("the actor did not fail")
+	}
+}
+
+func TestRecovery_FailsAfterMaxRetries(t *testing.T) {
+	failed := make(chan interface{}, 1)
+	spawnRecoveryLog(t, failingStateWithEvents(3, "a"), failed,
+		WithRecoveryFailurePolicy(RetryRecoveryWithBackoff(2, time.Millisecond)))
+
+	select {
+	case reason := <-failed:
+		assert.Equal(t, errStoreDown, reason)
+	case <-time.After(time.Second):
+		t.Fatal("the actor did not fail")
+	}
+}
+
+func TestRetryRecoveryWithBackoff(t *testing.T) {
+	policy := RetryRecoveryWithBackoff(3, 10*time.Millisecond)
+	for attempt, expected := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond} {
+		directive, backoff := policy(attempt+1, errStoreDown)
+		assert.Equal(t, RetryRecovery, directive)
+		assert.Equal(t, expected, backoff)
+	}
+	directive, _ := policy(4, errStoreDown)
+	assert.Equal(t, StopRecovery, directive)
+}
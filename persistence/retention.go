@@ -0,0 +1,33 @@
+package persistence
+
+import "math"
+
+// LastSnapshotIndex returns the event index of the last snapshot persisted or recovered by the actor,
+// the events are replayed from it on recovery
+func (mixin *Mixin) LastSnapshotIndex() int {
+	return mixin.lastSnapshotIndex
+}
+
+// DeleteEventsTo deletes the events of the actor up to inclusiveToIndex, typically after a snapshot.
+// The events the last snapshot does not cover are kept, so that the actor still recovers its whole state.
+func (mixin *Mixin) DeleteEventsTo(inclusiveToIndex int) {
+	if max := mixin.lastSnapshotIndex - 1; inclusiveToIndex > max {
+		inclusiveToIndex = max
+	}
+	if inclusiveToIndex < 0 {
+		return
+	}
+	mixin.providerState.DeleteEventsTo(mixin.Name(), inclusiveToIndex)
+}
+
+// DeleteSnapshots deletes the snapshots of the actor but the keepLatest latest ones
+func (mixin *Mixin) DeleteSnapshots(keepLatest int) {
+	mixin.providerState.DeleteSnapshots(mixin.Name(), keepLatest)
+}
+
+// Purge deletes all the events and snapshots of the actor named actorName, it recovers empty from now on
+func Purge(provider Provider, actorName string) {
+	state := provider.GetState()
+	state.DeleteEventsTo(actorName, math.MaxInt32)
+	state.DeleteSnapshots(actorName, 0)
+}
@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pruningActor deletes the events covered by its snapshots as it persists new ones
+type pruningActor struct {
+	Mixin
+	state string
+}
+
+func (a *pruningActor) GetState() proto.Message {
+	return newSnapshot(a.state)
+}
+
+func (a *pruningActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *Snapshot:
+		a.state = msg.state
+	case *Message:
+		if !a.Recovering() {
+			a.PersistReceive(msg)
+			a.DeleteEventsTo(a.LastSnapshotIndex())
+		}
+		a.state = msg.state
+	case *Query:
+		ctx.Respond(a.state)
+	}
+}
+
+func remainingEvents(provider *InMemoryProvider, actorName string) []string {
+	var res []string
+	provider.GetEvents(actorName, 0, 0, func(e interface{}) {
+		res = append(res, e.(*Message).state)
+	})
+	return res
+}
+
+func pruningState(t *testing.T, provider *InMemoryProvider, name string) string {
+	props := actor.PropsFromProducer(func() actor.Actor { return &pruningActor{} }).
+		WithReceiverMiddleware(Using(&dataStore{providerState: provider}, WithSnapshotStrategy(EveryNEvents(3))))
+	pid, err := system.Root.SpawnNamed(props, name)
+	require.NoError(t, err)
+	defer func() { _ = system.Root.PoisonFuture(pid).Wait() }()
+
+	res, err := system.Root.RequestFuture(pid, &Query{}, time.Second).Result()
+	require.NoError(t, err)
+	return res.(string)
+}
+
+func TestMixin_DeleteEventsToKeepsEventsAfterSnapshot(t *testing.T) {
+	provider := NewInMemoryProvider(100)
+	props := actor.PropsFromProducer(func() actor.Actor { return &pruningActor{} }).
+		WithReceiverMiddleware(Using(&dataStore{providerState: provider}, WithSnapshotStrategy(EveryNEvents(3))))
+	pid, err := system.Root.SpawnNamed(props, "pruning")
+	require.NoError(t, err)
+	for _, s := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		system.Root.Send(pid, newMessage(s))
+	}
+	require.NoError(t, system.Root.PoisonFuture(pid).Wait())
+
+	// the last snapshot is at 6, the events from it are kept to be replayed
+	_, index, ok := provider.GetSnapshot("pruning")
+	require.True(t, ok)
+	assert.Equal(t, 6, index)
+	assert.Equal(t, []string{"g", "h"}, remainingEvents(provider, "pruning"))
+
+	assert.Equal(t, "h", pruningState(t, provider, "pruning"))
+}
+
+func TestMixin_DeleteEventsToKeepsEventsWithoutSnapshot(t *testing.T) {
+	provider := NewInMemoryProvider(100)
+	for i, s := range []string{"a", "b"} {
+		provider.PersistEvent("unsnapshotted", i, newMessage(s))
+	}
+	mixin := &Mixin{providerState: provider, name: "unsnapshotted"}
+
+	mixin.DeleteEventsTo(1)
+	assert.Equal(t, []string{"a", "b"}, remainingEvents(provider, "unsnapshotted"))
+}
+
+func TestPurge(t *testing.T) {
+	provider := NewInMemoryProvider(100)
+	for i, s := range []string{"a", "b", "c"} {
+		provider.PersistEvent("purged", i, newMessage(s))
+	}
+	provider.PersistSnapshot("purged", 2, newSnapshot("b"))
+	provider.PersistEvent("kept", 0, newMessage("x"))
+
+	Purge(&dataStore{providerState: provider}, "purged")
+
+	_, _, ok := provider.GetSnapshot("purged")
+	assert.False(t, ok)
+	assert.Empty(t, remainingEvents(provider, "purged"))
+	assert.Equal(t, []string{"x"}, remainingEvents(provider, "kept"))
+	assert.Equal(t, "", pruningState(t, provider, "purged"))
+
+	// the purged events are no longer read by the projections either
+	events, next := provider.ReadAll(0, 10)
+	require.Len(t, events, 1)
+	assert.Equal(t, "kept", events[0].ActorName)
+	assert.Equal(t, int64(4), next)
+}
+
+func TestInMemoryProvider_DeleteSnapshotsKeepsLatest(t *testing.T) {
+	provider := NewInMemoryProvider(100)
+	provider.PersistSnapshot("snapshots", 3, newSnapshot("c"))
+
+	provider.DeleteSnapshots("snapshots", 1)
+	_, _, ok := provider.GetSnapshot("snapshots")
+	assert.True(t, ok)
+
+	provider.DeleteSnapshots("snapshots", 0)
+	_, _, ok = provider.GetSnapshot("snapshots")
+	assert.False(t, ok)
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import "time"
+
+// SnapshotStrategy decides when the Mixin snapshots an actor, after it persisted an event.
+// eventIndex is the index of the persisted event, lastSnapshotIndex the index of the latest snapshot
+// and elapsed the time since that snapshot, or since the actor recovered if it made none since.
+type SnapshotStrategy interface {
+	ShouldSnapshot(eventIndex int, lastSnapshotIndex int, elapsed time.Duration) bool
+}
+
+type everyNEvents int
+
+// EveryNEvents snapshots once n events were persisted since the latest snapshot
+func EveryNEvents(n int) SnapshotStrategy {
+	return everyNEvents(n)
+}
+
+func (n everyNEvents) ShouldSnapshot(eventIndex int, lastSnapshotIndex int, elapsed time.Duration) bool {
+	return eventIndex-lastSnapshotIndex >= int(n)
+}
+
+type atMostEvery time.Duration
+
+// AtMostEvery snapshots when an event is persisted once d passed since the latest snapshot
+func AtMostEvery(d time.Duration) SnapshotStrategy {
+	return atMostEvery(d)
+}
+
+func (d atMostEvery) ShouldSnapshot(eventIndex int, lastSnapshotIndex int, elapsed time.Duration) bool {
+	return elapsed >= time.Duration(d)
+}
+
+type allOf []SnapshotStrategy
+
+// And snapshots when all strategies would, e.g. And(EveryNEvents(100), AtMostEvery(time.Minute))
+func And(strategies ...SnapshotStrategy) SnapshotStrategy {
+	return allOf(strategies)
+}
+
+func (s allOf) ShouldSnapshot(eventIndex int, lastSnapshotIndex int, elapsed time.Duration) bool {
+	for _, strategy := range s {
+		if !strategy.ShouldSnapshot(eventIndex, lastSnapshotIndex, elapsed) {
+			return false
+		}
+	}
+	return true
+}
+
+type anyOf []SnapshotStrategy
+
+// Or snapshots when any of the strategies would
+func Or(strategies ...SnapshotStrategy) SnapshotStrategy {
+	return anyOf(strategies)
+}
+
+func (s anyOf) ShouldSnapshot(eventIndex int, lastSnapshotIndex int, elapsed time.Duration) bool {
+	for _, strategy := range s {
+		if strategy.ShouldSnapshot(eventIndex, lastSnapshotIndex, elapsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotInterval is the strategy used without options, it snapshots every interval events of the provider
+type snapshotInterval int
+
+func (interval snapshotInterval) ShouldSnapshot(eventIndex int, lastSnapshotIndex int, elapsed time.Duration) bool {
+	return eventIndex%int(interval) == 0
+}
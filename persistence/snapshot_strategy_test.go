@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotStrategies(t *testing.T) {
+	cases := []struct {
+		name     string
+		strategy SnapshotStrategy
+		index    int
+		elapsed  time.Duration
+		expected bool
+	}{
+		{"every n events, too few", EveryNEvents(3), 2, time.Hour, false},
+		{"every n events, enough", EveryNEvents(3), 3, 0, true},
+		{"at most every, too soon", AtMostEvery(time.Second), 100, time.Millisecond, false},
+		{"at most every, late enough", AtMostEvery(time.Second), 1, time.Second, true},
+		{"and, one fires", And(EveryNEvents(3), AtMostEvery(time.Second)), 3, 0, false},
+		{"and, all fire", And(EveryNEvents(3), AtMostEvery(time.Second)), 3, time.Second, true},
+		{"or, none fires", Or(EveryNEvents(3), AtMostEvery(time.Second)), 1, 0, false},
+		{"or, one fires", Or(EveryNEvents(3), AtMostEvery(time.Second)), 1, time.Second, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.strategy.ShouldSnapshot(tc.index, 0, tc.elapsed))
+		})
+	}
+}
+
+// snapshotRecorder records the indexes the snapshots are persisted at
+type snapshotRecorder struct {
+	ProviderState
+	indexes chan int
+}
+
+func (r *snapshotRecorder) GetState() ProviderState {
+	return r
+}
+
+func (r *snapshotRecorder) PersistSnapshot(actorName string, snapshotIndex int, snapshot proto.Message) {
+	r.ProviderState.PersistSnapshot(actorName, snapshotIndex, snapshot)
+	r.indexes <- snapshotIndex
+}
+
+// snapshottingActor produces its snapshots through GetState
+type snapshottingActor struct {
+	Mixin
+	state string
+}
+
+func (a *snapshottingActor) GetState() proto.Message {
+	return newSnapshot(a.state)
+}
+
+func (a *snapshottingActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *Snapshot:
+		a.state = msg.state
+	case *Message:
+		if !a.Recovering() {
+			a.PersistReceive(msg)
+		}
+		a.state = msg.state
+	}
+}
+
+// snapshotIndexes sends events to an actor, and returns the indexes it was snapshotted at
+func snapshotIndexes(t *testing.T, recorder *snapshotRecorder, producer actor.Producer, events int, opts ...Option) []int {
+	props := actor.PropsFromProducer(producer).WithReceiverMiddleware(Using(recorder, opts...))
+	pid, err := system.Root.SpawnNamed(props, "snapshots")
+	require.NoError(t, err)
+	for i := 0; i < events; i++ {
+		system.Root.Send(pid, newMessage(string(rune('a'+i))))
+	}
+	require.NoError(t, system.Root.PoisonFuture(pid).Wait())
+	close(recorder.indexes)
+
+	var indexes []int
+	for index := range recorder.indexes {
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+func TestSnapshotStrategy_EveryNEventsCadence(t *testing.T) {
+	recorder := &snapshotRecorder{ProviderState: NewInMemoryProvider(1), indexes: make(chan int, 100)}
+	indexes := snapshotIndexes(t, recorder, func() actor.Actor { return &snapshottingActor{} }, 10, WithSnapshotStrategy(EveryNEvents(3)))
+	assert.Equal(t, []int{3, 6, 9}, indexes)
+
+	// the snapshot at 9 holds the state before the event at 9 was applied
+	snapshot, index, ok := recorder.ProviderState.GetSnapshot("snapshots")
+	require.True(t, ok)
+	assert.Equal(t, 9, index)
+	assert.Equal(t, "i", snapshot.(*Snapshot).state)
+}
+
+func TestSnapshotStrategy_RequestsSnapshotWithoutSnapshotter(t *testing.T) {
+	recorder := &snapshotRecorder{ProviderState: NewInMemoryProvider(1), indexes: make(chan int, 100)}
+	indexes := snapshotIndexes(t, recorder, makeActor, 10, WithSnapshotStrategy(Or(EveryNEvents(4), AtMostEvery(time.Hour))))
+	assert.Equal(t, []int{4, 8}, indexes)
+}
+
+func TestSnapshotStrategy_CountsFromRecoveredSnapshot(t *testing.T) {
+	state := NewInMemoryProvider(1)
+	for i, s := range []string{"a", "b", "c", "d", "e"} {
+		state.PersistEvent("snapshots", i, newMessage(s))
+	}
+	state.PersistSnapshot("snapshots", 4, newSnapshot("d"))
+	recorder := &snapshotRecorder{ProviderState: state, indexes: make(chan int, 100)}
+
+	indexes := snapshotIndexes(t, recorder, func() actor.Actor { return &snapshottingActor{} }, 5, WithSnapshotStrategy(EveryNEvents(3)))
+	assert.Equal(t, []int{7}, indexes)
+}
+
+func TestSnapshotStrategy_DefaultsToProviderInterval(t *testing.T) {
+	recorder := &snapshotRecorder{ProviderState: NewInMemoryProvider(4), indexes: make(chan int, 100)}
+	indexes := snapshotIndexes(t, recorder, func() actor.Actor { return &snapshottingActor{} }, 10)
+	assert.Equal(t, []int{0, 4, 8}, indexes)
+}
@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+	"github.com/golang/protobuf/proto"
+)
+
+// StateStore persists the state of actors without their events. Each save increments the version of the state, and
+// a save expecting another version than the stored one saves nothing: two activations of the same actor, e.g. on
+// both sides of a network partition, can't silently overwrite each other
+type StateStore interface {
+	// LoadState returns the state of the actor and its version, ok is false when it has none
+	LoadState(actorName string) (state proto.Message, version uint64, ok bool)
+	// SaveState saves state as the version following expectedVersion, which is 0 for an actor without state yet, and
+	// returns it. When the stored version is another one it saves nothing, and returns false with the stored version
+	SaveState(actorName string, expectedVersion uint64, state proto.Message) (version uint64, ok bool)
+}
+
+// StateActor is implemented by the actors persisted with UsingStateStore, along with embedding StateMixin
+type StateActor interface {
+	GetState() proto.Message
+	// SetState replaces the state of the actor with the state loaded
+	SetState(state proto.Message)
+}
+
+// StateConflict is received by a state actor when saving its state failed: another activation saved the state since
+// the actor loaded or saved it. The state of the actor was not saved, ReloadState replaces it with the stored one
+type StateConflict struct {
+	// Version is the version the actor expected, StoredVersion the one saved by the other activation
+	Version       uint64
+	StoredVersion uint64
+}
+
+type stateful interface {
+	initState(store StateStore, context actor.Context, config *config)
+	saveStateOnStop()
+}
+
+// StateMixin persists the state of an actor with a StateStore, rather than its events. The state is loaded before
+// the actor receives Started, and saved with SaveState or when the actor stops, e.g. when it is passivated
+type StateMixin struct {
+	store   StateStore
+	name    string
+	version uint64
+	saved   proto.Message // the state as loaded or last saved, the unchanged states are not saved again
+	actor   StateActor
+	config  *config
+	context actor.Context
+}
+
+var _ stateful = (*StateMixin)(nil)
+
+// StateVersion is the version of the state the actor loaded or last saved, 0 when it has none yet
+func (mixin *StateMixin) StateVersion() uint64 {
+	return mixin.version
+}
+
+// SaveState saves the state of the actor, unless it did not change. When another activation saved the state
+// meanwhile, the actor receives StateConflict before SaveState returns false
+func (mixin *StateMixin) SaveState() bool {
+	state := mixin.actor.GetState()
+	if state == nil || (mixin.saved != nil && proto.Equal(state, mixin.saved)) {
+		return true
+	}
+	saved := proto.Clone(state)
+	if mixin.config.payloadCodec != nil {
+		state = encodePayload(mixin.config.payloadCodec, state)
+	}
+	version, ok := mixin.store.SaveState(mixin.name, mixin.version, state)
+	if !ok {
+		plog.Info("state conflict", log.String("actor", mixin.name), log.Uint64("version", mixin.version),
+			log.Uint64("storedVersion", version))
+		mixin.context.(receiver).Receive(&actor.MessageEnvelope{
+			Message: &StateConflict{Version: mixin.version, StoredVersion: version},
+		})
+		return false
+	}
+	mixin.version, mixin.saved = version, saved
+	return true
+}
+
+// ReloadState replaces the state of the actor with the stored one, e.g. to resolve a StateConflict
+func (mixin *StateMixin) ReloadState() {
+	state, version, ok := mixin.store.LoadState(mixin.name)
+	if !ok {
+		mixin.version, mixin.saved = 0, nil
+		return
+	}
+	if mixin.config.payloadCodec != nil {
+		decoded, err := DecodePayload(mixin.config.payloadCodec, state)
+		if err != nil {
+			panic(err)
+		}
+		state = decoded.(proto.Message)
+	}
+	mixin.version, mixin.saved = version, proto.Clone(state)
+	mixin.actor.SetState(state)
+}
+
+func (mixin *StateMixin) initState(store StateStore, context actor.Context, config *config) {
+	mixin.store = store
+	mixin.config = config
+	mixin.context = context
+	mixin.actor = context.Actor().(StateActor)
+	mixin.name = context.Self().Id
+	if config.name != nil {
+		mixin.name = config.name(context)
+	}
+	mixin.ReloadState()
+}
+
+// saveStateOnStop saves the state of the actor stopping, after it processed Stopping
+func (mixin *StateMixin) saveStateOnStop() {
+	if mixin.store != nil {
+		mixin.SaveState()
+	}
+}
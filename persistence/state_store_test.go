@@ -0,0 +1,136 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	increment  struct{}
+	saveState  struct{}
+	queryCount struct{}
+)
+
+type countResult struct {
+	count   int64
+	version uint64
+}
+
+// counterActor keeps a durable count, without events
+type counterActor struct {
+	StateMixin
+	count     *wrappers.Int64Value
+	conflicts chan *StateConflict
+}
+
+func (a *counterActor) GetState() proto.Message {
+	return a.count
+}
+
+func (a *counterActor) SetState(state proto.Message) {
+	a.count = state.(*wrappers.Int64Value)
+}
+
+func (a *counterActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		if a.count == nil {
+			a.count = &wrappers.Int64Value{}
+		}
+	case *increment:
+		a.count.Value++
+	case *saveState:
+		ctx.Respond(a.SaveState())
+	case *queryCount:
+		ctx.Respond(&countResult{count: a.count.Value, version: a.StateVersion()})
+	case *StateConflict:
+		a.conflicts <- msg
+		a.ReloadState()
+	}
+}
+
+func counterProps(store StateStore, conflicts chan *StateConflict) *actor.Props {
+	return actor.PropsFromProducer(func() actor.Actor { return &counterActor{conflicts: conflicts} }).
+		WithReceiverMiddleware(UsingStateStore(store, WithName(func(actor.Context) string { return "counter" })))
+}
+
+func request(t *testing.T, pid *actor.PID, message interface{}) interface{} {
+	res, err := system.Root.RequestFuture(pid, message, time.Second).Result()
+	require.NoError(t, err)
+	return res
+}
+
+func TestStateStore_SaveAndLoad(t *testing.T) {
+	store := NewInMemoryProvider(0)
+	pid := system.Root.Spawn(counterProps(store, nil))
+	system.Root.Send(pid, &increment{})
+	system.Root.Send(pid, &increment{})
+	assert.Equal(t, true, request(t, pid, &saveState{}))
+	assert.Equal(t, &countResult{count: 2, version: 1}, request(t, pid, &queryCount{}))
+
+	// the unchanged state is not saved again
+	assert.Equal(t, true, request(t, pid, &saveState{}))
+	assert.Equal(t, &countResult{count: 2, version: 1}, request(t, pid, &queryCount{}))
+
+	// the state is saved on stop, and loaded on start
+	system.Root.Send(pid, &increment{})
+	request(t, pid, &queryCount{})
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	pid = system.Root.Spawn(counterProps(store, nil))
+	defer system.Root.Stop(pid)
+	assert.Equal(t, &countResult{count: 3, version: 2}, request(t, pid, &queryCount{}))
+}
+
+func TestStateStore_SavedOnPassivation(t *testing.T) {
+	store := NewInMemoryProvider(0)
+	pid := system.Root.Spawn(counterProps(store, nil).WithIdlePassivation(20 * time.Millisecond))
+	system.Root.Send(pid, &increment{})
+	// the actor passivates once idle
+	var state proto.Message
+	var version uint64
+	require.Eventually(t, func() bool {
+		var ok bool
+		state, version, ok = store.LoadState("counter")
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(1), version)
+	assert.Equal(t, int64(1), state.(*wrappers.Int64Value).Value)
+}
+
+func TestStateStore_Conflict(t *testing.T) {
+	store := NewInMemoryProvider(0)
+	conflicts := make(chan *StateConflict, 1)
+	// two activations of the same actor, as on both sides of a partition
+	a := system.Root.Spawn(counterProps(store, nil))
+	defer system.Root.Stop(a)
+	b := system.Root.Spawn(counterProps(store, conflicts))
+	defer system.Root.Stop(b)
+	// b loaded the empty state before a saves
+	assert.Equal(t, &countResult{count: 0, version: 0}, request(t, b, &queryCount{}))
+
+	system.Root.Send(a, &increment{})
+	assert.Equal(t, true, request(t, a, &saveState{}))
+	system.Root.Send(b, &increment{})
+	system.Root.Send(b, &increment{})
+	assert.Equal(t, false, request(t, b, &saveState{}))
+	select {
+	case conflict := <-conflicts:
+		assert.Equal(t, &StateConflict{Version: 0, StoredVersion: 1}, conflict)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the conflict")
+	}
+
+	// b reloaded the state a saved, and saves on top of it
+	assert.Equal(t, &countResult{count: 1, version: 1}, request(t, b, &queryCount{}))
+	system.Root.Send(b, &increment{})
+	assert.Equal(t, true, request(t, b, &saveState{}))
+	state, version, _ := store.LoadState("counter")
+	assert.Equal(t, uint64(2), version)
+	assert.Equal(t, int64(2), state.(*wrappers.Int64Value).Value)
+}
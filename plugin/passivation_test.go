@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
-	"github.com/stretchr/testify/assert"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
 )
 
 var system = actor.NewActorSystem()
@@ -31,24 +31,13 @@ func TestPassivation(t *testing.T) {
 		PropsFromProducer(func() actor.Actor { return &SmartActor{} }).
 		WithReceiverMiddleware(Use(&PassivationPlugin{Duration: PassivationDuration}))
 
+	probe := testkit.NewTestProbe(system)
+	defer probe.Stop()
 	pid := rootContext.Spawn(props)
-	time.Sleep(UnitOfTime)
-	time.Sleep(UnitOfTime)
-	{
-		_, found := system.ProcessRegistry.LocalPIDs.Get(pid.Id)
-		assert.True(t, found)
-	}
+	probe.Watch(pid)
+
+	probe.ExpectNoMsg(t, 2*UnitOfTime)
 	rootContext.Send(pid, "keepalive")
-	time.Sleep(UnitOfTime)
-	time.Sleep(UnitOfTime)
-	{
-		_, found := system.ProcessRegistry.LocalPIDs.Get(pid.Id)
-		assert.True(t, found)
-	}
-	time.Sleep(UnitOfTime)
-	time.Sleep(UnitOfTime)
-	{
-		_, found := system.ProcessRegistry.LocalPIDs.Get(pid.Id)
-		assert.False(t, found)
-	}
+	probe.ExpectNoMsg(t, 2*UnitOfTime)
+	probe.ExpectTerminated(t, pid, 2*UnitOfTime)
 }
@@ -0,0 +1,266 @@
+// Package testgrain is generated by protoactor-go/protoc-gen-gograin@0.1.0
+package testgrain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/cluster"
+	logmod "github.com/AsynkronIT/protoactor-go/log"
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
+)
+
+var (
+	plog = logmod.New(logmod.InfoLevel, "[GRAIN]")
+	_    = proto.Marshal
+	_    = fmt.Errorf
+	_    = math.Inf
+	_    = io.EOF
+)
+
+// SetLogLevel sets the log level.
+func SetLogLevel(level logmod.Level) {
+	plog.SetLevel(level)
+}
+
+var xCounterFactory func() Counter
+
+// CounterFactory produces a Counter
+func CounterFactory(factory func() Counter) {
+	xCounterFactory = factory
+}
+
+// GetCounterGrainClient instantiates a new CounterGrainClient with given ID
+func GetCounterGrainClient(c *cluster.Cluster, id string) *CounterGrainClient {
+	if c == nil {
+		panic(fmt.Errorf("nil cluster instance"))
+	}
+	if id == "" {
+		panic(fmt.Errorf("empty id"))
+	}
+	return &CounterGrainClient{ID: id, cluster: c}
+}
+
+// Counter interfaces the services available to the Counter
+type Counter interface {
+	Init(id string)
+	Terminate()
+	ReceiveDefault(ctx actor.Context)
+	Echo(*EchoRequest, cluster.GrainContext) (*EchoResponse, error)
+	Count(*CountRequest, *CounterCountWriter, cluster.GrainContext) error
+}
+
+// CounterGrainClient holds the base data for the CounterGrain
+type CounterGrainClient struct {
+	ID      string
+	cluster *cluster.Cluster
+}
+
+// Echo requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *CounterGrainClient) Echo(ctx context.Context, r *EchoRequest, opts ...*cluster.GrainCallOptions) (*EchoResponse, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
+	resp, err := g.cluster.CallContext(ctx, g.ID, "Counter", reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := resp.(type) {
+	case *cluster.GrainResponse:
+		result := &EchoResponse{}
+		err = proto.Unmarshal(msg.MessageData, result)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	case *cluster.GrainErrorResponse:
+		return nil, errors.New(msg.Err)
+	default:
+		return nil, errors.New("unknown response")
+	}
+}
+
+// Count requests the execution on to the cluster with CallOptions, the responses are received until the method returns or ctx is done
+func (g *CounterGrainClient) Count(ctx context.Context, r *CountRequest, opts ...*cluster.GrainCallOptions) (*CounterCountStream, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg := &cluster.GrainRequest{MethodIndex: 1, MessageData: bytes}
+	stream, err := g.cluster.CallStream(ctx, g.ID, "Counter", reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CounterCountStream{stream: stream}, nil
+}
+
+// CounterCountStream receives the responses of Counter.Count
+type CounterCountStream struct {
+	stream *cluster.GrainStream
+}
+
+// Recv returns the next response, or io.EOF when the method returned
+func (s *CounterCountStream) Recv() (*CountResponse, error) {
+	bytes, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	result := &CountResponse{}
+	err = proto.Unmarshal(bytes, result)
+	if err != nil {
+		s.stream.Close()
+		return nil, err
+	}
+	return result, nil
+}
+
+// Close stops receiving the responses
+func (s *CounterCountStream) Close() {
+	s.stream.Close()
+}
+
+// CounterCountWriter sends the responses of Counter.Count to the caller
+type CounterCountWriter struct {
+	writer *cluster.GrainStreamWriter
+}
+
+// Context is done when the caller stopped receiving the responses
+func (w *CounterCountWriter) Context() context.Context {
+	return w.writer.Context()
+}
+
+// Send sends a response to the caller
+func (w *CounterCountWriter) Send(r *CountResponse) error {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return w.writer.Send(bytes)
+}
+
+// CounterActor represents the actor structure
+type CounterActor struct {
+	inner   Counter
+	Timeout time.Duration
+	dedup   *cluster.CallDedup
+}
+
+// Receive ensures the lifecycle of the actor for the received message
+func (a *CounterActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		a.inner = xCounterFactory()
+		id := ctx.Self().Id[17:] // skip "activator/Remote$"
+		a.inner.Init(id)
+		a.dedup = cluster.NewKindCallDedup(ctx.ActorSystem(), "Counter")
+		if a.Timeout > 0 {
+			ctx.SetReceiveTimeout(a.Timeout)
+		}
+	case *actor.ReceiveTimeout:
+		a.inner.Terminate()
+		ctx.Poison(ctx.Self())
+
+	case actor.AutoReceiveMessage: // pass
+	case actor.SystemMessage: // pass
+
+	case *cluster.GrainRequest:
+		// a retried call answered already is not invoked again, see cluster.Kind.WithCallDedup
+		if resp, ok := a.dedup.Response(ctx); ok {
+			ctx.Respond(resp)
+			return
+		}
+		switch msg.MethodIndex {
+		case 0:
+			req := &EchoRequest{}
+			err := proto.Unmarshal(msg.MessageData, req)
+			if err != nil {
+				plog.Error("Echo(EchoRequest) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
+			}
+			r0, err := a.inner.Echo(req, ctx)
+			if err != nil {
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				a.dedup.Respond(ctx, resp)
+				return
+			}
+			bytes, err := proto.Marshal(r0)
+			if err != nil {
+				plog.Error("Echo(EchoRequest) proto.Marshal failed", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				a.dedup.Respond(ctx, resp)
+				return
+			}
+			resp := &cluster.GrainResponse{MessageData: bytes}
+			a.dedup.Respond(ctx, resp)
+		case 1:
+			req := &CountRequest{}
+			err := proto.Unmarshal(msg.MessageData, req)
+			if err != nil {
+				plog.Error("Count(CountRequest) proto.Unmarshal failed.", logmod.Error(err))
+				resp := &cluster.GrainErrorResponse{Err: err.Error()}
+				ctx.Respond(resp)
+				return
+			}
+			w := cluster.NewGrainStreamWriter(ctx.ActorSystem(), ctx.Sender())
+			err = a.inner.Count(req, &CounterCountWriter{writer: w}, ctx)
+			w.Close(err)
+		}
+	default:
+		a.inner.ReceiveDefault(ctx)
+	}
+}
+
+// MockCounter is a Counter calling the functions it is set up with, for testing
+type MockCounter struct {
+	InitFunc           func(id string)
+	TerminateFunc      func()
+	ReceiveDefaultFunc func(ctx actor.Context)
+	EchoFunc           func(*EchoRequest, cluster.GrainContext) (*EchoResponse, error)
+	CountFunc          func(*CountRequest, *CounterCountWriter, cluster.GrainContext) error
+}
+
+// Init calls InitFunc if it is set
+func (m *MockCounter) Init(id string) {
+	if m.InitFunc != nil {
+		m.InitFunc(id)
+	}
+}
+
+// Terminate calls TerminateFunc if it is set
+func (m *MockCounter) Terminate() {
+	if m.TerminateFunc != nil {
+		m.TerminateFunc()
+	}
+}
+
+// ReceiveDefault calls ReceiveDefaultFunc if it is set
+func (m *MockCounter) ReceiveDefault(ctx actor.Context) {
+	if m.ReceiveDefaultFunc != nil {
+		m.ReceiveDefaultFunc(ctx)
+	}
+}
+
+// Echo calls EchoFunc, it fails if it is not set
+func (m *MockCounter) Echo(r *EchoRequest, ctx cluster.GrainContext) (*EchoResponse, error) {
+	if m.EchoFunc == nil {
+		return nil, errors.New("MockCounter.EchoFunc is not set")
+	}
+	return m.EchoFunc(r, ctx)
+}
+
+// Count calls CountFunc, it fails if it is not set
+func (m *MockCounter) Count(r *CountRequest, w *CounterCountWriter, ctx cluster.GrainContext) error {
+	if m.CountFunc == nil {
+		return errors.New("MockCounter.CountFunc is not set")
+	}
+	return m.CountFunc(r, w, ctx)
+}
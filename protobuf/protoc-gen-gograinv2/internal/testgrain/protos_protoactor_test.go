@@ -0,0 +1,260 @@
+package testgrain
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/cluster"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/AsynkronIT/protoactor-go/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// membership publishes the same topology to all members of an in-process cluster
+type membership struct {
+	mutex   sync.Mutex
+	members []*cluster.Cluster
+}
+
+func (m *membership) publish() {
+	topology := make(cluster.TopologyEvent, 0, len(m.members))
+	for _, c := range m.members {
+		host, port, _ := c.ActorSystem.GetHostPort()
+		topology = append(topology, &cluster.MemberStatus{
+			MemberID: c.ActorSystem.Address(),
+			Host:     host,
+			Port:     port,
+			Kinds:    c.GetClusterKinds(),
+			Alive:    true,
+		})
+	}
+	for _, c := range m.members {
+		c.ActorSystem.EventStream.Publish(topology)
+	}
+}
+
+type provider struct {
+	membership *membership
+}
+
+func (p *provider) StartMember(c *cluster.Cluster) error {
+	p.membership.mutex.Lock()
+	defer p.membership.mutex.Unlock()
+	p.membership.members = append(p.membership.members, c)
+	p.membership.publish()
+	return nil
+}
+
+func (p *provider) StartClient(c *cluster.Cluster) error {
+	return p.StartMember(c)
+}
+
+func (p *provider) Shutdown(graceful bool) error {
+	return nil
+}
+
+func (p *provider) UpdateClusterState(state cluster.ClusterState) error {
+	return nil
+}
+
+// startMembers starts two members hosting the Counter kind, the grains of which are implemented by mock
+func startMembers(mock *MockCounter) []*cluster.Cluster {
+	return startConfiguredMembers(mock, nil)
+}
+
+// startConfiguredMembers starts the members after configure changed their Counter kind
+func startConfiguredMembers(mock *MockCounter, configure func(*cluster.Kind)) []*cluster.Cluster {
+	CounterFactory(func() Counter {
+		return mock
+	})
+	network := remotetest.NewNetwork()
+	m := &membership{}
+	members := make([]*cluster.Cluster, 2)
+	for i := range members {
+		kind := cluster.NewKind("Counter", actor.PropsFromProducer(func() actor.Actor {
+			return &CounterActor{}
+		}))
+		if configure != nil {
+			configure(kind)
+		}
+		config := cluster.Configure("test", &provider{membership: m}, remote.Configure("node", 0).WithTransport(network.Transport()), kind)
+		members[i] = cluster.New(actor.NewActorSystem(), config)
+		members[i].Start()
+	}
+	return members
+}
+
+// remoteGrain returns the id of a grain activated on the other member than the one calling it
+func remoteGrain(t *testing.T, caller *cluster.Cluster) string {
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		pid, _ := caller.Get(id, "Counter")
+		if pid != nil && pid.Address != caller.ActorSystem.Address() {
+			return id
+		}
+	}
+	t.Fatal("no grain activated on the other member")
+	return ""
+}
+
+func TestCounter_StreamsResponsesAcrossMembers(t *testing.T) {
+	members := startMembers(&MockCounter{
+		CountFunc: func(r *CountRequest, w *CounterCountWriter, ctx cluster.GrainContext) error {
+			for i := int32(1); i <= r.To; i++ {
+				if err := w.Send(&CountResponse{Number: i}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+
+	client := GetCounterGrainClient(members[0], remoteGrain(t, members[0]))
+	stream, err := client.Count(context.Background(), &CountRequest{To: 5})
+	require.NoError(t, err)
+	var numbers []int32
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		numbers = append(numbers, res.Number)
+	}
+	assert.Equal(t, []int32{1, 2, 3, 4, 5}, numbers)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestCounter_StreamReturnsMethodError(t *testing.T) {
+	members := startMembers(&MockCounter{
+		CountFunc: func(r *CountRequest, w *CounterCountWriter, ctx cluster.GrainContext) error {
+			if err := w.Send(&CountResponse{Number: 1}); err != nil {
+				return err
+			}
+			return errors.New("count failed")
+		},
+	})
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+
+	client := GetCounterGrainClient(members[0], remoteGrain(t, members[0]))
+	stream, err := client.Count(context.Background(), &CountRequest{To: 5})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), res.Number)
+	_, err = stream.Recv()
+	assert.EqualError(t, err, "count failed")
+}
+
+func TestCounter_CancellingStreamStopsMethod(t *testing.T) {
+	stopped := make(chan error, 1)
+	members := startMembers(&MockCounter{
+		CountFunc: func(r *CountRequest, w *CounterCountWriter, ctx cluster.GrainContext) error {
+			for i := int32(1); ; i++ {
+				if err := w.Send(&CountResponse{Number: i}); err != nil {
+					stopped <- err
+					return err
+				}
+				time.Sleep(time.Millisecond)
+			}
+		},
+	})
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := GetCounterGrainClient(members[0], remoteGrain(t, members[0]))
+	stream, err := client.Count(ctx, &CountRequest{})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := stream.Recv()
+		require.NoError(t, err)
+	}
+	cancel()
+	_, err = stream.Recv()
+	assert.Equal(t, context.Canceled, err)
+
+	select {
+	case err := <-stopped:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the grain method kept streaming")
+	}
+}
+
+func TestCounter_ContextCancelsUnaryCall(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	members := startMembers(&MockCounter{
+		EchoFunc: func(r *EchoRequest, ctx cluster.GrainContext) (*EchoResponse, error) {
+			if r.Message == "block" {
+				<-release
+			}
+			return &EchoResponse{Message: r.Message}, nil
+		},
+	})
+	for _, c := range members {
+		defer c.Shutdown(false)
+	}
+
+	client := GetCounterGrainClient(members[0], remoteGrain(t, members[0]))
+	res, err := client.Echo(context.Background(), &EchoRequest{Message: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.Message)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Echo(ctx, &EchoRequest{Message: "block"})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestCounter_RetryOfAnsweredCallIsDeduplicated(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		window  int
+		invoked int32
+	}{
+		{"without dedup", 0, 2},
+		{"with dedup", 10, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var invoked int32
+			members := startConfiguredMembers(&MockCounter{
+				EchoFunc: func(r *EchoRequest, ctx cluster.GrainContext) (*EchoResponse, error) {
+					// the response to the first attempt is lost, it arrives after the caller timed out
+					if atomic.AddInt32(&invoked, 1) == 1 {
+						time.Sleep(150 * time.Millisecond)
+					}
+					return &EchoResponse{Message: r.Message}, nil
+				},
+			}, func(kind *cluster.Kind) {
+				kind.WithCallDedup(tc.window)
+			})
+			for _, c := range members {
+				defer c.Shutdown(false)
+			}
+
+			client := GetCounterGrainClient(members[0], remoteGrain(t, members[0]))
+			opts := cluster.NewGrainCallOptions(members[0]).WithTimeout(100 * time.Millisecond).WithRetry(3).WithRetryAction(func(int) {})
+			res, err := client.Echo(context.Background(), &EchoRequest{Message: "hello"}, opts)
+			require.NoError(t, err)
+			assert.Equal(t, "hello", res.Message)
+			assert.Equal(t, tc.invoked, atomic.LoadInt32(&invoked))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"fmt"
+	"go/format"
 	"strings"
 	"text/template"
 
@@ -24,7 +26,12 @@ func generateCode(req *plugin.CodeGeneratorRequest, filenameSuffix string, goFmt
 
 	response := &plugin.CodeGeneratorResponse{}
 	for _, f := range req.GetProtoFile() {
-		s := generate(f)
+		s, err := generate(f, goFmt)
+		if err != nil {
+			msg := err.Error()
+			response.Error = &msg
+			return response
+		}
 		fileName := strings.Replace(f.GetName(), ".", "_", 1) + "actor.go"
 		r := &plugin.CodeGeneratorResponse_File{
 			Content: &s,
@@ -37,9 +44,16 @@ func generateCode(req *plugin.CodeGeneratorRequest, filenameSuffix string, goFmt
 	return response
 }
 
-func generate(file *google_protobuf.FileDescriptorProto) string {
+func generate(file *google_protobuf.FileDescriptorProto, goFmt bool) (string, error) {
 
 	pkg := ProtoAst(file)
+	for _, service := range pkg.Services {
+		for _, method := range service.Methods {
+			if method.InputStream {
+				return "", fmt.Errorf("%s.%s: streaming requests are not supported by grains", service.Name, method.Name)
+			}
+		}
+	}
 
 	t := template.New("grain")
 	t, _ = t.Parse(code)
@@ -48,5 +62,12 @@ func generate(file *google_protobuf.FileDescriptorProto) string {
 	t.Execute(&doc, pkg)
 	s := doc.String()
 
-	return s
+	if goFmt && s != "" {
+		formatted, err := format.Source([]byte(s))
+		if err != nil {
+			return "", err
+		}
+		s = string(formatted)
+	}
+	return s, nil
 }
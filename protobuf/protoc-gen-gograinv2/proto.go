@@ -65,8 +65,8 @@ func ProtoAst(file *google_protobuf.FileDescriptorProto) *ProtoFile {
 			m.Index = i
 			m.Name = method.GetName()
 			m.PascalName = MakeFirstLowerCase(m.Name)
-			//		m.InputStream = *method.ClientStreaming
-			//		m.OutputStream = *method.ServerStreaming
+			m.InputStream = method.GetClientStreaming()
+			m.OutputStream = method.GetServerStreaming()
 			input := removePackagePrefix(method.GetInputType(), pkg.PackageName)
 			output := removePackagePrefix(method.GetOutputType(), pkg.PackageName)
 			m.Input = messages[input]
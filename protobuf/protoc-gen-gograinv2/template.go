@@ -7,6 +7,7 @@ package {{.PackageName}}
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/AsynkronIT/protoactor-go/cluster"
 	logmod "github.com/AsynkronIT/protoactor-go/log"
 	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
 )
 
 var (
@@ -21,6 +23,7 @@ var (
 	_    = proto.Marshal
 	_    = fmt.Errorf
 	_    = math.Inf
+	_    = io.EOF
 )
 
 // SetLogLevel sets the log level.
@@ -53,7 +56,11 @@ type {{ $service.Name }} interface {
 	Terminate()
 	ReceiveDefault(ctx actor.Context)
 	{{ range $method := $service.Methods -}}
+	{{ if $method.OutputStream -}}
+	{{ $method.Name }}(*{{ $method.Input.Name }}, *{{ $service.Name }}{{ $method.Name }}Writer, cluster.GrainContext) error
+	{{ else -}}
 	{{ $method.Name }}(*{{ $method.Input.Name }}, cluster.GrainContext) (*{{ $method.Output.Name }}, error)
+	{{ end -}}
 	{{ end }}
 }
 
@@ -63,14 +70,73 @@ type {{ $service.Name }}GrainClient struct {
 	cluster *cluster.Cluster
 }
 {{ range $method := $service.Methods}}
-// {{ $method.Name }} requests the execution on to the cluster with CallOptions
-func (g *{{ $service.Name }}GrainClient) {{ $method.Name }}(r *{{ $method.Input.Name }}, opts ...*cluster.GrainCallOptions) (*{{ $method.Output.Name }}, error) {
+{{ if $method.OutputStream -}}
+// {{ $method.Name }} requests the execution on to the cluster with CallOptions, the responses are received until the method returns or ctx is done
+func (g *{{ $service.Name }}GrainClient) {{ $method.Name }}(ctx context.Context, r *{{ $method.Input.Name }}, opts ...*cluster.GrainCallOptions) (*{{ $service.Name }}{{ $method.Name }}Stream, error) {
 	bytes, err := proto.Marshal(r)
 	if err != nil {
 		return nil, err
 	}
 	reqMsg := &cluster.GrainRequest{MethodIndex: {{ $method.Index }}, MessageData: bytes}
-	resp, err := g.cluster.Call(g.ID, "{{ $service.Name }}", reqMsg, opts...)
+	stream, err := g.cluster.CallStream(ctx, g.ID, "{{ $service.Name }}", reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &{{ $service.Name }}{{ $method.Name }}Stream{stream: stream}, nil
+}
+
+// {{ $service.Name }}{{ $method.Name }}Stream receives the responses of {{ $service.Name }}.{{ $method.Name }}
+type {{ $service.Name }}{{ $method.Name }}Stream struct {
+	stream *cluster.GrainStream
+}
+
+// Recv returns the next response, or io.EOF when the method returned
+func (s *{{ $service.Name }}{{ $method.Name }}Stream) Recv() (*{{ $method.Output.Name }}, error) {
+	bytes, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	result := &{{ $method.Output.Name }}{}
+	err = proto.Unmarshal(bytes, result)
+	if err != nil {
+		s.stream.Close()
+		return nil, err
+	}
+	return result, nil
+}
+
+// Close stops receiving the responses
+func (s *{{ $service.Name }}{{ $method.Name }}Stream) Close() {
+	s.stream.Close()
+}
+
+// {{ $service.Name }}{{ $method.Name }}Writer sends the responses of {{ $service.Name }}.{{ $method.Name }} to the caller
+type {{ $service.Name }}{{ $method.Name }}Writer struct {
+	writer *cluster.GrainStreamWriter
+}
+
+// Context is done when the caller stopped receiving the responses
+func (w *{{ $service.Name }}{{ $method.Name }}Writer) Context() context.Context {
+	return w.writer.Context()
+}
+
+// Send sends a response to the caller
+func (w *{{ $service.Name }}{{ $method.Name }}Writer) Send(r *{{ $method.Output.Name }}) error {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return w.writer.Send(bytes)
+}
+{{ else -}}
+// {{ $method.Name }} requests the execution on to the cluster with CallOptions, the call is cancelled when ctx is done
+func (g *{{ $service.Name }}GrainClient) {{ $method.Name }}(ctx context.Context, r *{{ $method.Input.Name }}, opts ...*cluster.GrainCallOptions) (*{{ $method.Output.Name }}, error) {
+	bytes, err := proto.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg := &cluster.GrainRequest{MethodIndex: {{ $method.Index }}, MessageData: bytes}
+	resp, err := g.cluster.CallContext(ctx, g.ID, "{{ $service.Name }}", reqMsg, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -88,12 +154,14 @@ func (g *{{ $service.Name }}GrainClient) {{ $method.Name }}(r *{{ $method.Input.
 		return nil, errors.New("unknown response")
 	}
 }
+{{ end -}}
 {{ end }}
 
 // {{ $service.Name }}Actor represents the actor structure
 type {{ $service.Name }}Actor struct {
 	inner   {{ $service.Name }}
 	Timeout time.Duration
+	dedup   *cluster.CallDedup
 }
 
 // Receive ensures the lifecycle of the actor for the received message
@@ -103,6 +171,7 @@ func (a *{{ $service.Name }}Actor) Receive(ctx actor.Context) {
 		a.inner = x{{ $service.Name }}Factory()
 		id := ctx.Self().Id[17:] // skip "activator/Remote$"
 		a.inner.Init(id)
+		a.dedup = cluster.NewKindCallDedup(ctx.ActorSystem(), "{{ $service.Name }}")
 		if a.Timeout > 0 {
 			ctx.SetReceiveTimeout(a.Timeout)
 		}
@@ -114,6 +183,11 @@ func (a *{{ $service.Name }}Actor) Receive(ctx actor.Context) {
 	case actor.SystemMessage: // pass
 
 	case *cluster.GrainRequest:
+		// a retried call answered already is not invoked again, see cluster.Kind.WithCallDedup
+		if resp, ok := a.dedup.Response(ctx); ok {
+			ctx.Respond(resp)
+			return
+		}
 		switch msg.MethodIndex {
 		{{ range $method := $service.Methods -}}
 		case {{ $method.Index }}:
@@ -125,27 +199,87 @@ func (a *{{ $service.Name }}Actor) Receive(ctx actor.Context) {
 				ctx.Respond(resp)
 				return
 			}
+			{{ if $method.OutputStream -}}
+			w := cluster.NewGrainStreamWriter(ctx.ActorSystem(), ctx.Sender())
+			err = a.inner.{{ $method.Name }}(req, &{{ $service.Name }}{{ $method.Name }}Writer{writer: w}, ctx)
+			w.Close(err)
+			{{ else -}}
 			r0, err := a.inner.{{ $method.Name }}(req, ctx)
 			if err != nil {
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
-				ctx.Respond(resp)
+				a.dedup.Respond(ctx, resp)
 				return
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
 				plog.Error("{{ $method.Name }}({{ $method.Input.Name }}) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
-				ctx.Respond(resp)
+				a.dedup.Respond(ctx, resp)
 				return
 			}
 			resp := &cluster.GrainResponse{MessageData: bytes}
-			ctx.Respond(resp)
-		{{ end }}
+			a.dedup.Respond(ctx, resp)
+			{{ end -}}
+		{{ end -}}
 		}
 	default:
 		a.inner.ReceiveDefault(ctx)
 	}
 }
+
+// Mock{{ $service.Name }} is a {{ $service.Name }} calling the functions it is set up with, for testing
+type Mock{{ $service.Name }} struct {
+	InitFunc           func(id string)
+	TerminateFunc      func()
+	ReceiveDefaultFunc func(ctx actor.Context)
+	{{ range $method := $service.Methods -}}
+	{{ if $method.OutputStream -}}
+	{{ $method.Name }}Func func(*{{ $method.Input.Name }}, *{{ $service.Name }}{{ $method.Name }}Writer, cluster.GrainContext) error
+	{{ else -}}
+	{{ $method.Name }}Func func(*{{ $method.Input.Name }}, cluster.GrainContext) (*{{ $method.Output.Name }}, error)
+	{{ end -}}
+	{{ end }}
+}
+
+// Init calls InitFunc if it is set
+func (m *Mock{{ $service.Name }}) Init(id string) {
+	if m.InitFunc != nil {
+		m.InitFunc(id)
+	}
+}
+
+// Terminate calls TerminateFunc if it is set
+func (m *Mock{{ $service.Name }}) Terminate() {
+	if m.TerminateFunc != nil {
+		m.TerminateFunc()
+	}
+}
+
+// ReceiveDefault calls ReceiveDefaultFunc if it is set
+func (m *Mock{{ $service.Name }}) ReceiveDefault(ctx actor.Context) {
+	if m.ReceiveDefaultFunc != nil {
+		m.ReceiveDefaultFunc(ctx)
+	}
+}
+{{ range $method := $service.Methods }}
+{{ if $method.OutputStream -}}
+// {{ $method.Name }} calls {{ $method.Name }}Func, it fails if it is not set
+func (m *Mock{{ $service.Name }}) {{ $method.Name }}(r *{{ $method.Input.Name }}, w *{{ $service.Name }}{{ $method.Name }}Writer, ctx cluster.GrainContext) error {
+	if m.{{ $method.Name }}Func == nil {
+		return errors.New("Mock{{ $service.Name }}.{{ $method.Name }}Func is not set")
+	}
+	return m.{{ $method.Name }}Func(r, w, ctx)
+}
+{{ else -}}
+// {{ $method.Name }} calls {{ $method.Name }}Func, it fails if it is not set
+func (m *Mock{{ $service.Name }}) {{ $method.Name }}(r *{{ $method.Input.Name }}, ctx cluster.GrainContext) (*{{ $method.Output.Name }}, error) {
+	if m.{{ $method.Name }}Func == nil {
+		return nil, errors.New("Mock{{ $service.Name }}.{{ $method.Name }}Func is not set")
+	}
+	return m.{{ $method.Name }}Func(r, ctx)
+}
+{{ end -}}
+{{ end }}
 {{ end -}}
 {{ end -}}
 `
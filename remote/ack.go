@@ -0,0 +1,171 @@
+package remote
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// AcknowledgeHeader is the header of the messages to send with an acknowledgment when the delivery is acknowledged,
+// see Config.WithAcknowledgedDelivery. Its value does not matter
+const AcknowledgeHeader = "remote-ack"
+
+// ackWindow is the number of acknowledged ids an endpoint reader remembers per sending endpoint
+const ackWindow = 10000
+
+// pendingAck is a message sent with an acknowledgment which did not come yet
+type pendingAck struct {
+	rd       *remoteDeliver
+	attempts int
+	timer    *time.Timer
+}
+
+// acknowledgments tracks the messages sent with an acknowledgment until it comes. A message is sent again, with the
+// same id, each time it does not come within the timeout, through a new endpoint when its endpoint terminated.
+// It is a dead letter with the DeadLetterNotAcknowledged reason after max attempts
+type acknowledgments struct {
+	remote      *Remote
+	timeout     time.Duration
+	maxAttempts int
+	lastID      uint64
+
+	mu      sync.Mutex
+	pending map[uint64]*pendingAck
+	stopped bool
+}
+
+func newAcknowledgments(remote *Remote, timeout time.Duration, maxAttempts int) *acknowledgments {
+	return &acknowledgments{
+		remote:      remote,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		// the ids of the previous processes at the address may still be remembered by the endpoint readers
+		lastID:  nextEpoch(),
+		pending: make(map[uint64]*pendingAck),
+	}
+}
+
+// awaits tells whether rd is to be sent with an acknowledgment
+func (a *acknowledgments) awaits(rd *remoteDeliver) bool {
+	return a != nil && (rd.ackID != 0 || rd.header != nil && rd.header.Get(AcknowledgeHeader) != "")
+}
+
+// track gives rd its id and awaits its acknowledgment, unless it is sent again
+func (a *acknowledgments) track(rd *remoteDeliver) uint64 {
+	if rd.ackID != 0 {
+		return rd.ackID
+	}
+	rd.ackID = atomic.AddUint64(&a.lastID, 1)
+	id := rd.ackID
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stopped {
+		return id
+	}
+	a.pending[id] = &pendingAck{
+		rd:       rd,
+		attempts: 1,
+		timer:    time.AfterFunc(a.timeout, func() { a.expire(id) }),
+	}
+	return id
+}
+
+// acknowledge stops awaiting the acknowledgments of ids
+func (a *acknowledgments) acknowledge(ids []uint64) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, id := range ids {
+		if p, ok := a.pending[id]; ok {
+			p.timer.Stop()
+			delete(a.pending, id)
+		}
+	}
+}
+
+// expire sends the message of id again, or gives up on it
+func (a *acknowledgments) expire(id uint64) {
+	a.mu.Lock()
+	p, ok := a.pending[id]
+	if !ok {
+		a.mu.Unlock()
+		return
+	}
+	if p.attempts >= a.maxAttempts {
+		delete(a.pending, id)
+		a.mu.Unlock()
+		plog.Info("EndpointWriter gave up on unacknowledged message", log.Stringer("target", p.rd.target),
+			log.TypeOf("type", p.rd.message), log.Int("attempts", p.attempts))
+		a.deadLetter(p.rd)
+		return
+	}
+	p.attempts++
+	p.timer.Reset(a.timeout)
+	a.mu.Unlock()
+
+	a.remote.edpManager.remoteDeliver(p.rd)
+}
+
+// stop gives up on the pending messages
+func (a *acknowledgments) stop() {
+	a.mu.Lock()
+	pending := a.pending
+	a.pending = make(map[uint64]*pendingAck)
+	a.stopped = true
+	a.mu.Unlock()
+
+	for _, p := range pending {
+		p.timer.Stop()
+		a.deadLetter(p.rd)
+	}
+}
+
+func (a *acknowledgments) deadLetter(rd *remoteDeliver) {
+	a.remote.actorSystem.DeadLetter.SendUserMessageWithReason(rd.target, rd.envelope(), actor.DeadLetterNotAcknowledged)
+}
+
+// receivedAcks remembers the last ids acknowledged by an endpoint reader to each sending endpoint, so that the
+// messages sent again are acknowledged again without being delivered twice
+type receivedAcks struct {
+	mu        sync.Mutex
+	addresses map[string]*ackedIDs
+}
+
+type ackedIDs struct {
+	ids   map[uint64]struct{}
+	order []uint64
+	next  int
+}
+
+func newReceivedAcks() *receivedAcks {
+	return &receivedAcks{addresses: make(map[string]*ackedIDs)}
+}
+
+// add remembers id as acknowledged to address, it returns false when it already was
+func (r *receivedAcks) add(address string, id uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	acked, ok := r.addresses[address]
+	if !ok {
+		acked = &ackedIDs{ids: make(map[uint64]struct{})}
+		r.addresses[address] = acked
+	}
+	if _, ok := acked.ids[id]; ok {
+		return false
+	}
+	if len(acked.order) < ackWindow {
+		acked.order = append(acked.order, id)
+	} else {
+		delete(acked.ids, acked.order[acked.next])
+		acked.order[acked.next] = id
+		acked.next = (acked.next + 1) % ackWindow
+	}
+	acked.ids[id] = struct{}{}
+	return true
+}
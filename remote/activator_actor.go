@@ -3,26 +3,102 @@ package remote
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/log"
+	"golang.org/x/net/context"
 )
 
 // Register a known actor props by name
 func (r *Remote) Register(kind string, props *actor.Props) {
-	r.nameLookup[kind] = *props
+	r.RegisterKind(NewKind(kind, props))
+}
+
+// RegisterKind registers a known actor kind together with its activation options. Once the remote is started, the
+// connected nodes are told the kinds changed with a KindsChanged, which is also published on the event stream
+func (r *Remote) RegisterKind(kind *Kind) {
+	r.kindsMu.Lock()
+	r.kinds[kind.Kind] = newActivatedKind(kind)
+	r.kindsMu.Unlock()
+	r.kindsChanged()
+}
+
+// UnregisterKind stops the activations of kind, the actors activated keep running. It returns once the spawn
+// requests in flight are answered, and tells the connected nodes like RegisterKind
+func (r *Remote) UnregisterKind(kind string) {
+	r.kindsMu.Lock()
+	_, ok := r.kinds[kind]
+	delete(r.kinds, kind)
+	r.kindsMu.Unlock()
+	if ok {
+		r.kindsChanged()
+	}
 }
 
 // GetKnownKinds returns a slice of known actor "Kinds"
 func (r *Remote) GetKnownKinds() []string {
-	keys := make([]string, 0, len(r.nameLookup))
-	for k := range r.nameLookup {
+	r.kindsMu.RLock()
+	defer r.kindsMu.RUnlock()
+	keys := make([]string, 0, len(r.kinds))
+	for k := range r.kinds {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return keys
 }
 
+// kindsChanged tells the connected nodes and the local subscribers the kinds activated
+func (r *Remote) kindsChanged() {
+	if r.edpManager == nil {
+		// not started
+		return
+	}
+	msg := &KindsChanged{Address: r.actorSystem.Address(), Kinds: r.GetKnownKinds()}
+	r.actorSystem.EventStream.Publish(msg)
+	for _, address := range r.edpManager.addresses() {
+		r.actorSystem.Root.Send(r.ActivatorForAddress(address), msg)
+	}
+}
+
+// StopActivations makes the activator answer further spawn requests with ResponseStatusCodeUNAVAILABLE,
+// e.g. while the node drains its actors before shutting down
+func (r *Remote) StopActivations() {
+	atomic.StoreInt32(&r.activationsStopped, 1)
+}
+
+// ListKinds asks the node at address for the kinds it can activate
+func (r *Remote) ListKinds(address string, timeout time.Duration) ([]*KindInfo, error) {
+	conn, err := r.transport.Dial(address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	res, err := conn.ListKinds(ctx, &ListKindsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Kinds, nil
+}
+
+func (r *Remote) kindInfos() []*KindInfo {
+	r.kindsMu.RLock()
+	defer r.kindsMu.RUnlock()
+	infos := make([]*KindInfo, 0, len(r.kinds))
+	for _, ak := range r.kinds {
+		infos = append(infos, ak.info())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
 type activator struct {
 	remote *Remote
 }
@@ -90,16 +166,37 @@ func (a *activator) Receive(context actor.Context) {
 		plog.Debug("Started Activator")
 	case *Ping:
 		context.Respond(&Pong{})
+	case *KindsChanged:
+		context.ActorSystem().EventStream.Publish(msg)
 	case *ActorPidRequest:
-		props, exist := a.remote.nameLookup[msg.Kind]
+		// the kind is not unregistered while it is activated
+		a.remote.kindsMu.RLock()
+		defer a.remote.kindsMu.RUnlock()
+		ak, exist := a.remote.kinds[msg.Kind]
 
-		// if props not exist, return error and panic
+		// the kind may be unregistered, the activator keeps its activations
 		if !exist {
+			plog.Error("Activator got a request for an unknown kind", log.String("kind", msg.Kind))
 			response := &ActorPidResponse{
 				StatusCode: ResponseStatusCodeERROR.ToInt32(),
 			}
 			context.Respond(response)
-			panic(fmt.Errorf("no Props found for kind %s", msg.Kind))
+			return
+		}
+
+		if atomic.LoadInt32(&a.remote.activationsStopped) == 1 {
+			context.Respond(&ActorPidResponse{
+				StatusCode: ResponseStatusCodeUNAVAILABLE.ToInt32(),
+			})
+			return
+		}
+
+		if !ak.tryActivate() {
+			plog.Info("Activator reached activation limit", log.String("kind", msg.Kind), log.Int("max", ak.kind.MaxActivations))
+			context.Respond(&ActorPidResponse{
+				StatusCode: ResponseStatusCodeACTIVATIONLIMITREACHED.ToInt32(),
+			})
+			return
 		}
 
 		name := msg.Name
@@ -109,9 +206,13 @@ func (a *activator) Receive(context actor.Context) {
 			name = context.ActorSystem().ProcessRegistry.NextId()
 		}
 
-		pid, err := context.SpawnNamed(&props, "Remote$"+name)
+		pid, err := context.SpawnNamed(ak.props, "Remote$"+name)
+		if err != nil {
+			ak.deactivate()
+		}
 
 		if err == nil {
+			a.remote.activations.Store(pid.Id, ak)
 			response := &ActorPidResponse{Pid: pid}
 			context.Respond(response)
 		} else if err == actor.ErrNameExists {
@@ -120,6 +221,12 @@ func (a *activator) Receive(context actor.Context) {
 				StatusCode: ResponseStatusCodePROCESSNAMEALREADYEXIST.ToInt32(),
 			}
 			context.Respond(response)
+		} else if actor.IsQuotaExceeded(err) {
+			// the activator or its node is over its quota, the spawn may succeed on another node
+			plog.Info("Activator refused spawn over quota", log.String("kind", msg.Kind), log.Error(err))
+			context.Respond(&ActorPidResponse{
+				StatusCode: ResponseStatusCodeQUOTAEXCEEDED.ToInt32(),
+			})
 		} else if aErr, ok := err.(*ActivatorError); ok {
 			response := &ActorPidResponse{
 				StatusCode: aErr.Code,
@@ -135,6 +242,11 @@ func (a *activator) Receive(context actor.Context) {
 			context.Respond(response)
 			panic(err)
 		}
+	case *actor.Terminated:
+		if ak, ok := a.remote.activations.Load(msg.Who.Id); ok {
+			a.remote.activations.Delete(msg.Who.Id)
+			ak.(*activatedKind).deactivate()
+		}
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		// ignore
 	default:
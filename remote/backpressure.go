@@ -0,0 +1,39 @@
+package remote
+
+import "time"
+
+// BackpressurePolicy decides what happens to messages sent to an endpoint
+// whose queue has reached the high watermark.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock blocks the sender until the queue drains below the watermark
+	// or the block timeout expires, in which case the message is dead lettered.
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDrop dead letters new messages while the queue is full.
+	BackpressureDrop
+	// BackpressureNotify keeps queueing and publishes an EndpointBackpressureEvent
+	// so that the application can shed load.
+	BackpressureNotify
+)
+
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BackpressureBlock:
+		return "Block"
+	case BackpressureDrop:
+		return "Drop"
+	case BackpressureNotify:
+		return "Notify"
+	}
+	return "Unknown"
+}
+
+// EndpointBackpressureEvent is published when the queue of an endpoint using
+// BackpressureNotify crosses the high watermark.
+type EndpointBackpressureEvent struct {
+	Address     string
+	QueueLength int
+}
+
+const defaultBackpressureBlockTimeout = 100 * time.Millisecond
@@ -2,7 +2,8 @@ package remote
 
 import (
 	"fmt"
-	"github.com/AsynkronIT/protoactor-go/actor"
+	"time"
+
 	"google.golang.org/grpc"
 )
 
@@ -14,7 +15,10 @@ func defaultRemoteConfig() Config {
 		EndpointManagerBatchSize: 1000,
 		EndpointWriterQueueSize:  1000000,
 		EndpointManagerQueueSize: 1000000,
-		Kinds:                    make(map[string]*actor.Props),
+		Kinds:                    make(map[string]*Kind),
+		BackpressureBlockTimeout: defaultBackpressureBlockTimeout,
+		ProtocolVersion:          ProtocolVersion,
+		Capabilities:             DefaultCapabilities,
 	}
 }
 
@@ -53,6 +57,69 @@ func (rc Config) WithCallOptions(options ...grpc.CallOption) Config {
 	return rc
 }
 
+// WithEndpointBackpressure bounds the number of user messages queued per endpoint to highWatermark
+// and applies policy to messages sent while the queue is full. System messages are never dropped.
+// A highWatermark of zero disables the bound.
+func (rc Config) WithEndpointBackpressure(highWatermark int, policy BackpressurePolicy) Config {
+	rc.EndpointWriterHighWatermark = highWatermark
+	rc.BackpressurePolicy = policy
+	return rc
+}
+
+// WithBackpressureBlockTimeout sets how long BackpressureBlock blocks a sender before dead lettering the message.
+func (rc Config) WithBackpressureBlockTimeout(timeout time.Duration) Config {
+	rc.BackpressureBlockTimeout = timeout
+	return rc
+}
+
+// WithTransport replaces the default gRPC transport.
+// The gRPC dial, server and call options are ignored when a custom transport is used.
+func (rc Config) WithTransport(transport Transport) Config {
+	rc.Transport = transport
+	return rc
+}
+
+// WithProtocolVersion sets the protocol version advertised in the handshake,
+// and the lowest version accepted from other endpoints.
+func (rc Config) WithProtocolVersion(version int32, minVersion int32) Config {
+	rc.ProtocolVersion = version
+	rc.MinProtocolVersion = minVersion
+	return rc
+}
+
+// WithCapabilities sets the capabilities advertised in the handshake.
+func (rc Config) WithCapabilities(capabilities Capability) Config {
+	rc.Capabilities = capabilities
+	return rc
+}
+
+// WithOrderedDelivery delivers the messages from each sender to each target in the order they were sent,
+// across the reconnects of the endpoints. The messages received ahead of a missing one are held, up to window per
+// pair of sender and target, beyond which the missing ones are skipped. It costs a sequence per pair on both sides,
+// and the sequences are only enforced between endpoints which both enable it
+func (rc Config) WithOrderedDelivery(window int) Config {
+	rc.OrderedDeliveryWindow = window
+	return rc
+}
+
+// WithMaxMessageSize rejects the messages received whose payload is larger than size bytes, before they are
+// deserialized: they go to the dead letters as TooLargeMessage with the DeadLetterTooLarge reason, and the requests
+// fail with actor.ErrMessageTooLarge. The batches received are bounded by the max receive size of the transport
+func (rc Config) WithMaxMessageSize(size int) Config {
+	rc.MaxMessageSize = size
+	return rc
+}
+
+// WithAcknowledgedDelivery acknowledges the messages sent with the AcknowledgeHeader header once the endpoint
+// reader received them, and enqueued them to the mailbox of their target. A message is sent again, across the
+// reconnects of its endpoint, each time its acknowledgment does not come within timeout, and the endpoint readers
+// deliver it once. After maxAttempts it is a dead letter with the actor.DeadLetterNotAcknowledged reason
+func (rc Config) WithAcknowledgedDelivery(timeout time.Duration, maxAttempts int) Config {
+	rc.AckTimeout = timeout
+	rc.AckMaxAttempts = maxAttempts
+	return rc
+}
+
 func (rc Config) WithAdvertisedHost(address string) Config {
 	rc.AdvertisedHost = address
 	return rc
@@ -68,33 +135,32 @@ func Configure(host string, port int, kinds ...*Kind) Config {
 	c.Port = port
 
 	for _, kind := range kinds {
-		c.Kinds[kind.Kind] = kind.Props
+		c.Kinds[kind.Kind] = kind
 	}
 	return c
 }
 
 type Config struct {
-	Host                     string
-	Port                     int
-	AdvertisedHost           string
-	ServerOptions            []grpc.ServerOption
-	CallOptions              []grpc.CallOption
-	DialOptions              []grpc.DialOption
-	EndpointWriterBatchSize  int
-	EndpointWriterQueueSize  int
-	EndpointManagerBatchSize int
-	EndpointManagerQueueSize int
-	Kinds                    map[string]*actor.Props
-}
-
-type Kind struct {
-	Kind  string
-	Props *actor.Props
-}
-
-func NewKind(kind string, props *actor.Props) *Kind {
-	return &Kind{
-		Kind:  kind,
-		Props: props,
-	}
+	Host                        string
+	Port                        int
+	AdvertisedHost              string
+	ServerOptions               []grpc.ServerOption
+	CallOptions                 []grpc.CallOption
+	DialOptions                 []grpc.DialOption
+	EndpointWriterBatchSize     int
+	EndpointWriterQueueSize     int
+	EndpointManagerBatchSize    int
+	EndpointManagerQueueSize    int
+	EndpointWriterHighWatermark int
+	BackpressurePolicy          BackpressurePolicy
+	BackpressureBlockTimeout    time.Duration
+	Kinds                       map[string]*Kind
+	Transport                   Transport
+	ProtocolVersion             int32
+	MinProtocolVersion          int32
+	Capabilities                Capability
+	OrderedDeliveryWindow       int
+	MaxMessageSize              int
+	AckTimeout                  time.Duration
+	AckMaxAttempts              int
 }
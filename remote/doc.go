@@ -1,4 +1,16 @@
 /*
 Package remote provides access to actors across a network or other I/O connection.
+
+# Ordering
+
+The messages from a sender to a target are delivered in the order they were sent as long as the connection
+between their endpoints holds. When an endpoint reconnects, the messages sent before the reconnect may still be
+in flight while the ones sent after are delivered, and without more care they interleave.
+
+Config.WithOrderedDelivery enforces the order across reconnects: the endpoint writer stamps the messages with a
+sequence per pair of sender and target, and the endpoint reader delivers them in their order, holding those received
+ahead of a missing one. When the endpoint is terminated on the sending side its sequences start over with a new epoch,
+the receiving side publishes a SequenceReset on its event stream and the messages of the previous epoch received
+afterwards are dead letters. The order is guaranteed, the delivery is not.
 */
 package remote
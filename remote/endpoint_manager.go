@@ -97,6 +97,20 @@ func (em *endpointManager) stop() {
 	plog.Info("Stopped EndpointManager")
 }
 
+// addresses returns the addresses of the nodes connected
+func (em *endpointManager) addresses() []string {
+	connections := em.connections
+	if connections == nil {
+		return nil
+	}
+	var addresses []string
+	connections.Range(func(key, value interface{}) bool {
+		addresses = append(addresses, key.(string))
+		return true
+	})
+	return addresses
+}
+
 func (em *endpointManager) startActivator() {
 	p := newActivatorActor(em.remote)
 	props := actor.PropsFromProducer(p).WithGuardian(actor.RestartingSupervisorStrategy())
@@ -170,12 +184,8 @@ func (em *endpointManager) remoteUnwatch(msg *remoteUnwatch) {
 
 func (em *endpointManager) remoteDeliver(msg *remoteDeliver) {
 	if em.stopped {
-		// send to deadletter
-		em.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
-			PID:     msg.target,
-			Message: msg.message,
-			Sender:  msg.sender,
-		})
+		// send to deadletter, failing the future awaiting the response
+		em.remote.actorSystem.DeadLetter.SendUserMessageWithReason(msg.target, msg.envelope(), actor.DeadLetterUndeliverable)
 		return
 	}
 	address := msg.target.Address
@@ -211,6 +221,8 @@ func (em *endpointManager) removeEndpoint(msg *EndpointTerminatedEvent) {
 		le := v.(*endpointLazy)
 		if atomic.CompareAndSwapUint32(&le.unloaded, 0, 1) {
 			em.connections.Delete(msg.Address)
+			em.remote.protocols.Delete(msg.Address)
+			em.remote.resetSequences(msg.Address)
 			ep := le.valueFunc()
 			em.remote.actorSystem.Root.Send(ep.watcher, msg)
 			em.remote.actorSystem.Root.Send(ep.writer, msg)
@@ -245,7 +257,7 @@ func (state *endpointSupervisor) HandleFailure(actorSystem *actor.ActorSystem, s
 func (state *endpointSupervisor) spawnEndpointWriter(remote *Remote, address string, ctx actor.Context) *actor.PID {
 	props := actor.
 		PropsFromProducer(endpointWriterProducer(remote, address, remote.config)).
-		WithMailbox(endpointWriterMailboxProducer(remote.config.EndpointWriterBatchSize, remote.config.EndpointWriterQueueSize))
+		WithMailbox(endpointWriterMailboxProducer(remote, address))
 	pid := ctx.Spawn(props)
 	return pid
 }
@@ -2,6 +2,7 @@ package remote
 
 import (
 	io "io"
+	"sync"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/log"
@@ -13,25 +14,52 @@ import (
 type endpointReader struct {
 	suspended bool
 	remote    *Remote
+	sequences *sequences // nil unless the delivery is ordered
+	acked     *receivedAcks
+	started   chan struct{}
 }
 
 func newEndpointReader(r *Remote) *endpointReader {
-	return &endpointReader{
-		remote: r,
+	reader := &endpointReader{
+		remote:  r,
+		acked:   newReceivedAcks(),
+		started: make(chan struct{}),
 	}
+	if r.config.OrderedDeliveryWindow > 0 {
+		reader.sequences = newSequences(r, r.config.OrderedDeliveryWindow)
+	}
+	return reader
+}
+
+// start lets the reader serve the connections, the transport accepts them as soon as it listens
+func (s *endpointReader) start() {
+	close(s.started)
 }
 
 func (s *endpointReader) Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error) {
+	<-s.started
 	if s.suspended {
 		return nil, status.Error(codes.Canceled, "Suspended")
 	}
 
-	return &ConnectResponse{DefaultSerializerId: DefaultSerializerID}, nil
+	res := s.remote.config.accept(req)
+	if res.RefusalReason != "" {
+		plog.Info("EndpointReader refused handshake", log.String("reason", res.RefusalReason))
+	}
+	return res, nil
+}
+
+func (s *endpointReader) ListKinds(ctx context.Context, req *ListKindsRequest) (*ListKindsResponse, error) {
+	<-s.started
+	return &ListKindsResponse{Kinds: s.remote.kindInfos()}, nil
 }
 
-func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
+func (s *endpointReader) Receive(stream TransportServerStream) error {
+	<-s.started
 	disconnectChan := make(chan bool, 1)
 	s.remote.edpManager.endpointReaderConnections.Store(stream, disconnectChan)
+	// the acknowledgments and the leaving notice are sent on the stream concurrently
+	var sendMu sync.Mutex
 	defer func() {
 		close(disconnectChan)
 	}()
@@ -40,7 +68,9 @@ func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
 		// endpointReader sends false
 		if <-disconnectChan {
 			plog.Debug("EndpointReader is telling to remote that it's leaving")
-			err := stream.SendMsg(&Unit{})
+			sendMu.Lock()
+			err := stream.Send(&Unit{})
+			sendMu.Unlock()
 			if err != nil {
 				plog.Error("EndpointReader failed to send disconnection message", log.Error(err))
 			}
@@ -63,6 +93,10 @@ func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
 		} else if s.suspended {
 			// We read all messages ignoring them to gracefully end the request
 			continue
+		} else if reason := s.remote.config.refusal(batch.ProtocolVersion); reason != "" {
+			// the streams opened without a handshake, or before the endpoint was refused
+			plog.Info("EndpointReader refused batch", log.String("address", batch.SenderAddress), log.String("reason", reason))
+			return status.Error(codes.FailedPrecondition, reason)
 		}
 
 		// only grow pid lookup if needed
@@ -73,41 +107,104 @@ func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
 		for i := 0; i < len(batch.TargetNames); i++ {
 			targets[i] = s.remote.actorSystem.NewLocalPID(batch.TargetNames[i])
 		}
+		s.remote.actorSystem.EventStream.Publish(&EndpointBatchReceivedEvent{
+			Messages: len(batch.Envelopes),
+			Bytes:    payloadSize(batch.Envelopes),
+		})
 
+		var acks []uint64
 		for _, envelope := range batch.Envelopes {
 			pid := targets[envelope.Target]
+			duplicate := false
+			if envelope.AckId != 0 {
+				// acknowledged once enqueued, the target is gone otherwise
+				if _, ok := s.remote.actorSystem.ProcessRegistry.GetLocal(pid.Id); ok {
+					acks = append(acks, envelope.AckId)
+					duplicate = !s.acked.add(batch.SenderAddress, envelope.AckId)
+				}
+			}
+			if limit := s.remote.config.MaxMessageSize; limit > 0 && len(envelope.MessageData) > limit {
+				s.receiveTooLarge(batch, pid, envelope)
+				continue
+			}
 			message, err := Deserialize(envelope.MessageData, batch.TypeNames[envelope.TypeId], envelope.SerializerId)
 			if err != nil {
 				plog.Debug("EndpointReader failed to deserialize", log.Error(err))
 				return err
 			}
-			// if message is system message send it as sysmsg instead of usermsg
+			if s.sequences != nil && envelope.Sequence != 0 {
+				envelope := envelope
+				// a duplicate still takes its place in the sequence, which it was sent again with
+				s.sequences.receive(batch.SenderAddress, batch.SequenceEpoch, envelope.Sequence, envelope.Sender, pid, message, func() {
+					if !duplicate {
+						s.deliver(pid, envelope, message)
+					}
+				})
+				continue
+			}
+			if !duplicate {
+				s.deliver(pid, envelope, message)
+			}
+		}
+		if len(acks) > 0 {
+			sendMu.Lock()
+			err := stream.Send(&Unit{Acks: acks})
+			sendMu.Unlock()
+			if err != nil {
+				plog.Info("EndpointReader failed to acknowledge", log.Error(err))
+			}
+		}
+	}
+}
 
-			sender := envelope.Sender
+// receiveTooLarge rejects a message larger than the max message size, in the order of its sequence
+func (s *endpointReader) receiveTooLarge(batch *MessageBatch, pid *actor.PID, envelope *MessageEnvelope) {
+	message := &TooLargeMessage{TypeName: batch.TypeNames[envelope.TypeId], Size: len(envelope.MessageData)}
+	reject := func() {
+		s.remote.actorSystem.DeadLetter.SendUserMessageWithReason(pid, &actor.MessageEnvelope{Message: message, Sender: envelope.Sender}, actor.DeadLetterTooLarge)
+		if envelope.Sender != nil {
+			s.remote.actorSystem.Root.Send(envelope.Sender, actor.NewErrorResponse(actor.ErrMessageTooLarge))
+		}
+	}
+	if s.sequences != nil && envelope.Sequence != 0 {
+		s.sequences.receive(batch.SenderAddress, batch.SequenceEpoch, envelope.Sequence, envelope.Sender, pid, message, reject)
+		return
+	}
+	reject()
+}
 
-			switch msg := message.(type) {
-			case *actor.Terminated:
-				rt := &remoteTerminate{
-					Watchee: msg.Who,
-					Watcher: pid,
-				}
-				s.remote.edpManager.remoteTerminate(rt)
-			case actor.SystemMessage:
-				ref, _ := s.remote.actorSystem.ProcessRegistry.GetLocal(pid.Id)
-				ref.SendSystemMessage(pid, msg)
-			default:
-				var header map[string]string
-				if envelope.MessageHeader != nil {
-					header = envelope.MessageHeader.HeaderData
-				}
-				localEnvelope := &actor.MessageEnvelope{
-					Header:  header,
-					Message: message,
-					Sender:  sender,
-				}
-				s.remote.actorSystem.Root.Send(pid, localEnvelope)
-			}
+// TooLargeMessage stands for a message received larger than the max message size, see Config.WithMaxMessageSize
+type TooLargeMessage struct {
+	TypeName string
+	Size     int
+}
+
+// deliver delivers a message received from a remote endpoint to its local target
+func (s *endpointReader) deliver(pid *actor.PID, envelope *MessageEnvelope, message interface{}) {
+	// if message is system message send it as sysmsg instead of usermsg
+	sender := envelope.Sender
+
+	switch msg := message.(type) {
+	case *actor.Terminated:
+		rt := &remoteTerminate{
+			Watchee: msg.Who,
+			Watcher: pid,
+		}
+		s.remote.edpManager.remoteTerminate(rt)
+	case actor.SystemMessage:
+		ref, _ := s.remote.actorSystem.ProcessRegistry.GetLocal(pid.Id)
+		ref.SendSystemMessage(pid, msg)
+	default:
+		var header map[string]string
+		if envelope.MessageHeader != nil {
+			header = envelope.MessageHeader.HeaderData
+		}
+		localEnvelope := &actor.MessageEnvelope{
+			Header:  header,
+			Message: message,
+			Sender:  sender,
 		}
+		s.remote.actorSystem.Root.Send(pid, localEnvelope)
 	}
 }
 
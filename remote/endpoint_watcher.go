@@ -70,6 +70,7 @@ func (state *endpointWatcher) connected(ctx actor.Context) {
 					terminated := &actor.Terminated{
 						Who:               pid,
 						AddressTerminated: true,
+						Why:               actor.TerminatedReason_AddressTerminated,
 					}
 
 					watcher := state.remote.actorSystem.NewLocalPID(id)
@@ -134,6 +135,7 @@ func (state *endpointWatcher) terminated(ctx actor.Context) {
 			terminated := &actor.Terminated{
 				Who:               msg.Watchee,
 				AddressTerminated: true,
+				Why:               actor.TerminatedReason_AddressTerminated,
 			}
 			// send the address Terminated event to the Watcher
 			ref.SendSystemMessage(msg.Watcher, terminated)
@@ -1,13 +1,13 @@
 package remote
 
 import (
+	"fmt"
 	io "io"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/log"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 )
 
 func endpointWriterProducer(remote *Remote, address string, config *Config) actor.Producer {
@@ -23,14 +23,25 @@ func endpointWriterProducer(remote *Remote, address string, config *Config) acto
 type endpointWriter struct {
 	config              *Config
 	address             string
-	conn                *grpc.ClientConn
-	stream              Remoting_ReceiveClient
+	conn                TransportConnection
+	stream              TransportClientStream
 	defaultSerializerId int32
 	remote              *Remote
 }
 
 func (state *endpointWriter) initialize() {
 	err := state.initializeInternal()
+	if herr, ok := err.(*handshakeError); ok {
+		plog.Error("EndpointWriter handshake failed", log.String("address", state.address), log.Error(err))
+		state.remote.actorSystem.EventStream.Publish(&EndpointHandshakeFailedEvent{
+			Address:         state.address,
+			Reason:          herr.reason,
+			ProtocolVersion: herr.remoteVersion,
+		})
+		// retrying will not help, drop the endpoint so that a later message starts over
+		state.remote.actorSystem.EventStream.Publish(&EndpointTerminatedEvent{Address: state.address})
+		return
+	}
 	if err != nil {
 		plog.Error("EndpointWriter failed to connect", log.String("address", state.address), log.Error(err))
 		// Wait 2 seconds to restart and retry
@@ -42,29 +53,37 @@ func (state *endpointWriter) initialize() {
 
 func (state *endpointWriter) initializeInternal() error {
 	plog.Info("Started EndpointWriter. connecting", log.String("address", state.address))
-	conn, err := grpc.Dial(state.address, state.config.DialOptions...)
+	conn, err := state.remote.transport.Dial(state.address)
 	if err != nil {
 		plog.Info("EndpointWriter connect failed", log.String("address", state.address), log.Error(err))
 		return err
 	}
 	state.conn = conn
-	c := NewRemotingClient(conn)
-	resp, err := c.Connect(context.Background(), &ConnectRequest{})
+	resp, err := conn.Connect(context.Background(), state.config.connectRequest())
 	if err != nil {
 		plog.Info("EndpointWriter connect failed", log.String("address", state.address), log.Error(err))
 		return err
 	}
+	protocol, err := state.config.negotiate(state.address, resp)
+	if err != nil {
+		return err
+	}
 	state.defaultSerializerId = resp.DefaultSerializerId
+	state.remote.protocols.Store(state.address, protocol)
 
 	//	log.Printf("Getting stream from address %v", state.address)
-	stream, err := c.Receive(context.Background(), state.config.CallOptions...)
+	stream, err := conn.Receive(context.Background())
 	if err != nil {
 		plog.Info("EndpointWriter connect failed", log.String("address", state.address), log.Error(err))
 		return err
 	}
 	go func() {
 		for {
-			_, err := stream.Recv()
+			unit, err := stream.Recv()
+			if err == nil && len(unit.Acks) > 0 {
+				state.remote.acks.acknowledge(unit.Acks)
+				continue
+			}
 			if err == io.EOF {
 				plog.Debug("EndpointWriter stream completed", log.String("address", state.address))
 				break
@@ -96,7 +115,8 @@ func (state *endpointWriter) initializeInternal() error {
 }
 
 func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context) {
-	envelopes := make([]*MessageEnvelope, len(msg))
+	envelopes := make([]*MessageEnvelope, 0, len(msg))
+	rds := make([]*remoteDeliver, 0, len(msg))
 
 	// type name uniqueness map name string to type index
 	typeNames := make(map[string]int32)
@@ -107,7 +127,7 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 	var typeID int32
 	var targetID int32
 	var serializerID int32
-	for i, tmp := range msg {
+	for _, tmp := range msg {
 
 		switch unwrapped := tmp.(type) {
 		case *EndpointTerminatedEvent, EndpointTerminatedEvent:
@@ -131,33 +151,73 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 
 		bytes, typeName, err := Serialize(rd.message, serializerID)
 		if err != nil {
-			panic(err)
+			state.undeliverable(rd, err)
+			continue
 		}
 		typeID, typeNamesArr = addToLookup(typeNames, typeName, typeNamesArr)
 		targetID, targetNamesArr = addToLookup(targetNames, rd.target.Id, targetNamesArr)
 
-		envelopes[i] = &MessageEnvelope{
+		var ackID uint64
+		if state.remote.acks.awaits(rd) {
+			ackID = state.remote.acks.track(rd)
+		}
+
+		rds = append(rds, rd)
+		envelopes = append(envelopes, &MessageEnvelope{
 			MessageHeader: header,
 			MessageData:   bytes,
 			Sender:        rd.sender,
 			Target:        targetID,
 			TypeId:        typeID,
 			SerializerId:  serializerID,
-		}
+			AckId:         ackID,
+		})
+	}
+	if len(envelopes) == 0 {
+		return
 	}
 
 	batch := &MessageBatch{
-		TypeNames:   typeNamesArr,
-		TargetNames: targetNamesArr,
-		Envelopes:   envelopes,
+		TypeNames:       typeNamesArr,
+		TargetNames:     targetNamesArr,
+		Envelopes:       envelopes,
+		ProtocolVersion: state.config.ProtocolVersion,
+	}
+	if state.remote.acks != nil {
+		// the acknowledged ids are unique per sending endpoint
+		batch.SenderAddress = state.remote.actorSystem.Address()
+	}
+	send := func() error {
+		return state.stream.Send(batch)
+	}
+	var err error
+	if state.config.OrderedDeliveryWindow > 0 {
+		sequencer := state.remote.sequencer(state.address)
+		batch.SenderAddress = state.remote.actorSystem.Address()
+		batch.SequenceEpoch = sequencer.epoch
+		err = sequencer.stamp(rds, envelopes, send)
+	} else {
+		err = send()
 	}
-	err := state.stream.Send(batch)
 
 	if err != nil {
 		ctx.Stash()
 		plog.Debug("gRPC Failed to send", log.String("address", state.address), log.Error(err))
 		panic("restart it")
 	}
+	state.remote.actorSystem.EventStream.Publish(&EndpointBatchSentEvent{
+		Address:  state.address,
+		Messages: len(envelopes),
+		Bytes:    payloadSize(envelopes),
+	})
+}
+
+func payloadSize(envelopes []*MessageEnvelope) int {
+	size := 0
+	for _, envelope := range envelopes {
+		size += len(envelope.MessageData)
+	}
+	return size
 }
 
 func addToLookup(m map[string]int32, name string, a []string) (int32, []string) {
@@ -171,27 +231,60 @@ func addToLookup(m map[string]int32, name string, a []string) (int32, []string)
 	return id, a
 }
 
+// undeliverable fails the message which cannot be serialized, rather than the endpoint: the future awaiting
+// its response fails with the error, and it is published as a dead letter
+func (state *endpointWriter) undeliverable(rd *remoteDeliver, err error) {
+	err = fmt.Errorf("remote: cannot send %T to %v: %w", rd.message, rd.target, err)
+	plog.Error("EndpointWriter failed to serialize message", log.String("address", state.address), log.Error(err))
+	system := state.remote.actorSystem
+	if future, ok := actor.FutureOf(system, rd.sender); ok {
+		future.Fail(err)
+	}
+	system.SystemEventStream.Publish(&actor.DeadLetterEvent{
+		PID:     rd.target,
+		Message: rd.message,
+		Sender:  rd.sender,
+	})
+}
+
+// deadLetter dead letters the messages that could not be sent because the endpoint was never connected, failing the
+// futures awaiting their responses
+func (state *endpointWriter) deadLetter(msg []interface{}, ctx actor.Context) {
+	for _, tmp := range msg {
+		switch m := tmp.(type) {
+		case *EndpointTerminatedEvent:
+			ctx.Stop(ctx.Self())
+			return
+		case *remoteDeliver:
+			state.remote.actorSystem.DeadLetter.SendUserMessageWithReason(m.target, m.envelope(), actor.DeadLetterUndeliverable)
+		}
+	}
+}
+
+func (state *endpointWriter) closeStream() {
+	if state.stream != nil {
+		err := state.stream.CloseSend()
+		if err != nil {
+			plog.Error("EndpointWriter error when closing the stream", log.Error(err))
+		}
+	}
+}
+
 func (state *endpointWriter) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 	case *actor.Started:
 		state.initialize()
 	case *actor.Stopped:
-		if state.stream != nil {
-			err := state.stream.CloseSend()
-			if err != nil {
-				plog.Error("EndpointWriter error when closing the stream", log.Error(err))
-			}
-		}
+		state.closeStream()
 	case *actor.Restarting:
-		if state.stream != nil {
-			err := state.stream.CloseSend()
-			if err != nil {
-				plog.Error("EndpointWriter error when closing the stream", log.Error(err))
-			}
-		}
+		state.closeStream()
 	case *EndpointTerminatedEvent:
 		ctx.Stop(ctx.Self())
 	case []interface{}:
+		if state.stream == nil {
+			state.deadLetter(msg, ctx)
+			return
+		}
 		state.sendEnvelopes(msg, ctx)
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		// ignore
@@ -3,7 +3,9 @@ package remote
 import (
 	"runtime"
 	"sync/atomic"
+	"time"
 
+	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/internal/queue/goring"
 	"github.com/AsynkronIT/protoactor-go/internal/queue/mpsc"
 	"github.com/AsynkronIT/protoactor-go/log"
@@ -28,14 +30,103 @@ type endpointWriterMailbox struct {
 	batchSize       int
 	dispatcher      mailbox.Dispatcher
 	suspended       bool
+	remote          *Remote
+	address         string
+	queued          int64
+	notified        int32
+	drained         chan struct{}
 }
 
 func (m *endpointWriterMailbox) PostUserMessage(message interface{}) {
+	if !m.reserve() && !m.admit(message) {
+		return
+	}
 	// batching mailbox only use the message part
 	m.userMailbox.Push(message)
 	m.schedule()
 }
 
+// reserve counts a message in the queue, unless the queue is full
+func (m *endpointWriterMailbox) reserve() bool {
+	watermark := int64(m.remote.config.EndpointWriterHighWatermark)
+	for {
+		queued := atomic.LoadInt64(&m.queued)
+		if watermark > 0 && queued >= watermark {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&m.queued, queued, queued+1) {
+			return true
+		}
+	}
+}
+
+// admit applies the backpressure policy to a message sent while the queue is full, and counts it when admitted
+func (m *endpointWriterMailbox) admit(message interface{}) bool {
+	rd, ok := message.(*remoteDeliver)
+	if !ok {
+		// endpoint control messages
+		atomic.AddInt64(&m.queued, 1)
+		return true
+	}
+	if _, ok := rd.message.(actor.SystemMessage); ok {
+		atomic.AddInt64(&m.queued, 1)
+		return true
+	}
+
+	config := m.remote.config
+	switch config.BackpressurePolicy {
+	case BackpressureNotify:
+		queued := atomic.AddInt64(&m.queued, 1)
+		if atomic.CompareAndSwapInt32(&m.notified, 0, 1) {
+			m.remote.actorSystem.EventStream.Publish(&EndpointBackpressureEvent{
+				Address:     m.address,
+				QueueLength: int(queued),
+			})
+		}
+		return true
+	case BackpressureBlock:
+		timeout := time.NewTimer(config.BackpressureBlockTimeout)
+		defer timeout.Stop()
+		for !m.reserve() {
+			select {
+			case <-m.drained:
+			case <-timeout.C:
+				m.deadLetter(rd)
+				return false
+			}
+		}
+		// wake the next blocked sender, if any
+		m.signalDrained()
+		return true
+	default:
+		m.deadLetter(rd)
+		return false
+	}
+}
+
+func (m *endpointWriterMailbox) deadLetter(rd *remoteDeliver) {
+	m.remote.actorSystem.SystemEventStream.Publish(&actor.DeadLetterEvent{
+		PID:     rd.target,
+		Message: rd.message,
+		Sender:  rd.sender,
+	})
+}
+
+func (m *endpointWriterMailbox) signalDrained() {
+	select {
+	case m.drained <- struct{}{}:
+	default:
+	}
+}
+
+func (m *endpointWriterMailbox) dequeued(count int) {
+	queued := atomic.AddInt64(&m.queued, -int64(count))
+	if watermark := m.remote.config.EndpointWriterHighWatermark; watermark > 0 && queued < int64(watermark) {
+		atomic.StoreInt32(&m.notified, 0)
+		m.signalDrained()
+	}
+}
+
 func (m *endpointWriterMailbox) PostSystemMessage(message interface{}) {
 	m.systemMailbox.Push(message)
 	m.schedule()
@@ -104,7 +195,10 @@ func (m *endpointWriterMailbox) run() {
 		}
 
 		var ok bool
-		if msg, ok = m.userMailbox.PopMany(int64(m.batchSize)); ok {
+		var batch []interface{}
+		if batch, ok = m.userMailbox.PopMany(int64(m.batchSize)); ok {
+			m.dequeued(len(batch))
+			msg = batch
 			m.invoker.InvokeUserMessage(msg)
 		} else {
 			return
@@ -114,16 +208,19 @@ func (m *endpointWriterMailbox) run() {
 	}
 }
 
-func endpointWriterMailboxProducer(batchSize, initialSize int) mailbox.Producer {
+func endpointWriterMailboxProducer(remote *Remote, address string) mailbox.Producer {
 	return func() mailbox.Mailbox {
-		userMailbox := goring.New(int64(initialSize))
+		userMailbox := goring.New(int64(remote.config.EndpointWriterQueueSize))
 		systemMailbox := mpsc.New()
 		return &endpointWriterMailbox{
 			userMailbox:     userMailbox,
 			systemMailbox:   systemMailbox,
 			hasMoreMessages: mailboxHasNoMessages,
 			schedulerStatus: mailboxIdle,
-			batchSize:       batchSize,
+			batchSize:       remote.config.EndpointWriterBatchSize,
+			remote:          remote,
+			address:         address,
+			drained:         make(chan struct{}, 1),
 		}
 	}
 }
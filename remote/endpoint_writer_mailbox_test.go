@@ -0,0 +1,42 @@
+package remote
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+// stalledDispatcher never runs the mailbox, the messages stay queued
+type stalledDispatcher struct{}
+
+func (stalledDispatcher) Schedule(func()) {}
+
+func (stalledDispatcher) Throughput() int { return 1 }
+
+func TestEndpointWriterMailbox_ConcurrentSendersStayUnderWatermark(t *testing.T) {
+	const watermark = 10
+	r := NewRemote(actor.NewActorSystem(), Configure("localhost", 0).WithEndpointBackpressure(watermark, BackpressureDrop))
+	m := endpointWriterMailboxProducer(r, "other:1")().(*endpointWriterMailbox)
+	m.RegisterHandlers(nil, stalledDispatcher{})
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for j := 0; j < 10; j++ {
+				m.PostUserMessage(&remoteDeliver{message: &ActorPidRequest{}, target: actor.NewPID("other:1", "echo")})
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int64(watermark), atomic.LoadInt64(&m.queued))
+	assert.Equal(t, int64(watermark), m.userMailbox.Length())
+}
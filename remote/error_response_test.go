@@ -0,0 +1,45 @@
+package remote
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorResponse_RoundTrip(t *testing.T) {
+	for _, serializerID := range []int32{0, 1} {
+		msg := &actor.ErrorResponse{Message: "config not found", Code: "NotFound", Retryable: true}
+		b, typeName, err := Serialize(msg, serializerID)
+		require.NoError(t, err)
+		res, err := Deserialize(b, typeName, serializerID)
+		require.NoError(t, err)
+		assert.Equal(t, msg, res)
+	}
+}
+
+func TestRespondError_OverRemote(t *testing.T) {
+	responding := actor.NewActorSystem()
+	respondingRemote := NewRemote(responding, Configure("localhost", 0))
+	respondingRemote.Start()
+	defer respondingRemote.Shutdown(false)
+	pid, err := responding.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.PID); ok {
+			ctx.RespondError(&actor.ActorError{Message: "config not found", Code: "NotFound", Retryable: true})
+		}
+	}), "responding")
+	require.NoError(t, err)
+
+	requesting := actor.NewActorSystem()
+	requestingRemote := NewRemote(requesting, Configure("localhost", 0))
+	requestingRemote.Start()
+	defer requestingRemote.Shutdown(false)
+
+	err = requesting.Root.RequestFuture(pid, pid, 5*time.Second).Wait()
+	var actorErr *actor.ActorError
+	require.True(t, errors.As(err, &actorErr), "%v", err)
+	assert.Equal(t, &actor.ActorError{Message: "config not found", Code: "NotFound", Retryable: true}, actorErr)
+}
@@ -5,6 +5,9 @@ var (
 	ErrTimeout                 = &ResponseError{ResponseStatusCodeTIMEOUT}
 	ErrProcessNameAlreadyExist = &ResponseError{ResponseStatusCodePROCESSNAMEALREADYEXIST}
 	ErrDeadLetter              = &ResponseError{ResponseStatusCodeDeadLetter}
+	ErrActivationLimitReached  = &ResponseError{ResponseStatusCodeACTIVATIONLIMITREACHED}
+	ErrOwnerChanged            = &ResponseError{ResponseStatusCodeOWNERCHANGED}
+	ErrQuotaExceeded           = &ResponseError{ResponseStatusCodeQUOTAEXCEEDED}
 	ErrUnknownError            = &ResponseError{ResponseStatusCodeERROR}
 )
 
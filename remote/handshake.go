@@ -0,0 +1,120 @@
+package remote
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProtocolVersion is the wire protocol version spoken by this remote implementation.
+const ProtocolVersion int32 = 1
+
+// Capability is a bitset of optional wire features an endpoint supports.
+type Capability uint64
+
+const (
+	CapabilityCompression Capability = 1 << iota
+	CapabilityBatching
+	CapabilityPriorityChannel
+	CapabilityDeadLetterResponse
+)
+
+// DefaultCapabilities are the capabilities advertised unless configured otherwise.
+const DefaultCapabilities = CapabilityBatching
+
+var capabilityNames = []struct {
+	capability Capability
+	name       string
+}{
+	{CapabilityCompression, "Compression"},
+	{CapabilityBatching, "Batching"},
+	{CapabilityPriorityChannel, "PriorityChannel"},
+	{CapabilityDeadLetterResponse, "DeadLetterResponse"},
+}
+
+// Has reports whether all capabilities in other are set.
+func (c Capability) Has(other Capability) bool {
+	return c&other == other
+}
+
+func (c Capability) String() string {
+	if c == 0 {
+		return "None"
+	}
+	var names []string
+	for _, n := range capabilityNames {
+		if c.Has(n.capability) {
+			names = append(names, n.name)
+			c &^= n.capability
+		}
+	}
+	if c != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint64(c)))
+	}
+	return strings.Join(names, "|")
+}
+
+// EndpointProtocol is the outcome of the handshake with a remote endpoint.
+type EndpointProtocol struct {
+	Address         string
+	ProtocolVersion int32
+	Capabilities    Capability
+}
+
+type handshakeError struct {
+	reason        string
+	remoteVersion int32
+}
+
+func (e *handshakeError) Error() string {
+	return e.reason
+}
+
+// connectRequest builds the handshake sent by the endpoint writer.
+func (rc *Config) connectRequest() *ConnectRequest {
+	return &ConnectRequest{
+		ProtocolVersion: rc.ProtocolVersion,
+		Capabilities:    uint64(rc.Capabilities),
+	}
+}
+
+// accept validates a handshake received by the endpoint reader.
+func (rc *Config) accept(req *ConnectRequest) *ConnectResponse {
+	res := &ConnectResponse{
+		DefaultSerializerId: DefaultSerializerID,
+		ProtocolVersion:     rc.ProtocolVersion,
+		Capabilities:        uint64(rc.Capabilities),
+	}
+	res.RefusalReason = rc.refusal(req.ProtocolVersion)
+	return res
+}
+
+// refusal returns why the endpoint reader refuses an endpoint of the given protocol version, empty when it does not.
+func (rc *Config) refusal(version int32) string {
+	if version < rc.MinProtocolVersion {
+		return fmt.Sprintf("protocol version %v is not supported, minimum is %v", version, rc.MinProtocolVersion)
+	}
+	return ""
+}
+
+// negotiate computes the protocol used with the endpoint that sent res.
+func (rc *Config) negotiate(address string, res *ConnectResponse) (*EndpointProtocol, error) {
+	if res.RefusalReason != "" {
+		return nil, &handshakeError{reason: res.RefusalReason, remoteVersion: res.ProtocolVersion}
+	}
+	if res.ProtocolVersion < rc.MinProtocolVersion {
+		return nil, &handshakeError{
+			reason:        fmt.Sprintf("protocol version %v is not supported, minimum is %v", res.ProtocolVersion, rc.MinProtocolVersion),
+			remoteVersion: res.ProtocolVersion,
+		}
+	}
+
+	version := rc.ProtocolVersion
+	if res.ProtocolVersion < version {
+		version = res.ProtocolVersion
+	}
+	return &EndpointProtocol{
+		Address:         address,
+		ProtocolVersion: version,
+		Capabilities:    rc.Capabilities & Capability(res.Capabilities),
+	}, nil
+}
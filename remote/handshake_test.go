@@ -0,0 +1,154 @@
+package remote
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func startEchoRemote(t *testing.T, config Config) (*actor.ActorSystem, *Remote) {
+	system := actor.NewActorSystem()
+	r := NewRemote(system, config)
+	r.Start()
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*ActorPidRequest); ok {
+			ctx.Respond(&ActorPidResponse{})
+		}
+	})
+	_, err := system.Root.SpawnNamed(props, "echo")
+	require.NoError(t, err)
+	return system, r
+}
+
+func TestCapability_String(t *testing.T) {
+	assert.Equal(t, "None", Capability(0).String())
+	assert.Equal(t, "Compression|Batching", (CapabilityBatching | CapabilityCompression).String())
+	assert.Equal(t, "Batching|0x100", (CapabilityBatching | 0x100).String())
+}
+
+func TestHandshake_NegotiatesWithOlderEndpoint(t *testing.T) {
+	newSystem, newRemote := startEchoRemote(t, Configure("localhost", 0).
+		WithProtocolVersion(2, 1).
+		WithCapabilities(CapabilityBatching|CapabilityCompression|CapabilityDeadLetterResponse))
+	defer newRemote.Shutdown(false)
+	oldSystem, oldRemote := startEchoRemote(t, Configure("localhost", 0).
+		WithProtocolVersion(1, 0).
+		WithCapabilities(CapabilityBatching))
+	defer oldRemote.Shutdown(false)
+
+	_, err := newSystem.Root.RequestFuture(actor.NewPID(oldSystem.Address(), "echo"), &ActorPidRequest{}, 5*time.Second).Result()
+	require.NoError(t, err)
+	_, err = oldSystem.Root.RequestFuture(actor.NewPID(newSystem.Address(), "echo"), &ActorPidRequest{}, 5*time.Second).Result()
+	require.NoError(t, err)
+
+	for _, c := range []struct {
+		remote  *Remote
+		address string
+	}{{newRemote, oldSystem.Address()}, {oldRemote, newSystem.Address()}} {
+		p, ok := c.remote.EndpointProtocol(c.address)
+		require.True(t, ok)
+		assert.Equal(t, int32(1), p.ProtocolVersion)
+		assert.Equal(t, CapabilityBatching, p.Capabilities)
+	}
+}
+
+func TestHandshake_RefusesUnsupportedVersion(t *testing.T) {
+	newSystem, newRemote := startEchoRemote(t, Configure("localhost", 0).WithProtocolVersion(2, 2))
+	defer newRemote.Shutdown(false)
+	oldSystem, oldRemote := startEchoRemote(t, Configure("localhost", 0).WithProtocolVersion(1, 0))
+	defer oldRemote.Shutdown(false)
+
+	failed := make(chan *EndpointHandshakeFailedEvent, 1)
+	sub := oldSystem.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*EndpointHandshakeFailedEvent); ok {
+			select {
+			case failed <- e:
+			default:
+			}
+		}
+	})
+	defer oldSystem.EventStream.Unsubscribe(sub)
+
+	oldSystem.Root.Send(actor.NewPID(newSystem.Address(), "echo"), &ActorPidRequest{})
+
+	select {
+	case e := <-failed:
+		assert.Equal(t, newSystem.Address(), e.Address)
+		assert.Equal(t, int32(2), e.ProtocolVersion)
+		assert.Contains(t, e.Reason, "protocol version 1 is not supported")
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected handshake to fail")
+	}
+	_, ok := oldRemote.EndpointProtocol(newSystem.Address())
+	assert.False(t, ok)
+}
+
+func TestHandshake_RefusedEndpointFailsRequests(t *testing.T) {
+	newSystem, newRemote := startEchoRemote(t, Configure("localhost", 0).WithProtocolVersion(2, 2))
+	defer newRemote.Shutdown(false)
+	oldSystem, oldRemote := startEchoRemote(t, Configure("localhost", 0).WithProtocolVersion(1, 0))
+	defer oldRemote.Shutdown(false)
+
+	start := time.Now()
+	_, err := oldSystem.Root.RequestFuture(actor.NewPID(newSystem.Address(), "echo"), &ActorPidRequest{}, 30*time.Second).Result()
+	assert.True(t, errors.Is(err, actor.ErrDeadLetter), "%v", err)
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second), "the request failed before its timeout")
+}
+
+func TestEndpointManager_StoppedFailsRequests(t *testing.T) {
+	system, r := startEchoRemote(t, Configure("localhost", 0))
+	r.Shutdown(true)
+
+	start := time.Now()
+	_, err := system.Root.RequestFuture(actor.NewPID("localhost:1", "echo"), &ActorPidRequest{}, 30*time.Second).Result()
+	assert.True(t, errors.Is(err, actor.ErrDeadLetter), "%v", err)
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second), "the request failed before its timeout")
+}
+
+func TestEndpointReader_RefusesBatchesOfUnsupportedVersion(t *testing.T) {
+	system := actor.NewActorSystem()
+	r := NewRemote(system, Configure("localhost", 0).WithProtocolVersion(2, 2))
+	r.edpManager = newEndpointManager(r)
+	reader := newEndpointReader(r)
+	reader.start()
+	received := make(chan string, 10)
+	_, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*ActorPidRequest); ok {
+			received <- msg.Name
+		}
+	}), "target")
+	require.NoError(t, err)
+
+	batch := func(version int32, name string) *MessageBatch {
+		data, typeName, err := Serialize(&ActorPidRequest{Name: name}, 0)
+		require.NoError(t, err)
+		return &MessageBatch{
+			TypeNames:       []string{typeName},
+			TargetNames:     []string{"target"},
+			Envelopes:       []*MessageEnvelope{{MessageData: data}},
+			ProtocolVersion: version,
+		}
+	}
+
+	// the stream was open without a handshake
+	stream := &batchStream{batches: make(chan *MessageBatch, 3)}
+	stream.batches <- batch(2, "supported")
+	stream.batches <- batch(1, "old")
+	stream.batches <- batch(2, "after")
+	close(stream.batches)
+	err = reader.Receive(stream)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "%v", err)
+
+	assert.Equal(t, "supported", <-received)
+	select {
+	case name := <-received:
+		t.Fatalf("%v delivered on the refused stream", name)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -2,7 +2,6 @@ package remote
 
 import (
 	"bytes"
-	"fmt"
 	"reflect"
 
 	"github.com/gogo/protobuf/jsonpb"
@@ -35,7 +34,7 @@ func (j *jsonSerializer) Serialize(msg interface{}) ([]byte, error) {
 
 		return []byte(str), nil
 	}
-	return nil, fmt.Errorf("msg must be proto.Message")
+	return nil, ErrNotSerializable
 }
 
 func (j *jsonSerializer) Deserialize(typeName string, b []byte) (interface{}, error) {
@@ -58,7 +57,7 @@ func (j *jsonSerializer) Deserialize(typeName string, b []byte) (interface{}, er
 		return instance, nil
 	}
 
-	return nil, fmt.Errorf("msg must be proto.Message")
+	return nil, ErrNotSerializable
 }
 
 func (j *jsonSerializer) GetTypeName(msg interface{}) (string, error) {
@@ -70,5 +69,5 @@ func (j *jsonSerializer) GetTypeName(msg interface{}) (string, error) {
 		return typeName, nil
 	}
 
-	return "", fmt.Errorf("msg must be proto.Message")
+	return "", ErrNotSerializable
 }
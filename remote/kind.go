@@ -0,0 +1,94 @@
+package remote
+
+import (
+	"sync/atomic"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// Kind is a named actor type that can be spawned remotely through the activator
+type Kind struct {
+	Kind               string
+	Props              *actor.Props
+	MaxActivations     int
+	ReceiverMiddleware []actor.ReceiverMiddleware
+	SenderMiddleware   []actor.SenderMiddleware
+}
+
+func NewKind(kind string, props *actor.Props) *Kind {
+	return &Kind{
+		Kind:  kind,
+		Props: props,
+	}
+}
+
+// WithReceiverMiddleware adds receiver middleware to actors activated remotely for the kind.
+// It runs after the middleware of the kind's Props.
+func (k *Kind) WithReceiverMiddleware(middleware ...actor.ReceiverMiddleware) *Kind {
+	k.ReceiverMiddleware = append(k.ReceiverMiddleware, middleware...)
+	return k
+}
+
+// WithSenderMiddleware adds sender middleware to actors activated remotely for the kind.
+// It runs after the middleware of the kind's Props.
+func (k *Kind) WithSenderMiddleware(middleware ...actor.SenderMiddleware) *Kind {
+	k.SenderMiddleware = append(k.SenderMiddleware, middleware...)
+	return k
+}
+
+// WithMaxActivations limits the number of live remote activations of the kind.
+// Further spawn requests are answered with ResponseStatusCodeACTIVATIONLIMITREACHED.
+// Zero means unlimited.
+func (k *Kind) WithMaxActivations(max int) *Kind {
+	k.MaxActivations = max
+	return k
+}
+
+// activatedKind is a registered kind as used by the activator
+type activatedKind struct {
+	kind        *Kind
+	props       *actor.Props
+	activations int32
+}
+
+func newActivatedKind(kind *Kind) *activatedKind {
+	props := kind.Props
+	if len(kind.ReceiverMiddleware) > 0 || len(kind.SenderMiddleware) > 0 {
+		props = props.Clone()
+		if len(kind.ReceiverMiddleware) > 0 {
+			props = props.WithReceiverMiddleware(kind.ReceiverMiddleware...)
+		}
+		if len(kind.SenderMiddleware) > 0 {
+			props = props.WithSenderMiddleware(kind.SenderMiddleware...)
+		}
+	}
+	return &activatedKind{
+		kind:  kind,
+		props: props,
+	}
+}
+
+// tryActivate reserves an activation slot, returning false when the limit is reached
+func (ak *activatedKind) tryActivate() bool {
+	for {
+		current := atomic.LoadInt32(&ak.activations)
+		if ak.kind.MaxActivations > 0 && int(current) >= ak.kind.MaxActivations {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&ak.activations, current, current+1) {
+			return true
+		}
+	}
+}
+
+func (ak *activatedKind) deactivate() {
+	atomic.AddInt32(&ak.activations, -1)
+}
+
+func (ak *activatedKind) info() *KindInfo {
+	return &KindInfo{
+		Name:           ak.kind.Kind,
+		MaxActivations: int32(ak.kind.MaxActivations),
+		Activations:    atomic.LoadInt32(&ak.activations),
+	}
+}
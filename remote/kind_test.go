@@ -0,0 +1,235 @@
+package remote
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKind_MaxActivations(t *testing.T) {
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0, NewKind("limited", props).WithMaxActivations(2)))
+	remote.Start()
+	defer remote.Shutdown(false)
+
+	address := system.Address()
+	first, err := remote.Spawn(address, "limited", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, ResponseStatusCodeOK.ToInt32(), first.StatusCode)
+	second, err := remote.Spawn(address, "limited", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, ResponseStatusCodeOK.ToInt32(), second.StatusCode)
+
+	res, err := remote.Spawn(address, "limited", time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, res.Pid)
+	assert.Equal(t, ErrActivationLimitReached, ResponseStatusCode(res.StatusCode).AsError())
+
+	require.NoError(t, system.Root.StopFuture(first.Pid).Wait())
+	assert.Eventually(t, func() bool {
+		res, err := remote.Spawn(address, "limited", time.Second)
+		return err == nil && res.StatusCode == ResponseStatusCodeOK.ToInt32()
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestKind_MaxActivationsAfterActivatorRestart(t *testing.T) {
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	failing := actor.PropsFromFunc(func(ctx actor.Context) {}).
+		WithSpawnFunc(func(*actor.ActorSystem, string, *actor.Props, actor.SpawnerContext) (*actor.PID, error) {
+			return nil, errors.New("boom")
+		})
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0,
+		NewKind("limited", props).WithMaxActivations(1), NewKind("failing", failing)))
+	remote.Start()
+	defer remote.Shutdown(false)
+	address := system.Address()
+	activations := func() int32 {
+		for _, info := range remote.kindInfos() {
+			if info.Name == "limited" {
+				return info.Activations
+			}
+		}
+		return -1
+	}
+
+	first, err := remote.Spawn(address, "limited", time.Second)
+	require.NoError(t, err)
+	require.Equal(t, ResponseStatusCodeOK.ToInt32(), first.StatusCode)
+
+	// the activator fails and restarts, stopping its activations
+	res, err := remote.Spawn(address, "failing", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, ResponseStatusCodeERROR.ToInt32(), res.StatusCode)
+	require.Eventually(t, func() bool {
+		_, alive := system.ProcessRegistry.GetLocal(first.Pid.Id)
+		return !alive && activations() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	second, err := remote.Spawn(address, "limited", time.Second)
+	require.NoError(t, err)
+	require.Equal(t, ResponseStatusCodeOK.ToInt32(), second.StatusCode)
+	assert.Equal(t, int32(1), activations())
+
+	// the restarted activator frees the capacity of the activations stopping
+	require.NoError(t, system.Root.StopFuture(second.Pid).Wait())
+	assert.Eventually(t, func() bool {
+		res, err := remote.Spawn(address, "limited", time.Second)
+		return err == nil && res.StatusCode == ResponseStatusCodeOK.ToInt32()
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestKind_QuotaExceeded(t *testing.T) {
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	system := actor.NewActorSystemWithConfig(actor.NewConfig(actor.WithMaxActors(100)))
+	remote := NewRemote(system, Configure("localhost", 0, NewKind("kind", props)))
+	remote.Start()
+	defer remote.Shutdown(false)
+
+	var filler *actor.PID
+	for {
+		pid, err := system.Root.SpawnChecked(props)
+		if err != nil {
+			break
+		}
+		filler = pid
+	}
+	res, err := remote.SpawnNamed(system.Address(), "refused", "kind", time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, res.Pid)
+	assert.Equal(t, ErrQuotaExceeded, ResponseStatusCode(res.StatusCode).AsError())
+
+	// the activator survived the refusal
+	require.NoError(t, system.Root.StopFuture(filler).Wait())
+	res, err = remote.SpawnNamed(system.Address(), "accepted", "kind", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, ResponseStatusCodeOK.ToInt32(), res.StatusCode)
+}
+
+func TestRemote_StopActivations(t *testing.T) {
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0, NewKind("kind", props)))
+	remote.Start()
+	defer remote.Shutdown(false)
+
+	address := system.Address()
+	res, err := remote.SpawnNamed(address, "before", "kind", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, ResponseStatusCodeOK.ToInt32(), res.StatusCode)
+
+	remote.StopActivations()
+	res, err = remote.SpawnNamed(address, "after", "kind", time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, res.Pid)
+	assert.Equal(t, ResponseStatusCodeUNAVAILABLE.ToInt32(), res.StatusCode)
+}
+
+func TestKind_Middleware(t *testing.T) {
+	received := make(chan interface{}, 10)
+	middleware := func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(ctx actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			received <- envelope.Message
+			next(ctx, envelope)
+		}
+	}
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0, NewKind("decorated", props).WithReceiverMiddleware(middleware)))
+	remote.Start()
+	defer remote.Shutdown(false)
+
+	res, err := remote.Spawn(system.Address(), "decorated", time.Second)
+	require.NoError(t, err)
+	system.Root.Send(res.Pid, "hello")
+
+	assert.IsType(t, &actor.Started{}, <-received)
+	assert.Equal(t, "hello", <-received)
+
+	// the registered props are not modified
+	plain := system.Root.Spawn(props)
+	system.Root.Send(plain, "world")
+	time.Sleep(10 * time.Millisecond)
+	assert.Len(t, received, 0)
+}
+
+func TestRemote_ListKinds(t *testing.T) {
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0,
+		NewKind("b", props).WithMaxActivations(5),
+		NewKind("a", props)))
+	remote.Start()
+	defer remote.Shutdown(false)
+
+	_, err := remote.Spawn(system.Address(), "b", time.Second)
+	require.NoError(t, err)
+
+	kinds, err := remote.ListKinds(system.Address(), time.Second)
+	require.NoError(t, err)
+	require.Len(t, kinds, 2)
+	assert.Equal(t, &KindInfo{Name: "a"}, kinds[0])
+	assert.Equal(t, &KindInfo{Name: "b", MaxActivations: 5, Activations: 1}, kinds[1])
+}
+
+func TestRemote_RegisterKindAtRuntime(t *testing.T) {
+	echo := actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*KindInfo); ok {
+			ctx.Respond(msg)
+		}
+	})
+	system1 := actor.NewActorSystem()
+	remote1 := NewRemote(system1, Configure("localhost", 0))
+	remote1.Start()
+	defer remote1.Shutdown(false)
+	system2 := actor.NewActorSystem()
+	remote2 := NewRemote(system2, Configure("localhost", 0, NewKind("initial", echo)))
+	remote2.Start()
+	defer remote2.Shutdown(false)
+
+	// the nodes are connected both ways once the first spawn is answered
+	res, err := remote1.Spawn(system2.Address(), "initial", time.Second)
+	require.NoError(t, err)
+	require.Equal(t, ResponseStatusCodeOK.ToInt32(), res.StatusCode)
+
+	changes := make(chan *KindsChanged, 10)
+	system1.EventStream.Subscribe(func(evt interface{}) {
+		if msg, ok := evt.(*KindsChanged); ok && msg.Address == system2.Address() {
+			changes <- msg
+		}
+	})
+
+	remote2.RegisterKind(NewKind("late", echo))
+	select {
+	case msg := <-changes:
+		assert.Equal(t, []string{"initial", "late"}, msg.Kinds)
+	case <-time.After(time.Second):
+		t.Fatal("the kinds changed were not told")
+	}
+	res, err = remote1.SpawnNamed(system2.Address(), "late-1", "late", time.Second)
+	require.NoError(t, err)
+	require.Equal(t, ResponseStatusCodeOK.ToInt32(), res.StatusCode)
+	late := res.Pid
+
+	remote2.UnregisterKind("late")
+	select {
+	case msg := <-changes:
+		assert.Equal(t, []string{"initial"}, msg.Kinds)
+	case <-time.After(time.Second):
+		t.Fatal("the kinds changed were not told")
+	}
+	res, err = remote1.SpawnNamed(system2.Address(), "late-2", "late", time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, res.Pid)
+	assert.Equal(t, ResponseStatusCodeERROR.ToInt32(), res.StatusCode)
+
+	// the actors activated keep running
+	reply, err := system1.Root.RequestFuture(late, &KindInfo{Name: "late"}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, &KindInfo{Name: "late"}, reply)
+}
@@ -10,6 +10,29 @@ type EndpointConnectedEvent struct {
 	Address string
 }
 
+// EndpointHandshakeFailedEvent is published when the handshake with a remote endpoint is refused,
+// either by the remote endpoint or locally.
+type EndpointHandshakeFailedEvent struct {
+	Address         string
+	Reason          string
+	ProtocolVersion int32
+}
+
+// EndpointBatchSentEvent is published when a batch of messages is sent to a remote endpoint,
+// Bytes is the size of their serialized payloads
+type EndpointBatchSentEvent struct {
+	Address  string
+	Messages int
+	Bytes    int
+}
+
+// EndpointBatchReceivedEvent is published when a batch of messages is received from a remote endpoint,
+// Bytes is the size of their serialized payloads
+type EndpointBatchReceivedEvent struct {
+	Messages int
+	Bytes    int
+}
+
 type remoteWatch struct {
 	Watcher *actor.PID
 	Watchee *actor.PID
@@ -26,6 +49,16 @@ type remoteDeliver struct {
 	target       *actor.PID
 	sender       *actor.PID
 	serializerID int32
+	ackID        uint64 // the id of its acknowledgment once sent, when it awaits one
+}
+
+// envelope returns the message with its header and sender, as it is dead lettered
+func (rd *remoteDeliver) envelope() *actor.MessageEnvelope {
+	var header map[string]string
+	if rd.header != nil {
+		header = rd.header.ToMap()
+	}
+	return &actor.MessageEnvelope{Header: header, Message: rd.message, Sender: rd.sender}
 }
 
 type remoteTerminate struct {
@@ -43,9 +76,10 @@ var (
 )
 
 var (
-	ActorPidRespErr         interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeERROR.ToInt32()}
-	ActorPidRespTimeout     interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeTIMEOUT.ToInt32()}
-	ActorPidRespUnavailable interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeUNAVAILABLE.ToInt32()}
+	ActorPidRespErr          interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeERROR.ToInt32()}
+	ActorPidRespTimeout      interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeTIMEOUT.ToInt32()}
+	ActorPidRespUnavailable  interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeUNAVAILABLE.ToInt32()}
+	ActorPidRespOwnerChanged interface{} = &ActorPidResponse{StatusCode: ResponseStatusCodeOWNERCHANGED.ToInt32()}
 )
 
 type (
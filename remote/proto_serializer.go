@@ -22,7 +22,7 @@ func (protoSerializer) Serialize(msg interface{}) ([]byte, error) {
 
 		return bytes, nil
 	}
-	return nil, fmt.Errorf("msg must be proto.Message")
+	return nil, ErrNotSerializable
 }
 
 func (protoSerializer) Deserialize(typeName string, bytes []byte) (interface{}, error) {
@@ -45,5 +45,5 @@ func (protoSerializer) GetTypeName(msg interface{}) (string, error) {
 
 		return typeName, nil
 	}
-	return "", fmt.Errorf("msg must be proto.Message")
+	return "", ErrNotSerializable
 }
@@ -2,20 +2,26 @@
 // source: protos.proto
 
 /*
-	Package remote is a generated protocol buffer package.
-
-	It is generated from these files:
-		protos.proto
-
-	It has these top-level messages:
-		MessageBatch
-		MessageEnvelope
-		MessageHeader
-		ActorPidRequest
-		ActorPidResponse
-		Unit
-		ConnectRequest
-		ConnectResponse
+Package remote is a generated protocol buffer package.
+
+It is generated from these files:
+
+	protos.proto
+
+It has these top-level messages:
+
+	MessageBatch
+	MessageEnvelope
+	MessageHeader
+	ActorPidRequest
+	ActorPidResponse
+	Unit
+	ConnectRequest
+	ConnectResponse
+	ListKindsRequest
+	ListKindsResponse
+	KindInfo
+	KindsChanged
 */
 package remote
 
@@ -27,14 +33,12 @@ import actor "github.com/AsynkronIT/protoactor-go/actor"
 
 import bytes "bytes"
 
-import (
-	context "golang.org/x/net/context"
-	grpc "google.golang.org/grpc"
-)
+import context "golang.org/x/net/context"
+import grpc "google.golang.org/grpc"
 
 import strings "strings"
 import reflect "reflect"
-import github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+import sortkeys "github.com/gogo/protobuf/sortkeys"
 
 import io "io"
 
@@ -53,6 +57,11 @@ type MessageBatch struct {
 	TypeNames   []string           `protobuf:"bytes,1,rep,name=type_names,json=typeNames" json:"type_names,omitempty"`
 	TargetNames []string           `protobuf:"bytes,2,rep,name=target_names,json=targetNames" json:"target_names,omitempty"`
 	Envelopes   []*MessageEnvelope `protobuf:"bytes,3,rep,name=envelopes" json:"envelopes,omitempty"`
+	// the address of the sending endpoint and the epoch of its sequences, when the envelopes are sequenced
+	SenderAddress string `protobuf:"bytes,4,opt,name=sender_address,json=senderAddress,proto3" json:"sender_address,omitempty"`
+	SequenceEpoch uint64 `protobuf:"varint,5,opt,name=sequence_epoch,json=sequenceEpoch,proto3" json:"sequence_epoch,omitempty"`
+	// the protocol version of the sending endpoint, the batches of versions refused in the handshake are refused too
+	ProtocolVersion int32 `protobuf:"varint,6,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
 }
 
 func (m *MessageBatch) Reset()                    { *m = MessageBatch{} }
@@ -80,6 +89,27 @@ func (m *MessageBatch) GetEnvelopes() []*MessageEnvelope {
 	return nil
 }
 
+func (m *MessageBatch) GetSenderAddress() string {
+	if m != nil {
+		return m.SenderAddress
+	}
+	return ""
+}
+
+func (m *MessageBatch) GetSequenceEpoch() uint64 {
+	if m != nil {
+		return m.SequenceEpoch
+	}
+	return 0
+}
+
+func (m *MessageBatch) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
 type MessageEnvelope struct {
 	TypeId        int32          `protobuf:"varint,1,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
 	MessageData   []byte         `protobuf:"bytes,2,opt,name=message_data,json=messageData,proto3" json:"message_data,omitempty"`
@@ -87,6 +117,10 @@ type MessageEnvelope struct {
 	Sender        *actor.PID     `protobuf:"bytes,4,opt,name=sender" json:"sender,omitempty"`
 	SerializerId  int32          `protobuf:"varint,5,opt,name=serializer_id,json=serializerId,proto3" json:"serializer_id,omitempty"`
 	MessageHeader *MessageHeader `protobuf:"bytes,6,opt,name=message_header,json=messageHeader" json:"message_header,omitempty"`
+	// the sequence of the envelope between its sender and its target, starting at 1, zero when not sequenced
+	Sequence uint64 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// the id acknowledging the envelope once it is received, unique per sending endpoint, zero when not acknowledged
+	AckId uint64 `protobuf:"varint,8,opt,name=ack_id,json=ackId,proto3" json:"ack_id,omitempty"`
 }
 
 func (m *MessageEnvelope) Reset()                    { *m = MessageEnvelope{} }
@@ -135,6 +169,20 @@ func (m *MessageEnvelope) GetMessageHeader() *MessageHeader {
 	return nil
 }
 
+func (m *MessageEnvelope) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *MessageEnvelope) GetAckId() uint64 {
+	if m != nil {
+		return m.AckId
+	}
+	return 0
+}
+
 type MessageHeader struct {
 	HeaderData map[string]string `protobuf:"bytes,1,rep,name=header_data,json=headerData" json:"header_data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
@@ -153,6 +201,8 @@ func (m *MessageHeader) GetHeaderData() map[string]string {
 type ActorPidRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// set on the requests forwarded to the new owner of an identity, which are not forwarded again
+	Forwarded bool `protobuf:"varint,3,opt,name=forwarded,proto3" json:"forwarded,omitempty"`
 }
 
 func (m *ActorPidRequest) Reset()                    { *m = ActorPidRequest{} }
@@ -173,6 +223,13 @@ func (m *ActorPidRequest) GetKind() string {
 	return ""
 }
 
+func (m *ActorPidRequest) GetForwarded() bool {
+	if m != nil {
+		return m.Forwarded
+	}
+	return false
+}
+
 type ActorPidResponse struct {
 	Pid        *actor.PID `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
 	StatusCode int32      `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
@@ -196,22 +253,51 @@ func (m *ActorPidResponse) GetStatusCode() int32 {
 	return 0
 }
 
+// Unit is sent back to an endpoint writer, with the ids of the envelopes acknowledged, or without when the endpoint
+// reader is leaving
 type Unit struct {
+	Acks []uint64 `protobuf:"varint,1,rep,packed,name=acks" json:"acks,omitempty"`
 }
 
 func (m *Unit) Reset()                    { *m = Unit{} }
 func (*Unit) ProtoMessage()               {}
 func (*Unit) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{5} }
 
+func (m *Unit) GetAcks() []uint64 {
+	if m != nil {
+		return m.Acks
+	}
+	return nil
+}
+
 type ConnectRequest struct {
+	ProtocolVersion int32  `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities    uint64 `protobuf:"varint,2,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
 }
 
 func (m *ConnectRequest) Reset()                    { *m = ConnectRequest{} }
 func (*ConnectRequest) ProtoMessage()               {}
 func (*ConnectRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{6} }
 
+func (m *ConnectRequest) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *ConnectRequest) GetCapabilities() uint64 {
+	if m != nil {
+		return m.Capabilities
+	}
+	return 0
+}
+
 type ConnectResponse struct {
-	DefaultSerializerId int32 `protobuf:"varint,1,opt,name=default_serializer_id,json=defaultSerializerId,proto3" json:"default_serializer_id,omitempty"`
+	DefaultSerializerId int32  `protobuf:"varint,1,opt,name=default_serializer_id,json=defaultSerializerId,proto3" json:"default_serializer_id,omitempty"`
+	ProtocolVersion     int32  `protobuf:"varint,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities        uint64 `protobuf:"varint,3,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	RefusalReason       string `protobuf:"bytes,4,opt,name=refusal_reason,json=refusalReason,proto3" json:"refusal_reason,omitempty"`
 }
 
 func (m *ConnectResponse) Reset()                    { *m = ConnectResponse{} }
@@ -225,6 +311,104 @@ func (m *ConnectResponse) GetDefaultSerializerId() int32 {
 	return 0
 }
 
+func (m *ConnectResponse) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *ConnectResponse) GetCapabilities() uint64 {
+	if m != nil {
+		return m.Capabilities
+	}
+	return 0
+}
+
+func (m *ConnectResponse) GetRefusalReason() string {
+	if m != nil {
+		return m.RefusalReason
+	}
+	return ""
+}
+
+type ListKindsRequest struct {
+}
+
+func (m *ListKindsRequest) Reset()                    { *m = ListKindsRequest{} }
+func (*ListKindsRequest) ProtoMessage()               {}
+func (*ListKindsRequest) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{8} }
+
+type ListKindsResponse struct {
+	Kinds []*KindInfo `protobuf:"bytes,1,rep,name=kinds" json:"kinds,omitempty"`
+}
+
+func (m *ListKindsResponse) Reset()                    { *m = ListKindsResponse{} }
+func (*ListKindsResponse) ProtoMessage()               {}
+func (*ListKindsResponse) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{9} }
+
+func (m *ListKindsResponse) GetKinds() []*KindInfo {
+	if m != nil {
+		return m.Kinds
+	}
+	return nil
+}
+
+type KindInfo struct {
+	Name           string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxActivations int32  `protobuf:"varint,2,opt,name=max_activations,json=maxActivations,proto3" json:"max_activations,omitempty"`
+	Activations    int32  `protobuf:"varint,3,opt,name=activations,proto3" json:"activations,omitempty"`
+}
+
+func (m *KindInfo) Reset()                    { *m = KindInfo{} }
+func (*KindInfo) ProtoMessage()               {}
+func (*KindInfo) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{10} }
+
+func (m *KindInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *KindInfo) GetMaxActivations() int32 {
+	if m != nil {
+		return m.MaxActivations
+	}
+	return 0
+}
+
+func (m *KindInfo) GetActivations() int32 {
+	if m != nil {
+		return m.Activations
+	}
+	return 0
+}
+
+// KindsChanged tells the kinds a node activates, once they changed
+type KindsChanged struct {
+	Address string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Kinds   []string `protobuf:"bytes,2,rep,name=kinds" json:"kinds,omitempty"`
+}
+
+func (m *KindsChanged) Reset()                    { *m = KindsChanged{} }
+func (*KindsChanged) ProtoMessage()               {}
+func (*KindsChanged) Descriptor() ([]byte, []int) { return fileDescriptorProtos, []int{11} }
+
+func (m *KindsChanged) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *KindsChanged) GetKinds() []string {
+	if m != nil {
+		return m.Kinds
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*MessageBatch)(nil), "remote.MessageBatch")
 	proto.RegisterType((*MessageEnvelope)(nil), "remote.MessageEnvelope")
@@ -234,13 +418,14 @@ func init() {
 	proto.RegisterType((*Unit)(nil), "remote.Unit")
 	proto.RegisterType((*ConnectRequest)(nil), "remote.ConnectRequest")
 	proto.RegisterType((*ConnectResponse)(nil), "remote.ConnectResponse")
+	proto.RegisterType((*ListKindsRequest)(nil), "remote.ListKindsRequest")
+	proto.RegisterType((*ListKindsResponse)(nil), "remote.ListKindsResponse")
+	proto.RegisterType((*KindInfo)(nil), "remote.KindInfo")
+	proto.RegisterType((*KindsChanged)(nil), "remote.KindsChanged")
 }
 func (this *MessageBatch) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*MessageBatch)
@@ -253,10 +438,7 @@ func (this *MessageBatch) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -284,14 +466,20 @@ func (this *MessageBatch) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.SenderAddress != that1.SenderAddress {
+		return false
+	}
+	if this.SequenceEpoch != that1.SequenceEpoch {
+		return false
+	}
+	if this.ProtocolVersion != that1.ProtocolVersion {
+		return false
+	}
 	return true
 }
 func (this *MessageEnvelope) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*MessageEnvelope)
@@ -304,10 +492,7 @@ func (this *MessageEnvelope) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -329,14 +514,17 @@ func (this *MessageEnvelope) Equal(that interface{}) bool {
 	if !this.MessageHeader.Equal(that1.MessageHeader) {
 		return false
 	}
+	if this.Sequence != that1.Sequence {
+		return false
+	}
+	if this.AckId != that1.AckId {
+		return false
+	}
 	return true
 }
 func (this *MessageHeader) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*MessageHeader)
@@ -349,10 +537,7 @@ func (this *MessageHeader) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -368,10 +553,7 @@ func (this *MessageHeader) Equal(that interface{}) bool {
 }
 func (this *ActorPidRequest) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*ActorPidRequest)
@@ -384,10 +566,7 @@ func (this *ActorPidRequest) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -397,14 +576,14 @@ func (this *ActorPidRequest) Equal(that interface{}) bool {
 	if this.Kind != that1.Kind {
 		return false
 	}
+	if this.Forwarded != that1.Forwarded {
+		return false
+	}
 	return true
 }
 func (this *ActorPidResponse) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*ActorPidResponse)
@@ -417,10 +596,7 @@ func (this *ActorPidResponse) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -434,10 +610,7 @@ func (this *ActorPidResponse) Equal(that interface{}) bool {
 }
 func (this *Unit) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*Unit)
@@ -450,21 +623,23 @@ func (this *Unit) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
+	if len(this.Acks) != len(that1.Acks) {
+		return false
+	}
+	for i := range this.Acks {
+		if this.Acks[i] != that1.Acks[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *ConnectRequest) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*ConnectRequest)
@@ -477,21 +652,21 @@ func (this *ConnectRequest) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
+	if this.ProtocolVersion != that1.ProtocolVersion {
+		return false
+	}
+	if this.Capabilities != that1.Capabilities {
+		return false
+	}
 	return true
 }
 func (this *ConnectResponse) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*ConnectResponse)
@@ -504,16 +679,134 @@ func (this *ConnectResponse) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.DefaultSerializerId != that1.DefaultSerializerId {
+		return false
+	}
+	if this.ProtocolVersion != that1.ProtocolVersion {
+		return false
+	}
+	if this.Capabilities != that1.Capabilities {
+		return false
+	}
+	if this.RefusalReason != that1.RefusalReason {
+		return false
+	}
+	return true
+}
+func (this *ListKindsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ListKindsRequest)
+	if !ok {
+		that2, ok := that.(ListKindsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (this *ListKindsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ListKindsResponse)
+	if !ok {
+		that2, ok := that.(ListKindsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Kinds) != len(that1.Kinds) {
+		return false
+	}
+	for i := range this.Kinds {
+		if !this.Kinds[i].Equal(that1.Kinds[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *KindInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*KindInfo)
+	if !ok {
+		that2, ok := that.(KindInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	if this.MaxActivations != that1.MaxActivations {
+		return false
+	}
+	if this.Activations != that1.Activations {
+		return false
+	}
+	return true
+}
+func (this *KindsChanged) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*KindsChanged)
+	if !ok {
+		that2, ok := that.(KindsChanged)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
 	} else if this == nil {
 		return false
 	}
-	if this.DefaultSerializerId != that1.DefaultSerializerId {
+	if this.Address != that1.Address {
+		return false
+	}
+	if len(this.Kinds) != len(that1.Kinds) {
 		return false
 	}
+	for i := range this.Kinds {
+		if this.Kinds[i] != that1.Kinds[i] {
+			return false
+		}
+	}
 	return true
 }
 
@@ -530,6 +823,7 @@ const _ = grpc.SupportPackageIsVersion4
 type RemotingClient interface {
 	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
 	Receive(ctx context.Context, opts ...grpc.CallOption) (Remoting_ReceiveClient, error)
+	ListKinds(ctx context.Context, in *ListKindsRequest, opts ...grpc.CallOption) (*ListKindsResponse, error)
 }
 
 type remotingClient struct {
@@ -580,11 +874,21 @@ func (x *remotingReceiveClient) Recv() (*Unit, error) {
 	return m, nil
 }
 
+func (c *remotingClient) ListKinds(ctx context.Context, in *ListKindsRequest, opts ...grpc.CallOption) (*ListKindsResponse, error) {
+	out := new(ListKindsResponse)
+	err := grpc.Invoke(ctx, "/remote.Remoting/ListKinds", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Remoting service
 
 type RemotingServer interface {
 	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
 	Receive(Remoting_ReceiveServer) error
+	ListKinds(context.Context, *ListKindsRequest) (*ListKindsResponse, error)
 }
 
 func RegisterRemotingServer(s *grpc.Server, srv RemotingServer) {
@@ -635,13 +939,35 @@ func (x *remotingReceiveServer) Recv() (*MessageBatch, error) {
 	return m, nil
 }
 
-var _Remoting_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "remote.Remoting",
-	HandlerType: (*RemotingServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Connect",
-			Handler:    _Remoting_Connect_Handler,
+func _Remoting_ListKinds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKindsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemotingServer).ListKinds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Remoting/ListKinds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemotingServer).ListKinds(ctx, req.(*ListKindsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Remoting_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "remote.Remoting",
+	HandlerType: (*RemotingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connect",
+			Handler:    _Remoting_Connect_Handler,
+		},
+		{
+			MethodName: "ListKinds",
+			Handler:    _Remoting_ListKinds_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
@@ -712,6 +1038,22 @@ func (m *MessageBatch) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.SenderAddress) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.SenderAddress)))
+		i += copy(dAtA[i:], m.SenderAddress)
+	}
+	if m.SequenceEpoch != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.SequenceEpoch))
+	}
+	if m.ProtocolVersion != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.ProtocolVersion))
+	}
 	return i, nil
 }
 
@@ -771,6 +1113,16 @@ func (m *MessageEnvelope) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.Sequence != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Sequence))
+	}
+	if m.AckId != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.AckId))
+	}
 	return i, nil
 }
 
@@ -836,6 +1188,16 @@ func (m *ActorPidRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintProtos(dAtA, i, uint64(len(m.Kind)))
 		i += copy(dAtA[i:], m.Kind)
 	}
+	if m.Forwarded {
+		dAtA[i] = 0x18
+		i++
+		if m.Forwarded {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -887,6 +1249,23 @@ func (m *Unit) MarshalTo(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Acks) > 0 {
+		dAtA5 := make([]byte, len(m.Acks)*10)
+		var j4 int
+		for _, num := range m.Acks {
+			for num >= 1<<7 {
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j4++
+			}
+			dAtA5[j4] = uint8(num)
+			j4++
+		}
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(j4))
+		i += copy(dAtA[i:], dAtA5[:j4])
+	}
 	return i, nil
 }
 
@@ -905,6 +1284,16 @@ func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ProtocolVersion != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.ProtocolVersion))
+	}
+	if m.Capabilities != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Capabilities))
+	}
 	return i, nil
 }
 
@@ -928,27 +1317,146 @@ func (m *ConnectResponse) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtos(dAtA, i, uint64(m.DefaultSerializerId))
 	}
+	if m.ProtocolVersion != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.ProtocolVersion))
+	}
+	if m.Capabilities != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Capabilities))
+	}
+	if len(m.RefusalReason) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.RefusalReason)))
+		i += copy(dAtA[i:], m.RefusalReason)
+	}
 	return i, nil
 }
 
-func encodeFixed64Protos(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Protos(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
+func (m *ListKindsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListKindsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ListKindsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListKindsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Kinds) > 0 {
+		for _, msg := range m.Kinds {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintProtos(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *KindInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KindInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.MaxActivations != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.MaxActivations))
+	}
+	if m.Activations != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(m.Activations))
+	}
+	return i, nil
+}
+
+func (m *KindsChanged) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KindsChanged) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtos(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if len(m.Kinds) > 0 {
+		for _, s := range m.Kinds {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
 }
+
 func encodeVarintProtos(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -979,6 +1487,16 @@ func (m *MessageBatch) Size() (n int) {
 			n += 1 + l + sovProtos(uint64(l))
 		}
 	}
+	l = len(m.SenderAddress)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.SequenceEpoch != 0 {
+		n += 1 + sovProtos(uint64(m.SequenceEpoch))
+	}
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovProtos(uint64(m.ProtocolVersion))
+	}
 	return n
 }
 
@@ -1006,6 +1524,12 @@ func (m *MessageEnvelope) Size() (n int) {
 		l = m.MessageHeader.Size()
 		n += 1 + l + sovProtos(uint64(l))
 	}
+	if m.Sequence != 0 {
+		n += 1 + sovProtos(uint64(m.Sequence))
+	}
+	if m.AckId != 0 {
+		n += 1 + sovProtos(uint64(m.AckId))
+	}
 	return n
 }
 
@@ -1034,6 +1558,9 @@ func (m *ActorPidRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProtos(uint64(l))
 	}
+	if m.Forwarded {
+		n += 2
+	}
 	return n
 }
 
@@ -1053,12 +1580,25 @@ func (m *ActorPidResponse) Size() (n int) {
 func (m *Unit) Size() (n int) {
 	var l int
 	_ = l
+	if len(m.Acks) > 0 {
+		l = 0
+		for _, e := range m.Acks {
+			l += sovProtos(uint64(e))
+		}
+		n += 1 + sovProtos(uint64(l)) + l
+	}
 	return n
 }
 
 func (m *ConnectRequest) Size() (n int) {
 	var l int
 	_ = l
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovProtos(uint64(m.ProtocolVersion))
+	}
+	if m.Capabilities != 0 {
+		n += 1 + sovProtos(uint64(m.Capabilities))
+	}
 	return n
 }
 
@@ -1068,6 +1608,66 @@ func (m *ConnectResponse) Size() (n int) {
 	if m.DefaultSerializerId != 0 {
 		n += 1 + sovProtos(uint64(m.DefaultSerializerId))
 	}
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovProtos(uint64(m.ProtocolVersion))
+	}
+	if m.Capabilities != 0 {
+		n += 1 + sovProtos(uint64(m.Capabilities))
+	}
+	l = len(m.RefusalReason)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	return n
+}
+
+func (m *ListKindsRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListKindsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Kinds) > 0 {
+		for _, e := range m.Kinds {
+			l = e.Size()
+			n += 1 + l + sovProtos(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *KindInfo) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if m.MaxActivations != 0 {
+		n += 1 + sovProtos(uint64(m.MaxActivations))
+	}
+	if m.Activations != 0 {
+		n += 1 + sovProtos(uint64(m.Activations))
+	}
+	return n
+}
+
+func (m *KindsChanged) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovProtos(uint64(l))
+	}
+	if len(m.Kinds) > 0 {
+		for _, s := range m.Kinds {
+			l = len(s)
+			n += 1 + l + sovProtos(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1092,6 +1692,9 @@ func (this *MessageBatch) String() string {
 		`TypeNames:` + fmt.Sprintf("%v", this.TypeNames) + `,`,
 		`TargetNames:` + fmt.Sprintf("%v", this.TargetNames) + `,`,
 		`Envelopes:` + strings.Replace(fmt.Sprintf("%v", this.Envelopes), "MessageEnvelope", "MessageEnvelope", 1) + `,`,
+		`SenderAddress:` + fmt.Sprintf("%v", this.SenderAddress) + `,`,
+		`SequenceEpoch:` + fmt.Sprintf("%v", this.SequenceEpoch) + `,`,
+		`ProtocolVersion:` + fmt.Sprintf("%v", this.ProtocolVersion) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1107,6 +1710,8 @@ func (this *MessageEnvelope) String() string {
 		`Sender:` + strings.Replace(fmt.Sprintf("%v", this.Sender), "PID", "actor.PID", 1) + `,`,
 		`SerializerId:` + fmt.Sprintf("%v", this.SerializerId) + `,`,
 		`MessageHeader:` + strings.Replace(fmt.Sprintf("%v", this.MessageHeader), "MessageHeader", "MessageHeader", 1) + `,`,
+		`Sequence:` + fmt.Sprintf("%v", this.Sequence) + `,`,
+		`AckId:` + fmt.Sprintf("%v", this.AckId) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1119,7 +1724,7 @@ func (this *MessageHeader) String() string {
 	for k, _ := range this.HeaderData {
 		keysForHeaderData = append(keysForHeaderData, k)
 	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForHeaderData)
+	sortkeys.Strings(keysForHeaderData)
 	mapStringForHeaderData := "map[string]string{"
 	for _, k := range keysForHeaderData {
 		mapStringForHeaderData += fmt.Sprintf("%v: %v,", k, this.HeaderData[k])
@@ -1138,6 +1743,7 @@ func (this *ActorPidRequest) String() string {
 	s := strings.Join([]string{`&ActorPidRequest{`,
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`Kind:` + fmt.Sprintf("%v", this.Kind) + `,`,
+		`Forwarded:` + fmt.Sprintf("%v", this.Forwarded) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1158,6 +1764,7 @@ func (this *Unit) String() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&Unit{`,
+		`Acks:` + fmt.Sprintf("%v", this.Acks) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1167,6 +1774,8 @@ func (this *ConnectRequest) String() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&ConnectRequest{`,
+		`ProtocolVersion:` + fmt.Sprintf("%v", this.ProtocolVersion) + `,`,
+		`Capabilities:` + fmt.Sprintf("%v", this.Capabilities) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1177,6 +1786,51 @@ func (this *ConnectResponse) String() string {
 	}
 	s := strings.Join([]string{`&ConnectResponse{`,
 		`DefaultSerializerId:` + fmt.Sprintf("%v", this.DefaultSerializerId) + `,`,
+		`ProtocolVersion:` + fmt.Sprintf("%v", this.ProtocolVersion) + `,`,
+		`Capabilities:` + fmt.Sprintf("%v", this.Capabilities) + `,`,
+		`RefusalReason:` + fmt.Sprintf("%v", this.RefusalReason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListKindsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListKindsRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListKindsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListKindsResponse{`,
+		`Kinds:` + strings.Replace(fmt.Sprintf("%v", this.Kinds), "KindInfo", "KindInfo", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *KindInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&KindInfo{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`MaxActivations:` + fmt.Sprintf("%v", this.MaxActivations) + `,`,
+		`Activations:` + fmt.Sprintf("%v", this.Activations) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *KindsChanged) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&KindsChanged{`,
+		`Address:` + fmt.Sprintf("%v", this.Address) + `,`,
+		`Kinds:` + fmt.Sprintf("%v", this.Kinds) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1307,16 +1961,83 @@ func (m *MessageBatch) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtos(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtos
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SenderAddress", wireType)
 			}
-			if (iNdEx + skippy) > l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SenderAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceEpoch", wireType)
+			}
+			m.SequenceEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequenceEpoch |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
@@ -1511,6 +2232,44 @@ func (m *MessageEnvelope) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckId", wireType)
+			}
+			m.AckId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AckId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtos(dAtA[iNdEx:])
@@ -1787,6 +2546,26 @@ func (m *ActorPidRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Kind = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Forwarded", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Forwarded = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtos(dAtA[iNdEx:])
@@ -1939,6 +2718,68 @@ func (m *Unit) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: Unit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtos
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Acks = append(m.Acks, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtos
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthProtos
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtos
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Acks = append(m.Acks, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Acks", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtos(dAtA[iNdEx:])
@@ -1989,6 +2830,44 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			m.Capabilities = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capabilities |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtos(dAtA[iNdEx:])
@@ -2058,6 +2937,429 @@ func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			m.Capabilities = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capabilities |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefusalReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RefusalReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListKindsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListKindsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListKindsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListKindsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListKindsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListKindsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kinds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kinds = append(m.Kinds, &KindInfo{})
+			if err := m.Kinds[len(m.Kinds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KindInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KindInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KindInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxActivations", wireType)
+			}
+			m.MaxActivations = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxActivations |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Activations", wireType)
+			}
+			m.Activations = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Activations |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KindsChanged) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KindsChanged: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KindsChanged: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kinds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kinds = append(m.Kinds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtos(dAtA[iNdEx:])
@@ -2187,44 +3489,62 @@ var (
 func init() { proto.RegisterFile("protos.proto", fileDescriptorProtos) }
 
 var fileDescriptorProtos = []byte{
-	// 620 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x3b, 0x6f, 0x13, 0x4b,
-	0x14, 0xde, 0x89, 0xe3, 0xcd, 0xf5, 0xb1, 0x13, 0x47, 0x73, 0xf3, 0xb0, 0xac, 0x7b, 0xf7, 0xfa,
-	0x2e, 0x42, 0x72, 0x41, 0xd6, 0xc8, 0x11, 0x08, 0x50, 0x28, 0xf2, 0x42, 0xb8, 0x00, 0x85, 0x05,
-	0x6a, 0x6b, 0xe2, 0x3d, 0x59, 0xaf, 0x62, 0xef, 0x98, 0x9d, 0x71, 0x24, 0x23, 0x21, 0xd1, 0xd1,
-	0x52, 0xf1, 0x1b, 0xf8, 0x29, 0x94, 0x29, 0x29, 0xc9, 0xd2, 0x50, 0x50, 0xe4, 0x27, 0xa0, 0x79,
-	0x24, 0xb1, 0x0d, 0xd5, 0x9e, 0xf3, 0x9d, 0xef, 0xbc, 0xbe, 0xb3, 0x03, 0x95, 0x51, 0xc6, 0x25,
-	0x17, 0x81, 0xfe, 0x50, 0x37, 0xc3, 0x21, 0x97, 0x58, 0xdf, 0x8a, 0x13, 0xd9, 0x1f, 0x1f, 0x07,
-	0x3d, 0x3e, 0x6c, 0xc5, 0x3c, 0xe6, 0x2d, 0x1d, 0x3e, 0x1e, 0x9f, 0x68, 0x4f, 0x3b, 0xda, 0x32,
-	0x69, 0xf5, 0xfb, 0x53, 0xf4, 0x5d, 0x31, 0x49, 0x4f, 0x33, 0x9e, 0x76, 0x5e, 0x99, 0x24, 0xd6,
-	0x93, 0x3c, 0xdb, 0x8a, 0x79, 0x4b, 0x1b, 0xad, 0xe9, 0x76, 0xfe, 0x07, 0x02, 0x95, 0x67, 0x28,
-	0x04, 0x8b, 0x71, 0x8f, 0xc9, 0x5e, 0x9f, 0xfe, 0x0b, 0x20, 0x27, 0x23, 0xec, 0xa6, 0x6c, 0x88,
-	0xa2, 0x46, 0x1a, 0x85, 0x66, 0x29, 0x2c, 0x29, 0xe4, 0xb9, 0x02, 0xe8, 0xff, 0x50, 0x91, 0x2c,
-	0x8b, 0x51, 0x5a, 0xc2, 0x82, 0x26, 0x94, 0x0d, 0x66, 0x28, 0xf7, 0xa0, 0x84, 0xe9, 0x19, 0x0e,
-	0xf8, 0x08, 0x45, 0xad, 0xd0, 0x28, 0x34, 0xcb, 0xed, 0xcd, 0xc0, 0x6c, 0x15, 0xd8, 0x56, 0x87,
-	0x36, 0x1e, 0xde, 0x30, 0xfd, 0x9f, 0x04, 0xaa, 0x73, 0x61, 0xba, 0x09, 0x4b, 0x7a, 0x98, 0x24,
-	0xaa, 0x91, 0x06, 0x69, 0x16, 0x43, 0x57, 0xb9, 0x9d, 0x48, 0x8d, 0x31, 0x34, 0xdc, 0x6e, 0xc4,
-	0x24, 0xab, 0x2d, 0x34, 0x48, 0xb3, 0x12, 0x96, 0x2d, 0x76, 0xc0, 0x24, 0xa3, 0x1b, 0xe0, 0x9a,
-	0xa9, 0x6a, 0x05, 0x9b, 0xaa, 0x3d, 0xea, 0x83, 0x2b, 0x30, 0x8d, 0x30, 0xab, 0x2d, 0x36, 0x48,
-	0xb3, 0xdc, 0x86, 0x40, 0xcb, 0x12, 0x1c, 0x75, 0x0e, 0x42, 0x1b, 0xa1, 0xb7, 0x60, 0x59, 0x60,
-	0x96, 0xb0, 0x41, 0xf2, 0x16, 0x33, 0xd5, 0xbd, 0xa8, 0x4b, 0x54, 0x6e, 0xc0, 0x4e, 0x44, 0x77,
-	0x60, 0xe5, 0x6a, 0x86, 0x3e, 0x32, 0x55, 0xd0, 0xd5, 0x05, 0xd7, 0xe7, 0x96, 0x7d, 0xaa, 0x83,
-	0xe1, 0xf2, 0x70, 0xda, 0xf5, 0x3f, 0x11, 0x58, 0x9e, 0x21, 0xd0, 0x27, 0x50, 0x36, 0x75, 0xcc,
-	0x4a, 0x44, 0x2b, 0x77, 0xfb, 0x8f, 0xc5, 0x02, 0xf3, 0x51, 0x7b, 0x1e, 0xa6, 0x32, 0x9b, 0x84,
-	0xd0, 0xbf, 0x06, 0xea, 0x8f, 0xa1, 0x3a, 0x17, 0xa6, 0xab, 0x50, 0x38, 0xc5, 0x89, 0xd6, 0xb0,
-	0x14, 0x2a, 0x93, 0xae, 0x41, 0xf1, 0x8c, 0x0d, 0xc6, 0xa8, 0x95, 0x2b, 0x85, 0xc6, 0x79, 0xb4,
-	0xf0, 0x80, 0xf8, 0x0f, 0xa1, 0xba, 0xab, 0x04, 0x39, 0x4a, 0xa2, 0x10, 0xdf, 0x8c, 0x51, 0x48,
-	0x4a, 0x61, 0x51, 0x5d, 0xdb, 0xe6, 0x6b, 0x5b, 0x61, 0xa7, 0x49, 0x1a, 0xd9, 0x7c, 0x6d, 0xfb,
-	0x2f, 0x60, 0xf5, 0x26, 0x55, 0x8c, 0x78, 0x2a, 0x90, 0xfe, 0x03, 0x85, 0x91, 0x3d, 0xdf, 0xac,
-	0xd6, 0x0a, 0xa6, 0xff, 0x41, 0x59, 0x48, 0x26, 0xc7, 0xa2, 0xdb, 0xe3, 0x91, 0x19, 0xa6, 0x18,
-	0x82, 0x81, 0xf6, 0x79, 0x84, 0xbe, 0x0b, 0x8b, 0xaf, 0xd3, 0x44, 0xfa, 0xab, 0xb0, 0xb2, 0xcf,
-	0xd3, 0x14, 0x7b, 0xd2, 0x0e, 0xe5, 0x1f, 0x42, 0xf5, 0x1a, 0xb1, 0xbd, 0xda, 0xb0, 0x1e, 0xe1,
-	0x09, 0x1b, 0x0f, 0x64, 0x77, 0xf6, 0x7c, 0xe6, 0xe7, 0xf9, 0xdb, 0x06, 0x5f, 0x4e, 0x5d, 0xb1,
-	0xfd, 0x0e, 0xfe, 0x0a, 0x95, 0xc2, 0x49, 0x1a, 0xd3, 0x1d, 0x58, 0xb2, 0x25, 0xe9, 0xc6, 0x95,
-	0xee, 0xb3, 0x5d, 0xeb, 0x9b, 0xbf, 0xe1, 0xa6, 0xb7, 0xef, 0xd0, 0x6d, 0x58, 0x0a, 0xb1, 0x87,
-	0xc9, 0x19, 0xd2, 0xb5, 0xb9, 0xab, 0xe9, 0xa7, 0x55, 0xaf, 0x5c, 0xa1, 0x7a, 0x23, 0xa7, 0x49,
-	0xee, 0x92, 0xbd, 0x3b, 0xe7, 0x17, 0x9e, 0xf3, 0xf5, 0xc2, 0x73, 0x2e, 0x2f, 0x3c, 0xe7, 0x7d,
-	0xee, 0x91, 0xcf, 0xb9, 0x47, 0xbe, 0xe4, 0x1e, 0x39, 0xcf, 0x3d, 0xf2, 0x2d, 0xf7, 0xc8, 0x8f,
-	0xdc, 0x73, 0x2e, 0x73, 0x8f, 0x7c, 0xfc, 0xee, 0x39, 0xc7, 0xae, 0x7e, 0xb4, 0xdb, 0xbf, 0x02,
-	0x00, 0x00, 0xff, 0xff, 0x32, 0xf3, 0x79, 0x19, 0x33, 0x04, 0x00, 0x00,
+	// 907 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4b, 0x8f, 0x23, 0x35,
+	0x10, 0x8e, 0xf3, 0x4e, 0x25, 0x99, 0x04, 0xb3, 0xb3, 0xd3, 0x44, 0x4b, 0x13, 0x1a, 0x2d, 0x04,
+	0x89, 0xcd, 0xa0, 0x59, 0x81, 0x10, 0x2c, 0x48, 0x33, 0xb3, 0x83, 0x88, 0x78, 0x68, 0x31, 0xaf,
+	0x63, 0xcb, 0xd3, 0xed, 0x24, 0x56, 0x12, 0x3b, 0xb4, 0x9d, 0xb0, 0xc3, 0x69, 0x7f, 0x02, 0x27,
+	0xae, 0x5c, 0xf9, 0x0f, 0x9c, 0xb8, 0x71, 0xdc, 0x23, 0x47, 0x26, 0x5c, 0x38, 0xee, 0x4f, 0x40,
+	0xb6, 0xbb, 0x27, 0x8f, 0xcd, 0x61, 0x4f, 0xed, 0xfa, 0xaa, 0xec, 0xaa, 0xfa, 0xea, 0xab, 0x86,
+	0xc6, 0x3c, 0x91, 0x5a, 0xaa, 0xbe, 0xfd, 0xe0, 0x72, 0xc2, 0x66, 0x52, 0xb3, 0xce, 0xbd, 0x11,
+	0xd7, 0xe3, 0xc5, 0x65, 0x3f, 0x92, 0xb3, 0xe3, 0x91, 0x1c, 0xc9, 0x63, 0xeb, 0xbe, 0x5c, 0x0c,
+	0xad, 0x65, 0x0d, 0x7b, 0x72, 0xd7, 0x3a, 0xef, 0x6f, 0x84, 0x9f, 0xaa, 0x2b, 0x31, 0x49, 0xa4,
+	0x18, 0x7c, 0xeb, 0x2e, 0xd1, 0x48, 0xcb, 0xe4, 0xde, 0x48, 0x1e, 0xdb, 0xc3, 0xf1, 0x66, 0xba,
+	0xe0, 0x49, 0x1e, 0x1a, 0x5f, 0x32, 0xa5, 0xe8, 0x88, 0x9d, 0x51, 0x1d, 0x8d, 0xf1, 0xab, 0x00,
+	0xfa, 0x6a, 0xce, 0x42, 0x41, 0x67, 0x4c, 0x79, 0xa8, 0x5b, 0xe8, 0xd5, 0x48, 0xcd, 0x20, 0x5f,
+	0x19, 0x00, 0xbf, 0x0e, 0x0d, 0x4d, 0x93, 0x11, 0xd3, 0x69, 0x40, 0xde, 0x06, 0xd4, 0x1d, 0xe6,
+	0x42, 0xde, 0x83, 0x1a, 0x13, 0x4b, 0x36, 0x95, 0x73, 0xa6, 0xbc, 0x42, 0xb7, 0xd0, 0xab, 0x9f,
+	0x1c, 0xf5, 0x5d, 0x57, 0xfd, 0x34, 0xd5, 0x45, 0xea, 0x27, 0xeb, 0x48, 0x7c, 0x17, 0x0e, 0x14,
+	0x13, 0x31, 0x4b, 0x42, 0x1a, 0xc7, 0x09, 0x53, 0xca, 0x2b, 0x76, 0x51, 0xaf, 0x46, 0x9a, 0x0e,
+	0x3d, 0x75, 0xa0, 0x0b, 0xfb, 0x71, 0xc1, 0x44, 0xc4, 0x42, 0x36, 0x97, 0xd1, 0xd8, 0x2b, 0x75,
+	0x51, 0xaf, 0x48, 0x9a, 0x19, 0x7a, 0x61, 0x40, 0xfc, 0x36, 0xb4, 0x6d, 0x83, 0x91, 0x9c, 0x86,
+	0x4b, 0x96, 0x28, 0x2e, 0x85, 0x57, 0xee, 0xa2, 0x5e, 0x89, 0xb4, 0x32, 0xfc, 0x7b, 0x07, 0x07,
+	0xbf, 0xe5, 0xa1, 0xb5, 0x53, 0x17, 0x3e, 0x82, 0x8a, 0x65, 0x81, 0xc7, 0x1e, 0xb2, 0xb7, 0xca,
+	0xc6, 0x1c, 0xc4, 0xa6, 0xff, 0x99, 0x8b, 0x0d, 0x63, 0xaa, 0xa9, 0x97, 0xef, 0xa2, 0x5e, 0x83,
+	0xd4, 0x53, 0xec, 0x21, 0xd5, 0x14, 0xdf, 0x86, 0xb2, 0xa3, 0xc3, 0x2b, 0xa4, 0x57, 0xad, 0x85,
+	0x03, 0x28, 0xbb, 0x56, 0x6c, 0x63, 0xf5, 0x13, 0xe8, 0xdb, 0x79, 0xf4, 0x1f, 0x0d, 0x1e, 0x92,
+	0xd4, 0x83, 0xdf, 0x80, 0xa6, 0x62, 0x09, 0xa7, 0x53, 0xfe, 0x33, 0x4b, 0x4c, 0xf6, 0x92, 0x7d,
+	0xa2, 0xb1, 0x06, 0x07, 0x31, 0x7e, 0x00, 0x07, 0x59, 0x0d, 0x63, 0x46, 0xcd, 0x83, 0x65, 0xfb,
+	0xe0, 0xe1, 0x0e, 0xcb, 0x9f, 0x59, 0x27, 0x69, 0xce, 0x36, 0x4d, 0xdc, 0x81, 0x6a, 0x46, 0x95,
+	0x57, 0xb1, 0xd4, 0xdd, 0xd8, 0xf8, 0x10, 0xca, 0x34, 0x9a, 0x98, 0xbc, 0x55, 0xeb, 0x29, 0xd1,
+	0x68, 0x32, 0x88, 0x83, 0x5f, 0x11, 0x34, 0xb7, 0xde, 0xc4, 0x9f, 0x42, 0xdd, 0xa5, 0x76, 0x2c,
+	0x20, 0x3b, 0xe5, 0xbb, 0x7b, 0xf3, 0xf7, 0xdd, 0xc7, 0x50, 0x73, 0x21, 0x74, 0x72, 0x45, 0x60,
+	0x7c, 0x03, 0x74, 0x3e, 0x86, 0xd6, 0x8e, 0x1b, 0xb7, 0xa1, 0x30, 0x61, 0x57, 0x96, 0xf6, 0x1a,
+	0x31, 0x47, 0x7c, 0x0b, 0x4a, 0x4b, 0x3a, 0x5d, 0x30, 0x4b, 0x76, 0x8d, 0x38, 0xe3, 0xc3, 0xfc,
+	0x07, 0x28, 0xf8, 0x01, 0x5a, 0xa7, 0x86, 0xc3, 0x47, 0x3c, 0x26, 0xa6, 0x07, 0xa5, 0x31, 0x86,
+	0xa2, 0x51, 0x66, 0x7a, 0xdf, 0x9e, 0x0d, 0x36, 0xe1, 0x22, 0x4e, 0xef, 0xdb, 0x33, 0xbe, 0x03,
+	0xb5, 0xa1, 0x4c, 0x7e, 0xa2, 0x49, 0xcc, 0x62, 0x3b, 0xa8, 0x2a, 0x59, 0x03, 0xc1, 0xd7, 0xd0,
+	0x5e, 0x3f, 0xac, 0xe6, 0x52, 0x28, 0x86, 0xef, 0x40, 0x61, 0x9e, 0xea, 0x61, 0x7b, 0x78, 0x06,
+	0xc6, 0xaf, 0x41, 0x5d, 0x69, 0xaa, 0x17, 0x2a, 0x8c, 0x64, 0xec, 0x4a, 0x2d, 0x11, 0x70, 0xd0,
+	0xb9, 0x8c, 0x59, 0xd0, 0x81, 0xe2, 0x77, 0x82, 0xdb, 0x02, 0x69, 0x34, 0x71, 0xab, 0x55, 0x24,
+	0xf6, 0x1c, 0x84, 0x70, 0x70, 0x2e, 0x85, 0x60, 0x91, 0xce, 0xda, 0xd8, 0xa7, 0x5f, 0xb4, 0x57,
+	0xbf, 0x38, 0x80, 0x46, 0x44, 0xe7, 0xf4, 0x92, 0x4f, 0xb9, 0xe6, 0x76, 0x25, 0xcd, 0xe8, 0xb6,
+	0xb0, 0xe0, 0x0f, 0x04, 0xad, 0x9b, 0x0c, 0x69, 0x3f, 0x27, 0x70, 0x18, 0xb3, 0x21, 0x5d, 0x4c,
+	0x75, 0xb8, 0xad, 0x39, 0x97, 0xe7, 0xe5, 0xd4, 0xf9, 0xcd, 0xa6, 0xf4, 0xf6, 0x95, 0x95, 0x7f,
+	0xb1, 0xb2, 0x0a, 0xcf, 0x97, 0x65, 0x96, 0x39, 0x61, 0xc3, 0x85, 0xa2, 0xd3, 0x30, 0x61, 0x54,
+	0x49, 0x91, 0xed, 0x7c, 0x8a, 0x12, 0x0b, 0x06, 0x18, 0xda, 0x5f, 0x70, 0xa5, 0x3f, 0xe7, 0x22,
+	0x56, 0x29, 0x41, 0xc1, 0x47, 0xf0, 0xd2, 0x06, 0x96, 0xb6, 0xf4, 0x26, 0x94, 0xcc, 0x70, 0x55,
+	0x2a, 0xc8, 0x76, 0x26, 0x48, 0x13, 0x35, 0x10, 0x43, 0x49, 0x9c, 0x3b, 0xe0, 0x50, 0xcd, 0xa0,
+	0xbd, 0x82, 0x79, 0x0b, 0x5a, 0x33, 0xfa, 0x38, 0xa4, 0x91, 0xe6, 0x4b, 0xaa, 0xb9, 0x14, 0x2a,
+	0xed, 0xf2, 0x60, 0x46, 0x1f, 0x9f, 0xae, 0x51, 0xdc, 0x85, 0xfa, 0x66, 0x90, 0x5b, 0xf8, 0x4d,
+	0x28, 0xf8, 0x04, 0x1a, 0xb6, 0xc6, 0xf3, 0x31, 0x15, 0x23, 0x16, 0x63, 0x0f, 0x2a, 0xd9, 0xff,
+	0xcd, 0x65, 0xcc, 0x4c, 0x23, 0x73, 0x57, 0xbc, 0xfb, 0xa7, 0x3a, 0xe3, 0xe4, 0x4f, 0x04, 0x55,
+	0x62, 0xba, 0xe0, 0x62, 0x84, 0x1f, 0x40, 0x25, 0x9d, 0x22, 0xbe, 0x9d, 0xf5, 0xb6, 0x2d, 0x9c,
+	0xce, 0xd1, 0x73, 0xb8, 0xe3, 0x26, 0xc8, 0xe1, 0xfb, 0x50, 0x21, 0x2c, 0x62, 0x7c, 0xc9, 0xf0,
+	0xad, 0x9d, 0x55, 0xb5, 0xff, 0xfe, 0x4e, 0x23, 0x43, 0x8d, 0x50, 0x83, 0x5c, 0x0f, 0xbd, 0x8b,
+	0xf0, 0x19, 0xd4, 0x6e, 0x78, 0xc6, 0x5e, 0x16, 0xb0, 0x3b, 0x8e, 0xce, 0x2b, 0x7b, 0x3c, 0x59,
+	0xe2, 0xb3, 0x77, 0x9e, 0x5e, 0xfb, 0xb9, 0xbf, 0xaf, 0xfd, 0xdc, 0xb3, 0x6b, 0x3f, 0xf7, 0x64,
+	0xe5, 0xa3, 0xdf, 0x57, 0x3e, 0xfa, 0x6b, 0xe5, 0xa3, 0xa7, 0x2b, 0x1f, 0xfd, 0xb3, 0xf2, 0xd1,
+	0x7f, 0x2b, 0x3f, 0xf7, 0x6c, 0xe5, 0xa3, 0x5f, 0xfe, 0xf5, 0x73, 0x97, 0x65, 0xab, 0xa4, 0xfb,
+	0xff, 0x0f, 0x00, 0x93, 0xe0, 0xa3, 0x1c, 0x18, 0x07, 0x00, 0x00,
 }
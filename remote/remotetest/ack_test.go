@@ -0,0 +1,119 @@
+package remotetest
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ackedNodes starts two remotes acknowledging the delivery on network, and a sink on the second one
+func ackedNodes(t *testing.T, network *Network, maxAttempts int) (*actor.ActorSystem, *orderedSink, *actor.PID, chan *actor.DeadLetterEvent) {
+	start := func(host string) *actor.ActorSystem {
+		system := actor.NewActorSystem()
+		r := remote.NewRemote(system, remote.Configure(host, 0).WithTransport(network.Transport()).
+			WithAcknowledgedDelivery(50*time.Millisecond, maxAttempts))
+		r.Start()
+		t.Cleanup(func() { r.Shutdown(false) })
+		return system
+	}
+	system1, system2 := start("node1"), start("node2")
+
+	sink := &orderedSink{received: make(map[string][]int)}
+	_, err := system2.Root.SpawnNamed(actor.PropsFromProducer(func() actor.Actor { return sink }), "sink")
+	require.NoError(t, err)
+
+	notAcknowledged := make(chan *actor.DeadLetterEvent, 100)
+	system1.EventStream.Subscribe(func(evt interface{}) {
+		if deadLetter, ok := evt.(*actor.DeadLetterEvent); ok && deadLetter.Reason == actor.DeadLetterNotAcknowledged {
+			notAcknowledged <- deadLetter
+		}
+	})
+	return system1, sink, actor.NewPID(system2.Address(), "sink"), notAcknowledged
+}
+
+func sendAcked(system *actor.ActorSystem, target *actor.PID, i int) {
+	system.Root.Send(target, &actor.MessageEnvelope{
+		Header:  map[string]string{remote.AcknowledgeHeader: "true"},
+		Message: &remote.ActorPidRequest{Name: strconv.Itoa(i)},
+		Sender:  actor.NewPID(system.Address(), "sender"),
+	})
+}
+
+func TestAcknowledgedDelivery_RetriesDroppedBatches(t *testing.T) {
+	network := NewNetwork()
+	system, sink, target, notAcknowledged := ackedNodes(t, network, 5)
+
+	// connects the endpoint first, so that the drops are the batches of the messages
+	sendAcked(system, target, 0)
+	require.Eventually(t, func() bool { return sink.count() == 1 }, time.Second, time.Millisecond)
+	network.DropBatches(system.Address(), target.Address, 3)
+	for i := 1; i < 10; i++ {
+		sendAcked(system, target, i)
+	}
+
+	require.Eventually(t, func() bool { return sink.count() == 10 }, time.Second, time.Millisecond)
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, 10, sink.count(), "the messages are delivered once")
+	assert.Len(t, notAcknowledged, 0)
+}
+
+func TestAcknowledgedDelivery_DeduplicatesAcrossReconnects(t *testing.T) {
+	network := NewNetwork()
+	system, sink, target, notAcknowledged := ackedNodes(t, network, 5)
+
+	sendAcked(system, target, 0)
+	require.Eventually(t, func() bool { return sink.count() == 1 }, time.Second, time.Millisecond)
+	network.DropAcks(system.Address(), target.Address, 1)
+	sendAcked(system, target, 1)
+	require.Eventually(t, func() bool { return sink.count() == 2 }, time.Second, time.Millisecond)
+	// the message unacknowledged is sent again on a new stream
+	network.Reconnect(system.Address(), target.Address)
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, []int{0, 1}, sink.received["sender"], "the message sent again is delivered once")
+	assert.Len(t, notAcknowledged, 0)
+}
+
+func TestAcknowledgedDelivery_GivesUp(t *testing.T) {
+	network := NewNetwork()
+	system, sink, target, notAcknowledged := ackedNodes(t, network, 3)
+
+	sendAcked(system, target, 0)
+	require.Eventually(t, func() bool { return sink.count() == 1 }, time.Second, time.Millisecond)
+	network.DropBatches(system.Address(), target.Address, 1000)
+	sendAcked(system, target, 1)
+	// the messages of a missing target are not acknowledged either
+	sendAcked(system, actor.NewPID(target.Address, "missing"), 2)
+
+	var given []string
+	for i := 0; i < 2; i++ {
+		select {
+		case deadLetter := <-notAcknowledged:
+			given = append(given, deadLetter.PID.Id+"/"+deadLetter.Message.(*remote.ActorPidRequest).Name)
+			assert.Equal(t, "true", deadLetter.Header.Get(remote.AcknowledgeHeader))
+		case <-time.After(time.Second):
+			t.Fatal("the messages were not given up")
+		}
+	}
+	assert.ElementsMatch(t, []string{"sink/1", "missing/2"}, given)
+	assert.Equal(t, 1, sink.count())
+}
+
+func TestAcknowledgedDelivery_OnlyWithHeader(t *testing.T) {
+	network := NewNetwork()
+	system, sink, target, notAcknowledged := ackedNodes(t, network, 3)
+
+	sendAcked(system, target, 0)
+	require.Eventually(t, func() bool { return sink.count() == 1 }, time.Second, time.Millisecond)
+	network.DropBatches(system.Address(), target.Address, 1)
+	system.Root.RequestWithCustomSender(target, &remote.ActorPidRequest{Name: "1"}, actor.NewPID(system.Address(), "sender"))
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 1, sink.count(), "the message without the header is not sent again")
+	assert.Len(t, notAcknowledged, 0)
+}
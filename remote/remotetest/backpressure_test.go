@@ -0,0 +1,150 @@
+package remotetest
+
+import (
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startBackpressureNodes(t *testing.T, network *Network, config remote.Config) (*actor.ActorSystem, *actor.PID, func()) {
+	system1 := actor.NewActorSystem()
+	remote1 := remote.NewRemote(system1, config.WithTransport(network.Transport()))
+	remote1.Start()
+	system2, remote2 := startNode(t, network, "node2")
+
+	// connect before slowing the link down
+	echo := actor.NewPID(system2.Address(), "echo")
+	_, err := system1.Root.RequestFuture(echo, &remote.ActorPidRequest{}, time.Second).Result()
+	require.NoError(t, err)
+
+	return system1, echo, func() {
+		network.SetSendDelay(0)
+		remote1.Shutdown(false)
+		remote2.Shutdown(false)
+	}
+}
+
+func countDeadLetters(system *actor.ActorSystem) *int64 {
+	var count int64
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if _, ok := evt.(*actor.DeadLetterEvent); ok {
+			atomic.AddInt64(&count, 1)
+		}
+	})
+	return &count
+}
+
+func TestBackpressure_DropKeepsMemoryBounded(t *testing.T) {
+	if testing.Short() {
+		t.Skip("soak test")
+	}
+	network := NewNetwork()
+	system, echo, stop := startBackpressureNodes(t, network, remote.Configure("node1", 0).
+		WithEndpointBackpressure(100, remote.BackpressureDrop))
+	defer stop()
+	deadLetters := countDeadLetters(system)
+
+	network.SetSendDelay(50 * time.Millisecond)
+
+	var before runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	const count = 20000
+	for i := 0; i < count; i++ {
+		system.Root.Send(echo, &remote.ActorPidRequest{Name: strings.Repeat("x", 10*1024)})
+	}
+
+	var after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+
+	// unbounded, the queue would hold ~200MB of payloads
+	growth := int64(after.HeapAlloc) - int64(before.HeapAlloc)
+	assert.True(t, growth < 50*1024*1024, "heap grew %v bytes", growth)
+	// the slow link lets through at most one queue per send
+	assert.True(t, atomic.LoadInt64(deadLetters) > count/2, "dead letters %v", atomic.LoadInt64(deadLetters))
+}
+
+func TestBackpressure_DropShort(t *testing.T) {
+	network := NewNetwork()
+	system, echo, stop := startBackpressureNodes(t, network, remote.Configure("node1", 0).
+		WithEndpointBackpressure(10, remote.BackpressureDrop))
+	defer stop()
+	deadLetters := countDeadLetters(system)
+
+	network.SetSendDelay(time.Second)
+	// first message occupies the writer, the next 10 fill the queue
+	for i := 0; i < 100; i++ {
+		system.Root.Send(echo, &remote.ActorPidRequest{})
+	}
+	assert.True(t, atomic.LoadInt64(deadLetters) >= 89)
+}
+
+func TestBackpressure_SystemMessagesAreNeverDropped(t *testing.T) {
+	network := NewNetwork()
+	system, echo, stop := startBackpressureNodes(t, network, remote.Configure("node1", 0).
+		WithEndpointBackpressure(1, remote.BackpressureDrop))
+	defer stop()
+	deadLetters := countDeadLetters(system)
+
+	network.SetSendDelay(time.Second)
+	for i := 0; i < 10; i++ {
+		system.Root.Send(echo, &remote.ActorPidRequest{})
+	}
+	dropped := atomic.LoadInt64(deadLetters)
+	for i := 0; i < 10; i++ {
+		system.Root.Send(echo, &actor.Stop{})
+	}
+	assert.Equal(t, dropped, atomic.LoadInt64(deadLetters))
+}
+
+func TestBackpressure_BlockTimesOut(t *testing.T) {
+	network := NewNetwork()
+	system, echo, stop := startBackpressureNodes(t, network, remote.Configure("node1", 0).
+		WithEndpointBackpressure(1, remote.BackpressureBlock).
+		WithBackpressureBlockTimeout(50*time.Millisecond))
+	defer stop()
+	deadLetters := countDeadLetters(system)
+
+	network.SetSendDelay(time.Second)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		system.Root.Send(echo, &remote.ActorPidRequest{})
+	}
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+	assert.True(t, atomic.LoadInt64(deadLetters) >= 2)
+}
+
+func TestBackpressure_Notify(t *testing.T) {
+	network := NewNetwork()
+	system, echo, stop := startBackpressureNodes(t, network, remote.Configure("node1", 0).
+		WithEndpointBackpressure(10, remote.BackpressureNotify))
+	defer stop()
+	deadLetters := countDeadLetters(system)
+
+	events := make(chan *remote.EndpointBackpressureEvent, 10)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*remote.EndpointBackpressureEvent); ok {
+			events <- e
+		}
+	})
+
+	network.SetSendDelay(time.Second)
+	for i := 0; i < 100; i++ {
+		system.Root.Send(echo, &remote.ActorPidRequest{})
+	}
+
+	require.Len(t, events, 1)
+	e := <-events
+	assert.Equal(t, echo.Address, e.Address)
+	assert.True(t, e.QueueLength >= 10)
+	assert.Equal(t, int64(0), atomic.LoadInt64(deadLetters))
+}
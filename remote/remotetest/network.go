@@ -0,0 +1,222 @@
+// Package remotetest provides an in-memory remote transport for tests.
+//
+// Several actor systems in the same process can be connected through a Network,
+// which can inject latency and partition endpoints from each other.
+package remotetest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/remote"
+)
+
+var (
+	ErrNoListener   = errors.New("remotetest: no listener at address")
+	ErrPartitioned  = errors.New("remotetest: endpoints are partitioned")
+	ErrStreamBroken = errors.New("remotetest: stream broken")
+	ErrStreamClosed = errors.New("remotetest: stream closed")
+)
+
+// Network connects in-memory transports with each other.
+type Network struct {
+	mu         sync.RWMutex
+	listeners  map[string]remote.TransportHandler
+	partitions map[string]bool
+	drops      map[dropKey]int
+	streams    map[*stream]struct{}
+	latency    time.Duration
+	sendDelay  time.Duration
+	nextPort   int
+}
+
+// NewNetwork creates an empty network.
+func NewNetwork() *Network {
+	return &Network{
+		listeners:  make(map[string]remote.TransportHandler),
+		partitions: make(map[string]bool),
+		drops:      make(map[dropKey]int),
+		streams:    make(map[*stream]struct{}),
+		nextPort:   1,
+	}
+}
+
+// Transport returns a new transport attached to the network.
+// Each remote needs its own transport.
+func (n *Network) Transport() remote.Transport {
+	return &transport{network: n}
+}
+
+// SetLatency delays the delivery of every batch sent after the call by d.
+func (n *Network) SetLatency(d time.Duration) {
+	n.mu.Lock()
+	n.latency = d
+	n.mu.Unlock()
+}
+
+// SetSendDelay makes every Send on a stream block for d, simulating a slow link.
+func (n *Network) SetSendDelay(d time.Duration) {
+	n.mu.Lock()
+	n.sendDelay = d
+	n.mu.Unlock()
+}
+
+// Partition cuts the link between the endpoints at address a and b.
+// Open streams between them are broken and new connections are refused until Heal is called.
+func (n *Network) Partition(a, b string) {
+	n.mu.Lock()
+	n.partitions[partitionKey(a, b)] = true
+	var broken []*stream
+	for s := range n.streams {
+		if partitionKey(s.from, s.to) == partitionKey(a, b) {
+			broken = append(broken, s)
+		}
+	}
+	n.mu.Unlock()
+
+	for _, s := range broken {
+		s.breakStream()
+	}
+}
+
+// Reconnect drops the open streams between the endpoints at address a and b as a flaky link would:
+// their senders see them broken and connect again, while the batches already sent are still delivered
+// after their latency, possibly after the batches sent on the new streams.
+func (n *Network) Reconnect(a, b string) {
+	n.mu.Lock()
+	var dropped []*stream
+	for s := range n.streams {
+		if partitionKey(s.from, s.to) == partitionKey(a, b) {
+			dropped = append(dropped, s)
+		}
+	}
+	n.mu.Unlock()
+
+	for _, s := range dropped {
+		s.drop()
+	}
+}
+
+// DropBatches silently drops the next count batches sent from the endpoint at address from to the one at address to,
+// as a lossy link would: their Send succeeds and the stream stays open.
+func (n *Network) DropBatches(from, to string, count int) {
+	n.mu.Lock()
+	n.drops[dropKey{from: from, to: to}] += count
+	n.mu.Unlock()
+}
+
+// DropAcks silently drops the next count acknowledgments of the batches sent from the endpoint at address from
+// to the one at address to.
+func (n *Network) DropAcks(from, to string, count int) {
+	n.mu.Lock()
+	n.drops[dropKey{from: from, to: to, acks: true}] += count
+	n.mu.Unlock()
+}
+
+// dropKey is the direction of a link in which traffic is dropped
+type dropKey struct {
+	from, to string
+	acks     bool
+}
+
+// dropped tells whether the traffic of key is to be dropped, counting it
+func (n *Network) dropped(key dropKey) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.drops[key] == 0 {
+		return false
+	}
+	n.drops[key]--
+	return true
+}
+
+// Heal restores the link between the endpoints at address a and b.
+func (n *Network) Heal(a, b string) {
+	n.mu.Lock()
+	delete(n.partitions, partitionKey(a, b))
+	n.mu.Unlock()
+}
+
+func (n *Network) isPartitioned(a, b string) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.partitions[partitionKey(a, b)]
+}
+
+func (n *Network) listen(address string, handler remote.TransportHandler) (string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if port == "0" {
+		port = strconv.Itoa(n.nextPort)
+		n.nextPort++
+	}
+	address = net.JoinHostPort(host, port)
+	if _, ok := n.listeners[address]; ok {
+		return "", fmt.Errorf("remotetest: address %v already in use", address)
+	}
+	n.listeners[address] = handler
+	return address, nil
+}
+
+func (n *Network) unlisten(address string) {
+	n.mu.Lock()
+	delete(n.listeners, address)
+	var broken []*stream
+	for s := range n.streams {
+		if s.to == address {
+			broken = append(broken, s)
+		}
+	}
+	n.mu.Unlock()
+
+	for _, s := range broken {
+		s.breakStream()
+	}
+}
+
+func (n *Network) handler(from, to string) (remote.TransportHandler, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.partitions[partitionKey(from, to)] {
+		return nil, ErrPartitioned
+	}
+	h, ok := n.listeners[to]
+	if !ok {
+		return nil, ErrNoListener
+	}
+	return h, nil
+}
+
+func (n *Network) addStream(s *stream) {
+	n.mu.Lock()
+	n.streams[s] = struct{}{}
+	n.mu.Unlock()
+}
+
+func (n *Network) removeStream(s *stream) {
+	n.mu.Lock()
+	delete(n.streams, s)
+	n.mu.Unlock()
+}
+
+func (n *Network) delays() (latency time.Duration, sendDelay time.Duration) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.latency, n.sendDelay
+}
+
+func partitionKey(a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + "|" + b
+}
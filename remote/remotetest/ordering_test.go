@@ -0,0 +1,106 @@
+package remotetest
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orderedSink records the sequence numbers it receives by sender, carried in the Name of ActorPidRequests
+type orderedSink struct {
+	mu       sync.Mutex
+	received map[string][]int
+}
+
+func (s *orderedSink) Receive(ctx actor.Context) {
+	if msg, ok := ctx.Message().(*remote.ActorPidRequest); ok {
+		n, _ := strconv.Atoi(msg.Name)
+		s.mu.Lock()
+		s.received[ctx.Sender().Id] = append(s.received[ctx.Sender().Id], n)
+		s.mu.Unlock()
+	}
+}
+
+func (s *orderedSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, received := range s.received {
+		count += len(received)
+	}
+	return count
+}
+
+func TestOrderedDelivery_ReconnectMidBurst(t *testing.T) {
+	network := NewNetwork()
+	start := func(host string) (*actor.ActorSystem, *remote.Remote) {
+		system := actor.NewActorSystem()
+		r := remote.NewRemote(system, remote.Configure(host, 0).WithTransport(network.Transport()).WithOrderedDelivery(100))
+		r.Start()
+		return system, r
+	}
+	system1, remote1 := start("node1")
+	system2, remote2 := start("node2")
+	defer remote1.Shutdown(false)
+	defer remote2.Shutdown(false)
+
+	sink := &orderedSink{received: make(map[string][]int)}
+	_, err := system2.Root.SpawnNamed(actor.PropsFromProducer(func() actor.Actor { return sink }), "sink")
+	require.NoError(t, err)
+	resets := make(chan string, 10)
+	system2.EventStream.Subscribe(func(evt interface{}) {
+		if reset, ok := evt.(*remote.SequenceReset); ok {
+			resets <- reset.Address
+		}
+	})
+
+	target := actor.NewPID(system2.Address(), "sink")
+	senders := []*actor.PID{
+		actor.NewPID(system1.Address(), "a"),
+		actor.NewPID(system1.Address(), "b"),
+		actor.NewPID(system1.Address(), "c"),
+	}
+	burst := func(from, to int) {
+		for i := from; i < to; i++ {
+			for _, sender := range senders {
+				system1.Root.RequestWithCustomSender(target, &remote.ActorPidRequest{Name: strconv.Itoa(i)}, sender)
+			}
+		}
+	}
+
+	burst(0, 100)
+	require.Eventually(t, func() bool { return sink.count() == 300 }, time.Second, 10*time.Millisecond)
+
+	// the batches sent before the reconnect are slow, the ones sent after overtake them
+	network.SetLatency(50 * time.Millisecond)
+	burst(100, 200)
+	time.Sleep(10 * time.Millisecond)
+	network.Reconnect(system1.Address(), system2.Address())
+	network.SetLatency(0)
+	time.Sleep(10 * time.Millisecond)
+	burst(200, 300)
+	time.Sleep(200 * time.Millisecond)
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	for _, sender := range senders {
+		received := sink.received[sender.Id]
+		require.NotEmpty(t, received, sender.Id)
+		for i := 1; i < len(received); i++ {
+			require.Lessf(t, received[i-1], received[i], "%v received out of order: %v", sender.Id, received)
+		}
+		assert.Equal(t, 299, received[len(received)-1], "%v receives the last message", sender.Id)
+	}
+	select {
+	case address := <-resets:
+		assert.Equal(t, system1.Address(), address)
+	default:
+		t.Fatal("the sequences were not reset")
+	}
+}
@@ -0,0 +1,240 @@
+package remotetest
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"golang.org/x/net/context"
+)
+
+type transport struct {
+	network *Network
+	address string
+}
+
+func (t *transport) Listen(address string, handler remote.TransportHandler) (string, error) {
+	address, err := t.network.listen(address, handler)
+	if err != nil {
+		return "", err
+	}
+	t.address = address
+	return address, nil
+}
+
+func (t *transport) Dial(address string) (remote.TransportConnection, error) {
+	if _, err := t.network.handler(t.address, address); err != nil {
+		return nil, err
+	}
+	return &connection{transport: t, address: address}, nil
+}
+
+func (t *transport) Stop(graceful bool) {
+	t.network.unlisten(t.address)
+}
+
+type connection struct {
+	transport *transport
+	address   string
+}
+
+func (c *connection) Connect(ctx context.Context, req *remote.ConnectRequest) (*remote.ConnectResponse, error) {
+	h, err := c.transport.network.handler(c.transport.address, c.address)
+	if err != nil {
+		return nil, err
+	}
+	return h.Connect(ctx, req)
+}
+
+func (c *connection) ListKinds(ctx context.Context, req *remote.ListKindsRequest) (*remote.ListKindsResponse, error) {
+	h, err := c.transport.network.handler(c.transport.address, c.address)
+	if err != nil {
+		return nil, err
+	}
+	return h.ListKinds(ctx, req)
+}
+
+func (c *connection) Close() error {
+	return nil
+}
+
+func (c *connection) Receive(ctx context.Context) (remote.TransportClientStream, error) {
+	n := c.transport.network
+	h, err := n.handler(c.transport.address, c.address)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newStream(n, c.transport.address, c.address)
+	n.addStream(s)
+	go func() {
+		err := h.Receive(&serverStream{s})
+		n.removeStream(s)
+		s.finish(err)
+	}()
+	return &clientStream{s}, nil
+}
+
+type delivery struct {
+	batch     *remote.MessageBatch
+	deliverAt time.Time
+}
+
+// stream is a single in-memory Receive call between two endpoints.
+type stream struct {
+	network *Network
+	from    string
+	to      string
+
+	mu         sync.Mutex
+	sendClosed bool
+	batches    chan delivery
+	units      chan *remote.Unit
+
+	broken      chan struct{}
+	brokenOnce  sync.Once
+	dropped     chan struct{}
+	droppedOnce sync.Once
+	done        chan struct{}
+	err         error
+}
+
+func newStream(n *Network, from, to string) *stream {
+	return &stream{
+		network: n,
+		from:    from,
+		to:      to,
+		batches: make(chan delivery, 1000),
+		units:   make(chan *remote.Unit, 1),
+		broken:  make(chan struct{}),
+		dropped: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+func (s *stream) breakStream() {
+	s.brokenOnce.Do(func() {
+		close(s.broken)
+	})
+}
+
+// drop breaks the stream for its sender, while the batches it already sent are still delivered
+func (s *stream) drop() {
+	s.droppedOnce.Do(func() {
+		s.mu.Lock()
+		if !s.sendClosed {
+			s.sendClosed = true
+			close(s.batches)
+		}
+		s.mu.Unlock()
+		close(s.dropped)
+	})
+}
+
+func (s *stream) finish(err error) {
+	s.err = err
+	close(s.done)
+}
+
+type clientStream struct {
+	*stream
+}
+
+func (s *clientStream) Send(batch *remote.MessageBatch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendClosed {
+		return ErrStreamClosed
+	}
+	if s.network.isPartitioned(s.from, s.to) {
+		s.breakStream()
+	}
+
+	latency, sendDelay := s.network.delays()
+	if sendDelay > 0 {
+		select {
+		case <-time.After(sendDelay):
+		case <-s.broken:
+			return ErrStreamBroken
+		}
+	}
+
+	if s.network.dropped(dropKey{from: s.from, to: s.to}) {
+		return nil
+	}
+
+	d := delivery{
+		batch:     batch,
+		deliverAt: time.Now().Add(latency),
+	}
+	select {
+	case <-s.broken:
+		return ErrStreamBroken
+	case <-s.done:
+		return io.EOF
+	case s.batches <- d:
+		return nil
+	}
+}
+
+func (s *clientStream) Recv() (*remote.Unit, error) {
+	select {
+	case u := <-s.units:
+		return u, nil
+	case <-s.broken:
+		return nil, ErrStreamBroken
+	case <-s.dropped:
+		return nil, ErrStreamBroken
+	case <-s.done:
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+}
+
+func (s *clientStream) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.sendClosed {
+		s.sendClosed = true
+		close(s.batches)
+	}
+	return nil
+}
+
+type serverStream struct {
+	*stream
+}
+
+func (s *serverStream) Recv() (*remote.MessageBatch, error) {
+	select {
+	case d, ok := <-s.batches:
+		if !ok {
+			return nil, io.EOF
+		}
+		if wait := time.Until(d.deliverAt); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-s.broken:
+				return nil, ErrStreamBroken
+			}
+		}
+		return d.batch, nil
+	case <-s.broken:
+		return nil, ErrStreamBroken
+	}
+}
+
+func (s *serverStream) Send(unit *remote.Unit) error {
+	if len(unit.Acks) > 0 && s.network.dropped(dropKey{from: s.from, to: s.to, acks: true}) {
+		return nil
+	}
+	select {
+	case <-s.broken:
+		return ErrStreamBroken
+	case s.units <- unit:
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+package remotetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startNode(t *testing.T, network *Network, host string) (*actor.ActorSystem, *remote.Remote) {
+	system := actor.NewActorSystem()
+	r := remote.NewRemote(system, remote.Configure(host, 0).WithTransport(network.Transport()))
+	r.Start()
+
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*remote.ActorPidRequest); ok {
+			ctx.Respond(&remote.ActorPidResponse{Pid: actor.NewPID(msg.Name, msg.Kind)})
+		}
+	})
+	_, err := system.Root.SpawnNamed(props, "echo")
+	require.NoError(t, err)
+	return system, r
+}
+
+func TestTwoRemotesInOneProcess(t *testing.T) {
+	network := NewNetwork()
+	system1, remote1 := startNode(t, network, "node1")
+	system2, remote2 := startNode(t, network, "node2")
+	defer remote1.Shutdown(false)
+	defer remote2.Shutdown(false)
+
+	assert.Equal(t, "node1:1", system1.Address())
+	assert.Equal(t, "node2:2", system2.Address())
+
+	echo := actor.NewPID(system2.Address(), "echo")
+	res, err := system1.Root.RequestFuture(echo, &remote.ActorPidRequest{Name: "a", Kind: "b"}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "a", res.(*remote.ActorPidResponse).Pid.Address)
+}
+
+func TestLatency(t *testing.T) {
+	network := NewNetwork()
+	network.SetLatency(50 * time.Millisecond)
+	system1, remote1 := startNode(t, network, "node1")
+	system2, remote2 := startNode(t, network, "node2")
+	defer remote1.Shutdown(false)
+	defer remote2.Shutdown(false)
+
+	echo := actor.NewPID(system2.Address(), "echo")
+	start := time.Now()
+	_, err := system1.Root.RequestFuture(echo, &remote.ActorPidRequest{}, time.Second).Result()
+	require.NoError(t, err)
+	// one batch in each direction
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestPartition(t *testing.T) {
+	network := NewNetwork()
+	system1, remote1 := startNode(t, network, "node1")
+	system2, remote2 := startNode(t, network, "node2")
+	defer remote1.Shutdown(false)
+	defer remote2.Shutdown(false)
+
+	terminated := make(chan string, 10)
+	system1.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*remote.EndpointTerminatedEvent); ok {
+			terminated <- e.Address
+		}
+	})
+
+	echo := actor.NewPID(system2.Address(), "echo")
+	_, err := system1.Root.RequestFuture(echo, &remote.ActorPidRequest{}, time.Second).Result()
+	require.NoError(t, err)
+
+	network.Partition(system1.Address(), system2.Address())
+	select {
+	case address := <-terminated:
+		assert.Equal(t, system2.Address(), address)
+	case <-time.After(time.Second):
+		t.Fatal("expected endpoint to terminate")
+	}
+
+	_, err = system1.Root.RequestFuture(echo, &remote.ActorPidRequest{}, 100*time.Millisecond).Result()
+	assert.Equal(t, actor.ErrTimeout, err)
+
+	network.Heal(system1.Address(), system2.Address())
+	assert.Eventually(t, func() bool {
+		_, err := system1.Root.RequestFuture(echo, &remote.ActorPidRequest{}, 200*time.Millisecond).Result()
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,58 @@
+//go:build go1.18
+
+package remotetest
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type greeting struct {
+	Text string
+}
+
+type unregistered struct{}
+
+func init() {
+	remote.RegisterWireType("remotetest.greeting", func(msg *greeting) ([]byte, error) {
+		return json.Marshal(msg)
+	}, func(bytes []byte) (*greeting, error) {
+		msg := &greeting{}
+		return msg, json.Unmarshal(bytes, msg)
+	})
+}
+
+func TestWireType_AcrossRemotes(t *testing.T) {
+	network := NewNetwork()
+	system1, remote1 := startNode(t, network, "node1")
+	system2, remote2 := startNode(t, network, "node2")
+	defer remote1.Shutdown(false)
+	defer remote2.Shutdown(false)
+	_, err := system2.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*greeting); ok {
+			ctx.Respond(&greeting{Text: msg.Text + " back"})
+		}
+	}), "greeter")
+	require.NoError(t, err)
+	greeter := actor.NewPID(system2.Address(), "greeter")
+
+	res, err := system1.Root.RequestFuture(greeter, &greeting{Text: "hello"}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, &greeting{Text: "hello back"}, res)
+
+	// the unregistered message fails its future, the endpoint keeps delivering
+	_, err = system1.Root.RequestFuture(greeter, &unregistered{}, time.Second).Result()
+	assert.True(t, errors.Is(err, remote.ErrNotSerializable), "%v", err)
+	assert.EqualError(t, err, "remote: cannot send *remotetest.unregistered to node2:2/greeter: "+remote.ErrNotSerializable.Error())
+
+	res, err = system1.Root.RequestFuture(greeter, &greeting{Text: "again"}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, &greeting{Text: "again back"}, res)
+}
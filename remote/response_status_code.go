@@ -11,6 +11,9 @@ const (
 	ResponseStatusCodePROCESSNAMEALREADYEXIST
 	ResponseStatusCodeERROR
 	ResponseStatusCodeDeadLetter
+	ResponseStatusCodeACTIVATIONLIMITREACHED
+	ResponseStatusCodeOWNERCHANGED
+	ResponseStatusCodeQUOTAEXCEEDED
 	ResponseStatusCodeMAX // just a boundary.
 )
 
@@ -26,6 +29,9 @@ func init() {
 	responseNames[ResponseStatusCodePROCESSNAMEALREADYEXIST] = "ResponseStatusCodePROCESSNAMEALREADYEXIST"
 	responseNames[ResponseStatusCodeERROR] = "ResponseStatusCodeERROR"
 	responseNames[ResponseStatusCodeDeadLetter] = "ResponseStatusCodeDeadLetter"
+	responseNames[ResponseStatusCodeACTIVATIONLIMITREACHED] = "ResponseStatusCodeACTIVATIONLIMITREACHED"
+	responseNames[ResponseStatusCodeOWNERCHANGED] = "ResponseStatusCodeOWNERCHANGED"
+	responseNames[ResponseStatusCodeQUOTAEXCEEDED] = "ResponseStatusCodeQUOTAEXCEEDED"
 }
 
 func (c ResponseStatusCode) ToInt32() int32 {
@@ -54,6 +60,12 @@ func (c ResponseStatusCode) AsError() *ResponseError {
 		return ErrUnknownError
 	case ResponseStatusCodeDeadLetter:
 		return ErrDeadLetter
+	case ResponseStatusCodeACTIVATIONLIMITREACHED:
+		return ErrActivationLimitReached
+	case ResponseStatusCodeOWNERCHANGED:
+		return ErrOwnerChanged
+	case ResponseStatusCodeQUOTAEXCEEDED:
+		return ErrQuotaExceeded
 	default:
 		return &ResponseError{c}
 	}
@@ -0,0 +1,208 @@
+package remote
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/log"
+)
+
+// SequenceReset is published when the messages received from the endpoint at Address start a new sequence,
+// after the endpoint was terminated on its side. The messages sent before are not ordered with the ones sent after,
+// those still received afterwards are dead letters
+type SequenceReset struct {
+	Address string
+}
+
+var lastEpoch int64
+
+// nextEpoch returns an epoch greater than the previous ones of the process, and than those of a previous process
+func nextEpoch() uint64 {
+	for {
+		last := atomic.LoadInt64(&lastEpoch)
+		epoch := time.Now().UnixNano()
+		if epoch <= last {
+			epoch = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&lastEpoch, last, epoch) {
+			return uint64(epoch)
+		}
+	}
+}
+
+// sequencer numbers the envelopes sent to an endpoint per sender and target. It lives until the endpoint
+// is terminated, across the restarts of its writer, so that a batch sent again keeps its sequences
+type sequencer struct {
+	epoch uint64
+
+	mu   sync.Mutex
+	next map[string]uint64
+}
+
+func newSequencer() *sequencer {
+	return &sequencer{epoch: nextEpoch(), next: make(map[string]uint64)}
+}
+
+// stamp numbers the envelopes of rds and sends them with send, the sequences are used once send succeeded only
+func (s *sequencer) stamp(rds []*remoteDeliver, envelopes []*MessageEnvelope, send func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stamped := make(map[string]uint64)
+	for i, rd := range rds {
+		key := pairKey(rd.sender, rd.target)
+		seq, ok := stamped[key]
+		if !ok {
+			seq = s.next[key]
+		}
+		seq++
+		stamped[key] = seq
+		envelopes[i].Sequence = seq
+	}
+	if err := send(); err != nil {
+		return err
+	}
+	for key, seq := range stamped {
+		s.next[key] = seq
+	}
+	return nil
+}
+
+func pairKey(sender, target *actor.PID) string {
+	if sender == nil {
+		return "|" + target.Id
+	}
+	return sender.Address + "/" + sender.Id + "|" + target.Id
+}
+
+func (r *Remote) sequencer(address string) *sequencer {
+	if s, ok := r.sequencers.Load(address); ok {
+		return s.(*sequencer)
+	}
+	s, _ := r.sequencers.LoadOrStore(address, newSequencer())
+	return s.(*sequencer)
+}
+
+// resetSequences starts new sequences for the endpoint at address
+func (r *Remote) resetSequences(address string) {
+	r.sequencers.Delete(address)
+}
+
+// pairSequence is the sequence received from a sender by a target
+type pairSequence struct {
+	next    uint64
+	pending map[uint64]func()
+}
+
+// sequences delivers the sequenced envelopes received from each endpoint in their order. The envelopes received
+// ahead of a missing one are held, up to window per pair, beyond which the missing ones are skipped.
+// The envelopes received twice or from a previous epoch are dead letters
+type sequences struct {
+	remote *Remote
+	window int
+
+	mu     sync.Mutex
+	epochs map[string]uint64
+	pairs  map[string]map[string]*pairSequence // by address and pair
+}
+
+func newSequences(remote *Remote, window int) *sequences {
+	return &sequences{
+		remote: remote,
+		window: window,
+		epochs: make(map[string]uint64),
+		pairs:  make(map[string]map[string]*pairSequence),
+	}
+}
+
+// receive delivers the envelope numbered seq from sender to target with deliver, in the order of the sequence
+func (s *sequences) receive(address string, epoch uint64, seq uint64, sender, target *actor.PID, message interface{}, deliver func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.epochs[address]
+	switch {
+	case ok && epoch < current:
+		s.deadLetter(sender, target, message)
+		return
+	case ok && epoch > current:
+		s.reset(address)
+		fallthrough
+	case !ok:
+		s.epochs[address] = epoch
+		s.pairs[address] = make(map[string]*pairSequence)
+	}
+
+	key := pairKey(sender, target)
+	pair, ok := s.pairs[address][key]
+	if !ok {
+		pair = &pairSequence{next: 1}
+		s.pairs[address][key] = pair
+	}
+	if _, held := pair.pending[seq]; seq < pair.next || held {
+		s.deadLetter(sender, target, message)
+		return
+	}
+	if seq > pair.next {
+		if pair.pending == nil {
+			pair.pending = make(map[uint64]func())
+		}
+		pair.pending[seq] = deliver
+		if len(pair.pending) > s.window {
+			skipped := pair.next
+			pair.next = lowest(pair.pending)
+			plog.Info("EndpointReader skipped missing messages", log.String("address", address),
+				log.Stringer("target", target), log.Uint64("from", skipped), log.Uint64("to", pair.next-1))
+			pair.flush()
+		}
+		return
+	}
+	deliver()
+	pair.next++
+	pair.flush()
+}
+
+// flush delivers the pending envelopes which follow the sequence
+func (pair *pairSequence) flush() {
+	for deliver, ok := pair.pending[pair.next]; ok; deliver, ok = pair.pending[pair.next] {
+		delete(pair.pending, pair.next)
+		deliver()
+		pair.next++
+	}
+}
+
+// reset delivers the pending envelopes of the previous epoch in their order, skipping the missing ones,
+// and publishes SequenceReset
+func (s *sequences) reset(address string) {
+	for _, pair := range s.pairs[address] {
+		seqs := make([]uint64, 0, len(pair.pending))
+		for seq := range pair.pending {
+			seqs = append(seqs, seq)
+		}
+		sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+		for _, seq := range seqs {
+			pair.pending[seq]()
+		}
+	}
+	s.remote.actorSystem.EventStream.Publish(&SequenceReset{Address: address})
+}
+
+func (s *sequences) deadLetter(sender, target *actor.PID, message interface{}) {
+	s.remote.actorSystem.SystemEventStream.Publish(&actor.DeadLetterEvent{
+		PID:     target,
+		Message: message,
+		Sender:  sender,
+	})
+}
+
+func lowest(pending map[uint64]func()) uint64 {
+	var min uint64
+	for seq := range pending {
+		if min == 0 || seq < min {
+			min = seq
+		}
+	}
+	return min
+}
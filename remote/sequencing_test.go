@@ -0,0 +1,126 @@
+package remote
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchStream is an inbound stream receiving the batches it is given
+type batchStream struct {
+	batches chan *MessageBatch
+}
+
+func (s *batchStream) Recv() (*MessageBatch, error) {
+	batch, ok := <-s.batches
+	if !ok {
+		return nil, io.EOF
+	}
+	return batch, nil
+}
+
+func (s *batchStream) Send(*Unit) error { return nil }
+
+// sequencedBatch is a batch of the sequences seqs to target, each one carrying its name
+func sequencedBatch(t *testing.T, epoch uint64, target string, seqs map[uint64]string, order ...uint64) *MessageBatch {
+	batch := &MessageBatch{SenderAddress: "sender:1", SequenceEpoch: epoch, TargetNames: []string{target}}
+	for _, seq := range order {
+		data, typeName, err := Serialize(&ActorPidRequest{Name: seqs[seq]}, 0)
+		require.NoError(t, err)
+		batch.TypeNames = []string{typeName}
+		batch.Envelopes = append(batch.Envelopes, &MessageEnvelope{MessageData: data, Sequence: seq})
+	}
+	return batch
+}
+
+func TestEndpointReader_OrdersSequences(t *testing.T) {
+	system := actor.NewActorSystem()
+	r := NewRemote(system, Configure("localhost", 0).WithOrderedDelivery(3))
+	r.edpManager = newEndpointManager(r)
+	reader := newEndpointReader(r)
+	reader.start()
+
+	received := make(chan string, 100)
+	_, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*ActorPidRequest); ok {
+			received <- msg.Name
+		}
+	}), "target")
+	require.NoError(t, err)
+	deadLetters := make(chan string, 100)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if deadLetter, ok := evt.(*actor.DeadLetterEvent); ok {
+			deadLetters <- deadLetter.Message.(*ActorPidRequest).Name
+		}
+	})
+	resets := make(chan string, 10)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if reset, ok := evt.(*SequenceReset); ok {
+			resets <- reset.Address
+		}
+	})
+
+	stream := &batchStream{batches: make(chan *MessageBatch, 10)}
+	done := make(chan error)
+	go func() { done <- reader.Receive(stream) }()
+
+	first := map[uint64]string{1: "1", 2: "2", 3: "3", 4: "4", 5: "5"}
+	second := map[uint64]string{1: "b1", 2: "b2", 3: "b3", 4: "b4", 5: "b5", 6: "b6"}
+	stream.batches <- sequencedBatch(t, 1, "target", first, 1, 3)
+	stream.batches <- sequencedBatch(t, 1, "target", first, 2, 2, 5)
+	// a reconnect mid-burst: the new epoch is received before the last message of the previous one
+	stream.batches <- sequencedBatch(t, 2, "target", second, 1)
+	stream.batches <- sequencedBatch(t, 1, "target", first, 4)
+	// beyond the window, the missing b2 is skipped
+	stream.batches <- sequencedBatch(t, 2, "target", second, 3, 4, 5, 6)
+	close(stream.batches)
+	require.NoError(t, <-done)
+
+	var order []string
+	for len(order) < 9 {
+		select {
+		case name := <-received:
+			order = append(order, name)
+		case <-time.After(time.Second):
+			t.Fatalf("received %v", order)
+		}
+	}
+	assert.Equal(t, []string{"1", "2", "3", "5", "b1", "b3", "b4", "b5", "b6"}, order)
+	assert.Equal(t, "2", <-deadLetters, "received twice")
+	assert.Equal(t, "4", <-deadLetters, "from the previous epoch")
+	assert.Equal(t, "sender:1", <-resets)
+}
+
+func TestSequencer_KeepsSequencesOfFailedSends(t *testing.T) {
+	s := newSequencer()
+	target := actor.NewPID("remote:1", "target")
+	other := actor.NewPID("remote:1", "other")
+	sender := actor.NewPID("local:1", "sender")
+	rds := []*remoteDeliver{{target: target}, {target: target, sender: sender}, {target: target}, {target: other}}
+	envelopes := func() []*MessageEnvelope {
+		return []*MessageEnvelope{{}, {}, {}, {}}
+	}
+	sequences := func(envelopes []*MessageEnvelope) []uint64 {
+		var seqs []uint64
+		for _, envelope := range envelopes {
+			seqs = append(seqs, envelope.Sequence)
+		}
+		return seqs
+	}
+
+	failed := envelopes()
+	assert.Error(t, s.stamp(rds, failed, func() error { return io.ErrClosedPipe }))
+	assert.Equal(t, []uint64{1, 1, 2, 1}, sequences(failed))
+
+	sent := envelopes()
+	assert.NoError(t, s.stamp(rds, sent, func() error { return nil }))
+	assert.Equal(t, []uint64{1, 1, 2, 1}, sequences(sent), "the failed send is sent again with its sequences")
+
+	next := envelopes()
+	assert.NoError(t, s.stamp(rds, next, func() error { return nil }))
+	assert.Equal(t, []uint64{3, 2, 4, 2}, sequences(next))
+}
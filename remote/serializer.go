@@ -23,12 +23,24 @@ type Serializer interface {
 	GetTypeName(msg interface{}) (string, error)
 }
 
+// Serialize serializes message with the serializer serializerID, unless it is a registered wire type
 func Serialize(message interface{}, serializerID int32) ([]byte, string, error) {
+	if wt, ok := wireTypeOf(message); ok {
+		res, err := wt.marshal(message)
+		return res, wt.name, err
+	}
 	res, err := serializers[serializerID].Serialize(message)
+	if err != nil {
+		return nil, "", err
+	}
 	typeName, err := serializers[serializerID].GetTypeName(message)
 	return res, typeName, err
 }
 
+// Deserialize deserializes message with the serializer serializerID, unless typeName is a registered wire type
 func Deserialize(message []byte, typeName string, serializerID int32) (interface{}, error) {
+	if wt, ok := wireTypeNamed(typeName); ok {
+		return wt.unmarshal(message)
+	}
 	return serializers[serializerID].Deserialize(typeName, message)
 }
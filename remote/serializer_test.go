@@ -45,3 +45,16 @@ func TestJsonSerializer_Serialize_PID(t *testing.T) {
 	assert.Equal(t, "actor.PID", typeName)
 	assert.Equal(t, m, typed)
 }
+
+func TestProtoSerializer_Stop_with_reason(t *testing.T) {
+	b, typeName, err := Serialize(&actor.Stop{Reason: actor.StopReason_ParentStopped}, 0)
+	assert.NoError(t, err)
+	res, err := Deserialize(b, typeName, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, actor.StopReason_ParentStopped, res.(*actor.Stop).Reason)
+
+	// the Stop of members without stop reasons is empty
+	res, err = Deserialize(nil, typeName, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, actor.StopReason_StopRequested, res.(*actor.Stop).Reason)
+}
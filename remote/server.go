@@ -2,35 +2,42 @@ package remote
 
 import (
 	"fmt"
-	"io/ioutil"
-	"net"
-	"time"
+	"sync"
 
 	"github.com/AsynkronIT/protoactor-go/extensions"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/AsynkronIT/protoactor-go/log"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/grpclog"
 )
 
 var extensionId = extensions.NextExtensionId()
 
 type Remote struct {
 	actorSystem  *actor.ActorSystem
-	s            *grpc.Server
+	transport    Transport
 	edpReader    *endpointReader
 	edpManager   *endpointManager
 	config       *Config
-	nameLookup   map[string]actor.Props
+	kindsMu      sync.RWMutex
+	kinds        map[string]*activatedKind
 	activatorPid *actor.PID
+	protocols    sync.Map
+	sequencers   sync.Map         // the sequencers of the endpoints, by address
+	acks         *acknowledgments // nil unless the delivery is acknowledged
+	// activations are the kinds of the live activations by PID id, they outlive the restarts of the activator
+	activations sync.Map
+	// activationsStopped is set when the activator refuses further activations
+	activationsStopped int32
 }
 
 func NewRemote(actorSystem *actor.ActorSystem, config Config) *Remote {
 	r := &Remote{
 		actorSystem: actorSystem,
 		config:      &config,
-		nameLookup:  make(map[string]actor.Props),
+		kinds:       make(map[string]*activatedKind),
+	}
+	for _, kind := range config.Kinds {
+		r.RegisterKind(kind)
 	}
 
 	actorSystem.Extensions.Register(r)
@@ -50,8 +57,18 @@ func (r *Remote) Id() extensions.ExtensionId {
 
 // Start the remote server
 func (r *Remote) Start() {
-	grpclog.SetLoggerV2(grpclog.NewLoggerV2(ioutil.Discard, ioutil.Discard, ioutil.Discard))
-	lis, err := net.Listen("tcp", r.config.Address())
+	if r.config.Transport != nil {
+		r.transport = r.config.Transport
+	} else {
+		r.transport = newGrpcTransport(r.config)
+	}
+
+	if r.config.AckTimeout > 0 {
+		r.acks = newAcknowledgments(r, r.config.AckTimeout, r.config.AckMaxAttempts)
+	}
+	// the reader serves the connections accepted meanwhile once the endpoint manager started
+	r.edpReader = newEndpointReader(r)
+	boundAddress, err := r.transport.Listen(r.config.Address(), r.edpReader)
 	if err != nil {
 		panic(fmt.Errorf("failed to listen: %v", err))
 	}
@@ -60,7 +77,7 @@ func (r *Remote) Start() {
 	if r.config.AdvertisedHost != "" {
 		address = r.config.AdvertisedHost
 	} else {
-		address = lis.Addr().String()
+		address = boundAddress
 	}
 	// r.actorSystem.ProcessRegistry.RegisterAddressResolver(remoteHandler)
 	r.actorSystem.ProcessRegistry.RegisterAddressResolver(r.remoteHandler)
@@ -68,42 +85,37 @@ func (r *Remote) Start() {
 
 	r.edpManager = newEndpointManager(r)
 	r.edpManager.start()
+	r.edpReader.start()
 
-	r.s = grpc.NewServer(r.config.ServerOptions...)
-	r.edpReader = newEndpointReader(r)
-	RegisterRemotingServer(r.s, r.edpReader)
 	plog.Info("Starting Proto.Actor server", log.String("address", address))
-	go r.s.Serve(lis)
 }
 
 func (r *Remote) Shutdown(graceful bool) {
+	if r.acks != nil {
+		r.acks.stop()
+	}
 	if graceful {
 		// TODO: need more graceful
 		r.edpReader.suspend(true)
 		r.edpManager.stop()
-
-		// For some reason GRPC doesn't want to stop
-		// Setup timeout as workaround but need to figure out in the future.
-		// TODO: grpc not stopping
-		c := make(chan bool, 1)
-		go func() {
-			r.s.GracefulStop()
-			c <- true
-		}()
-
-		select {
-		case <-c:
-			plog.Info("Stopped Proto.Actor server")
-		case <-time.After(time.Second * 10):
-			r.s.Stop()
-			plog.Info("Stopped Proto.Actor server", log.String("err", "timeout"))
-		}
+		r.transport.Stop(true)
+		plog.Info("Stopped Proto.Actor server")
 	} else {
-		r.s.Stop()
+		r.transport.Stop(false)
 		plog.Info("Killed Proto.Actor server")
 	}
 }
 
+// EndpointProtocol returns the protocol negotiated with the endpoint at address,
+// or false when no connection to it has been established.
+func (r *Remote) EndpointProtocol(address string) (*EndpointProtocol, bool) {
+	p, ok := r.protocols.Load(address)
+	if !ok {
+		return nil, false
+	}
+	return p.(*EndpointProtocol), true
+}
+
 func (r *Remote) SendMessage(pid *actor.PID, header actor.ReadonlyMessageHeader, message interface{}, sender *actor.PID, serializerID int32) {
 	rd := &remoteDeliver{
 		header:       header,
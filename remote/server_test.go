@@ -1,11 +1,14 @@
 package remote
 
 import (
+	"errors"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStart(t *testing.T) {
@@ -16,6 +19,62 @@ func TestStart(t *testing.T) {
 	remote.Shutdown(true)
 }
 
+// eagerTransport hands its stream to the handler as soon as it listens, or fails to listen with err
+type eagerTransport struct {
+	Transport
+	stream   *batchStream
+	err      error
+	received chan error
+}
+
+func (t *eagerTransport) Listen(address string, handler TransportHandler) (string, error) {
+	if t.err != nil {
+		return "", t.err
+	}
+	go func() {
+		t.received <- handler.Receive(t.stream)
+	}()
+	return "eager:1", nil
+}
+
+func (t *eagerTransport) Stop(bool) {}
+
+func TestStart_ServesConnectionsOnceStarted(t *testing.T) {
+	system := actor.NewActorSystem()
+	received := make(chan string, 1)
+	_, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*ActorPidRequest); ok {
+			received <- msg.Name
+		}
+	}), "target")
+	require.NoError(t, err)
+	data, typeName, err := Serialize(&ActorPidRequest{Name: "early"}, 0)
+	require.NoError(t, err)
+	transport := &eagerTransport{stream: &batchStream{batches: make(chan *MessageBatch, 1)}, received: make(chan error, 1)}
+	transport.stream.batches <- &MessageBatch{
+		TypeNames:   []string{typeName},
+		TargetNames: []string{"target"},
+		Envelopes:   []*MessageEnvelope{{MessageData: data}},
+	}
+	close(transport.stream.batches)
+
+	r := NewRemote(system, Configure("localhost", 0).WithTransport(transport))
+	r.Start()
+	defer r.Shutdown(false)
+	select {
+	case err := <-transport.received:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the stream was never served")
+	}
+	assert.Equal(t, "early", <-received)
+}
+
+func TestStart_FailsToListen(t *testing.T) {
+	r := NewRemote(actor.NewActorSystem(), Configure("localhost", 0).WithTransport(&eagerTransport{err: errors.New("address in use")}))
+	assert.PanicsWithError(t, "failed to listen: address in use", r.Start)
+}
+
 func TestConfig_WithAdvertisedHost(t *testing.T) {
 	system := actor.NewActorSystem()
 	config := Configure("localhost", 0).WithAdvertisedHost("Banana")
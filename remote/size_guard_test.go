@@ -0,0 +1,50 @@
+package remote
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxMessageSize_RejectsLargerMessages(t *testing.T) {
+	guarded := actor.NewActorSystem()
+	guardedRemote := NewRemote(guarded, Configure("localhost", 0).WithMaxMessageSize(1024))
+	guardedRemote.Start()
+	defer guardedRemote.Shutdown(false)
+	deadLetters := make(chan *actor.DeadLetterEvent, 10)
+	guarded.EventStream.Subscribe(func(msg interface{}) {
+		if deadLetter, ok := msg.(*actor.DeadLetterEvent); ok && deadLetter.Reason == actor.DeadLetterTooLarge {
+			deadLetters <- deadLetter
+		}
+	})
+	pid, err := guarded.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*ActorPidRequest); ok {
+			ctx.Respond(&ActorPidResponse{Pid: actor.NewPID("", msg.Name[:5])})
+		}
+	}), "guarded")
+	require.NoError(t, err)
+
+	sender := actor.NewActorSystem()
+	senderRemote := NewRemote(sender, Configure("localhost", 0))
+	senderRemote.Start()
+	defer senderRemote.Shutdown(false)
+
+	err = sender.Root.RequestFuture(pid, &ActorPidRequest{Name: strings.Repeat("large", 400)}, 5*time.Second).Wait()
+	var actorErr *actor.ActorError
+	require.True(t, errors.As(err, &actorErr), "%v", err)
+	assert.Equal(t, "TooLarge", actorErr.Code)
+	deadLetter := <-deadLetters
+	assert.Equal(t, pid.Id, deadLetter.PID.Id)
+	tooLarge := deadLetter.Message.(*TooLargeMessage)
+	assert.Equal(t, "remote.ActorPidRequest", tooLarge.TypeName)
+	assert.Greater(t, tooLarge.Size, 1024)
+
+	res, err := sender.Root.RequestFuture(pid, &ActorPidRequest{Name: "small"}, 5*time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "small", res.(*ActorPidResponse).Pid.Id)
+}
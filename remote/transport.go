@@ -0,0 +1,47 @@
+package remote
+
+import (
+	"golang.org/x/net/context"
+)
+
+// Transport is the wire used by Remote to move MessageBatches between endpoints.
+// gRPC is used unless another Transport is set on the Config.
+type Transport interface {
+	// Listen starts accepting inbound connections on address and hands them to handler.
+	// It returns the address the transport actually bound to.
+	Listen(address string, handler TransportHandler) (string, error)
+	// Dial opens a connection to the endpoint listening on address.
+	Dial(address string) (TransportConnection, error)
+	// Stop stops accepting connections and closes the inbound ones.
+	Stop(graceful bool)
+}
+
+// TransportHandler is implemented by the endpoint reader and receives inbound traffic.
+type TransportHandler interface {
+	Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error)
+	Receive(stream TransportServerStream) error
+	ListKinds(ctx context.Context, req *ListKindsRequest) (*ListKindsResponse, error)
+}
+
+// TransportConnection is the outbound side of a connection, used by the endpoint writer.
+type TransportConnection interface {
+	Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error)
+	Receive(ctx context.Context) (TransportClientStream, error)
+	ListKinds(ctx context.Context, req *ListKindsRequest) (*ListKindsResponse, error)
+	Close() error
+}
+
+// TransportClientStream sends batches to a remote endpoint reader.
+// Recv blocks until the remote side disconnects.
+type TransportClientStream interface {
+	Send(batch *MessageBatch) error
+	Recv() (*Unit, error)
+	CloseSend() error
+}
+
+// TransportServerStream receives batches from a remote endpoint writer.
+// Send is used to tell the remote side that this endpoint is leaving.
+type TransportServerStream interface {
+	Recv() (*MessageBatch, error)
+	Send(unit *Unit) error
+}
@@ -0,0 +1,110 @@
+package remote
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/log"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/grpclog"
+)
+
+type grpcTransport struct {
+	config *Config
+	s      *grpc.Server
+}
+
+func newGrpcTransport(config *Config) Transport {
+	return &grpcTransport{
+		config: config,
+	}
+}
+
+func (t *grpcTransport) Listen(address string, handler TransportHandler) (string, error) {
+	grpclog.SetLoggerV2(grpclog.NewLoggerV2(ioutil.Discard, ioutil.Discard, ioutil.Discard))
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", fmt.Errorf("failed to listen: %v", err)
+	}
+
+	t.s = grpc.NewServer(t.config.ServerOptions...)
+	RegisterRemotingServer(t.s, &grpcRemotingServer{handler: handler})
+	go t.s.Serve(lis)
+	return lis.Addr().String(), nil
+}
+
+func (t *grpcTransport) Dial(address string) (TransportConnection, error) {
+	conn, err := grpc.Dial(address, t.config.DialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcConnection{
+		conn:        conn,
+		client:      NewRemotingClient(conn),
+		callOptions: t.config.CallOptions,
+	}, nil
+}
+
+func (t *grpcTransport) Stop(graceful bool) {
+	if !graceful {
+		t.s.Stop()
+		return
+	}
+
+	// For some reason GRPC doesn't want to stop
+	// Setup timeout as workaround but need to figure out in the future.
+	// TODO: grpc not stopping
+	c := make(chan bool, 1)
+	go func() {
+		t.s.GracefulStop()
+		c <- true
+	}()
+
+	select {
+	case <-c:
+	case <-time.After(time.Second * 10):
+		t.s.Stop()
+		plog.Info("Stopped gRPC server", log.String("err", "timeout"))
+	}
+}
+
+type grpcRemotingServer struct {
+	handler TransportHandler
+}
+
+func (s *grpcRemotingServer) Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error) {
+	return s.handler.Connect(ctx, req)
+}
+
+func (s *grpcRemotingServer) ListKinds(ctx context.Context, req *ListKindsRequest) (*ListKindsResponse, error) {
+	return s.handler.ListKinds(ctx, req)
+}
+
+func (s *grpcRemotingServer) Receive(stream Remoting_ReceiveServer) error {
+	return s.handler.Receive(stream)
+}
+
+type grpcConnection struct {
+	conn        *grpc.ClientConn
+	client      RemotingClient
+	callOptions []grpc.CallOption
+}
+
+func (c *grpcConnection) Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error) {
+	return c.client.Connect(ctx, req)
+}
+
+func (c *grpcConnection) Receive(ctx context.Context) (TransportClientStream, error) {
+	return c.client.Receive(ctx, c.callOptions...)
+}
+
+func (c *grpcConnection) ListKinds(ctx context.Context, req *ListKindsRequest) (*ListKindsResponse, error) {
+	return c.client.ListKinds(ctx, req)
+}
+
+func (c *grpcConnection) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,64 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// ErrNotSerializable is wrapped by the errors of the messages sent remotely which are neither proto.Messages
+// nor registered wire types
+var ErrNotSerializable = errors.New("message is neither a proto.Message nor a registered wire type")
+
+// wireType is a Go type crossing the wire which is not a proto.Message, encoded by its own funcs
+type wireType struct {
+	name      string
+	marshal   func(msg interface{}) ([]byte, error)
+	unmarshal func(bytes []byte) (interface{}, error)
+}
+
+var wireTypes = struct {
+	sync.RWMutex
+	byName map[string]*wireType
+	byType map[reflect.Type]*wireType
+}{
+	byName: make(map[string]*wireType),
+	byType: make(map[reflect.Type]*wireType),
+}
+
+// RegisterWireTypeOf registers typ under name, its messages are encoded by marshal and decoded by unmarshal
+// whatever the serializer. The messages of typ must be registered on both sides, under the same name,
+// which must not be the name of a proto.Message
+func RegisterWireTypeOf(name string, typ reflect.Type, marshal func(msg interface{}) ([]byte, error), unmarshal func(bytes []byte) (interface{}, error)) {
+	if proto.MessageType(name) != nil {
+		panic(fmt.Sprintf("remote: wire type %v is the name of a proto message", name))
+	}
+	wireTypes.Lock()
+	defer wireTypes.Unlock()
+	if _, ok := wireTypes.byName[name]; ok {
+		panic(fmt.Sprintf("remote: wire type %v is already registered", name))
+	}
+	if registered, ok := wireTypes.byType[typ]; ok {
+		panic(fmt.Sprintf("remote: %v is already registered as wire type %v", typ, registered.name))
+	}
+	wt := &wireType{name: name, marshal: marshal, unmarshal: unmarshal}
+	wireTypes.byName[name] = wt
+	wireTypes.byType[typ] = wt
+}
+
+func wireTypeOf(msg interface{}) (*wireType, bool) {
+	wireTypes.RLock()
+	defer wireTypes.RUnlock()
+	wt, ok := wireTypes.byType[reflect.TypeOf(msg)]
+	return wt, ok
+}
+
+func wireTypeNamed(name string) (*wireType, bool) {
+	wireTypes.RLock()
+	defer wireTypes.RUnlock()
+	wt, ok := wireTypes.byName[name]
+	return wt, ok
+}
@@ -0,0 +1,57 @@
+//go:build go1.18
+
+package remote
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type plainPoint struct {
+	X, Y int
+}
+
+func init() {
+	RegisterWireType("remote.plainPoint", func(msg *plainPoint) ([]byte, error) {
+		return json.Marshal(msg)
+	}, func(bytes []byte) (*plainPoint, error) {
+		msg := &plainPoint{}
+		return msg, json.Unmarshal(bytes, msg)
+	})
+}
+
+func TestWireType_RoundTrip(t *testing.T) {
+	for _, serializerID := range []int32{0, 1} {
+		b, typeName, err := Serialize(&plainPoint{X: 1, Y: 2}, serializerID)
+		require.NoError(t, err)
+		assert.Equal(t, "remote.plainPoint", typeName)
+		res, err := Deserialize(b, typeName, serializerID)
+		require.NoError(t, err)
+		assert.Equal(t, &plainPoint{X: 1, Y: 2}, res)
+	}
+}
+
+func TestWireType_Unregistered(t *testing.T) {
+	for _, serializerID := range []int32{0, 1} {
+		_, _, err := Serialize(plainPoint{}, serializerID)
+		assert.Equal(t, ErrNotSerializable, err, "the value type is not registered")
+	}
+}
+
+func TestRegisterWireTypeOf_Conflicts(t *testing.T) {
+	marshal := func(interface{}) ([]byte, error) { return nil, nil }
+	unmarshal := func([]byte) (interface{}, error) { return nil, nil }
+	assert.Panics(t, func() {
+		RegisterWireTypeOf("remote.plainPoint", reflect.TypeOf(0), marshal, unmarshal)
+	})
+	assert.Panics(t, func() {
+		RegisterWireTypeOf("remote.otherPoint", reflect.TypeOf(&plainPoint{}), marshal, unmarshal)
+	})
+	assert.Panics(t, func() {
+		RegisterWireTypeOf("remote.Unit", reflect.TypeOf(0), marshal, unmarshal)
+	})
+}
@@ -0,0 +1,15 @@
+//go:build go1.18
+
+package remote
+
+import "reflect"
+
+// RegisterWireType registers T under name, so that its messages cross the wire although they are not
+// proto.Messages. They are encoded by marshal and decoded by unmarshal, see RegisterWireTypeOf
+func RegisterWireType[T any](name string, marshal func(msg T) ([]byte, error), unmarshal func(bytes []byte) (T, error)) {
+	RegisterWireTypeOf(name, reflect.TypeOf((*T)(nil)).Elem(), func(msg interface{}) ([]byte, error) {
+		return marshal(msg.(T))
+	}, func(bytes []byte) (interface{}, error) {
+		return unmarshal(bytes)
+	})
+}
@@ -30,8 +30,14 @@ func (state *broadcastRouterState) GetRoutees() *actor.PIDSet {
 }
 
 func (state *broadcastRouterState) RouteMessage(message interface{}) {
+	envelope, isEnvelope := message.(*actor.MessageEnvelope)
 	state.routees.ForEach(func(i int, pid *actor.PID) {
-		state.sender.Send(pid, message)
+		if isEnvelope {
+			// each routee owns its envelope
+			state.sender.Send(pid, envelope.Copy())
+		} else {
+			state.sender.Send(pid, message)
+		}
 	})
 }
 
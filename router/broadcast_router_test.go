@@ -1,12 +1,12 @@
 package router
 
 import (
-	"strconv"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
 )
 
 var system = actor.NewActorSystem()
@@ -15,14 +15,14 @@ func TestBroadcastRouterThreadSafe(t *testing.T) {
 	wg := sync.WaitGroup{}
 	wg.Add(2)
 
-	props := actor.PropsFromFunc(func(c actor.Context) {})
-
 	grp := system.Root.Spawn(NewBroadcastGroup())
+	var probes []*testkit.TestProbe
 	go func() {
 		count := 100
 		for i := 0; i < count; i++ {
-			pid, _ := system.Root.SpawnNamed(props, strconv.Itoa(i))
-			system.Root.Send(grp, &AddRoutee{pid})
+			probe := testkit.NewTestProbe(system)
+			probes = append(probes, probe)
+			system.Root.Send(grp, &AddRoutee{probe.PID()})
 			time.Sleep(10 * time.Millisecond)
 		}
 		wg.Done()
@@ -37,4 +37,11 @@ func TestBroadcastRouterThreadSafe(t *testing.T) {
 	}()
 
 	wg.Wait()
+	system.Root.Send(grp, "last")
+	for _, probe := range probes {
+		probe.FishForMessage(t, func(message interface{}) bool {
+			return message == "last"
+		}, 0)
+		probe.Stop()
+	}
 }
@@ -1,17 +1,26 @@
 package router
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 var nilPID *actor.PID
 
+// pidSetOf matches a PIDSet holding exactly pids, in order, whatever the removals it went through
+func pidSetOf(pids ...*actor.PID) interface{} {
+	return mock.MatchedBy(func(set *actor.PIDSet) bool {
+		return assert.ObjectsAreEqual(pids, set.Values())
+	})
+}
+
 func init() {
 	// discard all logging in tests
 	log.SetOutput(ioutil.Discard)
@@ -65,18 +74,50 @@ func (m *mockContext) Respond(response interface{}) {
 	m.Called(response)
 }
 
+func (m *mockContext) RespondError(err error) {
+	m.Called(err)
+}
+
 func (m *mockContext) Stash() {
 	m.Called()
 }
 
+func (m *mockContext) Become(receive actor.ReceiveFunc) {
+	m.Called(receive)
+}
+
+func (m *mockContext) BecomeStacked(receive actor.ReceiveFunc) {
+	m.Called(receive)
+}
+
+func (m *mockContext) UnbecomeStacked() {
+	m.Called()
+}
+
+func (m *mockContext) BecomeStashed(receive actor.ReceiveFunc, accept func(message interface{}) bool) {
+	m.Called(receive, accept)
+}
+
 func (m *mockContext) Watch(pid *actor.PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) WatchWith(pid *actor.PID, message interface{}) {
+	m.Called(pid, message)
+}
+
+func (m *mockContext) WatchWithTimeout(pid *actor.PID, d time.Duration) {
+	m.Called(pid, d)
+}
+
 func (m *mockContext) Unwatch(pid *actor.PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) UnwatchAll() {
+	m.Called()
+}
+
 func (m *mockContext) SetReceiveTimeout(d time.Duration) {
 	m.Called(d)
 }
@@ -93,6 +134,43 @@ func (m *mockContext) AwaitFuture(f *actor.Future, cont func(res interface{}, er
 	m.Called(f, cont)
 }
 
+func (m *mockContext) StopReason() actor.StopReason {
+	args := m.Called()
+	return args.Get(0).(actor.StopReason)
+}
+
+func (m *mockContext) RunBlocking(run func() (interface{}, error), cont func(res interface{}, err error)) {
+	m.Called(run, cont)
+}
+
+func (m *mockContext) RunBlockingExclusive(run func() (interface{}, error), cont func(res interface{}, err error)) {
+	m.Called(run, cont)
+}
+
+func (m *mockContext) RunBlockingContext(run func(c context.Context) (interface{}, error), cont func(res interface{}, err error)) {
+	m.Called(run, cont)
+}
+
+func (m *mockContext) GoContext() context.Context {
+	args := m.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (m *mockContext) MessageGoContext() context.Context {
+	args := m.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (m *mockContext) SendLater(pid *actor.PID, message interface{}, delay time.Duration) actor.CancelFunc {
+	args := m.Called(pid, message, delay)
+	return args.Get(0).(actor.CancelFunc)
+}
+
+func (m *mockContext) RequestLater(pid *actor.PID, message interface{}, delay time.Duration) actor.CancelFunc {
+	args := m.Called(pid, message, delay)
+	return args.Get(0).(actor.CancelFunc)
+}
+
 //
 // Interface: SenderContext
 //
@@ -143,6 +221,16 @@ func (m *mockContext) RequestFuture(pid *actor.PID, message interface{}, timeout
 	return args.Get(0).(*actor.Future)
 }
 
+func (m *mockContext) RequestWithCorrelation(pid *actor.PID, message interface{}, timeout time.Duration) *actor.Future {
+	args := m.Called(pid, message, timeout)
+	return args.Get(0).(*actor.Future)
+}
+
+func (m *mockContext) RequestAggregate(pids []*actor.PID, message interface{}, count int, timeout time.Duration) *actor.AggregatorFuture {
+	args := m.Called(pids, message, count, timeout)
+	return args.Get(0).(*actor.AggregatorFuture)
+}
+
 //
 // Interface: ReceiverContext
 //
@@ -151,6 +239,23 @@ func (m *mockContext) Receive(envelope *actor.MessageEnvelope) {
 	m.Called(envelope)
 }
 
+//
+// Interface: storage
+//
+
+func (m *mockContext) SetLocal(key *actor.LocalKey, value interface{}) {
+	m.Called(key, value)
+}
+
+func (m *mockContext) GetLocal(key *actor.LocalKey) (interface{}, bool) {
+	args := m.Called(key)
+	return args.Get(0), args.Bool(1)
+}
+
+func (m *mockContext) DeleteLocal(key *actor.LocalKey) {
+	m.Called(key)
+}
+
 //
 // Interface: SpawnerContext
 //
@@ -160,6 +265,11 @@ func (m *mockContext) Spawn(p *actor.Props) *actor.PID {
 	return args.Get(0).(*actor.PID)
 }
 
+func (m *mockContext) SpawnChecked(p *actor.Props) (*actor.PID, error) {
+	args := m.Called(p)
+	return args.Get(0).(*actor.PID), args.Error(1)
+}
+
 func (m *mockContext) SpawnPrefix(p *actor.Props, prefix string) *actor.PID {
 	args := m.Called(p, prefix)
 	return args.Get(0).(*actor.PID)
@@ -52,13 +52,14 @@ func (config *PoolRouter) RouterType() RouterType {
 	return PoolRouterType
 }
 
-func spawner(config RouterConfig) actor.SpawnFunc {
+func spawner(config RouterConfig, opts ...Option) actor.SpawnFunc {
+	options := newOptions(opts)
 	return func(actorSystem *actor.ActorSystem, id string, props *actor.Props, parentContext actor.SpawnerContext) (*actor.PID, error) {
-		return spawn(actorSystem, id, config, props, parentContext)
+		return spawn(actorSystem, id, config, options, props, parentContext)
 	}
 }
 
-func spawn(actorSystem *actor.ActorSystem, id string, config RouterConfig, props *actor.Props, parentContext actor.SpawnerContext) (*actor.PID, error) {
+func spawn(actorSystem *actor.ActorSystem, id string, config RouterConfig, options *options, props *actor.Props, parentContext actor.SpawnerContext) (*actor.PID, error) {
 	ref := &process{
 		actorSystem: actorSystem,
 	}
@@ -70,6 +71,9 @@ func spawn(actorSystem *actor.ActorSystem, id string, config RouterConfig, props
 	var pc = *props
 	pc.WithSpawnFunc(nil)
 	ref.state = config.CreateRouterState()
+	if options.maxInFlight > 0 {
+		ref.state = newInFlightState(ref.state, proxy, actorSystem, options)
+	}
 
 	if config.RouterType() == GroupRouterType {
 		wg := &sync.WaitGroup{}
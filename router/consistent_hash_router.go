@@ -32,6 +32,10 @@ func (state *consistentHashRouterState) SetSender(sender actor.SenderContext) {
 }
 
 func (state *consistentHashRouterState) SetRoutees(routees *actor.PIDSet) {
+	state.hmc = newHashmapContainer(routees)
+}
+
+func newHashmapContainer(routees *actor.PIDSet) *hashmapContainer {
 	// lookup from node name to PID
 	hmc := hashmapContainer{}
 	hmc.routeeMap = make(map[string]*actor.PID)
@@ -43,7 +47,32 @@ func (state *consistentHashRouterState) SetRoutees(routees *actor.PIDSet) {
 	})
 	// initialize hashring for mapping message keys to node names
 	hmc.hashring = hashring.New(nodes)
-	state.hmc = &hmc
+	return &hmc
+}
+
+// get returns the routee of key
+func (hmc *hashmapContainer) get(key string) (*actor.PID, bool) {
+	node, ok := hmc.hashring.GetNode(key)
+	if !ok {
+		return nil, false
+	}
+	routee, ok := hmc.routeeMap[node]
+	return routee, ok
+}
+
+// Ring maps the keys to the routees as the consistent hash routers do, e.g. to batch the messages per routee
+type Ring struct {
+	hmc *hashmapContainer
+}
+
+// NewRing returns the Ring of a consistent hash router with routees
+func NewRing(routees *actor.PIDSet) *Ring {
+	return &Ring{hmc: newHashmapContainer(routees)}
+}
+
+// Get returns the routee the consistent hash routers with the routees of the ring route key to
+func (r *Ring) Get(key string) (*actor.PID, bool) {
+	return r.hmc.get(key)
 }
 
 func (state *consistentHashRouterState) GetRoutees() *actor.PIDSet {
@@ -81,14 +110,19 @@ func (state *consistentHashRouterState) InvokeRouterManagementMessage(msg Manage
 
 }
 
-func NewConsistentHashPool(size int) *actor.Props {
-	return (&actor.Props{}).WithSpawnFunc(spawner(&consistentHashPoolRouter{PoolRouter{PoolSize: size}}))
+func NewConsistentHashPool(size int, opts ...Option) *actor.Props {
+	return (&actor.Props{}).WithSpawnFunc(spawner(&consistentHashPoolRouter{PoolRouter{PoolSize: size}}, opts...))
 }
 
 func NewConsistentHashGroup(routees ...*actor.PID) *actor.Props {
 	return (&actor.Props{}).WithSpawnFunc(spawner(&consistentHashGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}}))
 }
 
+// NewConsistentHashGroupWithOptions is NewConsistentHashGroup configured with opts
+func NewConsistentHashGroupWithOptions(routees []*actor.PID, opts ...Option) *actor.Props {
+	return (&actor.Props{}).WithSpawnFunc(spawner(&consistentHashGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}}, opts...))
+}
+
 func (config *consistentHashPoolRouter) CreateRouterState() State {
 	return &consistentHashRouterState{}
 }
@@ -98,3 +98,35 @@ func TestConcurrency(t *testing.T) {
 	system.Root.Send(pid, &getRoutees{rpid})
 	wait.Wait()
 }
+
+func TestRing(t *testing.T) {
+	keys := make(chan string, 10)
+	routees := make([]*actor.PID, 3)
+	for i := range routees {
+		routees[i] = system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+			if msg, ok := ctx.Message().(*myMessage); ok {
+				keys <- ctx.Self().Id + "/" + msg.Hash()
+			}
+		}))
+		defer system.Root.Stop(routees[i])
+	}
+	rpid := system.Root.Spawn(router.NewConsistentHashGroup(routees...))
+	defer system.Root.Stop(rpid)
+
+	// the ring maps the keys to the routees the router routes them to
+	ring := router.NewRing(actor.NewPIDSet(routees...))
+	for i := 0; i < 10; i++ {
+		system.Root.Send(rpid, &myMessage{i: int32(i)})
+		routee, ok := ring.Get(strconv.Itoa(i))
+		if !ok {
+			t.Fatal("no routee")
+		}
+		if got := <-keys; got != routee.Id+"/"+strconv.Itoa(i) {
+			t.Fatalf("routed %v, the ring maps it to %v", got, routee.Id)
+		}
+	}
+
+	if _, ok := router.NewRing(actor.NewPIDSet()).Get("a"); ok {
+		t.Fatal("the empty ring has no routee")
+	}
+}
@@ -0,0 +1,329 @@
+package router
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// ErrBusy fails the requests a router capping the messages in flight per routee can't route, queue or spill
+var ErrBusy = &actor.ActorError{Message: "every routee is at its in-flight cap", Code: "Busy", Retryable: true}
+
+// inFlightHeader holds the id of the router which sent a message other than a request, for Done
+const inFlightHeader = "router-in-flight"
+
+// RouteeDone completes a message which is not a request, for the routers capping the messages in flight per routee.
+// Routees send it with Done
+type RouteeDone struct {
+	Routee *actor.PID
+}
+
+// Done completes the message the routee is processing, when its router caps the messages in flight per routee. The
+// requests complete with their response: Done does nothing for them, nor for the messages of other routers
+func Done(ctx actor.Context) {
+	header := ctx.MessageHeader()
+	if header == nil {
+		return
+	}
+	if id := header.Get(inFlightHeader); id != "" {
+		ctx.Send(ctx.ActorSystem().NewLocalPID(id), &RouteeDone{Routee: ctx.Self()})
+	}
+}
+
+// Option configures a router
+type Option func(*options)
+
+type options struct {
+	maxInFlight int
+	queueSize   int
+	overflow    *actor.PID
+}
+
+// MaxInFlightPerRoutee caps the messages sent to a routee and not completed yet to n. A request completes when its
+// response passes through the router on its way to the requester, another message when the routee calls Done, else
+// it stays in flight until the routee stops.
+//
+// A message for a routee at its cap goes to the least busy routee under it. When every routee is at its cap the
+// message is queued, see WithOverflowQueue, else sent to the overflow PID, see WithOverflowPID, else the requests
+// fail with ErrBusy and the other messages are dead lettered
+func MaxInFlightPerRoutee(n int) Option {
+	return func(o *options) {
+		o.maxInFlight = n
+	}
+}
+
+// WithOverflowQueue queues up to size messages while every routee is at its cap, they are sent to the routees
+// completing messages
+func WithOverflowQueue(size int) Option {
+	return func(o *options) {
+		o.queueSize = size
+	}
+}
+
+// WithOverflowPID sends to pid the messages no routee under its cap nor the overflow queue can take
+func WithOverflowPID(pid *actor.PID) Option {
+	return func(o *options) {
+		o.overflow = pid
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// inFlightState caps the messages in flight per routee of the state it decorates: the decorated state picks the
+// routees, and inFlightState overrides the picks at their cap
+type inFlightState struct {
+	State
+	options     *options
+	router      *actor.PID
+	actorSystem *actor.ActorSystem
+	sender      actor.SenderContext
+
+	mu      sync.Mutex
+	routees []*inFlightRoutee // in the order of the routees
+	byPID   map[pidKey]*inFlightRoutee
+	queue   []interface{}
+}
+
+// pidKey is comparable, so that looking a routee up does not allocate a key
+type pidKey struct {
+	address string
+	id      string
+}
+
+func keyOf(pid *actor.PID) pidKey {
+	return pidKey{address: pid.Address, id: pid.Id}
+}
+
+type inFlightRoutee struct {
+	pid      *actor.PID
+	inFlight int
+	acks     map[*ackProcess]struct{} // the requests in flight
+}
+
+func newInFlightState(state State, router *actor.PID, actorSystem *actor.ActorSystem, options *options) *inFlightState {
+	return &inFlightState{
+		State:       state,
+		options:     options,
+		router:      router,
+		actorSystem: actorSystem,
+		byPID:       make(map[pidKey]*inFlightRoutee),
+	}
+}
+
+// cappedSender sends the messages the decorated state routes within the caps
+type cappedSender struct {
+	actor.SenderContext
+	state *inFlightState
+}
+
+func (sender *cappedSender) Send(pid *actor.PID, message interface{}) {
+	sender.state.route(pid, message)
+}
+
+func (state *inFlightState) SetSender(sender actor.SenderContext) {
+	state.sender = sender
+	state.State.SetSender(&cappedSender{SenderContext: sender, state: state})
+}
+
+func (state *inFlightState) SetRoutees(routees *actor.PIDSet) {
+	state.State.SetRoutees(routees)
+
+	state.mu.Lock()
+	byPID := make(map[pidKey]*inFlightRoutee, routees.Len())
+	list := make([]*inFlightRoutee, 0, routees.Len())
+	routees.ForEach(func(_ int, pid *actor.PID) {
+		key := keyOf(pid)
+		r, ok := state.byPID[key]
+		if !ok {
+			r = &inFlightRoutee{pid: pid, acks: make(map[*ackProcess]struct{})}
+		}
+		byPID[key] = r
+		list = append(list, r)
+	})
+	// the requests in flight to the removed routees are forgotten
+	var forgotten []*ackProcess
+	for key, r := range state.byPID {
+		if _, ok := byPID[key]; !ok {
+			for ack := range r.acks {
+				forgotten = append(forgotten, ack)
+			}
+		}
+	}
+	state.byPID, state.routees = byPID, list
+
+	// the new routees take the queued messages
+	var sends []routedMessage
+	for len(state.queue) > 0 {
+		r := state.leastBusy()
+		if r == nil {
+			break
+		}
+		sends = append(sends, state.dequeue(r))
+	}
+	state.mu.Unlock()
+
+	for _, ack := range forgotten {
+		ack.forget()
+	}
+	state.send(sends...)
+}
+
+type routedMessage struct {
+	pid     *actor.PID
+	message interface{}
+}
+
+// route sends message to pid, or to the least busy routee when pid is at its cap
+func (state *inFlightState) route(pid *actor.PID, message interface{}) {
+	state.mu.Lock()
+	r, ok := state.byPID[keyOf(pid)]
+	if !ok || r.inFlight >= state.options.maxInFlight {
+		r = state.leastBusy()
+	}
+	if r == nil {
+		if len(state.queue) < state.options.queueSize {
+			state.queue = append(state.queue, message)
+			state.mu.Unlock()
+			return
+		}
+		state.mu.Unlock()
+		state.overflow(message)
+		return
+	}
+	routed := state.track(r, message)
+	state.mu.Unlock()
+	state.send(routed)
+}
+
+// leastBusy returns the routee with the fewest messages in flight under its cap, nil when every routee is at it. The
+// lock is held
+func (state *inFlightState) leastBusy() *inFlightRoutee {
+	var least *inFlightRoutee
+	for _, r := range state.routees {
+		if r.inFlight < state.options.maxInFlight && (least == nil || r.inFlight < least.inFlight) {
+			least = r
+		}
+	}
+	return least
+}
+
+// track counts message in flight to r, and has its completion reported. The lock is held
+func (state *inFlightState) track(r *inFlightRoutee, message interface{}) routedMessage {
+	r.inFlight++
+	var envelope *actor.MessageEnvelope
+	if env, ok := message.(*actor.MessageEnvelope); ok {
+		envelope = env.Copy()
+	} else {
+		envelope = &actor.MessageEnvelope{Message: message}
+	}
+
+	if envelope.Sender != nil {
+		// the response passes through an ack process on its way to the requester
+		ack := &ackProcess{state: state, routee: r, replyTo: envelope.Sender}
+		ack.pid, _ = state.actorSystem.ProcessRegistry.Add(ack, "inflight"+state.actorSystem.ProcessRegistry.NextId())
+		r.acks[ack] = struct{}{}
+		envelope.Sender = ack.pid
+	} else {
+		envelope.SetHeader(inFlightHeader, state.router.Id)
+	}
+	return routedMessage{pid: r.pid, message: envelope}
+}
+
+// dequeue routes the oldest queued message to r. The lock is held
+func (state *inFlightState) dequeue(r *inFlightRoutee) routedMessage {
+	message := state.queue[0]
+	state.queue[0] = nil
+	state.queue = state.queue[1:]
+	return state.track(r, message)
+}
+
+func (state *inFlightState) send(messages ...routedMessage) {
+	for _, m := range messages {
+		state.sender.Send(m.pid, m.message)
+	}
+}
+
+// complete counts a message of r out of flight, r takes the oldest queued message if any
+func (state *inFlightState) complete(r *inFlightRoutee, ack *ackProcess) {
+	state.mu.Lock()
+	if ack != nil {
+		if _, ok := r.acks[ack]; !ok {
+			// forgotten with its routee
+			state.mu.Unlock()
+			return
+		}
+		delete(r.acks, ack)
+	}
+	if r.inFlight > 0 {
+		r.inFlight--
+	}
+	var sends []routedMessage
+	if len(state.queue) > 0 && state.byPID[keyOf(r.pid)] == r {
+		sends = append(sends, state.dequeue(r))
+	}
+	state.mu.Unlock()
+	state.send(sends...)
+}
+
+// routeeDone completes a message which is not a request
+func (state *inFlightState) routeeDone(routee *actor.PID) {
+	state.mu.Lock()
+	r, ok := state.byPID[keyOf(routee)]
+	state.mu.Unlock()
+	if ok {
+		state.complete(r, nil)
+	}
+}
+
+// overflow sends the message no routee can take to the overflow PID, or fails it
+func (state *inFlightState) overflow(message interface{}) {
+	if state.options.overflow != nil {
+		state.sender.Send(state.options.overflow, message)
+		return
+	}
+	if sender := actor.UnwrapEnvelopeSender(message); sender != nil {
+		state.sender.Send(sender, actor.NewErrorResponse(ErrBusy))
+		return
+	}
+	state.actorSystem.DeadLetter.SendUserMessage(state.router, message)
+}
+
+// ackProcess completes a request in flight when its response passes through, on its way to the requester
+type ackProcess struct {
+	pid     *actor.PID
+	state   *inFlightState
+	routee  *inFlightRoutee
+	replyTo *actor.PID
+	done    int32
+}
+
+func (ack *ackProcess) SendUserMessage(_ *actor.PID, message interface{}) {
+	if !atomic.CompareAndSwapInt32(&ack.done, 0, 1) {
+		return
+	}
+	ack.state.actorSystem.ProcessRegistry.Remove(ack.pid)
+	ack.state.complete(ack.routee, ack)
+	if r, ok := ack.state.actorSystem.ProcessRegistry.Get(ack.replyTo); ok {
+		r.SendUserMessage(ack.replyTo, message)
+	}
+}
+
+func (ack *ackProcess) SendSystemMessage(_ *actor.PID, _ interface{}) {}
+
+func (ack *ackProcess) Stop(pid *actor.PID) {
+	ack.forget()
+}
+
+// forget unregisters the ack process, its response is not awaited anymore
+func (ack *ackProcess) forget() {
+	if atomic.CompareAndSwapInt32(&ack.done, 0, 1) {
+		ack.state.actorSystem.ProcessRegistry.Remove(ack.pid)
+	}
+}
@@ -0,0 +1,182 @@
+package router
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type work struct{ i int }
+
+// slowRoutees process each work once the gate is open, and count the works they received
+type slowRoutees struct {
+	gate chan struct{}
+	done bool // the routees call Done
+
+	mu       sync.Mutex
+	received map[string]int
+}
+
+func newSlowRoutees() *slowRoutees {
+	return &slowRoutees{gate: make(chan struct{}), received: make(map[string]int)}
+}
+
+func (r *slowRoutees) receive(ctx actor.Context) {
+	if msg, ok := ctx.Message().(*work); ok {
+		r.mu.Lock()
+		r.received[ctx.Self().Id]++
+		r.mu.Unlock()
+		<-r.gate
+		if ctx.Sender() != nil {
+			ctx.Respond(msg.i)
+		}
+		if r.done {
+			Done(ctx)
+		}
+	}
+}
+
+func (r *slowRoutees) counts() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make([]int, 0, len(r.received))
+	for _, n := range r.received {
+		counts = append(counts, n)
+	}
+	return counts
+}
+
+func (r *slowRoutees) total() int {
+	total := 0
+	for _, n := range r.counts() {
+		total += n
+	}
+	return total
+}
+
+func TestMaxInFlightPerRoutee_Overflow(t *testing.T) {
+	routees := newSlowRoutees()
+	overflowed := make(chan int, 10)
+	overflow := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*work); ok {
+			overflowed <- msg.i
+			ctx.Respond(-msg.i)
+		}
+	}))
+	defer system.Root.Stop(overflow)
+	pid := system.Root.Spawn(NewRoundRobinPool(2, MaxInFlightPerRoutee(2), WithOverflowPID(overflow)).WithFunc(routees.receive))
+	defer system.Root.Stop(pid)
+
+	futures := make([]*actor.Future, 10)
+	for i := range futures {
+		futures[i] = system.Root.RequestFuture(pid, &work{i}, time.Second)
+	}
+	// the routees got their cap, the overflow PID the rest
+	for i := 0; i < 6; i++ {
+		select {
+		case <-overflowed:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the overflow")
+		}
+	}
+	close(routees.gate)
+	for i, f := range futures {
+		res, err := f.Result()
+		require.NoError(t, err)
+		assert.Contains(t, []interface{}{i, -i}, res)
+	}
+	assert.ElementsMatch(t, []int{2, 2}, routees.counts())
+
+	// the responses completed the requests in flight
+	for i := 0; i < 4; i++ {
+		res, err := system.Root.RequestFuture(pid, &work{10 + i}, time.Second).Result()
+		require.NoError(t, err)
+		assert.Equal(t, 10+i, res)
+	}
+	assert.Empty(t, overflowed)
+	assert.Equal(t, 8, routees.total())
+}
+
+func TestMaxInFlightPerRoutee_Busy(t *testing.T) {
+	routees := newSlowRoutees()
+	pid := system.Root.Spawn(NewRandomPool(1, MaxInFlightPerRoutee(1)).WithFunc(routees.receive))
+	defer system.Root.Stop(pid)
+
+	first := system.Root.RequestFuture(pid, &work{1}, time.Second)
+	_, err := system.Root.RequestFuture(pid, &work{2}, time.Second).Result()
+	assert.Equal(t, &actor.ActorError{Message: ErrBusy.Message, Code: "Busy", Retryable: true}, err)
+
+	close(routees.gate)
+	res, err := first.Result()
+	require.NoError(t, err)
+	assert.Equal(t, 1, res)
+	assert.Equal(t, []int{1}, routees.counts())
+}
+
+func TestMaxInFlightPerRoutee_Queue(t *testing.T) {
+	routees := newSlowRoutees()
+	pid := system.Root.Spawn(NewRoundRobinPool(2, MaxInFlightPerRoutee(1), WithOverflowQueue(4)).WithFunc(routees.receive))
+	defer system.Root.Stop(pid)
+
+	futures := make([]*actor.Future, 6)
+	for i := range futures {
+		futures[i] = system.Root.RequestFuture(pid, &work{i}, time.Second)
+	}
+	// the queue is full
+	_, err := system.Root.RequestFuture(pid, &work{6}, time.Second).Result()
+	assert.EqualError(t, err, ErrBusy.Error())
+	require.Eventually(t, func() bool { return routees.total() == 2 }, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []int{1, 1}, routees.counts())
+
+	close(routees.gate)
+	for i, f := range futures {
+		res, err := f.Result()
+		require.NoError(t, err)
+		assert.Equal(t, i, res)
+	}
+	assert.Equal(t, 6, routees.total())
+}
+
+func TestMaxInFlightPerRoutee_Done(t *testing.T) {
+	for _, done := range []bool{true, false} {
+		routees := newSlowRoutees()
+		routees.done = done
+		close(routees.gate)
+		routee := system.Root.Spawn(actor.PropsFromFunc(routees.receive))
+		pid := system.Root.Spawn(NewRoundRobinGroupWithOptions([]*actor.PID{routee}, MaxInFlightPerRoutee(1), WithOverflowQueue(10)))
+
+		for i := 0; i < 5; i++ {
+			system.Root.Send(pid, &work{i})
+		}
+		if done {
+			require.Eventually(t, func() bool { return routees.total() == 5 }, time.Second, time.Millisecond)
+		} else {
+			// the messages stay in flight, only the first one was sent
+			time.Sleep(20 * time.Millisecond)
+			assert.Equal(t, 1, routees.total())
+		}
+		system.Root.Stop(pid)
+		system.Root.Stop(routee)
+	}
+}
+
+func TestMaxInFlightPerRoutee_AddedRouteeTakesQueue(t *testing.T) {
+	routees := newSlowRoutees()
+	pid := system.Root.Spawn(NewRoundRobinPool(1, MaxInFlightPerRoutee(1), WithOverflowQueue(1)).WithFunc(routees.receive))
+	defer system.Root.Stop(pid)
+
+	system.Root.Send(pid, &work{1})
+	system.Root.Send(pid, &work{2})
+	require.Eventually(t, func() bool { return routees.total() == 1 }, time.Second, time.Millisecond)
+
+	added := system.Root.Spawn(actor.PropsFromFunc(routees.receive))
+	defer system.Root.Stop(added)
+	system.Root.Send(pid, &AddRoutee{PID: added})
+	require.Eventually(t, func() bool { return routees.total() == 2 }, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []int{1, 1}, routees.counts())
+	close(routees.gate)
+}
@@ -21,6 +21,12 @@ type process struct {
 
 func (ref *process) SendUserMessage(pid *actor.PID, message interface{}) {
 	_, msg, _ := actor.UnwrapEnvelope(message)
+	if done, ok := msg.(*RouteeDone); ok {
+		if state, ok := ref.state.(*inFlightState); ok {
+			state.routeeDone(done.Routee)
+		}
+		return
+	}
 	if _, ok := msg.(ManagementMessage); !ok {
 		ref.state.RouteMessage(message)
 	} else {
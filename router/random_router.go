@@ -35,14 +35,19 @@ func (state *randomRouterState) RouteMessage(message interface{}) {
 	state.sender.Send(pid, message)
 }
 
-func NewRandomPool(size int) *actor.Props {
-	return (&actor.Props{}).WithSpawnFunc(spawner(&randomPoolRouter{PoolRouter{PoolSize: size}}))
+func NewRandomPool(size int, opts ...Option) *actor.Props {
+	return (&actor.Props{}).WithSpawnFunc(spawner(&randomPoolRouter{PoolRouter{PoolSize: size}}, opts...))
 }
 
 func NewRandomGroup(routees ...*actor.PID) *actor.Props {
 	return (&actor.Props{}).WithSpawnFunc(spawner(&randomGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}}))
 }
 
+// NewRandomGroupWithOptions is NewRandomGroup configured with opts
+func NewRandomGroupWithOptions(routees []*actor.PID, opts ...Option) *actor.Props {
+	return (&actor.Props{}).WithSpawnFunc(spawner(&randomGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}}, opts...))
+}
+
 func (config *randomPoolRouter) CreateRouterState() State {
 	return &randomRouterState{}
 }
@@ -36,14 +36,19 @@ func (state *roundRobinState) RouteMessage(message interface{}) {
 	state.sender.Send(pid, message)
 }
 
-func NewRoundRobinPool(size int) *actor.Props {
-	return (&actor.Props{}).WithSpawnFunc(spawner(&roundRobinPoolRouter{PoolRouter{PoolSize: size}}))
+func NewRoundRobinPool(size int, opts ...Option) *actor.Props {
+	return (&actor.Props{}).WithSpawnFunc(spawner(&roundRobinPoolRouter{PoolRouter{PoolSize: size}}, opts...))
 }
 
 func NewRoundRobinGroup(routees ...*actor.PID) *actor.Props {
 	return (&actor.Props{}).WithSpawnFunc(spawner(&roundRobinGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}}))
 }
 
+// NewRoundRobinGroupWithOptions is NewRoundRobinGroup configured with opts
+func NewRoundRobinGroupWithOptions(routees []*actor.PID, opts ...Option) *actor.Props {
+	return (&actor.Props{}).WithSpawnFunc(spawner(&roundRobinGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}}, opts...))
+}
+
 func (config *roundRobinPoolRouter) CreateRouterState() State {
 	return &roundRobinState{}
 }
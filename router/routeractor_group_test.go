@@ -56,7 +56,7 @@ func TestGroupRouterActor_Receive_RemoveRoute(t *testing.T) {
 		}).Once()
 
 	state.On("GetRoutees").Return(actor.NewPIDSet(p1, p2))
-	state.On("SetRoutees", actor.NewPIDSet(p2)).Once()
+	state.On("SetRoutees", pidSetOf(p2)).Once()
 
 	a.Receive(c)
 	mock.AssertExpectationsForObjects(t, state, c)
@@ -0,0 +1,198 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// ownerKey identifies the actor owning timers
+type ownerKey struct {
+	system *actor.ActorSystem
+	id     string
+}
+
+// owners are the schedulers with pending timers of the actors, by ownerKey.
+// The actors are only registered while they have timers.
+var (
+	ownersMu sync.Mutex
+	owners   = make(map[ownerKey]map[*actorTimers]struct{})
+)
+
+// actorTimers are the timers started by a scheduler of an actor, they are cancelled when it stops or restarts
+type actorTimers struct {
+	key ownerKey
+	ctx actor.Context
+
+	mu      sync.Mutex
+	nextID  int
+	timers  map[int]CancelFunc
+	named   map[string]CancelFunc
+	stopped bool
+}
+
+func ownerOf(ctx actor.Context) *actorTimers {
+	return &actorTimers{
+		key:    ownerKey{system: ctx.ActorSystem(), id: ctx.Self().Id},
+		ctx:    ctx,
+		timers: make(map[int]CancelFunc),
+		named:  make(map[string]CancelFunc),
+	}
+}
+
+// track starts a timer with start, it is told whether the actor is still alive when the timer fires.
+// Once fired, one-shot timers call done.
+func (o *actorTimers) track(start func(alive func() bool, done func()) CancelFunc) CancelFunc {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.timers) == 0 && !o.stopped {
+		o.register()
+	}
+	if o.stopped {
+		return func() {}
+	}
+
+	id := o.nextID
+	o.nextID++
+	remove := func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if _, ok := o.timers[id]; ok {
+			delete(o.timers, id)
+			if len(o.timers) == 0 {
+				o.unregister()
+			}
+		}
+	}
+	cancel := start(o.alive, remove)
+	o.timers[id] = cancel
+	return func() {
+		cancel()
+		remove()
+	}
+}
+
+// register makes the timers found by the TimerPlugin, it is called with the lock held
+func (o *actorTimers) register() {
+	ownersMu.Lock()
+	defer ownersMu.Unlock()
+	set, ok := owners[o.key]
+	if !ok {
+		set = make(map[*actorTimers]struct{})
+		owners[o.key] = set
+	}
+	set[o] = struct{}{}
+}
+
+func (o *actorTimers) unregister() {
+	ownersMu.Lock()
+	defer ownersMu.Unlock()
+	if set, ok := owners[o.key]; ok {
+		delete(set, o)
+		if len(set) == 0 {
+			delete(owners, o.key)
+		}
+	}
+}
+
+// alive tells whether the actor still runs, the timers of the actors stopped without the TimerPlugin are cancelled
+// the next time they fire
+func (o *actorTimers) alive() bool {
+	if _, ok := o.ctx.ActorSystem().ProcessRegistry.GetLocal(o.key.id); ok {
+		return true
+	}
+	go o.cancelAll(true)
+	return false
+}
+
+// cancelAll cancels the timers, no timer is started anymore once the actor stopped
+func (o *actorTimers) cancelAll(stopped bool) {
+	o.mu.Lock()
+	o.stopped = o.stopped || stopped
+	timers := o.timers
+	o.timers = make(map[int]CancelFunc)
+	o.named = make(map[string]CancelFunc)
+	o.unregister()
+	o.mu.Unlock()
+
+	for _, cancel := range timers {
+		cancel()
+	}
+}
+
+func (o *actorTimers) startNamed(name string, start func() CancelFunc) {
+	o.mu.Lock()
+	previous := o.named[name]
+	o.mu.Unlock()
+	if previous != nil {
+		previous()
+	}
+
+	cancel := start()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.named[name] = cancel
+}
+
+// StartTimer sends message to the actor owning the scheduler after delay, it replaces the timer named name if any.
+// It panics when the scheduler was not created with an actor.Context.
+func (s *TimerScheduler) StartTimer(name string, delay time.Duration, message interface{}) {
+	o := s.mustOwner()
+	o.startNamed(name, func() CancelFunc {
+		return s.SendOnce(delay, o.ctx.Self(), message)
+	})
+}
+
+// StartPeriodicTimer sends message to the actor owning the scheduler after initial, then every interval.
+// It replaces the timer named name if any, and panics when the scheduler was not created with an actor.Context.
+func (s *TimerScheduler) StartPeriodicTimer(name string, initial, interval time.Duration, message interface{}, opts ...RepeatOption) {
+	o := s.mustOwner()
+	o.startNamed(name, func() CancelFunc {
+		return s.SendRepeatedly(initial, interval, o.ctx.Self(), message, opts...)
+	})
+}
+
+// CancelTimer cancels the timer named name, the message it sent already may still be received
+func (s *TimerScheduler) CancelTimer(name string) {
+	o := s.mustOwner()
+	o.mu.Lock()
+	cancel := o.named[name]
+	delete(o.named, name)
+	o.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// CancelAll cancels the timers of the actor owning the scheduler, it happens when it stops,
+// or restarts with the TimerPlugin
+func (s *TimerScheduler) CancelAll() {
+	s.mustOwner().cancelAll(false)
+}
+
+func (s *TimerScheduler) mustOwner() *actorTimers {
+	if s.owner == nil {
+		panic("scheduler: the timer scheduler is not owned by an actor")
+	}
+	return s.owner
+}
+
+// TimerPlugin cancels the timers of the actors when they stop or restart, it is used with plugin.Use
+type TimerPlugin struct{}
+
+func (p *TimerPlugin) OnStart(ctx actor.ReceiverContext) {}
+
+func (p *TimerPlugin) OnOtherMessage(ctx actor.ReceiverContext, env *actor.MessageEnvelope) {
+	switch env.Message.(type) {
+	case *actor.Stopping, *actor.Restarting:
+		key := ownerKey{system: ctx.ActorSystem(), id: ctx.Self().Id}
+		ownersMu.Lock()
+		set := owners[key]
+		delete(owners, key)
+		ownersMu.Unlock()
+		for owner := range set {
+			owner.cancelAll(true)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type startTicking struct{}
+type fail struct{}
+
+// tickingActor counts the ticks of the periodic timer it starts when asked to
+func tickingActor(ticks *int32) actor.Producer {
+	return func() actor.Actor {
+		var timers *TimerScheduler
+		return actor.ReceiveFunc(func(ctx actor.Context) {
+			switch ctx.Message().(type) {
+			case *actor.Started:
+				timers = NewTimerScheduler(ctx)
+			case *startTicking:
+				timers.StartPeriodicTimer("tick", time.Millisecond, time.Millisecond, "tick")
+			case *fail:
+				panic("failed")
+			case string:
+				atomic.AddInt32(ticks, 1)
+			}
+		})
+	}
+}
+
+func spawnTicking(ticks *int32) *actor.PID {
+	props := actor.PropsFromProducer(tickingActor(ticks)).WithReceiverMiddleware(plugin.Use(&TimerPlugin{}))
+	return system.Root.Spawn(props)
+}
+
+// assertStopsTicking asserts that ticks stops increasing
+func assertStopsTicking(t *testing.T, ticks *int32) {
+	time.Sleep(10 * time.Millisecond)
+	stopped := atomic.LoadInt32(ticks)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(ticks))
+}
+
+func hasTimers(pid *actor.PID) bool {
+	ownersMu.Lock()
+	defer ownersMu.Unlock()
+	_, ok := owners[ownerKey{system: system, id: pid.Id}]
+	return ok
+}
+
+func TestTimerScheduler_NamedTimers(t *testing.T) {
+	names := make(chan string, 10)
+	var timers *TimerScheduler
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			timers = NewTimerScheduler(ctx)
+			timers.StartTimer("replaced", 20*time.Millisecond, "first")
+			timers.StartTimer("replaced", time.Millisecond, "second")
+			timers.StartTimer("cancelled", time.Millisecond, "cancelled")
+			timers.CancelTimer("cancelled")
+		case string:
+			names <- msg
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	select {
+	case name := <-names:
+		assert.Equal(t, "second", name)
+	case <-time.After(time.Second):
+		t.Fatal("timer never fired")
+	}
+	select {
+	case name := <-names:
+		t.Fatalf("replaced or cancelled timer fired: %s", name)
+	case <-time.After(40 * time.Millisecond):
+	}
+	assert.False(t, hasTimers(pid))
+}
+
+func TestTimerScheduler_StopCancelsTimers(t *testing.T) {
+	var ticks int32
+	pid := spawnTicking(&ticks)
+	system.Root.Send(pid, &startTicking{})
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&ticks) > 2 }, time.Second, time.Millisecond)
+
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	stopped := atomic.LoadInt32(&ticks)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&ticks), "ticks received after the actor stopped")
+	assert.False(t, hasTimers(pid))
+}
+
+func TestTimerScheduler_RestartCancelsTimers(t *testing.T) {
+	var ticks int32
+	pid := spawnTicking(&ticks)
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+	system.Root.Send(pid, &startTicking{})
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&ticks) > 2 }, time.Second, time.Millisecond)
+
+	system.Root.Send(pid, &fail{})
+	assertStopsTicking(t, &ticks)
+	assert.False(t, hasTimers(pid))
+}
+
+func TestTimerScheduler_StopCancelsTimersWithoutPlugin(t *testing.T) {
+	var ticks int32
+	pid := system.Root.Spawn(actor.PropsFromProducer(tickingActor(&ticks)))
+	system.Root.Send(pid, &startTicking{})
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&ticks) > 2 }, time.Second, time.Millisecond)
+
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	assert.Eventually(t, func() bool { return !hasTimers(pid) }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+)
+
+// MisfirePolicy decides what a cron job does with the runs missed while the process was suspended,
+// or the clock moved forward
+type MisfirePolicy int
+
+const (
+	// SkipMisfires forgets the missed runs, the job runs next at its next time
+	SkipMisfires MisfirePolicy = iota
+	// FireOnceOnMisfire runs the job once right away for all the missed runs
+	FireOnceOnMisfire
+)
+
+// maxCronWait bounds the time a cron job sleeps, so that the jumps of the wall clock are noticed
+const maxCronWait = time.Minute
+
+type cronConfig struct {
+	location         *time.Location
+	misfire          MisfirePolicy
+	misfireThreshold time.Duration
+	clock            timerwheel.Clock
+}
+
+// CronOption configures a cron job
+type CronOption func(*cronConfig)
+
+// WithLocation evaluates the cron expression in the time zone loc rather than time.Local
+func WithLocation(loc *time.Location) CronOption {
+	return func(c *cronConfig) {
+		c.location = loc
+	}
+}
+
+// WithMisfirePolicy decides what the job does with the runs it missed, it skips them by default
+func WithMisfirePolicy(policy MisfirePolicy) CronOption {
+	return func(c *cronConfig) {
+		c.misfire = policy
+	}
+}
+
+// WithMisfireThreshold is how late a run may be before it is missed, one second by default
+func WithMisfireThreshold(threshold time.Duration) CronOption {
+	return func(c *cronConfig) {
+		c.misfireThreshold = threshold
+	}
+}
+
+func withClock(clock timerwheel.Clock) CronOption {
+	return func(c *cronConfig) {
+		c.clock = clock
+	}
+}
+
+// CronJob runs at the times matching a cron expression until it is cancelled
+type CronJob struct {
+	schedule *cronSchedule
+	config   cronConfig
+	run      func()
+	done     func()
+	cancel   CancelFunc
+
+	mu        sync.Mutex
+	next      time.Time
+	stop      func() bool
+	cancelled bool
+}
+
+// NextRun returns the time the job runs next, or the zero time when it is cancelled or never runs again
+func (j *CronJob) NextRun() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.next
+}
+
+// Cancel stops the job, a run already started still completes
+func (j *CronJob) Cancel() {
+	j.cancel()
+}
+
+func (j *CronJob) start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.scheduleAfter(j.config.clock.Now())
+}
+
+func (j *CronJob) stopTimer() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cancelled = true
+	j.next = time.Time{}
+	if j.stop != nil {
+		j.stop()
+		j.stop = nil
+	}
+}
+
+// scheduleAfter computes the next run after after and arms the timer, it is called with the lock held
+func (j *CronJob) scheduleAfter(after time.Time) {
+	j.next = j.schedule.next(after, j.config.location)
+	if j.next.IsZero() {
+		j.stop = nil
+		if j.done != nil {
+			go j.done()
+		}
+		return
+	}
+	j.arm()
+}
+
+func (j *CronJob) arm() {
+	wait := j.next.Sub(j.config.clock.Now())
+	if wait > maxCronWait {
+		wait = maxCronWait
+	}
+	j.stop = j.config.clock.AfterFunc(wait, j.tick)
+}
+
+func (j *CronJob) tick() {
+	j.mu.Lock()
+	if j.cancelled || j.next.IsZero() {
+		j.mu.Unlock()
+		return
+	}
+	now := j.config.clock.Now()
+	if now.Before(j.next) {
+		j.arm()
+		j.mu.Unlock()
+		return
+	}
+
+	missed := now.Sub(j.next) > j.config.misfireThreshold
+	if missed {
+		j.scheduleAfter(now)
+	} else {
+		j.scheduleAfter(j.next)
+	}
+	j.mu.Unlock()
+
+	if !missed || j.config.misfire == FireOnceOnMisfire {
+		j.run()
+	}
+}
+
+// SendCron sends the message returned by producer to pid at the times matching the cron expression expr.
+// The expression has 5 fields, minutes hours days-of-month months days-of-week, or 6 fields starting with seconds,
+// or is one of @yearly, @monthly, @weekly, @daily and @hourly. It is evaluated in time.Local unless configured.
+// The local times skipped when the clocks go forward never match, the ones repeated when they go back match once.
+func (s *TimerScheduler) SendCron(expr string, pid *actor.PID, producer func() interface{}, opts ...CronOption) (*CronJob, error) {
+	return s.cron(expr, func() {
+		s.ctx.Send(pid, producer())
+	}, opts)
+}
+
+func (s *TimerScheduler) cron(expr string, fn func(), opts []CronOption) (*CronJob, error) {
+	schedule, err := parseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	j := &CronJob{
+		schedule: schedule,
+		config: cronConfig{
+			location:         time.Local,
+			misfire:          SkipMisfires,
+			misfireThreshold: time.Second,
+			clock:            wheelClock{clock: s.ctx.ActorSystem().Clock()},
+		},
+	}
+	for _, opt := range opts {
+		opt(&j.config)
+	}
+
+	if s.owner == nil {
+		j.run = fn
+		j.start()
+		j.cancel = j.stopTimer
+		return j, nil
+	}
+	j.cancel = s.owner.track(func(alive func() bool, done func()) CancelFunc {
+		j.run = func() {
+			if alive() {
+				fn()
+			}
+		}
+		j.done = done
+		j.start()
+		return j.stopTimer
+	})
+	return j, nil
+}
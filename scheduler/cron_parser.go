@@ -0,0 +1,206 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed cron expression, every field is the set of the values it matches
+type cronSchedule struct {
+	seconds, minutes, hours, daysOfMonth, months, daysOfWeek uint64
+	// the days match either the day of month or the day of week when both are restricted
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondsField     = cronField{min: 0, max: 59}
+	minutesField     = cronField{min: 0, max: 59}
+	hoursField       = cronField{min: 0, max: 23}
+	daysOfMonthField = cronField{min: 1, max: 31}
+	monthsField      = cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is Sunday too
+	daysOfWeekField = cronField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// parseCron parses a cron expression of 5 fields, minutes hours days-of-month months days-of-week,
+// or of 6 fields starting with seconds. The fields are lists of values, ranges and steps such as "1,5-10,*/15".
+func parseCron(expr string) (*cronSchedule, error) {
+	if macro, ok := cronMacros[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("scheduler: cron expression %q has %d fields, 5 or 6 expected", expr, len(fields))
+	}
+
+	s := &cronSchedule{}
+	var err error
+	parsers := []struct {
+		field cronField
+		set   *uint64
+	}{
+		{secondsField, &s.seconds},
+		{minutesField, &s.minutes},
+		{hoursField, &s.hours},
+		{daysOfMonthField, &s.daysOfMonth},
+		{monthsField, &s.months},
+		{daysOfWeekField, &s.daysOfWeek},
+	}
+	for i, p := range parsers {
+		if *p.set, err = p.field.parse(fields[i]); err != nil {
+			return nil, fmt.Errorf("scheduler: cron expression %q: %w", expr, err)
+		}
+	}
+	if s.daysOfWeek&(1<<7) != 0 {
+		s.daysOfWeek |= 1
+	}
+	s.anyDayOfMonth = fields[3] == "*" || fields[3] == "?"
+	s.anyDayOfWeek = fields[5] == "*" || fields[5] == "?"
+	return s, nil
+}
+
+func (f cronField) parse(expr string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			part = part[:i]
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case part == "*" || part == "?":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			var err error
+			if low, err = f.value(part); err != nil {
+				return 0, err
+			}
+			// "5/15" starts at 5 and goes on to the maximum
+			if step > 1 {
+				high = f.max
+			} else {
+				high = low
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q, between %d and %d expected", s, f.min, f.max)
+	}
+	return v, nil
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+func (s *cronSchedule) matchesDay(date time.Time) bool {
+	if !has(s.months, int(date.Month())) {
+		return false
+	}
+	dom, dow := has(s.daysOfMonth, date.Day()), has(s.daysOfWeek, int(date.Weekday()))
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dow
+	case s.anyDayOfWeek:
+		return dom
+	}
+	return dom || dow
+}
+
+// maxCronYears bounds the search for the next run of the expressions which never match, such as "0 0 30 2 *"
+const maxCronYears = 5
+
+// next returns the first time after after matching the schedule in loc, or the zero time if there is none.
+// The local times skipped when the clocks go forward never match, the ones repeated when they go back match once.
+func (s *cronSchedule) next(after time.Time, loc *time.Location) time.Time {
+	after = after.In(loc)
+	// noon exists on every day, whatever the transitions of the time zone
+	day := time.Date(after.Year(), after.Month(), after.Day(), 12, 0, 0, 0, loc)
+	for i := 0; i < maxCronYears*366; i++ {
+		date := day.AddDate(0, 0, i)
+		if !s.matchesDay(date) {
+			continue
+		}
+		first := i == 0
+		for h := 0; h < 24; h++ {
+			if !has(s.hours, h) || first && h < after.Hour() {
+				continue
+			}
+			for m := 0; m < 60; m++ {
+				if !has(s.minutes, m) || first && h == after.Hour() && m < after.Minute() {
+					continue
+				}
+				for sec := 0; sec < 60; sec++ {
+					if !has(s.seconds, sec) {
+						continue
+					}
+					t := time.Date(date.Year(), date.Month(), date.Day(), h, m, sec, 0, loc)
+					if t.Hour() != h || t.Minute() != m {
+						// skipped by the clocks going forward
+						continue
+					}
+					if t.After(after) {
+						return t
+					}
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newYork(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	return loc
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * FOO *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := parseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	from := time.Date(2021, 6, 15, 10, 20, 30, 0, time.UTC) // a Tuesday
+	for _, tc := range []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2021, 6, 15, 10, 21, 0, 0, time.UTC)},
+		{"* * * * * *", time.Date(2021, 6, 15, 10, 20, 31, 0, time.UTC)},
+		{"*/15 * * * * *", time.Date(2021, 6, 15, 10, 20, 45, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2021, 6, 16, 9, 0, 0, 0, time.UTC)},
+		{"30 9-17/4 * * *", time.Date(2021, 6, 15, 13, 30, 0, 0, time.UTC)},
+		{"0 0 * * SAT,sun", time.Date(2021, 6, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2021, 6, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 JAN *", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// either the day of month or the day of week when both are restricted
+		{"0 0 20 * 3", time.Date(2021, 6, 16, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2021, 6, 15, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	} {
+		s, err := parseCron(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.next, s.next(from, time.UTC), tc.expr)
+	}
+}
+
+func TestCronSchedule_Next_Location(t *testing.T) {
+	loc := newYork(t)
+	s, err := parseCron("0 9 * * *")
+	require.NoError(t, err)
+
+	next := s.next(time.Date(2021, 6, 15, 12, 0, 0, 0, time.UTC), loc)
+	assert.Equal(t, time.Date(2021, 6, 15, 13, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestCronSchedule_Next_SpringForward(t *testing.T) {
+	// the clocks go from 2:00 EST to 3:00 EDT on March 14th 2021
+	loc := newYork(t)
+
+	daily, err := parseCron("30 2 * * *")
+	require.NoError(t, err)
+	next := daily.next(time.Date(2021, 3, 13, 3, 0, 0, 0, loc), loc)
+	assert.Equal(t, time.Date(2021, 3, 15, 2, 30, 0, 0, loc), next, "2:30 does not exist on the 14th")
+
+	hourly, err := parseCron("0 * * * *")
+	require.NoError(t, err)
+	next = hourly.next(time.Date(2021, 3, 14, 1, 0, 0, 0, loc), loc)
+	assert.Equal(t, time.Date(2021, 3, 14, 3, 0, 0, 0, loc), next)
+	assert.Equal(t, time.Hour, next.Sub(time.Date(2021, 3, 14, 1, 0, 0, 0, loc)))
+}
+
+func TestCronSchedule_Next_FallBack(t *testing.T) {
+	// the clocks go from 2:00 EDT back to 1:00 EST on November 7th 2021
+	loc := newYork(t)
+
+	daily, err := parseCron("30 1 * * *")
+	require.NoError(t, err)
+	first := daily.next(time.Date(2021, 11, 7, 0, 0, 0, 0, loc), loc)
+	assert.Equal(t, time.Date(2021, 11, 7, 5, 30, 0, 0, time.UTC), first.UTC(), "the first 1:30, in EDT")
+	next := daily.next(first, loc)
+	assert.Equal(t, time.Date(2021, 11, 8, 1, 30, 0, 0, loc), next, "1:30 EST does not run again")
+	next = daily.next(first.Add(time.Hour), loc)
+	assert.Equal(t, time.Date(2021, 11, 8, 1, 30, 0, 0, loc), next)
+
+	hourly, err := parseCron("0 * * * *")
+	require.NoError(t, err)
+	next = hourly.next(time.Date(2021, 11, 7, 1, 0, 0, 0, loc), loc)
+	assert.Equal(t, time.Date(2021, 11, 7, 7, 0, 0, 0, time.UTC), next.UTC(), "2:00 EST")
+}
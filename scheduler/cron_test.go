@@ -0,0 +1,153 @@
+package scheduler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runs records the times a cron job ran
+type runs struct {
+	mu    sync.Mutex
+	clock timerwheel.Clock
+	times []time.Time
+}
+
+func (r *runs) record() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.times = append(r.times, r.clock.Now())
+}
+
+func (r *runs) get() []time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Time(nil), r.times...)
+}
+
+func startCron(t *testing.T, clock *timerwheel.FakeClock, expr string, opts ...CronOption) (*CronJob, *runs) {
+	r := &runs{clock: clock}
+	job, err := NewTimerScheduler(system.Root).cron(expr, r.record, append([]CronOption{withClock(clock), WithLocation(time.UTC)}, opts...))
+	require.NoError(t, err)
+	return job, r
+}
+
+func TestCronJob_Runs(t *testing.T) {
+	clock := timerwheel.NewFakeClock(time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC))
+	job, r := startCron(t, clock, "*/20 * * * *")
+	assert.Equal(t, time.Date(2021, 6, 15, 10, 20, 0, 0, time.UTC), job.NextRun())
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, []time.Time{
+		time.Date(2021, 6, 15, 10, 20, 0, 0, time.UTC),
+		time.Date(2021, 6, 15, 10, 40, 0, 0, time.UTC),
+		time.Date(2021, 6, 15, 11, 0, 0, 0, time.UTC),
+	}, r.get())
+	assert.Equal(t, time.Date(2021, 6, 15, 11, 20, 0, 0, time.UTC), job.NextRun())
+
+	job.Cancel()
+	assert.True(t, job.NextRun().IsZero())
+	clock.Advance(time.Hour)
+	assert.Len(t, r.get(), 3)
+}
+
+func TestCronJob_Misfire(t *testing.T) {
+	start := time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		policy MisfirePolicy
+		runs   []time.Time
+	}{
+		{SkipMisfires, nil},
+		{FireOnceOnMisfire, []time.Time{start.Add(95 * time.Minute)}},
+	} {
+		clock := timerwheel.NewFakeClock(start)
+		job, r := startCron(t, clock, "0 * * * *", WithMisfirePolicy(tc.policy))
+
+		clock.Suspend(95 * time.Minute)
+		assert.Equal(t, tc.runs, r.get())
+		assert.Equal(t, time.Date(2021, 6, 15, 12, 0, 0, 0, time.UTC), job.NextRun())
+
+		clock.Advance(25 * time.Minute)
+		assert.Equal(t, append(tc.runs, time.Date(2021, 6, 15, 12, 0, 0, 0, time.UTC)), r.get())
+	}
+}
+
+func TestCronJob_MisfireThreshold(t *testing.T) {
+	clock := timerwheel.NewFakeClock(time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC))
+	_, r := startCron(t, clock, "0 * * * *", WithMisfireThreshold(time.Minute))
+
+	clock.Suspend(time.Hour + 30*time.Second)
+	assert.Len(t, r.get(), 1, "late but not missed")
+}
+
+func TestCronJob_DST(t *testing.T) {
+	loc := newYork(t)
+	clock := timerwheel.NewFakeClock(time.Date(2021, 3, 14, 0, 30, 0, 0, loc))
+	job, r := startCron(t, clock, "0 * * * *", WithLocation(loc))
+
+	clock.Advance(3 * time.Hour)
+	assert.Equal(t, []time.Time{
+		time.Date(2021, 3, 14, 1, 0, 0, 0, loc),
+		time.Date(2021, 3, 14, 3, 0, 0, 0, loc),
+		time.Date(2021, 3, 14, 4, 0, 0, 0, loc),
+	}, localTimes(r.get(), loc))
+	assert.Equal(t, time.Date(2021, 3, 14, 5, 0, 0, 0, loc), job.NextRun())
+	job.Cancel()
+}
+
+func localTimes(times []time.Time, loc *time.Location) []time.Time {
+	for i := range times {
+		times[i] = times[i].In(loc)
+	}
+	return times
+}
+
+func TestSendCron(t *testing.T) {
+	ch := make(chan interface{}, 10)
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ch <- msg
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+
+	s := NewTimerScheduler(system.Root)
+	job, err := s.SendCron("* * * * * *", pid, func() interface{} { return "tick" })
+	require.NoError(t, err)
+	defer job.Cancel()
+
+	select {
+	case msg := <-ch:
+		assert.Equal(t, "tick", msg)
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "timed out")
+	}
+
+	_, err = s.SendCron("* * *", pid, func() interface{} { return "tick" })
+	assert.Error(t, err)
+}
+
+func TestSendCron_OwnedByActor(t *testing.T) {
+	clock := timerwheel.NewFakeClock(time.Now())
+	var ran int
+	jobs := make(chan *CronJob, 1)
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			job, err := NewTimerScheduler(ctx).cron("* * * * * *", func() { ran++ }, []CronOption{withClock(clock)})
+			require.NoError(t, err)
+			jobs <- job
+		}
+	})
+	pid := system.Root.Spawn(props)
+	job := <-jobs
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+
+	clock.Advance(5 * time.Second)
+	assert.Zero(t, ran)
+	assert.Eventually(t, func() bool { return job.NextRun().IsZero() }, time.Second, time.Millisecond)
+}
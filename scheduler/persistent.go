@@ -0,0 +1,324 @@
+package scheduler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
+	"github.com/AsynkronIT/protoactor-go/log"
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/proto"
+)
+
+// ErrSchedulerStopped is returned when scheduling with a stopped PersistentScheduler
+var ErrSchedulerStopped = errors.New("scheduler: the scheduler is stopped")
+
+// IdentityResolver returns the activation of a cluster grain, e.g. with Cluster.Get
+type IdentityResolver func(identity, kind string) (*actor.PID, error)
+
+type persistentConfig struct {
+	resolver         IdentityResolver
+	misfire          MisfirePolicy
+	misfireThreshold time.Duration
+	retryInterval    time.Duration
+}
+
+// PersistentOption configures a PersistentScheduler
+type PersistentOption func(*persistentConfig)
+
+// WithIdentityResolver resolves the identity targets when their schedules fire, they can't be delivered without
+func WithIdentityResolver(resolver IdentityResolver) PersistentOption {
+	return func(c *persistentConfig) {
+		c.resolver = resolver
+	}
+}
+
+// WithDefaultMisfirePolicy decides what the schedules found overdue on recovery do, unless they were scheduled with
+// their own policy. They fire once by default
+func WithDefaultMisfirePolicy(policy MisfirePolicy) PersistentOption {
+	return func(c *persistentConfig) {
+		c.misfire = policy
+	}
+}
+
+// WithRecoveryMisfireThreshold is how late a schedule may be on recovery before it is missed, one second by default
+func WithRecoveryMisfireThreshold(threshold time.Duration) PersistentOption {
+	return func(c *persistentConfig) {
+		c.misfireThreshold = threshold
+	}
+}
+
+// WithResolveRetryInterval is how long a schedule waits before it fires again when its identity could not be
+// resolved, five seconds by default
+func WithResolveRetryInterval(interval time.Duration) PersistentOption {
+	return func(c *persistentConfig) {
+		c.retryInterval = interval
+	}
+}
+
+// ScheduleOption configures a durable schedule
+type ScheduleOption func(*DurableSchedule)
+
+// WithScheduleID names the schedule rather than generating its ID, scheduling it again replaces it
+func WithScheduleID(id string) ScheduleOption {
+	return func(s *DurableSchedule) {
+		s.ID = id
+	}
+}
+
+// WithScheduleMisfirePolicy overrides the default misfire policy of the scheduler for the schedule
+func WithScheduleMisfirePolicy(policy MisfirePolicy) ScheduleOption {
+	return func(s *DurableSchedule) {
+		s.Misfire = policy
+	}
+}
+
+// RecoveryStats counts what Recover did with the schedules it loaded
+type RecoveryStats struct {
+	// Rearmed is the number of schedules still in the future
+	Rearmed int
+	// Fired is the number of overdue schedules fired right away
+	Fired int
+	// Skipped is the number of overdue schedules deleted without firing, per their misfire policy
+	Skipped int
+}
+
+// PersistentScheduler sends protobuf messages at a time, durably: the schedules are written to a TimerStore before
+// they are acknowledged, and reloaded by Recover once the process restarts. The fired and cancelled schedules are
+// deleted from the store. A schedule is deleted after its message is sent, a crash in between sends it again on
+// recovery: the messages are delivered at least once.
+// The timers run on the clock of the actor system.
+type PersistentScheduler struct {
+	system *actor.ActorSystem
+	store  TimerStore
+	config persistentConfig
+	wheel  *timerwheel.Wheel
+
+	mu      sync.Mutex
+	timers  map[string]CancelFunc
+	stopped bool
+}
+
+func NewPersistentScheduler(system *actor.ActorSystem, store TimerStore, opts ...PersistentOption) *PersistentScheduler {
+	config := persistentConfig{
+		misfire:          FireOnceOnMisfire,
+		misfireThreshold: time.Second,
+		retryInterval:    5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return &PersistentScheduler{
+		system: system,
+		store:  store,
+		config: config,
+		wheel:  wheelOf(system.Clock()),
+		timers: make(map[string]CancelFunc),
+	}
+}
+
+// ScheduleOnce sends message to target after delay, see ScheduleAt
+func (s *PersistentScheduler) ScheduleOnce(delay time.Duration, target Target, message proto.Message, opts ...ScheduleOption) (string, error) {
+	return s.ScheduleAt(s.system.Clock().Now().Add(delay), target, message, opts...)
+}
+
+// ScheduleAt sends message to target at the time at. It returns the ID of the schedule once it is stored
+func (s *PersistentScheduler) ScheduleAt(at time.Time, target Target, message proto.Message, opts ...ScheduleOption) (string, error) {
+	if target.PID == nil && target.Identity == "" {
+		return "", errors.New("scheduler: the target has neither a PID nor an identity")
+	}
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	schedule := &DurableSchedule{
+		Target:      target,
+		FireAt:      at,
+		MessageType: messageName(message),
+		Message:     data,
+		Misfire:     s.config.misfire,
+	}
+	for _, opt := range opts {
+		opt(schedule)
+	}
+	if schedule.ID == "" {
+		schedule.ID = newScheduleID()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return "", ErrSchedulerStopped
+	}
+	if err := s.store.SaveSchedule(schedule); err != nil {
+		return "", err
+	}
+	s.arm(schedule, at.Sub(s.system.Clock().Now()))
+	return schedule.ID, nil
+}
+
+// Cancel deletes the schedule, its message is not sent unless it fired already
+func (s *PersistentScheduler) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cancel, ok := s.timers[id]; ok {
+		cancel()
+		delete(s.timers, id)
+	}
+	return s.store.DeleteSchedule(id)
+}
+
+// Recover loads the schedules of the store and arms the timers of those in the future. The overdue ones fire right
+// away, or are deleted when their misfire policy skips them and they are later than the misfire threshold.
+// It is called once at startup, before scheduling
+func (s *PersistentScheduler) Recover() (RecoveryStats, error) {
+	var stats RecoveryStats
+	schedules, err := s.store.LoadSchedules()
+	if err != nil {
+		return stats, err
+	}
+
+	var due []*DurableSchedule
+	s.mu.Lock()
+	now := s.system.Clock().Now()
+	for _, schedule := range schedules {
+		if _, armed := s.timers[schedule.ID]; armed || s.stopped {
+			continue
+		}
+		late := now.Sub(schedule.FireAt)
+		switch {
+		case late <= 0:
+			s.arm(schedule, -late)
+			stats.Rearmed++
+		case late <= s.config.misfireThreshold || schedule.Misfire == FireOnceOnMisfire:
+			s.timers[schedule.ID] = func() {}
+			due = append(due, schedule)
+			stats.Fired++
+		default:
+			plog.Info("Skipping missed schedule", log.String("id", schedule.ID), log.Duration("late", late))
+			if err := s.store.DeleteSchedule(schedule.ID); err != nil {
+				plog.Error("Failed to delete schedule", log.String("id", schedule.ID), log.Error(err))
+			}
+			stats.Skipped++
+		}
+	}
+	s.mu.Unlock()
+
+	for _, schedule := range due {
+		s.fire(schedule)
+	}
+	return stats, nil
+}
+
+// Stop disarms the timers, the schedules stay in the store for the next Recover
+func (s *PersistentScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	for id, cancel := range s.timers {
+		cancel()
+		delete(s.timers, id)
+	}
+}
+
+// arm fires the schedule after delay, it is called with the lock held
+func (s *PersistentScheduler) arm(schedule *DurableSchedule, delay time.Duration) {
+	if cancel, ok := s.timers[schedule.ID]; ok {
+		cancel()
+	}
+	s.timers[schedule.ID] = s.wheel.Schedule(delay, 0, false, func() {
+		s.fire(schedule)
+	})
+}
+
+// fire sends the message of the schedule and deletes it, unless it was cancelled or replaced meanwhile
+func (s *PersistentScheduler) fire(schedule *DurableSchedule) {
+	s.mu.Lock()
+	if _, ok := s.timers[schedule.ID]; !ok || s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.timers, schedule.ID)
+	s.mu.Unlock()
+
+	message, err := unmarshalMessage(schedule.MessageType, schedule.Message)
+	if err != nil {
+		// it never will be
+		plog.Error("Failed to decode scheduled message", log.String("id", schedule.ID), log.Error(err))
+		s.delete(schedule.ID)
+		return
+	}
+
+	pid, err := s.resolve(schedule.Target)
+	if err != nil {
+		plog.Info("Failed to resolve schedule target, retrying", log.String("id", schedule.ID), log.Error(err))
+		s.mu.Lock()
+		if _, replaced := s.timers[schedule.ID]; !replaced && !s.stopped {
+			s.arm(schedule, s.config.retryInterval)
+		}
+		s.mu.Unlock()
+		return
+	}
+
+	s.system.Root.Send(pid, message)
+	s.delete(schedule.ID)
+}
+
+// delete deletes the schedule from the store unless it was scheduled again meanwhile
+func (s *PersistentScheduler) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, replaced := s.timers[id]; replaced {
+		return
+	}
+	if err := s.store.DeleteSchedule(id); err != nil {
+		plog.Error("Failed to delete schedule", log.String("id", id), log.Error(err))
+	}
+}
+
+func (s *PersistentScheduler) resolve(target Target) (*actor.PID, error) {
+	if target.PID != nil {
+		return target.PID, nil
+	}
+	if s.config.resolver == nil {
+		return nil, fmt.Errorf("no identity resolver for %v/%v", target.Kind, target.Identity)
+	}
+	return s.config.resolver(target.Identity, target.Kind)
+}
+
+func newScheduleID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// messageName returns the name a message is registered with, by golang/protobuf or gogo/protobuf
+func messageName(message proto.Message) string {
+	if name := proto.MessageName(message); name != "" {
+		return name
+	}
+	return gogoproto.MessageName(message)
+}
+
+func unmarshalMessage(messageType string, data []byte) (proto.Message, error) {
+	t := proto.MessageType(messageType)
+	if t == nil {
+		t = gogoproto.MessageType(messageType)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("unknown message type %v", messageType)
+	}
+	message := reflect.New(t.Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
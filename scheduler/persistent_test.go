@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func str(s string) *wrappers.StringValue {
+	return &wrappers.StringValue{Value: s}
+}
+
+// process is an actor system on a manual clock, as started again after a restart. Its actor named "reminders"
+// forwards the messages to the probe
+type process struct {
+	system *actor.ActorSystem
+	probe  *testkit.TestProbe
+	target *actor.PID
+}
+
+func startProcess(t *testing.T, clock *testkit.ManualClock) *process {
+	system := actor.NewActorSystem(actor.WithClock(clock))
+	probe := testkit.NewTestProbe(system)
+	t.Cleanup(probe.Stop)
+	target, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*wrappers.StringValue); ok {
+			ctx.Forward(probe.PID())
+		}
+	}), "reminders")
+	require.NoError(t, err)
+	return &process{system: system, probe: probe, target: target}
+}
+
+func storedSchedules(t *testing.T, store TimerStore) int {
+	schedules, err := store.LoadSchedules()
+	require.NoError(t, err)
+	return len(schedules)
+}
+
+func TestPersistentScheduler_RecoversAfterRestart(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	store := NewInMemoryTimerStore()
+	p1 := startProcess(t, clock)
+	s1 := NewPersistentScheduler(p1.system, store)
+	_, err := s1.ScheduleOnce(time.Minute, PIDTarget(p1.target), str("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, storedSchedules(t, store), "the schedule is stored before it is acknowledged")
+
+	// the process crashes halfway
+	clock.Advance(30 * time.Second)
+	s1.Stop()
+
+	p2 := startProcess(t, clock)
+	resolved := map[string]*actor.PID{"greeter": p2.probe.PID()}
+	s2 := NewPersistentScheduler(p2.system, store, WithIdentityResolver(func(identity, kind string) (*actor.PID, error) {
+		return resolved[identity], nil
+	}))
+	stats, err := s2.Recover()
+	require.NoError(t, err)
+	assert.Equal(t, RecoveryStats{Rearmed: 1}, stats)
+	_, err = s2.ScheduleOnce(time.Minute, IdentityTarget("greeter", "greeter"), str("grain"))
+	require.NoError(t, err)
+
+	// the PID is delivered to the actor respawned with its name
+	clock.Advance(29 * time.Second)
+	p2.probe.ExpectNoMsg(t, 5*time.Millisecond)
+	clock.Advance(time.Second)
+	p2.probe.ExpectMsg(t, str("hello"), time.Second)
+	assert.Equal(t, 1, storedSchedules(t, store), "the fired schedule is deleted")
+
+	clock.Advance(30 * time.Second)
+	p2.probe.ExpectMsg(t, str("grain"), time.Second)
+	assert.Zero(t, storedSchedules(t, store))
+}
+
+func TestPersistentScheduler_Misfires(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	store := NewInMemoryTimerStore()
+	p1 := startProcess(t, clock)
+	s1 := NewPersistentScheduler(p1.system, store)
+	target := PIDTarget(p1.target)
+	_, err := s1.ScheduleOnce(10*time.Second, target, str("fired"))
+	require.NoError(t, err)
+	_, err = s1.ScheduleOnce(10*time.Second, target, str("skipped"), WithScheduleMisfirePolicy(SkipMisfires))
+	require.NoError(t, err)
+	_, err = s1.ScheduleOnce(30*time.Second, target, str("late"), WithScheduleMisfirePolicy(SkipMisfires))
+	require.NoError(t, err)
+	s1.Stop()
+
+	// down for longer than the misfire threshold for the first two, within it for the last one
+	clock.Advance(30*time.Second + 500*time.Millisecond)
+	p2 := startProcess(t, clock)
+	s2 := NewPersistentScheduler(p2.system, store)
+	stats, err := s2.Recover()
+	require.NoError(t, err)
+	assert.Equal(t, RecoveryStats{Fired: 2, Skipped: 1}, stats)
+	received := []interface{}{
+		p2.probe.ExpectMsgMatching(t, func(interface{}) bool { return true }, time.Second),
+		p2.probe.ExpectMsgMatching(t, func(interface{}) bool { return true }, time.Second),
+	}
+	assert.ElementsMatch(t, []interface{}{str("fired"), str("late")}, received)
+	p2.probe.ExpectNoMsg(t, 5*time.Millisecond)
+	p1.probe.ExpectNoMsg(t, 0)
+	assert.Zero(t, storedSchedules(t, store))
+}
+
+func TestPersistentScheduler_Cancel(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	store := NewInMemoryTimerStore()
+	p := startProcess(t, clock)
+	s := NewPersistentScheduler(p.system, store)
+	id, err := s.ScheduleOnce(time.Second, PIDTarget(p.probe.PID()), str("hello"))
+	require.NoError(t, err)
+	require.NoError(t, s.Cancel(id))
+	assert.Zero(t, storedSchedules(t, store))
+
+	clock.Advance(time.Second)
+	p.probe.ExpectNoMsg(t, 5*time.Millisecond)
+
+	// scheduling an ID again replaces its schedule
+	_, err = s.ScheduleOnce(time.Second, PIDTarget(p.probe.PID()), str("first"), WithScheduleID("reminder"))
+	require.NoError(t, err)
+	_, err = s.ScheduleOnce(2*time.Second, PIDTarget(p.probe.PID()), str("second"), WithScheduleID("reminder"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, storedSchedules(t, store))
+	clock.Advance(2 * time.Second)
+	p.probe.ExpectMsg(t, str("second"), time.Second)
+	p.probe.ExpectNoMsg(t, 5*time.Millisecond)
+}
+
+func TestPersistentScheduler_ResolvesIdentitiesWhenFiring(t *testing.T) {
+	clock := testkit.NewManualClock(time.Now())
+	store := NewInMemoryTimerStore()
+	p := startProcess(t, clock)
+	var resolutions int32
+	s := NewPersistentScheduler(p.system, store, WithResolveRetryInterval(time.Second),
+		WithIdentityResolver(func(identity, kind string) (*actor.PID, error) {
+			if atomic.AddInt32(&resolutions, 1) == 1 {
+				return nil, errors.New("unavailable")
+			}
+			return p.probe.PID(), nil
+		}))
+	_, err := s.ScheduleOnce(time.Second, IdentityTarget("a", "kind"), str("hello"))
+	require.NoError(t, err)
+	assert.Zero(t, atomic.LoadInt32(&resolutions), "the identity is resolved at fire time")
+
+	clock.Advance(time.Second)
+	p.probe.ExpectNoMsg(t, 5*time.Millisecond)
+	assert.Equal(t, 1, storedSchedules(t, store), "the schedule is kept until delivered")
+
+	clock.Advance(time.Second)
+	p.probe.ExpectMsg(t, str("hello"), time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&resolutions))
+	assert.Zero(t, storedSchedules(t, store))
+}
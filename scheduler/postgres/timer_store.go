@@ -0,0 +1,93 @@
+// Package postgres stores the durable schedules of scheduler.PersistentScheduler in PostgreSQL.
+// Its integration tests run with: go test -tags integration
+package postgres
+
+import (
+	"context"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/scheduler"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Schema creates the table of the store
+const Schema = `
+CREATE TABLE IF NOT EXISTS schedules (
+	id             TEXT        NOT NULL PRIMARY KEY,
+	target_address TEXT        NOT NULL,
+	target_id      TEXT        NOT NULL,
+	identity       TEXT        NOT NULL,
+	kind           TEXT        NOT NULL,
+	fire_at        TIMESTAMPTZ NOT NULL,
+	message_type   TEXT        NOT NULL,
+	message        BYTEA       NOT NULL,
+	misfire        INTEGER     NOT NULL
+);
+`
+
+// Migrate creates the table of the store if it doesn't exist yet
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	_, err := pool.Exec(ctx, Schema)
+	return err
+}
+
+// TimerStore stores the schedules in the table created by Migrate
+type TimerStore struct {
+	pool *pgxpool.Pool
+}
+
+var _ scheduler.TimerStore = (*TimerStore)(nil)
+
+func NewTimerStore(pool *pgxpool.Pool) *TimerStore {
+	return &TimerStore{pool: pool}
+}
+
+func (s *TimerStore) SaveSchedule(schedule *scheduler.DurableSchedule) error {
+	var address, id string
+	if pid := schedule.Target.PID; pid != nil {
+		address, id = pid.Address, pid.Id
+	}
+	_, err := s.pool.Exec(context.Background(), `
+		INSERT INTO schedules (id, target_address, target_id, identity, kind, fire_at, message_type, message, misfire)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE
+		SET target_address = excluded.target_address, target_id = excluded.target_id, identity = excluded.identity,
+			kind = excluded.kind, fire_at = excluded.fire_at, message_type = excluded.message_type,
+			message = excluded.message, misfire = excluded.misfire`,
+		schedule.ID, address, id, schedule.Target.Identity, schedule.Target.Kind, schedule.FireAt,
+		schedule.MessageType, schedule.Message, int(schedule.Misfire))
+	return err
+}
+
+func (s *TimerStore) DeleteSchedule(id string) error {
+	_, err := s.pool.Exec(context.Background(), "DELETE FROM schedules WHERE id = $1", id)
+	return err
+}
+
+// LoadSchedules returns the schedules, the earliest first
+func (s *TimerStore) LoadSchedules() ([]*scheduler.DurableSchedule, error) {
+	rows, err := s.pool.Query(context.Background(), `
+		SELECT id, target_address, target_id, identity, kind, fire_at, message_type, message, misfire
+		FROM schedules ORDER BY fire_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*scheduler.DurableSchedule
+	for rows.Next() {
+		var address, id string
+		var misfire int
+		schedule := &scheduler.DurableSchedule{}
+		if err := rows.Scan(&schedule.ID, &address, &id, &schedule.Target.Identity, &schedule.Target.Kind,
+			&schedule.FireAt, &schedule.MessageType, &schedule.Message, &misfire); err != nil {
+			return nil, err
+		}
+		if id != "" {
+			schedule.Target.PID = actor.NewPID(address, id)
+		}
+		schedule.Misfire = scheduler.MisfirePolicy(misfire)
+		res = append(res, schedule)
+	}
+	return res, rows.Err()
+}
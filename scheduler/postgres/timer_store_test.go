@@ -0,0 +1,91 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/scheduler"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pool is connected to a PostgreSQL container started for the tests, it is nil when docker is not available
+var pool *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	docker, err := dockertest.NewPool("")
+	if err != nil {
+		fmt.Printf("docker is not available: %v\n", err)
+		os.Exit(m.Run())
+	}
+	resource, err := docker.Run("postgres", "12-alpine", []string{"POSTGRES_PASSWORD=secret", "POSTGRES_DB=protoactor"})
+	if err != nil {
+		fmt.Printf("could not start postgres: %v\n", err)
+		os.Exit(m.Run())
+	}
+	_ = resource.Expire(120)
+
+	url := fmt.Sprintf("postgres://postgres:secret@%v/protoactor?sslmode=disable", resource.GetHostPort("5432/tcp"))
+	err = docker.Retry(func() error {
+		var err error
+		if pool, err = pgxpool.Connect(context.Background(), url); err != nil {
+			return err
+		}
+		_, err = pool.Exec(context.Background(), "SELECT 1")
+		return err
+	})
+	if err == nil {
+		err = Migrate(context.Background(), pool)
+	}
+	if err != nil {
+		fmt.Printf("could not connect to postgres: %v\n", err)
+		_ = docker.Purge(resource)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	pool.Close()
+	_ = docker.Purge(resource)
+	os.Exit(code)
+}
+
+func TestTimerStore(t *testing.T) {
+	if pool == nil {
+		t.Skip("docker is not available")
+	}
+	store := NewTimerStore(pool)
+	at := time.Now().Truncate(time.Microsecond)
+	byPID := &scheduler.DurableSchedule{ID: "a", Target: scheduler.PIDTarget(actor.NewPID("node:1", "reminders")),
+		FireAt: at.Add(time.Minute), MessageType: "google.protobuf.StringValue", Message: []byte{1}}
+	byIdentity := &scheduler.DurableSchedule{ID: "b", Target: scheduler.IdentityTarget("user-1", "user"),
+		FireAt: at, MessageType: "google.protobuf.StringValue", Message: []byte{2}, Misfire: scheduler.SkipMisfires}
+	require.NoError(t, store.SaveSchedule(byPID))
+	require.NoError(t, store.SaveSchedule(byIdentity))
+
+	byPID.FireAt = at.Add(time.Hour)
+	require.NoError(t, store.SaveSchedule(byPID), "saving an ID again replaces its schedule")
+	schedules, err := store.LoadSchedules()
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	assert.Equal(t, "b", schedules[0].ID)
+	assert.Equal(t, byIdentity.Target, schedules[0].Target)
+	assert.Equal(t, scheduler.SkipMisfires, schedules[0].Misfire)
+	assert.True(t, byPID.FireAt.Equal(schedules[1].FireAt))
+	assert.Equal(t, byPID.Target.PID.String(), schedules[1].Target.PID.String())
+	assert.Equal(t, []byte{1}, schedules[1].Message)
+
+	require.NoError(t, store.DeleteSchedule("a"))
+	require.NoError(t, store.DeleteSchedule("a"), "deleting a missing schedule is not an error")
+	schedules, err = store.LoadSchedules()
+	require.NoError(t, err)
+	assert.Len(t, schedules, 1)
+}
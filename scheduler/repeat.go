@@ -0,0 +1,26 @@
+package scheduler
+
+// RepeatMode decides when a repeated timer fires next
+type RepeatMode int
+
+const (
+	// FixedRate fires at the initial time plus a multiple of the interval, so that the timer does not drift.
+	// The ticks missed because the process was suspended or the timers were late are skipped.
+	FixedRate RepeatMode = iota
+	// FixedDelay fires an interval after the previous tick completed
+	FixedDelay
+)
+
+type repeatConfig struct {
+	mode RepeatMode
+}
+
+// RepeatOption configures a repeated timer
+type RepeatOption func(*repeatConfig)
+
+// WithRepeatMode selects how a repeated timer fires, FixedRate by default
+func WithRepeatMode(mode RepeatMode) RepeatOption {
+	return func(c *repeatConfig) {
+		c.mode = mode
+	}
+}
@@ -1,11 +1,10 @@
 package scheduler
 
 import (
-	"runtime"
-	"sync/atomic"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/AsynkronIT/protoactor-go/internal/timerwheel"
 )
 
 type CancelFunc func()
@@ -14,44 +13,13 @@ type Stopper interface {
 	Stop()
 }
 
-const (
-	stateInit = iota
-	stateReady
-	stateDone
-)
-
-func startTimer(delay, interval time.Duration, fn func()) CancelFunc {
-	var t *time.Timer
-	var state int32
-	t = time.AfterFunc(delay, func() {
-		state := atomic.LoadInt32(&state)
-		for state == stateInit {
-			runtime.Gosched()
-			state = atomic.LoadInt32(&state)
-		}
-
-		if state == stateDone {
-			return
-		}
-
-		fn()
-		t.Reset(interval)
-	})
-
-	// ensures t != nil and is required to avoid data race in
-	// AfterFunc calling t.Reset
-	atomic.StoreInt32(&state, stateReady)
-
-	return func() {
-		if atomic.SwapInt32(&state, stateDone) != stateDone {
-			t.Stop()
-		}
-	}
-}
-
 // A scheduler utilizing timers to send messages in the future and at regular intervals.
+// The timers of all the schedulers share a timer wheel, they do not hold goroutines while pending.
+// They run on the clock of the actor system of the context.
 type TimerScheduler struct {
-	ctx actor.SenderContext
+	ctx   actor.SenderContext
+	owner *actorTimers
+	wheel *timerwheel.Wheel
 }
 
 type timerOptionFunc func(*TimerScheduler)
@@ -66,27 +34,85 @@ func WithContext(ctx actor.SenderContext) timerOptionFunc {
 
 // NewTimerScheduler creates a new scheduler using the EmptyRootContext.
 // Additional options may be specified to override the default behavior.
+// The timers of the schedulers created with an actor.Context are owned by the actor: they are cancelled when it stops,
+// or restarts with the TimerPlugin.
 func NewTimerScheduler(sender actor.SenderContext, opts ...timerOptionFunc) *TimerScheduler {
 	s := &TimerScheduler{ctx: sender}
+	if ctx, ok := sender.(actor.Context); ok {
+		s.owner = ownerOf(ctx)
+	}
 	for _, opt := range opts {
 		opt(s)
 	}
+	s.wheel = wheelOf(s.ctx.ActorSystem().Clock())
 	return s
 }
 
+// wheelOf returns the wheel of the timers run on clock, the shared one for the system clock
+func wheelOf(clock actor.Clock) *timerwheel.Wheel {
+	if _, ok := clock.(actor.RealClock); ok {
+		return timerwheel.Default
+	}
+	return timerwheel.New(wheelClock{clock: clock})
+}
+
+// wheelClock runs a timer wheel or a cron job on an actor.Clock
+type wheelClock struct {
+	clock actor.Clock
+}
+
+func (c wheelClock) Now() time.Time {
+	return c.clock.Now()
+}
+
+func (c wheelClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return c.clock.AfterFunc(d, f).Stop
+}
+
+// once calls fn after delay, unless the actor owning the scheduler stopped
+func (s *TimerScheduler) once(delay time.Duration, fn func()) CancelFunc {
+	if s.owner == nil {
+		return s.wheel.Schedule(delay, 0, false, fn)
+	}
+	return s.owner.track(func(alive func() bool, done func()) CancelFunc {
+		return s.wheel.Schedule(delay, 0, false, func() {
+			done()
+			if alive() {
+				fn()
+			}
+		})
+	})
+}
+
+// repeatedly calls fn after delay and then every interval, until the actor owning the scheduler stopped
+func (s *TimerScheduler) repeatedly(delay, interval time.Duration, opts []RepeatOption, fn func()) CancelFunc {
+	config := repeatConfig{mode: FixedRate}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if s.owner == nil {
+		return s.wheel.Schedule(delay, interval, config.mode == FixedDelay, fn)
+	}
+	return s.owner.track(func(alive func() bool, done func()) CancelFunc {
+		return s.wheel.Schedule(delay, interval, config.mode == FixedDelay, func() {
+			if alive() {
+				fn()
+			}
+		})
+	})
+}
+
 // SendOnce waits for the duration to elapse and then calls actor.SenderContext.Send to forward the message to pid.
 func (s *TimerScheduler) SendOnce(delay time.Duration, pid *actor.PID, message interface{}) CancelFunc {
-	t := time.AfterFunc(delay, func() {
+	return s.once(delay, func() {
 		s.ctx.Send(pid, message)
 	})
-
-	return func() { t.Stop() }
 }
 
 // SendRepeatedly waits for the initial duration to elapse and then calls Send to forward the message to pid
-// repeatedly for each interval.
-func (s *TimerScheduler) SendRepeatedly(initial, interval time.Duration, pid *actor.PID, message interface{}) CancelFunc {
-	return startTimer(initial, interval, func() {
+// repeatedly for each interval, at a fixed rate unless configured otherwise.
+func (s *TimerScheduler) SendRepeatedly(initial, interval time.Duration, pid *actor.PID, message interface{}, opts ...RepeatOption) CancelFunc {
+	return s.repeatedly(initial, interval, opts, func() {
 		s.ctx.Send(pid, message)
 	})
 }
@@ -94,17 +120,15 @@ func (s *TimerScheduler) SendRepeatedly(initial, interval time.Duration, pid *ac
 // RequestOnce waits for the duration to elapse and then calls actor.SenderContext.Request to forward the message to
 // pid.
 func (s *TimerScheduler) RequestOnce(delay time.Duration, pid *actor.PID, message interface{}) CancelFunc {
-	t := time.AfterFunc(delay, func() {
+	return s.once(delay, func() {
 		s.ctx.Request(pid, message)
 	})
-
-	return func() { t.Stop() }
 }
 
 // RequestRepeatedly waits for the initial duration to elapse and then calls Request to forward the message to pid
-// repeatedly for each interval.
-func (s *TimerScheduler) RequestRepeatedly(delay, interval time.Duration, pid *actor.PID, message interface{}) CancelFunc {
-	return startTimer(delay, interval, func() {
+// repeatedly for each interval, at a fixed rate unless configured otherwise.
+func (s *TimerScheduler) RequestRepeatedly(delay, interval time.Duration, pid *actor.PID, message interface{}, opts ...RepeatOption) CancelFunc {
+	return s.repeatedly(delay, interval, opts, func() {
 		s.ctx.Request(pid, message)
 	})
 }
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// Target is the recipient of a durable schedule, either a PID or a cluster identity
+type Target struct {
+	// PID is delivered to on a best-effort basis, the actor may not exist anymore after a restart
+	PID *actor.PID
+	// Identity and Kind name a cluster grain, resolved to its activation when the schedule fires
+	Identity string
+	Kind     string
+}
+
+// PIDTarget targets the actor pid
+func PIDTarget(pid *actor.PID) Target {
+	return Target{PID: pid}
+}
+
+// IdentityTarget targets the grain identity of kind, see WithIdentityResolver
+func IdentityTarget(identity, kind string) Target {
+	return Target{Identity: identity, Kind: kind}
+}
+
+// DurableSchedule is a message to send at a time, as persisted by a TimerStore
+type DurableSchedule struct {
+	ID     string
+	Target Target
+	FireAt time.Time
+	// MessageType is the protobuf name of the message, Message its serialized form
+	MessageType string
+	Message     []byte
+	Misfire     MisfirePolicy
+}
+
+// TimerStore persists the schedules of a PersistentScheduler, so that they survive the restarts of the process
+type TimerStore interface {
+	// SaveSchedule inserts the schedule, or replaces the one with the same ID
+	SaveSchedule(schedule *DurableSchedule) error
+	// DeleteSchedule deletes the schedule, deleting a missing one is not an error
+	DeleteSchedule(id string) error
+	// LoadSchedules returns all the schedules
+	LoadSchedules() ([]*DurableSchedule, error)
+}
+
+// InMemoryTimerStore keeps the schedules in memory, they survive the schedulers using it but not the process
+type InMemoryTimerStore struct {
+	mu        sync.Mutex
+	schedules map[string]DurableSchedule
+}
+
+var _ TimerStore = (*InMemoryTimerStore)(nil)
+
+func NewInMemoryTimerStore() *InMemoryTimerStore {
+	return &InMemoryTimerStore{schedules: make(map[string]DurableSchedule)}
+}
+
+func (s *InMemoryTimerStore) SaveSchedule(schedule *DurableSchedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedules[schedule.ID] = *schedule
+	return nil
+}
+
+func (s *InMemoryTimerStore) DeleteSchedule(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.schedules, id)
+	return nil
+}
+
+// LoadSchedules returns copies of the schedules, the earliest first
+func (s *InMemoryTimerStore) LoadSchedules() ([]*DurableSchedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]*DurableSchedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedule := schedule
+		res = append(res, &schedule)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].FireAt.Before(res[j].FireAt) })
+	return res, nil
+}
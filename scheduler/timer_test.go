@@ -5,113 +5,86 @@ import (
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
-	"github.com/stretchr/testify/assert"
+	"github.com/AsynkronIT/protoactor-go/actor/testkit"
 )
 
 var system = actor.NewActorSystem()
 
 func TestNewTimerScheduler(t *testing.T) {
-	newActor := func(t *testing.T, n int) (pid *actor.PID, ch chan struct{}) {
-		ch = make(chan struct{}, n)
-		props := actor.PropsFromFunc(func(c actor.Context) {
-			switch c.Message().(type) {
-			case string:
-				select {
-				case ch <- struct{}{}:
-				default:
-					t.Errorf("exceeeded expected count %d", n)
-				}
-
-			}
-		})
-		return system.Root.Spawn(props), ch
+	// newScheduler returns a scheduler of a system on a manual clock, and a probe to target
+	newScheduler := func(t *testing.T) (*TimerScheduler, *testkit.ManualClock, *testkit.TestProbe) {
+		clock := testkit.NewManualClock(time.Now())
+		system := actor.NewActorSystem(actor.WithClock(clock))
+		probe := testkit.NewTestProbe(system)
+		t.Cleanup(probe.Stop)
+		return NewTimerScheduler(system.Root), clock, probe
 	}
 
-	// check verifies the number of times ch receives a message matches exp
-	// and executes once more to ensure no further messages are received
-	check := func(t *testing.T, ch chan struct{}, cancel CancelFunc, exp int) {
-		got := 0
-		for i := 0; i < exp+1; i++ {
-			select {
-			case <-ch:
-				got++
-				if got == exp {
-					cancel()
-				}
-			case <-time.After(3 * time.Millisecond):
-				if got != exp {
-					assert.Fail(t, "failed to receive message")
-				}
-			}
+	// check verifies that the probe receives a message each time the clock advances by a millisecond, exp times,
+	// and that it receives no more messages once cancelled
+	check := func(t *testing.T, clock *testkit.ManualClock, probe *testkit.TestProbe, cancel CancelFunc, exp int) {
+		for i := 0; i < exp; i++ {
+			clock.Advance(time.Millisecond)
+			probe.ExpectMsg(t, "hello", 0)
 		}
 		cancel()
-		assert.Equal(t, exp, got)
+		clock.Advance(10 * time.Millisecond)
+		probe.ExpectNoMsg(t, 5*time.Millisecond)
 	}
 
 	t.Run("does", func(t *testing.T) {
 		t.Run("send once", func(t *testing.T) {
-			s := NewTimerScheduler(system.Root)
-			pid, ch := newActor(t, 1)
-			tok := s.SendOnce(1*time.Millisecond, pid, "hello")
-
-			check(t, ch, tok, 1)
+			s, clock, probe := newScheduler(t)
+			tok := s.SendOnce(1*time.Millisecond, probe.PID(), "hello")
+			check(t, clock, probe, tok, 1)
 		})
 
 		t.Run("send repeatedly", func(t *testing.T) {
-			s := NewTimerScheduler(system.Root)
-			pid, ch := newActor(t, 5)
-			tok := s.SendRepeatedly(1*time.Millisecond, 1*time.Millisecond, pid, "hello")
-			check(t, ch, tok, 5)
+			s, clock, probe := newScheduler(t)
+			tok := s.SendRepeatedly(1*time.Millisecond, 1*time.Millisecond, probe.PID(), "hello")
+			check(t, clock, probe, tok, 5)
 		})
 
 		t.Run("request once", func(t *testing.T) {
-			s := NewTimerScheduler(system.Root)
-			pid, ch := newActor(t, 1)
-			tok := s.RequestOnce(1*time.Millisecond, pid, "hello")
-
-			check(t, ch, tok, 1)
+			s, clock, probe := newScheduler(t)
+			tok := s.RequestOnce(1*time.Millisecond, probe.PID(), "hello")
+			check(t, clock, probe, tok, 1)
 		})
 
 		t.Run("request repeatedly", func(t *testing.T) {
-			s := NewTimerScheduler(system.Root)
-			pid, ch := newActor(t, 5)
-			tok := s.RequestRepeatedly(1*time.Millisecond, 1*time.Millisecond, pid, "hello")
-			check(t, ch, tok, 5)
+			s, clock, probe := newScheduler(t)
+			tok := s.RequestRepeatedly(1*time.Millisecond, 1*time.Millisecond, probe.PID(), "hello")
+			check(t, clock, probe, tok, 5)
 		})
 	})
 
 	t.Run("does not", func(t *testing.T) {
 		t.Run("send once", func(t *testing.T) {
-			s := NewTimerScheduler(system.Root)
-			pid, ch := newActor(t, 1)
-			cancel := s.SendOnce(1*time.Millisecond, pid, "hello")
+			s, clock, probe := newScheduler(t)
+			cancel := s.SendOnce(1*time.Millisecond, probe.PID(), "hello")
 			cancel()
-			check(t, ch, cancel, 0)
+			check(t, clock, probe, cancel, 0)
 		})
 
 		t.Run("send repeatedly", func(t *testing.T) {
-			s := NewTimerScheduler(system.Root)
-			pid, ch := newActor(t, 5)
-			cancel := s.SendRepeatedly(1*time.Millisecond, 1*time.Millisecond, pid, "hello")
+			s, clock, probe := newScheduler(t)
+			cancel := s.SendRepeatedly(1*time.Millisecond, 1*time.Millisecond, probe.PID(), "hello")
 			cancel()
-			check(t, ch, cancel, 0)
+			check(t, clock, probe, cancel, 0)
 		})
 
 		t.Run("request once", func(t *testing.T) {
-			s := NewTimerScheduler(system.Root)
-			pid, ch := newActor(t, 1)
-			cancel := s.RequestOnce(1*time.Millisecond, pid, "hello")
+			s, clock, probe := newScheduler(t)
+			cancel := s.RequestOnce(1*time.Millisecond, probe.PID(), "hello")
 			cancel()
-			check(t, ch, cancel, 0)
+			check(t, clock, probe, cancel, 0)
 		})
 
 		t.Run("request repeatedly", func(t *testing.T) {
-			s := NewTimerScheduler(system.Root)
-			pid, ch := newActor(t, 5)
-			cancel := s.RequestRepeatedly(1*time.Millisecond, 1*time.Millisecond, pid, "hello")
+			s, clock, probe := newScheduler(t)
+			cancel := s.RequestRepeatedly(1*time.Millisecond, 1*time.Millisecond, probe.PID(), "hello")
 			cancel()
-			check(t, ch, cancel, 0)
+			check(t, clock, probe, cancel, 0)
 		})
 	})
-
 }
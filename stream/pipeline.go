@@ -0,0 +1,390 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// ErrStopped completes the future of a stream stopped before it completed
+var ErrStopped = errors.New("stream: stopped")
+
+// sinkDemand is the number of elements a sink requests ahead
+const sinkDemand = 16
+
+// Source is a stream under construction, from its source through its stages. Each stage runs in an actor
+// and receives at most the elements its downstream requested, so that a slow sink slows down the source
+type Source struct {
+	stages []func(r *run) actor.Actor
+}
+
+// Graph is a stream ending with a sink, ready to run
+type Graph struct {
+	stages []func(r *run) actor.Actor
+}
+
+// FromChannel streams the values received from ch until it is closed
+func FromChannel(ch <-chan interface{}) *Source {
+	return fromPull(func(cancel <-chan struct{}) (interface{}, bool) {
+		select {
+		case value, ok := <-ch:
+			return value, ok
+		case <-cancel:
+			return nil, false
+		}
+	})
+}
+
+// FromFunc streams the values produced until produce returns false
+func FromFunc(produce func() (interface{}, bool)) *Source {
+	return fromPull(func(<-chan struct{}) (interface{}, bool) {
+		return produce()
+	})
+}
+
+func fromPull(pull func(cancel <-chan struct{}) (interface{}, bool)) *Source {
+	return &Source{stages: []func(r *run) actor.Actor{func(r *run) actor.Actor {
+		return &sourceStage{run: r, pull: pull}
+	}}}
+}
+
+func (s *Source) via(stage func(r *run) actor.Actor) *Source {
+	return &Source{stages: append(append([]func(r *run) actor.Actor(nil), s.stages...), stage)}
+}
+
+func (s *Source) to(sink func(r *run) actor.Actor) *Graph {
+	return &Graph{stages: append(append([]func(r *run) actor.Actor(nil), s.stages...), sink)}
+}
+
+// Map transforms the values with f, the stream fails with the error f returns
+func (s *Source) Map(f func(value interface{}) (interface{}, error)) *Source {
+	return s.via(func(r *run) actor.Actor {
+		return &mapStage{run: r, f: f}
+	})
+}
+
+// Filter streams the values matching keep only
+func (s *Source) Filter(keep func(value interface{}) bool) *Source {
+	return s.via(func(r *run) actor.Actor {
+		return &mapStage{run: r, f: func(value interface{}) (interface{}, error) {
+			if keep(value) {
+				return value, nil
+			}
+			return filtered, nil
+		}}
+	})
+}
+
+// Buffer requests size values ahead of the demand of its downstream
+func (s *Source) Buffer(size int) *Source {
+	return s.via(func(r *run) actor.Actor {
+		return &bufferStage{run: r, size: size}
+	})
+}
+
+// ToChannel sends the values to ch, and closes it once the stream completes
+func (s *Source) ToChannel(ch chan<- interface{}) *Graph {
+	return s.to(func(r *run) actor.Actor {
+		return &sinkStage{run: r, consume: func(value interface{}) {
+			select {
+			case ch <- value:
+			case <-r.cancel:
+			}
+		}, complete: func() interface{} {
+			close(ch)
+			return nil
+		}}
+	})
+}
+
+// ToPID sends the values to pid. The values are sent as they come, pid does not slow the stream down
+func (s *Source) ToPID(pid *actor.PID) *Graph {
+	return s.to(func(r *run) actor.Actor {
+		return &sinkStage{run: r, consume: func(value interface{}) {
+			r.system.Root.Send(pid, value)
+		}}
+	})
+}
+
+// Fold folds the values with f from zero, the future of the stream completes with the result
+func (s *Source) Fold(zero interface{}, f func(acc, value interface{}) interface{}) *Graph {
+	return s.to(func(r *run) actor.Actor {
+		acc := zero
+		return &sinkStage{run: r, consume: func(value interface{}) {
+			acc = f(acc, value)
+		}, complete: func() interface{} {
+			return acc
+		}}
+	})
+}
+
+// Handle is a running stream
+type Handle struct {
+	root   *actor.RootContext
+	pid    *actor.PID
+	future *actor.Future
+}
+
+// Run runs the stream from root
+func (g *Graph) Run(root *actor.RootContext) *Handle {
+	future := actor.NewFuture(root.ActorSystem(), -1)
+	pid := root.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return &graphActor{stages: g.stages, future: future}
+	}))
+	return &Handle{root: root, pid: pid, future: future}
+}
+
+// Stop cancels the stream, its future fails with ErrStopped unless it completed
+func (h *Handle) Stop() {
+	h.root.Stop(h.pid)
+}
+
+// Future completes when the stream completes, with the result of its sink, or fails with the error of a stage
+func (h *Handle) Future() *actor.Future {
+	return h.future
+}
+
+// the messages between the stages, the demand goes upstream and the elements downstream
+type (
+	wire struct {
+		upstream, downstream *actor.PID
+	}
+	demand struct {
+		n int
+	}
+	element struct {
+		value interface{}
+	}
+	completed struct{}
+	failed    struct {
+		err error
+	}
+	finished struct {
+		result interface{}
+	}
+	pulled struct {
+		n    int
+		done bool
+	}
+)
+
+// filtered is mapped by Filter from the values it drops
+var filtered = &struct{}{}
+
+// run is the state of a running stream shared by its stages
+type run struct {
+	system *actor.ActorSystem
+	graph  *actor.PID
+	cancel chan struct{}
+}
+
+// safely calls f, the stream fails when it panics
+func (r *run) safely(f func()) (ok bool) {
+	defer func() {
+		if reason := recover(); reason != nil {
+			r.fail(fmt.Errorf("stream: stage failed: %v", reason))
+			ok = false
+		}
+	}()
+	f()
+	return true
+}
+
+func (r *run) fail(err error) {
+	r.system.Root.Send(r.graph, &failed{err: err})
+}
+
+// graphActor spawns the stages, and completes the future of the stream once its sink completes or a stage fails.
+// The stages are its children, they stop with it
+type graphActor struct {
+	stages []func(r *run) actor.Actor
+	future *actor.Future
+	run    *run
+}
+
+func (g *graphActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		g.run = &run{system: ctx.ActorSystem(), graph: ctx.Self(), cancel: make(chan struct{})}
+		pids := make([]*actor.PID, len(g.stages))
+		for i, stage := range g.stages {
+			stage := stage
+			pids[i] = ctx.Spawn(actor.PropsFromProducer(func() actor.Actor { return stage(g.run) }))
+		}
+		for i, pid := range pids {
+			w := &wire{}
+			if i > 0 {
+				w.upstream = pids[i-1]
+			}
+			if i < len(pids)-1 {
+				w.downstream = pids[i+1]
+			}
+			ctx.Send(pid, w)
+		}
+	case *finished:
+		ctx.Send(g.future.PID(), msg.result)
+		ctx.Stop(ctx.Self())
+	case *failed:
+		g.future.Fail(msg.err)
+		ctx.Stop(ctx.Self())
+	case *actor.Stopping:
+		close(g.run.cancel)
+		g.future.Fail(ErrStopped)
+	}
+}
+
+// sourceStage pulls as many values as requested on a goroutine, one pull at a time
+type sourceStage struct {
+	run        *run
+	pull       func(cancel <-chan struct{}) (interface{}, bool)
+	downstream *actor.PID
+	demand     int
+	pulling    bool
+	done       bool
+}
+
+func (s *sourceStage) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *wire:
+		s.downstream = msg.downstream
+	case *demand:
+		s.demand += msg.n
+		s.startPull(ctx.Self())
+	case *pulled:
+		s.pulling = false
+		s.demand -= msg.n
+		s.done = msg.done
+		s.startPull(ctx.Self())
+	}
+}
+
+func (s *sourceStage) startPull(self *actor.PID) {
+	if s.pulling || s.done || s.demand == 0 {
+		return
+	}
+	s.pulling = true
+	n, r, downstream := s.demand, s.run, s.downstream
+	go func() {
+		for i := 0; i < n; i++ {
+			var value interface{}
+			var ok bool
+			if !r.safely(func() { value, ok = s.pull(r.cancel) }) {
+				return
+			}
+			select {
+			case <-r.cancel:
+				return
+			default:
+			}
+			if !ok {
+				r.system.Root.Send(downstream, &completed{})
+				r.system.Root.Send(self, &pulled{n: i, done: true})
+				return
+			}
+			r.system.Root.Send(downstream, &element{value: value})
+		}
+		r.system.Root.Send(self, &pulled{n: n})
+	}()
+}
+
+// mapStage maps each element it receives, the filtered ones are requested again
+type mapStage struct {
+	run                  *run
+	f                    func(value interface{}) (interface{}, error)
+	upstream, downstream *actor.PID
+}
+
+func (s *mapStage) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *wire:
+		s.upstream, s.downstream = msg.upstream, msg.downstream
+	case *demand:
+		ctx.Send(s.upstream, msg)
+	case *element:
+		var value interface{}
+		var err error
+		if !s.run.safely(func() { value, err = s.f(msg.value) }) {
+			return
+		}
+		switch {
+		case err != nil:
+			s.run.fail(err)
+		case value == filtered:
+			ctx.Send(s.upstream, &demand{n: 1})
+		default:
+			ctx.Send(s.downstream, &element{value: value})
+		}
+	case *completed:
+		ctx.Send(s.downstream, msg)
+	}
+}
+
+// bufferStage keeps size elements requested ahead, and requests one more for each element it passes on
+type bufferStage struct {
+	run                  *run
+	size                 int
+	upstream, downstream *actor.PID
+	buffer               []interface{}
+	demand               int
+	completed            bool
+}
+
+func (s *bufferStage) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *wire:
+		s.upstream, s.downstream = msg.upstream, msg.downstream
+		ctx.Send(s.upstream, &demand{n: s.size})
+	case *demand:
+		s.demand += msg.n
+		s.flush(ctx)
+	case *element:
+		s.buffer = append(s.buffer, msg.value)
+		s.flush(ctx)
+	case *completed:
+		s.completed = true
+		s.flush(ctx)
+	}
+}
+
+func (s *bufferStage) flush(ctx actor.Context) {
+	for s.demand > 0 && len(s.buffer) > 0 {
+		ctx.Send(s.downstream, &element{value: s.buffer[0]})
+		s.buffer[0] = nil
+		s.buffer = s.buffer[1:]
+		s.demand--
+		if !s.completed {
+			ctx.Send(s.upstream, &demand{n: 1})
+		}
+	}
+	if s.completed && len(s.buffer) == 0 && s.downstream != nil {
+		ctx.Send(s.downstream, &completed{})
+		s.downstream = nil
+	}
+}
+
+// sinkStage consumes the elements, requesting one more for each one consumed
+type sinkStage struct {
+	run      *run
+	consume  func(value interface{})
+	complete func() interface{}
+	upstream *actor.PID
+}
+
+func (s *sinkStage) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *wire:
+		s.upstream = msg.upstream
+		ctx.Send(s.upstream, &demand{n: sinkDemand})
+	case *element:
+		if s.run.safely(func() { s.consume(msg.value) }) {
+			ctx.Send(s.upstream, &demand{n: 1})
+		}
+	case *completed:
+		var result interface{}
+		if s.complete != nil && !s.run.safely(func() { result = s.complete() }) {
+			return
+		}
+		ctx.Send(s.run.graph, &finished{result: result})
+	}
+}
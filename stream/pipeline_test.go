@@ -0,0 +1,119 @@
+package stream
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// counting produces the integers from 0 to n excluded, counting them in produced
+func counting(n int64, produced *int64) func() (interface{}, bool) {
+	return func() (interface{}, bool) {
+		i := atomic.AddInt64(produced, 1) - 1
+		return int(i), i < n
+	}
+}
+
+func TestPipeline_FoldFilterBuffer(t *testing.T) {
+	var produced int64
+	handle := FromFunc(counting(100, &produced)).
+		Filter(func(value interface{}) bool { return value.(int)%2 == 0 }).
+		Map(func(value interface{}) (interface{}, error) { return value.(int) * 10, nil }).
+		Buffer(8).
+		Fold(0, func(acc, value interface{}) interface{} { return acc.(int) + value.(int) }).
+		Run(system.Root)
+
+	res, err := handle.Future().Result()
+	require.NoError(t, err)
+	assert.Equal(t, 24500, res)
+}
+
+func TestPipeline_ToChannelBackpressure(t *testing.T) {
+	var produced int64
+	out := make(chan interface{})
+	handle := FromFunc(counting(1000, &produced)).
+		Map(func(value interface{}) (interface{}, error) { return value, nil }).
+		Buffer(10).
+		ToChannel(out).
+		Run(system.Root)
+
+	consumed := 0
+	for value := range out {
+		assert.Equal(t, consumed, value)
+		consumed++
+		if consumed%100 == 0 {
+			// the slow sink holds the source back, by the buffer and the demand of the sink at most
+			time.Sleep(10 * time.Millisecond)
+			assert.LessOrEqual(t, atomic.LoadInt64(&produced), int64(consumed+10+sinkDemand+2))
+		}
+	}
+	assert.Equal(t, 1000, consumed)
+	res, err := handle.Future().Result()
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestPipeline_FailureCancelsUpstream(t *testing.T) {
+	var produced int64
+	boom := errors.New("boom")
+	handle := FromFunc(counting(1<<30, &produced)).
+		Map(func(value interface{}) (interface{}, error) {
+			if value.(int) == 50 {
+				return nil, boom
+			}
+			return value, nil
+		}).
+		Fold(0, func(acc, value interface{}) interface{} { return value }).
+		Run(system.Root)
+
+	_, err := handle.Future().Result()
+	assert.Equal(t, boom, err)
+	time.Sleep(10 * time.Millisecond)
+	stopped := atomic.LoadInt64(&produced)
+	assert.Less(t, stopped, int64(50+2*sinkDemand))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt64(&produced), "the source stopped pulling")
+}
+
+func TestPipeline_PanicFailsStream(t *testing.T) {
+	ch := make(chan interface{}, 3)
+	ch <- 1
+	ch <- 0
+	close(ch)
+	handle := FromChannel(ch).
+		Map(func(value interface{}) (interface{}, error) { return 1 / value.(int), nil }).
+		Fold(0, func(acc, value interface{}) interface{} { return value }).
+		Run(system.Root)
+
+	_, err := handle.Future().Result()
+	assert.EqualError(t, err, "stream: stage failed: runtime error: integer divide by zero")
+}
+
+func TestPipeline_ToPID(t *testing.T) {
+	received := make(chan interface{}, 10)
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if value, ok := ctx.Message().(string); ok {
+			received <- value
+		}
+	}))
+	defer func() { _ = system.Root.StopFuture(pid).Wait() }()
+	ch := make(chan interface{}, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+
+	require.NoError(t, FromChannel(ch).ToPID(pid).Run(system.Root).Future().Wait())
+	assert.Equal(t, "a", <-received)
+	assert.Equal(t, "b", <-received)
+}
+
+func TestPipeline_Stop(t *testing.T) {
+	handle := FromChannel(make(chan interface{})).ToChannel(make(chan interface{})).Run(system.Root)
+	handle.Stop()
+	assert.Equal(t, ErrStopped, handle.Future().Wait())
+}